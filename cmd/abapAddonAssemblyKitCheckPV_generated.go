@@ -9,7 +9,9 @@ import (
 	"time"
 
 	"github.com/SAP/jenkins-library/pkg/config"
+	piperGithub "github.com/SAP/jenkins-library/pkg/github"
 	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/opentelemetry"
 	"github.com/SAP/jenkins-library/pkg/piperenv"
 	"github.com/SAP/jenkins-library/pkg/splunk"
 	"github.com/SAP/jenkins-library/pkg/telemetry"
@@ -63,6 +65,8 @@ func AbapAddonAssemblyKitCheckPVCommand() *cobra.Command {
 	var commonPipelineEnvironment abapAddonAssemblyKitCheckPVCommonPipelineEnvironment
 	var logCollector *log.CollectorHook
 	var splunkClient *splunk.Splunk
+	var otelClient *opentelemetry.OpenTelemetry
+	var checkRun *piperGithub.StepCheckRun
 	telemetryClient := &telemetry.Telemetry{}
 
 	var createAbapAddonAssemblyKitCheckPVCmd = &cobra.Command{
@@ -102,6 +106,13 @@ For Terminology refer to the [Scenario Description](https://www.project-piper.io
 				log.RegisterHook(logCollector)
 			}
 
+			if len(GeneralConfig.HookConfig.OpenTelemetryConfig.Endpoint) > 0 {
+				otelClient = &opentelemetry.OpenTelemetry{}
+				if err := otelClient.Initialize(GeneralConfig.CorrelationID, STEP_NAME, GeneralConfig.HookConfig.OpenTelemetryConfig); err != nil {
+					log.Entry().WithError(err).Warning("failed to initialize OpenTelemetry")
+				}
+			}
+
 			validation, err := validation.New(validation.WithJSONNamesForStructFields(), validation.WithPredefinedErrorMessages())
 			if err != nil {
 				return err
@@ -127,6 +138,12 @@ For Terminology refer to the [Scenario Description](https://www.project-piper.io
 				if len(GeneralConfig.HookConfig.SplunkConfig.Dsn) > 0 {
 					splunkClient.Send(telemetryClient.GetData(), logCollector)
 				}
+				if len(GeneralConfig.HookConfig.OpenTelemetryConfig.Endpoint) > 0 {
+					otelClient.Send(telemetryClient.GetData().Attributes())
+				}
+				if checkRun != nil {
+					checkRun.Complete(stepTelemetryData.ErrorCode != "0", log.GetErrorCategory())
+				}
 			}
 			log.DeferExitHandler(handler)
 			defer handler()
@@ -138,6 +155,9 @@ For Terminology refer to the [Scenario Description](https://www.project-piper.io
 					GeneralConfig.HookConfig.SplunkConfig.Index,
 					GeneralConfig.HookConfig.SplunkConfig.SendLogs)
 			}
+			if GeneralConfig.HookConfig.GitHubChecksConfig.Enabled {
+				checkRun = piperGithub.StartStepCheckRun(STEP_NAME, GeneralConfig.StageName, GeneralConfig.HookConfig.GitHubChecksConfig)
+			}
 			abapAddonAssemblyKitCheckPV(stepConfig, &stepTelemetryData, &commonPipelineEnvironment)
 			stepTelemetryData.ErrorCode = "0"
 			log.Entry().Info("SUCCESS")
@@ -8,7 +8,9 @@ import (
 	"time"
 
 	"github.com/SAP/jenkins-library/pkg/config"
+	piperGithub "github.com/SAP/jenkins-library/pkg/github"
 	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/opentelemetry"
 	"github.com/SAP/jenkins-library/pkg/splunk"
 	"github.com/SAP/jenkins-library/pkg/telemetry"
 	"github.com/SAP/jenkins-library/pkg/validation"
@@ -34,6 +36,8 @@ func AbapAddonAssemblyKitPublishTargetVectorCommand() *cobra.Command {
 	var startTime time.Time
 	var logCollector *log.CollectorHook
 	var splunkClient *splunk.Splunk
+	var otelClient *opentelemetry.OpenTelemetry
+	var checkRun *piperGithub.StepCheckRun
 	telemetryClient := &telemetry.Telemetry{}
 
 	var createAbapAddonAssemblyKitPublishTargetVectorCmd = &cobra.Command{
@@ -73,6 +77,13 @@ For Terminology refer to the [Scenario Description](https://www.project-piper.io
 				log.RegisterHook(logCollector)
 			}
 
+			if len(GeneralConfig.HookConfig.OpenTelemetryConfig.Endpoint) > 0 {
+				otelClient = &opentelemetry.OpenTelemetry{}
+				if err := otelClient.Initialize(GeneralConfig.CorrelationID, STEP_NAME, GeneralConfig.HookConfig.OpenTelemetryConfig); err != nil {
+					log.Entry().WithError(err).Warning("failed to initialize OpenTelemetry")
+				}
+			}
+
 			validation, err := validation.New(validation.WithJSONNamesForStructFields(), validation.WithPredefinedErrorMessages())
 			if err != nil {
 				return err
@@ -97,6 +108,12 @@ For Terminology refer to the [Scenario Description](https://www.project-piper.io
 				if len(GeneralConfig.HookConfig.SplunkConfig.Dsn) > 0 {
 					splunkClient.Send(telemetryClient.GetData(), logCollector)
 				}
+				if len(GeneralConfig.HookConfig.OpenTelemetryConfig.Endpoint) > 0 {
+					otelClient.Send(telemetryClient.GetData().Attributes())
+				}
+				if checkRun != nil {
+					checkRun.Complete(stepTelemetryData.ErrorCode != "0", log.GetErrorCategory())
+				}
 			}
 			log.DeferExitHandler(handler)
 			defer handler()
@@ -108,6 +125,9 @@ For Terminology refer to the [Scenario Description](https://www.project-piper.io
 					GeneralConfig.HookConfig.SplunkConfig.Index,
 					GeneralConfig.HookConfig.SplunkConfig.SendLogs)
 			}
+			if GeneralConfig.HookConfig.GitHubChecksConfig.Enabled {
+				checkRun = piperGithub.StartStepCheckRun(STEP_NAME, GeneralConfig.StageName, GeneralConfig.HookConfig.GitHubChecksConfig)
+			}
 			abapAddonAssemblyKitPublishTargetVector(stepConfig, &stepTelemetryData)
 			stepTelemetryData.ErrorCode = "0"
 			log.Entry().Info("SUCCESS")
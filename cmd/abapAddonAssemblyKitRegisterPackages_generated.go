@@ -9,7 +9,9 @@ import (
 	"time"
 
 	"github.com/SAP/jenkins-library/pkg/config"
+	piperGithub "github.com/SAP/jenkins-library/pkg/github"
 	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/opentelemetry"
 	"github.com/SAP/jenkins-library/pkg/piperenv"
 	"github.com/SAP/jenkins-library/pkg/splunk"
 	"github.com/SAP/jenkins-library/pkg/telemetry"
@@ -62,6 +64,8 @@ func AbapAddonAssemblyKitRegisterPackagesCommand() *cobra.Command {
 	var commonPipelineEnvironment abapAddonAssemblyKitRegisterPackagesCommonPipelineEnvironment
 	var logCollector *log.CollectorHook
 	var splunkClient *splunk.Splunk
+	var otelClient *opentelemetry.OpenTelemetry
+	var checkRun *piperGithub.StepCheckRun
 	telemetryClient := &telemetry.Telemetry{}
 
 	var createAbapAddonAssemblyKitRegisterPackagesCmd = &cobra.Command{
@@ -103,6 +107,13 @@ For Terminology refer to the [Scenario Description](https://www.project-piper.io
 				log.RegisterHook(logCollector)
 			}
 
+			if len(GeneralConfig.HookConfig.OpenTelemetryConfig.Endpoint) > 0 {
+				otelClient = &opentelemetry.OpenTelemetry{}
+				if err := otelClient.Initialize(GeneralConfig.CorrelationID, STEP_NAME, GeneralConfig.HookConfig.OpenTelemetryConfig); err != nil {
+					log.Entry().WithError(err).Warning("failed to initialize OpenTelemetry")
+				}
+			}
+
 			validation, err := validation.New(validation.WithJSONNamesForStructFields(), validation.WithPredefinedErrorMessages())
 			if err != nil {
 				return err
@@ -128,6 +139,12 @@ For Terminology refer to the [Scenario Description](https://www.project-piper.io
 				if len(GeneralConfig.HookConfig.SplunkConfig.Dsn) > 0 {
 					splunkClient.Send(telemetryClient.GetData(), logCollector)
 				}
+				if len(GeneralConfig.HookConfig.OpenTelemetryConfig.Endpoint) > 0 {
+					otelClient.Send(telemetryClient.GetData().Attributes())
+				}
+				if checkRun != nil {
+					checkRun.Complete(stepTelemetryData.ErrorCode != "0", log.GetErrorCategory())
+				}
 			}
 			log.DeferExitHandler(handler)
 			defer handler()
@@ -139,6 +156,9 @@ For Terminology refer to the [Scenario Description](https://www.project-piper.io
 					GeneralConfig.HookConfig.SplunkConfig.Index,
 					GeneralConfig.HookConfig.SplunkConfig.SendLogs)
 			}
+			if GeneralConfig.HookConfig.GitHubChecksConfig.Enabled {
+				checkRun = piperGithub.StartStepCheckRun(STEP_NAME, GeneralConfig.StageName, GeneralConfig.HookConfig.GitHubChecksConfig)
+			}
 			abapAddonAssemblyKitRegisterPackages(stepConfig, &stepTelemetryData, &commonPipelineEnvironment)
 			stepTelemetryData.ErrorCode = "0"
 			log.Entry().Info("SUCCESS")
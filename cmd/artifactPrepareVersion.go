@@ -6,6 +6,8 @@ import (
 	"io"
 	netHttp "net/http"
 	"os"
+	"path/filepath"
+	"sort"
 	"strings"
 	"text/template"
 	"time"
@@ -97,6 +99,19 @@ func artifactPrepareVersion(config artifactPrepareVersionOptions, telemetryData
 
 var sshAgentAuth = ssh.NewSSHAgentAuth
 
+var newVersioningArtifact = versioning.GetArtifact
+
+// defaultBuildDescriptorPatterns defines per build tool which descriptors are versioned together in case of multiDescriptor: true
+var defaultBuildDescriptorPatterns = map[string]string{
+	"cargo": "**/Cargo.toml",
+	"dub":   "**/dub.json",
+	"helm":  "**/Chart.yaml",
+	"maven": "**/pom.xml",
+	"mta":   "**/mta.yaml",
+	"npm":   "**/package.json",
+	"yarn":  "**/package.json",
+}
+
 func runArtifactPrepareVersion(config *artifactPrepareVersionOptions, telemetryData *telemetry.CustomData, commonPipelineEnvironment *artifactPrepareVersionCommonPipelineEnvironment, artifact versioning.Artifact, utils artifactPrepareVersionUtils, repository gitRepository, getWorktree func(gitRepository) (gitWorktree, error)) error {
 
 	telemetryData.Custom1Label = "buildTool"
@@ -116,12 +131,14 @@ func runArtifactPrepareVersion(config *artifactPrepareVersionOptions, telemetryD
 	}
 
 	var err error
+	artifacts := map[string]versioning.Artifact{config.FilePath: artifact}
 	if artifact == nil {
-		artifact, err = versioning.GetArtifact(config.BuildTool, config.FilePath, &artifactOpts, utils)
+		artifacts, err = getVersioningArtifacts(config, &artifactOpts, utils)
 		if err != nil {
 			log.SetErrorCategory(log.ErrorConfiguration)
 			return errors.Wrap(err, "failed to retrieve artifact")
 		}
+		artifact = artifacts[firstDescriptor(artifacts)]
 	}
 
 	versioningType := config.VersioningType
@@ -138,6 +155,14 @@ func runArtifactPrepareVersion(config *artifactPrepareVersionOptions, telemetryD
 	}
 	log.Entry().Infof("Version before automatic versioning: %v", version)
 
+	if len(artifacts) > 1 {
+		err = checkVersionsInSync(artifacts, version)
+		if err != nil {
+			log.SetErrorCategory(log.ErrorConfiguration)
+			return err
+		}
+	}
+
 	gitCommit, gitCommitMessage, err := getGitCommitID(repository)
 	if err != nil {
 		log.SetErrorCategory(log.ErrorConfiguration)
@@ -148,18 +173,28 @@ func runArtifactPrepareVersion(config *artifactPrepareVersionOptions, telemetryD
 	commonPipelineEnvironment.git.headCommitID = gitCommitID
 	newVersion := version
 
-	if versioningType == "cloud" || versioningType == "cloud_noTag" {
-		versioningTempl, err := versioningTemplate(artifact.VersioningScheme())
-		if err != nil {
-			log.SetErrorCategory(log.ErrorConfiguration)
-			return errors.Wrapf(err, "failed to get versioning template for scheme '%v'", artifact.VersioningScheme())
-		}
-
+	if versioningType == "cloud" || versioningType == "cloud_noTag" || versioningType == "semantic" {
 		now := time.Now()
 
-		newVersion, err = calculateNewVersion(versioningTempl, version, gitCommitID, config.IncludeCommitID, config.ShortCommitID, config.UnixTimestamp, now)
-		if err != nil {
-			return errors.Wrap(err, "failed to calculate new version")
+		if versioningType == "semantic" {
+			newVersion, err = calculateSemanticVersion(config, version, repository, utils, now)
+			if err != nil {
+				return errors.Wrap(err, "failed to calculate new version")
+			}
+			if newVersion != version && len(config.ChangelogFile) > 0 {
+				commonPipelineEnvironment.custom.changelogFile = config.ChangelogFile
+			}
+		} else {
+			versioningTempl, err := versioningTemplate(artifact.VersioningScheme())
+			if err != nil {
+				log.SetErrorCategory(log.ErrorConfiguration)
+				return errors.Wrapf(err, "failed to get versioning template for scheme '%v'", artifact.VersioningScheme())
+			}
+
+			newVersion, err = calculateNewVersion(versioningTempl, version, gitCommitID, config.IncludeCommitID, config.ShortCommitID, config.UnixTimestamp, now)
+			if err != nil {
+				return errors.Wrap(err, "failed to calculate new version")
+			}
 		}
 
 		worktree, err := getWorktree(repository)
@@ -178,16 +213,22 @@ func runArtifactPrepareVersion(config *artifactPrepareVersionOptions, telemetryD
 
 		// only update version in build descriptor if required in order to save prossing time (e.g. maven case)
 		if newVersion != version {
-			err = artifact.SetVersion(newVersion)
-			if err != nil {
-				log.SetErrorCategory(log.ErrorConfiguration)
-				return errors.Wrap(err, "failed to write version")
+			for _, descriptor := range sortedDescriptors(artifacts) {
+				err = artifacts[descriptor].SetVersion(newVersion)
+				if err != nil {
+					log.SetErrorCategory(log.ErrorConfiguration)
+					if len(artifacts) > 1 {
+						return errors.Wrapf(err, "failed to write version to '%v'", descriptor)
+					}
+					return errors.Wrap(err, "failed to write version")
+				}
 			}
 		}
 
 		//ToDo: what about closure in current Groovy step. Discard the possibility or provide extension mechanism?
 
-		if versioningType == "cloud" {
+		// in semantic mode a release is only created if relevant changes are available
+		if versioningType == "cloud" || (versioningType == "semantic" && newVersion != version) {
 			// commit changes and push to repository (including new version tag)
 			gitCommitID, err = pushChanges(config, newVersion, repository, worktree, now)
 			if err != nil {
@@ -221,6 +262,94 @@ func runArtifactPrepareVersion(config *artifactPrepareVersionOptions, telemetryD
 	return nil
 }
 
+// getVersioningArtifacts returns the artifacts to be versioned by their build descriptor path.
+// Without multiDescriptor this is exactly one artifact.
+func getVersioningArtifacts(config *artifactPrepareVersionOptions, artifactOpts *versioning.Options, utils artifactPrepareVersionUtils) (map[string]versioning.Artifact, error) {
+	if !config.MultiDescriptor {
+		artifact, err := newVersioningArtifact(config.BuildTool, config.FilePath, artifactOpts, utils)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]versioning.Artifact{config.FilePath: artifact}, nil
+	}
+
+	descriptors, err := findBuildDescriptors(config, utils)
+	if err != nil {
+		return nil, err
+	}
+	log.Entry().Infof("Versioning build descriptors %v", descriptors)
+
+	artifacts := map[string]versioning.Artifact{}
+	for _, descriptor := range descriptors {
+		artifact, err := newVersioningArtifact(config.BuildTool, descriptor, artifactOpts, utils)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to retrieve artifact for '%v'", descriptor)
+		}
+		artifacts[descriptor] = artifact
+	}
+	return artifacts, nil
+}
+
+func findBuildDescriptors(config *artifactPrepareVersionOptions, utils artifactPrepareVersionUtils) ([]string, error) {
+	patterns := config.BuildDescriptorList
+	if len(patterns) == 0 {
+		pattern, ok := defaultBuildDescriptorPatterns[config.BuildTool]
+		if !ok {
+			return nil, fmt.Errorf("no default build descriptors known for build tool '%v', please provide them via buildDescriptorList", config.BuildTool)
+		}
+		patterns = []string{pattern}
+	}
+
+	descriptors := []string{}
+	for _, pattern := range patterns {
+		matches, err := utils.Glob(pattern)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to search for build descriptors matching '%v'", pattern)
+		}
+		descriptors = append(descriptors, matches...)
+	}
+	descriptors, err := piperutils.ExcludeFiles(piperutils.UniqueStrings(descriptors), config.BuildDescriptorExcludeList)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to exclude build descriptors")
+	}
+	if len(descriptors) == 0 {
+		return nil, fmt.Errorf("no build descriptors found matching %v", patterns)
+	}
+	sort.Strings(descriptors)
+	return descriptors, nil
+}
+
+// checkVersionsInSync makes sure that all build descriptors share the same version before a new one is applied
+func checkVersionsInSync(artifacts map[string]versioning.Artifact, expectedVersion string) error {
+	drifted := []string{}
+	for _, descriptor := range sortedDescriptors(artifacts) {
+		version, err := artifacts[descriptor].GetVersion()
+		if err != nil {
+			return errors.Wrapf(err, "failed to retrieve version of '%v'", descriptor)
+		}
+		if version != expectedVersion {
+			drifted = append(drifted, fmt.Sprintf("%v: %v", descriptor, version))
+		}
+	}
+	if len(drifted) > 0 {
+		return fmt.Errorf("versions of build descriptors differ from version '%v' of '%v': %v", expectedVersion, firstDescriptor(artifacts), strings.Join(drifted, ", "))
+	}
+	return nil
+}
+
+func sortedDescriptors(artifacts map[string]versioning.Artifact) []string {
+	descriptors := make([]string, 0, len(artifacts))
+	for descriptor := range artifacts {
+		descriptors = append(descriptors, descriptor)
+	}
+	sort.Strings(descriptors)
+	return descriptors
+}
+
+func firstDescriptor(artifacts map[string]versioning.Artifact) string {
+	return sortedDescriptors(artifacts)[0]
+}
+
 func openGit() (gitRepository, error) {
 	workdir, _ := os.Getwd()
 	return gitUtils.PlainOpen(workdir)
@@ -261,6 +390,77 @@ func versioningTemplate(scheme string) (string, error) {
 	return "", fmt.Errorf("versioning scheme '%v' not supported", scheme)
 }
 
+// getCommitsSince returns the commits reachable from HEAD but not from the revision, all commits are returned if the revision does not exist
+var getCommitsSince = getCommitsSinceDefault
+
+func getCommitsSinceDefault(repository gitRepository, revision string) ([]*object.Commit, error) {
+	repo, ok := repository.(*git.Repository)
+	if !ok {
+		return nil, fmt.Errorf("commit history not available for repository of type %T", repository)
+	}
+
+	var commitIter object.CommitIter
+	var err error
+	if _, resolveErr := repo.ResolveRevision(plumbing.Revision(revision)); resolveErr == nil {
+		commitIter, err = gitUtils.LogRange(repo, revision, "HEAD")
+	} else {
+		log.Entry().Infof("revision '%v' not found, considering complete history", revision)
+		commitIter, err = repo.Log(&git.LogOptions{})
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to retrieve commit history")
+	}
+
+	commits := []*object.Commit{}
+	err = commitIter.ForEach(func(c *object.Commit) error {
+		commits = append(commits, c)
+		return nil
+	})
+	return commits, err
+}
+
+// calculateSemanticVersion bumps the version based on the Conventional Commits since the tag of the current version
+// and writes the changelog of the new version to config.ChangelogFile
+func calculateSemanticVersion(config *artifactPrepareVersionOptions, version string, repository gitRepository, utils artifactPrepareVersionUtils, t time.Time) (string, error) {
+	tag := fmt.Sprintf("%v%v", config.TagPrefix, version)
+	commits, err := getCommitsSince(repository, "refs/tags/"+tag)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to retrieve commits since tag '%v'", tag)
+	}
+
+	conventionalCommits := []versioning.ConventionalCommit{}
+	for _, commit := range commits {
+		if conventionalCommit, ok := versioning.ParseConventionalCommit(commit.Hash.String(), commit.Message); ok {
+			conventionalCommits = append(conventionalCommits, conventionalCommit)
+		}
+	}
+
+	bump := versioning.DetermineVersionBump(conventionalCommits)
+	log.Entry().Infof("%v commits since tag '%v' (%v following Conventional Commits) result in version bump '%v'", len(commits), tag, len(conventionalCommits), bump)
+	if bump == versioning.BumpNone {
+		return version, nil
+	}
+
+	newVersion, err := versioning.BumpVersion(version, bump)
+	if err != nil {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return "", err
+	}
+
+	if len(config.ChangelogFile) > 0 {
+		changelog := versioning.GenerateChangelog(newVersion, t, conventionalCommits)
+		err = utils.MkdirAll(filepath.Dir(config.ChangelogFile), 0755)
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to create directory for changelog file '%v'", config.ChangelogFile)
+		}
+		err = utils.FileWrite(config.ChangelogFile, []byte(changelog), 0644)
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to write changelog file '%v'", config.ChangelogFile)
+		}
+	}
+	return newVersion, nil
+}
+
 func calculateNewVersion(versioningTemplate, currentVersion, commitID string, includeCommitID, shortCommitID, unixTimestamp bool, t time.Time) (string, error) {
 	tmpl, err := template.New("version").Parse(versioningTemplate)
 	if err != nil {
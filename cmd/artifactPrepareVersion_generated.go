@@ -9,7 +9,9 @@ import (
 	"time"
 
 	"github.com/SAP/jenkins-library/pkg/config"
+	piperGithub "github.com/SAP/jenkins-library/pkg/github"
 	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/opentelemetry"
 	"github.com/SAP/jenkins-library/pkg/piperenv"
 	"github.com/SAP/jenkins-library/pkg/splunk"
 	"github.com/SAP/jenkins-library/pkg/telemetry"
@@ -18,25 +20,29 @@ import (
 )
 
 type artifactPrepareVersionOptions struct {
-	BuildTool              string `json:"buildTool,omitempty" validate:"possible-values=custom docker dub golang gradle maven mta npm pip sbt yarn"`
-	CommitUserName         string `json:"commitUserName,omitempty"`
-	CustomVersionField     string `json:"customVersionField,omitempty"`
-	CustomVersionSection   string `json:"customVersionSection,omitempty"`
-	CustomVersioningScheme string `json:"customVersioningScheme,omitempty" validate:"possible-values=docker maven pep440 semver2"`
-	DockerVersionSource    string `json:"dockerVersionSource,omitempty"`
-	FetchCoordinates       bool   `json:"fetchCoordinates,omitempty"`
-	FilePath               string `json:"filePath,omitempty"`
-	GlobalSettingsFile     string `json:"globalSettingsFile,omitempty"`
-	IncludeCommitID        bool   `json:"includeCommitId,omitempty"`
-	M2Path                 string `json:"m2Path,omitempty"`
-	Password               string `json:"password,omitempty"`
-	ProjectSettingsFile    string `json:"projectSettingsFile,omitempty"`
-	ShortCommitID          bool   `json:"shortCommitId,omitempty"`
-	TagPrefix              string `json:"tagPrefix,omitempty"`
-	UnixTimestamp          bool   `json:"unixTimestamp,omitempty"`
-	Username               string `json:"username,omitempty"`
-	VersioningTemplate     string `json:"versioningTemplate,omitempty"`
-	VersioningType         string `json:"versioningType,omitempty" validate:"possible-values=cloud cloud_noTag library"`
+	BuildDescriptorExcludeList []string `json:"buildDescriptorExcludeList,omitempty"`
+	BuildDescriptorList        []string `json:"buildDescriptorList,omitempty"`
+	BuildTool                  string   `json:"buildTool,omitempty" validate:"possible-values=cargo custom docker dotnet dub golang gradle helm maven mta npm pip sbt yarn"`
+	ChangelogFile              string   `json:"changelogFile,omitempty"`
+	CommitUserName             string   `json:"commitUserName,omitempty"`
+	CustomVersionField         string   `json:"customVersionField,omitempty"`
+	CustomVersionSection       string   `json:"customVersionSection,omitempty"`
+	CustomVersioningScheme     string   `json:"customVersioningScheme,omitempty" validate:"possible-values=docker maven pep440 semver2"`
+	DockerVersionSource        string   `json:"dockerVersionSource,omitempty"`
+	FetchCoordinates           bool     `json:"fetchCoordinates,omitempty"`
+	FilePath                   string   `json:"filePath,omitempty"`
+	GlobalSettingsFile         string   `json:"globalSettingsFile,omitempty"`
+	IncludeCommitID            bool     `json:"includeCommitId,omitempty"`
+	M2Path                     string   `json:"m2Path,omitempty"`
+	MultiDescriptor            bool     `json:"multiDescriptor,omitempty"`
+	Password                   string   `json:"password,omitempty"`
+	ProjectSettingsFile        string   `json:"projectSettingsFile,omitempty"`
+	ShortCommitID              bool     `json:"shortCommitId,omitempty"`
+	TagPrefix                  string   `json:"tagPrefix,omitempty"`
+	UnixTimestamp              bool     `json:"unixTimestamp,omitempty"`
+	Username                   string   `json:"username,omitempty"`
+	VersioningTemplate         string   `json:"versioningTemplate,omitempty"`
+	VersioningType             string   `json:"versioningType,omitempty" validate:"possible-values=cloud cloud_noTag library semantic"`
 }
 
 type artifactPrepareVersionCommonPipelineEnvironment struct {
@@ -50,6 +56,9 @@ type artifactPrepareVersionCommonPipelineEnvironment struct {
 		headCommitID  string
 		commitMessage string
 	}
+	custom struct {
+		changelogFile string
+	}
 }
 
 func (p *artifactPrepareVersionCommonPipelineEnvironment) persist(path, resourceName string) {
@@ -66,6 +75,7 @@ func (p *artifactPrepareVersionCommonPipelineEnvironment) persist(path, resource
 		{category: "git", name: "commitId", value: p.git.commitID},
 		{category: "git", name: "headCommitId", value: p.git.headCommitID},
 		{category: "git", name: "commitMessage", value: p.git.commitMessage},
+		{category: "custom", name: "changelogFile", value: p.custom.changelogFile},
 	}
 
 	errCount := 0
@@ -91,6 +101,8 @@ func ArtifactPrepareVersionCommand() *cobra.Command {
 	var commonPipelineEnvironment artifactPrepareVersionCommonPipelineEnvironment
 	var logCollector *log.CollectorHook
 	var splunkClient *splunk.Splunk
+	var otelClient *opentelemetry.OpenTelemetry
+	var checkRun *piperGithub.StepCheckRun
 	telemetryClient := &telemetry.Telemetry{}
 
 	var createArtifactPrepareVersionCmd = &cobra.Command{
@@ -134,6 +146,30 @@ Unlike for the _Continuous Deloyment_ pattern described above, in this case ther
 
 Configuration of this pattern is done via ` + "`" + `versioningType: library` + "`" + `.
 
+### Rust crates, Helm charts and .NET projects
+
+* ` + "`" + `buildTool: cargo` + "`" + ` updates the ` + "`" + `version` + "`" + ` of the ` + "`" + `[package]` + "`" + ` (or ` + "`" + `[workspace.package]` + "`" + `) section in ` + "`" + `Cargo.toml` + "`" + `.
+* ` + "`" + `buildTool: helm` + "`" + ` updates ` + "`" + `version` + "`" + ` as well as ` + "`" + `appVersion` + "`" + ` (if available) in ` + "`" + `Chart.yaml` + "`" + `.
+* ` + "`" + `buildTool: dotnet` + "`" + ` updates the ` + "`" + `<Version>` + "`" + ` property in ` + "`" + `Directory.Build.props` + "`" + ` or in the project's ` + "`" + `*.csproj` + "`" + ` file.
+
+For these build tools the version is extended according to [Semantic Versioning 2.0.0](https://semver.org/spec/v2.0.0.html) and only the version lines of the descriptor files are changed.
+
+### 3. Semantic versioning based on Conventional Commits
+
+With ` + "`" + `versioningType: semantic` + "`" + ` the commits since the tag of the current version (` + "`" + `<tagPrefix><version>` + "`" + `) are analyzed according to [Conventional Commits](https://www.conventionalcommits.org):
+
+* a ` + "`" + `BREAKING CHANGE:` + "`" + ` footer or a ` + "`" + `!` + "`" + ` after type/scope (e.g. ` + "`" + `feat!: ...` + "`" + `) increases the major version,
+* ` + "`" + `feat` + "`" + ` increases the minor version,
+* ` + "`" + `fix` + "`" + ` and ` + "`" + `perf` + "`" + ` increase the patch version.
+
+The new version is written to the build descriptor, committed and tagged. If no relevant commits are available the version stays unchanged and no tag is created.
+A changelog section of the new version is written to ` + "`" + `changelogFile` + "`" + ` and made available to ` + "`" + `githubPublishRelease` + "`" + ` via the common pipeline environment.
+
+### Monorepos with multiple build descriptors
+
+With ` + "`" + `multiDescriptor: true` + "`" + ` all build descriptors matching ` + "`" + `buildDescriptorList` + "`" + ` (minus ` + "`" + `buildDescriptorExcludeList` + "`" + `) are versioned together.
+The step fails if the current versions of the descriptors differ. Otherwise the new version is written to all descriptors which are then committed and tagged in one commit.
+
 ### Support of additional build tools
 
 Besides the ` + "`" + `buildTools` + "`" + ` provided out of the box (like ` + "`" + `maven` + "`" + `, ` + "`" + `mta` + "`" + `, ` + "`" + `npm` + "`" + `, ...) it is possible to set ` + "`" + `buildTool: custom` + "`" + `.
@@ -189,6 +225,13 @@ Define ` + "`" + `buildTool: custom` + "`" + `, ` + "`" + `filePath: <path to yo
 				log.RegisterHook(logCollector)
 			}
 
+			if len(GeneralConfig.HookConfig.OpenTelemetryConfig.Endpoint) > 0 {
+				otelClient = &opentelemetry.OpenTelemetry{}
+				if err := otelClient.Initialize(GeneralConfig.CorrelationID, STEP_NAME, GeneralConfig.HookConfig.OpenTelemetryConfig); err != nil {
+					log.Entry().WithError(err).Warning("failed to initialize OpenTelemetry")
+				}
+			}
+
 			validation, err := validation.New(validation.WithJSONNamesForStructFields(), validation.WithPredefinedErrorMessages())
 			if err != nil {
 				return err
@@ -214,6 +257,12 @@ Define ` + "`" + `buildTool: custom` + "`" + `, ` + "`" + `filePath: <path to yo
 				if len(GeneralConfig.HookConfig.SplunkConfig.Dsn) > 0 {
 					splunkClient.Send(telemetryClient.GetData(), logCollector)
 				}
+				if len(GeneralConfig.HookConfig.OpenTelemetryConfig.Endpoint) > 0 {
+					otelClient.Send(telemetryClient.GetData().Attributes())
+				}
+				if checkRun != nil {
+					checkRun.Complete(stepTelemetryData.ErrorCode != "0", log.GetErrorCategory())
+				}
 			}
 			log.DeferExitHandler(handler)
 			defer handler()
@@ -225,6 +274,9 @@ Define ` + "`" + `buildTool: custom` + "`" + `, ` + "`" + `filePath: <path to yo
 					GeneralConfig.HookConfig.SplunkConfig.Index,
 					GeneralConfig.HookConfig.SplunkConfig.SendLogs)
 			}
+			if GeneralConfig.HookConfig.GitHubChecksConfig.Enabled {
+				checkRun = piperGithub.StartStepCheckRun(STEP_NAME, GeneralConfig.StageName, GeneralConfig.HookConfig.GitHubChecksConfig)
+			}
 			artifactPrepareVersion(stepConfig, &stepTelemetryData, &commonPipelineEnvironment)
 			stepTelemetryData.ErrorCode = "0"
 			log.Entry().Info("SUCCESS")
@@ -236,17 +288,21 @@ Define ` + "`" + `buildTool: custom` + "`" + `, ` + "`" + `filePath: <path to yo
 }
 
 func addArtifactPrepareVersionFlags(cmd *cobra.Command, stepConfig *artifactPrepareVersionOptions) {
+	cmd.Flags().StringSliceVar(&stepConfig.BuildDescriptorExcludeList, "buildDescriptorExcludeList", []string{`**/node_modules/**`, `**/target/**`}, "Only relevant in case of `multiDescriptor: true` - List of glob patterns for build descriptors which are excluded from versioning.")
+	cmd.Flags().StringSliceVar(&stepConfig.BuildDescriptorList, "buildDescriptorList", []string{}, "Only relevant in case of `multiDescriptor: true` - List of glob patterns for the build descriptors to be versioned. Build tool specific defaults are used if empty (e.g. `maven: **/pom.xml`, `npm: **/package.json`, `cargo: **/Cargo.toml`, `helm: **/Chart.yaml`).")
 	cmd.Flags().StringVar(&stepConfig.BuildTool, "buildTool", os.Getenv("PIPER_buildTool"), "Defines the tool which is used for building the artifact.")
+	cmd.Flags().StringVar(&stepConfig.ChangelogFile, "changelogFile", `.pipeline/changelog.md`, "Only relevant for `versioningType: semantic` - Path of the file the changelog of the new version is written to. The file is not committed, it can be picked up by step `githubPublishRelease`.")
 	cmd.Flags().StringVar(&stepConfig.CommitUserName, "commitUserName", `Project Piper`, "Defines the user name which appears in version control for the versioning update (in case `versioningType: cloud`).")
 	cmd.Flags().StringVar(&stepConfig.CustomVersionField, "customVersionField", os.Getenv("PIPER_customVersionField"), "For `buildTool: custom`: Defines the field which contains the version in the descriptor file.")
 	cmd.Flags().StringVar(&stepConfig.CustomVersionSection, "customVersionSection", os.Getenv("PIPER_customVersionSection"), "For `buildTool: custom`: Defines the section for version retrieval in vase a *.ini/*.cfg file is used.")
 	cmd.Flags().StringVar(&stepConfig.CustomVersioningScheme, "customVersioningScheme", `maven`, "For `buildTool: custom`: Defines the versioning scheme to be used.")
 	cmd.Flags().StringVar(&stepConfig.DockerVersionSource, "dockerVersionSource", os.Getenv("PIPER_dockerVersionSource"), "For `buildTool: docker`: Defines the source of the version. Can be `FROM`, any supported _buildTool_ or an environment variable name.")
 	cmd.Flags().BoolVar(&stepConfig.FetchCoordinates, "fetchCoordinates", false, "If set to `true` the step will retreive artifact coordinates and store them in the common pipeline environment.")
-	cmd.Flags().StringVar(&stepConfig.FilePath, "filePath", os.Getenv("PIPER_filePath"), "Defines a custom path to the descriptor file. Build tool specific defaults are used (e.g. `maven: pom.xml`, `npm: package.json`, `mta: mta.yaml`).")
+	cmd.Flags().StringVar(&stepConfig.FilePath, "filePath", os.Getenv("PIPER_filePath"), "Defines a custom path to the descriptor file. Build tool specific defaults are used (e.g. `maven: pom.xml`, `npm: package.json`, `mta: mta.yaml`, `cargo: Cargo.toml`, `helm: Chart.yaml`, `dotnet: Directory.Build.props` or the only `*.csproj` file).")
 	cmd.Flags().StringVar(&stepConfig.GlobalSettingsFile, "globalSettingsFile", os.Getenv("PIPER_globalSettingsFile"), "Maven only - Path to the mvn settings file that should be used as global settings file.")
 	cmd.Flags().BoolVar(&stepConfig.IncludeCommitID, "includeCommitId", true, "Defines if the automatically generated version (`versioningType: cloud`) should include the commit id hash.")
 	cmd.Flags().StringVar(&stepConfig.M2Path, "m2Path", os.Getenv("PIPER_m2Path"), "Maven only - Path to the location of the local repository that should be used.")
+	cmd.Flags().BoolVar(&stepConfig.MultiDescriptor, "multiDescriptor", false, "Defines if all build descriptors of a monorepo are versioned together. The version of all descriptors found via `buildDescriptorList` needs to be identical, it is updated in all descriptors and committed/tagged once.")
 	cmd.Flags().StringVar(&stepConfig.Password, "password", os.Getenv("PIPER_password"), "Password/token for git authentication.")
 	cmd.Flags().StringVar(&stepConfig.ProjectSettingsFile, "projectSettingsFile", os.Getenv("PIPER_projectSettingsFile"), "Maven only - Path to the mvn settings file that should be used as project settings file.")
 	cmd.Flags().BoolVar(&stepConfig.ShortCommitID, "shortCommitId", false, "Defines if a short version of the commitId should be used. GitHub format is used (first 7 characters).")
@@ -254,7 +310,7 @@ func addArtifactPrepareVersionFlags(cmd *cobra.Command, stepConfig *artifactPrep
 	cmd.Flags().BoolVar(&stepConfig.UnixTimestamp, "unixTimestamp", false, "Defines if the Unix timestamp number should be used as build number instead of the standard date format.")
 	cmd.Flags().StringVar(&stepConfig.Username, "username", os.Getenv("PIPER_username"), "User name for git authentication")
 	cmd.Flags().StringVar(&stepConfig.VersioningTemplate, "versioningTemplate", os.Getenv("PIPER_versioningTemplate"), "DEPRECATED: Defines the template for the automatic version which will be created")
-	cmd.Flags().StringVar(&stepConfig.VersioningType, "versioningType", `cloud`, "Defines the type of versioning (`cloud`: fully automatic, `cloud_noTag`: automatic but no tag created, `library`: manual, i.e. the pipeline will pick up the version from the build descriptor, but not generate a new version)")
+	cmd.Flags().StringVar(&stepConfig.VersioningType, "versioningType", `cloud`, "Defines the type of versioning (`cloud`: fully automatic, `cloud_noTag`: automatic but no tag created, `library`: manual, i.e. the pipeline will pick up the version from the build descriptor, but not generate a new version, `semantic`: `<major>.<minor>.<patch>` is increased based on the Conventional Commits since the last version tag)")
 
 	cmd.MarkFlagRequired("buildTool")
 }
@@ -274,6 +330,24 @@ func artifactPrepareVersionMetadata() config.StepData {
 					{Name: "gitSshKeyCredentialsId", Description: "Jenkins 'SSH Username with private key' credentials ID ssh key for accessing your git repository. You can find details about how to generate an ssh key in the [GitHub documentation](https://docs.github.com/en/enterprise/2.15/user/articles/generating-a-new-ssh-key-and-adding-it-to-the-ssh-agent).", Type: "jenkins", Aliases: []config.Alias{{Name: "gitCredentialsId", Deprecated: true}}},
 				},
 				Parameters: []config.StepParameters{
+					{
+						Name:        "buildDescriptorExcludeList",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "[]string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     []string{`**/node_modules/**`, `**/target/**`},
+					},
+					{
+						Name:        "buildDescriptorList",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "[]string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     []string{},
+					},
 					{
 						Name:        "buildTool",
 						ResourceRef: []config.ResourceReference{},
@@ -283,6 +357,15 @@ func artifactPrepareVersionMetadata() config.StepData {
 						Aliases:     []config.Alias{},
 						Default:     os.Getenv("PIPER_buildTool"),
 					},
+					{
+						Name:        "changelogFile",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     `.pipeline/changelog.md`,
+					},
 					{
 						Name:        "commitUserName",
 						ResourceRef: []config.ResourceReference{},
@@ -373,6 +456,15 @@ func artifactPrepareVersionMetadata() config.StepData {
 						Aliases:     []config.Alias{{Name: "maven/m2Path"}},
 						Default:     os.Getenv("PIPER_m2Path"),
 					},
+					{
+						Name:        "multiDescriptor",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "bool",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     false,
+					},
 					{
 						Name: "password",
 						ResourceRef: []config.ResourceReference{
@@ -488,6 +580,7 @@ func artifactPrepareVersionMetadata() config.StepData {
 							{"Name": "git/commitId"},
 							{"Name": "git/headCommitId"},
 							{"Name": "git/commitMessage"},
+							{"Name": "custom/changelogFile"},
 						},
 					},
 				},
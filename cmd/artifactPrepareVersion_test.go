@@ -2,20 +2,25 @@ package cmd
 
 import (
 	"fmt"
+	netHttp "net/http"
 	"testing"
 	"time"
 
+	"github.com/SAP/jenkins-library/pkg/mock"
 	"github.com/SAP/jenkins-library/pkg/versioning"
 
 	"github.com/SAP/jenkins-library/pkg/telemetry"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"github.com/go-git/go-billy/v5/memfs"
 	"github.com/go-git/go-git/v5"
 	gitConfig "github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/go-git/go-git/v5/plumbing/transport/http"
 	"github.com/go-git/go-git/v5/plumbing/transport/ssh"
+	"github.com/go-git/go-git/v5/storage/memory"
 )
 
 type artifactVersioningMock struct {
@@ -55,6 +60,22 @@ func (a *artifactVersioningMock) GetCoordinates() (versioning.Coordinates, error
 	return a.coordinates, nil
 }
 
+type artifactPrepareVersionMockUtils struct {
+	*mock.ExecMockRunner
+	*mock.FilesMock
+}
+
+func (a *artifactPrepareVersionMockUtils) DownloadFile(url, filename string, header netHttp.Header, cookies []*netHttp.Cookie) error {
+	return fmt.Errorf("not implemented")
+}
+
+func newArtifactPrepareVersionMockUtils() *artifactPrepareVersionMockUtils {
+	return &artifactPrepareVersionMockUtils{
+		ExecMockRunner: &mock.ExecMockRunner{},
+		FilesMock:      &mock.FilesMock{},
+	}
+}
+
 type gitRepositoryMock struct {
 	createRemoteConfigs []*gitConfig.RemoteConfig
 	createRemoteCalls   int
@@ -503,6 +524,120 @@ func TestRunArtifactPrepareVersion(t *testing.T) {
 	})
 }
 
+func TestRunArtifactPrepareVersionMultiDescriptor(t *testing.T) {
+	defer func() { newVersioningArtifact = versioning.GetArtifact }()
+
+	setup := func(versions map[string]string) (*artifactPrepareVersionMockUtils, map[string]*artifactVersioningMock) {
+		utils := newArtifactPrepareVersionMockUtils()
+		mocks := map[string]*artifactVersioningMock{}
+		for path, version := range versions {
+			utils.AddFile(path, []byte{})
+			mocks[path] = &artifactVersioningMock{originalVersion: version, versioningScheme: "semver2"}
+		}
+		newVersioningArtifact = func(buildTool, buildDescriptorFilePath string, opts *versioning.Options, utils versioning.Utils) (versioning.Artifact, error) {
+			artifactMock, ok := mocks[buildDescriptorFilePath]
+			if !ok {
+				return nil, fmt.Errorf("unexpected descriptor '%v'", buildDescriptorFilePath)
+			}
+			return artifactMock, nil
+		}
+		return utils, mocks
+	}
+
+	t.Run("success case - all descriptors updated", func(t *testing.T) {
+		config := artifactPrepareVersionOptions{
+			BuildTool:                  "npm",
+			MultiDescriptor:            true,
+			BuildDescriptorExcludeList: []string{"**/node_modules/**"},
+			Password:                   "****",
+			Username:                   "testUser",
+			VersioningType:             "cloud",
+		}
+		cpe := artifactPrepareVersionCommonPipelineEnvironment{}
+		utils, mocks := setup(map[string]string{
+			"package.json":          "1.2.3",
+			"ui/package.json":       "1.2.3",
+			"services/package.json": "1.2.3",
+		})
+		utils.AddFile("ui/node_modules/dep/package.json", []byte{})
+
+		worktree := gitWorktreeMock{
+			commitHash: plumbing.ComputeHash(plumbing.CommitObject, []byte{2, 3, 4}),
+		}
+		conf := gitConfig.RemoteConfig{Name: "origin", URLs: []string{"https://my.test.server"}}
+		repo := gitRepositoryMock{
+			revisionHash: plumbing.ComputeHash(plumbing.CommitObject, []byte{1, 2, 3}),
+			remote:       git.NewRemote(nil, &conf),
+		}
+
+		err := runArtifactPrepareVersion(&config, &telemetry.CustomData{}, &cpe, nil, utils, &repo, func(r gitRepository) (gitWorktree, error) { return &worktree, nil })
+
+		assert.NoError(t, err)
+		for path, artifactMock := range mocks {
+			assert.Contains(t, artifactMock.newVersion, "1.2.3-", path)
+		}
+		assert.Equal(t, mocks["package.json"].newVersion, cpe.artifactVersion)
+		assert.True(t, worktree.commitOpts.All)
+		assert.True(t, repo.pushCalled)
+	})
+
+	t.Run("error case - versions differ", func(t *testing.T) {
+		config := artifactPrepareVersionOptions{
+			BuildTool:       "npm",
+			MultiDescriptor: true,
+			VersioningType:  "cloud",
+		}
+		utils, mocks := setup(map[string]string{
+			"package.json":    "1.2.3",
+			"ui/package.json": "1.3.0",
+		})
+
+		err := runArtifactPrepareVersion(&config, &telemetry.CustomData{}, &artifactPrepareVersionCommonPipelineEnvironment{}, nil, utils, &gitRepositoryMock{}, nil)
+
+		assert.EqualError(t, err, "versions of build descriptors differ from version '1.2.3' of 'package.json': ui/package.json: 1.3.0")
+		assert.Empty(t, mocks["package.json"].newVersion)
+	})
+}
+
+func TestFindBuildDescriptors(t *testing.T) {
+	t.Run("default patterns", func(t *testing.T) {
+		utils := newArtifactPrepareVersionMockUtils()
+		utils.AddFile("pom.xml", []byte{})
+		utils.AddFile("module-b/pom.xml", []byte{})
+		utils.AddFile("module-a/pom.xml", []byte{})
+		utils.AddFile("module-a/target/pom.xml", []byte{})
+
+		descriptors, err := findBuildDescriptors(&artifactPrepareVersionOptions{BuildTool: "maven", BuildDescriptorExcludeList: []string{"**/target/**"}}, utils)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"module-a/pom.xml", "module-b/pom.xml", "pom.xml"}, descriptors)
+	})
+
+	t.Run("custom patterns", func(t *testing.T) {
+		utils := newArtifactPrepareVersionMockUtils()
+		utils.AddFile("charts/a/Chart.yaml", []byte{})
+		utils.AddFile("charts/b/Chart.yaml", []byte{})
+		utils.AddFile("test/Chart.yaml", []byte{})
+
+		descriptors, err := findBuildDescriptors(&artifactPrepareVersionOptions{BuildTool: "helm", BuildDescriptorList: []string{"charts/*/Chart.yaml", "charts/a/Chart.yaml"}}, utils)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"charts/a/Chart.yaml", "charts/b/Chart.yaml"}, descriptors)
+	})
+
+	t.Run("error case - no descriptors found", func(t *testing.T) {
+		_, err := findBuildDescriptors(&artifactPrepareVersionOptions{BuildTool: "npm"}, newArtifactPrepareVersionMockUtils())
+
+		assert.EqualError(t, err, "no build descriptors found matching [**/package.json]")
+	})
+
+	t.Run("error case - no default patterns", func(t *testing.T) {
+		_, err := findBuildDescriptors(&artifactPrepareVersionOptions{BuildTool: "custom"}, newArtifactPrepareVersionMockUtils())
+
+		assert.EqualError(t, err, "no default build descriptors known for build tool 'custom', please provide them via buildDescriptorList")
+	})
+}
+
 func TestVersioningTemplate(t *testing.T) {
 	tt := []struct {
 		scheme      string
@@ -526,6 +661,124 @@ func TestVersioningTemplate(t *testing.T) {
 	}
 }
 
+func TestRunArtifactPrepareVersionSemantic(t *testing.T) {
+	defer func() { getCommitsSince = getCommitsSinceDefault }()
+
+	t.Run("success case - minor bump", func(t *testing.T) {
+		getCommitsSince = func(repository gitRepository, revision string) ([]*object.Commit, error) {
+			assert.Equal(t, "refs/tags/v1.2.3", revision)
+			return []*object.Commit{
+				{Hash: plumbing.NewHash("1111111111111111111111111111111111111111"), Message: "feat: new feature"},
+				{Hash: plumbing.NewHash("2222222222222222222222222222222222222222"), Message: "fix(ui): fix layout"},
+			}, nil
+		}
+		config := artifactPrepareVersionOptions{
+			BuildTool:      "npm",
+			ChangelogFile:  ".pipeline/changelog.md",
+			Password:       "****",
+			TagPrefix:      "v",
+			Username:       "testUser",
+			VersioningType: "semantic",
+		}
+		cpe := artifactPrepareVersionCommonPipelineEnvironment{}
+		versioningMock := artifactVersioningMock{originalVersion: "1.2.3", versioningScheme: "semver2"}
+		utils := newArtifactPrepareVersionMockUtils()
+		worktree := gitWorktreeMock{commitHash: plumbing.ComputeHash(plumbing.CommitObject, []byte{2, 3, 4})}
+		conf := gitConfig.RemoteConfig{Name: "origin", URLs: []string{"https://my.test.server"}}
+		repo := gitRepositoryMock{
+			revisionHash: plumbing.ComputeHash(plumbing.CommitObject, []byte{1, 2, 3}),
+			remote:       git.NewRemote(nil, &conf),
+		}
+
+		err := runArtifactPrepareVersion(&config, &telemetry.CustomData{}, &cpe, &versioningMock, utils, &repo, func(r gitRepository) (gitWorktree, error) { return &worktree, nil })
+
+		assert.NoError(t, err)
+		assert.Equal(t, "1.3.0", versioningMock.newVersion)
+		assert.Equal(t, "1.3.0", cpe.artifactVersion)
+		assert.Equal(t, "v1.3.0", repo.tag)
+		assert.True(t, repo.pushCalled)
+		assert.Equal(t, ".pipeline/changelog.md", cpe.custom.changelogFile)
+		changelog, err := utils.FileRead(".pipeline/changelog.md")
+		assert.NoError(t, err)
+		assert.Contains(t, string(changelog), "## 1.3.0 (")
+		assert.Contains(t, string(changelog), "### Features\n\n* new feature (1111111)")
+		assert.Contains(t, string(changelog), "### Bug Fixes\n\n* **ui:** fix layout (2222222)")
+	})
+
+	t.Run("success case - no relevant changes", func(t *testing.T) {
+		getCommitsSince = func(repository gitRepository, revision string) ([]*object.Commit, error) {
+			return []*object.Commit{{Message: "docs: update readme"}, {Message: "Merge branch 'foo'"}}, nil
+		}
+		config := artifactPrepareVersionOptions{
+			BuildTool:      "npm",
+			ChangelogFile:  ".pipeline/changelog.md",
+			VersioningType: "semantic",
+		}
+		cpe := artifactPrepareVersionCommonPipelineEnvironment{}
+		versioningMock := artifactVersioningMock{originalVersion: "1.2.3", versioningScheme: "semver2"}
+		utils := newArtifactPrepareVersionMockUtils()
+		worktree := gitWorktreeMock{}
+		repo := gitRepositoryMock{}
+
+		err := runArtifactPrepareVersion(&config, &telemetry.CustomData{}, &cpe, &versioningMock, utils, &repo, func(r gitRepository) (gitWorktree, error) { return &worktree, nil })
+
+		assert.NoError(t, err)
+		assert.Empty(t, versioningMock.newVersion)
+		assert.Equal(t, "1.2.3", cpe.artifactVersion)
+		assert.False(t, repo.pushCalled)
+		assert.Empty(t, cpe.custom.changelogFile)
+		assert.False(t, utils.HasFile(".pipeline/changelog.md"))
+	})
+
+	t.Run("error case - commits not available", func(t *testing.T) {
+		getCommitsSince = func(repository gitRepository, revision string) ([]*object.Commit, error) {
+			return nil, fmt.Errorf("log error")
+		}
+		config := artifactPrepareVersionOptions{BuildTool: "npm", VersioningType: "semantic"}
+		versioningMock := artifactVersioningMock{originalVersion: "1.2.3", versioningScheme: "semver2"}
+
+		err := runArtifactPrepareVersion(&config, &telemetry.CustomData{}, &artifactPrepareVersionCommonPipelineEnvironment{}, &versioningMock, newArtifactPrepareVersionMockUtils(), &gitRepositoryMock{}, nil)
+
+		assert.EqualError(t, err, "failed to calculate new version: failed to retrieve commits since tag '1.2.3': log error")
+	})
+}
+
+func TestGetCommitsSince(t *testing.T) {
+	repository, err := git.Init(memory.NewStorage(), memfs.New())
+	require.NoError(t, err)
+	worktree, err := repository.Worktree()
+	require.NoError(t, err)
+	commit := func(msg string) plumbing.Hash {
+		hash, err := worktree.Commit(msg, &git.CommitOptions{Author: &object.Signature{Name: "piper", When: time.Now()}})
+		require.NoError(t, err)
+		return hash
+	}
+	commit("feat: first")
+	tagged := commit("fix: second")
+	_, err = repository.CreateTag("v1.0.0", tagged, nil)
+	require.NoError(t, err)
+	commit("feat: third")
+
+	t.Run("commits since tag", func(t *testing.T) {
+		commits, err := getCommitsSince(repository, "refs/tags/v1.0.0")
+		assert.NoError(t, err)
+		if assert.Len(t, commits, 1) {
+			assert.Equal(t, "feat: third", commits[0].Message)
+		}
+	})
+
+	t.Run("complete history without tag", func(t *testing.T) {
+		commits, err := getCommitsSince(repository, "refs/tags/v2.0.0")
+		assert.NoError(t, err)
+		assert.Len(t, commits, 3)
+	})
+
+	t.Run("error case - no go-git repository", func(t *testing.T) {
+		_, err := getCommitsSince(&gitRepositoryMock{}, "refs/tags/v1.0.0")
+		assert.EqualError(t, err, "commit history not available for repository of type *cmd.gitRepositoryMock")
+	})
+}
+
 func TestCalculateNewVersion(t *testing.T) {
 
 	currentVersion := "1.2.3"
@@ -17,9 +17,13 @@ import (
 	"encoding/xml"
 
 	"github.com/SAP/jenkins-library/pkg/checkmarx"
+	"github.com/SAP/jenkins-library/pkg/decoration"
+	piperGithub "github.com/SAP/jenkins-library/pkg/github"
 	piperHttp "github.com/SAP/jenkins-library/pkg/http"
 	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/orchestrator"
 	"github.com/SAP/jenkins-library/pkg/piperutils"
+	"github.com/SAP/jenkins-library/pkg/reporting"
 	"github.com/SAP/jenkins-library/pkg/telemetry"
 	"github.com/SAP/jenkins-library/pkg/toolrecord"
 	"github.com/bmatcuk/doublestar"
@@ -67,14 +71,24 @@ func checkmarxExecuteScan(config checkmarxExecuteScanOptions, _ *telemetry.Custo
 	client := &piperHttp.Client{}
 	options := piperHttp.ClientOptions{MaxRetries: config.MaxRetries}
 	client.SetOptions(options)
-	sys, err := checkmarx.NewSystemInstance(client, config.ServerURL, config.Username, config.Password)
-	if err != nil {
-		log.Entry().WithError(err).Fatalf("Failed to create Checkmarx client talking to URL %v", config.ServerURL)
-	}
 	influx.step_data.fields.checkmarx = false
 	utils := checkmarxExecuteScanUtilsBundle{workspace: "./"}
-	if err := runScan(config, sys, influx, utils); err != nil {
-		log.Entry().WithError(err).Fatal("Failed to execute Checkmarx scan.")
+	if config.Platform == "CxOne" {
+		sys, err := checkmarx.NewOneSystemInstance(client, config.ServerURL, config.IamURL, config.Tenant, config.APIKey)
+		if err != nil {
+			log.Entry().WithError(err).Fatalf("Failed to create Checkmarx One client talking to URL %v", config.ServerURL)
+		}
+		if err := runScanOne(config, sys, influx, utils); err != nil {
+			log.Entry().WithError(err).Fatal("Failed to execute Checkmarx One scan.")
+		}
+	} else {
+		sys, err := checkmarx.NewSystemInstance(client, config.ServerURL, config.Username, config.Password)
+		if err != nil {
+			log.Entry().WithError(err).Fatalf("Failed to create Checkmarx client talking to URL %v", config.ServerURL)
+		}
+		if err := runScan(config, sys, influx, utils); err != nil {
+			log.Entry().WithError(err).Fatal("Failed to execute Checkmarx scan.")
+		}
 	}
 	influx.step_data.fields.checkmarx = true
 }
@@ -88,6 +102,19 @@ func runScan(config checkmarxExecuteScanOptions, sys checkmarx.System, influx *c
 		}
 		teamID = readTeamID
 	}
+	if config.NewFindingsOnly {
+		// the base project is looked up by its team when comparing the findings
+		config.TeamID = teamID
+		if len(config.PullRequestName) == 0 || len(config.PullRequestBaseBranch) == 0 {
+			pullRequestName, baseBranch := detectPullRequestFromCI()
+			if len(config.PullRequestName) == 0 {
+				config.PullRequestName = pullRequestName
+			}
+			if len(config.PullRequestBaseBranch) == 0 {
+				config.PullRequestBaseBranch = baseBranch
+			}
+		}
+	}
 	project, projectName, err := loadExistingProject(sys, config.ProjectName, config.PullRequestName, teamID)
 	if err != nil {
 		return errors.Wrap(err, "error when trying to load project")
@@ -111,6 +138,33 @@ func runScan(config checkmarxExecuteScanOptions, sys checkmarx.System, influx *c
 	return nil
 }
 
+// detectPullRequestFromCI returns the name of the pull request built by the orchestrator, e.g. PR-42, and its target branch
+func detectPullRequestFromCI() (string, string) {
+	provider, err := orchestrator.NewOrchestratorSpecificConfigProvider()
+	if err != nil {
+		log.Entry().WithError(err).Warning("Cannot infer pull request from CI environment")
+		return "", ""
+	}
+	if !provider.IsPullRequest() {
+		return "", ""
+	}
+	config := provider.GetPullRequestConfig()
+	log.Entry().Infof("Inferring pull request from environment: %v into %v", config.Key, config.Base)
+	return fmt.Sprintf("PR-%v", config.Key), config.Base
+}
+
+// syncScanReportIssues keeps the GitHub issues of the findings of the report in sync. Pull request scans are skipped
+// since their findings are not part of the main branch yet. Failures are only logged, the issues are for information only.
+func syncScanReportIssues(token, apiURL, pullRequestName string, options piperGithub.ResultIssueOptions, report *reporting.ScanReport) {
+	if detectedPullRequest, _ := detectPullRequestFromCI(); len(pullRequestName) > 0 || len(detectedPullRequest) > 0 {
+		log.Entry().Info("GitHub issues of the findings are not synchronized for pull request scans")
+		return
+	}
+	if err := piperGithub.SyncScanReportIssues(token, apiURL, options, report); err != nil {
+		log.Entry().WithError(err).Warn("Failed to synchronize the GitHub issues of the findings")
+	}
+}
+
 func loadTeamIDByTeamName(config checkmarxExecuteScanOptions, sys checkmarx.System, teamID string) (string, error) {
 	team, err := loadTeam(sys, config.TeamName)
 	if err != nil {
@@ -288,13 +342,32 @@ func verifyCxProjectCompliance(config checkmarxExecuteScanOptions, sys checkmarx
 		log.Entry().Debug("Report generation is disabled via configuration")
 	}
 
+	triage, err := reporting.ReadTriage(config.TriageFile, &piperutils.Files{})
+	if err != nil {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return err
+	}
+
 	xmlReportName := createReportName(utils.GetWorkspace(), "CxSASTResults_%v.xml")
-	results, err := getDetailedResults(sys, xmlReportName, scanID, utils)
+	results, err := getDetailedResults(sys, xmlReportName, scanID, utils, triage)
 	if err != nil {
 		return errors.Wrap(err, "failed to get detailed results")
 	}
 	reports = append(reports, piperutils.Path{Target: xmlReportName})
 
+	var delta *reporting.FindingsDelta
+	if config.NewFindingsOnly && len(config.PullRequestName) > 0 {
+		delta, err = compareWithBaseProject(config, sys, results, triage)
+		if err != nil {
+			return errors.Wrap(err, "failed to compare findings with base project")
+		}
+	}
+	return enforceCompliance(config, results, delta, reports, triage, influx, utils)
+}
+
+// enforceCompliance records and reports the results of the scan and checks them against the configured thresholds.
+// In case a delta to the base project is provided, only new findings are checked against the thresholds.
+func enforceCompliance(config checkmarxExecuteScanOptions, results map[string]interface{}, delta *reporting.FindingsDelta, reports []piperutils.Path, triage *reporting.Triage, influx *checkmarxExecuteScanInflux, utils checkmarxExecuteScanUtils) error {
 	// create toolrecord
 	toolRecordFileName, err := createToolRecordCx(utils.GetWorkspace(), config, results)
 	if err != nil {
@@ -309,14 +382,22 @@ func verifyCxProjectCompliance(config checkmarxExecuteScanOptions, sys checkmarx
 
 	reportToInflux(results, influx)
 
+	thresholdResults := results
+	if delta != nil {
+		thresholdResults = newFindingsResults(results, delta.New)
+	}
+
 	insecure := false
 	insecureResults := []string{}
 	neutralResults := []string{}
 
-	err = nil
 	if config.VulnerabilityThresholdEnabled {
-		insecure, insecureResults, neutralResults = enforceThresholds(config, results)
+		insecure, insecureResults, neutralResults = enforceThresholds(config, thresholdResults)
 		scanReport := checkmarx.CreateCustomReport(results, insecureResults, neutralResults)
+		triage.Apply(&scanReport)
+		if delta != nil {
+			scanReport.AddDelta(*delta)
+		}
 		paths, err := checkmarx.WriteCustomReports(scanReport, fmt.Sprint(results["ProjectName"]), fmt.Sprint(results["ProjectID"]))
 		if err != nil {
 			// do not fail until we have a better idea to handle it
@@ -324,6 +405,26 @@ func verifyCxProjectCompliance(config checkmarxExecuteScanOptions, sys checkmarx
 		} else {
 			reports = append(reports, paths...)
 		}
+		if config.CreateResultIssue {
+			options := piperGithub.ResultIssueOptions{
+				Owner:      config.Owner,
+				Repository: config.Repository,
+				Scope:      checkmarx.ResultIssueScope(fmt.Sprint(results["ProjectName"]), fmt.Sprint(results["ProjectID"])),
+				Labels:     config.IssueLabels,
+				Assignees:  config.IssueAssignees,
+			}
+			syncScanReportIssues(config.GithubToken, config.GithubAPIURL, config.PullRequestName, options, &scanReport)
+		}
+		if config.DecoratePullRequest {
+			decoration.PublishReport(decoration.Options{
+				PullRequest:  config.PullRequestName,
+				Owner:        config.Owner,
+				Repository:   config.Repository,
+				GitHubToken:  config.GithubToken,
+				GitHubAPIURL: config.GithubAPIURL,
+				AzureToken:   config.AzureAccessToken,
+			}, scanReport, config.MaxLineComments, &piperHttp.Client{})
+		}
 	}
 
 	if insecure {
@@ -628,7 +729,8 @@ func getNumCoherentIncrementalScans(scans []checkmarx.ScanStatus) int {
 	return count
 }
 
-func getDetailedResults(sys checkmarx.System, reportFileName string, scanID int, utils checkmarxExecuteScanUtils) (map[string]interface{}, error) {
+// getDetailedResults reads the results of the scan, findings suppressed via the triage file are not counted as NotFalsePositive
+func getDetailedResults(sys checkmarx.System, reportFileName string, scanID int, utils checkmarxExecuteScanUtils, triage *reporting.Triage) (map[string]interface{}, error) {
 	resultMap := map[string]interface{}{}
 	data, err := generateAndDownloadReport(sys, scanID, "XML")
 	if err != nil {
@@ -664,8 +766,16 @@ func getDetailedResults(sys checkmarx.System, reportFileName string, scanID int,
 		resultMap["Medium"] = map[string]int{}
 		resultMap["Low"] = map[string]int{}
 		resultMap["Information"] = map[string]int{}
+		findings := []reporting.Finding{}
 		for _, query := range xmlResult.Queries {
 			for _, result := range query.Results {
+				finding := checkmarx.ToFinding(query, result)
+				suppressed := triage.Suppresses(finding)
+				if suppressed {
+					finding.Suppressed = true
+				}
+				findings = append(findings, finding)
+
 				key := result.Severity
 				var submap map[string]int
 				if resultMap[key] == nil {
@@ -697,15 +807,122 @@ func getDetailedResults(sys checkmarx.System, reportFileName string, scanID int,
 				}
 				submap[auditState]++
 
-				if result.FalsePositive != "True" {
+				if result.FalsePositive != "True" && !suppressed {
 					submap["NotFalsePositive"]++
 				}
 			}
 		}
+		resultMap["Findings"] = findings
 	}
 	return resultMap, nil
 }
 
+// compareWithBaseProject compares the findings of the pull request scan with the findings of the latest finished scan of the base project
+func compareWithBaseProject(config checkmarxExecuteScanOptions, sys checkmarx.System, results map[string]interface{}, triage *reporting.Triage) (*reporting.FindingsDelta, error) {
+	baseFindings := []reporting.Finding{}
+	baseProjectName, baseScanID, err := loadBaseProjectScan(config, sys)
+	if err != nil {
+		return nil, err
+	}
+	if baseScanID > 0 {
+		data, err := generateAndDownloadReport(sys, baseScanID, "XML")
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to download xml report of scan %v", baseScanID)
+		}
+		var xmlResult checkmarx.DetailedResult
+		if err := xml.Unmarshal(data, &xmlResult); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal XML report for scan %v", baseScanID)
+		}
+		for _, query := range xmlResult.Queries {
+			for _, result := range query.Results {
+				finding := checkmarx.ToFinding(query, result)
+				if triage.Suppresses(finding) {
+					finding.Suppressed = true
+				}
+				baseFindings = append(baseFindings, finding)
+			}
+		}
+	} else {
+		log.Entry().Warnf("No finished scan of base project %v available, all findings are considered as new", baseProjectName)
+	}
+
+	findings, _ := results["Findings"].([]reporting.Finding)
+	delta := reporting.CompareFindings(baseFindings, findings)
+	delta.Base = baseProjectName
+	log.Entry().Infof("Compared with project %v: %v new, %v fixed and %v unchanged findings", baseProjectName, len(delta.New), len(delta.Fixed), len(delta.Unchanged))
+	return &delta, nil
+}
+
+// loadBaseProjectScan returns the name of the project of the branch the pull request targets and the ID of its latest finished scan.
+// The branch project is named like the project of the pull request, e.g. <projectName>_release-1.0, if it does not exist
+// the project projectName is used which branch projects are created from.
+func loadBaseProjectScan(config checkmarxExecuteScanOptions, sys checkmarx.System) (string, int, error) {
+	if len(config.PullRequestBaseBranch) > 0 {
+		branchProjectName := fmt.Sprintf("%v_%v", config.ProjectName, config.PullRequestBaseBranch)
+		projects, err := sys.GetProjectsByNameAndTeam(branchProjectName, config.TeamID)
+		if err == nil && len(projects) > 0 {
+			scanID, err := loadLatestFinishedScan(sys, projects[0].ID, branchProjectName)
+			return branchProjectName, scanID, err
+		}
+		log.Entry().Warnf("No project %v of base branch %v found, comparing with project %v", branchProjectName, config.PullRequestBaseBranch, config.ProjectName)
+	}
+	projects, err := sys.GetProjectsByNameAndTeam(config.ProjectName, config.TeamID)
+	if err != nil {
+		return config.ProjectName, 0, errors.Wrapf(err, "failed to load base project %v", config.ProjectName)
+	}
+	if len(projects) == 0 {
+		return config.ProjectName, 0, nil
+	}
+	scanID, err := loadLatestFinishedScan(sys, projects[0].ID, config.ProjectName)
+	return config.ProjectName, scanID, err
+}
+
+// loadLatestFinishedScan returns the ID of the latest finished scan of the project or 0 if there is none
+func loadLatestFinishedScan(sys checkmarx.System, projectID int, projectName string) (int, error) {
+	scans, err := sys.GetScans(projectID)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to load scans of base project %v", projectName)
+	}
+	for _, scan := range scans {
+		if scan.Status.Name == "Finished" {
+			return scan.ID, nil
+		}
+	}
+	return 0, nil
+}
+
+// newFindingsResults returns a copy of the results in which the issue counts per severity only consider the new findings
+func newFindingsResults(results map[string]interface{}, newFindings []reporting.Finding) map[string]interface{} {
+	newResults := map[string]interface{}{}
+	for key, value := range results {
+		newResults[key] = value
+	}
+	severities := map[reporting.Severity]string{
+		reporting.SeverityHigh:   "High",
+		reporting.SeverityMedium: "Medium",
+		reporting.SeverityLow:    "Low",
+		reporting.SeverityInfo:   "Information",
+	}
+	for _, key := range severities {
+		newResults[key] = map[string]int{}
+	}
+	for _, finding := range newFindings {
+		key, ok := severities[finding.Severity]
+		if !ok {
+			key = "Information"
+		}
+		submap := newResults[key].(map[string]int)
+		submap["Issues"]++
+		if finding.Suppressed {
+			submap["NotExploitable"]++
+		} else {
+			submap["NotFalsePositive"]++
+			submap["ToVerify"]++
+		}
+	}
+	return newResults
+}
+
 func zipFolder(source string, zipFile io.Writer, patterns []string, utils checkmarxExecuteScanUtils) error {
 	archive := zip.NewWriter(zipFile)
 	defer archive.Close()
@@ -831,3 +1048,259 @@ func createToolRecordCx(workspace string, config checkmarxExecuteScanOptions, re
 	}
 	return record.GetFileName(), nil
 }
+
+func runScanOne(config checkmarxExecuteScanOptions, sys checkmarx.OneSystem, influx *checkmarxExecuteScanInflux, utils checkmarxExecuteScanUtils) error {
+	if config.NewFindingsOnly {
+		log.Entry().Warning("Parameter newFindingsOnly is not supported for Checkmarx One, all findings are considered")
+	}
+	branch := config.Branch
+	if len(config.PullRequestName) > 0 {
+		branch = config.PullRequestName
+	}
+	if len(branch) == 0 {
+		branch = "main"
+	}
+
+	project, err := loadOrCreateOneProject(config, sys)
+	if err != nil {
+		return err
+	}
+
+	previousScans, err := sys.GetScans(project.ID, branch)
+	if err != nil {
+		if !config.VerifyOnly {
+			return errors.Wrapf(err, "failed to load scans of project %v", project.Name)
+		}
+		log.Entry().WithError(err).Warnf("Cannot load scans for project %v, verification only mode aborted", project.Name)
+	}
+	if config.VerifyOnly {
+		for _, scan := range previousScans {
+			if scan.Status == "Completed" {
+				if err := verifyCxOneProjectCompliance(config, sys, project, scan, influx, utils); err != nil {
+					log.SetErrorCategory(log.ErrorCompliance)
+					return errors.Wrapf(err, "project %v not compliant", project.Name)
+				}
+				return nil
+			}
+		}
+	}
+
+	zipFile, err := zipWorkspaceFiles(config.FilterPattern, utils)
+	if err != nil {
+		return errors.Wrap(err, "failed to zip workspace files")
+	}
+	uploadURL, err := sys.UploadProjectSourceCode(zipFile.Name())
+	if err != nil {
+		return errors.Wrapf(err, "failed to upload source code for project %v", project.Name)
+	}
+	log.Entry().Debugf("Source code uploaded for project %v", project.Name)
+	err = os.Remove(zipFile.Name())
+	if err != nil {
+		log.Entry().WithError(err).Warnf("Failed to delete zipped source code for project %v", project.Name)
+	}
+
+	incremental := config.Incremental
+	fullScanCycle, err := strconv.Atoi(config.FullScanCycle)
+	if err != nil {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return errors.Wrapf(err, "invalid configuration value for fullScanCycle %v, must be a positive int", config.FullScanCycle)
+	}
+	if config.IsOptimizedAndScheduled {
+		incremental = false
+	} else if incremental && config.FullScansScheduled && fullScanCycle > 0 && (getNumCoherentIncrementalOneScans(previousScans)+1)%fullScanCycle == 0 {
+		incremental = false
+	}
+
+	scan, err := sys.ScanProject(project.ID, uploadURL, branch, config.Preset, incremental)
+	if err != nil {
+		return errors.Wrapf(err, "cannot scan project %v", project.Name)
+	}
+	log.Entry().Debugf("Scanning project %v ", project.Name)
+	scan, err = pollOneScanStatus(sys, scan)
+	if err != nil {
+		return errors.Wrap(err, "polling scan status failed")
+	}
+	log.Entry().Debugln("Scan finished")
+	return verifyCxOneProjectCompliance(config, sys, project, scan, influx, utils)
+}
+
+func loadOrCreateOneProject(config checkmarxExecuteScanOptions, sys checkmarx.OneSystem) (checkmarx.OneProject, error) {
+	project, err := sys.GetProjectByName(config.ProjectName)
+	if err != nil {
+		return project, errors.Wrap(err, "error when trying to load project")
+	}
+	if len(project.ID) > 0 {
+		log.Entry().Infof("Project %v exists...", config.ProjectName)
+		return project, nil
+	}
+
+	log.Entry().Infof("Project %v does not exist, starting to create it...", config.ProjectName)
+	applicationID := ""
+	if len(config.ApplicationName) > 0 {
+		application, err := sys.GetApplicationByName(config.ApplicationName)
+		if err != nil {
+			return project, errors.Wrapf(err, "failed to load application %v", config.ApplicationName)
+		}
+		if len(application.ID) == 0 {
+			log.SetErrorCategory(log.ErrorConfiguration)
+			return project, fmt.Errorf("application %v does not exist", config.ApplicationName)
+		}
+		applicationID = application.ID
+	}
+	project, err = sys.CreateProject(config.ProjectName, applicationID)
+	if err != nil {
+		return project, errors.Wrapf(err, "failed to create project %v", config.ProjectName)
+	}
+	return project, nil
+}
+
+func pollOneScanStatus(sys checkmarx.OneSystem, scan checkmarx.OneScan) (checkmarx.OneScan, error) {
+	pastStatus := ""
+	for {
+		var err error
+		scan, err = sys.GetScan(scan.ID)
+		if err != nil {
+			return scan, err
+		}
+		if scan.Status == "Completed" || scan.Status == "Partial" || scan.Status == "Canceled" || scan.Status == "Failed" {
+			break
+		}
+		status := fmt.Sprintf("Scan status: %v", scan.Status)
+		if pastStatus != status {
+			log.Entry().Info(status)
+			pastStatus = status
+		}
+		log.Entry().Debug("Polling for status: sleeping...")
+		time.Sleep(10 * time.Second)
+	}
+	if scan.Status == "Canceled" {
+		log.SetErrorCategory(log.ErrorCustom)
+		return scan, fmt.Errorf("scan canceled via web interface")
+	}
+	if scan.Status == "Failed" {
+		return scan, fmt.Errorf("scan failed, please check the Checkmarx One UI for details")
+	}
+	if scan.Status == "Partial" {
+		log.Entry().Warning("Scan finished partially, please check the Checkmarx One UI for details")
+	}
+	return scan, nil
+}
+
+func getNumCoherentIncrementalOneScans(scans []checkmarx.OneScan) int {
+	count := 0
+	for _, scan := range scans {
+		if !scan.IsIncremental() {
+			break
+		}
+		count++
+	}
+	return count
+}
+
+func verifyCxOneProjectCompliance(config checkmarxExecuteScanOptions, sys checkmarx.OneSystem, project checkmarx.OneProject, scan checkmarx.OneScan, influx *checkmarxExecuteScanInflux, utils checkmarxExecuteScanUtils) error {
+	triage, err := reporting.ReadTriage(config.TriageFile, &piperutils.Files{})
+	if err != nil {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return err
+	}
+
+	jsonReportName := createReportName(utils.GetWorkspace(), "CxOneResults_%v.json")
+	results, err := getOneDetailedResults(config, sys, project, scan, jsonReportName, utils, triage)
+	if err != nil {
+		return errors.Wrap(err, "failed to get detailed results")
+	}
+	reports := []piperutils.Path{{Target: jsonReportName}}
+	return enforceCompliance(config, results, nil, reports, triage, influx, utils)
+}
+
+// getOneDetailedResults reads the results of the Checkmarx One scan into the same structure as the results of a CxSAST scan,
+// findings suppressed via the triage file are not counted as NotFalsePositive
+func getOneDetailedResults(config checkmarxExecuteScanOptions, sys checkmarx.OneSystem, project checkmarx.OneProject, scan checkmarx.OneScan, reportFileName string, utils checkmarxExecuteScanUtils, triage *reporting.Triage) (map[string]interface{}, error) {
+	resultMap := map[string]interface{}{}
+	oneResults, err := sys.GetResults(scan.ID)
+	if err != nil {
+		return resultMap, errors.Wrapf(err, "failed to load results of scan %v", scan.ID)
+	}
+	data, err := json.MarshalIndent(oneResults, "", "  ")
+	if err != nil {
+		return resultMap, errors.Wrap(err, "failed to marshal results")
+	}
+	if err := utils.WriteFile(reportFileName, data, 0700); err != nil {
+		return resultMap, errors.Wrap(err, "failed to write file")
+	}
+
+	scanType := "Full"
+	if scan.IsIncremental() {
+		scanType = "Incremental"
+	}
+	deepLink := sys.DeepLink(project.ID, scan.ID)
+	resultMap["InitiatorName"] = scan.Initiator
+	resultMap["Owner"] = scan.Initiator
+	resultMap["ScanId"] = scan.ID
+	resultMap["ProjectId"] = project.ID
+	resultMap["ProjectName"] = project.Name
+	resultMap["Team"] = strings.Join(project.Groups, ", ")
+	resultMap["TeamFullPathOnReportDate"] = strings.Join(project.Groups, ", ")
+	resultMap["ScanStart"] = scan.CreatedAt
+	resultMap["ScanTime"] = oneScanDuration(scan)
+	resultMap["LinesOfCodeScanned"] = 0
+	resultMap["FilesScanned"] = 0
+	resultMap["CheckmarxVersion"] = "Checkmarx One"
+	resultMap["ScanType"] = scanType
+	resultMap["Preset"] = config.Preset
+	resultMap["DeepLink"] = deepLink
+	resultMap["ReportCreationTime"] = time.Now().Format(time.RFC3339)
+	resultMap["High"] = map[string]int{}
+	resultMap["Medium"] = map[string]int{}
+	resultMap["Low"] = map[string]int{}
+	resultMap["Information"] = map[string]int{}
+
+	severities := map[string]string{"HIGH": "High", "MEDIUM": "Medium", "LOW": "Low", "INFO": "Information"}
+	auditStates := map[string]string{
+		"TO_VERIFY":                "ToVerify",
+		"NOT_EXPLOITABLE":          "NotExploitable",
+		"PROPOSED_NOT_EXPLOITABLE": "ProposedNotExploitable",
+		"CONFIRMED":                "Confirmed",
+		"URGENT":                   "Urgent",
+	}
+	findings := []reporting.Finding{}
+	for _, result := range oneResults {
+		finding := checkmarx.OneResultToFinding(result, deepLink)
+		suppressed := triage.Suppresses(finding)
+		if suppressed {
+			finding.Suppressed = true
+		}
+		findings = append(findings, finding)
+
+		key, ok := severities[strings.ToUpper(result.Severity)]
+		if !ok {
+			key = "Information"
+		}
+		submap := resultMap[key].(map[string]int)
+		submap["Issues"]++
+
+		auditState, ok := auditStates[result.State]
+		if !ok {
+			auditState = "ToVerify"
+		}
+		submap[auditState]++
+
+		if result.State != "NOT_EXPLOITABLE" && !suppressed {
+			submap["NotFalsePositive"]++
+		}
+	}
+	resultMap["Findings"] = findings
+	return resultMap, nil
+}
+
+func oneScanDuration(scan checkmarx.OneScan) string {
+	start, err := time.Parse(time.RFC3339, scan.CreatedAt)
+	if err != nil {
+		return ""
+	}
+	end, err := time.Parse(time.RFC3339, scan.UpdatedAt)
+	if err != nil {
+		return ""
+	}
+	return end.Sub(start).Round(time.Second).String()
+}
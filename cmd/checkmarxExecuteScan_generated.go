@@ -9,7 +9,9 @@ import (
 	"time"
 
 	"github.com/SAP/jenkins-library/pkg/config"
+	piperGithub "github.com/SAP/jenkins-library/pkg/github"
 	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/opentelemetry"
 	"github.com/SAP/jenkins-library/pkg/piperenv"
 	"github.com/SAP/jenkins-library/pkg/splunk"
 	"github.com/SAP/jenkins-library/pkg/telemetry"
@@ -18,30 +20,49 @@ import (
 )
 
 type checkmarxExecuteScanOptions struct {
-	AvoidDuplicateProjectScans    bool   `json:"avoidDuplicateProjectScans,omitempty"`
-	FilterPattern                 string `json:"filterPattern,omitempty"`
-	FullScanCycle                 string `json:"fullScanCycle,omitempty"`
-	FullScansScheduled            bool   `json:"fullScansScheduled,omitempty"`
-	GeneratePdfReport             bool   `json:"generatePdfReport,omitempty"`
-	Incremental                   bool   `json:"incremental,omitempty"`
-	MaxRetries                    int    `json:"maxRetries,omitempty"`
-	Password                      string `json:"password,omitempty"`
-	Preset                        string `json:"preset,omitempty"`
-	ProjectName                   string `json:"projectName,omitempty"`
-	PullRequestName               string `json:"pullRequestName,omitempty"`
-	ServerURL                     string `json:"serverUrl,omitempty"`
-	SourceEncoding                string `json:"sourceEncoding,omitempty"`
-	TeamID                        string `json:"teamId,omitempty"`
-	TeamName                      string `json:"teamName,omitempty"`
-	Username                      string `json:"username,omitempty"`
-	VerifyOnly                    bool   `json:"verifyOnly,omitempty"`
-	VulnerabilityThresholdEnabled bool   `json:"vulnerabilityThresholdEnabled,omitempty"`
-	VulnerabilityThresholdHigh    int    `json:"vulnerabilityThresholdHigh,omitempty"`
-	VulnerabilityThresholdLow     int    `json:"vulnerabilityThresholdLow,omitempty"`
-	VulnerabilityThresholdMedium  int    `json:"vulnerabilityThresholdMedium,omitempty"`
-	VulnerabilityThresholdResult  string `json:"vulnerabilityThresholdResult,omitempty" validate:"possible-values=FAILURE"`
-	VulnerabilityThresholdUnit    string `json:"vulnerabilityThresholdUnit,omitempty"`
-	IsOptimizedAndScheduled       bool   `json:"isOptimizedAndScheduled,omitempty"`
+	APIKey                        string   `json:"apiKey,omitempty" validate:"required_if=Platform CxOne"`
+	ApplicationName               string   `json:"applicationName,omitempty"`
+	AvoidDuplicateProjectScans    bool     `json:"avoidDuplicateProjectScans,omitempty"`
+	Branch                        string   `json:"branch,omitempty"`
+	FilterPattern                 string   `json:"filterPattern,omitempty"`
+	FullScanCycle                 string   `json:"fullScanCycle,omitempty"`
+	FullScansScheduled            bool     `json:"fullScansScheduled,omitempty"`
+	GeneratePdfReport             bool     `json:"generatePdfReport,omitempty"`
+	IamURL                        string   `json:"iamUrl,omitempty"`
+	Incremental                   bool     `json:"incremental,omitempty"`
+	MaxRetries                    int      `json:"maxRetries,omitempty"`
+	Password                      string   `json:"password,omitempty" validate:"required_if=Platform CxSAST"`
+	Platform                      string   `json:"platform,omitempty" validate:"possible-values=CxSAST CxOne"`
+	Preset                        string   `json:"preset,omitempty"`
+	ProjectName                   string   `json:"projectName,omitempty"`
+	PullRequestName               string   `json:"pullRequestName,omitempty"`
+	PullRequestBaseBranch         string   `json:"pullRequestBaseBranch,omitempty"`
+	NewFindingsOnly               bool     `json:"newFindingsOnly,omitempty"`
+	ServerURL                     string   `json:"serverUrl,omitempty"`
+	SourceEncoding                string   `json:"sourceEncoding,omitempty"`
+	Tenant                        string   `json:"tenant,omitempty" validate:"required_if=Platform CxOne"`
+	TeamID                        string   `json:"teamId,omitempty"`
+	TeamName                      string   `json:"teamName,omitempty"`
+	CreateResultIssue             bool     `json:"createResultIssue,omitempty"`
+	IssueAssignees                []string `json:"issueAssignees,omitempty"`
+	IssueLabels                   []string `json:"issueLabels,omitempty"`
+	DecoratePullRequest           bool     `json:"decoratePullRequest,omitempty"`
+	MaxLineComments               int      `json:"maxLineComments,omitempty"`
+	AzureAccessToken              string   `json:"azureAccessToken,omitempty"`
+	GithubAPIURL                  string   `json:"githubApiUrl,omitempty"`
+	GithubToken                   string   `json:"githubToken,omitempty"`
+	Owner                         string   `json:"owner,omitempty"`
+	Repository                    string   `json:"repository,omitempty"`
+	TriageFile                    string   `json:"triageFile,omitempty"`
+	Username                      string   `json:"username,omitempty" validate:"required_if=Platform CxSAST"`
+	VerifyOnly                    bool     `json:"verifyOnly,omitempty"`
+	VulnerabilityThresholdEnabled bool     `json:"vulnerabilityThresholdEnabled,omitempty"`
+	VulnerabilityThresholdHigh    int      `json:"vulnerabilityThresholdHigh,omitempty"`
+	VulnerabilityThresholdLow     int      `json:"vulnerabilityThresholdLow,omitempty"`
+	VulnerabilityThresholdMedium  int      `json:"vulnerabilityThresholdMedium,omitempty"`
+	VulnerabilityThresholdResult  string   `json:"vulnerabilityThresholdResult,omitempty" validate:"possible-values=FAILURE"`
+	VulnerabilityThresholdUnit    string   `json:"vulnerabilityThresholdUnit,omitempty"`
+	IsOptimizedAndScheduled       bool     `json:"isOptimizedAndScheduled,omitempty"`
 }
 
 type checkmarxExecuteScanInflux struct {
@@ -181,6 +202,8 @@ func CheckmarxExecuteScanCommand() *cobra.Command {
 	var influx checkmarxExecuteScanInflux
 	var logCollector *log.CollectorHook
 	var splunkClient *splunk.Splunk
+	var otelClient *opentelemetry.OpenTelemetry
+	var checkRun *piperGithub.StepCheckRun
 	telemetryClient := &telemetry.Telemetry{}
 
 	var createCheckmarxExecuteScanCmd = &cobra.Command{
@@ -196,7 +219,12 @@ This step by default enforces a specific audit baseline for findings and therefo
 * 10% of all Low issues are 'Confirmed' or 'Not Exploitable'
 
 You can adapt above thresholds specifically using the provided configuration parameters and i.e. check for ` + "`" + `absolute` + "`" + `
-thresholds instead of ` + "`" + `percentage` + "`" + ` whereas we strongly recommend you to stay with the defaults provided.`,
+thresholds instead of ` + "`" + `percentage` + "`" + ` whereas we strongly recommend you to stay with the defaults provided.
+
+Besides Checkmarx SAST, the step supports scans with the SAST engine of Checkmarx One (parameter ` + "`" + `platform: CxOne` + "`" + `).
+The same thresholds apply, the Checkmarx One project is scanned on the branch configured via ` + "`" + `branch` + "`" + ` respectively ` + "`" + `pullRequestName` + "`" + `.
+Authentication to Checkmarx One happens via an API key (` + "`" + `checkmarxOneApiKeyCredentialsId` + "`" + `) of the configured ` + "`" + `tenant` + "`" + `.
+The parameter ` + "`" + `newFindingsOnly` + "`" + ` is only supported for Checkmarx SAST.`,
 		PreRunE: func(cmd *cobra.Command, _ []string) error {
 			startTime = time.Now()
 			log.SetStepName(STEP_NAME)
@@ -213,7 +241,10 @@ thresholds instead of ` + "`" + `percentage` + "`" + ` whereas we strongly recom
 				log.SetErrorCategory(log.ErrorConfiguration)
 				return err
 			}
+			log.RegisterSecret(stepConfig.APIKey)
 			log.RegisterSecret(stepConfig.Password)
+			log.RegisterSecret(stepConfig.AzureAccessToken)
+			log.RegisterSecret(stepConfig.GithubToken)
 			log.RegisterSecret(stepConfig.Username)
 
 			if len(GeneralConfig.HookConfig.SentryConfig.Dsn) > 0 {
@@ -227,6 +258,13 @@ thresholds instead of ` + "`" + `percentage` + "`" + ` whereas we strongly recom
 				log.RegisterHook(logCollector)
 			}
 
+			if len(GeneralConfig.HookConfig.OpenTelemetryConfig.Endpoint) > 0 {
+				otelClient = &opentelemetry.OpenTelemetry{}
+				if err := otelClient.Initialize(GeneralConfig.CorrelationID, STEP_NAME, GeneralConfig.HookConfig.OpenTelemetryConfig); err != nil {
+					log.Entry().WithError(err).Warning("failed to initialize OpenTelemetry")
+				}
+			}
+
 			validation, err := validation.New(validation.WithJSONNamesForStructFields(), validation.WithPredefinedErrorMessages())
 			if err != nil {
 				return err
@@ -252,6 +290,12 @@ thresholds instead of ` + "`" + `percentage` + "`" + ` whereas we strongly recom
 				if len(GeneralConfig.HookConfig.SplunkConfig.Dsn) > 0 {
 					splunkClient.Send(telemetryClient.GetData(), logCollector)
 				}
+				if len(GeneralConfig.HookConfig.OpenTelemetryConfig.Endpoint) > 0 {
+					otelClient.Send(telemetryClient.GetData().Attributes())
+				}
+				if checkRun != nil {
+					checkRun.Complete(stepTelemetryData.ErrorCode != "0", log.GetErrorCategory())
+				}
 			}
 			log.DeferExitHandler(handler)
 			defer handler()
@@ -263,6 +307,9 @@ thresholds instead of ` + "`" + `percentage` + "`" + ` whereas we strongly recom
 					GeneralConfig.HookConfig.SplunkConfig.Index,
 					GeneralConfig.HookConfig.SplunkConfig.SendLogs)
 			}
+			if GeneralConfig.HookConfig.GitHubChecksConfig.Enabled {
+				checkRun = piperGithub.StartStepCheckRun(STEP_NAME, GeneralConfig.StageName, GeneralConfig.HookConfig.GitHubChecksConfig)
+			}
 			checkmarxExecuteScan(stepConfig, &stepTelemetryData, &influx)
 			stepTelemetryData.ErrorCode = "0"
 			log.Entry().Info("SUCCESS")
@@ -274,21 +321,40 @@ thresholds instead of ` + "`" + `percentage` + "`" + ` whereas we strongly recom
 }
 
 func addCheckmarxExecuteScanFlags(cmd *cobra.Command, stepConfig *checkmarxExecuteScanOptions) {
+	cmd.Flags().StringVar(&stepConfig.APIKey, "apiKey", os.Getenv("PIPER_apiKey"), "Checkmarx One only: The API key to authenticate, it is exchanged for an access token of the tenant")
+	cmd.Flags().StringVar(&stepConfig.ApplicationName, "applicationName", os.Getenv("PIPER_applicationName"), "Checkmarx One only: The name of the application newly created projects are assigned to")
 	cmd.Flags().BoolVar(&stepConfig.AvoidDuplicateProjectScans, "avoidDuplicateProjectScans", true, "Whether duplicate scans of the same project state shall be avoided or not")
+	cmd.Flags().StringVar(&stepConfig.Branch, "branch", os.Getenv("PIPER_branch"), "Checkmarx One only: The branch the scan is assigned to within the project. In pull request scenarios `pullRequestName` is used, otherwise it defaults to `main`")
 	cmd.Flags().StringVar(&stepConfig.FilterPattern, "filterPattern", `!**/node_modules/**, !**/.xmake/**, !**/*_test.go, !**/vendor/**/*.go, **/*.html, **/*.xml, **/*.go, **/*.py, **/*.js, **/*.scala, **/*.ts`, "The filter pattern used to zip the files relevant for scanning, patterns can be negated by setting an exclamation mark in front i.e. `!test/*.js` would avoid adding any javascript files located in the test directory")
 	cmd.Flags().StringVar(&stepConfig.FullScanCycle, "fullScanCycle", `5`, "Indicates how often a full scan should happen between the incremental scans when activated")
 	cmd.Flags().BoolVar(&stepConfig.FullScansScheduled, "fullScansScheduled", true, "Whether full scans are to be scheduled or not. Should be used in relation with `incremental` and `fullScanCycle`")
 	cmd.Flags().BoolVar(&stepConfig.GeneratePdfReport, "generatePdfReport", true, "Whether to generate a PDF report of the analysis results or not")
+	cmd.Flags().StringVar(&stepConfig.IamURL, "iamUrl", os.Getenv("PIPER_iamUrl"), "Checkmarx One only: The URL of the identity and access management of the Checkmarx One tenant, e.g. https://eu.iam.checkmarx.net. If not set, `serverUrl` is used")
 	cmd.Flags().BoolVar(&stepConfig.Incremental, "incremental", true, "Whether incremental scans are to be applied which optimizes the scan time but might reduce detection capabilities. Therefore full scans are still required from time to time and should be scheduled via `fullScansScheduled` and `fullScanCycle`")
 	cmd.Flags().IntVar(&stepConfig.MaxRetries, "maxRetries", 3, "Maximum number of HTTP request retries upon intermittend connetion interrupts")
 	cmd.Flags().StringVar(&stepConfig.Password, "password", os.Getenv("PIPER_password"), "The password to authenticate")
+	cmd.Flags().StringVar(&stepConfig.Platform, "platform", `CxSAST`, "The Checkmarx platform to scan with, either the Checkmarx SAST server (`CxSAST`) or Checkmarx One (`CxOne`). Both platforms apply the same thresholds and create the same reports.")
 	cmd.Flags().StringVar(&stepConfig.Preset, "preset", os.Getenv("PIPER_preset"), "The preset to use for scanning, if not set explicitly the step will attempt to look up the project's setting based on the availability of `checkmarxCredentialsId`")
 	cmd.Flags().StringVar(&stepConfig.ProjectName, "projectName", os.Getenv("PIPER_projectName"), "The name of the Checkmarx project to scan into")
 	cmd.Flags().StringVar(&stepConfig.PullRequestName, "pullRequestName", os.Getenv("PIPER_pullRequestName"), "Used to supply the name for the newly created PR project branch when being used in pull request scenarios")
+	cmd.Flags().StringVar(&stepConfig.PullRequestBaseBranch, "pullRequestBaseBranch", os.Getenv("PIPER_pullRequestBaseBranch"), "The branch the pull request targets. With `newFindingsOnly` the findings are compared with the latest scan of the project `<projectName>_<pullRequestBaseBranch>`, or of `projectName` if there is no such project. If not set, it is detected from the CI environment.")
+	cmd.Flags().BoolVar(&stepConfig.NewFindingsOnly, "newFindingsOnly", false, "Whether in pull request scenarios only findings which are not contained in the latest scan of the base project are considered for the vulnerability thresholds. The base project is the project of the branch the pull request targets, see `pullRequestBaseBranch`. New, fixed and unchanged findings are listed separately in the report. If `pullRequestName` is not set, the pull request is detected from the CI environment.")
 	cmd.Flags().StringVar(&stepConfig.ServerURL, "serverUrl", os.Getenv("PIPER_serverUrl"), "The URL pointing to the root of the Checkmarx server to be used")
 	cmd.Flags().StringVar(&stepConfig.SourceEncoding, "sourceEncoding", `1`, "The source encoding to be used, if not set explicitly the project's default will be used")
+	cmd.Flags().StringVar(&stepConfig.Tenant, "tenant", os.Getenv("PIPER_tenant"), "Checkmarx One only: The name of the Checkmarx One tenant")
 	cmd.Flags().StringVar(&stepConfig.TeamID, "teamId", os.Getenv("PIPER_teamId"), "The group ID related to your team which can be obtained via the Pipeline Syntax plugin as described in the `Details` section")
 	cmd.Flags().StringVar(&stepConfig.TeamName, "teamName", os.Getenv("PIPER_teamName"), "The full name of the team to assign newly created projects to which is preferred to teamId")
+	cmd.Flags().BoolVar(&stepConfig.CreateResultIssue, "createResultIssue", false, "Whether the step keeps one GitHub issue per group of findings in sync. Issues are created for new findings, updated on later scans and closed when the findings disappear. The issues carry the label `piper-result`, issues without it are not touched. Scans of pull requests do not synchronize issues and failures of the synchronization do not fail the step.")
+	cmd.Flags().StringSliceVar(&stepConfig.IssueAssignees, "issueAssignees", []string{}, "Defines the GitHub users assigned to the issues created via `createResultIssue`.")
+	cmd.Flags().StringSliceVar(&stepConfig.IssueLabels, "issueLabels", []string{}, "Defines the labels of the issues created via `createResultIssue`.")
+	cmd.Flags().BoolVar(&stepConfig.DecoratePullRequest, "decoratePullRequest", false, "Whether the scan report is published as comments in the pull request the pipeline is running for. Findings within the changes of the pull request are commented on their line.")
+	cmd.Flags().IntVar(&stepConfig.MaxLineComments, "maxLineComments", 20, "Defines the maximum number of findings within the changes of the pull request which are commented on their line when `decoratePullRequest` is active. Line comments are disabled with 0.")
+	cmd.Flags().StringVar(&stepConfig.AzureAccessToken, "azureAccessToken", os.Getenv("PIPER_azureAccessToken"), "Token to authenticate to Azure DevOps when decorating pull requests of Azure Repos. The environment variable `SYSTEM_ACCESSTOKEN` is used if empty.")
+	cmd.Flags().StringVar(&stepConfig.GithubAPIURL, "githubApiUrl", `https://api.github.com`, "Set the GitHub API URL.")
+	cmd.Flags().StringVar(&stepConfig.GithubToken, "githubToken", os.Getenv("PIPER_githubToken"), "GitHub personal access token as per https://help.github.com/en/github/authenticating-to-github/creating-a-personal-access-token-for-the-command-line")
+	cmd.Flags().StringVar(&stepConfig.Owner, "owner", os.Getenv("PIPER_owner"), "Set the GitHub organization.")
+	cmd.Flags().StringVar(&stepConfig.Repository, "repository", os.Getenv("PIPER_repository"), "Set the GitHub repository.")
+	cmd.Flags().StringVar(&stepConfig.TriageFile, "triageFile", `.pipeline/triage.yml`, "Path of the triage file shared by all scan steps. Findings suppressed via query name or CWE (optionally restricted to files) do not count towards the vulnerability thresholds, expired suppressions are flagged in the report. The file is ignored if it does not exist.")
 	cmd.Flags().StringVar(&stepConfig.Username, "username", os.Getenv("PIPER_username"), "The username to authenticate")
 	cmd.Flags().BoolVar(&stepConfig.VerifyOnly, "verifyOnly", false, "Whether the step shall only apply verification checks or whether it does a full scan and check cycle")
 	cmd.Flags().BoolVar(&stepConfig.VulnerabilityThresholdEnabled, "vulnerabilityThresholdEnabled", true, "Whether the thresholds are enabled or not. If enabled the build will be set to `vulnerabilityThresholdResult` in case a specific threshold value is exceeded")
@@ -299,10 +365,8 @@ func addCheckmarxExecuteScanFlags(cmd *cobra.Command, stepConfig *checkmarxExecu
 	cmd.Flags().StringVar(&stepConfig.VulnerabilityThresholdUnit, "vulnerabilityThresholdUnit", `percentage`, "The unit for the threshold to apply.")
 	cmd.Flags().BoolVar(&stepConfig.IsOptimizedAndScheduled, "isOptimizedAndScheduled", false, "Whether the pipeline runs in optimized mode and the current execution is a scheduled one")
 
-	cmd.MarkFlagRequired("password")
 	cmd.MarkFlagRequired("projectName")
 	cmd.MarkFlagRequired("serverUrl")
-	cmd.MarkFlagRequired("username")
 }
 
 // retrieve step metadata
@@ -317,11 +381,42 @@ func checkmarxExecuteScanMetadata() config.StepData {
 			Inputs: config.StepInputs{
 				Secrets: []config.StepSecrets{
 					{Name: "checkmarxCredentialsId", Description: "Jenkins 'Username with password' credentials ID containing username and password to communicate with the Checkmarx backend.", Type: "jenkins"},
+					{Name: "checkmarxOneApiKeyCredentialsId", Description: "Jenkins 'Secret text' credentials ID containing the API key to communicate with the Checkmarx One backend.", Type: "jenkins"},
+					{Name: "githubTokenCredentialsId", Description: "Jenkins 'Secret text' credentials ID containing token to authenticate to GitHub.", Type: "jenkins"},
 				},
 				Resources: []config.StepResources{
 					{Name: "checkmarx", Type: "stash"},
 				},
 				Parameters: []config.StepParameters{
+					{
+						Name: "apiKey",
+						ResourceRef: []config.ResourceReference{
+							{
+								Name: "checkmarxOneApiKeyCredentialsId",
+								Type: "secret",
+							},
+
+							{
+								Name:    "checkmarxOneVaultSecretName",
+								Type:    "vaultSecret",
+								Default: "checkmarxOne",
+							},
+						},
+						Scope:     []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:      "string",
+						Mandatory: false,
+						Aliases:   []config.Alias{},
+						Default:   os.Getenv("PIPER_apiKey"),
+					},
+					{
+						Name:        "applicationName",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     os.Getenv("PIPER_applicationName"),
+					},
 					{
 						Name:        "avoidDuplicateProjectScans",
 						ResourceRef: []config.ResourceReference{},
@@ -331,6 +426,15 @@ func checkmarxExecuteScanMetadata() config.StepData {
 						Aliases:     []config.Alias{},
 						Default:     true,
 					},
+					{
+						Name:        "branch",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     os.Getenv("PIPER_branch"),
+					},
 					{
 						Name:        "filterPattern",
 						ResourceRef: []config.ResourceReference{},
@@ -367,6 +471,15 @@ func checkmarxExecuteScanMetadata() config.StepData {
 						Aliases:     []config.Alias{},
 						Default:     true,
 					},
+					{
+						Name:        "iamUrl",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"GENERAL", "PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     os.Getenv("PIPER_iamUrl"),
+					},
 					{
 						Name:        "incremental",
 						ResourceRef: []config.ResourceReference{},
@@ -402,10 +515,19 @@ func checkmarxExecuteScanMetadata() config.StepData {
 						},
 						Scope:     []string{"PARAMETERS", "STAGES", "STEPS"},
 						Type:      "string",
-						Mandatory: true,
+						Mandatory: false,
 						Aliases:   []config.Alias{},
 						Default:   os.Getenv("PIPER_password"),
 					},
+					{
+						Name:        "platform",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"GENERAL", "PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     `CxSAST`,
+					},
 					{
 						Name:        "preset",
 						ResourceRef: []config.ResourceReference{},
@@ -433,6 +555,24 @@ func checkmarxExecuteScanMetadata() config.StepData {
 						Aliases:     []config.Alias{},
 						Default:     os.Getenv("PIPER_pullRequestName"),
 					},
+					{
+						Name:        "pullRequestBaseBranch",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     os.Getenv("PIPER_pullRequestBaseBranch"),
+					},
+					{
+						Name:        "newFindingsOnly",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "bool",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     false,
+					},
 					{
 						Name:        "serverUrl",
 						ResourceRef: []config.ResourceReference{},
@@ -451,6 +591,15 @@ func checkmarxExecuteScanMetadata() config.StepData {
 						Aliases:     []config.Alias{},
 						Default:     `1`,
 					},
+					{
+						Name:        "tenant",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"GENERAL", "PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     os.Getenv("PIPER_tenant"),
+					},
 					{
 						Name:        "teamId",
 						ResourceRef: []config.ResourceReference{},
@@ -469,6 +618,126 @@ func checkmarxExecuteScanMetadata() config.StepData {
 						Aliases:     []config.Alias{},
 						Default:     os.Getenv("PIPER_teamName"),
 					},
+					{
+						Name:        "createResultIssue",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"GENERAL", "PARAMETERS", "STAGES", "STEPS"},
+						Type:        "bool",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     false,
+					},
+					{
+						Name:        "issueAssignees",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "[]string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     []string{},
+					},
+					{
+						Name:        "issueLabels",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "[]string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     []string{},
+					},
+					{
+						Name:        "decoratePullRequest",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "bool",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     false,
+					},
+					{
+						Name:        "maxLineComments",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "int",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     20,
+					},
+					{
+						Name:        "azureAccessToken",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     os.Getenv("PIPER_azureAccessToken"),
+					},
+					{
+						Name:        "githubApiUrl",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"GENERAL", "PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     `https://api.github.com`,
+					},
+					{
+						Name: "githubToken",
+						ResourceRef: []config.ResourceReference{
+							{
+								Name: "githubTokenCredentialsId",
+								Type: "secret",
+							},
+
+							{
+								Name:    "githubVaultSecretName",
+								Type:    "vaultSecret",
+								Default: "github",
+							},
+						},
+						Scope:     []string{"GENERAL", "PARAMETERS", "STAGES", "STEPS"},
+						Type:      "string",
+						Mandatory: false,
+						Aliases:   []config.Alias{{Name: "access_token"}},
+						Default:   os.Getenv("PIPER_githubToken"),
+					},
+					{
+						Name: "owner",
+						ResourceRef: []config.ResourceReference{
+							{
+								Name:  "commonPipelineEnvironment",
+								Param: "github/owner",
+							},
+						},
+						Scope:     []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:      "string",
+						Mandatory: false,
+						Aliases:   []config.Alias{{Name: "githubOrg"}},
+						Default:   os.Getenv("PIPER_owner"),
+					},
+					{
+						Name: "repository",
+						ResourceRef: []config.ResourceReference{
+							{
+								Name:  "commonPipelineEnvironment",
+								Param: "github/repository",
+							},
+						},
+						Scope:     []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:      "string",
+						Mandatory: false,
+						Aliases:   []config.Alias{{Name: "githubRepo"}},
+						Default:   os.Getenv("PIPER_repository"),
+					},
+					{
+						Name:        "triageFile",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"GENERAL", "PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     `.pipeline/triage.yml`,
+					},
 					{
 						Name: "username",
 						ResourceRef: []config.ResourceReference{
@@ -486,7 +755,7 @@ func checkmarxExecuteScanMetadata() config.StepData {
 						},
 						Scope:     []string{"PARAMETERS", "STAGES", "STEPS"},
 						Type:      "string",
-						Mandatory: true,
+						Mandatory: false,
 						Aliases:   []config.Alias{},
 						Default:   os.Getenv("PIPER_username"),
 					},
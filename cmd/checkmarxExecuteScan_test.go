@@ -16,6 +16,7 @@ import (
 	"github.com/bmatcuk/doublestar"
 
 	"github.com/SAP/jenkins-library/pkg/checkmarx"
+	"github.com/SAP/jenkins-library/pkg/reporting"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -475,7 +476,7 @@ func TestGetDetailedResults(t *testing.T) {
 		}
 		// clean up tmp dir
 		defer os.RemoveAll(dir)
-		result, err := getDetailedResults(sys, filepath.Join(dir, "abc.xml"), 2635, newCheckmarxExecuteScanUtilsMock())
+		result, err := getDetailedResults(sys, filepath.Join(dir, "abc.xml"), 2635, newCheckmarxExecuteScanUtilsMock(), nil)
 		assert.NoError(t, err, "error occurred but none expected")
 		assert.Equal(t, "2", result["ProjectId"], "Project ID incorrect")
 		assert.Equal(t, "Project 1", result["ProjectName"], "Project name incorrect")
@@ -483,6 +484,15 @@ func TestGetDetailedResults(t *testing.T) {
 		assert.Equal(t, 2, result["High"].(map[string]int)["NotFalsePositive"], "Number of High NotFalsePositive issues incorrect")
 		assert.Equal(t, 1, result["Medium"].(map[string]int)["Issues"], "Number of Medium issues incorrect")
 		assert.Equal(t, 0, result["Medium"].(map[string]int)["NotFalsePositive"], "Number of Medium NotFalsePositive issues incorrect")
+
+		triage := &reporting.Triage{Suppressions: []reporting.Suppression{{ID: "CWE-89", Component: "bookstore/**", Justification: "parameterized by the ORM"}}}
+		result, err = getDetailedResults(sys, filepath.Join(dir, "abc.xml"), 2635, newCheckmarxExecuteScanUtilsMock(), triage)
+		assert.NoError(t, err, "error occurred but none expected")
+		assert.Equal(t, 2, result["High"].(map[string]int)["Issues"], "Number of High issues incorrect")
+		assert.Equal(t, 0, result["High"].(map[string]int)["NotFalsePositive"], "Number of High NotFalsePositive issues incorrect")
+		if findings := result["Findings"].([]reporting.Finding); assert.Len(t, findings, 5) {
+			assert.True(t, findings[0].Suppressed)
+		}
 	})
 
 	t.Run("error on write file", func(t *testing.T) {
@@ -515,11 +525,114 @@ func TestGetDetailedResults(t *testing.T) {
 		defer os.RemoveAll(dir)
 		utils := newCheckmarxExecuteScanUtilsMock()
 		utils.errorOnWriteFile = true
-		_, err = getDetailedResults(sys, filepath.Join(dir, "abc.xml"), 2635, utils)
+		_, err = getDetailedResults(sys, filepath.Join(dir, "abc.xml"), 2635, utils, nil)
 		assert.EqualError(t, err, "failed to write file: error on WriteFile")
 	})
 }
 
+type systemMockWithBaseScan struct {
+	*systemMock
+}
+
+func (sys *systemMockWithBaseScan) GetScans(int) ([]checkmarx.ScanStatus, error) {
+	return []checkmarx.ScanStatus{{ID: 21, Status: checkmarx.Status{Name: "Failed"}}, {ID: 20, Status: checkmarx.Status{Name: "Finished"}}}, nil
+}
+
+type systemMockWithBranchProjects struct {
+	*systemMockWithBaseScan
+	projects       map[string]int
+	scansOfProject []int
+}
+
+func (sys *systemMockWithBranchProjects) GetProjectsByNameAndTeam(projectName, teamID string) ([]checkmarx.Project, error) {
+	if id, ok := sys.projects[projectName]; ok {
+		return []checkmarx.Project{{ID: id, Name: projectName, TeamID: teamID}}, nil
+	}
+	return []checkmarx.Project{}, nil
+}
+
+func (sys *systemMockWithBranchProjects) GetScans(projectID int) ([]checkmarx.ScanStatus, error) {
+	sys.scansOfProject = append(sys.scansOfProject, projectID)
+	return sys.systemMockWithBaseScan.GetScans(projectID)
+}
+
+func TestCompareWithBaseProject(t *testing.T) {
+	t.Parallel()
+	config := checkmarxExecuteScanOptions{ProjectName: "Test", TeamID: "16", PullRequestName: "PR-17", NewFindingsOnly: true}
+	baseReport := []byte(`<?xml version="1.0" encoding="utf-8"?>
+		<CxXMLResults ProjectName="Test">
+		<Query cweId="89" name="SQL_Injection" Severity="High">
+			<Result FileName="bookstore/Login.cs" Line="170" FalsePositive="False" Severity="High" state="0"/>
+		</Query>
+		<Query cweId="79" name="Reflected_XSS" Severity="Medium">
+			<Result FileName="bookstore/Search.cs" Line="12" FalsePositive="False" Severity="Medium" state="0"/>
+		</Query>
+		</CxXMLResults>`)
+	results := map[string]interface{}{"Findings": []reporting.Finding{
+		{RuleID: "SQL_Injection", Severity: reporting.SeverityHigh, Location: "bookstore/Login.cs", Line: 179},
+		{RuleID: "SQL_Injection", Severity: reporting.SeverityHigh, Location: "bookstore/Order.cs", Line: 20},
+		{RuleID: "Path_Traversal", Severity: reporting.SeverityMedium, Location: "bookstore/Upload.cs", Line: 8, Suppressed: true},
+	}}
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		sys := &systemMockWithBaseScan{systemMock: &systemMock{response: baseReport}}
+
+		delta, err := compareWithBaseProject(config, sys, results, nil)
+		if assert.NoError(t, err) {
+			assert.Equal(t, "Test", delta.Base)
+			assert.Len(t, delta.New, 2)
+			assert.Len(t, delta.Unchanged, 1)
+			if assert.Len(t, delta.Fixed, 1) {
+				assert.Equal(t, "Reflected_XSS", delta.Fixed[0].RuleID)
+			}
+		}
+
+		newResults := newFindingsResults(results, delta.New)
+		assert.Equal(t, map[string]int{"Issues": 1, "NotFalsePositive": 1, "ToVerify": 1}, newResults["High"])
+		assert.Equal(t, map[string]int{"Issues": 1, "NotExploitable": 1}, newResults["Medium"])
+		assert.Equal(t, map[string]int{}, newResults["Low"])
+		assert.Equal(t, results["Findings"], newResults["Findings"])
+	})
+
+	t.Run("project of base branch", func(t *testing.T) {
+		t.Parallel()
+		branchConfig := config
+		branchConfig.PullRequestBaseBranch = "release-1.0"
+		sys := &systemMockWithBranchProjects{systemMockWithBaseScan: &systemMockWithBaseScan{systemMock: &systemMock{response: baseReport}}, projects: map[string]int{"Test": 1, "Test_release-1.0": 2}}
+
+		delta, err := compareWithBaseProject(branchConfig, sys, results, nil)
+		if assert.NoError(t, err) {
+			assert.Equal(t, "Test_release-1.0", delta.Base)
+			assert.Equal(t, []int{2}, sys.scansOfProject)
+		}
+	})
+
+	t.Run("base branch without project", func(t *testing.T) {
+		t.Parallel()
+		branchConfig := config
+		branchConfig.PullRequestBaseBranch = "main"
+		sys := &systemMockWithBranchProjects{systemMockWithBaseScan: &systemMockWithBaseScan{systemMock: &systemMock{response: baseReport}}, projects: map[string]int{"Test": 1}}
+
+		delta, err := compareWithBaseProject(branchConfig, sys, results, nil)
+		if assert.NoError(t, err) {
+			assert.Equal(t, "Test", delta.Base)
+			assert.Equal(t, []int{1}, sys.scansOfProject)
+		}
+	})
+
+	t.Run("no finished scan of base project", func(t *testing.T) {
+		t.Parallel()
+		sys := &systemMock{}
+
+		delta, err := compareWithBaseProject(config, sys, results, nil)
+		if assert.NoError(t, err) {
+			assert.Len(t, delta.New, 3)
+			assert.Empty(t, delta.Fixed)
+		}
+	})
+}
+
 func TestRunScan(t *testing.T) {
 	t.Parallel()
 
@@ -1015,3 +1128,159 @@ func TestPreventInfiniteLoop(t *testing.T) {
 
 	assert.Equal(t, "abc", utils.GetWorkspace(), "Wrong workspace has been loaded")
 }
+
+type oneSystemMock struct {
+	project        checkmarx.OneProject
+	previousScans  []checkmarx.OneScan
+	results        []checkmarx.OneResult
+	createdProject string
+	application    string
+	uploaded       bool
+	scannedBranch  string
+	incremental    bool
+	scansError     error
+}
+
+func (sys *oneSystemMock) GetApplicationByName(applicationName string) (checkmarx.OneApplication, error) {
+	if applicationName == "Bookstore" {
+		return checkmarx.OneApplication{ID: "app-1", Name: applicationName}, nil
+	}
+	return checkmarx.OneApplication{}, nil
+}
+
+func (sys *oneSystemMock) GetProjectByName(string) (checkmarx.OneProject, error) {
+	return sys.project, nil
+}
+
+func (sys *oneSystemMock) CreateProject(projectName, applicationID string) (checkmarx.OneProject, error) {
+	sys.createdProject = projectName
+	sys.application = applicationID
+	return checkmarx.OneProject{ID: "prj-new", Name: projectName}, nil
+}
+
+func (sys *oneSystemMock) UploadProjectSourceCode(string) (string, error) {
+	sys.uploaded = true
+	return "https://upload.example.com/4711", nil
+}
+
+func (sys *oneSystemMock) ScanProject(projectID, uploadURL, branch, preset string, incremental bool) (checkmarx.OneScan, error) {
+	sys.scannedBranch = branch
+	sys.incremental = incremental
+	return checkmarx.OneScan{ID: "scan-new", Status: "Queued", ProjectID: projectID}, nil
+}
+
+func (sys *oneSystemMock) GetScan(scanID string) (checkmarx.OneScan, error) {
+	return checkmarx.OneScan{ID: scanID, Status: "Completed", CreatedAt: "2022-03-01T10:00:00Z", UpdatedAt: "2022-03-01T10:02:30Z"}, nil
+}
+
+func (sys *oneSystemMock) GetScans(string, string) ([]checkmarx.OneScan, error) {
+	return sys.previousScans, sys.scansError
+}
+
+func (sys *oneSystemMock) GetResults(string) ([]checkmarx.OneResult, error) {
+	return sys.results, nil
+}
+
+func (sys *oneSystemMock) DeepLink(projectID, scanID string) string {
+	return fmt.Sprintf("https://cxone.example.com/results/%v/%v/sast", projectID, scanID)
+}
+
+func TestRunScanOne(t *testing.T) {
+	incrementalScan := checkmarx.OneScan{ID: "scan-1", Status: "Completed", Metadata: checkmarx.OneScanMetadata{Configs: []checkmarx.OneScanConfig{{Type: "sast", Value: map[string]string{"incremental": "true"}}}}}
+	results := []checkmarx.OneResult{
+		{QueryName: "SQL_Injection", Severity: "HIGH", State: "TO_VERIFY", Nodes: []checkmarx.OneResultNode{{FileName: "/src/main/java/Dao.java", Line: 42}}},
+		{QueryName: "Reflected_XSS", Severity: "MEDIUM", State: "NOT_EXPLOITABLE"},
+		{QueryName: "Path_Traversal", Severity: "MEDIUM", State: "CONFIRMED"},
+	}
+
+	newWorkspace := func(t *testing.T) string {
+		workspace, err := ioutil.TempDir("", "cxone")
+		if err != nil {
+			t.Fatal("Failed to create temporary workspace directory")
+		}
+		err = ioutil.WriteFile(filepath.Join(workspace, "abcd.go"), []byte("abcd.go"), 0700)
+		assert.NoError(t, err)
+		return workspace
+	}
+	defer os.Remove("checkmarxExecuteScan_reports.json")
+	defer os.Remove("checkmarxExecuteScan_links.json")
+
+	t.Run("new project in application", func(t *testing.T) {
+		workspace := newWorkspace(t)
+		defer os.RemoveAll(workspace)
+		utilsMock := newCheckmarxExecuteScanUtilsMock()
+		utilsMock.workspace = workspace
+		sys := &oneSystemMock{results: results, previousScans: []checkmarx.OneScan{incrementalScan}}
+		options := checkmarxExecuteScanOptions{Platform: "CxOne", ProjectName: "bookstore-ui", ApplicationName: "Bookstore", FilterPattern: "**/abcd.go", FullScanCycle: "2", Incremental: true, FullScansScheduled: true, VulnerabilityThresholdUnit: "absolute", VulnerabilityThresholdHigh: 1, VulnerabilityThresholdMedium: 1, VulnerabilityThresholdEnabled: true}
+		influx := checkmarxExecuteScanInflux{}
+
+		err := runScanOne(options, sys, &influx, utilsMock)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "bookstore-ui", sys.createdProject)
+		assert.Equal(t, "app-1", sys.application)
+		assert.True(t, sys.uploaded)
+		assert.Equal(t, "main", sys.scannedBranch)
+		assert.False(t, sys.incremental, "every second scan is expected to be a full scan")
+		assert.Equal(t, 1, influx.checkmarx_data.fields.high_not_false_postive)
+		assert.Equal(t, 1, influx.checkmarx_data.fields.medium_not_false_postive)
+		assert.Equal(t, 1, influx.checkmarx_data.fields.medium_not_exploitable)
+		assert.Equal(t, "scan-new", influx.checkmarx_data.fields.scan_id)
+		assert.Equal(t, "https://cxone.example.com/results/prj-new/scan-new/sast", influx.checkmarx_data.fields.deep_link)
+		assert.Equal(t, "2m30s", influx.checkmarx_data.fields.scan_time)
+		reports, _ := filepath.Glob(filepath.Join(workspace, "CxOneResults_*.json"))
+		assert.Len(t, reports, 1)
+	})
+
+	t.Run("pull request violating thresholds", func(t *testing.T) {
+		workspace := newWorkspace(t)
+		defer os.RemoveAll(workspace)
+		utilsMock := newCheckmarxExecuteScanUtilsMock()
+		utilsMock.workspace = workspace
+		sys := &oneSystemMock{project: checkmarx.OneProject{ID: "prj-1", Name: "bookstore-ui"}, results: results}
+		options := checkmarxExecuteScanOptions{Platform: "CxOne", ProjectName: "bookstore-ui", PullRequestName: "PR-17", Branch: "develop", FilterPattern: "**/abcd.go", FullScanCycle: "5", Incremental: true, VulnerabilityThresholdUnit: "absolute", VulnerabilityThresholdEnabled: true, VulnerabilityThresholdResult: "FAILURE"}
+
+		err := runScanOne(options, sys, &checkmarxExecuteScanInflux{}, utilsMock)
+
+		assert.EqualError(t, err, "the project is not compliant - see report for details")
+		assert.Empty(t, sys.createdProject)
+		assert.Equal(t, "PR-17", sys.scannedBranch)
+		assert.True(t, sys.incremental)
+	})
+
+	t.Run("verify only", func(t *testing.T) {
+		workspace := newWorkspace(t)
+		defer os.RemoveAll(workspace)
+		utilsMock := newCheckmarxExecuteScanUtilsMock()
+		utilsMock.workspace = workspace
+		sys := &oneSystemMock{project: checkmarx.OneProject{ID: "prj-1", Name: "bookstore-ui"}, previousScans: []checkmarx.OneScan{{ID: "scan-2", Status: "Failed"}, incrementalScan}}
+		options := checkmarxExecuteScanOptions{Platform: "CxOne", ProjectName: "bookstore-ui", VerifyOnly: true, VulnerabilityThresholdUnit: "absolute", VulnerabilityThresholdEnabled: true}
+		influx := checkmarxExecuteScanInflux{}
+
+		err := runScanOne(options, sys, &influx, utilsMock)
+
+		assert.NoError(t, err)
+		assert.False(t, sys.uploaded)
+		assert.Equal(t, "scan-1", influx.checkmarx_data.fields.scan_id)
+		assert.Equal(t, "Incremental", influx.checkmarx_data.fields.scan_type)
+	})
+
+	t.Run("unknown application", func(t *testing.T) {
+		sys := &oneSystemMock{}
+		options := checkmarxExecuteScanOptions{Platform: "CxOne", ProjectName: "bookstore-ui", ApplicationName: "Library"}
+
+		err := runScanOne(options, sys, &checkmarxExecuteScanInflux{}, newCheckmarxExecuteScanUtilsMock())
+
+		assert.EqualError(t, err, "application Library does not exist")
+	})
+
+	t.Run("error loading previous scans", func(t *testing.T) {
+		sys := &oneSystemMock{project: checkmarx.OneProject{ID: "prj-1", Name: "bookstore-ui"}, scansError: fmt.Errorf("service unavailable")}
+		options := checkmarxExecuteScanOptions{Platform: "CxOne", ProjectName: "bookstore-ui", FullScanCycle: "5"}
+
+		err := runScanOne(options, sys, &checkmarxExecuteScanInflux{}, newCheckmarxExecuteScanUtilsMock())
+
+		assert.EqualError(t, err, "failed to load scans of project bookstore-ui: service unavailable")
+		assert.False(t, sys.uploaded)
+	})
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/SAP/jenkins-library/pkg/cnbutils/bindings"
 	"github.com/SAP/jenkins-library/pkg/cnbutils/project"
 	"github.com/SAP/jenkins-library/pkg/command"
+	"github.com/SAP/jenkins-library/pkg/cyclonedx"
 	"github.com/SAP/jenkins-library/pkg/docker"
 	piperhttp "github.com/SAP/jenkins-library/pkg/http"
 	"github.com/SAP/jenkins-library/pkg/log"
@@ -30,6 +31,7 @@ const (
 	builderPath  = "/cnb/lifecycle/builder"
 	exporterPath = "/cnb/lifecycle/exporter"
 	platformPath = "/tmp/platform"
+	cnbBOMFile   = "bom-image.xml"
 )
 
 type cnbBuildUtilsBundle struct {
@@ -429,5 +431,17 @@ func runCnbBuild(config *cnbBuildOptions, telemetryData *telemetry.CustomData, u
 		return errors.Wrapf(err, "execution of '%s' failed", exporterPath)
 	}
 
+	commonPipelineEnvironment.custom.sbomFiles = config.SbomFiles
+	if config.CreateBOM {
+		dockerConfigDir := ""
+		if len(dockerConfigFile) > 0 {
+			dockerConfigDir = filepath.Dir(dockerConfigFile)
+		}
+		if err := cyclonedx.CreateImageBOM("registry:"+targets[0], cnbBOMFile, dockerConfigDir, utils); err != nil {
+			return err
+		}
+		commonPipelineEnvironment.custom.sbomFiles = cyclonedx.AppendSBOMFile(config.SbomFiles, cnbBOMFile)
+	}
+
 	return nil
 }
@@ -9,7 +9,9 @@ import (
 	"time"
 
 	"github.com/SAP/jenkins-library/pkg/config"
+	piperGithub "github.com/SAP/jenkins-library/pkg/github"
 	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/opentelemetry"
 	"github.com/SAP/jenkins-library/pkg/piperenv"
 	"github.com/SAP/jenkins-library/pkg/splunk"
 	"github.com/SAP/jenkins-library/pkg/telemetry"
@@ -29,6 +31,8 @@ type cnbBuildOptions struct {
 	CustomTLSCertificateLinks []string               `json:"customTlsCertificateLinks,omitempty"`
 	AdditionalTags            []string               `json:"additionalTags,omitempty"`
 	Bindings                  map[string]interface{} `json:"bindings,omitempty"`
+	CreateBOM                 bool                   `json:"createBOM,omitempty"`
+	SbomFiles                 []string               `json:"sbomFiles,omitempty"`
 }
 
 type cnbBuildCommonPipelineEnvironment struct {
@@ -36,6 +40,9 @@ type cnbBuildCommonPipelineEnvironment struct {
 		registryURL  string
 		imageNameTag string
 	}
+	custom struct {
+		sbomFiles []string
+	}
 }
 
 func (p *cnbBuildCommonPipelineEnvironment) persist(path, resourceName string) {
@@ -46,6 +53,7 @@ func (p *cnbBuildCommonPipelineEnvironment) persist(path, resourceName string) {
 	}{
 		{category: "container", name: "registryUrl", value: p.container.registryURL},
 		{category: "container", name: "imageNameTag", value: p.container.imageNameTag},
+		{category: "custom", name: "sbomFiles", value: p.custom.sbomFiles},
 	}
 
 	errCount := 0
@@ -71,6 +79,8 @@ func CnbBuildCommand() *cobra.Command {
 	var commonPipelineEnvironment cnbBuildCommonPipelineEnvironment
 	var logCollector *log.CollectorHook
 	var splunkClient *splunk.Splunk
+	var otelClient *opentelemetry.OpenTelemetry
+	var checkRun *piperGithub.StepCheckRun
 	telemetryClient := &telemetry.Telemetry{}
 
 	var createCnbBuildCmd = &cobra.Command{
@@ -106,6 +116,13 @@ func CnbBuildCommand() *cobra.Command {
 				log.RegisterHook(logCollector)
 			}
 
+			if len(GeneralConfig.HookConfig.OpenTelemetryConfig.Endpoint) > 0 {
+				otelClient = &opentelemetry.OpenTelemetry{}
+				if err := otelClient.Initialize(GeneralConfig.CorrelationID, STEP_NAME, GeneralConfig.HookConfig.OpenTelemetryConfig); err != nil {
+					log.Entry().WithError(err).Warning("failed to initialize OpenTelemetry")
+				}
+			}
+
 			validation, err := validation.New(validation.WithJSONNamesForStructFields(), validation.WithPredefinedErrorMessages())
 			if err != nil {
 				return err
@@ -131,6 +148,12 @@ func CnbBuildCommand() *cobra.Command {
 				if len(GeneralConfig.HookConfig.SplunkConfig.Dsn) > 0 {
 					splunkClient.Send(telemetryClient.GetData(), logCollector)
 				}
+				if len(GeneralConfig.HookConfig.OpenTelemetryConfig.Endpoint) > 0 {
+					otelClient.Send(telemetryClient.GetData().Attributes())
+				}
+				if checkRun != nil {
+					checkRun.Complete(stepTelemetryData.ErrorCode != "0", log.GetErrorCategory())
+				}
 			}
 			log.DeferExitHandler(handler)
 			defer handler()
@@ -142,6 +165,9 @@ func CnbBuildCommand() *cobra.Command {
 					GeneralConfig.HookConfig.SplunkConfig.Index,
 					GeneralConfig.HookConfig.SplunkConfig.SendLogs)
 			}
+			if GeneralConfig.HookConfig.GitHubChecksConfig.Enabled {
+				checkRun = piperGithub.StartStepCheckRun(STEP_NAME, GeneralConfig.StageName, GeneralConfig.HookConfig.GitHubChecksConfig)
+			}
 			cnbBuild(stepConfig, &stepTelemetryData, &commonPipelineEnvironment)
 			stepTelemetryData.ErrorCode = "0"
 			log.Entry().Info("SUCCESS")
@@ -164,6 +190,9 @@ func addCnbBuildFlags(cmd *cobra.Command, stepConfig *cnbBuildOptions) {
 	cmd.Flags().StringSliceVar(&stepConfig.CustomTLSCertificateLinks, "customTlsCertificateLinks", []string{}, "List containing download links of custom TLS certificates. This is required to ensure trusted connections to registries with custom certificates.")
 	cmd.Flags().StringSliceVar(&stepConfig.AdditionalTags, "additionalTags", []string{}, "List of tags which will be pushed to the registry (additionally to the provided `containerImageTag`), e.g. \"latest\".")
 
+	cmd.Flags().BoolVar(&stepConfig.CreateBOM, "createBOM", false, "Creates a CycloneDX SBOM of the built container image using [syft](https://github.com/anchore/syft). The syft executable needs to be available in the container of the step, e.g. by using a custom image.")
+	cmd.Flags().StringSliceVar(&stepConfig.SbomFiles, "sbomFiles", []string{}, "Paths of the CycloneDX SBOMs created by previous build steps. The step adds the SBOMs it creates and passes the list on via the common pipeline environment.")
+
 	cmd.MarkFlagRequired("containerImageName")
 	cmd.MarkFlagRequired("containerImageTag")
 	cmd.MarkFlagRequired("containerRegistryUrl")
@@ -307,6 +336,29 @@ func cnbBuildMetadata() config.StepData {
 						Mandatory:   false,
 						Aliases:     []config.Alias{},
 					},
+					{
+						Name:        "createBOM",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"GENERAL", "STEPS", "STAGES", "PARAMETERS"},
+						Type:        "bool",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     false,
+					},
+					{
+						Name: "sbomFiles",
+						ResourceRef: []config.ResourceReference{
+							{
+								Name:  "commonPipelineEnvironment",
+								Param: "custom/sbomFiles",
+							},
+						},
+						Scope:     []string{"STEPS", "STAGES", "PARAMETERS"},
+						Type:      "[]string",
+						Mandatory: false,
+						Aliases:   []config.Alias{},
+						Default:   []string{},
+					},
 				},
 			},
 			Containers: []config.Container{
@@ -320,6 +372,7 @@ func cnbBuildMetadata() config.StepData {
 						Parameters: []map[string]interface{}{
 							{"Name": "container/registryUrl"},
 							{"Name": "container/imageNameTag"},
+							{"Name": "custom/sbomFiles"},
 						},
 					},
 				},
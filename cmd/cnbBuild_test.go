@@ -166,6 +166,33 @@ func TestRunCnbBuild(t *testing.T) {
 		assert.Equal(t, []string{fmt.Sprintf("%s/%s:%s", registry, config.ContainerImageName, config.ContainerImageTag)}, runner.Calls[2].Params)
 	})
 
+	t.Run("success case (createBOM)", func(t *testing.T) {
+		t.Parallel()
+		registry := "some-registry"
+		config := cnbBuildOptions{
+			ContainerImageName:   "my-image",
+			ContainerImageTag:    "0.0.1",
+			ContainerRegistryURL: registry,
+			DockerConfigJSON:     "/path/to/config.json",
+			CreateBOM:            true,
+			SbomFiles:            []string{"bom.xml"},
+		}
+		cpe := cnbBuildCommonPipelineEnvironment{}
+
+		utils := newCnbBuildTestsUtils()
+		utils.FilesMock.AddFile(config.DockerConfigJSON, []byte(`{"auths":{"my-registry":{"auth":"dXNlcjpwYXNz"}}}`))
+		addBuilderFiles(&utils)
+
+		err := runCnbBuild(&config, &telemetry.CustomData{}, &utils, &cpe, &kanikoMockClient{})
+
+		assert.NoError(t, err)
+		runner := utils.ExecMockRunner
+		assert.Contains(t, runner.Env, "DOCKER_CONFIG=/path/to")
+		assert.Equal(t, mock.ExecCall{Exec: "syft", Params: []string{"version"}}, runner.Calls[3])
+		assert.Equal(t, mock.ExecCall{Exec: "syft", Params: []string{"packages", "registry:some-registry/my-image:0.0.1", "-o", "cyclonedx", "--file", "bom-image.xml", "-q"}}, runner.Calls[4])
+		assert.Equal(t, []string{"bom.xml", "bom-image.xml"}, cpe.custom.sbomFiles)
+	})
+
 	t.Run("success case (additionalTags)", func(t *testing.T) {
 		t.Parallel()
 
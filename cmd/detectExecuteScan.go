@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"math"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -117,7 +118,13 @@ func detectExecuteScan(config detectExecuteScanOptions, _ *telemetry.CustomData,
 
 func runDetect(config detectExecuteScanOptions, utils detectUtils, influx *detectExecuteScanInflux) error {
 	// detect execution details, see https://synopsys.atlassian.net/wiki/spaces/INTDOCS/pages/88440888/Sample+Synopsys+Detect+Scan+Configuration+Scenarios+for+Black+Duck
-	err := getDetectScript(config, utils)
+	triage, err := reporting.ReadTriage(config.TriageFile, utils)
+	if err != nil {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return err
+	}
+
+	err = getDetectScript(config, utils)
 	if err != nil {
 		return fmt.Errorf("failed to download 'detect.sh' script: %w", err)
 	}
@@ -158,7 +165,7 @@ func runDetect(config detectExecuteScanOptions, utils detectUtils, influx *detec
 
 	err = utils.RunShell("/bin/bash", script)
 	blackduckSystem := newBlackduckSystem(config)
-	reportingErr := postScanChecksAndReporting(config, influx, utils, blackduckSystem)
+	reportingErr := postScanChecksAndReporting(config, influx, utils, blackduckSystem, triage)
 	if reportingErr != nil {
 		if strings.Contains(reportingErr.Error(), "License Policy Violations found") {
 			log.Entry().Errorf("License Policy Violations found")
@@ -360,12 +367,12 @@ func getVersionName(config detectExecuteScanOptions) string {
 	return detectVersionName
 }
 
-func postScanChecksAndReporting(config detectExecuteScanOptions, influx *detectExecuteScanInflux, utils detectUtils, sys *blackduckSystem) error {
-	vulns, _, err := getVulnsAndComponents(config, influx, sys)
+func postScanChecksAndReporting(config detectExecuteScanOptions, influx *detectExecuteScanInflux, utils detectUtils, sys *blackduckSystem, triage *reporting.Triage) error {
+	vulns, _, err := getVulnsAndComponents(config, influx, sys, triage)
 	if err != nil {
 		return err
 	}
-	scanReport := createVulnerabilityReport(config, vulns, influx, sys)
+	scanReport := createVulnerabilityReport(config, vulns, influx, sys, triage)
 	paths, err := writeVulnerabilityReports(scanReport, config, utils)
 
 	policyStatus, err := getPolicyStatus(config, influx, sys)
@@ -377,7 +384,7 @@ func postScanChecksAndReporting(config detectExecuteScanOptions, influx *detectE
 	if err != nil {
 		return errors.Wrapf(err, "failed to check and report scan results")
 	}
-	policyJsonErr, violationCount := writeIpPolicyJson(config, utils, paths, sys)
+	policyJsonErr, violationCount := writeIpPolicyJson(config, utils, paths, sys, triage)
 	if policyJsonErr != nil {
 		return errors.Wrapf(policyJsonErr, "failed to write IP policy violations json file")
 	}
@@ -387,7 +394,7 @@ func postScanChecksAndReporting(config detectExecuteScanOptions, influx *detectE
 	return nil
 }
 
-func getVulnsAndComponents(config detectExecuteScanOptions, influx *detectExecuteScanInflux, sys *blackduckSystem) (*bd.Vulnerabilities, *bd.Components, error) {
+func getVulnsAndComponents(config detectExecuteScanOptions, influx *detectExecuteScanInflux, sys *blackduckSystem, triage *reporting.Triage) (*bd.Vulnerabilities, *bd.Components, error) {
 	detectVersionName := getVersionName(config)
 	vulns, err := sys.Client.GetVulnerabilities(config.ProjectName, detectVersionName)
 	if err != nil {
@@ -397,7 +404,7 @@ func getVulnsAndComponents(config detectExecuteScanOptions, influx *detectExecut
 	majorVulns := 0
 	activeVulns := 0
 	for _, vuln := range vulns.Items {
-		if isActiveVulnerability(vuln) {
+		if isActiveVulnerability(vuln) && !triage.Suppresses(detectVulnerabilityFinding(vuln)) {
 			activeVulns++
 			if isMajorVulnerability(vuln) {
 				majorVulns++
@@ -417,11 +424,12 @@ func getVulnsAndComponents(config detectExecuteScanOptions, influx *detectExecut
 	return vulns, components, nil
 }
 
-func createVulnerabilityReport(config detectExecuteScanOptions, vulns *bd.Vulnerabilities, influx *detectExecuteScanInflux, sys *blackduckSystem) reporting.ScanReport {
+func createVulnerabilityReport(config detectExecuteScanOptions, vulns *bd.Vulnerabilities, influx *detectExecuteScanInflux, sys *blackduckSystem, triage *reporting.Triage) reporting.ScanReport {
 	versionName := getVersionName(config)
 	versionUrl, _ := sys.Client.GetProjectVersionLink(config.ProjectName, versionName)
 	scanReport := reporting.ScanReport{
-		Title: "BlackDuck Security Vulnerability Report",
+		StepName: "detectExecuteScan",
+		Title:    "BlackDuck Security Vulnerability Report",
 		Subheaders: []reporting.Subheader{
 			{Description: "BlackDuck Project Name ", Details: config.ProjectName},
 			{Description: "BlackDuck Project Version ", Details: fmt.Sprintf("<a href='%v'>%v</a>", versionUrl, versionName)},
@@ -464,7 +472,8 @@ func createVulnerabilityReport(config detectExecuteScanOptions, vulns *bd.Vulner
 		if isMajorVulnerability(vuln) {
 			scoreStyle = reporting.Red
 		}
-		if !isActiveVulnerability(vuln) {
+		finding := detectVulnerabilityFinding(vuln)
+		if finding.Suppressed || triage.Suppresses(finding) {
 			scoreStyle = reporting.Grey
 		}
 		row.AddColumn(vuln.VulnerabilityWithRemediation.OverallScore, scoreStyle)
@@ -475,12 +484,28 @@ func createVulnerabilityReport(config detectExecuteScanOptions, vulns *bd.Vulner
 		row.AddColumn(vuln.VulnerabilityWithRemediation.RemediationStatus, 0)
 
 		detailTable.Rows = append(detailTable.Rows, row)
+		scanReport.AddFinding(finding)
 	}
 
 	scanReport.DetailTable = detailTable
+	triage.Apply(&scanReport)
 	return scanReport
 }
 
+// detectVulnerabilityFinding converts a vulnerability into a tool independent finding, remediated vulnerabilities are suppressed
+func detectVulnerabilityFinding(vuln bd.Vulnerability) reporting.Finding {
+	return reporting.Finding{
+		RuleID:      vuln.VulnerabilityWithRemediation.VulnerabilityName,
+		Title:       vuln.VulnerabilityWithRemediation.VulnerabilityName,
+		Description: vuln.VulnerabilityWithRemediation.Description,
+		Severity:    reporting.ParseSeverity(vuln.VulnerabilityWithRemediation.Severity),
+		Category:    reporting.CategoryVulnerability,
+		Score:       math.Round(float64(vuln.VulnerabilityWithRemediation.OverallScore)*10) / 10,
+		Component:   fmt.Sprintf("%v:%v", vuln.Name, vuln.Version),
+		Suppressed:  !isActiveVulnerability(vuln),
+	}
+}
+
 func writeVulnerabilityReports(scanReport reporting.ScanReport, config detectExecuteScanOptions, utils detectUtils) ([]piperutils.Path, error) {
 	reportPaths := []piperutils.Path{}
 
@@ -525,7 +550,8 @@ func createPolicyStatusReport(config detectExecuteScanOptions, policyStatus *bd.
 	versionName := getVersionName(config)
 	versionUrl, _ := sys.Client.GetProjectVersionLink(config.ProjectName, versionName)
 	policyReport := reporting.ScanReport{
-		Title: "BlackDuck Policy Violations Report",
+		StepName: "detectExecuteScan",
+		Title:    "BlackDuck Policy Violations Report",
 		Subheaders: []reporting.Subheader{
 			{Description: "BlackDuck project name ", Details: config.ProjectName},
 			{Description: "BlackDuck project version name", Details: fmt.Sprintf("<a href='%v'>%v</a>", versionUrl, versionName)},
@@ -581,14 +607,14 @@ func writePolicyStatusReports(scanReport reporting.ScanReport, config detectExec
 	return reportPaths, nil
 }
 
-func writeIpPolicyJson(config detectExecuteScanOptions, utils detectUtils, paths []piperutils.Path, sys *blackduckSystem) (error, int) {
+func writeIpPolicyJson(config detectExecuteScanOptions, utils detectUtils, paths []piperutils.Path, sys *blackduckSystem, triage *reporting.Triage) (error, int) {
 	components, err := sys.Client.GetComponentsWithLicensePolicyRule(config.ProjectName, getVersionName(config))
 	if err != nil {
 		errors.Wrapf(err, "failed to get License Policy Violations")
 		return err, 0
 	}
 
-	violationCount := getActivePolicyViolations(components)
+	violationCount := getActivePolicyViolations(components, triage)
 	violations := struct {
 		PolicyViolations int      `json:"policyViolations"`
 		Reports          []string `json:"reports"`
@@ -618,15 +644,26 @@ func writeIpPolicyJson(config detectExecuteScanOptions, utils detectUtils, paths
 	return nil, violationCount
 }
 
-func getActivePolicyViolations(components *bd.Components) int {
+// getActivePolicyViolations counts the components in violation of a license policy,
+// components can be suppressed via the triage file using the id "license".
+func getActivePolicyViolations(components *bd.Components, triage *reporting.Triage) int {
 	if components.TotalCount == 0 {
 		return 0
 	}
 	activeViolations := 0
 	for _, component := range components.Items {
-		if isActivePolicyViolation(component.PolicyStatus) {
-			activeViolations++
+		if !isActivePolicyViolation(component.PolicyStatus) {
+			continue
+		}
+		suppression, expired := triage.Find([]string{string(reporting.CategoryLicense)}, fmt.Sprintf("%v:%v", component.Name, component.Version))
+		if suppression != nil && !expired {
+			log.Entry().Infof("Policy violation of component %v %v has been suppressed via triage file", component.Name, component.Version)
+			continue
+		}
+		if expired {
+			log.Entry().Warnf("Suppression of policy violation of component %v has expired on %v, please re-assess the finding", component.Name, suppression.Expires)
 		}
+		activeViolations++
 	}
 	return activeViolations
 }
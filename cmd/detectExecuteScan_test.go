@@ -13,6 +13,7 @@ import (
 	bd "github.com/SAP/jenkins-library/pkg/blackduck"
 	piperhttp "github.com/SAP/jenkins-library/pkg/http"
 	"github.com/SAP/jenkins-library/pkg/mock"
+	"github.com/SAP/jenkins-library/pkg/reporting"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -597,13 +598,28 @@ func TestPostScanChecksAndReporting(t *testing.T) {
 		config := detectExecuteScanOptions{Token: "token", ServerURL: "https://my.blackduck.system", ProjectName: "SHC-PiperTest", Version: "", CustomScanVersion: "1.0"}
 		utils := newDetectTestUtilsBundle()
 		sys := newBlackduckMockSystem(config)
-		err := postScanChecksAndReporting(config, &detectExecuteScanInflux{}, utils, &sys)
+		err := postScanChecksAndReporting(config, &detectExecuteScanInflux{}, utils, &sys, nil)
 
 		assert.EqualError(t, err, "License Policy Violations found")
 		content, err := utils.FileRead("blackduck-ip.json")
 		assert.NoError(t, err)
 		assert.Contains(t, string(content), `"policyViolations":2`)
 	})
+	t.Run("Policy violations suppressed via triage file", func(t *testing.T) {
+		config := detectExecuteScanOptions{Token: "token", ServerURL: "https://my.blackduck.system", ProjectName: "SHC-PiperTest", Version: "", CustomScanVersion: "1.0"}
+		utils := newDetectTestUtilsBundle()
+		sys := newBlackduckMockSystem(config)
+		triage := &reporting.Triage{Suppressions: []reporting.Suppression{
+			{ID: "license", Component: "Spring Framework", Justification: "approved by legal"},
+			{ID: "license", Component: "Apache Tomcat:9.0.52", Justification: "approved by legal"},
+		}}
+		err := postScanChecksAndReporting(config, &detectExecuteScanInflux{}, utils, &sys, triage)
+
+		assert.NoError(t, err)
+		content, err := utils.FileRead("blackduck-ip.json")
+		assert.NoError(t, err)
+		assert.Contains(t, string(content), `"policyViolations":0`)
+	})
 }
 
 func TestIsMajorVulnerability(t *testing.T) {
@@ -678,6 +694,15 @@ func TestGetActivePolicyViolations(t *testing.T) {
 
 		components, err := sys.Client.GetComponents("SHC-PiperTest", "1.0")
 		assert.NoError(t, err)
-		assert.Equal(t, getActivePolicyViolations(components), 2)
+		assert.Equal(t, getActivePolicyViolations(components, nil), 2)
+	})
+	t.Run("Suppressed via triage file", func(t *testing.T) {
+		components := &bd.Components{TotalCount: 2, Items: []bd.Component{
+			{Name: "Spring Framework", Version: "5.3.9", PolicyStatus: "IN_VIOLATION"},
+			{Name: "Apache Log4j", Version: "2.14.1", PolicyStatus: "IN_VIOLATION"},
+		}}
+		triage := &reporting.Triage{Suppressions: []reporting.Suppression{{ID: "license", Component: "Spring Framework", Justification: "approved by legal"}}}
+
+		assert.Equal(t, 1, getActivePolicyViolations(components, triage))
 	})
 }
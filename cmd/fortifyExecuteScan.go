@@ -14,6 +14,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/SAP/jenkins-library/pkg/decoration"
 	piperhttp "github.com/SAP/jenkins-library/pkg/http"
 
 	"github.com/bmatcuk/doublestar"
@@ -28,6 +29,7 @@ import (
 	"github.com/SAP/jenkins-library/pkg/log"
 	"github.com/SAP/jenkins-library/pkg/maven"
 	"github.com/SAP/jenkins-library/pkg/piperutils"
+	"github.com/SAP/jenkins-library/pkg/reporting"
 	"github.com/SAP/jenkins-library/pkg/telemetry"
 	"github.com/SAP/jenkins-library/pkg/toolrecord"
 	"github.com/SAP/jenkins-library/pkg/versioning"
@@ -149,7 +151,12 @@ func runFortifyScan(config fortifyExecuteScanOptions, sys fortify.System, utils
 		return reports, fmt.Errorf("Failed to load project version %v: %w", fortifyProjectVersion, err)
 	}
 
+	if config.NewFindingsOnly && len(config.PullRequestName) == 0 {
+		config.PullRequestName, _ = detectPullRequestFromCI()
+	}
+	var baseProjectVersion *models.ProjectVersion
 	if len(config.PullRequestName) > 0 {
+		baseProjectVersion = projectVersion
 		fortifyProjectVersion = config.PullRequestName
 		projectVersion, err = sys.LookupOrCreateProjectVersionDetailsForPullRequest(project.ID, projectVersion, fortifyProjectVersion)
 		if err != nil {
@@ -186,7 +193,7 @@ func runFortifyScan(config fortifyExecuteScanOptions, sys fortify.System, utils
 
 	if config.VerifyOnly {
 		log.Entry().Infof("Starting audit status check on project %v with version %v and project version ID %v", fortifyProjectName, fortifyProjectVersion, projectVersion.ID)
-		err, paths := verifyFFProjectCompliance(config, sys, project, projectVersion, filterSet, influx, auditStatus)
+		err, paths := verifyFFProjectCompliance(config, sys, project, projectVersion, baseProjectVersion, filterSet, influx, auditStatus)
 		reports = append(reports, paths...)
 		return reports, err
 	}
@@ -244,7 +251,7 @@ func runFortifyScan(config fortifyExecuteScanOptions, sys fortify.System, utils
 		return reports, err
 	}
 
-	err, paths := verifyFFProjectCompliance(config, sys, project, projectVersion, filterSet, influx, auditStatus)
+	err, paths := verifyFFProjectCompliance(config, sys, project, projectVersion, baseProjectVersion, filterSet, influx, auditStatus)
 	reports = append(reports, paths...)
 	return reports, err
 }
@@ -255,7 +262,7 @@ func classifyErrorOnLookup(err error) {
 	}
 }
 
-func verifyFFProjectCompliance(config fortifyExecuteScanOptions, sys fortify.System, project *models.Project, projectVersion *models.ProjectVersion, filterSet *models.FilterSet, influx *fortifyExecuteScanInflux, auditStatus map[string]string) (error, []piperutils.Path) {
+func verifyFFProjectCompliance(config fortifyExecuteScanOptions, sys fortify.System, project *models.Project, projectVersion, baseProjectVersion *models.ProjectVersion, filterSet *models.FilterSet, influx *fortifyExecuteScanInflux, auditStatus map[string]string) (error, []piperutils.Path) {
 	reports := []piperutils.Path{}
 	// Generate report
 	if config.Reporting {
@@ -270,6 +277,11 @@ func verifyFFProjectCompliance(config fortifyExecuteScanOptions, sys fortify.Sys
 	}
 
 	// Perform audit compliance checks
+	triage, err := reporting.ReadTriage(config.TriageFile, &piperutils.Files{})
+	if err != nil {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return err, reports
+	}
 	issueFilterSelectorSet, err := sys.GetIssueFilterSelectorOfProjectVersionByName(projectVersion.ID, []string{"Analysis", "Folder", "Category"}, nil)
 	if err != nil {
 		return errors.Wrapf(err, "failed to fetch project version issue filter selector for project version ID %v", projectVersion.ID), reports
@@ -277,7 +289,7 @@ func verifyFFProjectCompliance(config fortifyExecuteScanOptions, sys fortify.Sys
 	log.Entry().Debugf("initial filter selector set: %v", issueFilterSelectorSet)
 
 	spotChecksCountByCategory := []fortify.SpotChecksAuditCount{}
-	numberOfViolations, issueGroups, err := analyseUnauditedIssues(config, sys, projectVersion, filterSet, issueFilterSelectorSet, influx, auditStatus, &spotChecksCountByCategory)
+	numberOfViolations, issueGroups, err := analyseUnauditedIssues(config, sys, projectVersion, filterSet, issueFilterSelectorSet, influx, auditStatus, &spotChecksCountByCategory, triage)
 	if err != nil {
 		return errors.Wrap(err, "failed to analyze unaudited issues"), reports
 	}
@@ -287,6 +299,17 @@ func verifyFFProjectCompliance(config fortifyExecuteScanOptions, sys fortify.Sys
 
 	log.Entry().Infof("Counted %v violations, details: %v", numberOfViolations, auditStatus)
 
+	var delta *reporting.FindingsDelta
+	if config.NewFindingsOnly && baseProjectVersion != nil {
+		var issues []*models.ProjectVersionIssue
+		delta, issues, err = compareWithBaseProjectVersion(sys, projectVersion, baseProjectVersion, filterSet, triage)
+		if err != nil {
+			return errors.Wrap(err, "failed to compare issues with base project version"), reports
+		}
+		numberOfViolations = countNewViolations(config, issues, delta.ActiveNew(), filterSet, spotChecksCountByCategory, triage)
+		log.Entry().Infof("Counted %v violations among the %v new issues which are not suppressed, only these are considered", numberOfViolations, len(delta.ActiveNew()))
+	}
+
 	influx.fortify_data.fields.projectName = *project.Name
 	influx.fortify_data.fields.projectVersion = *projectVersion.Name
 	influx.fortify_data.fields.projectVersionID = projectVersion.ID
@@ -294,12 +317,39 @@ func verifyFFProjectCompliance(config fortifyExecuteScanOptions, sys fortify.Sys
 
 	fortifyReportingData := prepareReportData(influx)
 	scanReport := fortify.CreateCustomReport(fortifyReportingData, issueGroups)
+	scanReport.BuildDescriptor = config.BuildDescriptorFile
+	triage.Apply(&scanReport)
+	if delta != nil {
+		scanReport.AddDelta(*delta)
+	}
 	paths, err := fortify.WriteCustomReports(scanReport, influx.fortify_data.fields.projectName, influx.fortify_data.fields.projectVersion)
 	if err != nil {
 		return errors.Wrap(err, "failed to write custom reports"), reports
 	}
 	reports = append(reports, paths...)
 
+	if config.CreateResultIssue {
+		options := piperGithub.ResultIssueOptions{
+			Owner:      config.Owner,
+			Repository: config.Repository,
+			Scope:      fortify.ResultIssueScope(influx.fortify_data.fields.projectName, influx.fortify_data.fields.projectVersion),
+			Labels:     config.IssueLabels,
+			Assignees:  config.IssueAssignees,
+		}
+		syncScanReportIssues(config.GithubToken, config.GithubAPIURL, config.PullRequestName, options, &scanReport)
+	}
+
+	if config.DecoratePullRequest {
+		decoration.PublishReport(decoration.Options{
+			PullRequest:  config.PullRequestName,
+			Owner:        config.Owner,
+			Repository:   config.Repository,
+			GitHubToken:  config.GithubToken,
+			GitHubAPIURL: config.GithubAPIURL,
+			AzureToken:   config.AzureAccessToken,
+		}, scanReport, config.MaxLineComments, &piperhttp.Client{})
+	}
+
 	jsonReport := fortify.CreateJSONReport(fortifyReportingData, spotChecksCountByCategory, config.ServerURL)
 	paths, err = fortify.WriteJSONReport(jsonReport)
 	if err != nil {
@@ -314,6 +364,83 @@ func verifyFFProjectCompliance(config fortifyExecuteScanOptions, sys fortify.Sys
 	return nil, reports
 }
 
+// compareWithBaseProjectVersion compares the issues of the pull request project version with the issues of the base project version
+func compareWithBaseProjectVersion(sys fortify.System, projectVersion, baseProjectVersion *models.ProjectVersion, filterSet *models.FilterSet, triage *reporting.Triage) (*reporting.FindingsDelta, []*models.ProjectVersionIssue, error) {
+	baseFindings, _, err := projectVersionFindings(sys, baseProjectVersion.ID, filterSet, triage)
+	if err != nil {
+		return nil, nil, err
+	}
+	findings, issues, err := projectVersionFindings(sys, projectVersion.ID, filterSet, triage)
+	if err != nil {
+		return nil, nil, err
+	}
+	delta := reporting.CompareFindings(baseFindings, findings)
+	delta.Base = *baseProjectVersion.Name
+	log.Entry().Infof("Compared with project version %v: %v new, %v fixed and %v unchanged issues", delta.Base, len(delta.New), len(delta.Fixed), len(delta.Unchanged))
+	return &delta, issues, nil
+}
+
+func projectVersionFindings(sys fortify.System, projectVersionID int64, filterSet *models.FilterSet, triage *reporting.Triage) ([]reporting.Finding, []*models.ProjectVersionIssue, error) {
+	issues, err := sys.GetIssuesOfProjectVersion(projectVersionID, filterSet.GUID)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to fetch issues of project version ID %v", projectVersionID)
+	}
+	findings := []reporting.Finding{}
+	for _, issue := range issues {
+		finding := fortify.IssueFinding(issue)
+		if triage.Suppresses(finding) {
+			finding.Suppressed = true
+		}
+		findings = append(findings, finding)
+	}
+	return findings, issues, nil
+}
+
+// countNewViolations applies the audit criteria to the new issues: issues tagged as exploitable (or suspicious if configured),
+// unaudited issues of the groups which must be audited and unaudited issues of spot check categories which miss the spot check minimum.
+// Per spot check category at most as many new issues are counted as audits are missing.
+func countNewViolations(config fortifyExecuteScanOptions, issues []*models.ProjectVersionIssue, newFindings []reporting.Finding, filterSet *models.FilterSet, spotChecksCountByCategory []fortify.SpotChecksAuditCount, triage *reporting.Triage) int {
+	newFingerprints := map[string]bool{}
+	for _, finding := range newFindings {
+		newFingerprints[finding.Fingerprint()] = true
+	}
+	folders := map[string]string{}
+	for _, folder := range filterSet.Folders {
+		if folder != nil {
+			folders[folder.GUID] = folder.Name
+		}
+	}
+	spotCheckGaps := map[string]int{}
+	for _, category := range spotChecksCountByCategory {
+		if !isSuppressedIssueGroup(triage, category.Type) {
+			spotCheckGaps[category.Type] = spotCheckGap(config, category.Total, category.Audited)
+		}
+	}
+
+	violations := 0
+	for _, issue := range issues {
+		finding := fortify.IssueFinding(issue)
+		if !newFingerprints[finding.Fingerprint()] {
+			continue
+		}
+		folder := folders[fortify.StringValue(issue.FolderGUID)]
+		category := fortify.StringValue(issue.IssueName)
+		switch analysis := fortify.StringValue(issue.PrimaryTag); {
+		case analysis == "Exploitable" || (analysis == "Suspicious" && config.ConsiderSuspicious):
+			violations++
+		case issue.Audited || len(folder) == 0:
+		case strings.Contains(config.MustAuditIssueGroups, folder):
+			if !isSuppressedIssueGroup(triage, folder) {
+				violations++
+			}
+		case strings.Contains(config.SpotAuditIssueGroups, folder) && spotCheckGaps[category] > 0:
+			spotCheckGaps[category]--
+			violations++
+		}
+	}
+	return violations
+}
+
 func prepareReportData(influx *fortifyExecuteScanInflux) fortify.FortifyReportData {
 	input := influx.fortify_data.fields
 	output := fortify.FortifyReportData{}
@@ -333,7 +460,7 @@ func prepareReportData(influx *fortifyExecuteScanInflux) fortify.FortifyReportDa
 	return output
 }
 
-func analyseUnauditedIssues(config fortifyExecuteScanOptions, sys fortify.System, projectVersion *models.ProjectVersion, filterSet *models.FilterSet, issueFilterSelectorSet *models.IssueFilterSelectorSet, influx *fortifyExecuteScanInflux, auditStatus map[string]string, spotChecksCountByCategory *[]fortify.SpotChecksAuditCount) (int, []*models.ProjectVersionIssueGroup, error) {
+func analyseUnauditedIssues(config fortifyExecuteScanOptions, sys fortify.System, projectVersion *models.ProjectVersion, filterSet *models.FilterSet, issueFilterSelectorSet *models.IssueFilterSelectorSet, influx *fortifyExecuteScanInflux, auditStatus map[string]string, spotChecksCountByCategory *[]fortify.SpotChecksAuditCount, triage *reporting.Triage) (int, []*models.ProjectVersionIssueGroup, error) {
 	log.Entry().Info("Analyzing unaudited issues")
 	reducedFilterSelectorSet := sys.ReduceIssueFilterSelectorSet(issueFilterSelectorSet, []string{"Folder"}, nil)
 	fetchedIssueGroups, err := sys.GetProjectIssuesByIDAndFilterSetGroupedBySelector(projectVersion.ID, "", filterSet.GUID, reducedFilterSelectorSet)
@@ -342,7 +469,7 @@ func analyseUnauditedIssues(config fortifyExecuteScanOptions, sys fortify.System
 	}
 	overallViolations := 0
 	for _, issueGroup := range fetchedIssueGroups {
-		issueDelta, err := getIssueDeltaFor(config, sys, issueGroup, projectVersion.ID, filterSet, issueFilterSelectorSet, influx, auditStatus, spotChecksCountByCategory)
+		issueDelta, err := getIssueDeltaFor(config, sys, issueGroup, projectVersion.ID, filterSet, issueFilterSelectorSet, influx, auditStatus, spotChecksCountByCategory, triage)
 		if err != nil {
 			return overallViolations, fetchedIssueGroups, errors.Wrap(err, "failed to get issue delta")
 		}
@@ -351,7 +478,7 @@ func analyseUnauditedIssues(config fortifyExecuteScanOptions, sys fortify.System
 	return overallViolations, fetchedIssueGroups, nil
 }
 
-func getIssueDeltaFor(config fortifyExecuteScanOptions, sys fortify.System, issueGroup *models.ProjectVersionIssueGroup, projectVersionID int64, filterSet *models.FilterSet, issueFilterSelectorSet *models.IssueFilterSelectorSet, influx *fortifyExecuteScanInflux, auditStatus map[string]string, spotChecksCountByCategory *[]fortify.SpotChecksAuditCount) (int, error) {
+func getIssueDeltaFor(config fortifyExecuteScanOptions, sys fortify.System, issueGroup *models.ProjectVersionIssueGroup, projectVersionID int64, filterSet *models.FilterSet, issueFilterSelectorSet *models.IssueFilterSelectorSet, influx *fortifyExecuteScanInflux, auditStatus map[string]string, spotChecksCountByCategory *[]fortify.SpotChecksAuditCount, triage *reporting.Triage) (int, error) {
 	totalMinusAuditedDelta := 0
 	group := ""
 	total := 0
@@ -372,7 +499,7 @@ func getIssueDeltaFor(config fortifyExecuteScanOptions, sys fortify.System, issu
 
 		auditStatus[group] = fmt.Sprintf("%v total : %v audited", total, audited)
 
-		if strings.Contains(config.MustAuditIssueGroups, group) {
+		if strings.Contains(config.MustAuditIssueGroups, group) && !isSuppressedIssueGroup(triage, group) {
 			totalMinusAuditedDelta += groupTotalMinusAuditedDelta
 			if group == "Corporate Security Requirements" {
 				influx.fortify_data.fields.corporateTotal = total
@@ -393,13 +520,13 @@ func getIssueDeltaFor(config fortifyExecuteScanOptions, sys fortify.System, issu
 			if err != nil {
 				return totalMinusAuditedDelta, errors.Wrapf(err, "failed to fetch project version issue groups with filter %v, filter set %v and selector %v for project version ID %v", filter, filterSet, issueFilterSelectorSet, projectVersionID)
 			}
-			totalMinusAuditedDelta += getSpotIssueCount(config, sys, fetchedIssueGroups, projectVersionID, filterSet, reducedFilterSelectorSet, influx, auditStatus, spotChecksCountByCategory)
+			totalMinusAuditedDelta += getSpotIssueCount(config, sys, fetchedIssueGroups, projectVersionID, filterSet, reducedFilterSelectorSet, influx, auditStatus, spotChecksCountByCategory, triage)
 		}
 	}
 	return totalMinusAuditedDelta, nil
 }
 
-func getSpotIssueCount(config fortifyExecuteScanOptions, sys fortify.System, spotCheckCategories []*models.ProjectVersionIssueGroup, projectVersionID int64, filterSet *models.FilterSet, issueFilterSelectorSet *models.IssueFilterSelectorSet, influx *fortifyExecuteScanInflux, auditStatus map[string]string, spotChecksCountByCategory *[]fortify.SpotChecksAuditCount) int {
+func getSpotIssueCount(config fortifyExecuteScanOptions, sys fortify.System, spotCheckCategories []*models.ProjectVersionIssueGroup, projectVersionID int64, filterSet *models.FilterSet, issueFilterSelectorSet *models.IssueFilterSelectorSet, influx *fortifyExecuteScanInflux, auditStatus map[string]string, spotChecksCountByCategory *[]fortify.SpotChecksAuditCount, triage *reporting.Triage) int {
 	overallDelta := 0
 	overallIssues := 0
 	overallIssuesAudited := 0
@@ -414,19 +541,13 @@ func getSpotIssueCount(config fortifyExecuteScanOptions, sys fortify.System, spo
 		}
 		flagOutput := ""
 
-		if ((total <= config.SpotCheckMinimum || config.SpotCheckMinimum < 0) && audited != total) || (total > config.SpotCheckMinimum && audited < config.SpotCheckMinimum) {
-			currentDelta := config.SpotCheckMinimum - audited
-			if config.SpotCheckMinimum < 0 || config.SpotCheckMinimum > total {
-				currentDelta = total - audited
-			}
-			if currentDelta > 0 {
-				filterSelectorFolder := sys.GetFilterSetByDisplayName(issueFilterSelectorSet, "Folder")
-				filterSelectorAnalysis := sys.GetFilterSetByDisplayName(issueFilterSelectorSet, "Analysis")
-				overallDelta += currentDelta
-				log.Entry().Errorf("[projectVersionId %v]: %v unaudited spot check issues detected in group %v", projectVersionID, currentDelta, group)
-				logIssueURL(config, projectVersionID, filterSelectorFolder, filterSelectorAnalysis)
-				flagOutput = checkString
-			}
+		if currentDelta := spotCheckGap(config, total, audited); currentDelta > 0 && !isSuppressedIssueGroup(triage, group) {
+			filterSelectorFolder := sys.GetFilterSetByDisplayName(issueFilterSelectorSet, "Folder")
+			filterSelectorAnalysis := sys.GetFilterSetByDisplayName(issueFilterSelectorSet, "Analysis")
+			overallDelta += currentDelta
+			log.Entry().Errorf("[projectVersionId %v]: %v unaudited spot check issues detected in group %v", projectVersionID, currentDelta, group)
+			logIssueURL(config, projectVersionID, filterSelectorFolder, filterSelectorAnalysis)
+			flagOutput = checkString
 		}
 
 		overallIssues += total
@@ -443,6 +564,31 @@ func getSpotIssueCount(config fortifyExecuteScanOptions, sys fortify.System, spo
 	return overallDelta
 }
 
+// spotCheckGap returns how many issues of a spot check category still need to be audited to reach the spot check minimum
+func spotCheckGap(config fortifyExecuteScanOptions, total, audited int) int {
+	if ((total <= config.SpotCheckMinimum || config.SpotCheckMinimum < 0) && audited != total) || (total > config.SpotCheckMinimum && audited < config.SpotCheckMinimum) {
+		if config.SpotCheckMinimum < 0 || config.SpotCheckMinimum > total {
+			return total - audited
+		}
+		return config.SpotCheckMinimum - audited
+	}
+	return 0
+}
+
+// isSuppressedIssueGroup checks whether unaudited issues of the issue group or category have been suppressed via the triage file
+func isSuppressedIssueGroup(triage *reporting.Triage, group string) bool {
+	suppression, expired := triage.Find([]string{group})
+	if suppression == nil {
+		return false
+	}
+	if expired {
+		log.Entry().Warnf("Suppression of unaudited issues in %v has expired on %v, please re-assess the issues", group, suppression.Expires)
+		return false
+	}
+	log.Entry().Infof("Unaudited issues in %v have been suppressed via triage file: %v", group, suppression.Justification)
+	return true
+}
+
 func analyseSuspiciousExploitable(config fortifyExecuteScanOptions, sys fortify.System, projectVersion *models.ProjectVersion, filterSet *models.FilterSet, issueFilterSelectorSet *models.IssueFilterSelectorSet, influx *fortifyExecuteScanInflux, auditStatus map[string]string) (int, []*models.ProjectVersionIssueGroup) {
 	log.Entry().Info("Analyzing suspicious and exploitable issues")
 	reducedFilterSelectorSet := sys.ReduceIssueFilterSelectorSet(issueFilterSelectorSet, []string{"Analysis"}, []string{})
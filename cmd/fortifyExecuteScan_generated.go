@@ -9,7 +9,9 @@ import (
 	"time"
 
 	"github.com/SAP/jenkins-library/pkg/config"
+	piperGithub "github.com/SAP/jenkins-library/pkg/github"
 	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/opentelemetry"
 	"github.com/SAP/jenkins-library/pkg/piperenv"
 	"github.com/SAP/jenkins-library/pkg/splunk"
 	"github.com/SAP/jenkins-library/pkg/telemetry"
@@ -59,12 +61,20 @@ type fortifyExecuteScanOptions struct {
 	PullRequestMessageRegexGroup    int      `json:"pullRequestMessageRegexGroup,omitempty"`
 	DeltaMinutes                    int      `json:"deltaMinutes,omitempty"`
 	SpotCheckMinimum                int      `json:"spotCheckMinimum,omitempty"`
+	CreateResultIssue               bool     `json:"createResultIssue,omitempty"`
+	IssueAssignees                  []string `json:"issueAssignees,omitempty"`
+	IssueLabels                     []string `json:"issueLabels,omitempty"`
+	DecoratePullRequest             bool     `json:"decoratePullRequest,omitempty"`
+	MaxLineComments                 int      `json:"maxLineComments,omitempty"`
+	AzureAccessToken                string   `json:"azureAccessToken,omitempty"`
+	TriageFile                      string   `json:"triageFile,omitempty"`
 	FprDownloadEndpoint             string   `json:"fprDownloadEndpoint,omitempty"`
 	VersioningModel                 string   `json:"versioningModel,omitempty" validate:"possible-values=major major-minor semantic full"`
 	PythonInstallCommand            string   `json:"pythonInstallCommand,omitempty"`
 	ReportTemplateID                int      `json:"reportTemplateId,omitempty"`
 	FilterSetTitle                  string   `json:"filterSetTitle,omitempty"`
 	PullRequestName                 string   `json:"pullRequestName,omitempty"`
+	NewFindingsOnly                 bool     `json:"newFindingsOnly,omitempty"`
 	PullRequestMessageRegex         string   `json:"pullRequestMessageRegex,omitempty"`
 	BuildTool                       string   `json:"buildTool,omitempty"`
 	ProjectSettingsFile             string   `json:"projectSettingsFile,omitempty"`
@@ -151,6 +161,8 @@ func FortifyExecuteScanCommand() *cobra.Command {
 	var influx fortifyExecuteScanInflux
 	var logCollector *log.CollectorHook
 	var splunkClient *splunk.Splunk
+	var otelClient *opentelemetry.OpenTelemetry
+	var checkRun *piperGithub.StepCheckRun
 	telemetryClient := &telemetry.Telemetry{}
 
 	var createFortifyExecuteScanCmd = &cobra.Command{
@@ -186,6 +198,7 @@ Besides triggering a scan the step verifies the results after they have been upl
 			}
 			log.RegisterSecret(stepConfig.AuthToken)
 			log.RegisterSecret(stepConfig.GithubToken)
+			log.RegisterSecret(stepConfig.AzureAccessToken)
 
 			if len(GeneralConfig.HookConfig.SentryConfig.Dsn) > 0 {
 				sentryHook := log.NewSentryHook(GeneralConfig.HookConfig.SentryConfig.Dsn, GeneralConfig.CorrelationID)
@@ -198,6 +211,13 @@ Besides triggering a scan the step verifies the results after they have been upl
 				log.RegisterHook(logCollector)
 			}
 
+			if len(GeneralConfig.HookConfig.OpenTelemetryConfig.Endpoint) > 0 {
+				otelClient = &opentelemetry.OpenTelemetry{}
+				if err := otelClient.Initialize(GeneralConfig.CorrelationID, STEP_NAME, GeneralConfig.HookConfig.OpenTelemetryConfig); err != nil {
+					log.Entry().WithError(err).Warning("failed to initialize OpenTelemetry")
+				}
+			}
+
 			validation, err := validation.New(validation.WithJSONNamesForStructFields(), validation.WithPredefinedErrorMessages())
 			if err != nil {
 				return err
@@ -223,6 +243,12 @@ Besides triggering a scan the step verifies the results after they have been upl
 				if len(GeneralConfig.HookConfig.SplunkConfig.Dsn) > 0 {
 					splunkClient.Send(telemetryClient.GetData(), logCollector)
 				}
+				if len(GeneralConfig.HookConfig.OpenTelemetryConfig.Endpoint) > 0 {
+					otelClient.Send(telemetryClient.GetData().Attributes())
+				}
+				if checkRun != nil {
+					checkRun.Complete(stepTelemetryData.ErrorCode != "0", log.GetErrorCategory())
+				}
 			}
 			log.DeferExitHandler(handler)
 			defer handler()
@@ -234,6 +260,9 @@ Besides triggering a scan the step verifies the results after they have been upl
 					GeneralConfig.HookConfig.SplunkConfig.Index,
 					GeneralConfig.HookConfig.SplunkConfig.SendLogs)
 			}
+			if GeneralConfig.HookConfig.GitHubChecksConfig.Enabled {
+				checkRun = piperGithub.StartStepCheckRun(STEP_NAME, GeneralConfig.StageName, GeneralConfig.HookConfig.GitHubChecksConfig)
+			}
 			fortifyExecuteScan(stepConfig, &stepTelemetryData, &influx)
 			stepTelemetryData.ErrorCode = "0"
 			log.Entry().Info("SUCCESS")
@@ -286,12 +315,20 @@ func addFortifyExecuteScanFlags(cmd *cobra.Command, stepConfig *fortifyExecuteSc
 	cmd.Flags().IntVar(&stepConfig.PullRequestMessageRegexGroup, "pullRequestMessageRegexGroup", 1, "The group number for extracting the pull request id in `'pullRequestMessageRegex'`")
 	cmd.Flags().IntVar(&stepConfig.DeltaMinutes, "deltaMinutes", 5, "The number of minutes for which an uploaded FPR artifact is considered to be recent and healthy, if exceeded an error will be thrown")
 	cmd.Flags().IntVar(&stepConfig.SpotCheckMinimum, "spotCheckMinimum", 1, "The minimum number of issues that must be audited per category in the `Spot Checks of each Category` folder to avoid an error being thrown")
+	cmd.Flags().BoolVar(&stepConfig.CreateResultIssue, "createResultIssue", false, "Whether the step keeps one GitHub issue per group of findings in sync. Issues are created for new findings, updated on later scans and closed when the findings disappear. The issues carry the label `piper-result`, issues without it are not touched. Scans of pull requests do not synchronize issues and failures of the synchronization do not fail the step.")
+	cmd.Flags().StringSliceVar(&stepConfig.IssueAssignees, "issueAssignees", []string{}, "Defines the GitHub users assigned to the issues created via `createResultIssue`.")
+	cmd.Flags().StringSliceVar(&stepConfig.IssueLabels, "issueLabels", []string{}, "Defines the labels of the issues created via `createResultIssue`.")
+	cmd.Flags().BoolVar(&stepConfig.DecoratePullRequest, "decoratePullRequest", false, "Whether the scan report is published as comments in the pull request the pipeline is running for. Findings within the changes of the pull request are commented on their line.")
+	cmd.Flags().IntVar(&stepConfig.MaxLineComments, "maxLineComments", 20, "Defines the maximum number of findings within the changes of the pull request which are commented on their line when `decoratePullRequest` is active. Line comments are disabled with 0.")
+	cmd.Flags().StringVar(&stepConfig.AzureAccessToken, "azureAccessToken", os.Getenv("PIPER_azureAccessToken"), "Token to authenticate to Azure DevOps when decorating pull requests of Azure Repos. The environment variable `SYSTEM_ACCESSTOKEN` is used if empty.")
+	cmd.Flags().StringVar(&stepConfig.TriageFile, "triageFile", `.pipeline/triage.yml`, "Path of the triage file shared by all scan steps. Issue groups and categories suppressed via their name are not considered in the audit compliance check, expired suppressions are flagged in the report. The file is ignored if it does not exist.")
 	cmd.Flags().StringVar(&stepConfig.FprDownloadEndpoint, "fprDownloadEndpoint", `/download/currentStateFprDownload.html`, "Fortify SSC endpoint for FPR downloads")
 	cmd.Flags().StringVar(&stepConfig.VersioningModel, "versioningModel", `major`, "The default project versioning model used for creating the version based on the build descriptor version to report results in SSC, can be one of `'major'`, `'major-minor'`, `'semantic'`, `'full'`")
 	cmd.Flags().StringVar(&stepConfig.PythonInstallCommand, "pythonInstallCommand", `{{.Pip}} install --user .`, "Additional install command that can be run when `buildTool: 'pip'` is used which allows further customizing the execution environment of the scan")
 	cmd.Flags().IntVar(&stepConfig.ReportTemplateID, "reportTemplateId", 18, "Report template ID to be used for generating the Fortify report")
 	cmd.Flags().StringVar(&stepConfig.FilterSetTitle, "filterSetTitle", `SAP`, "Title of the filter set to use for analysing the results")
 	cmd.Flags().StringVar(&stepConfig.PullRequestName, "pullRequestName", os.Getenv("PIPER_pullRequestName"), "The name of the pull request branch which will trigger creation of a new version in Fortify SSC based on the master branch version")
+	cmd.Flags().BoolVar(&stepConfig.NewFindingsOnly, "newFindingsOnly", false, "Whether in pull request scenarios only issues which are not contained in the master branch version are checked instead of the audit status of the whole pull request version. The audit criteria (`mustAuditIssueGroups`, `spotAuditIssueGroups` with `spotCheckMinimum`, exploitable and suspicious issues) are applied to the new issues which are neither suppressed in Fortify SSC nor via the triage file, only new issues violating them are counted. New, fixed and unchanged issues are listed separately in the report. If `pullRequestName` is not set, the pull request is detected from the CI environment.")
 	cmd.Flags().StringVar(&stepConfig.PullRequestMessageRegex, "pullRequestMessageRegex", `.*Merge pull request #(\\d+) from.*`, "Regex used to identify the PR-XXX reference within the merge commit message")
 	cmd.Flags().StringVar(&stepConfig.BuildTool, "buildTool", `maven`, "Scan type used for the step which can be `'maven'`, `'pip'`")
 	cmd.Flags().StringVar(&stepConfig.ProjectSettingsFile, "projectSettingsFile", os.Getenv("PIPER_projectSettingsFile"), "Path to the mvn settings file that should be used as project settings file.")
@@ -753,6 +790,69 @@ func fortifyExecuteScanMetadata() config.StepData {
 						Aliases:     []config.Alias{},
 						Default:     1,
 					},
+					{
+						Name:        "createResultIssue",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"GENERAL", "PARAMETERS", "STAGES", "STEPS"},
+						Type:        "bool",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     false,
+					},
+					{
+						Name:        "issueAssignees",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "[]string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     []string{},
+					},
+					{
+						Name:        "issueLabels",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "[]string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     []string{},
+					},
+					{
+						Name:        "decoratePullRequest",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "bool",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     false,
+					},
+					{
+						Name:        "maxLineComments",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "int",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     20,
+					},
+					{
+						Name:        "azureAccessToken",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     os.Getenv("PIPER_azureAccessToken"),
+					},
+					{
+						Name:        "triageFile",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"GENERAL", "PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     `.pipeline/triage.yml`,
+					},
 					{
 						Name:        "fprDownloadEndpoint",
 						ResourceRef: []config.ResourceReference{},
@@ -807,6 +907,15 @@ func fortifyExecuteScanMetadata() config.StepData {
 						Aliases:     []config.Alias{},
 						Default:     os.Getenv("PIPER_pullRequestName"),
 					},
+					{
+						Name:        "newFindingsOnly",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "bool",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     false,
+					},
 					{
 						Name:        "pullRequestMessageRegex",
 						ResourceRef: []config.ResourceReference{},
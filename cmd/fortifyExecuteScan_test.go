@@ -19,6 +19,7 @@ import (
 
 	"github.com/SAP/jenkins-library/pkg/fortify"
 	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/reporting"
 	"github.com/SAP/jenkins-library/pkg/versioning"
 
 	"github.com/google/go-github/v32/github"
@@ -253,6 +254,24 @@ func (f *fortifyMock) GetIssueStatisticsOfProjectVersion(id int64) ([]*models.Is
 	suppressed := int32(6)
 	return []*models.IssueStatistics{{SuppressedCount: &suppressed}}, nil
 }
+func (f *fortifyMock) GetIssuesOfProjectVersion(id int64, filterSetGUID string) ([]*models.ProjectVersionIssue, error) {
+	sqlInjection, xss, logForging, deadCode := "SQL Injection", "Cross-Site Scripting: Reflected", "Log Forging", "Dead Code: Unused Method"
+	dao, app, logger := "src/main/java/Dao.java", "src/main/js/app.js", "src/main/java/Logger.java"
+	suppressed := true
+	if id == 4712 {
+		line1, line2, line3 := int32(45), int32(17), int32(80)
+		return []*models.ProjectVersionIssue{
+			{IssueName: &sqlInjection, FullFileName: &dao, LineNumber: &line1},
+			{IssueName: &logForging, FullFileName: &logger, LineNumber: &line2},
+			{IssueName: &deadCode, FullFileName: &dao, LineNumber: &line3, Suppressed: &suppressed},
+		}, nil
+	}
+	line1, line2 := int32(42), int32(12)
+	return []*models.ProjectVersionIssue{
+		{IssueName: &sqlInjection, FullFileName: &dao, LineNumber: &line1},
+		{IssueName: &xss, FullFileName: &app, LineNumber: &line2},
+	}, nil
+}
 func (f *fortifyMock) GenerateQGateReport(projectID, projectVersionID, reportTemplateID int64, projectName, projectVersionName, reportFormat string) (*models.SavedReport, error) {
 	if !f.Successive {
 		f.Successive = true
@@ -493,7 +512,7 @@ func TestAnalyseUnauditedIssues(t *testing.T) {
 	}
 
 	spotChecksCountByCategory := []fortify.SpotChecksAuditCount{}
-	issues, groups, err := analyseUnauditedIssues(config, &ff, &projectVersion, &models.FilterSet{}, &selectorSet, &influx, auditStatus, &spotChecksCountByCategory)
+	issues, groups, err := analyseUnauditedIssues(config, &ff, &projectVersion, &models.FilterSet{}, &selectorSet, &influx, auditStatus, &spotChecksCountByCategory, nil)
 	assert.NoError(t, err)
 	assert.Equal(t, 13, issues)
 	assert.Equal(t, 3, len(groups))
@@ -506,6 +525,98 @@ func TestAnalyseUnauditedIssues(t *testing.T) {
 	assert.Equal(t, 11, influx.fortify_data.fields.spotChecksAudited)
 	assert.Equal(t, 1, influx.fortify_data.fields.spotChecksGap)
 	assert.Equal(t, 3, len(spotChecksCountByCategory))
+
+	t.Run("suppressed via triage file", func(t *testing.T) {
+		triage := &reporting.Triage{Suppressions: []reporting.Suppression{
+			{ID: "Corporate Security Requirements", Justification: "audited in the previous release"},
+			{ID: "HTTP Verb tampering", Justification: "service is not exposed"},
+		}}
+		influx := fortifyExecuteScanInflux{}
+		spotChecksCountByCategory := []fortify.SpotChecksAuditCount{}
+
+		issues, _, err := analyseUnauditedIssues(config, &ff, &projectVersion, &models.FilterSet{}, &selectorSet, &influx, map[string]string{}, &spotChecksCountByCategory, triage)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, issues)
+		assert.Equal(t, 0, influx.fortify_data.fields.spotChecksGap)
+	})
+}
+
+func TestCompareWithBaseProjectVersion(t *testing.T) {
+	ff := fortifyMock{}
+	masterName, prName := "master", "PR-42"
+	baseProjectVersion := &models.ProjectVersion{ID: 4711, Name: &masterName}
+	projectVersion := &models.ProjectVersion{ID: 4712, Name: &prName}
+
+	t.Run("success", func(t *testing.T) {
+		delta, issues, err := compareWithBaseProjectVersion(&ff, projectVersion, baseProjectVersion, &models.FilterSet{}, nil)
+		if assert.NoError(t, err) {
+			assert.Len(t, issues, 3)
+			assert.Equal(t, "master", delta.Base)
+			assert.Len(t, delta.New, 2)
+			assert.Len(t, delta.Unchanged, 1)
+			if assert.Len(t, delta.Fixed, 1) {
+				assert.Equal(t, "Cross-Site Scripting: Reflected", delta.Fixed[0].RuleID)
+			}
+			if assert.Len(t, delta.ActiveNew(), 1) {
+				assert.Equal(t, "Log Forging", delta.ActiveNew()[0].RuleID)
+			}
+		}
+	})
+
+	t.Run("new issue suppressed via triage file", func(t *testing.T) {
+		utils := mock.FilesMock{}
+		utils.AddFile(reporting.DefaultTriageFile, []byte("suppressions:\n  - id: Log Forging\n    component: src/main/java/Logger.java\n    justification: logger escapes line breaks\n"))
+		triage, _ := reporting.ReadTriage(reporting.DefaultTriageFile, &utils)
+
+		delta, _, err := compareWithBaseProjectVersion(&ff, projectVersion, baseProjectVersion, &models.FilterSet{}, triage)
+		if assert.NoError(t, err) {
+			assert.Len(t, delta.New, 2)
+			assert.Empty(t, delta.ActiveNew())
+		}
+	})
+}
+
+func TestCountNewViolations(t *testing.T) {
+	config := fortifyExecuteScanOptions{MustAuditIssueGroups: "Audit All", SpotAuditIssueGroups: "Spot Checks of Each Category", SpotCheckMinimum: 1}
+	filterSet := &models.FilterSet{Folders: []*models.FolderDto{
+		{GUID: "1", Name: "Audit All"},
+		{GUID: "2", Name: "Spot Checks of Each Category"},
+		{GUID: "3", Name: "Low"},
+	}}
+	auditAll, spotChecks, low := "1", "2", "3"
+	sqlInjection, xss, deadCode, logForging, pathManipulation := "SQL Injection", "Cross-Site Scripting: Reflected", "Dead Code: Unused Method", "Log Forging", "Path Manipulation"
+	exploitable := "Exploitable"
+	issues := []*models.ProjectVersionIssue{
+		{IssueName: &sqlInjection, FolderGUID: &auditAll},
+		{IssueName: &xss, FolderGUID: &auditAll, Audited: true},
+		{IssueName: &deadCode, FolderGUID: &low},
+		{IssueName: &logForging, FolderGUID: &spotChecks},
+		{IssueName: &logForging, FolderGUID: &spotChecks},
+		{IssueName: &pathManipulation, FolderGUID: &spotChecks},
+		{IssueName: &xss, FolderGUID: &low, Audited: true, PrimaryTag: &exploitable},
+	}
+	newFindings := []reporting.Finding{}
+	for _, issue := range issues {
+		newFindings = append(newFindings, fortify.IssueFinding(issue))
+	}
+	spotChecksCountByCategory := []fortify.SpotChecksAuditCount{
+		{Type: logForging, Total: 3, Audited: 0},
+		{Type: pathManipulation, Total: 4, Audited: 2},
+	}
+
+	t.Run("audit criteria", func(t *testing.T) {
+		// unaudited Audit All issue, one Log Forging issue missing the spot check minimum and the exploitable issue
+		assert.Equal(t, 3, countNewViolations(config, issues, newFindings, filterSet, spotChecksCountByCategory, nil))
+	})
+
+	t.Run("only new issues", func(t *testing.T) {
+		assert.Equal(t, 1, countNewViolations(config, issues, newFindings[:1], filterSet, spotChecksCountByCategory, nil))
+	})
+
+	t.Run("issue group suppressed via triage file", func(t *testing.T) {
+		triage := &reporting.Triage{Suppressions: []reporting.Suppression{{ID: "Audit All", Justification: "audited in the previous release"}}}
+		assert.Equal(t, 2, countNewViolations(config, issues, newFindings, filterSet, spotChecksCountByCategory, triage))
+	})
 }
 
 func TestTriggerFortifyScan(t *testing.T) {
@@ -6,7 +6,6 @@ import (
 	"github.com/SAP/jenkins-library/pkg/log"
 	"github.com/SAP/jenkins-library/pkg/telemetry"
 	"github.com/google/go-github/v32/github"
-	"github.com/pkg/errors"
 
 	piperGithub "github.com/SAP/jenkins-library/pkg/github"
 )
@@ -32,32 +31,17 @@ func githubCreatePullRequest(config githubCreatePullRequestOptions, telemetryDat
 }
 
 func runGithubCreatePullRequest(ctx context.Context, config *githubCreatePullRequestOptions, ghPRService githubPRService, ghIssueService githubIssueService) error {
-
-	prRequest := github.NewPullRequest{
-		Title: &config.Title,
-		Head:  &config.Head,
-		Base:  &config.Base,
-		Body:  &config.Body,
-	}
-
-	newPR, resp, err := ghPRService.Create(ctx, config.Owner, config.Repository, &prRequest)
-	if err != nil {
-		log.Entry().Errorf("GitHub response code %v", resp.Status)
-		return errors.Wrap(err, "Error occurred when creating pull request")
-	}
-	log.Entry().Debugf("New pull request created: %v", newPR)
-
-	issueRequest := github.IssueRequest{
-		Labels:    &config.Labels,
-		Assignees: &config.Assignees,
-	}
-
-	updatedPr, resp, err := ghIssueService.Edit(ctx, config.Owner, config.Repository, newPR.GetNumber(), &issueRequest)
-	if err != nil {
-		log.Entry().Errorf("GitHub response code %v", resp.Status)
-		return errors.Wrap(err, "Error occurred when editing pull request")
+	options := piperGithub.CreatePullRequestOptions{
+		Owner:      config.Owner,
+		Repository: config.Repository,
+		Title:      config.Title,
+		Body:       config.Body,
+		Head:       config.Head,
+		Base:       config.Base,
+		Labels:     config.Labels,
+		Assignees:  config.Assignees,
 	}
-	log.Entry().Debugf("Updated pull request: %v", updatedPr)
 
-	return nil
+	_, err := piperGithub.CreatePullRequest(ctx, ghPRService, ghIssueService, &options)
+	return err
 }
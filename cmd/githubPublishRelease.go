@@ -3,6 +3,7 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"io/ioutil"
 	"mime"
 	"os"
 	"path/filepath"
@@ -59,7 +60,10 @@ func runGithubPublishRelease(ctx context.Context, config *githubPublishReleaseOp
 
 	//updating assets only supported on latest release
 	if len(config.AssetPath) > 0 && config.Version == "latest" {
-		return uploadReleaseAsset(ctx, lastRelease.GetID(), config, ghRepoClient)
+		if err := uploadReleaseAsset(ctx, lastRelease.GetID(), config, ghRepoClient); err != nil {
+			return err
+		}
+		return uploadSBOMAssets(ctx, lastRelease.GetID(), config, ghRepoClient)
 	}
 
 	releaseBody := ""
@@ -68,6 +72,10 @@ func runGithubPublishRelease(ctx context.Context, config *githubPublishReleaseOp
 		releaseBody += config.ReleaseBodyHeader + "\n"
 	}
 
+	if len(config.ChangelogFile) > 0 {
+		releaseBody += getChangelogText(config.ChangelogFile)
+	}
+
 	if config.AddClosedIssues {
 		releaseBody += getClosedIssuesText(ctx, publishedAt, config, ghIssueClient)
 	}
@@ -93,10 +101,21 @@ func runGithubPublishRelease(ctx context.Context, config *githubPublishReleaseOp
 	log.Entry().Infof("Release %v created on %v/%v", *createdRelease.TagName, config.Owner, config.Repository)
 
 	if len(config.AssetPath) > 0 {
-		return uploadReleaseAsset(ctx, createdRelease.GetID(), config, ghRepoClient)
+		if err := uploadReleaseAsset(ctx, createdRelease.GetID(), config, ghRepoClient); err != nil {
+			return err
+		}
 	}
 
-	return nil
+	return uploadSBOMAssets(ctx, createdRelease.GetID(), config, ghRepoClient)
+}
+
+func getChangelogText(changelogFile string) string {
+	changelog, err := ioutil.ReadFile(changelogFile)
+	if err != nil {
+		log.Entry().WithError(err).Warnf("Failed to read changelog file '%v', release is created without changelog.", changelogFile)
+		return ""
+	}
+	return "\n" + strings.TrimSpace(string(changelog)) + "\n"
 }
 
 func getClosedIssuesText(ctx context.Context, publishedAt github.Timestamp, config *githubPublishReleaseOptions, ghIssueClient githubIssueClient) string {
@@ -157,6 +176,34 @@ func getReleaseDeltaText(config *githubPublishReleaseOptions, lastRelease *githu
 }
 
 func uploadReleaseAsset(ctx context.Context, releaseID int64, config *githubPublishReleaseOptions, ghRepoClient githubRepoClient) error {
+	return uploadReleaseAssetFile(ctx, releaseID, config, config.AssetPath, filepath.Base(config.AssetPath), ghRepoClient)
+}
+
+// uploadSBOMAssets uploads the SBOMs created during the build, SBOMs with the same file name are named by their path
+func uploadSBOMAssets(ctx context.Context, releaseID int64, config *githubPublishReleaseOptions, ghRepoClient githubRepoClient) error {
+	for _, sbomFile := range config.SbomFiles {
+		if _, err := os.Stat(sbomFile); err != nil {
+			log.Entry().Warnf("Not uploading SBOM '%v' since it does not exist", sbomFile)
+			continue
+		}
+		if err := uploadReleaseAssetFile(ctx, releaseID, config, sbomFile, sbomAssetName(sbomFile, config.SbomFiles), ghRepoClient); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func sbomAssetName(sbomFile string, sbomFiles []string) string {
+	name := filepath.Base(sbomFile)
+	for _, other := range sbomFiles {
+		if other != sbomFile && filepath.Base(other) == name {
+			return strings.ReplaceAll(strings.TrimPrefix(filepath.ToSlash(filepath.Clean(sbomFile)), "/"), "/", "-")
+		}
+	}
+	return name
+}
+
+func uploadReleaseAssetFile(ctx context.Context, releaseID int64, config *githubPublishReleaseOptions, assetPath, name string, ghRepoClient githubRepoClient) error {
 
 	assets, _, err := ghRepoClient.ListReleaseAssets(ctx, config.Owner, config.Repository, releaseID, &github.ListOptions{})
 	if err != nil {
@@ -164,7 +211,7 @@ func uploadReleaseAsset(ctx context.Context, releaseID int64, config *githubPubl
 	}
 	var assetID int64
 	for _, a := range assets {
-		if a.GetName() == filepath.Base(config.AssetPath) {
+		if a.GetName() == name {
 			assetID = a.GetID()
 			break
 		}
@@ -177,23 +224,22 @@ func uploadReleaseAsset(ctx context.Context, releaseID int64, config *githubPubl
 		}
 	}
 
-	mediaType := mime.TypeByExtension(filepath.Ext(config.AssetPath))
+	mediaType := mime.TypeByExtension(filepath.Ext(assetPath))
 	if mediaType == "" {
 		mediaType = "application/octet-stream"
 	}
 	log.Entry().Debugf("Using mediaType '%v'", mediaType)
 
-	name := filepath.Base(config.AssetPath)
 	log.Entry().Debugf("Using file name '%v'", name)
 
 	opts := github.UploadOptions{
 		Name:      name,
 		MediaType: mediaType,
 	}
-	file, err := os.Open(config.AssetPath)
+	file, err := os.Open(assetPath)
 	defer file.Close()
 	if err != nil {
-		return errors.Wrapf(err, "Failed to load release asset '%v'", config.AssetPath)
+		return errors.Wrapf(err, "Failed to load release asset '%v'", assetPath)
 	}
 
 	log.Entry().Info("Starting to upload release asset.")
@@ -3,6 +3,7 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -330,6 +331,28 @@ func TestGetReleaseDeltaText(t *testing.T) {
 	assert.Equal(t, "\n**Changes**\n[1.0...1.1](https://github.com/TEST/test/compare/1.0...1.1)\n", res)
 }
 
+func TestGetChangelogText(t *testing.T) {
+	t.Run("changelog available", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "")
+		if err != nil {
+			t.Fatal("Failed to create temporary directory")
+		}
+		defer os.RemoveAll(dir)
+		changelogFile := filepath.Join(dir, "changelog.md")
+		ioutil.WriteFile(changelogFile, []byte("## 1.1.0 (2021-06-01)\n\n### Features\n\n* new feature\n\n"), 0644)
+
+		res := getChangelogText(changelogFile)
+
+		assert.Equal(t, "\n## 1.1.0 (2021-06-01)\n\n### Features\n\n* new feature\n", res)
+	})
+
+	t.Run("changelog not available", func(t *testing.T) {
+		res := getChangelogText("not/existing/changelog.md")
+
+		assert.Equal(t, "", res)
+	})
+}
+
 func TestUploadReleaseAsset(t *testing.T) {
 	ctx := context.Background()
 
@@ -408,6 +431,50 @@ func TestUploadReleaseAsset(t *testing.T) {
 	})
 }
 
+func TestUploadSBOMAssets(t *testing.T) {
+	ctx := context.Background()
+	var releaseID int64 = 1
+
+	dir, err := ioutil.TempDir("", "sbom")
+	if err != nil {
+		t.Fatal("Failed to create temporary directory")
+	}
+	defer os.RemoveAll(dir)
+	sbomFile := filepath.Join(dir, "bom.xml")
+	assert.NoError(t, ioutil.WriteFile(sbomFile, []byte("<bom/>"), 0644))
+
+	t.Run("Success", func(t *testing.T) {
+		ghRepoClient := ghRCMock{}
+		myGithubPublishReleaseOptions := githubPublishReleaseOptions{
+			Owner:      "TEST",
+			Repository: "test",
+			SbomFiles:  []string{sbomFile, "not/existing/bom-image.xml"},
+		}
+
+		err := uploadSBOMAssets(ctx, releaseID, &myGithubPublishReleaseOptions, &ghRepoClient)
+
+		assert.NoError(t, err, "Error occurred but none expected.")
+		assert.Equal(t, releaseID, ghRepoClient.uploadID)
+		assert.Equal(t, "bom.xml", ghRepoClient.uploadOpts.Name)
+		assert.Equal(t, "text/xml; charset=utf-8", ghRepoClient.uploadOpts.MediaType)
+	})
+
+	t.Run("Error - upload fails", func(t *testing.T) {
+		ghRepoClient := ghRCMock{listErr: fmt.Errorf("List Asset Error")}
+		myGithubPublishReleaseOptions := githubPublishReleaseOptions{SbomFiles: []string{sbomFile}}
+
+		err := uploadSBOMAssets(ctx, releaseID, &myGithubPublishReleaseOptions, &ghRepoClient)
+		assert.EqualError(t, err, "Failed to get list of release assets.: List Asset Error")
+	})
+}
+
+func TestSbomAssetName(t *testing.T) {
+	sbomFiles := []string{"bom.xml", "/tmp/target/bom.xml", "bom-image.xml"}
+	assert.Equal(t, "bom.xml", sbomAssetName("bom.xml", []string{"bom.xml", "bom-image.xml"}))
+	assert.Equal(t, "bom-image.xml", sbomAssetName("bom-image.xml", sbomFiles))
+	assert.Equal(t, "tmp-target-bom.xml", sbomAssetName("/tmp/target/bom.xml", sbomFiles))
+}
+
 func TestIsExcluded(t *testing.T) {
 
 	l1 := "label1"
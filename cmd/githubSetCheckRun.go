@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+
+	piperGithub "github.com/SAP/jenkins-library/pkg/github"
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/piperutils"
+	"github.com/SAP/jenkins-library/pkg/reporting"
+	"github.com/SAP/jenkins-library/pkg/telemetry"
+	"github.com/pkg/errors"
+)
+
+type githubSetCheckRunUtils interface {
+	FileRead(path string) ([]byte, error)
+	Glob(pattern string) (matches []string, err error)
+}
+
+func githubSetCheckRun(config githubSetCheckRunOptions, telemetryData *telemetry.CustomData) {
+	ctx, client, err := piperGithub.NewClient(config.Token, config.APIURL, "")
+	if err != nil {
+		log.Entry().WithError(err).Fatal("Failed to get GitHub client")
+	}
+
+	err = runGithubSetCheckRun(ctx, &config, telemetryData, client.Checks, &piperutils.Files{})
+	if err != nil {
+		log.Entry().WithError(err).Fatal("GitHub check run update failed")
+	}
+}
+
+func runGithubSetCheckRun(ctx context.Context, config *githubSetCheckRunOptions, telemetryData *telemetry.CustomData, checks piperGithub.ChecksService, utils githubSetCheckRunUtils) error {
+	summary := config.Summary
+	if len(config.SummaryFile) > 0 {
+		content, err := utils.FileRead(config.SummaryFile)
+		if err != nil {
+			log.SetErrorCategory(log.ErrorConfiguration)
+			return errors.Wrapf(err, "failed to read summary file %v", config.SummaryFile)
+		}
+		summary = string(content)
+	}
+
+	update := piperGithub.CheckRunUpdate{
+		Status:     config.Status,
+		DetailsURL: config.DetailsURL,
+		Title:      config.Title,
+		Summary:    summary,
+	}
+	if config.Status == piperGithub.CheckRunStatusCompleted {
+		update.Conclusion = config.Conclusion
+	}
+	if config.AnnotateFindings {
+		findings, err := readScanReportFindings(utils)
+		if err != nil {
+			return err
+		}
+		update.Annotations = piperGithub.AnnotationsFromFindings(findings)
+		log.Entry().Infof("Adding %v annotations to check run '%v'", len(update.Annotations), config.Name)
+	}
+
+	checkRun := piperGithub.NewCheckRun(ctx, checks, config.Owner, config.Repository, config.CommitID, config.Name)
+	if _, err := checkRun.Find(); err != nil {
+		return err
+	}
+	if err := checkRun.Update(update); err != nil {
+		return errors.Wrapf(err, "failed to set check run on commitId '%v'", config.CommitID)
+	}
+
+	if config.ReRequest {
+		if err := checkRun.ReRequest(); err != nil {
+			return err
+		}
+		log.Entry().Infof("Re-run of check run '%v' requested", config.Name)
+	}
+	return nil
+}
+
+// readScanReportFindings returns the findings of all scan reports written by previous steps
+func readScanReportFindings(utils githubSetCheckRunUtils) ([]reporting.Finding, error) {
+	reports, _ := utils.Glob(reporting.StepReportDirectory + "/*.json")
+	findings := []reporting.Finding{}
+	for _, report := range reports {
+		content, err := utils.FileRead(report)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read report %v", report)
+		}
+		scanReport := reporting.ScanReport{}
+		if err := json.Unmarshal(content, &scanReport); err != nil {
+			return nil, errors.Wrapf(err, "failed to parse report %v", report)
+		}
+		findings = append(findings, scanReport.Findings...)
+	}
+	return findings, nil
+}
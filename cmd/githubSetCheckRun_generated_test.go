@@ -0,0 +1,17 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGithubSetCheckRunCommand(t *testing.T) {
+	t.Parallel()
+
+	testCmd := GithubSetCheckRunCommand()
+
+	// only high level testing performed - details are tested in step generation procedure
+	assert.Equal(t, "githubSetCheckRun", testCmd.Use, "command name incorrect")
+
+}
@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/SAP/jenkins-library/pkg/mock"
+	"github.com/SAP/jenkins-library/pkg/telemetry"
+	"github.com/google/go-github/v32/github"
+	"github.com/stretchr/testify/assert"
+)
+
+type ghCheckRunServiceMock struct {
+	existing     []*github.CheckRun
+	created      []github.CreateCheckRunOptions
+	updated      []github.UpdateCheckRunOptions
+	updatedID    int64
+	reRequested  int64
+	serviceError error
+}
+
+func (g *ghCheckRunServiceMock) CreateCheckRun(ctx context.Context, owner, repo string, opts github.CreateCheckRunOptions) (*github.CheckRun, *github.Response, error) {
+	if g.serviceError != nil {
+		return nil, nil, g.serviceError
+	}
+	g.created = append(g.created, opts)
+	id, suiteID := int64(1), int64(10)
+	return &github.CheckRun{ID: &id, CheckSuite: &github.CheckSuite{ID: &suiteID}}, nil, nil
+}
+
+func (g *ghCheckRunServiceMock) UpdateCheckRun(ctx context.Context, owner, repo string, checkRunID int64, opts github.UpdateCheckRunOptions) (*github.CheckRun, *github.Response, error) {
+	g.updatedID = checkRunID
+	g.updated = append(g.updated, opts)
+	return &github.CheckRun{ID: &checkRunID}, nil, nil
+}
+
+func (g *ghCheckRunServiceMock) ListCheckRunsForRef(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+	return &github.ListCheckRunsResults{CheckRuns: g.existing}, nil, nil
+}
+
+func (g *ghCheckRunServiceMock) ReRequestCheckSuite(ctx context.Context, owner, repo string, checkSuiteID int64) (*github.Response, error) {
+	g.reRequested = checkSuiteID
+	return nil, nil
+}
+
+func TestRunGithubSetCheckRun(t *testing.T) {
+	ctx := context.Background()
+	telemetryData := telemetry.CustomData{}
+
+	t.Run("create check run with summary file and annotations", func(t *testing.T) {
+		config := githubSetCheckRunOptions{
+			CommitID:         "testSha",
+			Owner:            "testOrg",
+			Repository:       "testRepo",
+			Name:             "security scans",
+			Status:           "completed",
+			Conclusion:       "failure",
+			Title:            "2 findings",
+			SummaryFile:      "scanSummary.md",
+			AnnotateFindings: true,
+		}
+		utils := &mock.FilesMock{}
+		utils.AddFile("scanSummary.md", []byte("## Scan summary"))
+		utils.AddFile(".pipeline/stepReports/checkmarxExecuteScan.json", []byte(`{"stepName":"checkmarxExecuteScan","findings":[{"ruleId":"SQL_Injection","title":"SQL Injection","severity":"high","location":"src/Dao.java","line":42},{"ruleId":"XSS","title":"XSS","severity":"medium"}]}`))
+		checks := &ghCheckRunServiceMock{}
+
+		err := runGithubSetCheckRun(ctx, &config, &telemetryData, checks, utils)
+
+		assert.NoError(t, err)
+		if assert.Len(t, checks.created, 1) {
+			created := checks.created[0]
+			assert.Equal(t, "security scans", created.Name)
+			assert.Equal(t, "testSha", created.HeadSHA)
+			assert.Equal(t, "failure", created.GetConclusion())
+			assert.NotNil(t, created.CompletedAt)
+			assert.Equal(t, "## Scan summary", created.Output.GetSummary())
+			if assert.Len(t, created.Output.Annotations, 1) {
+				assert.Equal(t, "src/Dao.java", created.Output.Annotations[0].GetPath())
+				assert.Equal(t, "failure", created.Output.Annotations[0].GetAnnotationLevel())
+			}
+		}
+		assert.Empty(t, checks.updated)
+	})
+
+	t.Run("update existing check run and re-request", func(t *testing.T) {
+		id, suiteID, name := int64(4711), int64(42), "security scans"
+		config := githubSetCheckRunOptions{CommitID: "testSha", Owner: "testOrg", Repository: "testRepo", Name: name, Status: "in_progress", Conclusion: "success", Summary: "running", ReRequest: true}
+		checks := &ghCheckRunServiceMock{existing: []*github.CheckRun{{ID: &id, Name: &name, CheckSuite: &github.CheckSuite{ID: &suiteID}}}}
+
+		err := runGithubSetCheckRun(ctx, &config, &telemetryData, checks, &mock.FilesMock{})
+
+		assert.NoError(t, err)
+		assert.Empty(t, checks.created)
+		assert.Equal(t, int64(4711), checks.updatedID)
+		if assert.Len(t, checks.updated, 1) {
+			assert.Equal(t, "in_progress", checks.updated[0].GetStatus())
+			assert.Nil(t, checks.updated[0].Conclusion)
+			assert.Equal(t, "running", checks.updated[0].Output.GetSummary())
+		}
+		assert.Equal(t, int64(42), checks.reRequested)
+	})
+
+	t.Run("error - summary file missing", func(t *testing.T) {
+		config := githubSetCheckRunOptions{CommitID: "testSha", Name: "scan", Status: "completed", SummaryFile: "scanSummary.md"}
+
+		err := runGithubSetCheckRun(ctx, &config, &telemetryData, &ghCheckRunServiceMock{}, &mock.FilesMock{})
+
+		assert.Contains(t, fmt.Sprint(err), "failed to read summary file scanSummary.md")
+	})
+
+	t.Run("error calling GitHub", func(t *testing.T) {
+		config := githubSetCheckRunOptions{CommitID: "testSha", Owner: "testOrg", Repository: "testRepo", Name: "scan", Status: "queued"}
+		checks := &ghCheckRunServiceMock{serviceError: fmt.Errorf("gh test error")}
+
+		err := runGithubSetCheckRun(ctx, &config, &telemetryData, checks, &mock.FilesMock{})
+
+		assert.EqualError(t, err, "failed to set check run on commitId 'testSha': failed to create check run 'scan': gh test error")
+	})
+}
@@ -3,9 +3,12 @@ package cmd
 import (
 	"bytes"
 	"fmt"
+	"github.com/SAP/jenkins-library/pkg/ado"
 	"github.com/SAP/jenkins-library/pkg/command"
 	"github.com/SAP/jenkins-library/pkg/docker"
 	gitUtil "github.com/SAP/jenkins-library/pkg/git"
+	piperGithub "github.com/SAP/jenkins-library/pkg/github"
+	"github.com/SAP/jenkins-library/pkg/gitops"
 	"github.com/SAP/jenkins-library/pkg/log"
 	"github.com/SAP/jenkins-library/pkg/piperutils"
 	"github.com/SAP/jenkins-library/pkg/telemetry"
@@ -15,15 +18,20 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 )
 
 const toolKubectl = "kubectl"
 const toolHelm = "helm"
+const toolKustomize = "kustomize"
+const toolYAML = "yaml"
+const pullRequestProviderAzure = "azure"
 
 type iGitopsUpdateDeploymentGitUtils interface {
 	CommitSingleFile(filePath, commitMessage, author string) (plumbing.Hash, error)
 	PushChangesToRepository(username, password string) error
+	PushBranchToRepository(username, password, branchName string, force bool) error
 	PlainClone(username, password, serverURL, directory string) error
 	ChangeBranch(branchName string) error
 }
@@ -31,6 +39,7 @@ type iGitopsUpdateDeploymentGitUtils interface {
 type gitopsUpdateDeploymentFileUtils interface {
 	TempDir(dir, pattern string) (name string, err error)
 	RemoveAll(path string) error
+	FileRead(path string) ([]byte, error)
 	FileWrite(path string, content []byte, perm os.FileMode) error
 }
 
@@ -40,6 +49,10 @@ type gitopsUpdateDeploymentExecRunner interface {
 	Stderr(err io.Writer)
 }
 
+type gitopsUpdateDeploymentPullRequestUtils interface {
+	CreatePullRequest(config *gitopsUpdateDeploymentOptions, branchName, title, body string) (string, error)
+}
+
 type gitopsUpdateDeploymentGitUtils struct {
 	worktree   *git.Worktree
 	repository *git.Repository
@@ -53,6 +66,10 @@ func (g *gitopsUpdateDeploymentGitUtils) PushChangesToRepository(username, passw
 	return gitUtil.PushChangesToRepository(username, password, g.repository)
 }
 
+func (g *gitopsUpdateDeploymentGitUtils) PushBranchToRepository(username, password, branchName string, force bool) error {
+	return gitUtil.PushBranchToRepository(username, password, branchName, force, g.repository)
+}
+
 func (g *gitopsUpdateDeploymentGitUtils) PlainClone(username, password, serverURL, directory string) error {
 	var err error
 	g.repository, err = gitUtil.PlainClone(username, password, serverURL, directory)
@@ -67,7 +84,63 @@ func (g *gitopsUpdateDeploymentGitUtils) ChangeBranch(branchName string) error {
 	return gitUtil.ChangeBranch(branchName, g.worktree)
 }
 
-func gitopsUpdateDeployment(config gitopsUpdateDeploymentOptions, _ *telemetry.CustomData) {
+type gitopsUpdateDeploymentPullRequestClient struct{}
+
+// CreatePullRequest creates the pull request on GitHub or Azure DevOps and returns its URL
+func (gitopsUpdateDeploymentPullRequestClient) CreatePullRequest(config *gitopsUpdateDeploymentOptions, branchName, title, body string) (string, error) {
+	if config.PullRequestProvider == pullRequestProviderAzure {
+		organizationURL, project, repository, err := ado.RepositoryFromURL(config.ServerURL)
+		if err != nil {
+			log.SetErrorCategory(log.ErrorConfiguration)
+			return "", err
+		}
+		client, err := ado.NewPullRequestClient(organizationURL, config.Password)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to create Azure DevOps client")
+		}
+		return client.CreateOrGetPullRequest(ado.PullRequestOptions{
+			Project:       project,
+			Repository:    repository,
+			SourceBranch:  branchName,
+			TargetBranch:  config.BranchName,
+			Title:         title,
+			Description:   body,
+			AutoComplete:  config.PullRequestAutoMerge,
+			MergeStrategy: config.PullRequestMergeMethod,
+		})
+	}
+
+	owner, repository, err := piperGithub.RepositoryFromURL(config.ServerURL)
+	if err != nil {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return "", err
+	}
+	ctx, client, err := piperGithub.NewClient(config.Password, config.GithubAPIURL, "")
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get GitHub client")
+	}
+	pullRequest, err := piperGithub.CreateOrGetPullRequest(ctx, client.PullRequests, client.Issues, &piperGithub.CreatePullRequestOptions{
+		Owner:      owner,
+		Repository: repository,
+		Title:      title,
+		Body:       body,
+		Head:       branchName,
+		Base:       config.BranchName,
+		Labels:     config.PullRequestLabels,
+	})
+	if err != nil {
+		return "", err
+	}
+	if config.PullRequestAutoMerge {
+		err = piperGithub.EnableAutoMerge(ctx, client, config.GithubAPIURL, pullRequest, config.PullRequestMergeMethod)
+		if err != nil {
+			return "", err
+		}
+	}
+	return pullRequest.GetHTMLURL(), nil
+}
+
+func gitopsUpdateDeployment(config gitopsUpdateDeploymentOptions, _ *telemetry.CustomData, commonPipelineEnvironment *gitopsUpdateDeploymentCommonPipelineEnvironment) {
 	// for command execution use Command
 	var c gitopsUpdateDeploymentExecRunner = &command.Command{}
 	// reroute command output to logging framework
@@ -79,13 +152,13 @@ func gitopsUpdateDeployment(config gitopsUpdateDeploymentOptions, _ *telemetry.C
 	// Example: step checkmarxExecuteScan.go
 
 	// error situations should stop execution through log.Entry().Fatal() call which leads to an os.Exit(1) in the end
-	err := runGitopsUpdateDeployment(&config, c, &gitopsUpdateDeploymentGitUtils{}, piperutils.Files{})
+	err := runGitopsUpdateDeployment(&config, c, &gitopsUpdateDeploymentGitUtils{}, piperutils.Files{}, gitopsUpdateDeploymentPullRequestClient{}, commonPipelineEnvironment)
 	if err != nil {
 		log.Entry().WithError(err).Fatal("step execution failed")
 	}
 }
 
-func runGitopsUpdateDeployment(config *gitopsUpdateDeploymentOptions, command gitopsUpdateDeploymentExecRunner, gitUtils iGitopsUpdateDeploymentGitUtils, fileUtils gitopsUpdateDeploymentFileUtils) error {
+func runGitopsUpdateDeployment(config *gitopsUpdateDeploymentOptions, command gitopsUpdateDeploymentExecRunner, gitUtils iGitopsUpdateDeploymentGitUtils, fileUtils gitopsUpdateDeploymentFileUtils, pullRequestUtils gitopsUpdateDeploymentPullRequestUtils, commonPipelineEnvironment *gitopsUpdateDeploymentCommonPipelineEnvironment) error {
 	err := checkRequiredFieldsForDeployTool(config)
 	if err != nil {
 		return err
@@ -108,6 +181,15 @@ func runGitopsUpdateDeployment(config *gitopsUpdateDeploymentOptions, command gi
 		return errors.Wrap(err, "repository could not get prepared")
 	}
 
+	var pullRequestBranch string
+	if config.CreatePullRequest {
+		pullRequestBranch = pullRequestBranchName(config)
+		err = gitUtils.ChangeBranch(pullRequestBranch)
+		if err != nil {
+			return errors.Wrap(err, "failed to create pull request branch")
+		}
+	}
+
 	filePath := filepath.Join(temporaryFolder, config.FilePath)
 
 	var outputBytes []byte
@@ -121,6 +203,16 @@ func runGitopsUpdateDeployment(config *gitopsUpdateDeploymentOptions, command gi
 		if err != nil {
 			return errors.Wrap(err, "failed to apply helm command")
 		}
+	} else if config.Tool == toolKustomize {
+		outputBytes, err = updateKustomizeImage(config, fileUtils, filePath)
+		if err != nil {
+			return errors.Wrap(err, "failed to update kustomization")
+		}
+	} else if config.Tool == toolYAML {
+		outputBytes, err = updateYAMLImage(config, fileUtils, filePath)
+		if err != nil {
+			return errors.Wrap(err, "failed to update YAML values")
+		}
 	} else {
 		log.SetErrorCategory(log.ErrorConfiguration)
 		return errors.New("tool " + config.Tool + " is not supported")
@@ -131,13 +223,23 @@ func runGitopsUpdateDeployment(config *gitopsUpdateDeploymentOptions, command gi
 		return errors.Wrap(err, "failed to write file")
 	}
 
-	commit, err := commitAndPushChanges(config, gitUtils)
+	commit, err := commitAndPushChanges(config, gitUtils, pullRequestBranch)
 	if err != nil {
 		return errors.Wrap(err, "failed to commit and push changes")
 	}
 
 	log.Entry().Infof("Changes committed with %s", commit.String())
 
+	if config.CreatePullRequest {
+		body := fmt.Sprintf("Updates `%v` with the changes of commit %v.", config.FilePath, commit.String())
+		pullRequestURL, err := pullRequestUtils.CreatePullRequest(config, pullRequestBranch, commitMessage(config), body)
+		if err != nil {
+			return errors.Wrap(err, "failed to create pull request")
+		}
+		log.Entry().Infof("Changes proposed with pull request %v", pullRequestURL)
+		commonPipelineEnvironment.custom.gitopsPullRequestURL = pullRequestURL
+	}
+
 	return nil
 }
 
@@ -154,6 +256,13 @@ func checkRequiredFieldsForDeployTool(config *gitopsUpdateDeploymentOptions) err
 			return errors.Wrap(err, "missing required fields for kubectl")
 		}
 		logNotRequiredButFilledFieldForKubectl(config)
+	} else if config.Tool == toolKustomize {
+		logNotRequiredButFilledFieldForKustomize(config)
+	} else if config.Tool == toolYAML {
+		err := checkRequiredFieldsForYAML(config)
+		if err != nil {
+			return errors.Wrap(err, "missing required fields for yaml")
+		}
 	}
 
 	return nil
@@ -186,6 +295,14 @@ func checkRequiredFieldsForKubectl(config *gitopsUpdateDeploymentOptions) error
 	return nil
 }
 
+func checkRequiredFieldsForYAML(config *gitopsUpdateDeploymentOptions) error {
+	if len(config.YamlImagePaths) == 0 && len(config.YamlTagPaths) == 0 && config.ContainerName == "" {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return errors.New("the following parameters are necessary for yaml: [yamlImagePaths] or [yamlTagPaths] or [containerName]")
+	}
+	return nil
+}
+
 func logNotRequiredButFilledFieldForHelm(config *gitopsUpdateDeploymentOptions) {
 	if config.ContainerName != "" {
 		log.Entry().Info("containerName is not used for helm and can be removed")
@@ -204,6 +321,18 @@ func logNotRequiredButFilledFieldForKubectl(config *gitopsUpdateDeploymentOption
 	}
 }
 
+func logNotRequiredButFilledFieldForKustomize(config *gitopsUpdateDeploymentOptions) {
+	if config.ContainerName != "" {
+		log.Entry().Info("containerName is not used for kustomize and can be removed")
+	}
+	if config.ChartPath != "" {
+		log.Entry().Info("chartPath is not used for kustomize and can be removed")
+	}
+	if len(config.HelmValues) > 0 {
+		log.Entry().Info("helmValues is not used for kustomize and can be removed")
+	}
+}
+
 func cloneRepositoryAndChangeBranch(config *gitopsUpdateDeploymentOptions, gitUtils iGitopsUpdateDeploymentGitUtils, temporaryFolder string) error {
 	err := gitUtils.PlainClone(config.Username, config.Password, config.ServerURL, temporaryFolder)
 	if err != nil {
@@ -231,6 +360,76 @@ func executeKubectl(config *gitopsUpdateDeploymentOptions, command gitopsUpdateD
 	return outputBytes, nil
 }
 
+func updateKustomizeImage(config *gitopsUpdateDeploymentOptions, fileUtils gitopsUpdateDeploymentFileUtils, filePath string) ([]byte, error) {
+	registryImage, imageTag, err := buildRegistryPlusImageAndTagSeparately(config)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to extract registry URL, image name, and image tag")
+	}
+	content, err := fileUtils.FileRead(filePath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read kustomization '%v'", config.FilePath)
+	}
+
+	image := gitops.KustomizeImage{
+		Name:    imageName(config.ContainerImageNameTag),
+		Aliases: []string{registryImage},
+		NewName: registryImage,
+		NewTag:  imageTag,
+	}
+	outputBytes, err := gitops.SetKustomizeImage(content, image)
+	if err != nil {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return nil, errors.Wrapf(err, "failed to set image in kustomization '%v'", config.FilePath)
+	}
+	return outputBytes, nil
+}
+
+func updateYAMLImage(config *gitopsUpdateDeploymentOptions, fileUtils gitopsUpdateDeploymentFileUtils, filePath string) ([]byte, error) {
+	imagePaths := config.YamlImagePaths
+	if len(imagePaths) == 0 && len(config.YamlTagPaths) == 0 {
+		imagePaths = []string{"spec.template.spec.containers[name=" + config.ContainerName + "].image"}
+	}
+
+	outputBytes, err := fileUtils.FileRead(filePath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read file '%v'", config.FilePath)
+	}
+
+	var matches int
+	if len(imagePaths) > 0 {
+		registryImage, err := buildRegistryPlusImage(config)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to build image reference")
+		}
+		var imageMatches int
+		outputBytes, imageMatches, err = gitops.SetYAMLValues(outputBytes, imagePaths, registryImage)
+		if err != nil {
+			log.SetErrorCategory(log.ErrorConfiguration)
+			return nil, errors.Wrapf(err, "failed to set image in '%v'", config.FilePath)
+		}
+		matches += imageMatches
+	}
+	if len(config.YamlTagPaths) > 0 {
+		_, imageTag, err := buildRegistryPlusImageAndTagSeparately(config)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to extract image tag")
+		}
+		var tagMatches int
+		outputBytes, tagMatches, err = gitops.SetYAMLValues(outputBytes, config.YamlTagPaths, imageTag)
+		if err != nil {
+			log.SetErrorCategory(log.ErrorConfiguration)
+			return nil, errors.Wrapf(err, "failed to set image tag in '%v'", config.FilePath)
+		}
+		matches += tagMatches
+	}
+
+	if matches == 0 {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return nil, errors.Errorf("none of the YAML paths %v matches a value in '%v'", append(imagePaths, config.YamlTagPaths...), config.FilePath)
+	}
+	return outputBytes, nil
+}
+
 func buildRegistryPlusImage(config *gitopsUpdateDeploymentOptions) (string, error) {
 	registryURL := config.ContainerRegistryURL
 	if registryURL == "" {
@@ -308,20 +507,17 @@ func buildRegistryPlusImageAndTagSeparately(config *gitopsUpdateDeploymentOption
 		url = containerURL
 	}
 
-	imageNameTag := config.ContainerImageNameTag
-	var imageName, imageTag string
-	if strings.Contains(imageNameTag, ":") {
-		split := strings.Split(imageNameTag, ":")
-		if split[0] == "" {
+	if separator := tagSeparatorIndex(config.ContainerImageNameTag); separator >= 0 {
+		imageName := config.ContainerImageNameTag[:separator]
+		imageTag := config.ContainerImageNameTag[separator+1:]
+		if imageName == "" {
 			log.SetErrorCategory(log.ErrorConfiguration)
 			return "", "", errors.New("image name could not be extracted")
 		}
-		if split[1] == "" {
+		if imageTag == "" {
 			log.SetErrorCategory(log.ErrorConfiguration)
 			return "", "", errors.New("tag could not be extracted")
 		}
-		imageName = split[0]
-		imageTag = split[1]
 		return url + imageName, imageTag, nil
 	}
 
@@ -330,19 +526,19 @@ func buildRegistryPlusImageAndTagSeparately(config *gitopsUpdateDeploymentOption
 
 }
 
-func commitAndPushChanges(config *gitopsUpdateDeploymentOptions, gitUtils iGitopsUpdateDeploymentGitUtils) (plumbing.Hash, error) {
-	commitMessage := config.CommitMessage
-
-	if commitMessage == "" {
-		commitMessage = defaultCommitMessage(config)
-	}
-
-	commit, err := gitUtils.CommitSingleFile(config.FilePath, commitMessage, config.Username)
+// commitAndPushChanges pushes the changes to the given pull request branch, without pull request branch the changes are pushed directly
+func commitAndPushChanges(config *gitopsUpdateDeploymentOptions, gitUtils iGitopsUpdateDeploymentGitUtils, pullRequestBranch string) (plumbing.Hash, error) {
+	commit, err := gitUtils.CommitSingleFile(config.FilePath, commitMessage(config), config.Username)
 	if err != nil {
 		return [20]byte{}, errors.Wrap(err, "committing changes failed")
 	}
 
-	err = gitUtils.PushChangesToRepository(config.Username, config.Password)
+	if pullRequestBranch != "" {
+		// the branch is generated for the pull request, therefore a leftover of a previous run can be overwritten
+		err = gitUtils.PushBranchToRepository(config.Username, config.Password, pullRequestBranch, true)
+	} else {
+		err = gitUtils.PushChangesToRepository(config.Username, config.Password)
+	}
 	if err != nil {
 		return [20]byte{}, errors.Wrap(err, "pushing changes failed")
 	}
@@ -350,8 +546,41 @@ func commitAndPushChanges(config *gitopsUpdateDeploymentOptions, gitUtils iGitop
 	return commit, nil
 }
 
+func commitMessage(config *gitopsUpdateDeploymentOptions) string {
+	if config.CommitMessage != "" {
+		return config.CommitMessage
+	}
+	return defaultCommitMessage(config)
+}
+
+var invalidBranchCharacters = regexp.MustCompile(`[^A-Za-z0-9._/-]+`)
+
+// pullRequestBranchName returns the name of the pull request branch consisting of prefix, image name and tag
+func pullRequestBranchName(config *gitopsUpdateDeploymentOptions) string {
+	imageNameTag := strings.Replace(config.ContainerImageNameTag, ":", "-", 1)
+	return config.PullRequestBranchPrefix + invalidBranchCharacters.ReplaceAllString(imageNameTag, "-")
+}
+
 func defaultCommitMessage(config *gitopsUpdateDeploymentOptions) string {
 	image, tag, _ := buildRegistryPlusImageAndTagSeparately(config)
 	commitMessage := fmt.Sprintf("Updated %v to version %v", image, tag)
 	return commitMessage
 }
+
+// tagSeparatorIndex returns the index of the colon separating the tag from the image name or -1 if the image has no tag.
+// Colons before the last slash belong to the registry, e.g. the port of localhost:5000/app:1.0.
+func tagSeparatorIndex(imageNameTag string) int {
+	separator := strings.LastIndex(imageNameTag, ":")
+	if separator < strings.LastIndex(imageNameTag, "/") {
+		return -1
+	}
+	return separator
+}
+
+// imageName returns the image name without its tag
+func imageName(imageNameTag string) string {
+	if separator := tagSeparatorIndex(imageNameTag); separator >= 0 {
+		return imageNameTag[:separator]
+	}
+	return imageNameTag
+}
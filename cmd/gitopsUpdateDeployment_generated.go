@@ -5,10 +5,14 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/SAP/jenkins-library/pkg/config"
+	piperGithub "github.com/SAP/jenkins-library/pkg/github"
 	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/opentelemetry"
+	"github.com/SAP/jenkins-library/pkg/piperenv"
 	"github.com/SAP/jenkins-library/pkg/splunk"
 	"github.com/SAP/jenkins-library/pkg/telemetry"
 	"github.com/SAP/jenkins-library/pkg/validation"
@@ -16,19 +20,56 @@ import (
 )
 
 type gitopsUpdateDeploymentOptions struct {
-	BranchName            string   `json:"branchName,omitempty"`
-	CommitMessage         string   `json:"commitMessage,omitempty"`
-	ServerURL             string   `json:"serverUrl,omitempty"`
-	Username              string   `json:"username,omitempty"`
-	Password              string   `json:"password,omitempty"`
-	FilePath              string   `json:"filePath,omitempty"`
-	ContainerName         string   `json:"containerName,omitempty"`
-	ContainerRegistryURL  string   `json:"containerRegistryUrl,omitempty"`
-	ContainerImageNameTag string   `json:"containerImageNameTag,omitempty"`
-	ChartPath             string   `json:"chartPath,omitempty"`
-	HelmValues            []string `json:"helmValues,omitempty"`
-	DeploymentName        string   `json:"deploymentName,omitempty"`
-	Tool                  string   `json:"tool,omitempty" validate:"possible-values=kubectl helm"`
+	BranchName              string   `json:"branchName,omitempty"`
+	CommitMessage           string   `json:"commitMessage,omitempty"`
+	ServerURL               string   `json:"serverUrl,omitempty"`
+	Username                string   `json:"username,omitempty"`
+	Password                string   `json:"password,omitempty"`
+	FilePath                string   `json:"filePath,omitempty"`
+	ContainerName           string   `json:"containerName,omitempty"`
+	ContainerRegistryURL    string   `json:"containerRegistryUrl,omitempty"`
+	ContainerImageNameTag   string   `json:"containerImageNameTag,omitempty"`
+	ChartPath               string   `json:"chartPath,omitempty"`
+	HelmValues              []string `json:"helmValues,omitempty"`
+	DeploymentName          string   `json:"deploymentName,omitempty"`
+	YamlImagePaths          []string `json:"yamlImagePaths,omitempty"`
+	YamlTagPaths            []string `json:"yamlTagPaths,omitempty"`
+	Tool                    string   `json:"tool,omitempty" validate:"possible-values=kubectl helm kustomize yaml"`
+	CreatePullRequest       bool     `json:"createPullRequest,omitempty"`
+	PullRequestProvider     string   `json:"pullRequestProvider,omitempty" validate:"possible-values=github azure"`
+	PullRequestBranchPrefix string   `json:"pullRequestBranchPrefix,omitempty"`
+	PullRequestLabels       []string `json:"pullRequestLabels,omitempty"`
+	PullRequestAutoMerge    bool     `json:"pullRequestAutoMerge,omitempty"`
+	PullRequestMergeMethod  string   `json:"pullRequestMergeMethod,omitempty" validate:"possible-values=merge squash rebase"`
+	GithubAPIURL            string   `json:"githubApiUrl,omitempty"`
+}
+
+type gitopsUpdateDeploymentCommonPipelineEnvironment struct {
+	custom struct {
+		gitopsPullRequestURL string
+	}
+}
+
+func (p *gitopsUpdateDeploymentCommonPipelineEnvironment) persist(path, resourceName string) {
+	content := []struct {
+		category string
+		name     string
+		value    interface{}
+	}{
+		{category: "custom", name: "gitopsPullRequestUrl", value: p.custom.gitopsPullRequestURL},
+	}
+
+	errCount := 0
+	for _, param := range content {
+		err := piperenv.SetResourceParameter(path, resourceName, filepath.Join(param.category, param.name), param.value)
+		if err != nil {
+			log.Entry().WithError(err).Error("Error persisting piper environment.")
+			errCount++
+		}
+	}
+	if errCount > 0 {
+		log.Entry().Fatal("failed to persist Piper environment")
+	}
 }
 
 // GitopsUpdateDeploymentCommand Updates Kubernetes Deployment Manifest in an Infrastructure Git Repository
@@ -38,8 +79,11 @@ func GitopsUpdateDeploymentCommand() *cobra.Command {
 	metadata := gitopsUpdateDeploymentMetadata()
 	var stepConfig gitopsUpdateDeploymentOptions
 	var startTime time.Time
+	var commonPipelineEnvironment gitopsUpdateDeploymentCommonPipelineEnvironment
 	var logCollector *log.CollectorHook
 	var splunkClient *splunk.Splunk
+	var otelClient *opentelemetry.OpenTelemetry
+	var checkRun *piperGithub.StepCheckRun
 	telemetryClient := &telemetry.Telemetry{}
 
 	var createGitopsUpdateDeploymentCmd = &cobra.Command{
@@ -49,9 +93,18 @@ func GitopsUpdateDeploymentCommand() *cobra.Command {
 
 It can for example be used for GitOps scenarios where the update of the manifests triggers an update of the corresponding deployment in Kubernetes.
 
-As of today, it supports the update of deployment yaml files via kubectl patch and update a whole helm template.
+As of today, it supports the update of deployment yaml files via kubectl patch, update a whole helm template, the update of the images of a kustomization as well as the update of image references at arbitrary YAML paths.
 For kubectl the container inside the yaml must be described within the following hierarchy: ` + "`" + `{"spec":{"template":{"spec":{"containers":[{...}]}}}}` + "`" + `
-For helm the whole template is generated into a file and uploaded into the repository.`,
+For helm the whole template is generated into a file and uploaded into the repository.
+For kustomize the entry of the image in the ` + "`" + `images` + "`" + ` list of the ` + "`" + `kustomization.yaml` + "`" + ` defined by ` + "`" + `filePath` + "`" + ` is updated, a new entry is added if the image is not yet listed.
+For yaml the values at the paths defined by ` + "`" + `yamlImagePaths` + "`" + ` and ` + "`" + `yamlTagPaths` + "`" + ` are updated without calling any CLI, e.g. for Argo CD ApplicationSets.
+Paths consist of keys separated by dots, list entries can be selected by index (` + "`" + `[0]` + "`" + `), by a key value pair (` + "`" + `[name=myContainer]` + "`" + `) or all at once (` + "`" + `[*]` + "`" + `).
+
+For kustomize and yaml only the affected values are changed, formatting and comments of the file are kept.
+
+In case the branch is protected the changes can be proposed via pull request using ` + "`" + `createPullRequest` + "`" + `.
+The changes are pushed to a feature branch and a pull request into ` + "`" + `branchName` + "`" + ` is opened on GitHub or Azure DevOps.
+Optionally the pull request is merged automatically as soon as all required checks have passed.`,
 		PreRunE: func(cmd *cobra.Command, _ []string) error {
 			startTime = time.Now()
 			log.SetStepName(STEP_NAME)
@@ -82,6 +135,13 @@ For helm the whole template is generated into a file and uploaded into the repos
 				log.RegisterHook(logCollector)
 			}
 
+			if len(GeneralConfig.HookConfig.OpenTelemetryConfig.Endpoint) > 0 {
+				otelClient = &opentelemetry.OpenTelemetry{}
+				if err := otelClient.Initialize(GeneralConfig.CorrelationID, STEP_NAME, GeneralConfig.HookConfig.OpenTelemetryConfig); err != nil {
+					log.Entry().WithError(err).Warning("failed to initialize OpenTelemetry")
+				}
+			}
+
 			validation, err := validation.New(validation.WithJSONNamesForStructFields(), validation.WithPredefinedErrorMessages())
 			if err != nil {
 				return err
@@ -98,6 +158,7 @@ For helm the whole template is generated into a file and uploaded into the repos
 			stepTelemetryData.ErrorCode = "1"
 			handler := func() {
 				config.RemoveVaultSecretFiles()
+				commonPipelineEnvironment.persist(GeneralConfig.EnvRootPath, "commonPipelineEnvironment")
 				stepTelemetryData.Duration = fmt.Sprintf("%v", time.Since(startTime).Milliseconds())
 				stepTelemetryData.ErrorCategory = log.GetErrorCategory().String()
 				stepTelemetryData.PiperCommitHash = GitCommit
@@ -106,6 +167,12 @@ For helm the whole template is generated into a file and uploaded into the repos
 				if len(GeneralConfig.HookConfig.SplunkConfig.Dsn) > 0 {
 					splunkClient.Send(telemetryClient.GetData(), logCollector)
 				}
+				if len(GeneralConfig.HookConfig.OpenTelemetryConfig.Endpoint) > 0 {
+					otelClient.Send(telemetryClient.GetData().Attributes())
+				}
+				if checkRun != nil {
+					checkRun.Complete(stepTelemetryData.ErrorCode != "0", log.GetErrorCategory())
+				}
 			}
 			log.DeferExitHandler(handler)
 			defer handler()
@@ -117,7 +184,10 @@ For helm the whole template is generated into a file and uploaded into the repos
 					GeneralConfig.HookConfig.SplunkConfig.Index,
 					GeneralConfig.HookConfig.SplunkConfig.SendLogs)
 			}
-			gitopsUpdateDeployment(stepConfig, &stepTelemetryData)
+			if GeneralConfig.HookConfig.GitHubChecksConfig.Enabled {
+				checkRun = piperGithub.StartStepCheckRun(STEP_NAME, GeneralConfig.StageName, GeneralConfig.HookConfig.GitHubChecksConfig)
+			}
+			gitopsUpdateDeployment(stepConfig, &stepTelemetryData, &commonPipelineEnvironment)
 			stepTelemetryData.ErrorCode = "0"
 			log.Entry().Info("SUCCESS")
 		},
@@ -140,7 +210,16 @@ func addGitopsUpdateDeploymentFlags(cmd *cobra.Command, stepConfig *gitopsUpdate
 	cmd.Flags().StringVar(&stepConfig.ChartPath, "chartPath", os.Getenv("PIPER_chartPath"), "Defines the chart path for deployments using helm.")
 	cmd.Flags().StringSliceVar(&stepConfig.HelmValues, "helmValues", []string{}, "List of helm values as YAML file reference or URL (as per helm parameter description for `-f` / `--values`)")
 	cmd.Flags().StringVar(&stepConfig.DeploymentName, "deploymentName", os.Getenv("PIPER_deploymentName"), "Defines the name of the deployment.")
+	cmd.Flags().StringSliceVar(&stepConfig.YamlImagePaths, "yamlImagePaths", []string{}, "Defines the YAML paths of the values which are set to the image including registry and tag, e.g. `spec.template.spec.containers[name=myContainer].image`. Only used for tool `yaml`, defaults to the image of the container `containerName` in case neither `yamlImagePaths` nor `yamlTagPaths` are defined.")
+	cmd.Flags().StringSliceVar(&stepConfig.YamlTagPaths, "yamlTagPaths", []string{}, "Defines the YAML paths of the values which are set to the image tag, e.g. `spec.generators[0].list.elements[*].imageTag`. Only used for tool `yaml`.")
 	cmd.Flags().StringVar(&stepConfig.Tool, "tool", `kubectl`, "Defines the tool which should be used to update the deployment description.")
+	cmd.Flags().BoolVar(&stepConfig.CreatePullRequest, "createPullRequest", false, "Pushes the changes to a feature branch and creates a pull request into `branchName` instead of pushing to `branchName` directly, e.g. in case `branchName` is protected.")
+	cmd.Flags().StringVar(&stepConfig.PullRequestProvider, "pullRequestProvider", `github`, "Defines where the repository is hosted and the pull request is created. The password is used as access token for the API.")
+	cmd.Flags().StringVar(&stepConfig.PullRequestBranchPrefix, "pullRequestBranchPrefix", `gitops/`, "Prefix of the feature branch for the pull request, the branch name is completed with image name and tag, e.g. `gitops/myImage-1.0.0`.")
+	cmd.Flags().StringSliceVar(&stepConfig.PullRequestLabels, "pullRequestLabels", []string{}, "Labels of the pull request. Only used for GitHub.")
+	cmd.Flags().BoolVar(&stepConfig.PullRequestAutoMerge, "pullRequestAutoMerge", false, "Enables auto-merge (GitHub) or auto-complete (Azure DevOps) of the pull request, it is merged as soon as all required checks have passed. Auto-merge needs to be allowed in the settings of GitHub repositories.")
+	cmd.Flags().StringVar(&stepConfig.PullRequestMergeMethod, "pullRequestMergeMethod", `squash`, "Defines how the pull request is merged in case `pullRequestAutoMerge` is active.")
+	cmd.Flags().StringVar(&stepConfig.GithubAPIURL, "githubApiUrl", `https://api.github.com`, "Set the GitHub API URL, used for the creation of pull requests.")
 
 	cmd.MarkFlagRequired("branchName")
 	cmd.MarkFlagRequired("serverUrl")
@@ -299,6 +378,24 @@ func gitopsUpdateDeploymentMetadata() config.StepData {
 						Aliases:     []config.Alias{{Name: "helmDeploymentName"}},
 						Default:     os.Getenv("PIPER_deploymentName"),
 					},
+					{
+						Name:        "yamlImagePaths",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "[]string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     []string{},
+					},
+					{
+						Name:        "yamlTagPaths",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "[]string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     []string{},
+					},
 					{
 						Name:        "tool",
 						ResourceRef: []config.ResourceReference{},
@@ -308,12 +405,86 @@ func gitopsUpdateDeploymentMetadata() config.StepData {
 						Aliases:     []config.Alias{},
 						Default:     `kubectl`,
 					},
+					{
+						Name:        "createPullRequest",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "bool",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     false,
+					},
+					{
+						Name:        "pullRequestProvider",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     `github`,
+					},
+					{
+						Name:        "pullRequestBranchPrefix",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     `gitops/`,
+					},
+					{
+						Name:        "pullRequestLabels",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "[]string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     []string{},
+					},
+					{
+						Name:        "pullRequestAutoMerge",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "bool",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     false,
+					},
+					{
+						Name:        "pullRequestMergeMethod",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     `squash`,
+					},
+					{
+						Name:        "githubApiUrl",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"GENERAL", "PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     `https://api.github.com`,
+					},
 				},
 			},
 			Containers: []config.Container{
 				{Image: "dtzar/helm-kubectl:3.3.4", WorkingDir: "/config", Options: []config.Option{{Name: "-u", Value: "0"}}, Conditions: []config.Condition{{ConditionRef: "strings-equal", Params: []config.Param{{Name: "tool", Value: "helm"}}}}},
 				{Image: "dtzar/helm-kubectl:2.17.0", WorkingDir: "/config", Options: []config.Option{{Name: "-u", Value: "0"}}, Conditions: []config.Condition{{ConditionRef: "strings-equal", Params: []config.Param{{Name: "tool", Value: "kubectl"}}}}},
 			},
+			Outputs: config.StepOutputs{
+				Resources: []config.StepResources{
+					{
+						Name: "commonPipelineEnvironment",
+						Type: "piperEnvironment",
+						Parameters: []map[string]interface{}{
+							{"Name": "custom/gitopsPullRequestUrl"},
+						},
+					},
+				},
+			},
 		},
 	}
 	return theMetaData
@@ -67,6 +67,15 @@ func TestBuildRegistryPlusImageWithoutTag(t *testing.T) {
 		assert.Equal(t, "myFancyContainer", registryImage)
 		assert.Equal(t, "1337", tag)
 	})
+	t.Run("registry with port", func(t *testing.T) {
+		t.Parallel()
+		registryImage, tag, err := buildRegistryPlusImageAndTagSeparately(&gitopsUpdateDeploymentOptions{
+			ContainerImageNameTag: "localhost:5000/myFancyContainer:1337",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "localhost:5000/myFancyContainer", registryImage)
+		assert.Equal(t, "1337", tag)
+	})
 	t.Run("without faulty URL", func(t *testing.T) {
 		t.Parallel()
 		_, _, err := buildRegistryPlusImageAndTagSeparately(&gitopsUpdateDeploymentOptions{
@@ -97,7 +106,7 @@ func TestRunGitopsUpdateDeploymentWithKubectl(t *testing.T) {
 		gitUtilsMock := &gitUtilsMock{}
 		runnerMock := &gitOpsExecRunnerMock{}
 
-		err := runGitopsUpdateDeployment(validConfiguration, runnerMock, gitUtilsMock, &filesMock{})
+		err := runGitopsUpdateDeployment(validConfiguration, runnerMock, gitUtilsMock, &filesMock{}, &pullRequestUtilsMock{}, &gitopsUpdateDeploymentCommonPipelineEnvironment{})
 		assert.NoError(t, err)
 		assert.Equal(t, validConfiguration.BranchName, gitUtilsMock.changedBranch)
 		assert.Equal(t, expectedYaml, gitUtilsMock.savedFile)
@@ -118,7 +127,7 @@ func TestRunGitopsUpdateDeploymentWithKubectl(t *testing.T) {
 		gitUtilsMock := &gitUtilsMock{}
 		runnerMock := &gitOpsExecRunnerMock{}
 
-		err := runGitopsUpdateDeployment(&configuration, runnerMock, gitUtilsMock, &filesMock{})
+		err := runGitopsUpdateDeployment(&configuration, runnerMock, gitUtilsMock, &filesMock{}, &pullRequestUtilsMock{}, &gitopsUpdateDeploymentCommonPipelineEnvironment{})
 		assert.NoError(t, err)
 		assert.Equal(t, validConfiguration.BranchName, gitUtilsMock.changedBranch)
 		assert.Equal(t, expectedYaml, gitUtilsMock.savedFile)
@@ -139,7 +148,7 @@ func TestRunGitopsUpdateDeploymentWithKubectl(t *testing.T) {
 		gitUtilsMock := &gitUtilsMock{}
 		runnerMock := &gitOpsExecRunnerMock{}
 
-		err := runGitopsUpdateDeployment(&configuration, runnerMock, gitUtilsMock, &filesMock{})
+		err := runGitopsUpdateDeployment(&configuration, runnerMock, gitUtilsMock, &filesMock{}, &pullRequestUtilsMock{}, &gitopsUpdateDeploymentCommonPipelineEnvironment{})
 		assert.NoError(t, err)
 		assert.Equal(t, configuration.BranchName, gitUtilsMock.changedBranch)
 		assert.Equal(t, expectedYaml, gitUtilsMock.savedFile)
@@ -159,7 +168,7 @@ func TestRunGitopsUpdateDeploymentWithKubectl(t *testing.T) {
 		gitUtilsMock := &gitUtilsMock{}
 		runnerMock := &gitOpsExecRunnerMock{}
 
-		err := runGitopsUpdateDeployment(&configuration, runnerMock, gitUtilsMock, &filesMock{})
+		err := runGitopsUpdateDeployment(&configuration, runnerMock, gitUtilsMock, &filesMock{}, &pullRequestUtilsMock{}, &gitopsUpdateDeploymentCommonPipelineEnvironment{})
 		assert.NoError(t, err)
 		assert.Equal(t, configuration.BranchName, gitUtilsMock.changedBranch)
 		assert.Equal(t, expectedYaml, gitUtilsMock.savedFile)
@@ -179,7 +188,7 @@ func TestRunGitopsUpdateDeploymentWithKubectl(t *testing.T) {
 		gitUtilsMock := &gitUtilsMock{}
 		runnerMock := &gitOpsExecRunnerMock{}
 
-		err := runGitopsUpdateDeployment(&configuration, runnerMock, gitUtilsMock, &filesMock{})
+		err := runGitopsUpdateDeployment(&configuration, runnerMock, gitUtilsMock, &filesMock{}, &pullRequestUtilsMock{}, &gitopsUpdateDeploymentCommonPipelineEnvironment{})
 		assert.NoError(t, err)
 		assert.Equal(t, configuration.BranchName, gitUtilsMock.changedBranch)
 		assert.Equal(t, expectedYaml, gitUtilsMock.savedFile)
@@ -199,7 +208,7 @@ func TestRunGitopsUpdateDeploymentWithKubectl(t *testing.T) {
 		gitUtilsMock := &gitUtilsMock{}
 		runnerMock := &gitOpsExecRunnerMock{}
 
-		err := runGitopsUpdateDeployment(&configuration, runnerMock, gitUtilsMock, &filesMock{})
+		err := runGitopsUpdateDeployment(&configuration, runnerMock, gitUtilsMock, &filesMock{}, &pullRequestUtilsMock{}, &gitopsUpdateDeploymentCommonPipelineEnvironment{})
 		assert.EqualError(t, err, "missing required fields for kubectl: the following parameters are necessary for kubectl: [containerName]")
 	})
 
@@ -207,7 +216,7 @@ func TestRunGitopsUpdateDeploymentWithKubectl(t *testing.T) {
 		t.Parallel()
 		runner := &gitOpsExecRunnerMock{failOnRunExecutable: true}
 
-		err := runGitopsUpdateDeployment(validConfiguration, runner, &gitUtilsMock{}, &filesMock{})
+		err := runGitopsUpdateDeployment(validConfiguration, runner, &gitUtilsMock{}, &filesMock{}, &pullRequestUtilsMock{}, &gitopsUpdateDeploymentCommonPipelineEnvironment{})
 		assert.EqualError(t, err, "error on kubectl execution: failed to apply kubectl command: failed to apply kubectl command: error happened")
 	})
 
@@ -216,7 +225,7 @@ func TestRunGitopsUpdateDeploymentWithKubectl(t *testing.T) {
 		var configuration = *validConfiguration
 		configuration.ContainerRegistryURL = "//myregistry.com/registry/containers"
 
-		err := runGitopsUpdateDeployment(&configuration, &gitOpsExecRunnerMock{}, &gitUtilsMock{}, &filesMock{})
+		err := runGitopsUpdateDeployment(&configuration, &gitOpsExecRunnerMock{}, &gitUtilsMock{}, &filesMock{}, &pullRequestUtilsMock{}, &gitopsUpdateDeploymentCommonPipelineEnvironment{})
 		assert.EqualError(t, err, "error on kubectl execution: failed to apply kubectl command: registry URL could not be extracted: invalid registry url")
 	})
 
@@ -224,7 +233,7 @@ func TestRunGitopsUpdateDeploymentWithKubectl(t *testing.T) {
 		t.Parallel()
 		gitUtils := &gitUtilsMock{failOnClone: true}
 
-		err := runGitopsUpdateDeployment(validConfiguration, &gitOpsExecRunnerMock{}, gitUtils, &filesMock{})
+		err := runGitopsUpdateDeployment(validConfiguration, &gitOpsExecRunnerMock{}, gitUtils, &filesMock{}, &pullRequestUtilsMock{}, &gitopsUpdateDeploymentCommonPipelineEnvironment{})
 		assert.EqualError(t, err, "repository could not get prepared: failed to plain clone repository: error on clone")
 	})
 
@@ -232,7 +241,7 @@ func TestRunGitopsUpdateDeploymentWithKubectl(t *testing.T) {
 		t.Parallel()
 		gitUtils := &gitUtilsMock{failOnChangeBranch: true}
 
-		err := runGitopsUpdateDeployment(validConfiguration, &gitOpsExecRunnerMock{}, gitUtils, &filesMock{})
+		err := runGitopsUpdateDeployment(validConfiguration, &gitOpsExecRunnerMock{}, gitUtils, &filesMock{}, &pullRequestUtilsMock{}, &gitopsUpdateDeploymentCommonPipelineEnvironment{})
 		assert.EqualError(t, err, "repository could not get prepared: failed to change branch: error on change branch")
 	})
 
@@ -240,7 +249,7 @@ func TestRunGitopsUpdateDeploymentWithKubectl(t *testing.T) {
 		t.Parallel()
 		gitUtils := &gitUtilsMock{failOnCommit: true}
 
-		err := runGitopsUpdateDeployment(validConfiguration, &gitOpsExecRunnerMock{}, gitUtils, &filesMock{})
+		err := runGitopsUpdateDeployment(validConfiguration, &gitOpsExecRunnerMock{}, gitUtils, &filesMock{}, &pullRequestUtilsMock{}, &gitopsUpdateDeploymentCommonPipelineEnvironment{})
 		assert.EqualError(t, err, "failed to commit and push changes: committing changes failed: error on commit")
 	})
 
@@ -248,7 +257,7 @@ func TestRunGitopsUpdateDeploymentWithKubectl(t *testing.T) {
 		t.Parallel()
 		gitUtils := &gitUtilsMock{failOnPush: true}
 
-		err := runGitopsUpdateDeployment(validConfiguration, &gitOpsExecRunnerMock{}, gitUtils, &filesMock{})
+		err := runGitopsUpdateDeployment(validConfiguration, &gitOpsExecRunnerMock{}, gitUtils, &filesMock{}, &pullRequestUtilsMock{}, &gitopsUpdateDeploymentCommonPipelineEnvironment{})
 		assert.EqualError(t, err, "failed to commit and push changes: pushing changes failed: error on push")
 	})
 
@@ -256,7 +265,7 @@ func TestRunGitopsUpdateDeploymentWithKubectl(t *testing.T) {
 		t.Parallel()
 		fileUtils := &filesMock{failOnCreation: true}
 
-		err := runGitopsUpdateDeployment(validConfiguration, &gitOpsExecRunnerMock{}, &gitUtilsMock{}, fileUtils)
+		err := runGitopsUpdateDeployment(validConfiguration, &gitOpsExecRunnerMock{}, &gitUtilsMock{}, fileUtils, &pullRequestUtilsMock{}, &gitopsUpdateDeploymentCommonPipelineEnvironment{})
 		assert.EqualError(t, err, "failed to create temporary directory: error appeared")
 	})
 
@@ -264,7 +273,7 @@ func TestRunGitopsUpdateDeploymentWithKubectl(t *testing.T) {
 		t.Parallel()
 		fileUtils := &filesMock{failOnWrite: true}
 
-		err := runGitopsUpdateDeployment(validConfiguration, &gitOpsExecRunnerMock{}, &gitUtilsMock{}, fileUtils)
+		err := runGitopsUpdateDeployment(validConfiguration, &gitOpsExecRunnerMock{}, &gitUtilsMock{}, fileUtils, &pullRequestUtilsMock{}, &gitopsUpdateDeploymentCommonPipelineEnvironment{})
 		assert.EqualError(t, err, "failed to write file: error appeared")
 	})
 
@@ -272,7 +281,7 @@ func TestRunGitopsUpdateDeploymentWithKubectl(t *testing.T) {
 		t.Parallel()
 		fileUtils := &filesMock{failOnDeletion: true}
 
-		err := runGitopsUpdateDeployment(validConfiguration, &gitOpsExecRunnerMock{}, &gitUtilsMock{}, fileUtils)
+		err := runGitopsUpdateDeployment(validConfiguration, &gitOpsExecRunnerMock{}, &gitUtilsMock{}, fileUtils, &pullRequestUtilsMock{}, &gitopsUpdateDeploymentCommonPipelineEnvironment{})
 		assert.NoError(t, err)
 		_ = piperutils.Files{}.RemoveAll(fileUtils.path)
 	})
@@ -297,7 +306,7 @@ func TestRunGitopsUpdateDeploymentWithInvalid(t *testing.T) {
 			HelmValues:            []string{"./helm/additionalValues.yaml"},
 		}
 
-		err := runGitopsUpdateDeployment(configuration, &gitOpsExecRunnerMock{}, &gitUtilsMock{}, &filesMock{})
+		err := runGitopsUpdateDeployment(configuration, &gitOpsExecRunnerMock{}, &gitUtilsMock{}, &filesMock{}, &pullRequestUtilsMock{}, &gitopsUpdateDeploymentCommonPipelineEnvironment{})
 		assert.EqualError(t, err, "tool invalid is not supported")
 	})
 }
@@ -324,7 +333,7 @@ func TestRunGitopsUpdateDeploymentWithHelm(t *testing.T) {
 		gitUtilsMock := &gitUtilsMock{}
 		runnerMock := &gitOpsExecRunnerMock{}
 
-		err := runGitopsUpdateDeployment(validConfiguration, runnerMock, gitUtilsMock, &filesMock{})
+		err := runGitopsUpdateDeployment(validConfiguration, runnerMock, gitUtilsMock, &filesMock{}, &pullRequestUtilsMock{}, &gitopsUpdateDeploymentCommonPipelineEnvironment{})
 		assert.NoError(t, err)
 		assert.Equal(t, validConfiguration.BranchName, gitUtilsMock.changedBranch)
 		assert.Equal(t, expectedYaml, gitUtilsMock.savedFile)
@@ -347,7 +356,7 @@ func TestRunGitopsUpdateDeploymentWithHelm(t *testing.T) {
 		gitUtilsMock := &gitUtilsMock{}
 		runnerMock := &gitOpsExecRunnerMock{}
 
-		err := runGitopsUpdateDeployment(&configuration, runnerMock, gitUtilsMock, &filesMock{})
+		err := runGitopsUpdateDeployment(&configuration, runnerMock, gitUtilsMock, &filesMock{}, &pullRequestUtilsMock{}, &gitopsUpdateDeploymentCommonPipelineEnvironment{})
 		assert.NoError(t, err)
 		assert.Equal(t, configuration.BranchName, gitUtilsMock.changedBranch)
 		assert.Equal(t, expectedYaml, gitUtilsMock.savedFile)
@@ -370,7 +379,7 @@ func TestRunGitopsUpdateDeploymentWithHelm(t *testing.T) {
 		gitUtilsMock := &gitUtilsMock{}
 		runnerMock := &gitOpsExecRunnerMock{}
 
-		err := runGitopsUpdateDeployment(&configuration, runnerMock, gitUtilsMock, &filesMock{})
+		err := runGitopsUpdateDeployment(&configuration, runnerMock, gitUtilsMock, &filesMock{}, &pullRequestUtilsMock{}, &gitopsUpdateDeploymentCommonPipelineEnvironment{})
 		assert.NoError(t, err)
 		assert.Equal(t, configuration.BranchName, gitUtilsMock.changedBranch)
 		assert.Equal(t, expectedYaml, gitUtilsMock.savedFile)
@@ -392,7 +401,7 @@ func TestRunGitopsUpdateDeploymentWithHelm(t *testing.T) {
 		gitUtilsMock := &gitUtilsMock{}
 		runnerMock := &gitOpsExecRunnerMock{}
 
-		err := runGitopsUpdateDeployment(&configuration, runnerMock, gitUtilsMock, &filesMock{})
+		err := runGitopsUpdateDeployment(&configuration, runnerMock, gitUtilsMock, &filesMock{}, &pullRequestUtilsMock{}, &gitopsUpdateDeploymentCommonPipelineEnvironment{})
 		assert.NoError(t, err)
 		assert.Equal(t, configuration.BranchName, gitUtilsMock.changedBranch)
 		assert.Equal(t, expectedYaml, gitUtilsMock.savedFile)
@@ -409,7 +418,7 @@ func TestRunGitopsUpdateDeploymentWithHelm(t *testing.T) {
 		var configuration = *validConfiguration
 		configuration.ContainerRegistryURL = "://myregistry.com"
 
-		err := runGitopsUpdateDeployment(&configuration, &gitOpsExecRunnerMock{}, &gitUtilsMock{}, &filesMock{})
+		err := runGitopsUpdateDeployment(&configuration, &gitOpsExecRunnerMock{}, &gitUtilsMock{}, &filesMock{}, &pullRequestUtilsMock{}, &gitopsUpdateDeploymentCommonPipelineEnvironment{})
 		assert.EqualError(t, err, `failed to apply helm command: failed to extract registry URL, image name, and image tag: registry URL could not be extracted: invalid registry url: parse "://myregistry.com": missing protocol scheme`)
 	})
 
@@ -418,7 +427,7 @@ func TestRunGitopsUpdateDeploymentWithHelm(t *testing.T) {
 		var configuration = *validConfiguration
 		configuration.ChartPath = ""
 
-		err := runGitopsUpdateDeployment(&configuration, &gitOpsExecRunnerMock{}, &gitUtilsMock{}, &filesMock{})
+		err := runGitopsUpdateDeployment(&configuration, &gitOpsExecRunnerMock{}, &gitUtilsMock{}, &filesMock{}, &pullRequestUtilsMock{}, &gitopsUpdateDeploymentCommonPipelineEnvironment{})
 		assert.EqualError(t, err, "missing required fields for helm: the following parameters are necessary for helm: [chartPath]")
 	})
 
@@ -427,7 +436,7 @@ func TestRunGitopsUpdateDeploymentWithHelm(t *testing.T) {
 		var configuration = *validConfiguration
 		configuration.DeploymentName = ""
 
-		err := runGitopsUpdateDeployment(&configuration, &gitOpsExecRunnerMock{}, &gitUtilsMock{}, &filesMock{})
+		err := runGitopsUpdateDeployment(&configuration, &gitOpsExecRunnerMock{}, &gitUtilsMock{}, &filesMock{}, &pullRequestUtilsMock{}, &gitopsUpdateDeploymentCommonPipelineEnvironment{})
 		assert.EqualError(t, err, "missing required fields for helm: the following parameters are necessary for helm: [deploymentName]")
 	})
 
@@ -437,7 +446,7 @@ func TestRunGitopsUpdateDeploymentWithHelm(t *testing.T) {
 		configuration.DeploymentName = ""
 		configuration.ChartPath = ""
 
-		err := runGitopsUpdateDeployment(&configuration, &gitOpsExecRunnerMock{}, &gitUtilsMock{}, &filesMock{})
+		err := runGitopsUpdateDeployment(&configuration, &gitOpsExecRunnerMock{}, &gitUtilsMock{}, &filesMock{}, &pullRequestUtilsMock{}, &gitopsUpdateDeploymentCommonPipelineEnvironment{})
 		assert.EqualError(t, err, "missing required fields for helm: the following parameters are necessary for helm: [chartPath deploymentName]")
 	})
 
@@ -446,7 +455,7 @@ func TestRunGitopsUpdateDeploymentWithHelm(t *testing.T) {
 		var configuration = *validConfiguration
 		configuration.ContainerImageNameTag = "registry/containers/myFancyContainer:"
 
-		err := runGitopsUpdateDeployment(&configuration, &gitOpsExecRunnerMock{}, &gitUtilsMock{}, &filesMock{})
+		err := runGitopsUpdateDeployment(&configuration, &gitOpsExecRunnerMock{}, &gitUtilsMock{}, &filesMock{}, &pullRequestUtilsMock{}, &gitopsUpdateDeploymentCommonPipelineEnvironment{})
 		assert.EqualError(t, err, "failed to apply helm command: failed to extract registry URL, image name, and image tag: tag could not be extracted")
 	})
 
@@ -455,7 +464,7 @@ func TestRunGitopsUpdateDeploymentWithHelm(t *testing.T) {
 		var configuration = *validConfiguration
 		configuration.ContainerImageNameTag = ":1.0.1"
 
-		err := runGitopsUpdateDeployment(&configuration, &gitOpsExecRunnerMock{}, &gitUtilsMock{}, &filesMock{})
+		err := runGitopsUpdateDeployment(&configuration, &gitOpsExecRunnerMock{}, &gitUtilsMock{}, &filesMock{}, &pullRequestUtilsMock{}, &gitopsUpdateDeploymentCommonPipelineEnvironment{})
 		assert.EqualError(t, err, "failed to apply helm command: failed to extract registry URL, image name, and image tag: image name could not be extracted")
 	})
 
@@ -463,7 +472,7 @@ func TestRunGitopsUpdateDeploymentWithHelm(t *testing.T) {
 		t.Parallel()
 		runner := &gitOpsExecRunnerMock{failOnRunExecutable: true}
 
-		err := runGitopsUpdateDeployment(validConfiguration, runner, &gitUtilsMock{}, &filesMock{})
+		err := runGitopsUpdateDeployment(validConfiguration, runner, &gitUtilsMock{}, &filesMock{}, &pullRequestUtilsMock{}, &gitopsUpdateDeploymentCommonPipelineEnvironment{})
 		assert.EqualError(t, err, "failed to apply helm command: failed to execute helm command: error happened")
 	})
 
@@ -471,7 +480,7 @@ func TestRunGitopsUpdateDeploymentWithHelm(t *testing.T) {
 		t.Parallel()
 		gitUtils := &gitUtilsMock{failOnClone: true}
 
-		err := runGitopsUpdateDeployment(validConfiguration, &gitOpsExecRunnerMock{}, gitUtils, &filesMock{})
+		err := runGitopsUpdateDeployment(validConfiguration, &gitOpsExecRunnerMock{}, gitUtils, &filesMock{}, &pullRequestUtilsMock{}, &gitopsUpdateDeploymentCommonPipelineEnvironment{})
 		assert.EqualError(t, err, "repository could not get prepared: failed to plain clone repository: error on clone")
 	})
 
@@ -479,7 +488,7 @@ func TestRunGitopsUpdateDeploymentWithHelm(t *testing.T) {
 		t.Parallel()
 		gitUtils := &gitUtilsMock{failOnChangeBranch: true}
 
-		err := runGitopsUpdateDeployment(validConfiguration, &gitOpsExecRunnerMock{}, gitUtils, &filesMock{})
+		err := runGitopsUpdateDeployment(validConfiguration, &gitOpsExecRunnerMock{}, gitUtils, &filesMock{}, &pullRequestUtilsMock{}, &gitopsUpdateDeploymentCommonPipelineEnvironment{})
 		assert.EqualError(t, err, "repository could not get prepared: failed to change branch: error on change branch")
 	})
 
@@ -487,7 +496,7 @@ func TestRunGitopsUpdateDeploymentWithHelm(t *testing.T) {
 		t.Parallel()
 		gitUtils := &gitUtilsMock{failOnCommit: true}
 
-		err := runGitopsUpdateDeployment(validConfiguration, &gitOpsExecRunnerMock{}, gitUtils, &filesMock{})
+		err := runGitopsUpdateDeployment(validConfiguration, &gitOpsExecRunnerMock{}, gitUtils, &filesMock{}, &pullRequestUtilsMock{}, &gitopsUpdateDeploymentCommonPipelineEnvironment{})
 		assert.EqualError(t, err, "failed to commit and push changes: committing changes failed: error on commit")
 	})
 
@@ -495,7 +504,7 @@ func TestRunGitopsUpdateDeploymentWithHelm(t *testing.T) {
 		t.Parallel()
 		gitUtils := &gitUtilsMock{failOnPush: true}
 
-		err := runGitopsUpdateDeployment(validConfiguration, &gitOpsExecRunnerMock{}, gitUtils, &filesMock{})
+		err := runGitopsUpdateDeployment(validConfiguration, &gitOpsExecRunnerMock{}, gitUtils, &filesMock{}, &pullRequestUtilsMock{}, &gitopsUpdateDeploymentCommonPipelineEnvironment{})
 		assert.EqualError(t, err, "failed to commit and push changes: pushing changes failed: error on push")
 	})
 
@@ -503,7 +512,7 @@ func TestRunGitopsUpdateDeploymentWithHelm(t *testing.T) {
 		t.Parallel()
 		fileUtils := &filesMock{failOnCreation: true}
 
-		err := runGitopsUpdateDeployment(validConfiguration, &gitOpsExecRunnerMock{}, &gitUtilsMock{}, fileUtils)
+		err := runGitopsUpdateDeployment(validConfiguration, &gitOpsExecRunnerMock{}, &gitUtilsMock{}, fileUtils, &pullRequestUtilsMock{}, &gitopsUpdateDeploymentCommonPipelineEnvironment{})
 		assert.EqualError(t, err, "failed to create temporary directory: error appeared")
 	})
 
@@ -511,7 +520,7 @@ func TestRunGitopsUpdateDeploymentWithHelm(t *testing.T) {
 		t.Parallel()
 		fileUtils := &filesMock{failOnWrite: true}
 
-		err := runGitopsUpdateDeployment(validConfiguration, &gitOpsExecRunnerMock{}, &gitUtilsMock{}, fileUtils)
+		err := runGitopsUpdateDeployment(validConfiguration, &gitOpsExecRunnerMock{}, &gitUtilsMock{}, fileUtils, &pullRequestUtilsMock{}, &gitopsUpdateDeploymentCommonPipelineEnvironment{})
 		assert.EqualError(t, err, "failed to write file: error appeared")
 	})
 
@@ -519,7 +528,7 @@ func TestRunGitopsUpdateDeploymentWithHelm(t *testing.T) {
 		t.Parallel()
 		fileUtils := &filesMock{failOnDeletion: true}
 
-		err := runGitopsUpdateDeployment(validConfiguration, &gitOpsExecRunnerMock{}, &gitUtilsMock{}, fileUtils)
+		err := runGitopsUpdateDeployment(validConfiguration, &gitOpsExecRunnerMock{}, &gitUtilsMock{}, fileUtils, &pullRequestUtilsMock{}, &gitopsUpdateDeploymentCommonPipelineEnvironment{})
 		assert.NoError(t, err)
 		_ = piperutils.Files{}.RemoveAll(fileUtils.path)
 	})
@@ -564,6 +573,10 @@ func (f filesMock) FileWrite(path string, content []byte, perm os.FileMode) erro
 	return piperutils.Files{}.FileWrite(path, content, perm)
 }
 
+func (f filesMock) FileRead(path string) ([]byte, error) {
+	return piperutils.Files{}.FileRead(path)
+}
+
 func (f filesMock) TempDir(dir string, pattern string) (name string, err error) {
 	if f.failOnCreation {
 		return "", errors.New("error appeared")
@@ -579,11 +592,31 @@ func (f *filesMock) RemoveAll(path string) error {
 	return piperutils.Files{}.RemoveAll(path)
 }
 
+type pullRequestUtilsMock struct {
+	branchName   string
+	title        string
+	body         string
+	failOnCreate bool
+}
+
+func (p *pullRequestUtilsMock) CreatePullRequest(_ *gitopsUpdateDeploymentOptions, branchName, title, body string) (string, error) {
+	if p.failOnCreate {
+		return "", errors.New("error on pull request creation")
+	}
+	p.branchName = branchName
+	p.title = title
+	p.body = body
+	return "https://github.com/SAP/gitops/pull/1", nil
+}
+
 type gitUtilsMock struct {
 	savedFile          string
 	changedBranch      string
 	commitMessage      string
 	temporaryDirectory string
+	existingFile       string
+	pushedBranch       string
+	forcedPush         bool
 	failOnClone        bool
 	failOnChangeBranch bool
 	failOnCommit       bool
@@ -625,6 +658,15 @@ func (v gitUtilsMock) PushChangesToRepository(string, string) error {
 	return nil
 }
 
+func (v *gitUtilsMock) PushBranchToRepository(_, _, branchName string, force bool) error {
+	if v.failOnPush {
+		return errors.New("error on push")
+	}
+	v.pushedBranch = branchName
+	v.forcedPush = force
+	return nil
+}
+
 func (v *gitUtilsMock) PlainClone(_, _, _, directory string) error {
 	if v.failOnClone {
 		return errors.New("error on clone")
@@ -635,7 +677,11 @@ func (v *gitUtilsMock) PlainClone(_, _, _, directory string) error {
 	if err != nil {
 		return err
 	}
-	err = piperutils.Files{}.FileWrite(filePath, []byte(existingYaml), 0755)
+	existingFile := existingYaml
+	if v.existingFile != "" {
+		existingFile = v.existingFile
+	}
+	err = piperutils.Files{}.FileWrite(filePath, []byte(existingFile), 0755)
 	if err != nil {
 		return err
 	}
@@ -644,3 +690,180 @@ func (v *gitUtilsMock) PlainClone(_, _, _, directory string) error {
 
 var existingYaml = "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: myFancyApp\n  labels:\n    tier: application\nspec:\n  replicas: 4\n  selector:\n    matchLabels:\n      run: myContainer\n  template:\n    metadata:\n      labels:\n        run: myContainer\n    spec:\n      containers:\n      - image: myregistry.com/myFancyContainer:1336\n        name: myContainer"
 var expectedYaml = "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: myFancyApp\n  labels:\n    tier: application\nspec:\n  replicas: 4\n  selector:\n    matchLabels:\n      run: myContainer\n  template:\n    metadata:\n      labels:\n        run: myContainer\n    spec:\n      containers:\n      - image: myregistry.com/myFancyContainer:1337\n        name: myContainer"
+
+func TestRunGitopsUpdateDeploymentWithKustomize(t *testing.T) {
+	var validConfiguration = &gitopsUpdateDeploymentOptions{
+		BranchName:            "main",
+		CommitMessage:         "This is the commit message",
+		ServerURL:             "https://github.com",
+		Username:              "admin3",
+		Password:              "validAccessToken",
+		FilePath:              "dir1/dir2/depl.yaml",
+		ContainerRegistryURL:  "https://myregistry.com/registry/containers",
+		ContainerImageNameTag: "myFancyContainer:1337",
+		Tool:                  "kustomize",
+	}
+	var existingKustomization = "resources:\n- ../base # shared manifests\nimages:\n- name: myFancyContainer\n  newName: myregistry.com/myFancyContainer\n  newTag: \"1336\"\n"
+	var expectedKustomization = "resources:\n- ../base # shared manifests\nimages:\n- name: myFancyContainer\n  newName: myregistry.com/myFancyContainer\n  newTag: \"1337\"\n"
+
+	t.Parallel()
+	t.Run("successful run", func(t *testing.T) {
+		t.Parallel()
+		gitUtilsMock := &gitUtilsMock{existingFile: existingKustomization}
+		runnerMock := &gitOpsExecRunnerMock{}
+
+		err := runGitopsUpdateDeployment(validConfiguration, runnerMock, gitUtilsMock, &filesMock{}, &pullRequestUtilsMock{}, &gitopsUpdateDeploymentCommonPipelineEnvironment{})
+		assert.NoError(t, err)
+		assert.Equal(t, validConfiguration.BranchName, gitUtilsMock.changedBranch)
+		assert.Equal(t, expectedKustomization, gitUtilsMock.savedFile)
+		assert.Equal(t, "This is the commit message", gitUtilsMock.commitMessage)
+		assert.Empty(t, runnerMock.executable)
+	})
+
+	t.Run("image of registry with port", func(t *testing.T) {
+		t.Parallel()
+		var configuration = *validConfiguration
+		configuration.ContainerRegistryURL = ""
+		configuration.ContainerImageNameTag = "localhost:5000/myFancyContainer:1337"
+		gitUtilsMock := &gitUtilsMock{existingFile: "images:\n- name: localhost:5000/myFancyContainer\n  newTag: \"1336\"\n"}
+
+		err := runGitopsUpdateDeployment(&configuration, &gitOpsExecRunnerMock{}, gitUtilsMock, &filesMock{}, &pullRequestUtilsMock{}, &gitopsUpdateDeploymentCommonPipelineEnvironment{})
+		assert.NoError(t, err)
+		assert.Equal(t, "images:\n- name: localhost:5000/myFancyContainer\n  newTag: \"1337\"\n", gitUtilsMock.savedFile)
+	})
+
+	t.Run("invalid kustomization", func(t *testing.T) {
+		t.Parallel()
+		gitUtilsMock := &gitUtilsMock{existingFile: "images: myFancyContainer\n"}
+
+		err := runGitopsUpdateDeployment(validConfiguration, &gitOpsExecRunnerMock{}, gitUtilsMock, &filesMock{}, &pullRequestUtilsMock{}, &gitopsUpdateDeploymentCommonPipelineEnvironment{})
+		assert.EqualError(t, err, "failed to update kustomization: failed to set image in kustomization 'dir1/dir2/depl.yaml': images in line 1 is not a list")
+	})
+
+	t.Run("erroneous tag", func(t *testing.T) {
+		t.Parallel()
+		var configuration = *validConfiguration
+		configuration.ContainerImageNameTag = "myFancyContainer:"
+
+		err := runGitopsUpdateDeployment(&configuration, &gitOpsExecRunnerMock{}, &gitUtilsMock{}, &filesMock{}, &pullRequestUtilsMock{}, &gitopsUpdateDeploymentCommonPipelineEnvironment{})
+		assert.EqualError(t, err, "failed to update kustomization: failed to extract registry URL, image name, and image tag: tag could not be extracted")
+	})
+}
+
+func TestRunGitopsUpdateDeploymentWithYAML(t *testing.T) {
+	var validConfiguration = &gitopsUpdateDeploymentOptions{
+		BranchName:            "main",
+		CommitMessage:         "This is the commit message",
+		ServerURL:             "https://github.com",
+		Username:              "admin3",
+		Password:              "validAccessToken",
+		FilePath:              "dir1/dir2/depl.yaml",
+		ContainerName:         "myContainer",
+		ContainerRegistryURL:  "https://myregistry.com/registry/containers",
+		ContainerImageNameTag: "myFancyContainer:1337",
+		Tool:                  "yaml",
+	}
+
+	t.Parallel()
+	t.Run("default path of container", func(t *testing.T) {
+		t.Parallel()
+		gitUtilsMock := &gitUtilsMock{}
+		runnerMock := &gitOpsExecRunnerMock{}
+
+		err := runGitopsUpdateDeployment(validConfiguration, runnerMock, gitUtilsMock, &filesMock{}, &pullRequestUtilsMock{}, &gitopsUpdateDeploymentCommonPipelineEnvironment{})
+		assert.NoError(t, err)
+		assert.Equal(t, expectedYaml, gitUtilsMock.savedFile)
+		assert.Equal(t, "This is the commit message", gitUtilsMock.commitMessage)
+		assert.Empty(t, runnerMock.executable)
+	})
+
+	t.Run("image and tag paths", func(t *testing.T) {
+		t.Parallel()
+		var configuration = *validConfiguration
+		configuration.ContainerName = ""
+		configuration.YamlImagePaths = []string{"spec.generators[0].list.elements[*].image"}
+		configuration.YamlTagPaths = []string{"spec.generators[0].list.elements[cluster=dev].tag"}
+		gitUtilsMock := &gitUtilsMock{existingFile: "spec:\n  generators:\n  - list:\n      elements:\n      # development\n      - cluster: dev\n        image: myregistry.com/myFancyContainer:1336\n        tag: '1336'\n"}
+
+		err := runGitopsUpdateDeployment(&configuration, &gitOpsExecRunnerMock{}, gitUtilsMock, &filesMock{}, &pullRequestUtilsMock{}, &gitopsUpdateDeploymentCommonPipelineEnvironment{})
+		assert.NoError(t, err)
+		assert.Equal(t, "spec:\n  generators:\n  - list:\n      elements:\n      # development\n      - cluster: dev\n        image: myregistry.com/myFancyContainer:1337\n        tag: '1337'\n", gitUtilsMock.savedFile)
+	})
+
+	t.Run("no matching path", func(t *testing.T) {
+		t.Parallel()
+		var configuration = *validConfiguration
+		configuration.ContainerName = "unknownContainer"
+
+		err := runGitopsUpdateDeployment(&configuration, &gitOpsExecRunnerMock{}, &gitUtilsMock{}, &filesMock{}, &pullRequestUtilsMock{}, &gitopsUpdateDeploymentCommonPipelineEnvironment{})
+		assert.EqualError(t, err, "failed to update YAML values: none of the YAML paths [spec.template.spec.containers[name=unknownContainer].image] matches a value in 'dir1/dir2/depl.yaml'")
+	})
+
+	t.Run("missing paths and ContainerName", func(t *testing.T) {
+		t.Parallel()
+		var configuration = *validConfiguration
+		configuration.ContainerName = ""
+
+		err := runGitopsUpdateDeployment(&configuration, &gitOpsExecRunnerMock{}, &gitUtilsMock{}, &filesMock{}, &pullRequestUtilsMock{}, &gitopsUpdateDeploymentCommonPipelineEnvironment{})
+		assert.EqualError(t, err, "missing required fields for yaml: the following parameters are necessary for yaml: [yamlImagePaths] or [yamlTagPaths] or [containerName]")
+	})
+}
+
+func TestRunGitopsUpdateDeploymentWithPullRequest(t *testing.T) {
+	var validConfiguration = &gitopsUpdateDeploymentOptions{
+		BranchName:              "main",
+		ServerURL:               "https://github.com/SAP/gitops",
+		Username:                "admin3",
+		Password:                "validAccessToken",
+		FilePath:                "dir1/dir2/depl.yaml",
+		ContainerName:           "myContainer",
+		ContainerRegistryURL:    "https://myregistry.com/registry/containers",
+		ContainerImageNameTag:   "myFancyContainer:1337",
+		Tool:                    "yaml",
+		CreatePullRequest:       true,
+		PullRequestBranchPrefix: "gitops/",
+	}
+
+	t.Parallel()
+	t.Run("successful run", func(t *testing.T) {
+		t.Parallel()
+		gitUtilsMock := &gitUtilsMock{}
+		pullRequestUtils := &pullRequestUtilsMock{}
+		cpe := gitopsUpdateDeploymentCommonPipelineEnvironment{}
+
+		err := runGitopsUpdateDeployment(validConfiguration, &gitOpsExecRunnerMock{}, gitUtilsMock, &filesMock{}, pullRequestUtils, &cpe)
+		assert.NoError(t, err)
+		assert.Equal(t, "gitops/myFancyContainer-1337", gitUtilsMock.changedBranch)
+		assert.Equal(t, expectedYaml, gitUtilsMock.savedFile)
+		assert.Equal(t, "gitops/myFancyContainer-1337", gitUtilsMock.pushedBranch)
+		assert.True(t, gitUtilsMock.forcedPush)
+		assert.Equal(t, "gitops/myFancyContainer-1337", pullRequestUtils.branchName)
+		assert.Equal(t, "Updated myregistry.com/myFancyContainer to version 1337", pullRequestUtils.title)
+		assert.Contains(t, pullRequestUtils.body, "dir1/dir2/depl.yaml")
+		assert.Equal(t, "https://github.com/SAP/gitops/pull/1", cpe.custom.gitopsPullRequestURL)
+	})
+
+	t.Run("error on pull request creation", func(t *testing.T) {
+		t.Parallel()
+		cpe := gitopsUpdateDeploymentCommonPipelineEnvironment{}
+
+		err := runGitopsUpdateDeployment(validConfiguration, &gitOpsExecRunnerMock{}, &gitUtilsMock{}, &filesMock{}, &pullRequestUtilsMock{failOnCreate: true}, &cpe)
+		assert.EqualError(t, err, "failed to create pull request: error on pull request creation")
+		assert.Empty(t, cpe.custom.gitopsPullRequestURL)
+	})
+
+	t.Run("error on push", func(t *testing.T) {
+		t.Parallel()
+		pullRequestUtils := &pullRequestUtilsMock{}
+
+		err := runGitopsUpdateDeployment(validConfiguration, &gitOpsExecRunnerMock{}, &gitUtilsMock{failOnPush: true}, &filesMock{}, pullRequestUtils, &gitopsUpdateDeploymentCommonPipelineEnvironment{})
+		assert.EqualError(t, err, "failed to commit and push changes: pushing changes failed: error on push")
+		assert.Empty(t, pullRequestUtils.branchName)
+	})
+}
+
+func TestPullRequestBranchName(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "gitops/myFancyContainer-1337", pullRequestBranchName(&gitopsUpdateDeploymentOptions{PullRequestBranchPrefix: "gitops/", ContainerImageNameTag: "myFancyContainer:1337"}))
+	assert.Equal(t, "promote-org/app-1.0-rc_1", pullRequestBranchName(&gitopsUpdateDeploymentOptions{PullRequestBranchPrefix: "promote-", ContainerImageNameTag: "org/app:1.0+rc_1"}))
+}
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"path/filepath"
+
+	"github.com/SAP/jenkins-library/pkg/buildsettings"
+	"github.com/SAP/jenkins-library/pkg/gradle"
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/telemetry"
+	"github.com/pkg/errors"
+)
+
+const gradleInitScriptPath = ".pipeline/piperGradleInit.gradle"
+
+func gradleBuild(config gradleBuildOptions, telemetryData *telemetry.CustomData, commonPipelineEnvironment *gradleBuildCommonPipelineEnvironment) {
+	utils := gradle.NewUtilsBundle()
+
+	err := runGradleBuild(&config, telemetryData, utils, commonPipelineEnvironment)
+	if err != nil {
+		log.Entry().WithError(err).Fatal("step execution failed")
+	}
+}
+
+func runGradleBuild(config *gradleBuildOptions, telemetryData *telemetry.CustomData, utils gradle.Utils, commonPipelineEnvironment *gradleBuildCommonPipelineEnvironment) error {
+	gradleOptions := gradle.ExecuteOptions{
+		ProjectDir: config.Path,
+		UseWrapper: config.UseWrapper,
+		Tasks:      config.Tasks,
+	}
+	if len(config.InitScriptFile) > 0 {
+		gradleOptions.InitScripts = append(gradleOptions.InitScripts, config.InitScriptFile)
+	}
+
+	initScriptOptions := gradle.InitScriptOptions{
+		MirrorURL:      config.RepositoryMirrorURL,
+		MirrorUsername: config.RepositoryMirrorUsername,
+		MirrorPassword: config.RepositoryMirrorPassword,
+	}
+	if config.Publish {
+		initScriptOptions.PublishURL = config.RepositoryURL
+		initScriptOptions.PublishUsername = config.RepositoryUsername
+		initScriptOptions.PublishPassword = config.RepositoryPassword
+	}
+	initScript, err := gradle.WriteInitScript(filepath.FromSlash(gradleInitScriptPath), initScriptOptions, utils)
+	if err != nil {
+		return errors.Wrap(err, "failed to create Gradle init script")
+	}
+	if len(initScript) > 0 {
+		defer func() { _ = utils.FileRemove(initScript) }()
+		gradleOptions.InitScripts = append(gradleOptions.InitScripts, initScript)
+	}
+
+	err = gradle.Execute(&gradleOptions, utils)
+
+	log.Entry().Infof("creating build settings information...")
+	gradleConfig := buildsettings.BuildOptions{
+		Publish:           config.Publish,
+		BuildSettingsInfo: config.BuildSettingsInfo,
+	}
+	buildSettings, settingsErr := buildsettings.CreateBuildSettingsInfo(&gradleConfig, "gradleBuild")
+	if settingsErr != nil {
+		log.Entry().Warnf("failed to create build settings info : ''%v", settingsErr)
+	}
+	commonPipelineEnvironment.custom.buildSettingsInfo = buildSettings
+
+	if err != nil {
+		return err
+	}
+
+	if !config.Publish {
+		log.Entry().Infof("publish not detected, ignoring gradle publish")
+		return nil
+	}
+	log.Entry().Infof("publish detected, running gradle publish to %v", config.RepositoryURL)
+	gradleOptions.Tasks = []string{"publish"}
+	return gradle.Execute(&gradleOptions, utils)
+}
@@ -0,0 +1,388 @@
+// Code generated by piper's step-generator. DO NOT EDIT.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/SAP/jenkins-library/pkg/config"
+	piperGithub "github.com/SAP/jenkins-library/pkg/github"
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/opentelemetry"
+	"github.com/SAP/jenkins-library/pkg/piperenv"
+	"github.com/SAP/jenkins-library/pkg/splunk"
+	"github.com/SAP/jenkins-library/pkg/telemetry"
+	"github.com/SAP/jenkins-library/pkg/validation"
+	"github.com/spf13/cobra"
+)
+
+type gradleBuildOptions struct {
+	Path                     string   `json:"path,omitempty"`
+	Tasks                    []string `json:"tasks,omitempty"`
+	UseWrapper               bool     `json:"useWrapper,omitempty"`
+	InitScriptFile           string   `json:"initScriptFile,omitempty"`
+	RepositoryMirrorURL      string   `json:"repositoryMirrorUrl,omitempty"`
+	RepositoryMirrorUsername string   `json:"repositoryMirrorUsername,omitempty"`
+	RepositoryMirrorPassword string   `json:"repositoryMirrorPassword,omitempty"`
+	Publish                  bool     `json:"publish,omitempty"`
+	RepositoryURL            string   `json:"repositoryUrl,omitempty" validate:"required_if=Publish true"`
+	RepositoryUsername       string   `json:"repositoryUsername,omitempty"`
+	RepositoryPassword       string   `json:"repositoryPassword,omitempty"`
+	BuildSettingsInfo        string   `json:"buildSettingsInfo,omitempty"`
+}
+
+type gradleBuildCommonPipelineEnvironment struct {
+	custom struct {
+		buildSettingsInfo string
+	}
+}
+
+func (p *gradleBuildCommonPipelineEnvironment) persist(path, resourceName string) {
+	content := []struct {
+		category string
+		name     string
+		value    interface{}
+	}{
+		{category: "custom", name: "buildSettingsInfo", value: p.custom.buildSettingsInfo},
+	}
+
+	errCount := 0
+	for _, param := range content {
+		err := piperenv.SetResourceParameter(path, resourceName, filepath.Join(param.category, param.name), param.value)
+		if err != nil {
+			log.Entry().WithError(err).Error("Error persisting piper environment.")
+			errCount++
+		}
+	}
+	if errCount > 0 {
+		log.Entry().Fatal("failed to persist Piper environment")
+	}
+}
+
+// GradleBuildCommand This step builds a Gradle project.
+func GradleBuildCommand() *cobra.Command {
+	const STEP_NAME = "gradleBuild"
+
+	metadata := gradleBuildMetadata()
+	var stepConfig gradleBuildOptions
+	var startTime time.Time
+	var commonPipelineEnvironment gradleBuildCommonPipelineEnvironment
+	var logCollector *log.CollectorHook
+	var splunkClient *splunk.Splunk
+	var otelClient *opentelemetry.OpenTelemetry
+	var checkRun *piperGithub.StepCheckRun
+	telemetryClient := &telemetry.Telemetry{}
+
+	var createGradleBuildCmd = &cobra.Command{
+		Use:   STEP_NAME,
+		Short: "This step builds a Gradle project.",
+		Long: `This step executes the configured tasks of a Gradle project, by default ` + "`" + `build` + "`" + `.
+The Gradle wrapper of the project is used if available, otherwise the ` + "`" + `gradle` + "`" + ` executable of the container.
+
+Repositories are configured via a Gradle init script which is created by the step:
+
+* With ` + "`" + `repositoryMirrorUrl` + "`" + ` all Maven repositories of the build, including the plugin repositories, are replaced by the given mirror, e.g. a Nexus proxy repository.
+* With ` + "`" + `publish` + "`" + ` the artifacts of all projects applying the ` + "`" + `maven-publish` + "`" + ` plugin are published via task ` + "`" + `publish` + "`" + ` to the repository ` + "`" + `repositoryUrl` + "`" + `.
+  Like with ` + "`" + `nexusUpload` + "`" + `, the repository and its credentials can also be provided via the common pipeline environment.
+
+Credentials are passed to the init script via environment variables and are not written to the file system.`,
+		PreRunE: func(cmd *cobra.Command, _ []string) error {
+			startTime = time.Now()
+			log.SetStepName(STEP_NAME)
+			log.SetVerbose(GeneralConfig.Verbose)
+
+			GeneralConfig.GitHubAccessTokens = ResolveAccessTokens(GeneralConfig.GitHubTokens)
+
+			path, _ := os.Getwd()
+			fatalHook := &log.FatalHook{CorrelationID: GeneralConfig.CorrelationID, Path: path}
+			log.RegisterHook(fatalHook)
+
+			err := PrepareConfig(cmd, &metadata, STEP_NAME, &stepConfig, config.OpenPiperFile)
+			if err != nil {
+				log.SetErrorCategory(log.ErrorConfiguration)
+				return err
+			}
+			log.RegisterSecret(stepConfig.RepositoryMirrorUsername)
+			log.RegisterSecret(stepConfig.RepositoryMirrorPassword)
+			log.RegisterSecret(stepConfig.RepositoryUsername)
+			log.RegisterSecret(stepConfig.RepositoryPassword)
+
+			if len(GeneralConfig.HookConfig.SentryConfig.Dsn) > 0 {
+				sentryHook := log.NewSentryHook(GeneralConfig.HookConfig.SentryConfig.Dsn, GeneralConfig.CorrelationID)
+				log.RegisterHook(&sentryHook)
+			}
+
+			if len(GeneralConfig.HookConfig.SplunkConfig.Dsn) > 0 {
+				splunkClient = &splunk.Splunk{}
+				logCollector = &log.CollectorHook{CorrelationID: GeneralConfig.CorrelationID}
+				log.RegisterHook(logCollector)
+			}
+
+			if len(GeneralConfig.HookConfig.OpenTelemetryConfig.Endpoint) > 0 {
+				otelClient = &opentelemetry.OpenTelemetry{}
+				if err := otelClient.Initialize(GeneralConfig.CorrelationID, STEP_NAME, GeneralConfig.HookConfig.OpenTelemetryConfig); err != nil {
+					log.Entry().WithError(err).Warning("failed to initialize OpenTelemetry")
+				}
+			}
+
+			validation, err := validation.New(validation.WithJSONNamesForStructFields(), validation.WithPredefinedErrorMessages())
+			if err != nil {
+				return err
+			}
+			if err = validation.ValidateStruct(stepConfig); err != nil {
+				log.SetErrorCategory(log.ErrorConfiguration)
+				return err
+			}
+
+			return nil
+		},
+		Run: func(_ *cobra.Command, _ []string) {
+			stepTelemetryData := telemetry.CustomData{}
+			stepTelemetryData.ErrorCode = "1"
+			handler := func() {
+				config.RemoveVaultSecretFiles()
+				commonPipelineEnvironment.persist(GeneralConfig.EnvRootPath, "commonPipelineEnvironment")
+				stepTelemetryData.Duration = fmt.Sprintf("%v", time.Since(startTime).Milliseconds())
+				stepTelemetryData.ErrorCategory = log.GetErrorCategory().String()
+				stepTelemetryData.PiperCommitHash = GitCommit
+				telemetryClient.SetData(&stepTelemetryData)
+				telemetryClient.Send()
+				if len(GeneralConfig.HookConfig.SplunkConfig.Dsn) > 0 {
+					splunkClient.Send(telemetryClient.GetData(), logCollector)
+				}
+				if len(GeneralConfig.HookConfig.OpenTelemetryConfig.Endpoint) > 0 {
+					otelClient.Send(telemetryClient.GetData().Attributes())
+				}
+				if checkRun != nil {
+					checkRun.Complete(stepTelemetryData.ErrorCode != "0", log.GetErrorCategory())
+				}
+			}
+			log.DeferExitHandler(handler)
+			defer handler()
+			telemetryClient.Initialize(GeneralConfig.NoTelemetry, STEP_NAME)
+			if len(GeneralConfig.HookConfig.SplunkConfig.Dsn) > 0 {
+				splunkClient.Initialize(GeneralConfig.CorrelationID,
+					GeneralConfig.HookConfig.SplunkConfig.Dsn,
+					GeneralConfig.HookConfig.SplunkConfig.Token,
+					GeneralConfig.HookConfig.SplunkConfig.Index,
+					GeneralConfig.HookConfig.SplunkConfig.SendLogs)
+			}
+			if GeneralConfig.HookConfig.GitHubChecksConfig.Enabled {
+				checkRun = piperGithub.StartStepCheckRun(STEP_NAME, GeneralConfig.StageName, GeneralConfig.HookConfig.GitHubChecksConfig)
+			}
+			gradleBuild(stepConfig, &stepTelemetryData, &commonPipelineEnvironment)
+			stepTelemetryData.ErrorCode = "0"
+			log.Entry().Info("SUCCESS")
+		},
+	}
+
+	addGradleBuildFlags(createGradleBuildCmd, &stepConfig)
+	return createGradleBuildCmd
+}
+
+func addGradleBuildFlags(cmd *cobra.Command, stepConfig *gradleBuildOptions) {
+	cmd.Flags().StringVar(&stepConfig.Path, "path", `.`, "Path to the root directory of the Gradle project.")
+	cmd.Flags().StringSliceVar(&stepConfig.Tasks, "tasks", []string{`build`}, "Defines the Gradle tasks to be executed.")
+	cmd.Flags().BoolVar(&stepConfig.UseWrapper, "useWrapper", true, "Defines whether the Gradle wrapper (`gradlew`) of the project is used if available.")
+	cmd.Flags().StringVar(&stepConfig.InitScriptFile, "initScriptFile", os.Getenv("PIPER_initScriptFile"), "Path to an additional Gradle init script which is passed to all Gradle executions of the step.")
+	cmd.Flags().StringVar(&stepConfig.RepositoryMirrorURL, "repositoryMirrorUrl", os.Getenv("PIPER_repositoryMirrorUrl"), "URL of a Maven repository which replaces all Maven repositories of the build, e.g. a Nexus proxy repository.")
+	cmd.Flags().StringVar(&stepConfig.RepositoryMirrorUsername, "repositoryMirrorUsername", os.Getenv("PIPER_repositoryMirrorUsername"), "Username for accessing the repository mirror.")
+	cmd.Flags().StringVar(&stepConfig.RepositoryMirrorPassword, "repositoryMirrorPassword", os.Getenv("PIPER_repositoryMirrorPassword"), "Password for accessing the repository mirror.")
+	cmd.Flags().BoolVar(&stepConfig.Publish, "publish", false, "Configures Gradle to publish the artifacts of the projects applying the `maven-publish` plugin to the repository `repositoryUrl`.")
+	cmd.Flags().StringVar(&stepConfig.RepositoryURL, "repositoryUrl", os.Getenv("PIPER_repositoryUrl"), "URL of the Maven repository the artifacts are published to.")
+	cmd.Flags().StringVar(&stepConfig.RepositoryUsername, "repositoryUsername", os.Getenv("PIPER_repositoryUsername"), "Username for publishing to the repository `repositoryUrl`.")
+	cmd.Flags().StringVar(&stepConfig.RepositoryPassword, "repositoryPassword", os.Getenv("PIPER_repositoryPassword"), "Password for publishing to the repository `repositoryUrl`.")
+	cmd.Flags().StringVar(&stepConfig.BuildSettingsInfo, "buildSettingsInfo", os.Getenv("PIPER_buildSettingsInfo"), "build settings info is typically filled by the step automatically to create information about the build settings that were used during the gradle build. This information is typically used for compliance related processes.")
+
+}
+
+// retrieve step metadata
+func gradleBuildMetadata() config.StepData {
+	var theMetaData = config.StepData{
+		Metadata: config.StepMetadata{
+			Name:        "gradleBuild",
+			Aliases:     []config.Alias{{Name: "gradleExecute", Deprecated: false}},
+			Description: "This step builds a Gradle project.",
+		},
+		Spec: config.StepSpec{
+			Inputs: config.StepInputs{
+				Secrets: []config.StepSecrets{
+					{Name: "publishCredentialsId", Description: "Jenkins 'Username with password' credentials ID containing the technical user to publish to the repository `repositoryUrl`.", Type: "jenkins"},
+					{Name: "repositoryMirrorCredentialsId", Description: "Jenkins 'Username with password' credentials ID containing the technical user to access the repository mirror.", Type: "jenkins"},
+				},
+				Parameters: []config.StepParameters{
+					{
+						Name:        "path",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     `.`,
+					},
+					{
+						Name:        "tasks",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "[]string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     []string{`build`},
+					},
+					{
+						Name:        "useWrapper",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"GENERAL", "PARAMETERS", "STAGES", "STEPS"},
+						Type:        "bool",
+						Mandatory:   false,
+						Aliases:     []config.Alias{{Name: "gradle/useWrapper"}},
+						Default:     true,
+					},
+					{
+						Name:        "initScriptFile",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"GENERAL", "PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{{Name: "gradle/initScriptFile"}},
+						Default:     os.Getenv("PIPER_initScriptFile"),
+					},
+					{
+						Name:        "repositoryMirrorUrl",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"GENERAL", "PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{{Name: "gradle/repositoryMirrorUrl"}},
+						Default:     os.Getenv("PIPER_repositoryMirrorUrl"),
+					},
+					{
+						Name: "repositoryMirrorUsername",
+						ResourceRef: []config.ResourceReference{
+							{
+								Name:  "repositoryMirrorCredentialsId",
+								Param: "username",
+								Type:  "secret",
+							},
+						},
+						Scope:     []string{"PARAMETERS"},
+						Type:      "string",
+						Mandatory: false,
+						Aliases:   []config.Alias{},
+						Default:   os.Getenv("PIPER_repositoryMirrorUsername"),
+					},
+					{
+						Name: "repositoryMirrorPassword",
+						ResourceRef: []config.ResourceReference{
+							{
+								Name:  "repositoryMirrorCredentialsId",
+								Param: "password",
+								Type:  "secret",
+							},
+						},
+						Scope:     []string{"PARAMETERS"},
+						Type:      "string",
+						Mandatory: false,
+						Aliases:   []config.Alias{},
+						Default:   os.Getenv("PIPER_repositoryMirrorPassword"),
+					},
+					{
+						Name:        "publish",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "bool",
+						Mandatory:   false,
+						Aliases:     []config.Alias{{Name: "gradle/publish"}},
+						Default:     false,
+					},
+					{
+						Name: "repositoryUrl",
+						ResourceRef: []config.ResourceReference{
+							{
+								Name:  "commonPipelineEnvironment",
+								Param: "custom/repositoryUrl",
+							},
+						},
+						Scope:     []string{"GENERAL", "PARAMETERS", "STAGES", "STEPS"},
+						Type:      "string",
+						Mandatory: false,
+						Aliases:   []config.Alias{},
+						Default:   os.Getenv("PIPER_repositoryUrl"),
+					},
+					{
+						Name: "repositoryUsername",
+						ResourceRef: []config.ResourceReference{
+							{
+								Name:  "publishCredentialsId",
+								Param: "username",
+								Type:  "secret",
+							},
+
+							{
+								Name:  "commonPipelineEnvironment",
+								Param: "custom/repositoryUsername",
+							},
+						},
+						Scope:     []string{"PARAMETERS"},
+						Type:      "string",
+						Mandatory: false,
+						Aliases:   []config.Alias{},
+						Default:   os.Getenv("PIPER_repositoryUsername"),
+					},
+					{
+						Name: "repositoryPassword",
+						ResourceRef: []config.ResourceReference{
+							{
+								Name:  "publishCredentialsId",
+								Param: "password",
+								Type:  "secret",
+							},
+
+							{
+								Name:  "commonPipelineEnvironment",
+								Param: "custom/repositoryPassword",
+							},
+						},
+						Scope:     []string{"PARAMETERS"},
+						Type:      "string",
+						Mandatory: false,
+						Aliases:   []config.Alias{},
+						Default:   os.Getenv("PIPER_repositoryPassword"),
+					},
+					{
+						Name: "buildSettingsInfo",
+						ResourceRef: []config.ResourceReference{
+							{
+								Name:  "commonPipelineEnvironment",
+								Param: "custom/buildSettingsInfo",
+							},
+						},
+						Scope:     []string{"STEPS", "STAGES", "PARAMETERS"},
+						Type:      "string",
+						Mandatory: false,
+						Aliases:   []config.Alias{},
+						Default:   os.Getenv("PIPER_buildSettingsInfo"),
+					},
+				},
+			},
+			Containers: []config.Container{
+				{Name: "gradle", Image: "gradle:7-jdk11"},
+			},
+			Outputs: config.StepOutputs{
+				Resources: []config.StepResources{
+					{
+						Name: "commonPipelineEnvironment",
+						Type: "piperEnvironment",
+						Parameters: []map[string]interface{}{
+							{"Name": "custom/buildSettingsInfo"},
+						},
+					},
+				},
+			},
+		},
+	}
+	return theMetaData
+}
@@ -0,0 +1,17 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGradleBuildCommand(t *testing.T) {
+	t.Parallel()
+
+	testCmd := GradleBuildCommand()
+
+	// only high level testing performed - details are tested in step generation procedure
+	assert.Equal(t, "gradleBuild", testCmd.Use, "command name incorrect")
+
+}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/SAP/jenkins-library/pkg/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+type gradleBuildMockUtils struct {
+	*mock.ExecMockRunner
+	*mock.FilesMock
+}
+
+func newGradleBuildTestsUtils() gradleBuildMockUtils {
+	utils := gradleBuildMockUtils{
+		ExecMockRunner: &mock.ExecMockRunner{},
+		FilesMock:      &mock.FilesMock{},
+	}
+	return utils
+}
+
+func TestRunGradleBuild(t *testing.T) {
+	t.Parallel()
+
+	t.Run("build with wrapper", func(t *testing.T) {
+		t.Parallel()
+		// init
+		config := gradleBuildOptions{Path: ".", Tasks: []string{"build"}, UseWrapper: true, InitScriptFile: "ci.gradle"}
+		utils := newGradleBuildTestsUtils()
+		utils.AddFile("gradlew", []byte{})
+		cpe := gradleBuildCommonPipelineEnvironment{}
+
+		// test
+		err := runGradleBuild(&config, nil, utils, &cpe)
+
+		// assert
+		assert.NoError(t, err)
+		if assert.Len(t, utils.Calls, 1) {
+			assert.Equal(t, "./gradlew", utils.Calls[0].Exec)
+			assert.Equal(t, []string{"--no-daemon", "--console=plain", "--init-script", "ci.gradle", "build"}, utils.Calls[0].Params)
+		}
+		assert.False(t, utils.HasWrittenFile(gradleInitScriptPath))
+		assert.Equal(t, `{"gradleBuild":[{}]}`, cpe.custom.buildSettingsInfo)
+	})
+
+	t.Run("build with mirror and publish", func(t *testing.T) {
+		t.Parallel()
+		// init
+		config := gradleBuildOptions{
+			Path:                     ".",
+			Tasks:                    []string{"build"},
+			RepositoryMirrorURL:      "https://nexus.example.com/repository/maven-public/",
+			RepositoryMirrorUsername: "reader",
+			RepositoryMirrorPassword: "readerSecret",
+			Publish:                  true,
+			RepositoryURL:            "https://nexus.example.com/repository/maven-releases/",
+			RepositoryUsername:       "deployer",
+			RepositoryPassword:       "deployerSecret",
+		}
+		utils := newGradleBuildTestsUtils()
+		cpe := gradleBuildCommonPipelineEnvironment{}
+
+		// test
+		err := runGradleBuild(&config, nil, utils, &cpe)
+
+		// assert
+		assert.NoError(t, err)
+		if assert.Len(t, utils.Calls, 2) {
+			assert.Equal(t, "gradle", utils.Calls[0].Exec)
+			assert.Equal(t, []string{"--no-daemon", "--console=plain", "--init-script", gradleInitScriptPath, "build"}, utils.Calls[0].Params)
+			assert.Equal(t, []string{"--no-daemon", "--console=plain", "--init-script", gradleInitScriptPath, "publish"}, utils.Calls[1].Params)
+		}
+		assert.True(t, utils.HasWrittenFile(gradleInitScriptPath))
+		assert.True(t, utils.HasRemovedFile(gradleInitScriptPath))
+		assert.Contains(t, utils.Env, "PIPER_GRADLE_MIRROR_USERNAME=reader")
+		assert.Contains(t, utils.Env, "PIPER_GRADLE_PUBLISH_PASSWORD=deployerSecret")
+		assert.Equal(t, `{"gradleBuild":[{"publish":true}]}`, cpe.custom.buildSettingsInfo)
+	})
+
+	t.Run("build failure skips publish", func(t *testing.T) {
+		t.Parallel()
+		// init
+		config := gradleBuildOptions{Tasks: []string{"build"}, Publish: true, RepositoryURL: "https://nexus.example.com/repository/maven-releases/"}
+		utils := newGradleBuildTestsUtils()
+		utils.ShouldFailOnCommand = map[string]error{"gradle": errors.New("exit status 1")}
+		cpe := gradleBuildCommonPipelineEnvironment{}
+
+		// test
+		err := runGradleBuild(&config, nil, utils, &cpe)
+
+		// assert
+		assert.EqualError(t, err, "failed to run executable, command: '[gradle --no-daemon --console=plain --init-script .pipeline/piperGradleInit.gradle build]', error: exit status 1")
+		assert.Len(t, utils.Calls, 1)
+	})
+}
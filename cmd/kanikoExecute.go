@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/SAP/jenkins-library/pkg/certutils"
@@ -10,6 +11,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/SAP/jenkins-library/pkg/command"
+	"github.com/SAP/jenkins-library/pkg/cyclonedx"
 	"github.com/SAP/jenkins-library/pkg/docker"
 	"github.com/SAP/jenkins-library/pkg/log"
 	"github.com/SAP/jenkins-library/pkg/piperutils"
@@ -112,10 +114,32 @@ func runKanikoExecute(config *kanikoExecuteOptions, telemetryData *telemetry.Cus
 	kanikoOpts := []string{"--dockerfile", config.DockerfilePath, "--context", cwd}
 	kanikoOpts = append(kanikoOpts, config.BuildOptions...)
 
+	var imageTarPath string
+	if config.CreateBOM {
+		tmpDir, err := fileUtils.TempDir("", "kaniko")
+		if err != nil {
+			return errors.Wrap(err, "failed to create temporary directory for image tarball")
+		}
+		defer fileUtils.RemoveAll(tmpDir)
+		imageTarPath = filepath.Join(tmpDir, "image.tar")
+		kanikoOpts = append(kanikoOpts, "--tar-path", imageTarPath)
+	}
+
 	err = execRunner.RunExecutable("/kaniko/executor", kanikoOpts...)
 	if err != nil {
 		log.SetErrorCategory(log.ErrorBuild)
 		return errors.Wrap(err, "execution of '/kaniko/executor' failed")
 	}
+
+	commonPipelineEnvironment.custom.sbomFiles = config.SbomFiles
+	if config.CreateBOM {
+		if err := cyclonedx.CreateImageBOM("docker-archive:"+imageTarPath, kanikoBOMFile, "", execRunner); err != nil {
+			return err
+		}
+		commonPipelineEnvironment.custom.sbomFiles = cyclonedx.AppendSBOMFile(config.SbomFiles, kanikoBOMFile)
+	}
 	return nil
 }
+
+// kanikoBOMFile is the SBOM of the container image built by kaniko
+const kanikoBOMFile = "bom-image.xml"
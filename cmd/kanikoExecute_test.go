@@ -189,6 +189,35 @@ func TestRunKanikoExecute(t *testing.T) {
 		assert.Equal(t, []string{"--dockerfile", "Dockerfile", "--context", cwd, "--skip-tls-verify-pull", "--no-push"}, runner.Calls[1].Params)
 	})
 
+	t.Run("success case - create SBOM", func(t *testing.T) {
+		config := &kanikoExecuteOptions{
+			ContainerImage:              "myImage:tag",
+			ContainerPreparationCommand: "rm -f /kaniko/.docker/config.json",
+			DockerfilePath:              "Dockerfile",
+			CreateBOM:                   true,
+			SbomFiles:                   []string{"bom.xml"},
+		}
+
+		runner := &mock.ExecMockRunner{}
+
+		certClient := &kanikoMockClient{}
+		fileUtils := &kanikoFileMock{
+			FilesMock:        &mock.FilesMock{},
+			fileWriteContent: map[string]string{},
+		}
+
+		err := runKanikoExecute(config, &telemetry.CustomData{}, &commonPipelineEnvironment, runner, certClient, fileUtils)
+
+		assert.NoError(t, err)
+
+		cwd, _ := os.Getwd()
+		assert.Equal(t, []string{"--dockerfile", "Dockerfile", "--context", cwd, "--destination", "myImage:tag", "--tar-path", "/tmp/kanikotest/image.tar"}, runner.Calls[1].Params)
+		assert.Equal(t, mock.ExecCall{Exec: "syft", Params: []string{"version"}}, runner.Calls[2])
+		assert.Equal(t, mock.ExecCall{Exec: "syft", Params: []string{"packages", "docker-archive:/tmp/kanikotest/image.tar", "-o", "cyclonedx", "--file", "bom-image.xml", "-q"}}, runner.Calls[3])
+		assert.Equal(t, []string{"bom.xml", "bom-image.xml"}, commonPipelineEnvironment.custom.sbomFiles)
+		assert.True(t, fileUtils.HasRemovedFile("/tmp/kanikotest"))
+	})
+
 	t.Run("success case - backward compatibility", func(t *testing.T) {
 		config := &kanikoExecuteOptions{
 			ContainerBuildOptions:       "--skip-tls-verify-pull",
@@ -11,11 +11,26 @@ import (
 	"strings"
 
 	"github.com/SAP/jenkins-library/pkg/command"
+	"github.com/SAP/jenkins-library/pkg/kubernetes"
 	"github.com/SAP/jenkins-library/pkg/log"
 	"github.com/SAP/jenkins-library/pkg/telemetry"
 )
 
-func kubernetesDeploy(config kubernetesDeployOptions, telemetryData *telemetry.CustomData) {
+const (
+	deploymentStrategyRolling   = "rolling"
+	deploymentStrategyBlueGreen = "blueGreen"
+
+	// blueGreenSelectorLabel is set by helm charts on all resources of a release and is used to route the service to the live release
+	blueGreenSelectorLabel = "app.kubernetes.io/instance"
+
+	deployOutcomeDeployed   = "deployed"
+	deployOutcomePromoted   = "promoted"
+	deployOutcomeAborted    = "aborted"
+	deployOutcomeRolledBack = "rolledBack"
+	deployOutcomeFailed     = "failed"
+)
+
+func kubernetesDeploy(config kubernetesDeployOptions, telemetryData *telemetry.CustomData, influxData *kubernetesDeployInflux) {
 	c := command.Command{
 		ErrorCategoryMapping: map[string][]string{
 			log.ErrorConfiguration.String(): {
@@ -38,27 +53,35 @@ func kubernetesDeploy(config kubernetesDeployOptions, telemetryData *telemetry.C
 	c.Stderr(log.Writer())
 
 	// error situations should stop execution through log.Entry().Fatal() call which leads to an os.Exit(1) in the end
-	err := runKubernetesDeploy(config, &c, log.Writer())
+	err := runKubernetesDeploy(config, &c, log.Writer(), influxData)
 	if err != nil {
 		log.Entry().WithError(err).Fatal("step execution failed")
 	}
 }
 
-func runKubernetesDeploy(config kubernetesDeployOptions, command command.ExecRunner, stdout io.Writer) error {
+func runKubernetesDeploy(config kubernetesDeployOptions, command command.ExecRunner, stdout io.Writer, influxData *kubernetesDeployInflux) error {
 	if config.DeployTool == "helm" || config.DeployTool == "helm3" {
-		return runHelmDeploy(config, command, stdout)
+		outcome, err := runHelmDeploy(config, command, stdout)
+		prepareKubernetesDeployInflux(outcome, config, influxData)
+		return err
 	} else if config.DeployTool == "kubectl" {
-		return runKubectlDeploy(config, command)
+		if len(config.DeploymentStrategy) > 0 && config.DeploymentStrategy != deploymentStrategyRolling {
+			log.SetErrorCategory(log.ErrorConfiguration)
+			return fmt.Errorf("deployment strategy '%v' is only supported with deployTool helm or helm3", config.DeploymentStrategy)
+		}
+		outcome, err := runKubectlDeploy(config, command, stdout)
+		prepareKubernetesDeployInflux(outcome, config, influxData)
+		return err
 	}
 	return fmt.Errorf("Failed to execute deployments")
 }
 
-func runHelmDeploy(config kubernetesDeployOptions, command command.ExecRunner, stdout io.Writer) error {
+func runHelmDeploy(config kubernetesDeployOptions, command command.ExecRunner, stdout io.Writer) (string, error) {
 	if len(config.ChartPath) <= 0 {
-		return fmt.Errorf("chart path has not been set, please configure chartPath parameter")
+		return deployOutcomeFailed, fmt.Errorf("chart path has not been set, please configure chartPath parameter")
 	}
 	if len(config.DeploymentName) <= 0 {
-		return fmt.Errorf("deployment name has not been set, please configure deploymentName parameter")
+		return deployOutcomeFailed, fmt.Errorf("deployment name has not been set, please configure deploymentName parameter")
 	}
 	_, containerRegistry, err := splitRegistryURL(config.ContainerRegistryURL)
 	if err != nil {
@@ -77,7 +100,7 @@ func runHelmDeploy(config kubernetesDeployOptions, command command.ExecRunner, s
 		containerImageName = config.ContainerImageName
 		containerImageTag = config.ContainerImageTag
 	} else {
-		return fmt.Errorf("image information not given - please either set image or containerImageName and containerImageTag")
+		return deployOutcomeFailed, fmt.Errorf("image information not given - please either set image or containerImageName and containerImageTag")
 	}
 	helmLogFields := map[string]interface{}{}
 	helmLogFields["Chart Path"] = config.ChartPath
@@ -148,9 +171,37 @@ func runHelmDeploy(config kubernetesDeployOptions, command command.ExecRunner, s
 		ingressHosts += fmt.Sprintf(",ingress.hosts[%v]=%v", i, h)
 	}
 
+	values := fmt.Sprintf("image.repository=%v/%v,image.tag=%v%v%v", containerRegistry, containerImageName, containerImageTag, secretsData, ingressHosts)
+
+	command.Stdout(stdout)
+	if config.DeploymentStrategy == deploymentStrategyBlueGreen {
+		return runHelmBlueGreenDeploy(config, command, stdout, values)
+	}
+
+	previousRevision := 0
+	if config.VerifyRollout {
+		previousRevision = helmCurrentRevision(config, command, stdout)
+	}
+
+	upgradeParams := helmUpgradeParams(config, config.DeploymentName, values)
+	log.Entry().Info("Calling helm upgrade ...")
+	log.Entry().Debugf("Helm parameters %v", upgradeParams)
+	if err := command.RunExecutable("helm", upgradeParams...); err != nil {
+		log.Entry().WithError(err).Fatal("Helm upgrade call failed")
+	}
+
+	if config.VerifyRollout {
+		if err := verifyHelmRollout(config, command, stdout, config.DeploymentName); err != nil {
+			return helmRollbackRelease(config, command, previousRevision, err)
+		}
+	}
+	return deployOutcomeDeployed, nil
+}
+
+func helmUpgradeParams(config kubernetesDeployOptions, releaseName, values string) []string {
 	upgradeParams := []string{
 		"upgrade",
-		config.DeploymentName,
+		releaseName,
 		config.ChartPath,
 	}
 
@@ -163,7 +214,7 @@ func runHelmDeploy(config kubernetesDeployOptions, command command.ExecRunner, s
 		"--install",
 		"--namespace", config.Namespace,
 		"--set",
-		fmt.Sprintf("image.repository=%v/%v,image.tag=%v%v%v", containerRegistry, containerImageName, containerImageTag, secretsData, ingressHosts),
+		values,
 	)
 
 	if config.ForceUpdates {
@@ -189,17 +240,303 @@ func runHelmDeploy(config kubernetesDeployOptions, command command.ExecRunner, s
 	if len(config.AdditionalParameters) > 0 {
 		upgradeParams = append(upgradeParams, config.AdditionalParameters...)
 	}
+	return upgradeParams
+}
+
+// runHelmBlueGreenDeploy installs the new version next to the live one as release <deploymentName>-blue or <deploymentName>-green.
+// Once the new release is verified, the service deploymentName is switched to it and the previous release is removed.
+// A failing verification removes the new release again while the service keeps routing to the previous release.
+func runHelmBlueGreenDeploy(config kubernetesDeployOptions, command command.ExecRunner, stdout io.Writer, values string) (string, error) {
+	liveRelease, err := blueGreenLiveRelease(config, command, stdout)
+	if err != nil {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return deployOutcomeFailed, err
+	}
+	previousRelease := ""
+	releaseName := config.DeploymentName + "-blue"
+	switch liveRelease {
+	case config.DeploymentName + "-blue":
+		previousRelease = liveRelease
+		releaseName = config.DeploymentName + "-green"
+	case config.DeploymentName + "-green":
+		previousRelease = liveRelease
+	}
+
+	log.Entry().Infof("Deploying new version as release '%v' ...", releaseName)
+	if err := command.RunExecutable("helm", helmUpgradeParams(config, releaseName, values)...); err != nil {
+		helmUninstall(config, command, releaseName)
+		return deployOutcomeAborted, fmt.Errorf("deployment of release '%v' failed: %w", releaseName, err)
+	}
+	if err := verifyHelmRelease(config, command, stdout, releaseName); err != nil {
+		helmUninstall(config, command, releaseName)
+		return deployOutcomeAborted, err
+	}
+
+	log.Entry().Infof("Switching service '%v' to release '%v' ...", config.DeploymentName, releaseName)
+	selector := fmt.Sprintf(`{"spec":{"selector":{"%v":"%v"}}}`, blueGreenSelectorLabel, releaseName)
+	if err := command.RunExecutable("kubectl", helmKubectlParams(config, "patch", "service", config.DeploymentName, "--type=merge", "--patch="+selector)...); err != nil {
+		helmUninstall(config, command, releaseName)
+		return deployOutcomeAborted, fmt.Errorf("switching service '%v' to release '%v' failed: %w", config.DeploymentName, releaseName, err)
+	}
+	if len(previousRelease) > 0 {
+		helmUninstall(config, command, previousRelease)
+	}
+	return deployOutcomePromoted, nil
+}
 
+// blueGreenLiveRelease returns the release the service deploymentName currently routes to, empty in case it does not select a release yet
+func blueGreenLiveRelease(config kubernetesDeployOptions, command command.ExecRunner, stdout io.Writer) (string, error) {
+	var selector bytes.Buffer
+	command.Stdout(&selector)
+	jsonPath := fmt.Sprintf("--output=jsonpath={.spec.selector.%v}", strings.ReplaceAll(blueGreenSelectorLabel, ".", `\.`))
+	err := command.RunExecutable("kubectl", helmKubectlParams(config, "get", "service", config.DeploymentName, jsonPath)...)
 	command.Stdout(stdout)
-	log.Entry().Info("Calling helm upgrade ...")
-	log.Entry().Debugf("Helm parameters %v", upgradeParams)
-	if err := command.RunExecutable("helm", upgradeParams...); err != nil {
-		log.Entry().WithError(err).Fatal("Helm upgrade call failed")
+	if err != nil {
+		return "", fmt.Errorf("failed to read service '%v' which routes the traffic to the blue or green release: %w", config.DeploymentName, err)
+	}
+	return strings.TrimSpace(selector.String()), nil
+}
+
+// helmRollbackRelease rolls the release deploymentName back to the previous revision after the deployment failed with err
+func helmRollbackRelease(config kubernetesDeployOptions, command command.ExecRunner, previousRevision int, err error) (string, error) {
+	if previousRevision == 0 {
+		log.Entry().Warnf("Release '%v' has no previous revision, skipping rollback", config.DeploymentName)
+		return deployOutcomeFailed, err
+	}
+	if rollbackErr := helmRollback(config, command, previousRevision); rollbackErr != nil {
+		log.Entry().WithError(rollbackErr).Errorf("Rollback of release '%v' to revision %v failed", config.DeploymentName, previousRevision)
+		return deployOutcomeFailed, err
+	}
+	log.Entry().Infof("Release '%v' has been rolled back to revision %v", config.DeploymentName, previousRevision)
+	return deployOutcomeRolledBack, err
+}
+
+// helmCurrentRevision returns the newest successfully deployed revision of the release deploymentName, 0 in case there is none.
+// Failed or pending revisions are skipped since rolling back to them would not restore a working state.
+func helmCurrentRevision(config kubernetesDeployOptions, command command.ExecRunner, stdout io.Writer) int {
+	var history bytes.Buffer
+	command.Stdout(&history)
+	defer command.Stdout(stdout)
+
+	historyParams := []string{"history", config.DeploymentName, "--output", "json"}
+	historyParams = append(historyParams, helmReleaseParams(config)...)
+	if err := command.RunExecutable("helm", historyParams...); err != nil {
+		log.Entry().WithError(err).Infof("No revision of release '%v' found", config.DeploymentName)
+		return 0
+	}
+
+	var revisions []struct {
+		Revision int    `json:"revision"`
+		Status   string `json:"status"`
+	}
+	if err := json.Unmarshal(history.Bytes(), &revisions); err != nil {
+		log.Entry().Infof("No revision of release '%v' found", config.DeploymentName)
+		return 0
+	}
+	current := 0
+	for _, revision := range revisions {
+		// helm 2 reports the status in upper case
+		status := strings.ToLower(revision.Status)
+		if (status == "deployed" || status == "superseded") && revision.Revision > current {
+			current = revision.Revision
+		}
+	}
+	if current == 0 {
+		log.Entry().Infof("No successfully deployed revision of release '%v' found", config.DeploymentName)
+	}
+	return current
+}
+
+func helmRollback(config kubernetesDeployOptions, command command.ExecRunner, revision int) error {
+	rollbackParams := []string{"rollback", config.DeploymentName, strconv.Itoa(revision), "--wait", "--timeout"}
+	if config.DeployTool == "helm3" {
+		rollbackParams = append(rollbackParams, fmt.Sprintf("%vs", config.HelmDeployWaitSeconds))
+	} else {
+		rollbackParams = append(rollbackParams, strconv.Itoa(config.HelmDeployWaitSeconds))
+	}
+	rollbackParams = append(rollbackParams, helmReleaseParams(config)...)
+	log.Entry().Infof("Rolling back release '%v' to revision %v ...", config.DeploymentName, revision)
+	return command.RunExecutable("helm", rollbackParams...)
+}
+
+func helmUninstall(config kubernetesDeployOptions, command command.ExecRunner, releaseName string) {
+	uninstallParams := []string{"uninstall", releaseName}
+	if config.DeployTool == "helm" {
+		uninstallParams = []string{"delete", releaseName, "--purge"}
+	}
+	uninstallParams = append(uninstallParams, helmReleaseParams(config)...)
+	log.Entry().Infof("Removing release '%v' ...", releaseName)
+	if err := command.RunExecutable("helm", uninstallParams...); err != nil {
+		log.Entry().WithError(err).Warnf("Removing release '%v' failed", releaseName)
+	}
+}
+
+// helmReleaseParams returns the parameters addressing a release, helm 2 identifies releases without namespace
+func helmReleaseParams(config kubernetesDeployOptions) []string {
+	params := []string{}
+	if config.DeployTool == "helm3" {
+		params = append(params, "--namespace", config.Namespace)
+	}
+	if len(config.KubeContext) > 0 {
+		params = append(params, "--kube-context", config.KubeContext)
+	}
+	return params
+}
+
+// helmKubectlParams returns new kubectl parameters addressing the namespace and context of the helm releases followed by args
+func helmKubectlParams(config kubernetesDeployOptions, args ...string) []string {
+	params := []string{fmt.Sprintf("--namespace=%v", config.Namespace)}
+	if len(config.KubeContext) > 0 {
+		params = append(params, fmt.Sprintf("--context=%v", config.KubeContext))
+	}
+	return append(params, args...)
+}
+
+// verifyHelmRelease checks the rollout of the release and runs the smoke test against it
+func verifyHelmRelease(config kubernetesDeployOptions, command command.ExecRunner, stdout io.Writer, releaseName string) error {
+	if config.VerifyRollout {
+		if err := verifyHelmRollout(config, command, stdout, releaseName); err != nil {
+			return err
+		}
+	}
+	return runKubernetesSmokeTest(config, command, releaseName)
+}
+
+func verifyHelmRollout(config kubernetesDeployOptions, command command.ExecRunner, stdout io.Writer, releaseName string) error {
+	var manifests bytes.Buffer
+	command.Stdout(&manifests)
+	manifestParams := append([]string{"get", "manifest", releaseName}, helmReleaseParams(config)...)
+	err := command.RunExecutable("helm", manifestParams...)
+	command.Stdout(stdout)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve manifests of release '%v': %w", releaseName, err)
+	}
+	workloads, err := kubernetes.WorkloadsFromManifests(manifests.Bytes())
+	if err != nil {
+		return fmt.Errorf("failed to determine workloads of release '%v': %w", releaseName, err)
+	}
+
+	if failed := verifyKubernetesRollout(config, command, stdout, helmKubectlParams(config), workloads); len(failed) > 0 {
+		return kubernetesRolloutError(config, failed)
+	}
+	return nil
+}
+
+// verifyKubernetesRollout waits until the pods of the workloads are ready and returns the workloads whose rollout did not finish
+func verifyKubernetesRollout(config kubernetesDeployOptions, command command.ExecRunner, stdout io.Writer, kubeParams []string, workloads []kubernetes.Workload) []kubernetes.Workload {
+	command.Stdout(stdout)
+	failed := []kubernetes.Workload{}
+	for _, workload := range workloads {
+		log.Entry().Infof("Waiting for rollout of %v ...", workload.Resource())
+		statusParams := kubernetesWorkloadParams(kubeParams, workload, "rollout", "status", workload.Resource(), fmt.Sprintf("--timeout=%vs", config.RolloutTimeoutSeconds))
+		if err := command.RunExecutable("kubectl", statusParams...); err != nil {
+			log.Entry().WithError(err).Errorf("Rollout of %v did not finish", workload.Resource())
+			logFailingPods(command, stdout, kubeParams, workload)
+			failed = append(failed, workload)
+		}
+	}
+	return failed
+}
+
+// logFailingPods writes events and container logs of the pods of the workload which are not ready to the step log
+func logFailingPods(command command.ExecRunner, stdout io.Writer, kubeParams []string, workload kubernetes.Workload) {
+	if len(workload.Selector) == 0 {
+		return
+	}
+	var podList bytes.Buffer
+	command.Stdout(&podList)
+	err := command.RunExecutable("kubectl", kubernetesWorkloadParams(kubeParams, workload, "get", "pods", "--selector="+workload.LabelSelector(), "--output=json")...)
+	command.Stdout(stdout)
+	if err != nil {
+		log.Entry().WithError(err).Warnf("Failed to retrieve pods of %v", workload.Resource())
+		return
+	}
+	pods, err := kubernetes.FailingPods(podList.Bytes())
+	if err != nil {
+		log.Entry().WithError(err).Warnf("Failed to retrieve pods of %v", workload.Resource())
+		return
+	}
+
+	for _, pod := range pods {
+		log.Entry().Errorf("Pod '%v' is not ready (phase: %v)", pod.Name, pod.Phase)
+		log.Entry().Infof("Events of pod '%v':", pod.Name)
+		eventParams := kubernetesWorkloadParams(kubeParams, workload, "get", "events", fmt.Sprintf("--field-selector=involvedObject.kind=Pod,involvedObject.name=%v", pod.Name))
+		if err := command.RunExecutable("kubectl", eventParams...); err != nil {
+			log.Entry().WithError(err).Warnf("Failed to retrieve events of pod '%v'", pod.Name)
+		}
+		for _, container := range pod.Containers {
+			log.Entry().Infof("Logs of container '%v' of pod '%v' (reason: %v):", container.Name, pod.Name, container.Reason)
+			logParams := kubernetesWorkloadParams(kubeParams, workload, "logs", pod.Name, "--container="+container.Name, "--tail=100")
+			if container.Restarted {
+				// the current instance of a crashing container has not logged anything yet
+				logParams = append(logParams, "--previous")
+			}
+			if err := command.RunExecutable("kubectl", logParams...); err != nil {
+				log.Entry().WithError(err).Warnf("Failed to retrieve logs of container '%v' of pod '%v'", container.Name, pod.Name)
+			}
+		}
+	}
+}
+
+// kubernetesWorkloadParams returns new kubectl parameters addressing the namespace of the workload followed by args
+func kubernetesWorkloadParams(kubeParams []string, workload kubernetes.Workload, args ...string) []string {
+	params := append([]string{}, kubeParams...)
+	if len(workload.Namespace) > 0 {
+		params = append(params, fmt.Sprintf("--namespace=%v", workload.Namespace))
+	}
+	return append(params, args...)
+}
+
+func kubernetesRolloutError(config kubernetesDeployOptions, failed []kubernetes.Workload) error {
+	log.SetErrorCategory(log.ErrorDeployment)
+	resources := []string{}
+	for _, workload := range failed {
+		resources = append(resources, workload.Resource())
+	}
+	return fmt.Errorf("rollout of %v did not finish within %v seconds", strings.Join(resources, ", "), config.RolloutTimeoutSeconds)
+}
+
+func runKubernetesSmokeTest(config kubernetesDeployOptions, command command.ExecRunner, releaseName string) error {
+	if len(config.SmokeTestScript) == 0 {
+		return nil
+	}
+	smokeTestScript := config.SmokeTestScript
+	if !strings.ContainsRune(smokeTestScript, '/') {
+		smokeTestScript = "./" + smokeTestScript
+	}
+	log.Entry().Infof("Running smoke test '%v' for release '%v' ...", smokeTestScript, releaseName)
+	if err := command.RunExecutable(smokeTestScript, releaseName, config.Namespace); err != nil {
+		log.SetErrorCategory(log.ErrorTest)
+		return fmt.Errorf("smoke test of release '%v' failed: %w", releaseName, err)
 	}
 	return nil
 }
 
-func runKubectlDeploy(config kubernetesDeployOptions, command command.ExecRunner) error {
+func prepareKubernetesDeployInflux(outcome string, config kubernetesDeployOptions, influxData *kubernetesDeployInflux) {
+	if influxData == nil {
+		return
+	}
+
+	result := "FAILURE"
+	if outcome == deployOutcomeDeployed || outcome == deployOutcomePromoted {
+		result = "SUCCESS"
+	}
+	strategy := config.DeploymentStrategy
+	if len(strategy) == 0 {
+		strategy = deploymentStrategyRolling
+	}
+
+	influxData.deployment_data.tags.deployTool = config.DeployTool
+	influxData.deployment_data.tags.deployStrategy = strategy
+	influxData.deployment_data.tags.deployResult = result
+	influxData.deployment_data.tags.deployOutcome = outcome
+	influxData.deployment_data.tags.namespace = config.Namespace
+
+	influxData.deployment_data.fields.deploymentName = config.DeploymentName
+	influxData.deployment_data.fields.deployTime = strings.ToUpper(_now().Format("Jan 02 2006 15:04:05"))
+}
+
+func runKubectlDeploy(config kubernetesDeployOptions, command command.ExecRunner, stdout io.Writer) (string, error) {
 	_, containerRegistry, err := splitRegistryURL(config.ContainerRegistryURL)
 	if err != nil {
 		log.Entry().WithError(err).Fatalf("Container registry url '%v' incorrect", config.ContainerRegistryURL)
@@ -256,7 +593,7 @@ func runKubectlDeploy(config kubernetesDeployOptions, command command.ExecRunner
 	} else if len(config.ContainerImageName) > 0 && len(config.ContainerImageTag) > 0 {
 		fullImage = config.ContainerImageName + ":" + config.ContainerImageTag
 	} else {
-		return fmt.Errorf("image information not given - please either set image or containerImageName and containerImageTag")
+		return deployOutcomeFailed, fmt.Errorf("image information not given - please either set image or containerImageName and containerImageTag")
 	}
 
 	// Update image name in deployment yaml, expects placeholder like 'image: <image-name>'
@@ -268,6 +605,7 @@ func runKubectlDeploy(config kubernetesDeployOptions, command command.ExecRunner
 		log.Entry().WithError(err).Fatalf("Error when updating appTemplate '%v'", config.AppTemplate)
 	}
 
+	rolloutParams := append([]string{}, kubeParams...)
 	kubeParams = append(kubeParams, config.DeployCommand, "--filename", config.AppTemplate)
 	if config.ForceUpdates == true && config.DeployCommand == "replace" {
 		kubeParams = append(kubeParams, "--force")
@@ -281,7 +619,29 @@ func runKubectlDeploy(config kubernetesDeployOptions, command command.ExecRunner
 		log.Entry().Debugf("Running kubectl with following parameters: %v", kubeParams)
 		log.Entry().WithError(err).Fatal("Deployment with kubectl failed.")
 	}
-	return nil
+
+	if !config.VerifyRollout {
+		return deployOutcomeDeployed, nil
+	}
+	workloads, err := kubernetes.WorkloadsFromManifests(appTemplate)
+	if err != nil {
+		return deployOutcomeFailed, fmt.Errorf("failed to determine workloads of appTemplate '%v': %w", config.AppTemplate, err)
+	}
+	failed := verifyKubernetesRollout(config, command, stdout, rolloutParams, workloads)
+	if len(failed) == 0 {
+		return deployOutcomeDeployed, nil
+	}
+
+	outcome := deployOutcomeRolledBack
+	for _, workload := range failed {
+		log.Entry().Infof("Rolling back %v ...", workload.Resource())
+		undoParams := kubernetesWorkloadParams(rolloutParams, workload, "rollout", "undo", workload.Resource())
+		if err := command.RunExecutable("kubectl", undoParams...); err != nil {
+			log.Entry().WithError(err).Errorf("Rollback of %v failed", workload.Resource())
+			outcome = deployOutcomeFailed
+		}
+	}
+	return outcome, kubernetesRolloutError(config, failed)
 }
 
 func splitRegistryURL(registryURL string) (protocol, registry string, err error) {
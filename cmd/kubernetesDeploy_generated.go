@@ -5,10 +5,14 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/SAP/jenkins-library/pkg/config"
+	piperGithub "github.com/SAP/jenkins-library/pkg/github"
 	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/opentelemetry"
+	"github.com/SAP/jenkins-library/pkg/piperenv"
 	"github.com/SAP/jenkins-library/pkg/splunk"
 	"github.com/SAP/jenkins-library/pkg/telemetry"
 	"github.com/SAP/jenkins-library/pkg/validation"
@@ -27,6 +31,10 @@ type kubernetesDeployOptions struct {
 	ContainerRegistryUser      string   `json:"containerRegistryUser,omitempty"`
 	ContainerRegistrySecret    string   `json:"containerRegistrySecret,omitempty"`
 	CreateDockerRegistrySecret bool     `json:"createDockerRegistrySecret,omitempty"`
+	DeploymentStrategy         string   `json:"deploymentStrategy,omitempty" validate:"possible-values=rolling blueGreen"`
+	SmokeTestScript            string   `json:"smokeTestScript,omitempty"`
+	VerifyRollout              bool     `json:"verifyRollout,omitempty"`
+	RolloutTimeoutSeconds      int      `json:"rolloutTimeoutSeconds,omitempty"`
 	DeploymentName             string   `json:"deploymentName,omitempty"`
 	DeployTool                 string   `json:"deployTool,omitempty" validate:"possible-values=kubectl helm helm3"`
 	ForceUpdates               bool     `json:"forceUpdates,omitempty"`
@@ -44,6 +52,51 @@ type kubernetesDeployOptions struct {
 	DeployCommand              string   `json:"deployCommand,omitempty" validate:"possible-values=apply replace"`
 }
 
+type kubernetesDeployInflux struct {
+	deployment_data struct {
+		fields struct {
+			deploymentName string
+			deployTime     string
+		}
+		tags struct {
+			deployTool     string
+			deployStrategy string
+			deployResult   string
+			deployOutcome  string
+			namespace      string
+		}
+	}
+}
+
+func (i *kubernetesDeployInflux) persist(path, resourceName string) {
+	measurementContent := []struct {
+		measurement string
+		valType     string
+		name        string
+		value       interface{}
+	}{
+		{valType: config.InfluxField, measurement: "deployment_data", name: "deploymentName", value: i.deployment_data.fields.deploymentName},
+		{valType: config.InfluxField, measurement: "deployment_data", name: "deployTime", value: i.deployment_data.fields.deployTime},
+		{valType: config.InfluxTag, measurement: "deployment_data", name: "deployTool", value: i.deployment_data.tags.deployTool},
+		{valType: config.InfluxTag, measurement: "deployment_data", name: "deployStrategy", value: i.deployment_data.tags.deployStrategy},
+		{valType: config.InfluxTag, measurement: "deployment_data", name: "deployResult", value: i.deployment_data.tags.deployResult},
+		{valType: config.InfluxTag, measurement: "deployment_data", name: "deployOutcome", value: i.deployment_data.tags.deployOutcome},
+		{valType: config.InfluxTag, measurement: "deployment_data", name: "namespace", value: i.deployment_data.tags.namespace},
+	}
+
+	errCount := 0
+	for _, metric := range measurementContent {
+		err := piperenv.SetResourceParameter(path, resourceName, filepath.Join(metric.measurement, fmt.Sprintf("%vs", metric.valType), metric.name), metric.value)
+		if err != nil {
+			log.Entry().WithError(err).Error("Error persisting influx environment.")
+			errCount++
+		}
+	}
+	if errCount > 0 {
+		log.Entry().Fatal("failed to persist Influx environment")
+	}
+}
+
 // KubernetesDeployCommand Deployment to Kubernetes test or production namespace within the specified Kubernetes cluster.
 func KubernetesDeployCommand() *cobra.Command {
 	const STEP_NAME = "kubernetesDeploy"
@@ -51,8 +104,11 @@ func KubernetesDeployCommand() *cobra.Command {
 	metadata := kubernetesDeployMetadata()
 	var stepConfig kubernetesDeployOptions
 	var startTime time.Time
+	var influx kubernetesDeployInflux
 	var logCollector *log.CollectorHook
 	var splunkClient *splunk.Splunk
+	var otelClient *opentelemetry.OpenTelemetry
+	var checkRun *piperGithub.StepCheckRun
 	telemetryClient := &telemetry.Telemetry{}
 
 	var createKubernetesDeployCmd = &cobra.Command{
@@ -75,7 +131,23 @@ helm upgrade <deploymentName> <chartPath> --install --force --namespace <namespa
 
 * ` + "`" + `yourRegistry` + "`" + ` will be retrieved from ` + "`" + `containerRegistryUrl` + "`" + `
 * ` + "`" + `yourImageName` + "`" + `, ` + "`" + `yourImageTag` + "`" + ` will be retrieved from ` + "`" + `image` + "`" + `
-* ` + "`" + `dockerSecret` + "`" + ` will be calculated with a call to ` + "`" + `kubectl create secret docker-registry regsecret --docker-server=<yourRegistry> --docker-username=<containerRegistryUser> --docker-password=<containerRegistryPassword> --dry-run=true --output=json'` + "`" + ``,
+* ` + "`" + `dockerSecret` + "`" + ` will be calculated with a call to ` + "`" + `kubectl create secret docker-registry regsecret --docker-server=<yourRegistry> --docker-username=<containerRegistryUser> --docker-password=<containerRegistryPassword> --dry-run=true --output=json'` + "`" + `
+
+## Blue-green deployment
+With ` + "`" + `deployTool: helm` + "`" + ` or ` + "`" + `deployTool: helm3` + "`" + ` the parameter ` + "`" + `deploymentStrategy: blueGreen` + "`" + ` keeps the live version running while the new version is installed next to it.
+The two versions are installed as the releases ` + "`" + `<deploymentName>-blue` + "`" + ` and ` + "`" + `<deploymentName>-green` + "`" + `, the traffic is routed to one of them by the Kubernetes service ` + "`" + `<deploymentName>` + "`" + `.
+This service is not part of the releases and needs to exist before the first deployment, e.g. together with the ingress pointing to it.
+
+The step reads the label ` + "`" + `app.kubernetes.io/instance` + "`" + ` from the selector of the service to determine the live release and installs the new version as the other release.
+After the rollout of the new release has been verified via ` + "`" + `verifyRollout` + "`" + ` and ` + "`" + `smokeTestScript` + "`" + `, the selector of the service is switched to the new release and the previous release is removed.
+In case the verification or the switch fails, the new release is removed again and the service keeps routing to the previous release.
+
+The chart needs to label its pods with ` + "`" + `app.kubernetes.io/instance` + "`" + ` set to the release name, like the charts created by ` + "`" + `helm create` + "`" + ` do.
+
+## Rollout verification
+With ` + "`" + `verifyRollout` + "`" + ` the step watches the rollout of every Deployment and StatefulSet contained in the ` + "`" + `appTemplate` + "`" + ` or in the helm release until all pods are ready.
+In case a rollout does not finish within ` + "`" + `rolloutTimeoutSeconds` + "`" + `, events and container logs of the failing pods are written to the step log.
+Afterwards the failing workloads are reverted via ` + "`" + `kubectl rollout undo` + "`" + `, respectively the helm release is rolled back to its previous revision, and the step fails with error category ` + "`" + `deployment` + "`" + `.`,
 		PreRunE: func(cmd *cobra.Command, _ []string) error {
 			startTime = time.Now()
 			log.SetStepName(STEP_NAME)
@@ -109,6 +181,13 @@ helm upgrade <deploymentName> <chartPath> --install --force --namespace <namespa
 				log.RegisterHook(logCollector)
 			}
 
+			if len(GeneralConfig.HookConfig.OpenTelemetryConfig.Endpoint) > 0 {
+				otelClient = &opentelemetry.OpenTelemetry{}
+				if err := otelClient.Initialize(GeneralConfig.CorrelationID, STEP_NAME, GeneralConfig.HookConfig.OpenTelemetryConfig); err != nil {
+					log.Entry().WithError(err).Warning("failed to initialize OpenTelemetry")
+				}
+			}
+
 			validation, err := validation.New(validation.WithJSONNamesForStructFields(), validation.WithPredefinedErrorMessages())
 			if err != nil {
 				return err
@@ -125,6 +204,7 @@ helm upgrade <deploymentName> <chartPath> --install --force --namespace <namespa
 			stepTelemetryData.ErrorCode = "1"
 			handler := func() {
 				config.RemoveVaultSecretFiles()
+				influx.persist(GeneralConfig.EnvRootPath, "influx")
 				stepTelemetryData.Duration = fmt.Sprintf("%v", time.Since(startTime).Milliseconds())
 				stepTelemetryData.ErrorCategory = log.GetErrorCategory().String()
 				stepTelemetryData.PiperCommitHash = GitCommit
@@ -133,6 +213,12 @@ helm upgrade <deploymentName> <chartPath> --install --force --namespace <namespa
 				if len(GeneralConfig.HookConfig.SplunkConfig.Dsn) > 0 {
 					splunkClient.Send(telemetryClient.GetData(), logCollector)
 				}
+				if len(GeneralConfig.HookConfig.OpenTelemetryConfig.Endpoint) > 0 {
+					otelClient.Send(telemetryClient.GetData().Attributes())
+				}
+				if checkRun != nil {
+					checkRun.Complete(stepTelemetryData.ErrorCode != "0", log.GetErrorCategory())
+				}
 			}
 			log.DeferExitHandler(handler)
 			defer handler()
@@ -144,7 +230,10 @@ helm upgrade <deploymentName> <chartPath> --install --force --namespace <namespa
 					GeneralConfig.HookConfig.SplunkConfig.Index,
 					GeneralConfig.HookConfig.SplunkConfig.SendLogs)
 			}
-			kubernetesDeploy(stepConfig, &stepTelemetryData)
+			if GeneralConfig.HookConfig.GitHubChecksConfig.Enabled {
+				checkRun = piperGithub.StartStepCheckRun(STEP_NAME, GeneralConfig.StageName, GeneralConfig.HookConfig.GitHubChecksConfig)
+			}
+			kubernetesDeploy(stepConfig, &stepTelemetryData, &influx)
 			stepTelemetryData.ErrorCode = "0"
 			log.Entry().Info("SUCCESS")
 		},
@@ -166,6 +255,10 @@ func addKubernetesDeployFlags(cmd *cobra.Command, stepConfig *kubernetesDeployOp
 	cmd.Flags().StringVar(&stepConfig.ContainerRegistryUser, "containerRegistryUser", os.Getenv("PIPER_containerRegistryUser"), "Username for container registry access - typically provided by the CI/CD environment.")
 	cmd.Flags().StringVar(&stepConfig.ContainerRegistrySecret, "containerRegistrySecret", `regsecret`, "Name of the container registry secret used for pulling containers from the registry.")
 	cmd.Flags().BoolVar(&stepConfig.CreateDockerRegistrySecret, "createDockerRegistrySecret", false, "Only for `deployTool:kubectl`: Toggle to turn on `containerRegistrySecret` creation.")
+	cmd.Flags().StringVar(&stepConfig.DeploymentStrategy, "deploymentStrategy", `rolling`, "Only for `deployTool: helm` or `deployTool: helm3`: defines how a new version is rolled out. `rolling` upgrades the release directly, `blueGreen` verifies the new version in a second release before the service is switched to it.")
+	cmd.Flags().StringVar(&stepConfig.SmokeTestScript, "smokeTestScript", os.Getenv("PIPER_smokeTestScript"), "Only for `deploymentStrategy: blueGreen`: executable script which verifies the new release before the traffic is switched to it. It gets the release name and the namespace as parameters and needs to return `exit code 0` in case the release works as expected. Without a script the release is considered to be working as soon as it is ready.")
+	cmd.Flags().BoolVar(&stepConfig.VerifyRollout, "verifyRollout", false, "Watches the rollout of the deployed Deployments and StatefulSets and reverts them in case their pods do not become ready.")
+	cmd.Flags().IntVar(&stepConfig.RolloutTimeoutSeconds, "rolloutTimeoutSeconds", 300, "Only for `verifyRollout: true`: number of seconds to wait for the rollout of a single workload.")
 	cmd.Flags().StringVar(&stepConfig.DeploymentName, "deploymentName", os.Getenv("PIPER_deploymentName"), "Defines the name of the deployment. It is a mandatory parameter when `deployTool:helm` or `deployTool:helm3`.")
 	cmd.Flags().StringVar(&stepConfig.DeployTool, "deployTool", `kubectl`, "Defines the tool which should be used for deployment.")
 	cmd.Flags().BoolVar(&stepConfig.ForceUpdates, "forceUpdates", true, "Adds `--force` flag to a helm resource update command or to a kubectl replace command")
@@ -328,6 +421,42 @@ func kubernetesDeployMetadata() config.StepData {
 						Aliases:     []config.Alias{},
 						Default:     false,
 					},
+					{
+						Name:        "deploymentStrategy",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     `rolling`,
+					},
+					{
+						Name:        "smokeTestScript",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     os.Getenv("PIPER_smokeTestScript"),
+					},
+					{
+						Name:        "verifyRollout",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "bool",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     false,
+					},
+					{
+						Name:        "rolloutTimeoutSeconds",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "int",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     300,
+					},
 					{
 						Name:        "deploymentName",
 						ResourceRef: []config.ResourceReference{},
@@ -507,6 +636,17 @@ func kubernetesDeployMetadata() config.StepData {
 				{Image: "dtzar/helm-kubectl:2.17.0", WorkingDir: "/config", Options: []config.Option{{Name: "-u", Value: "0"}}, Conditions: []config.Condition{{ConditionRef: "strings-equal", Params: []config.Param{{Name: "deployTool", Value: "helm"}}}}},
 				{Image: "dtzar/helm-kubectl:2.17.0", WorkingDir: "/config", Options: []config.Option{{Name: "-u", Value: "0"}}, Conditions: []config.Condition{{ConditionRef: "strings-equal", Params: []config.Param{{Name: "deployTool", Value: "kubectl"}}}}},
 			},
+			Outputs: config.StepOutputs{
+				Resources: []config.StepResources{
+					{
+						Name: "influx",
+						Type: "influx",
+						Parameters: []map[string]interface{}{
+							{"Name": "deployment_data"}, {"fields": []map[string]string{{"name": "deploymentName"}, {"name": "deployTime"}}}, {"tags": []map[string]string{{"name": "deployTool"}, {"name": "deployStrategy"}, {"name": "deployResult"}, {"name": "deployOutcome"}, {"name": "namespace"}}},
+						},
+					},
+				},
+			},
 		},
 	}
 	return theMetaData
@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/SAP/jenkins-library/pkg/log"
 	"github.com/SAP/jenkins-library/pkg/mock"
 
 	"github.com/stretchr/testify/assert"
@@ -43,7 +44,7 @@ func TestRunKubernetesDeploy(t *testing.T) {
 
 		var stdout bytes.Buffer
 
-		runKubernetesDeploy(opts, &e, &stdout)
+		runKubernetesDeploy(opts, &e, &stdout, nil)
 
 		assert.Equal(t, "helm", e.Calls[0].Exec, "Wrong init command")
 		assert.Equal(t, []string{"init", "--client-only"}, e.Calls[0].Params, "Wrong init parameters")
@@ -102,7 +103,7 @@ func TestRunKubernetesDeploy(t *testing.T) {
 
 		var stdout bytes.Buffer
 
-		runKubernetesDeploy(opts, &e, &stdout)
+		runKubernetesDeploy(opts, &e, &stdout, nil)
 
 		assert.Equal(t, "helm", e.Calls[0].Exec, "Wrong init command")
 		assert.Equal(t, []string{"init", "--client-only"}, e.Calls[0].Params, "Wrong init parameters")
@@ -141,7 +142,7 @@ func TestRunKubernetesDeploy(t *testing.T) {
 
 		var stdout bytes.Buffer
 
-		err := runKubernetesDeploy(opts, &e, &stdout)
+		err := runKubernetesDeploy(opts, &e, &stdout, nil)
 		assert.NoError(t, err)
 
 		assert.Equal(t, "helm", e.Calls[0].Exec, "Wrong init command")
@@ -211,7 +212,7 @@ func TestRunKubernetesDeploy(t *testing.T) {
 
 		var stdout bytes.Buffer
 
-		runKubernetesDeploy(opts, &e, &stdout)
+		runKubernetesDeploy(opts, &e, &stdout, nil)
 
 		assert.Equal(t, "helm", e.Calls[0].Exec, "Wrong init command")
 		assert.Equal(t, []string{"init", "--client-only"}, e.Calls[0].Params, "Wrong init parameters")
@@ -258,7 +259,7 @@ func TestRunKubernetesDeploy(t *testing.T) {
 
 		var stdout bytes.Buffer
 
-		err := runKubernetesDeploy(opts, &e, &stdout)
+		err := runKubernetesDeploy(opts, &e, &stdout, nil)
 		assert.EqualError(t, err, "image information not given - please either set image or containerImageName and containerImageTag")
 	})
 
@@ -290,7 +291,7 @@ func TestRunKubernetesDeploy(t *testing.T) {
 
 		var stdout bytes.Buffer
 
-		runKubernetesDeploy(opts, &e, &stdout)
+		runKubernetesDeploy(opts, &e, &stdout, nil)
 
 		assert.Equal(t, "kubectl", e.Calls[0].Exec, "Wrong secret creation command")
 		assert.Equal(t, []string{"create", "secret", "--insecure-skip-tls-verify=true", "--dry-run=true", "--output=json", "docker-registry", "testSecret", "--docker-server=my.registry:55555", "--docker-username=registryUser", "--docker-password=********"}, e.Calls[0].Params, "Wrong secret creation parameters")
@@ -350,7 +351,7 @@ func TestRunKubernetesDeploy(t *testing.T) {
 
 		var stdout bytes.Buffer
 
-		runKubernetesDeploy(opts, &e, &stdout)
+		runKubernetesDeploy(opts, &e, &stdout, nil)
 
 		assert.Equal(t, "kubectl", e.Calls[0].Exec, "Wrong secret creation command")
 		assert.Equal(t, []string{"create", "secret", "--insecure-skip-tls-verify=true", "--dry-run=true", "--output=json", "docker-registry", "testSecret", "--docker-server=my.registry:55555", "--docker-username=registryUser", "--docker-password=********"}, e.Calls[0].Params, "Wrong secret creation parameters")
@@ -379,7 +380,7 @@ func TestRunKubernetesDeploy(t *testing.T) {
 
 		var stdout bytes.Buffer
 
-		err := runKubernetesDeploy(opts, &e, &stdout)
+		err := runKubernetesDeploy(opts, &e, &stdout, nil)
 		assert.EqualError(t, err, "image information not given - please either set image or containerImageName and containerImageTag")
 	})
 
@@ -412,7 +413,7 @@ func TestRunKubernetesDeploy(t *testing.T) {
 
 		var stdout bytes.Buffer
 
-		runKubernetesDeploy(opts, &e, &stdout)
+		runKubernetesDeploy(opts, &e, &stdout, nil)
 
 		assert.Equal(t, "kubectl", e.Calls[0].Exec, "Wrong secret creation command")
 		assert.Equal(t, []string{"create", "secret", "--insecure-skip-tls-verify=true", "--dry-run=true", "--output=json", "docker-registry", "testSecret", "--docker-server=my.registry:55555", "--docker-username=registryUser", "--docker-password=********"}, e.Calls[0].Params, "Wrong secret creation parameters")
@@ -461,7 +462,7 @@ func TestRunKubernetesDeploy(t *testing.T) {
 
 		var stdout bytes.Buffer
 
-		runKubernetesDeploy(opts, &e, &stdout)
+		runKubernetesDeploy(opts, &e, &stdout, nil)
 
 		assert.Equal(t, 1, len(e.Calls), "Wrong number of upgrade commands")
 		assert.Equal(t, "helm", e.Calls[0].Exec, "Wrong upgrade command")
@@ -504,7 +505,7 @@ func TestRunKubernetesDeploy(t *testing.T) {
 
 		var stdout bytes.Buffer
 
-		err := runKubernetesDeploy(opts, &e, &stdout)
+		err := runKubernetesDeploy(opts, &e, &stdout, nil)
 		assert.EqualError(t, err, "chart path has not been set, please configure chartPath parameter")
 	})
 
@@ -526,7 +527,7 @@ func TestRunKubernetesDeploy(t *testing.T) {
 
 		var stdout bytes.Buffer
 
-		err := runKubernetesDeploy(opts, &e, &stdout)
+		err := runKubernetesDeploy(opts, &e, &stdout, nil)
 		assert.EqualError(t, err, "deployment name has not been set, please configure deploymentName parameter")
 	})
 
@@ -548,7 +549,7 @@ func TestRunKubernetesDeploy(t *testing.T) {
 
 		var stdout bytes.Buffer
 
-		runKubernetesDeploy(opts, &e, &stdout)
+		runKubernetesDeploy(opts, &e, &stdout, nil)
 		assert.Equal(t, []string{
 			"upgrade",
 			"deploymentName",
@@ -604,7 +605,7 @@ spec:
 			},
 		}
 		var stdout bytes.Buffer
-		runKubernetesDeploy(opts, &e, &stdout)
+		runKubernetesDeploy(opts, &e, &stdout, nil)
 
 		assert.Equal(t, e.Env, []string{"KUBECONFIG=This is my kubeconfig"})
 
@@ -684,7 +685,7 @@ spec:
 			},
 		}
 		var stdout bytes.Buffer
-		runKubernetesDeploy(opts, &e, &stdout)
+		runKubernetesDeploy(opts, &e, &stdout, nil)
 
 		assert.Equal(t, e.Env, []string{"KUBECONFIG=This is my kubeconfig"})
 
@@ -725,7 +726,7 @@ spec:
 		e := mock.ExecMockRunner{}
 
 		var stdout bytes.Buffer
-		runKubernetesDeploy(opts, &e, &stdout)
+		runKubernetesDeploy(opts, &e, &stdout, nil)
 
 		assert.Equal(t, "kubectl", e.Calls[0].Exec, "Wrong secret lookup command")
 		assert.Equal(t, []string{
@@ -771,7 +772,7 @@ spec:
 			ShouldFailOnCommand: map[string]error{},
 		}
 		var stdout bytes.Buffer
-		runKubernetesDeploy(opts, &e, &stdout)
+		runKubernetesDeploy(opts, &e, &stdout, nil)
 
 		assert.Equal(t, "kubectl", e.Calls[0].Exec, "Wrong apply command")
 		assert.Equal(t, []string{
@@ -811,7 +812,7 @@ spec:
 			ShouldFailOnCommand: map[string]error{},
 		}
 		var stdout bytes.Buffer
-		runKubernetesDeploy(opts, &e, &stdout)
+		runKubernetesDeploy(opts, &e, &stdout, nil)
 
 		assert.Equal(t, "kubectl", e.Calls[0].Exec, "Wrong apply command")
 
@@ -842,7 +843,7 @@ spec:
 
 		var stdout bytes.Buffer
 
-		err = runKubernetesDeploy(opts, &e, &stdout)
+		err = runKubernetesDeploy(opts, &e, &stdout, nil)
 		assert.EqualError(t, err, "image information not given - please either set image or containerImageName and containerImageTag")
 	})
 
@@ -878,7 +879,7 @@ spec:
 
 		e := mock.ExecMockRunner{}
 		var stdout bytes.Buffer
-		err = runKubernetesDeploy(opts, &e, &stdout)
+		err = runKubernetesDeploy(opts, &e, &stdout, nil)
 		assert.NoError(t, err, "Command should not fail")
 
 		assert.Equal(t, e.Env, []string{"KUBECONFIG=This is my kubeconfig"})
@@ -932,7 +933,7 @@ spec:
 
 		e := mock.ExecMockRunner{}
 		var stdout bytes.Buffer
-		err = runKubernetesDeploy(opts, &e, &stdout)
+		err = runKubernetesDeploy(opts, &e, &stdout, nil)
 		assert.NoError(t, err, "Command should not fail")
 
 		assert.Equal(t, e.Env, []string{"KUBECONFIG=This is my kubeconfig"})
@@ -956,6 +957,284 @@ spec:
 
 }
 
+func TestRunKubernetesDeployBlueGreen(t *testing.T) {
+	defer log.SetErrorCategory(log.ErrorUndefined)
+
+	opts := kubernetesDeployOptions{
+		ContainerRegistryURL:    "https://my.registry:55555",
+		ContainerRegistrySecret: "testSecret",
+		ChartPath:               "path/to/chart",
+		DeploymentName:          "deploymentName",
+		DeploymentStrategy:      "blueGreen",
+		DeployTool:              "helm3",
+		HelmDeployWaitSeconds:   400,
+		Image:                   "path/to/Image:latest",
+		KubeContext:             "testCluster",
+		Namespace:               "deploymentNamespace",
+		SmokeTestScript:         "smokeTest.sh",
+	}
+	kubeParams := []string{"--namespace=deploymentNamespace", "--context=testCluster"}
+	values := "image.repository=my.registry:55555/path/to/Image,image.tag=latest,imagePullSecrets[0].name=testSecret"
+
+	t.Run("switch from blue to green", func(t *testing.T) {
+		e := mock.ExecMockRunner{StdoutReturn: map[string]string{"get service deploymentName": "deploymentName-blue"}}
+		influx := kubernetesDeployInflux{}
+		var stdout bytes.Buffer
+
+		err := runKubernetesDeploy(opts, &e, &stdout, &influx)
+
+		assert.NoError(t, err)
+		if assert.Len(t, e.Calls, 5) {
+			assert.Equal(t, mock.ExecCall{Exec: "kubectl", Params: append(kubeParams, "get", "service", "deploymentName", `--output=jsonpath={.spec.selector.app\.kubernetes\.io/instance}`)}, e.Calls[0])
+			assert.Equal(t, mock.ExecCall{Exec: "helm", Params: []string{"upgrade", "deploymentName-green", "path/to/chart", "--install", "--namespace", "deploymentNamespace", "--set", values, "--wait", "--timeout", "400s", "--atomic", "--kube-context", "testCluster"}}, e.Calls[1])
+			assert.Equal(t, mock.ExecCall{Exec: "./smokeTest.sh", Params: []string{"deploymentName-green", "deploymentNamespace"}}, e.Calls[2])
+			assert.Equal(t, mock.ExecCall{Exec: "kubectl", Params: append(kubeParams, "patch", "service", "deploymentName", "--type=merge", `--patch={"spec":{"selector":{"app.kubernetes.io/instance":"deploymentName-green"}}}`)}, e.Calls[3])
+			assert.Equal(t, mock.ExecCall{Exec: "helm", Params: []string{"uninstall", "deploymentName-blue", "--namespace", "deploymentNamespace", "--kube-context", "testCluster"}}, e.Calls[4])
+		}
+		assert.Equal(t, "blueGreen", influx.deployment_data.tags.deployStrategy)
+		assert.Equal(t, "SUCCESS", influx.deployment_data.tags.deployResult)
+		assert.Equal(t, "promoted", influx.deployment_data.tags.deployOutcome)
+	})
+
+	t.Run("first deployment", func(t *testing.T) {
+		e := mock.ExecMockRunner{}
+		influx := kubernetesDeployInflux{}
+		var stdout bytes.Buffer
+
+		err := runKubernetesDeploy(opts, &e, &stdout, &influx)
+
+		assert.NoError(t, err)
+		if assert.Len(t, e.Calls, 4) {
+			assert.Equal(t, "deploymentName-blue", e.Calls[1].Params[1])
+			assert.Equal(t, append(kubeParams, "patch", "service", "deploymentName", "--type=merge", `--patch={"spec":{"selector":{"app.kubernetes.io/instance":"deploymentName-blue"}}}`), e.Calls[3].Params)
+		}
+		assert.Equal(t, "promoted", influx.deployment_data.tags.deployOutcome)
+	})
+
+	t.Run("smoke test fails", func(t *testing.T) {
+		e := mock.ExecMockRunner{
+			StdoutReturn:        map[string]string{"get service deploymentName": "deploymentName-green"},
+			ShouldFailOnCommand: map[string]error{"./smokeTest.sh deploymentName-blue": fmt.Errorf("exit status 1")},
+		}
+		influx := kubernetesDeployInflux{}
+		var stdout bytes.Buffer
+
+		err := runKubernetesDeploy(opts, &e, &stdout, &influx)
+
+		assert.EqualError(t, err, "smoke test of release 'deploymentName-blue' failed: exit status 1")
+		if assert.Len(t, e.Calls, 4) {
+			assert.Equal(t, "deploymentName-blue", e.Calls[1].Params[1])
+			assert.Equal(t, mock.ExecCall{Exec: "helm", Params: []string{"uninstall", "deploymentName-blue", "--namespace", "deploymentNamespace", "--kube-context", "testCluster"}}, e.Calls[3])
+		}
+		assert.Equal(t, "FAILURE", influx.deployment_data.tags.deployResult)
+		assert.Equal(t, "aborted", influx.deployment_data.tags.deployOutcome)
+	})
+
+	t.Run("switch fails", func(t *testing.T) {
+		e := mock.ExecMockRunner{
+			StdoutReturn:        map[string]string{"get service deploymentName": "deploymentName-blue"},
+			ShouldFailOnCommand: map[string]error{"patch service": fmt.Errorf("forbidden")},
+		}
+		influx := kubernetesDeployInflux{}
+		var stdout bytes.Buffer
+
+		err := runKubernetesDeploy(opts, &e, &stdout, &influx)
+
+		assert.EqualError(t, err, "switching service 'deploymentName' to release 'deploymentName-green' failed: forbidden")
+		if assert.Len(t, e.Calls, 5) {
+			assert.Equal(t, []string{"uninstall", "deploymentName-green", "--namespace", "deploymentNamespace", "--kube-context", "testCluster"}, e.Calls[4].Params)
+		}
+		assert.Equal(t, "aborted", influx.deployment_data.tags.deployOutcome)
+	})
+
+	t.Run("helm 2 deployment fails", func(t *testing.T) {
+		config := opts
+		config.DeployTool = "helm"
+		config.SmokeTestScript = ""
+		e := mock.ExecMockRunner{ShouldFailOnCommand: map[string]error{"helm upgrade": fmt.Errorf("timed out waiting for the condition")}}
+		influx := kubernetesDeployInflux{}
+		var stdout bytes.Buffer
+
+		err := runKubernetesDeploy(config, &e, &stdout, &influx)
+
+		assert.EqualError(t, err, "deployment of release 'deploymentName-blue' failed: timed out waiting for the condition")
+		if assert.Len(t, e.Calls, 4) {
+			assert.Equal(t, []string{"delete", "deploymentName-blue", "--purge", "--kube-context", "testCluster"}, e.Calls[3].Params)
+		}
+		assert.Equal(t, "aborted", influx.deployment_data.tags.deployOutcome)
+	})
+
+	t.Run("service missing", func(t *testing.T) {
+		e := mock.ExecMockRunner{ShouldFailOnCommand: map[string]error{"get service": fmt.Errorf("services \"deploymentName\" not found")}}
+		influx := kubernetesDeployInflux{}
+		var stdout bytes.Buffer
+
+		err := runKubernetesDeploy(opts, &e, &stdout, &influx)
+
+		assert.EqualError(t, err, "failed to read service 'deploymentName' which routes the traffic to the blue or green release: services \"deploymentName\" not found")
+		assert.Equal(t, log.ErrorConfiguration, log.GetErrorCategory())
+		assert.Len(t, e.Calls, 1)
+		assert.Equal(t, "failed", influx.deployment_data.tags.deployOutcome)
+	})
+
+	t.Run("kubectl not supported", func(t *testing.T) {
+		config := opts
+		config.DeployTool = "kubectl"
+		e := mock.ExecMockRunner{}
+		var stdout bytes.Buffer
+
+		err := runKubernetesDeploy(config, &e, &stdout, nil)
+
+		assert.EqualError(t, err, "deployment strategy 'blueGreen' is only supported with deployTool helm or helm3")
+		assert.Empty(t, e.Calls)
+	})
+}
+
+func TestRunKubernetesDeployRolloutVerification(t *testing.T) {
+	defer log.SetErrorCategory(log.ErrorUndefined)
+
+	manifests := `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: my-app
+spec:
+  selector:
+    matchLabels:
+      app: my-app
+  template:
+    spec:
+      containers:
+      - image: <image-name>
+---
+apiVersion: apps/v1
+kind: StatefulSet
+metadata:
+  name: my-db
+  namespace: data
+spec:
+  selector:
+    matchLabels:
+      app: my-db
+`
+	podList := `{"items": [{"metadata": {"name": "my-app-1"}, "status": {"phase": "Running", "containerStatuses": [{"name": "app", "ready": false, "restartCount": 3, "state": {"waiting": {"reason": "CrashLoopBackOff"}}}]}}]}`
+
+	kubectlOpts := func(t *testing.T) kubernetesDeployOptions {
+		dir := t.TempDir()
+		opts := kubernetesDeployOptions{
+			AppTemplate:           filepath.Join(dir, "test.yaml"),
+			ContainerRegistryURL:  "https://my.registry:55555",
+			DeployTool:            "kubectl",
+			Image:                 "path/to/Image:latest",
+			KubeConfig:            "This is my kubeconfig",
+			Namespace:             "deploymentNamespace",
+			DeployCommand:         "apply",
+			VerifyRollout:         true,
+			RolloutTimeoutSeconds: 120,
+		}
+		ioutil.WriteFile(opts.AppTemplate, []byte(manifests), 0755)
+		return opts
+	}
+
+	t.Run("kubectl - rollout finished", func(t *testing.T) {
+		opts := kubectlOpts(t)
+		e := mock.ExecMockRunner{}
+		influx := kubernetesDeployInflux{}
+		var stdout bytes.Buffer
+
+		err := runKubernetesDeploy(opts, &e, &stdout, &influx)
+
+		assert.NoError(t, err)
+		if assert.Len(t, e.Calls, 3) {
+			assert.Equal(t, []string{"--insecure-skip-tls-verify=true", "--namespace=deploymentNamespace", "rollout", "status", "deployment/my-app", "--timeout=120s"}, e.Calls[1].Params)
+			assert.Equal(t, []string{"--insecure-skip-tls-verify=true", "--namespace=deploymentNamespace", "--namespace=data", "rollout", "status", "statefulset/my-db", "--timeout=120s"}, e.Calls[2].Params)
+		}
+		assert.Equal(t, "deployed", influx.deployment_data.tags.deployOutcome)
+	})
+
+	t.Run("kubectl - rollout undo", func(t *testing.T) {
+		opts := kubectlOpts(t)
+		e := mock.ExecMockRunner{
+			StdoutReturn:        map[string]string{"get pods --selector=app=my-app": podList},
+			ShouldFailOnCommand: map[string]error{"rollout status deployment/my-app": fmt.Errorf("timed out waiting for the condition")},
+		}
+		influx := kubernetesDeployInflux{}
+		var stdout bytes.Buffer
+
+		err := runKubernetesDeploy(opts, &e, &stdout, &influx)
+
+		assert.EqualError(t, err, "rollout of deployment/my-app did not finish within 120 seconds")
+		assert.Equal(t, log.ErrorDeployment, log.GetErrorCategory())
+		params := [][]string{}
+		for _, call := range e.Calls {
+			params = append(params, call.Params)
+		}
+		base := []string{"--insecure-skip-tls-verify=true", "--namespace=deploymentNamespace"}
+		assert.Equal(t, [][]string{
+			append(base, "apply", "--filename", opts.AppTemplate),
+			append(base, "rollout", "status", "deployment/my-app", "--timeout=120s"),
+			append(base, "get", "pods", "--selector=app=my-app", "--output=json"),
+			append(base, "get", "events", "--field-selector=involvedObject.kind=Pod,involvedObject.name=my-app-1"),
+			append(base, "logs", "my-app-1", "--container=app", "--tail=100", "--previous"),
+			append(base, "--namespace=data", "rollout", "status", "statefulset/my-db", "--timeout=120s"),
+			append(base, "rollout", "undo", "deployment/my-app"),
+		}, params)
+		assert.Equal(t, "FAILURE", influx.deployment_data.tags.deployResult)
+		assert.Equal(t, "rolledBack", influx.deployment_data.tags.deployOutcome)
+	})
+
+	t.Run("helm - rollback", func(t *testing.T) {
+		opts := kubernetesDeployOptions{
+			ContainerRegistryURL:    "https://my.registry:55555",
+			ContainerRegistrySecret: "testSecret",
+			ChartPath:               "path/to/chart",
+			DeploymentName:          "deploymentName",
+			DeployTool:              "helm3",
+			HelmDeployWaitSeconds:   400,
+			Image:                   "path/to/Image:latest",
+			KubeContext:             "testCluster",
+			Namespace:               "deploymentNamespace",
+			VerifyRollout:           true,
+			RolloutTimeoutSeconds:   120,
+		}
+		e := mock.ExecMockRunner{
+			StdoutReturn: map[string]string{
+				"helm history deploymentName":   `[{"revision":5,"status":"DEPLOYED"},{"revision":6,"status":"PENDING_UPGRADE"}]`,
+				"helm get manifest":             manifests,
+				"get pods --selector=app=my-db": `{"items": []}`,
+			},
+			ShouldFailOnCommand: map[string]error{"rollout status statefulset/my-db": fmt.Errorf("timed out waiting for the condition")},
+		}
+		influx := kubernetesDeployInflux{}
+		var stdout bytes.Buffer
+
+		err := runKubernetesDeploy(opts, &e, &stdout, &influx)
+
+		assert.EqualError(t, err, "rollout of statefulset/my-db did not finish within 120 seconds")
+		if assert.Len(t, e.Calls, 7) {
+			assert.Equal(t, mock.ExecCall{Exec: "helm", Params: []string{"get", "manifest", "deploymentName", "--namespace", "deploymentNamespace", "--kube-context", "testCluster"}}, e.Calls[2])
+			assert.Equal(t, mock.ExecCall{Exec: "kubectl", Params: []string{"--namespace=deploymentNamespace", "--context=testCluster", "rollout", "status", "deployment/my-app", "--timeout=120s"}}, e.Calls[3])
+			assert.Equal(t, mock.ExecCall{Exec: "helm", Params: []string{"rollback", "deploymentName", "5", "--wait", "--timeout", "400s", "--namespace", "deploymentNamespace", "--kube-context", "testCluster"}}, e.Calls[6])
+		}
+		assert.Equal(t, "rolledBack", influx.deployment_data.tags.deployOutcome)
+	})
+}
+
+func TestHelmCurrentRevision(t *testing.T) {
+	config := kubernetesDeployOptions{DeploymentName: "deploymentName", DeployTool: "helm3", Namespace: "deploymentNamespace"}
+
+	t.Run("newest successful revision", func(t *testing.T) {
+		e := mock.ExecMockRunner{StdoutReturn: map[string]string{"helm history deploymentName": `[{"revision":7,"status":"superseded"},{"revision":8,"status":"failed"},{"revision":9,"status":"superseded"},{"revision":10,"status":"failed"}]`}}
+
+		assert.Equal(t, 9, helmCurrentRevision(config, &e, &bytes.Buffer{}))
+	})
+
+	t.Run("only failed revisions", func(t *testing.T) {
+		e := mock.ExecMockRunner{StdoutReturn: map[string]string{"helm history deploymentName": `[{"revision":1,"status":"failed"}]`}}
+
+		assert.Equal(t, 0, helmCurrentRevision(config, &e, &bytes.Buffer{}))
+	})
+}
+
 func TestSplitRegistryURL(t *testing.T) {
 	tt := []struct {
 		in          string
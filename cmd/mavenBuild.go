@@ -9,6 +9,7 @@ import (
 
 	"github.com/SAP/jenkins-library/pkg/buildsettings"
 	"github.com/SAP/jenkins-library/pkg/command"
+	"github.com/SAP/jenkins-library/pkg/cyclonedx"
 	"github.com/SAP/jenkins-library/pkg/log"
 	"github.com/SAP/jenkins-library/pkg/maven"
 	"github.com/SAP/jenkins-library/pkg/piperutils"
@@ -51,19 +52,8 @@ func runMavenBuild(config *mavenBuildOptions, telemetryData *telemetry.CustomDat
 	}
 
 	if config.CreateBOM {
-		goals = append(goals, "org.cyclonedx:cyclonedx-maven-plugin:makeAggregateBom")
-		createBOMConfig := []string{
-			"-DschemaVersion=1.2",
-			"-DincludeBomSerialNumber=true",
-			"-DincludeCompileScope=true",
-			"-DincludeProvidedScope=true",
-			"-DincludeRuntimeScope=true",
-			"-DincludeSystemScope=true",
-			"-DincludeTestScope=false",
-			"-DincludeLicenseText=false",
-			"-DoutputFormat=xml",
-		}
-		defines = append(defines, createBOMConfig...)
+		goals = append(goals, cyclonedx.MavenGoal)
+		defines = append(defines, cyclonedx.MavenDefines...)
 	}
 
 	if config.Verify {
@@ -100,6 +90,12 @@ func runMavenBuild(config *mavenBuildOptions, telemetryData *telemetry.CustomDat
 	}
 	commonPipelineEnvironment.custom.buildSettingsInfo = builSettings
 
+	commonPipelineEnvironment.custom.sbomFiles = config.SbomFiles
+	if config.CreateBOM {
+		bomPath := filepath.Join(filepath.Dir(config.PomPath), "target", cyclonedx.BOMFileName)
+		commonPipelineEnvironment.custom.sbomFiles = cyclonedx.AppendSBOMFile(config.SbomFiles, bomPath)
+	}
+
 	if err == nil {
 		if config.Publish && !config.Verify {
 			log.Entry().Infof("publish detected, running mvn deploy")
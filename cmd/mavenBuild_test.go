@@ -78,6 +78,18 @@ func TestMavenBuild(t *testing.T) {
 		assert.Contains(t, mockedUtils.Calls[0].Params, "-DincludeTestScope=false")
 		assert.Contains(t, mockedUtils.Calls[0].Params, "-DincludeLicenseText=false")
 		assert.Contains(t, mockedUtils.Calls[0].Params, "-DoutputFormat=xml")
+		assert.Equal(t, []string{"target/bom.xml"}, cpe.custom.sbomFiles)
+	})
+
+	t.Run("mavenBuild should add BOM to SBOMs of previous steps", func(t *testing.T) {
+		mockedUtils := newMavenMockUtils()
+
+		config := mavenBuildOptions{CreateBOM: true, PomPath: "backend/pom.xml", SbomFiles: []string{"ui/bom.xml"}}
+
+		err := runMavenBuild(&config, nil, &mockedUtils, &cpe)
+
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"ui/bom.xml", "backend/target/bom.xml"}, cpe.custom.sbomFiles)
 	})
 
 	t.Run("mavenBuild include install and deploy when publish is true", func(t *testing.T) {
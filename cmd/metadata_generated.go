@@ -49,8 +49,10 @@ func GetAllStepMetadata() map[string]config.StepData {
 		"githubCreateIssue":                         githubCreateIssueMetadata(),
 		"githubCreatePullRequest":                   githubCreatePullRequestMetadata(),
 		"githubPublishRelease":                      githubPublishReleaseMetadata(),
+		"githubSetCheckRun":                         githubSetCheckRunMetadata(),
 		"githubSetCommitStatus":                     githubSetCommitStatusMetadata(),
 		"gitopsUpdateDeployment":                    gitopsUpdateDeploymentMetadata(),
+		"gradleBuild":                               gradleBuildMetadata(),
 		"hadolintExecute":                           hadolintExecuteMetadata(),
 		"influxWriteData":                           influxWriteDataMetadata(),
 		"integrationArtifactDeploy":                 integrationArtifactDeployMetadata(),
@@ -77,6 +79,7 @@ func GetAllStepMetadata() map[string]config.StepData {
 		"nexusUpload":                               nexusUploadMetadata(),
 		"npmExecuteLint":                            npmExecuteLintMetadata(),
 		"npmExecuteScripts":                         npmExecuteScriptsMetadata(),
+		"osvExecuteScan":                            osvExecuteScanMetadata(),
 		"pipelineCreateScanSummary":                 pipelineCreateScanSummaryMetadata(),
 		"protecodeExecuteScan":                      protecodeExecuteScanMetadata(),
 		"sonarExecuteScan":                          sonarExecuteScanMetadata(),
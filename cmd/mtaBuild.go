@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/SAP/jenkins-library/pkg/buildsettings"
+	"github.com/SAP/jenkins-library/pkg/cyclonedx"
 	"github.com/SAP/jenkins-library/pkg/npm"
 
 	"github.com/SAP/jenkins-library/pkg/command"
@@ -249,6 +250,16 @@ func runMtaBuild(config mtaBuildOptions,
 
 	commonPipelineEnvironment.mtarFilePath = mtarName
 
+	commonPipelineEnvironment.custom.sbomFiles = config.SbomFiles
+	if config.CreateBOM {
+		bomPath, err := createMtaBOM(config, mtaYamlFile, mtarName, utils)
+		if err != nil {
+			log.SetErrorCategory(log.ErrorBuild)
+			return err
+		}
+		commonPipelineEnvironment.custom.sbomFiles = cyclonedx.AppendSBOMFile(config.SbomFiles, bomPath)
+	}
+
 	if config.InstallArtifacts {
 		// install maven artifacts in local maven repo because `mbt build` executes `mvn package -B`
 		err = installMavenArtifacts(utils, config)
@@ -309,6 +320,61 @@ func runMtaBuild(config mtaBuildOptions,
 	return err
 }
 
+type mtaDescriptor struct {
+	ID      string `json:"ID"`
+	Version string `json:"version"`
+	Modules []struct {
+		Name string `json:"name"`
+		Path string `json:"path"`
+	} `json:"modules"`
+}
+
+// createMtaBOM creates the SBOMs of all modules and merges them into one SBOM describing the mtar
+func createMtaBOM(config mtaBuildOptions, mtaYamlFile, mtarName string, utils mtaBuildUtils) (string, error) {
+	content, err := utils.FileRead(mtaYamlFile)
+	if err != nil {
+		return "", err
+	}
+	descriptor := mtaDescriptor{}
+	if err := yaml.Unmarshal(content, &descriptor); err != nil {
+		return "", errors.Wrapf(err, "failed to parse %v", mtaYamlFile)
+	}
+
+	boms := []cyclonedx.BOM{}
+	for _, module := range descriptor.Modules {
+		if len(module.Path) == 0 {
+			log.Entry().Debugf("Not creating SBOM of module '%v' since it has no path", module.Name)
+			continue
+		}
+		bomPath, err := cyclonedx.CreateModuleBOM(path.Join(config.Source, module.Path), utils)
+		if err != nil {
+			return "", err
+		}
+		if len(bomPath) == 0 {
+			continue
+		}
+		bom, err := cyclonedx.ReadBOM(bomPath, utils)
+		if err != nil {
+			return "", err
+		}
+		boms = append(boms, bom)
+	}
+
+	mtaComponent := cyclonedx.Component{
+		Type:    cyclonedx.ComponentTypeApplication,
+		BOMRef:  descriptor.ID,
+		Group:   config.MtarGroup,
+		Name:    descriptor.ID,
+		Version: descriptor.Version,
+	}
+	mtaBOMPath := strings.TrimSuffix(mtarName, ".mtar") + ".bom.xml"
+	log.Entry().Infof("Writing SBOM of %v modules to %v", len(boms), mtaBOMPath)
+	if err := cyclonedx.WriteBOM(cyclonedx.Merge(mtaComponent, boms...), mtaBOMPath, utils); err != nil {
+		return "", err
+	}
+	return mtaBOMPath, nil
+}
+
 func handleActiveProfileUpdate(config mtaBuildOptions, utils mtaBuildUtils) error {
 	if len(config.Profiles) > 0 {
 		return maven.UpdateActiveProfileInSettingsXML(config.Profiles, utils)
@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/SAP/jenkins-library/pkg/cyclonedx"
 	"github.com/SAP/jenkins-library/pkg/mock"
 	"github.com/ghodss/yaml"
 	"github.com/stretchr/testify/assert"
@@ -250,6 +251,51 @@ func TestMarBuild(t *testing.T) {
 		})
 	})
 
+	t.Run("SBOM related tests", func(t *testing.T) {
+
+		t.Run("merge SBOMs of modules", func(t *testing.T) {
+
+			utilsMock := newMtaBuildTestUtilsBundle()
+			utilsMock.AddFile("mta.yaml", []byte("ID: myMta\nversion: 1.0.0\nmodules:\n  - name: srv\n    path: srv\n  - name: ui\n    path: app/ui\n  - name: db\n    path: db\n  - name: content\n"))
+			utilsMock.AddFile("srv/pom.xml", []byte("<project/>"))
+			utilsMock.AddFile("srv/target/bom.xml", []byte(`<bom xmlns="http://cyclonedx.org/schema/bom/1.2" version="1"><metadata><component type="library"><group>com.example</group><name>srv</name><version>1.0.0</version><purl>pkg:maven/com.example/srv@1.0.0</purl></component></metadata><components><component type="library"><name>slf4j-api</name><version>1.7.32</version><purl>pkg:maven/org.slf4j/slf4j-api@1.7.32</purl></component></components></bom>`))
+			utilsMock.AddFile("app/ui/package.json", []byte("{}"))
+			utilsMock.AddFile("app/ui/bom.xml", []byte(`<bom xmlns="http://cyclonedx.org/schema/bom/1.3" version="1"><components><component type="library"><name>lodash</name><version>4.17.21</version><purl>pkg:npm/lodash@4.17.21</purl></component></components></bom>`))
+			utilsMock.AddFile("db/package.yaml", []byte(""))
+
+			options := mtaBuildOptions{Platform: "CF", MtarGroup: "com.example", MtarName: "myMta.mtar", Source: "./", Target: "./", CreateBOM: true, SbomFiles: []string{"image.bom.xml"}}
+
+			err := runMtaBuild(options, &cpe, utilsMock)
+
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"image.bom.xml", "myMta.bom.xml"}, cpe.custom.sbomFiles)
+			bom, err := cyclonedx.ReadBOM("myMta.bom.xml", utilsMock)
+			if assert.NoError(t, err) {
+				assert.True(t, bom.Describes("com.example", "myMta"))
+				assert.Equal(t, "1.0.0", bom.Metadata.Component.Version)
+				names := []string{}
+				for _, component := range bom.Components {
+					names = append(names, component.Name)
+				}
+				assert.Equal(t, []string{"srv", "slf4j-api", "lodash"}, names)
+			}
+		})
+
+		t.Run("error when creating module SBOM fails", func(t *testing.T) {
+
+			utilsMock := newMtaBuildTestUtilsBundle()
+			utilsMock.AddFile("mta.yaml", []byte("ID: myMta\nversion: 1.0.0\nmodules:\n  - name: ui\n    path: ui\n"))
+			utilsMock.AddFile("ui/package.json", []byte("{}"))
+			utilsMock.ShouldFailOnCommand = map[string]error{"npx cyclonedx-bom": errors.New("execution failed")}
+
+			options := mtaBuildOptions{Platform: "CF", MtarName: "myMta.mtar", CreateBOM: true}
+
+			err := runMtaBuild(options, &cpe, utilsMock)
+
+			assert.EqualError(t, err, "failed to create SBOM of npm module 'ui': execution failed")
+		})
+	})
+
 	t.Run("publish related tests", func(t *testing.T) {
 
 		t.Run("error when no repository url", func(t *testing.T) {
@@ -13,6 +13,7 @@ import (
 	b64 "encoding/base64"
 
 	"github.com/SAP/jenkins-library/pkg/command"
+	"github.com/SAP/jenkins-library/pkg/cyclonedx"
 	"github.com/SAP/jenkins-library/pkg/log"
 	"github.com/SAP/jenkins-library/pkg/maven"
 	"github.com/SAP/jenkins-library/pkg/nexus"
@@ -170,7 +171,10 @@ func uploadMTA(utils nexusUploadUtils, uploader nexus.Uploader, options *nexusUp
 		mtaPath = "mta.yml"
 	}
 	mtaInfo, err := getInfoFromMtaFile(utils, mtaPath)
+	var mtaID string
 	if err == nil {
+		// the SBOM created by mtaBuild describes the MTA by the ID from the descriptor
+		mtaID = mtaInfo.ID
 		if options.ArtifactID != "" {
 			mtaInfo.ID = options.ArtifactID
 		}
@@ -187,6 +191,9 @@ func uploadMTA(utils nexusUploadUtils, uploader nexus.Uploader, options *nexusUp
 		log.Entry().Debugf("mtar file path: '%s'", mtarFilePath)
 		err = addArtifact(utils, uploader, mtarFilePath, "", "mtar")
 	}
+	if err == nil {
+		err = addSBOM(utils, uploader, options.SbomFiles, options.GroupID, mtaID)
+	}
 	if err == nil {
 		err = uploadArtifacts(utils, uploader, options, false)
 	}
@@ -373,6 +380,17 @@ func addArtifact(utils nexusUploadUtils, uploader nexus.Uploader, filePath, clas
 	return uploader.AddArtifact(artifact)
 }
 
+// addSBOM adds the SBOM describing the artifact with the given coordinates, if one has been created during the build
+func addSBOM(utils nexusUploadUtils, uploader nexus.Uploader, sbomFiles []string, groupID, artifactID string) error {
+	sbomFile := cyclonedx.FindBOM(sbomFiles, groupID, artifactID, utils)
+	if sbomFile == "" {
+		log.Entry().Debugf("No SBOM found for artifact '%s:%s'", groupID, artifactID)
+		return nil
+	}
+	log.Entry().Infof("Adding SBOM '%s' of artifact '%s:%s'", sbomFile, groupID, artifactID)
+	return addArtifact(utils, uploader, sbomFile, "cyclonedx", "xml")
+}
+
 var errPomNotFound = errors.New("pom.xml not found")
 
 func uploadMaven(utils nexusUploadUtils, uploader nexus.Uploader, options *nexusUploadOptions) error {
@@ -439,6 +457,9 @@ func uploadMavenArtifacts(utils nexusUploadUtils, uploader nexus.Uploader, optio
 	if err == nil {
 		err = addArtifact(utils, uploader, pomFile, "", "pom")
 	}
+	if err == nil {
+		err = addSBOM(utils, uploader, options.SbomFiles, groupID, artifactID)
+	}
 	if err == nil && packaging != "pom" {
 		err = addMavenTargetArtifacts(utils, uploader, pomFile, targetFolder, finalBuildName, packaging)
 	}
@@ -8,7 +8,9 @@ import (
 	"time"
 
 	"github.com/SAP/jenkins-library/pkg/config"
+	piperGithub "github.com/SAP/jenkins-library/pkg/github"
 	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/opentelemetry"
 	"github.com/SAP/jenkins-library/pkg/splunk"
 	"github.com/SAP/jenkins-library/pkg/telemetry"
 	"github.com/SAP/jenkins-library/pkg/validation"
@@ -16,17 +18,18 @@ import (
 )
 
 type nexusUploadOptions struct {
-	Version            string `json:"version,omitempty" validate:"possible-values=nexus2 nexus3"`
-	Format             string `json:"format,omitempty" validate:"possible-values=maven npm"`
-	Url                string `json:"url,omitempty"`
-	MavenRepository    string `json:"mavenRepository,omitempty"`
-	NpmRepository      string `json:"npmRepository,omitempty"`
-	GroupID            string `json:"groupId,omitempty"`
-	ArtifactID         string `json:"artifactId,omitempty"`
-	GlobalSettingsFile string `json:"globalSettingsFile,omitempty"`
-	M2Path             string `json:"m2Path,omitempty"`
-	Username           string `json:"username,omitempty"`
-	Password           string `json:"password,omitempty"`
+	Version            string   `json:"version,omitempty" validate:"possible-values=nexus2 nexus3"`
+	Format             string   `json:"format,omitempty" validate:"possible-values=maven npm"`
+	Url                string   `json:"url,omitempty"`
+	MavenRepository    string   `json:"mavenRepository,omitempty"`
+	NpmRepository      string   `json:"npmRepository,omitempty"`
+	GroupID            string   `json:"groupId,omitempty"`
+	ArtifactID         string   `json:"artifactId,omitempty"`
+	GlobalSettingsFile string   `json:"globalSettingsFile,omitempty"`
+	M2Path             string   `json:"m2Path,omitempty"`
+	Username           string   `json:"username,omitempty"`
+	Password           string   `json:"password,omitempty"`
+	SbomFiles          []string `json:"sbomFiles,omitempty"`
 }
 
 // NexusUploadCommand Upload artifacts to Nexus Repository Manager
@@ -38,6 +41,8 @@ func NexusUploadCommand() *cobra.Command {
 	var startTime time.Time
 	var logCollector *log.CollectorHook
 	var splunkClient *splunk.Splunk
+	var otelClient *opentelemetry.OpenTelemetry
+	var checkRun *piperGithub.StepCheckRun
 	telemetryClient := &telemetry.Telemetry{}
 
 	var createNexusUploadCmd = &cobra.Command{
@@ -93,6 +98,13 @@ If an image for mavenExecute is configured, and npm packages are to be published
 				log.RegisterHook(logCollector)
 			}
 
+			if len(GeneralConfig.HookConfig.OpenTelemetryConfig.Endpoint) > 0 {
+				otelClient = &opentelemetry.OpenTelemetry{}
+				if err := otelClient.Initialize(GeneralConfig.CorrelationID, STEP_NAME, GeneralConfig.HookConfig.OpenTelemetryConfig); err != nil {
+					log.Entry().WithError(err).Warning("failed to initialize OpenTelemetry")
+				}
+			}
+
 			validation, err := validation.New(validation.WithJSONNamesForStructFields(), validation.WithPredefinedErrorMessages())
 			if err != nil {
 				return err
@@ -117,6 +129,12 @@ If an image for mavenExecute is configured, and npm packages are to be published
 				if len(GeneralConfig.HookConfig.SplunkConfig.Dsn) > 0 {
 					splunkClient.Send(telemetryClient.GetData(), logCollector)
 				}
+				if len(GeneralConfig.HookConfig.OpenTelemetryConfig.Endpoint) > 0 {
+					otelClient.Send(telemetryClient.GetData().Attributes())
+				}
+				if checkRun != nil {
+					checkRun.Complete(stepTelemetryData.ErrorCode != "0", log.GetErrorCategory())
+				}
 			}
 			log.DeferExitHandler(handler)
 			defer handler()
@@ -128,6 +146,9 @@ If an image for mavenExecute is configured, and npm packages are to be published
 					GeneralConfig.HookConfig.SplunkConfig.Index,
 					GeneralConfig.HookConfig.SplunkConfig.SendLogs)
 			}
+			if GeneralConfig.HookConfig.GitHubChecksConfig.Enabled {
+				checkRun = piperGithub.StartStepCheckRun(STEP_NAME, GeneralConfig.StageName, GeneralConfig.HookConfig.GitHubChecksConfig)
+			}
 			nexusUpload(stepConfig, &stepTelemetryData)
 			stepTelemetryData.ErrorCode = "0"
 			log.Entry().Info("SUCCESS")
@@ -150,6 +171,7 @@ func addNexusUploadFlags(cmd *cobra.Command, stepConfig *nexusUploadOptions) {
 	cmd.Flags().StringVar(&stepConfig.M2Path, "m2Path", os.Getenv("PIPER_m2Path"), "The path to the local .m2 directory, only used for Maven projects.")
 	cmd.Flags().StringVar(&stepConfig.Username, "username", os.Getenv("PIPER_username"), "Username for accessing the Nexus endpoint.")
 	cmd.Flags().StringVar(&stepConfig.Password, "password", os.Getenv("PIPER_password"), "Password for accessing the Nexus endpoint.")
+	cmd.Flags().StringSliceVar(&stepConfig.SbomFiles, "sbomFiles", []string{}, "Paths of the CycloneDX SBOMs created by the build steps. The SBOM describing an uploaded maven artifact or MTA is uploaded along with it using the classifier `cyclonedx`.")
 
 	cmd.MarkFlagRequired("url")
 }
@@ -303,6 +325,20 @@ func nexusUploadMetadata() config.StepData {
 						Aliases:   []config.Alias{},
 						Default:   os.Getenv("PIPER_password"),
 					},
+					{
+						Name: "sbomFiles",
+						ResourceRef: []config.ResourceReference{
+							{
+								Name:  "commonPipelineEnvironment",
+								Param: "custom/sbomFiles",
+							},
+						},
+						Scope:     []string{"STEPS", "STAGES", "PARAMETERS"},
+						Type:      "[]string",
+						Mandatory: false,
+						Aliases:   []config.Alias{},
+						Default:   []string{},
+					},
 				},
 			},
 			Containers: []config.Container{
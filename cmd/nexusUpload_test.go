@@ -263,6 +263,28 @@ func TestUploadMTAProjects(t *testing.T) {
 			assert.Equal(t, "mtar", artifacts[1].Type)
 		}
 	})
+	t.Run("Test uploading mta.yaml project with SBOM works", func(t *testing.T) {
+		t.Parallel()
+		utils := newMockUtilsBundle(true, false, false)
+		utils.AddFile("mta.yaml", testMtaYml)
+		utils.AddFile("test.mtar", []byte("contentsOfMtar"))
+		utils.AddFile("bom-image.xml", []byte(`<bom version="1"><metadata><component type="container"><name>my-image</name></component></metadata></bom>`))
+		utils.AddFile("test.bom.xml", []byte(`<bom version="1"><metadata><component type="application"><group>my.group.id</group><name>test</name></component></metadata></bom>`))
+		utils.cpe[".pipeline/commonPipelineEnvironment/mtarFilePath"] = "test.mtar"
+		uploader := mockUploader{}
+		options := createOptions()
+		options.SbomFiles = []string{"bom-image.xml", "test.bom.xml"}
+
+		err := runNexusUpload(utils, &uploader, &options)
+		assert.NoError(t, err, "expected mta.yaml project upload to work")
+
+		artifacts := uploader.uploadedArtifacts
+		if assert.Equal(t, 3, len(artifacts)) {
+			assert.Equal(t, "test.bom.xml", artifacts[2].File)
+			assert.Equal(t, "xml", artifacts[2].Type)
+			assert.Equal(t, "cyclonedx", artifacts[2].Classifier)
+		}
+	})
 	t.Run("Test uploading mta.yml project works", func(t *testing.T) {
 		t.Parallel()
 		utils := newMockUtilsBundle(true, false, false)
@@ -442,6 +464,31 @@ func TestUploadMavenProjects(t *testing.T) {
 			assert.Equal(t, "pom", artifacts[0].Type)
 		}
 	})
+	t.Run("Test uploading Maven project with SBOM works", func(t *testing.T) {
+		t.Parallel()
+		utils := newMockUtilsBundle(false, true, false)
+		utils.setProperty("pom.xml", "project.version", "1.0")
+		utils.setProperty("pom.xml", "project.groupId", "com.mycompany.app")
+		utils.setProperty("pom.xml", "project.artifactId", "my-app")
+		utils.setProperty("pom.xml", "project.packaging", "pom")
+		utils.setProperty("pom.xml", "project.build.finalName", "my-app-1.0")
+		utils.AddFile("pom.xml", testPomXml)
+		utils.AddFile("target/bom.xml", []byte(`<bom version="1"><metadata><component type="library"><group>com.mycompany.app</group><name>my-app</name></component></metadata></bom>`))
+		uploader := mockUploader{}
+		options := createOptions()
+		options.SbomFiles = []string{"target/bom.xml"}
+
+		err := runNexusUpload(utils, &uploader, &options)
+		assert.NoError(t, err, "expected Maven upload to work")
+
+		artifacts := uploader.uploadedArtifacts
+		if assert.Equal(t, 2, len(artifacts)) {
+			assert.Equal(t, "pom.xml", artifacts[0].File)
+			assert.Equal(t, "target/bom.xml", artifacts[1].File)
+			assert.Equal(t, "xml", artifacts[1].Type)
+			assert.Equal(t, "cyclonedx", artifacts[1].Classifier)
+		}
+	})
 	t.Run("Test uploading Maven project with JAR packaging fails without main target", func(t *testing.T) {
 		t.Parallel()
 		utils := newMockUtilsBundle(false, true, false)
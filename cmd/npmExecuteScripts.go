@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"github.com/SAP/jenkins-library/pkg/buildsettings"
+	"github.com/SAP/jenkins-library/pkg/cyclonedx"
 	"github.com/SAP/jenkins-library/pkg/log"
 	"github.com/SAP/jenkins-library/pkg/npm"
 	"github.com/SAP/jenkins-library/pkg/telemetry"
@@ -60,6 +61,11 @@ func runNpmExecuteScripts(npmExecutor npm.Executor, config *npmExecuteScriptsOpt
 	}
 	commonPipelineEnvironment.custom.buildSettingsInfo = builSettings
 
+	commonPipelineEnvironment.custom.sbomFiles = config.SbomFiles
+	if config.CreateBOM {
+		commonPipelineEnvironment.custom.sbomFiles = cyclonedx.AppendSBOMFile(config.SbomFiles, cyclonedx.BOMFileName)
+	}
+
 	if config.Publish {
 		packageJSONFiles, err := npmExecutor.FindPackageJSONFilesWithExcludes(config.BuildDescriptorExcludeList)
 		if err != nil {
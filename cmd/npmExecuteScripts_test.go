@@ -135,5 +135,6 @@ func TestNpmExecuteScripts(t *testing.T) {
 		err := runNpmExecuteScripts(&npmExecutor, &config, &cpe)
 
 		assert.NoError(t, err)
+		assert.Equal(t, []string{"bom.xml"}, cpe.custom.sbomFiles)
 	})
 }
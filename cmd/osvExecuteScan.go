@@ -0,0 +1,189 @@
+package cmd
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/SAP/jenkins-library/pkg/command"
+	"github.com/SAP/jenkins-library/pkg/cyclonedx"
+	piperhttp "github.com/SAP/jenkins-library/pkg/http"
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/maven"
+	"github.com/SAP/jenkins-library/pkg/osv"
+	"github.com/SAP/jenkins-library/pkg/piperutils"
+	"github.com/SAP/jenkins-library/pkg/reporting"
+	"github.com/SAP/jenkins-library/pkg/telemetry"
+	"github.com/pkg/errors"
+)
+
+const osvReportsDirectory = "osv"
+
+type osvExecuteScanUtils interface {
+	maven.Utils
+
+	DirExists(path string) (bool, error)
+}
+
+type osvExecuteScanUtilsBundle struct {
+	*command.Command
+	*piperutils.Files
+	*piperhttp.Client
+}
+
+func newOsvExecuteScanUtils() osvExecuteScanUtils {
+	utils := osvExecuteScanUtilsBundle{
+		Command: &command.Command{},
+		Files:   &piperutils.Files{},
+		Client:  &piperhttp.Client{},
+	}
+	// Reroute command output to logging framework
+	utils.Stdout(log.Writer())
+	utils.Stderr(log.Writer())
+	return &utils
+}
+
+func osvExecuteScan(config osvExecuteScanOptions, telemetryData *telemetry.CustomData) {
+	utils := newOsvExecuteScanUtils()
+
+	err := runOsvExecuteScan(&config, telemetryData, utils)
+	if err != nil {
+		log.Entry().WithError(err).Fatal("step execution failed")
+	}
+}
+
+func runOsvExecuteScan(config *osvExecuteScanOptions, telemetryData *telemetry.CustomData, utils osvExecuteScanUtils) error {
+	cvssSeverityLimit, err := strconv.ParseFloat(config.CvssSeverityLimit, 64)
+	if err != nil {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return fmt.Errorf("failed to parse parameter cvssSeverityLimit (%s) "+
+			"as floating point number: %w", config.CvssSeverityLimit, err)
+	}
+
+	sbomFiles, err := osvSBOMFiles(config, utils)
+	if err != nil {
+		return err
+	}
+	components := []osv.Component{}
+	for _, sbomFile := range sbomFiles {
+		sbomComponents, err := osv.ReadComponents(sbomFile, utils)
+		if err != nil {
+			log.SetErrorCategory(log.ErrorConfiguration)
+			return err
+		}
+		log.Entry().Infof("Scanning %v components of SBOM '%v'", len(sbomComponents), sbomFile)
+		components = append(components, sbomComponents...)
+	}
+
+	db, err := osv.LoadDatabase(config.OsvDatabasePath, utils)
+	if err != nil {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return errors.Wrap(err, "failed to load OSV database")
+	}
+	triage, err := osvTriage(config, utils)
+	if err != nil {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return err
+	}
+
+	results := osv.Scan(db, components, triage)
+	scanReport := osv.CreateScanReport(results, len(components), sbomFiles, config.OsvDatabasePath, cvssSeverityLimit)
+	triage.Apply(&scanReport)
+	reportPaths, err := writeOsvReports(scanReport, sbomFiles, utils)
+	if err != nil {
+		return err
+	}
+	piperutils.PersistReportsAndLinks("osvExecuteScan", "", reportPaths, nil)
+
+	severeVulnerabilities := 0
+	for _, result := range results {
+		switch {
+		case result.IsSevere(cvssSeverityLimit):
+			severeVulnerabilities++
+			log.Entry().Errorf("%v (CVSS score %.1f) in %v@%v: %v", result.Vulnerability.CVE(), result.Score, result.Component.Package.Name, result.Component.Version, result.Vulnerability.Summary)
+		case result.Suppression != nil:
+			log.Entry().Infof("%v in %v@%v has been excluded", result.Vulnerability.CVE(), result.Component.Package.Name, result.Component.Version)
+		default:
+			log.Entry().Warnf("%v (CVSS score %.1f) in %v@%v: %v", result.Vulnerability.CVE(), result.Score, result.Component.Package.Name, result.Component.Version, result.Vulnerability.Summary)
+		}
+	}
+	if severeVulnerabilities > 0 {
+		log.SetErrorCategory(log.ErrorCompliance)
+		return fmt.Errorf("%v Open Source Software Security vulnerabilities with CVSS score greater "+
+			"or equal to %.1f detected", severeVulnerabilities, cvssSeverityLimit)
+	}
+	log.Entry().Infof("No Open Source Software Security vulnerabilities with CVSS score greater or equal to %.1f detected in %v components", cvssSeverityLimit, len(components))
+	return nil
+}
+
+// osvSBOMFiles returns the existing SBOMs, if there is none an SBOM of the resolved dependencies of the project is created
+func osvSBOMFiles(config *osvExecuteScanOptions, utils osvExecuteScanUtils) ([]string, error) {
+	sbomFiles := []string{}
+	for _, sbomFile := range config.SbomFiles {
+		if exists, _ := utils.FileExists(sbomFile); !exists {
+			log.Entry().Warnf("Ignoring SBOM '%v' since it does not exist", sbomFile)
+			continue
+		}
+		sbomFiles = append(sbomFiles, sbomFile)
+	}
+	if len(sbomFiles) > 0 {
+		return sbomFiles, nil
+	}
+
+	log.Entry().Infof("No SBOM available, scanning the resolved dependencies of '%v'", config.ScanPath)
+	bomPath, err := cyclonedx.CreateModuleBOM(config.ScanPath, utils)
+	if err != nil {
+		return nil, err
+	}
+	if len(bomPath) == 0 {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return nil, fmt.Errorf("no SBOM available and '%v' does not contain a supported build descriptor", config.ScanPath)
+	}
+	return []string{bomPath}, nil
+}
+
+// osvTriage returns the triage file extended by the vulnerabilities excluded via configuration
+func osvTriage(config *osvExecuteScanOptions, utils osvExecuteScanUtils) (*reporting.Triage, error) {
+	triage, err := reporting.ReadTriage(config.TriageFile, utils)
+	if err != nil {
+		return nil, err
+	}
+	for _, cve := range config.ExcludeCVEs {
+		triage.Suppressions = append(triage.Suppressions, reporting.Suppression{ID: cve, Justification: "excluded via configuration"})
+	}
+	return triage, nil
+}
+
+func writeOsvReports(scanReport reporting.ScanReport, sbomFiles []string, utils osvExecuteScanUtils) ([]piperutils.Path, error) {
+	reportPaths := []piperutils.Path{}
+
+	// ignore templating errors since template is in our hands and issues will be detected with the automated tests
+	htmlReport, _ := scanReport.ToHTML()
+	htmlReportPath := filepath.Join(osvReportsDirectory, "piper_osv_report.html")
+	if err := utils.MkdirAll(osvReportsDirectory, 0777); err != nil {
+		return reportPaths, errors.Wrap(err, "failed to create report directory")
+	}
+	if err := utils.FileWrite(htmlReportPath, htmlReport, 0666); err != nil {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return reportPaths, errors.Wrap(err, "failed to write html report")
+	}
+	reportPaths = append(reportPaths, piperutils.Path{Name: "OSV Vulnerability Report", Target: htmlReportPath})
+
+	// JSON reports are used by step pipelineCreateSummary in order to e.g. prepare an issue creation in GitHub
+	// ignore JSON errors since structure is in our hands
+	jsonReport, _ := scanReport.ToJSON()
+	if exists, _ := utils.DirExists(reporting.StepReportDirectory); !exists {
+		err := utils.MkdirAll(reporting.StepReportDirectory, 0777)
+		if err != nil {
+			return reportPaths, errors.Wrap(err, "failed to create reporting directory")
+		}
+	}
+	reportSha := fmt.Sprintf("%x", sha1.Sum([]byte(strings.Join(sbomFiles, ","))))
+	if err := utils.FileWrite(filepath.Join(reporting.StepReportDirectory, fmt.Sprintf("osvExecuteScan_osv_%v.json", reportSha)), jsonReport, 0666); err != nil {
+		return reportPaths, errors.Wrap(err, "failed to write json report")
+	}
+
+	return reportPaths, nil
+}
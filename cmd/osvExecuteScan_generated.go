@@ -0,0 +1,243 @@
+// Code generated by piper's step-generator. DO NOT EDIT.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/SAP/jenkins-library/pkg/config"
+	piperGithub "github.com/SAP/jenkins-library/pkg/github"
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/opentelemetry"
+	"github.com/SAP/jenkins-library/pkg/splunk"
+	"github.com/SAP/jenkins-library/pkg/telemetry"
+	"github.com/SAP/jenkins-library/pkg/validation"
+	"github.com/spf13/cobra"
+)
+
+type osvExecuteScanOptions struct {
+	OsvDatabasePath   string   `json:"osvDatabasePath,omitempty"`
+	SbomFiles         []string `json:"sbomFiles,omitempty"`
+	ScanPath          string   `json:"scanPath,omitempty"`
+	CvssSeverityLimit string   `json:"cvssSeverityLimit,omitempty"`
+	ExcludeCVEs       []string `json:"excludeCVEs,omitempty"`
+	TriageFile        string   `json:"triageFile,omitempty"`
+}
+
+// OsvExecuteScanCommand Matches the dependencies of a project against a local OSV vulnerability database
+func OsvExecuteScanCommand() *cobra.Command {
+	const STEP_NAME = "osvExecuteScan"
+
+	metadata := osvExecuteScanMetadata()
+	var stepConfig osvExecuteScanOptions
+	var startTime time.Time
+	var logCollector *log.CollectorHook
+	var splunkClient *splunk.Splunk
+	var otelClient *opentelemetry.OpenTelemetry
+	var checkRun *piperGithub.StepCheckRun
+	telemetryClient := &telemetry.Telemetry{}
+
+	var createOsvExecuteScanCmd = &cobra.Command{
+		Use:   STEP_NAME,
+		Short: "Matches the dependencies of a project against a local OSV vulnerability database",
+		Long: `This step scans the open source dependencies of a project for publicly known vulnerabilities without the need for a commercial scan service.
+The dependencies are matched against a local mirror of the [OSV database](https://osv.dev), which allows to scan also in air-gapped environments.
+
+The dependencies are taken from the SBOMs created by the build steps (see parameter ` + "`" + `createBOM` + "`" + ` of the build steps) or provided via ` + "`" + `sbomFiles` + "`" + `.
+CycloneDX SBOMs in XML or JSON format as well as SPDX SBOMs in JSON format are supported, components are identified by their package URL.
+If no SBOM is available, the step creates one for the resolved dependency tree of the project in ` + "`" + `scanPath` + "`" + ` (maven, npm, go and pip projects are supported).
+
+The OSV database is read from the directory ` + "`" + `osvDatabasePath` + "`" + `. It may contain the OSV entries as single JSON files or the zip archives
+provided per ecosystem by osv.dev, e.g. ` + "`" + `gsutil cp gs://osv-vulnerabilities/npm/all.zip osv/npm/all.zip` + "`" + `.
+
+The step fails if a vulnerability with a CVSS v3 score greater or equal to ` + "`" + `cvssSeverityLimit` + "`" + ` is found, unless it has been excluded.
+Vulnerabilities can be excluded via ` + "`" + `excludeCVEs` + "`" + ` or via a triage file which documents the assessment:
+
+` + "`" + `` + "`" + `` + "`" + `yaml
+exclusions:
+  - id: CVE-2021-23337
+    component: pkg:npm/lodash
+    reason: template function is not used
+` + "`" + `` + "`" + `` + "`" + ``,
+		PreRunE: func(cmd *cobra.Command, _ []string) error {
+			startTime = time.Now()
+			log.SetStepName(STEP_NAME)
+			log.SetVerbose(GeneralConfig.Verbose)
+
+			GeneralConfig.GitHubAccessTokens = ResolveAccessTokens(GeneralConfig.GitHubTokens)
+
+			path, _ := os.Getwd()
+			fatalHook := &log.FatalHook{CorrelationID: GeneralConfig.CorrelationID, Path: path}
+			log.RegisterHook(fatalHook)
+
+			err := PrepareConfig(cmd, &metadata, STEP_NAME, &stepConfig, config.OpenPiperFile)
+			if err != nil {
+				log.SetErrorCategory(log.ErrorConfiguration)
+				return err
+			}
+
+			if len(GeneralConfig.HookConfig.SentryConfig.Dsn) > 0 {
+				sentryHook := log.NewSentryHook(GeneralConfig.HookConfig.SentryConfig.Dsn, GeneralConfig.CorrelationID)
+				log.RegisterHook(&sentryHook)
+			}
+
+			if len(GeneralConfig.HookConfig.SplunkConfig.Dsn) > 0 {
+				splunkClient = &splunk.Splunk{}
+				logCollector = &log.CollectorHook{CorrelationID: GeneralConfig.CorrelationID}
+				log.RegisterHook(logCollector)
+			}
+
+			if len(GeneralConfig.HookConfig.OpenTelemetryConfig.Endpoint) > 0 {
+				otelClient = &opentelemetry.OpenTelemetry{}
+				if err := otelClient.Initialize(GeneralConfig.CorrelationID, STEP_NAME, GeneralConfig.HookConfig.OpenTelemetryConfig); err != nil {
+					log.Entry().WithError(err).Warning("failed to initialize OpenTelemetry")
+				}
+			}
+
+			validation, err := validation.New(validation.WithJSONNamesForStructFields(), validation.WithPredefinedErrorMessages())
+			if err != nil {
+				return err
+			}
+			if err = validation.ValidateStruct(stepConfig); err != nil {
+				log.SetErrorCategory(log.ErrorConfiguration)
+				return err
+			}
+
+			return nil
+		},
+		Run: func(_ *cobra.Command, _ []string) {
+			stepTelemetryData := telemetry.CustomData{}
+			stepTelemetryData.ErrorCode = "1"
+			handler := func() {
+				config.RemoveVaultSecretFiles()
+				stepTelemetryData.Duration = fmt.Sprintf("%v", time.Since(startTime).Milliseconds())
+				stepTelemetryData.ErrorCategory = log.GetErrorCategory().String()
+				stepTelemetryData.PiperCommitHash = GitCommit
+				telemetryClient.SetData(&stepTelemetryData)
+				telemetryClient.Send()
+				if len(GeneralConfig.HookConfig.SplunkConfig.Dsn) > 0 {
+					splunkClient.Send(telemetryClient.GetData(), logCollector)
+				}
+				if len(GeneralConfig.HookConfig.OpenTelemetryConfig.Endpoint) > 0 {
+					otelClient.Send(telemetryClient.GetData().Attributes())
+				}
+				if checkRun != nil {
+					checkRun.Complete(stepTelemetryData.ErrorCode != "0", log.GetErrorCategory())
+				}
+			}
+			log.DeferExitHandler(handler)
+			defer handler()
+			telemetryClient.Initialize(GeneralConfig.NoTelemetry, STEP_NAME)
+			if len(GeneralConfig.HookConfig.SplunkConfig.Dsn) > 0 {
+				splunkClient.Initialize(GeneralConfig.CorrelationID,
+					GeneralConfig.HookConfig.SplunkConfig.Dsn,
+					GeneralConfig.HookConfig.SplunkConfig.Token,
+					GeneralConfig.HookConfig.SplunkConfig.Index,
+					GeneralConfig.HookConfig.SplunkConfig.SendLogs)
+			}
+			if GeneralConfig.HookConfig.GitHubChecksConfig.Enabled {
+				checkRun = piperGithub.StartStepCheckRun(STEP_NAME, GeneralConfig.StageName, GeneralConfig.HookConfig.GitHubChecksConfig)
+			}
+			osvExecuteScan(stepConfig, &stepTelemetryData)
+			stepTelemetryData.ErrorCode = "0"
+			log.Entry().Info("SUCCESS")
+		},
+	}
+
+	addOsvExecuteScanFlags(createOsvExecuteScanCmd, &stepConfig)
+	return createOsvExecuteScanCmd
+}
+
+func addOsvExecuteScanFlags(cmd *cobra.Command, stepConfig *osvExecuteScanOptions) {
+	cmd.Flags().StringVar(&stepConfig.OsvDatabasePath, "osvDatabasePath", os.Getenv("PIPER_osvDatabasePath"), "Path of the directory containing the local mirror of the OSV database.")
+	cmd.Flags().StringSliceVar(&stepConfig.SbomFiles, "sbomFiles", []string{}, "Paths of the SBOMs to be scanned. By default the SBOMs created by the build steps are scanned.")
+	cmd.Flags().StringVar(&stepConfig.ScanPath, "scanPath", `.`, "Directory of the project whose resolved dependencies are scanned in case no SBOM is available.")
+	cmd.Flags().StringVar(&stepConfig.CvssSeverityLimit, "cvssSeverityLimit", `7.0`, "Limit of tolerable CVSS v3 score, the step fails if a vulnerability reaches the limit. A negative value disables the check. Vulnerabilities without CVSS v3 vector are rated by the lower bound of the severity provided by the database.")
+	cmd.Flags().StringSliceVar(&stepConfig.ExcludeCVEs, "excludeCVEs", []string{}, "List of vulnerabilities (CVE or OSV ids) which do not fail the step.")
+	cmd.Flags().StringVar(&stepConfig.TriageFile, "triageFile", `.pipeline/triage.yml`, "Path of the triage file shared by all scan steps. Vulnerabilities suppressed via their CVE or OSV id do not fail the step, expired suppressions are flagged in the report. The file is ignored if it does not exist.")
+
+	cmd.MarkFlagRequired("osvDatabasePath")
+}
+
+// retrieve step metadata
+func osvExecuteScanMetadata() config.StepData {
+	var theMetaData = config.StepData{
+		Metadata: config.StepMetadata{
+			Name:        "osvExecuteScan",
+			Aliases:     []config.Alias{},
+			Description: "Matches the dependencies of a project against a local OSV vulnerability database",
+		},
+		Spec: config.StepSpec{
+			Inputs: config.StepInputs{
+				Resources: []config.StepResources{
+					{Name: "buildDescriptor", Type: "stash"},
+					{Name: "buildResult", Type: "stash"},
+				},
+				Parameters: []config.StepParameters{
+					{
+						Name:        "osvDatabasePath",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS", "GENERAL"},
+						Type:        "string",
+						Mandatory:   true,
+						Aliases:     []config.Alias{},
+						Default:     os.Getenv("PIPER_osvDatabasePath"),
+					},
+					{
+						Name: "sbomFiles",
+						ResourceRef: []config.ResourceReference{
+							{
+								Name:  "commonPipelineEnvironment",
+								Param: "custom/sbomFiles",
+							},
+						},
+						Scope:     []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:      "[]string",
+						Mandatory: false,
+						Aliases:   []config.Alias{},
+						Default:   []string{},
+					},
+					{
+						Name:        "scanPath",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     `.`,
+					},
+					{
+						Name:        "cvssSeverityLimit",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     `7.0`,
+					},
+					{
+						Name:        "excludeCVEs",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "[]string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     []string{},
+					},
+					{
+						Name:        "triageFile",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"GENERAL", "PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     `.pipeline/triage.yml`,
+					},
+				},
+			},
+		},
+	}
+	return theMetaData
+}
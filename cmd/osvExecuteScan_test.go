@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/SAP/jenkins-library/pkg/mock"
+	"github.com/SAP/jenkins-library/pkg/reporting"
+	"github.com/stretchr/testify/assert"
+)
+
+type osvExecuteScanMockUtils struct {
+	*mock.ExecMockRunner
+	*mock.FilesMock
+}
+
+func (o *osvExecuteScanMockUtils) DownloadFile(url, filename string, header http.Header, cookies []*http.Cookie) error {
+	return fmt.Errorf("not implemented")
+}
+
+func newOsvExecuteScanTestsUtils() *osvExecuteScanMockUtils {
+	utils := osvExecuteScanMockUtils{
+		ExecMockRunner: &mock.ExecMockRunner{},
+		FilesMock:      &mock.FilesMock{},
+	}
+	utils.AddFile("osv/npm/GHSA-35jh-r3h4-6jhm.json", []byte(`{
+  "id": "GHSA-35jh-r3h4-6jhm",
+  "aliases": ["CVE-2021-23337"],
+  "summary": "Command Injection in lodash",
+  "affected": [{
+    "package": {"ecosystem": "npm", "name": "lodash"},
+    "ranges": [{"type": "SEMVER", "events": [{"introduced": "0"}, {"fixed": "4.17.21"}]}]
+  }],
+  "severity": [{"type": "CVSS_V3", "score": "CVSS:3.1/AV:N/AC:L/PR:H/UI:N/S:U/C:H/I:H/A:H"}]
+}`))
+	utils.AddFile("bom.json", []byte(`{
+  "bomFormat": "CycloneDX",
+  "components": [
+    {"type": "library", "name": "lodash", "version": "4.17.20", "purl": "pkg:npm/lodash@4.17.20"},
+    {"type": "library", "name": "react", "version": "17.0.2", "purl": "pkg:npm/react@17.0.2"}
+  ]
+}`))
+	return &utils
+}
+
+func TestRunOsvExecuteScan(t *testing.T) {
+	defer os.Remove("osvExecuteScan_reports.json")
+	defer os.Remove("osvExecuteScan_links.json")
+
+	t.Run("success - no severe vulnerability", func(t *testing.T) {
+		config := osvExecuteScanOptions{OsvDatabasePath: "osv", SbomFiles: []string{"bom.json", "missing.xml"}, CvssSeverityLimit: "9.0"}
+		utils := newOsvExecuteScanTestsUtils()
+
+		err := runOsvExecuteScan(&config, nil, utils)
+		assert.NoError(t, err)
+		assert.True(t, utils.HasWrittenFile("osv/piper_osv_report.html"))
+		reports, _ := utils.Glob(reporting.StepReportDirectory + "/osvExecuteScan_osv_*.json")
+		assert.Len(t, reports, 1)
+		assert.Empty(t, utils.Calls)
+	})
+
+	t.Run("success - vulnerability excluded via triage file", func(t *testing.T) {
+		config := osvExecuteScanOptions{OsvDatabasePath: "osv", SbomFiles: []string{"bom.json"}, CvssSeverityLimit: "7.0", TriageFile: ".pipeline/triage.yml"}
+		utils := newOsvExecuteScanTestsUtils()
+		utils.AddFile(".pipeline/triage.yml", []byte("suppressions:\n  - id: CVE-2021-23337\n    component: lodash\n    justification: template function is not used\n"))
+
+		err := runOsvExecuteScan(&config, nil, utils)
+		assert.NoError(t, err)
+	})
+
+	t.Run("success - vulnerability excluded via configuration", func(t *testing.T) {
+		config := osvExecuteScanOptions{OsvDatabasePath: "osv", SbomFiles: []string{"bom.json"}, CvssSeverityLimit: "7.0", ExcludeCVEs: []string{"GHSA-35jh-r3h4-6jhm"}, TriageFile: ".pipeline/triage.yml"}
+		utils := newOsvExecuteScanTestsUtils()
+
+		err := runOsvExecuteScan(&config, nil, utils)
+		assert.NoError(t, err)
+	})
+
+	t.Run("error - suppression expired", func(t *testing.T) {
+		config := osvExecuteScanOptions{OsvDatabasePath: "osv", SbomFiles: []string{"bom.json"}, CvssSeverityLimit: "7.0", TriageFile: ".pipeline/triage.yml"}
+		utils := newOsvExecuteScanTestsUtils()
+		utils.AddFile(".pipeline/triage.yml", []byte("suppressions:\n  - id: CVE-2021-23337\n    justification: template function is not used\n    expires: 2021-12-31\n"))
+
+		err := runOsvExecuteScan(&config, nil, utils)
+		assert.EqualError(t, err, "1 Open Source Software Security vulnerabilities with CVSS score greater or equal to 7.0 detected")
+	})
+
+	t.Run("success - SBOM of resolved dependencies", func(t *testing.T) {
+		config := osvExecuteScanOptions{OsvDatabasePath: "osv", ScanPath: ".", CvssSeverityLimit: "-1"}
+		utils := newOsvExecuteScanTestsUtils()
+		utils.AddFile("package.json", []byte(`{"name": "ui"}`))
+		utils.AddFile("bom.xml", []byte(`<bom xmlns="http://cyclonedx.org/schema/bom/1.3" version="1"><components/></bom>`))
+
+		err := runOsvExecuteScan(&config, nil, utils)
+		assert.NoError(t, err)
+		if assert.Len(t, utils.Calls, 3) {
+			assert.Equal(t, "npx", utils.Calls[2].Exec)
+			assert.Contains(t, utils.Calls[2].Params, "bom.xml")
+		}
+	})
+
+	t.Run("error - severe vulnerability", func(t *testing.T) {
+		config := osvExecuteScanOptions{OsvDatabasePath: "osv", SbomFiles: []string{"bom.json"}, CvssSeverityLimit: "7.0"}
+		utils := newOsvExecuteScanTestsUtils()
+
+		err := runOsvExecuteScan(&config, nil, utils)
+		assert.EqualError(t, err, "1 Open Source Software Security vulnerabilities with CVSS score greater or equal to 7.0 detected")
+		assert.True(t, utils.HasWrittenFile("osv/piper_osv_report.html"))
+	})
+
+	t.Run("error - no SBOM and no build descriptor", func(t *testing.T) {
+		config := osvExecuteScanOptions{OsvDatabasePath: "osv", ScanPath: "db", CvssSeverityLimit: "7.0"}
+		utils := newOsvExecuteScanTestsUtils()
+
+		err := runOsvExecuteScan(&config, nil, utils)
+		assert.EqualError(t, err, "no SBOM available and 'db' does not contain a supported build descriptor")
+	})
+
+	t.Run("error - missing database", func(t *testing.T) {
+		config := osvExecuteScanOptions{OsvDatabasePath: "/data/osv", SbomFiles: []string{"bom.json"}, CvssSeverityLimit: "7.0"}
+		utils := newOsvExecuteScanTestsUtils()
+
+		err := runOsvExecuteScan(&config, nil, utils)
+		assert.EqualError(t, err, "failed to load OSV database: no vulnerabilities found in OSV database '/data/osv'")
+	})
+
+	t.Run("error - invalid severity limit", func(t *testing.T) {
+		config := osvExecuteScanOptions{OsvDatabasePath: "osv", CvssSeverityLimit: "high"}
+		utils := newOsvExecuteScanTestsUtils()
+
+		err := runOsvExecuteScan(&config, nil, utils)
+		assert.Contains(t, fmt.Sprint(err), "failed to parse parameter cvssSeverityLimit (high) as floating point number")
+	})
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/SAP/jenkins-library/pkg/decoration"
+	piperhttp "github.com/SAP/jenkins-library/pkg/http"
 	"github.com/SAP/jenkins-library/pkg/log"
 	"github.com/SAP/jenkins-library/pkg/piperutils"
 	"github.com/SAP/jenkins-library/pkg/reporting"
@@ -16,6 +18,7 @@ type pipelineCreateScanSummaryUtils interface {
 	FileRead(path string) ([]byte, error)
 	FileWrite(path string, content []byte, perm os.FileMode) error
 	Glob(pattern string) (matches []string, err error)
+	NewDecorator(options decoration.Options) (decoration.Decorator, error)
 }
 
 type pipelineCreateScanSummaryUtilsBundle struct {
@@ -29,16 +32,20 @@ func newPipelineCreateScanSummaryUtils() pipelineCreateScanSummaryUtils {
 	return &utils
 }
 
-func pipelineCreateScanSummary(config pipelineCreateScanSummaryOptions, telemetryData *telemetry.CustomData) {
+func (p *pipelineCreateScanSummaryUtilsBundle) NewDecorator(options decoration.Options) (decoration.Decorator, error) {
+	return decoration.NewDecorator(options, &piperhttp.Client{})
+}
+
+func pipelineCreateScanSummary(config pipelineCreateScanSummaryOptions, telemetryData *telemetry.CustomData, commonPipelineEnvironment *pipelineCreateScanSummaryCommonPipelineEnvironment) {
 	utils := newPipelineCreateScanSummaryUtils()
 
-	err := runPipelineCreateScanSummary(&config, telemetryData, utils)
+	err := runPipelineCreateScanSummary(&config, telemetryData, utils, commonPipelineEnvironment)
 	if err != nil {
 		log.Entry().WithError(err).Fatal("failed to create scan summary")
 	}
 }
 
-func runPipelineCreateScanSummary(config *pipelineCreateScanSummaryOptions, telemetryData *telemetry.CustomData, utils pipelineCreateScanSummaryUtils) error {
+func runPipelineCreateScanSummary(config *pipelineCreateScanSummaryOptions, telemetryData *telemetry.CustomData, utils pipelineCreateScanSummaryUtils, commonPipelineEnvironment *pipelineCreateScanSummaryCommonPipelineEnvironment) error {
 
 	pattern := reporting.StepReportDirectory + "/*.json"
 	reports, _ := utils.Glob(pattern)
@@ -74,5 +81,69 @@ func runPipelineCreateScanSummary(config *pipelineCreateScanSummaryOptions, tele
 		return errors.Wrapf(err, "failed to write %v", config.OutputFilePath)
 	}
 
+	if len(config.SarifFilePath) > 0 {
+		sarif, err := reporting.ScanReportsToSARIF(scanReports)
+		if err != nil {
+			return errors.Wrap(err, "failed to create SARIF report")
+		}
+		if err := utils.FileWrite(config.SarifFilePath, sarif, 0666); err != nil {
+			log.SetErrorCategory(log.ErrorConfiguration)
+			return errors.Wrapf(err, "failed to write %v", config.SarifFilePath)
+		}
+	}
+
+	if config.DecoratePullRequest {
+		decoratePullRequest(config, scanReports, utils)
+	}
+
+	if len(config.QualityGatePolicy) > 0 {
+		return evaluateQualityGate(config.QualityGatePolicy, scanReports, commonPipelineEnvironment)
+	}
+
+	return nil
+}
+
+func evaluateQualityGate(policyConfig map[string]interface{}, scanReports []reporting.ScanReport, commonPipelineEnvironment *pipelineCreateScanSummaryCommonPipelineEnvironment) error {
+	policy, err := reporting.ParseQualityGatePolicy(policyConfig)
+	if err != nil {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return errors.Wrap(err, "invalid parameter qualityGatePolicy")
+	}
+
+	verdict := policy.Evaluate(scanReports)
+	commonPipelineEnvironment.custom.qualityGatePassed = verdict.Passed
+	commonPipelineEnvironment.custom.qualityGateViolations = verdict.ViolatedRules()
+
+	for _, result := range verdict.Rules {
+		log.Entry().Infof("quality gate rule '%v': %v finding(s), at most %v allowed", result.Rule.Name, result.Findings, result.Rule.MaxFindings)
+	}
+	if !verdict.Passed {
+		log.SetErrorCategory(log.ErrorCompliance)
+		return fmt.Errorf("quality gate failed:\n%v", verdict.Explanation())
+	}
+	log.Entry().Info("quality gate passed")
 	return nil
 }
+
+// decoratePullRequest publishes the scan reports in the pull request, failures are not propagated since the decoration is for information only
+func decoratePullRequest(config *pipelineCreateScanSummaryOptions, scanReports []reporting.ScanReport, utils pipelineCreateScanSummaryUtils) {
+	decorator, err := utils.NewDecorator(decoration.Options{
+		Owner:        config.Owner,
+		Repository:   config.Repository,
+		GitHubToken:  config.Token,
+		GitHubAPIURL: config.GithubAPIURL,
+		AzureToken:   config.AzureAccessToken,
+	})
+	if err != nil {
+		log.Entry().WithError(err).Warn("Pull request is not decorated")
+		return
+	}
+	for _, scanReport := range scanReports {
+		if config.FailedOnly && scanReport.SuccessfulScan {
+			continue
+		}
+		if err := decoration.Decorate(decorator, scanReport, config.MaxLineComments); err != nil {
+			log.Entry().WithError(err).Warnf("Failed to decorate pull request with report '%v'", scanReport.Title)
+		}
+	}
+}
@@ -5,10 +5,14 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/SAP/jenkins-library/pkg/config"
+	piperGithub "github.com/SAP/jenkins-library/pkg/github"
 	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/opentelemetry"
+	"github.com/SAP/jenkins-library/pkg/piperenv"
 	"github.com/SAP/jenkins-library/pkg/splunk"
 	"github.com/SAP/jenkins-library/pkg/telemetry"
 	"github.com/SAP/jenkins-library/pkg/validation"
@@ -16,9 +20,48 @@ import (
 )
 
 type pipelineCreateScanSummaryOptions struct {
-	FailedOnly     bool   `json:"failedOnly,omitempty"`
-	OutputFilePath string `json:"outputFilePath,omitempty"`
-	PipelineLink   string `json:"pipelineLink,omitempty"`
+	AzureAccessToken    string                 `json:"azureAccessToken,omitempty"`
+	DecoratePullRequest bool                   `json:"decoratePullRequest,omitempty"`
+	FailedOnly          bool                   `json:"failedOnly,omitempty"`
+	GithubAPIURL        string                 `json:"githubApiUrl,omitempty"`
+	MaxLineComments     int                    `json:"maxLineComments,omitempty"`
+	OutputFilePath      string                 `json:"outputFilePath,omitempty"`
+	Owner               string                 `json:"owner,omitempty"`
+	PipelineLink        string                 `json:"pipelineLink,omitempty"`
+	QualityGatePolicy   map[string]interface{} `json:"qualityGatePolicy,omitempty"`
+	Repository          string                 `json:"repository,omitempty"`
+	SarifFilePath       string                 `json:"sarifFilePath,omitempty"`
+	Token               string                 `json:"token,omitempty"`
+}
+
+type pipelineCreateScanSummaryCommonPipelineEnvironment struct {
+	custom struct {
+		qualityGatePassed     bool
+		qualityGateViolations []string
+	}
+}
+
+func (p *pipelineCreateScanSummaryCommonPipelineEnvironment) persist(path, resourceName string) {
+	content := []struct {
+		category string
+		name     string
+		value    interface{}
+	}{
+		{category: "custom", name: "qualityGatePassed", value: p.custom.qualityGatePassed},
+		{category: "custom", name: "qualityGateViolations", value: p.custom.qualityGateViolations},
+	}
+
+	errCount := 0
+	for _, param := range content {
+		err := piperenv.SetResourceParameter(path, resourceName, filepath.Join(param.category, param.name), param.value)
+		if err != nil {
+			log.Entry().WithError(err).Error("Error persisting piper environment.")
+			errCount++
+		}
+	}
+	if errCount > 0 {
+		log.Entry().Fatal("failed to persist Piper environment")
+	}
 }
 
 // PipelineCreateScanSummaryCommand Collect scan result information anc create a summary report
@@ -28,8 +71,11 @@ func PipelineCreateScanSummaryCommand() *cobra.Command {
 	metadata := pipelineCreateScanSummaryMetadata()
 	var stepConfig pipelineCreateScanSummaryOptions
 	var startTime time.Time
+	var commonPipelineEnvironment pipelineCreateScanSummaryCommonPipelineEnvironment
 	var logCollector *log.CollectorHook
 	var splunkClient *splunk.Splunk
+	var otelClient *opentelemetry.OpenTelemetry
+	var checkRun *piperGithub.StepCheckRun
 	telemetryClient := &telemetry.Telemetry{}
 
 	var createPipelineCreateScanSummaryCmd = &cobra.Command{
@@ -37,7 +83,33 @@ func PipelineCreateScanSummaryCommand() *cobra.Command {
 		Short: "Collect scan result information anc create a summary report",
 		Long: `This step allows you to create a summary report of your scan results.
 
-It is for example used to create a markdown file which can be used to create a GitHub issue.`,
+It is for example used to create a markdown file which can be used to create a GitHub issue.
+
+In addition the findings of all scan reports can be written into one aggregated [SARIF](https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html) file, e.g. for GitHub code scanning.
+
+The findings of all scan reports can also be evaluated against a quality gate policy.
+Each rule limits the number of unsuppressed findings matching its optional ` + "`" + `stepName` + "`" + `, ` + "`" + `category` + "`" + ` (` + "`" + `sast` + "`" + `, ` + "`" + `vulnerability` + "`" + `, ` + "`" + `license` + "`" + `) and ` + "`" + `severities` + "`" + ` (` + "`" + `critical` + "`" + `, ` + "`" + `high` + "`" + `, ` + "`" + `medium` + "`" + `, ` + "`" + `low` + "`" + `, ` + "`" + `info` + "`" + `).
+The step fails if at least one rule is violated, the verdict is available in the common pipeline environment.
+
+` + "`" + `` + "`" + `` + "`" + `yaml
+steps:
+  pipelineCreateScanSummary:
+    qualityGatePolicy:
+      rules:
+        - name: no high Checkmarx findings
+          stepName: checkmarxExecuteScan
+          severities: [critical, high]
+        - name: at most 5 medium Fortify findings
+          stepName: fortifyExecuteScan
+          severities: [medium]
+          maxFindings: 5
+        - name: no unapproved licenses
+          category: license
+` + "`" + `` + "`" + `` + "`" + `
+
+When running for a pull request the scan reports can be published in the pull request with ` + "`" + `decoratePullRequest: true` + "`" + `.
+The markdown of each scan report is posted as one comment which is updated by subsequent runs, findings with file and line information are commented on the respective line.
+Pull requests of Azure Repos are decorated on Azure DevOps using the access token of the build (` + "`" + `System.AccessToken` + "`" + ` needs to be mapped to the environment variable ` + "`" + `SYSTEM_ACCESSTOKEN` + "`" + `), otherwise GitHub is used which requires ` + "`" + `token` + "`" + `, ` + "`" + `owner` + "`" + ` and ` + "`" + `repository` + "`" + `.`,
 		PreRunE: func(cmd *cobra.Command, _ []string) error {
 			startTime = time.Now()
 			log.SetStepName(STEP_NAME)
@@ -54,6 +126,8 @@ It is for example used to create a markdown file which can be used to create a G
 				log.SetErrorCategory(log.ErrorConfiguration)
 				return err
 			}
+			log.RegisterSecret(stepConfig.AzureAccessToken)
+			log.RegisterSecret(stepConfig.Token)
 
 			if len(GeneralConfig.HookConfig.SentryConfig.Dsn) > 0 {
 				sentryHook := log.NewSentryHook(GeneralConfig.HookConfig.SentryConfig.Dsn, GeneralConfig.CorrelationID)
@@ -66,6 +140,13 @@ It is for example used to create a markdown file which can be used to create a G
 				log.RegisterHook(logCollector)
 			}
 
+			if len(GeneralConfig.HookConfig.OpenTelemetryConfig.Endpoint) > 0 {
+				otelClient = &opentelemetry.OpenTelemetry{}
+				if err := otelClient.Initialize(GeneralConfig.CorrelationID, STEP_NAME, GeneralConfig.HookConfig.OpenTelemetryConfig); err != nil {
+					log.Entry().WithError(err).Warning("failed to initialize OpenTelemetry")
+				}
+			}
+
 			validation, err := validation.New(validation.WithJSONNamesForStructFields(), validation.WithPredefinedErrorMessages())
 			if err != nil {
 				return err
@@ -82,6 +163,7 @@ It is for example used to create a markdown file which can be used to create a G
 			stepTelemetryData.ErrorCode = "1"
 			handler := func() {
 				config.RemoveVaultSecretFiles()
+				commonPipelineEnvironment.persist(GeneralConfig.EnvRootPath, "commonPipelineEnvironment")
 				stepTelemetryData.Duration = fmt.Sprintf("%v", time.Since(startTime).Milliseconds())
 				stepTelemetryData.ErrorCategory = log.GetErrorCategory().String()
 				stepTelemetryData.PiperCommitHash = GitCommit
@@ -90,6 +172,12 @@ It is for example used to create a markdown file which can be used to create a G
 				if len(GeneralConfig.HookConfig.SplunkConfig.Dsn) > 0 {
 					splunkClient.Send(telemetryClient.GetData(), logCollector)
 				}
+				if len(GeneralConfig.HookConfig.OpenTelemetryConfig.Endpoint) > 0 {
+					otelClient.Send(telemetryClient.GetData().Attributes())
+				}
+				if checkRun != nil {
+					checkRun.Complete(stepTelemetryData.ErrorCode != "0", log.GetErrorCategory())
+				}
 			}
 			log.DeferExitHandler(handler)
 			defer handler()
@@ -101,7 +189,10 @@ It is for example used to create a markdown file which can be used to create a G
 					GeneralConfig.HookConfig.SplunkConfig.Index,
 					GeneralConfig.HookConfig.SplunkConfig.SendLogs)
 			}
-			pipelineCreateScanSummary(stepConfig, &stepTelemetryData)
+			if GeneralConfig.HookConfig.GitHubChecksConfig.Enabled {
+				checkRun = piperGithub.StartStepCheckRun(STEP_NAME, GeneralConfig.StageName, GeneralConfig.HookConfig.GitHubChecksConfig)
+			}
+			pipelineCreateScanSummary(stepConfig, &stepTelemetryData, &commonPipelineEnvironment)
 			stepTelemetryData.ErrorCode = "0"
 			log.Entry().Info("SUCCESS")
 		},
@@ -112,10 +203,19 @@ It is for example used to create a markdown file which can be used to create a G
 }
 
 func addPipelineCreateScanSummaryFlags(cmd *cobra.Command, stepConfig *pipelineCreateScanSummaryOptions) {
+	cmd.Flags().StringVar(&stepConfig.AzureAccessToken, "azureAccessToken", os.Getenv("PIPER_azureAccessToken"), "Token to authenticate to Azure DevOps when decorating pull requests of Azure Repos. The environment variable `SYSTEM_ACCESSTOKEN` is used if empty.")
+	cmd.Flags().BoolVar(&stepConfig.DecoratePullRequest, "decoratePullRequest", false, "Defines if the scan reports are published as comments in the pull request the pipeline is running for.")
 	cmd.Flags().BoolVar(&stepConfig.FailedOnly, "failedOnly", false, "Defines if only failed scans should be included into the summary.")
+	cmd.Flags().StringVar(&stepConfig.GithubAPIURL, "githubApiUrl", `https://api.github.com`, "Set the GitHub API url.")
+	cmd.Flags().IntVar(&stepConfig.MaxLineComments, "maxLineComments", 20, "Defines the maximum number of findings per scan report which are commented on their line in the pull request. Only findings within the changes of the pull request are commented. Line comments are disabled with 0.")
 	cmd.Flags().StringVar(&stepConfig.OutputFilePath, "outputFilePath", `scanSummary.md`, "Defines the filepath to the target file which will be created by the step.")
+	cmd.Flags().StringVar(&stepConfig.Owner, "owner", os.Getenv("PIPER_owner"), "Name of the GitHub organization.")
 	cmd.Flags().StringVar(&stepConfig.PipelineLink, "pipelineLink", os.Getenv("PIPER_pipelineLink"), "Link to the pipeline (e.g. Jenkins job url) for reference in the scan summary.")
 
+	cmd.Flags().StringVar(&stepConfig.Repository, "repository", os.Getenv("PIPER_repository"), "Name of the GitHub repository.")
+	cmd.Flags().StringVar(&stepConfig.SarifFilePath, "sarifFilePath", os.Getenv("PIPER_sarifFilePath"), "Defines the filepath of an aggregated SARIF file containing the findings of all scan reports. No SARIF file is created if empty.")
+	cmd.Flags().StringVar(&stepConfig.Token, "token", os.Getenv("PIPER_token"), "GitHub personal access token used to decorate pull requests on GitHub.")
+
 }
 
 // retrieve step metadata
@@ -128,7 +228,28 @@ func pipelineCreateScanSummaryMetadata() config.StepData {
 		},
 		Spec: config.StepSpec{
 			Inputs: config.StepInputs{
+				Secrets: []config.StepSecrets{
+					{Name: "githubTokenCredentialsId", Description: "Jenkins 'Secret text' credentials ID containing token to authenticate to GitHub.", Type: "jenkins"},
+				},
 				Parameters: []config.StepParameters{
+					{
+						Name:        "azureAccessToken",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     os.Getenv("PIPER_azureAccessToken"),
+					},
+					{
+						Name:        "decoratePullRequest",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "bool",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     false,
+					},
 					{
 						Name:        "failedOnly",
 						ResourceRef: []config.ResourceReference{},
@@ -138,6 +259,24 @@ func pipelineCreateScanSummaryMetadata() config.StepData {
 						Aliases:     []config.Alias{},
 						Default:     false,
 					},
+					{
+						Name:        "githubApiUrl",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"GENERAL", "PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     `https://api.github.com`,
+					},
+					{
+						Name:        "maxLineComments",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "int",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     20,
+					},
 					{
 						Name:        "outputFilePath",
 						ResourceRef: []config.ResourceReference{},
@@ -147,6 +286,20 @@ func pipelineCreateScanSummaryMetadata() config.StepData {
 						Aliases:     []config.Alias{},
 						Default:     `scanSummary.md`,
 					},
+					{
+						Name: "owner",
+						ResourceRef: []config.ResourceReference{
+							{
+								Name:  "commonPipelineEnvironment",
+								Param: "github/owner",
+							},
+						},
+						Scope:     []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:      "string",
+						Mandatory: false,
+						Aliases:   []config.Alias{{Name: "githubOrg"}},
+						Default:   os.Getenv("PIPER_owner"),
+					},
 					{
 						Name:        "pipelineLink",
 						ResourceRef: []config.ResourceReference{},
@@ -156,6 +309,69 @@ func pipelineCreateScanSummaryMetadata() config.StepData {
 						Aliases:     []config.Alias{},
 						Default:     os.Getenv("PIPER_pipelineLink"),
 					},
+					{
+						Name:        "qualityGatePolicy",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "map[string]interface{}",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+					},
+					{
+						Name: "repository",
+						ResourceRef: []config.ResourceReference{
+							{
+								Name:  "commonPipelineEnvironment",
+								Param: "github/repository",
+							},
+						},
+						Scope:     []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:      "string",
+						Mandatory: false,
+						Aliases:   []config.Alias{{Name: "githubRepo"}},
+						Default:   os.Getenv("PIPER_repository"),
+					},
+					{
+						Name:        "sarifFilePath",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     os.Getenv("PIPER_sarifFilePath"),
+					},
+					{
+						Name: "token",
+						ResourceRef: []config.ResourceReference{
+							{
+								Name: "githubTokenCredentialsId",
+								Type: "secret",
+							},
+
+							{
+								Name:    "githubVaultSecretName",
+								Type:    "vaultSecret",
+								Default: "github",
+							},
+						},
+						Scope:     []string{"GENERAL", "PARAMETERS", "STAGES", "STEPS"},
+						Type:      "string",
+						Mandatory: false,
+						Aliases:   []config.Alias{{Name: "githubToken"}, {Name: "access_token"}},
+						Default:   os.Getenv("PIPER_token"),
+					},
+				},
+			},
+			Outputs: config.StepOutputs{
+				Resources: []config.StepResources{
+					{
+						Name: "commonPipelineEnvironment",
+						Type: "piperEnvironment",
+						Parameters: []map[string]interface{}{
+							{"Name": "custom/qualityGatePassed"},
+							{"Name": "custom/qualityGateViolations"},
+						},
+					},
 				},
 			},
 		},
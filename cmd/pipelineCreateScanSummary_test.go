@@ -1,24 +1,63 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"testing"
 
+	"github.com/SAP/jenkins-library/pkg/decoration"
 	"github.com/SAP/jenkins-library/pkg/mock"
+	"github.com/SAP/jenkins-library/pkg/reporting"
 	"github.com/stretchr/testify/assert"
 )
 
 type pipelineCreateScanSummaryMockUtils struct {
 	*mock.FilesMock
+	decorator         *scanSummaryDecoratorMock
+	decoratorOptions  *decoration.Options
+	newDecoratorError error
 }
 
 func newPipelineCreateScanSummaryTestsUtils() pipelineCreateScanSummaryMockUtils {
 	utils := pipelineCreateScanSummaryMockUtils{
-		FilesMock: &mock.FilesMock{},
+		FilesMock:        &mock.FilesMock{},
+		decorator:        &scanSummaryDecoratorMock{comments: map[string]string{}},
+		decoratorOptions: &decoration.Options{},
 	}
 	return utils
 }
 
+func (p pipelineCreateScanSummaryMockUtils) NewDecorator(options decoration.Options) (decoration.Decorator, error) {
+	*p.decoratorOptions = options
+	if p.newDecoratorError != nil {
+		return nil, p.newDecoratorError
+	}
+	return p.decorator, nil
+}
+
+type scanSummaryDecoratorMock struct {
+	comments     map[string]string
+	lineComments []decoration.LineComment
+}
+
+func (d *scanSummaryDecoratorMock) UpsertComment(marker, body string) error {
+	d.comments[marker] = body
+	return nil
+}
+
+func (d *scanSummaryDecoratorMock) LineCommentBodies() ([]string, error) {
+	return []string{}, nil
+}
+
+func (d *scanSummaryDecoratorMock) Changes() (decoration.Changes, error) {
+	return decoration.Changes{"src/Dao.java": {{Start: 40, End: 45}}}, nil
+}
+
+func (d *scanSummaryDecoratorMock) CreateLineComment(comment decoration.LineComment) error {
+	d.lineComments = append(d.lineComments, comment)
+	return nil
+}
+
 func TestRunPipelineCreateScanSummary(t *testing.T) {
 	t.Parallel()
 
@@ -34,7 +73,7 @@ func TestRunPipelineCreateScanSummary(t *testing.T) {
 		utils.AddFile(".pipeline/stepReports/step2.json", []byte(`{"title":"Title Scan 2"}`))
 		utils.AddFile(".pipeline/stepReports/step3.json", []byte(`{"title":"Title Scan 3"}`))
 
-		err := runPipelineCreateScanSummary(&config, nil, utils)
+		err := runPipelineCreateScanSummary(&config, nil, utils, &pipelineCreateScanSummaryCommonPipelineEnvironment{})
 
 		assert.NoError(t, err)
 		reportExists, _ := utils.FileExists("scanSummary.md")
@@ -59,7 +98,7 @@ func TestRunPipelineCreateScanSummary(t *testing.T) {
 		utils.AddFile(".pipeline/stepReports/step2.json", []byte(`{"title":"Title Scan 2", "successfulScan": false}`))
 		utils.AddFile(".pipeline/stepReports/step3.json", []byte(`{"title":"Title Scan 3", "successfulScan": false}`))
 
-		err := runPipelineCreateScanSummary(&config, nil, utils)
+		err := runPipelineCreateScanSummary(&config, nil, utils, &pipelineCreateScanSummaryCommonPipelineEnvironment{})
 
 		assert.NoError(t, err)
 		reportExists, _ := utils.FileExists("scanSummary.md")
@@ -81,7 +120,7 @@ func TestRunPipelineCreateScanSummary(t *testing.T) {
 
 		utils := newPipelineCreateScanSummaryTestsUtils()
 
-		err := runPipelineCreateScanSummary(&config, nil, utils)
+		err := runPipelineCreateScanSummary(&config, nil, utils, &pipelineCreateScanSummaryCommonPipelineEnvironment{})
 
 		assert.NoError(t, err)
 		reportExists, _ := utils.FileExists("scanSummary.md")
@@ -91,6 +130,148 @@ func TestRunPipelineCreateScanSummary(t *testing.T) {
 		assert.Contains(t, fileContentString, "https://test.com/link")
 	})
 
+	t.Run("success - with SARIF", func(t *testing.T) {
+		t.Parallel()
+
+		config := pipelineCreateScanSummaryOptions{
+			OutputFilePath: "scanSummary.md",
+			SarifFilePath:  "scanSummary.sarif",
+		}
+
+		utils := newPipelineCreateScanSummaryTestsUtils()
+		utils.AddFile(".pipeline/stepReports/step1.json", []byte(`{"stepName":"checkmarxExecuteScan","title":"Title Scan 1","findings":[{"ruleId":"SQL_Injection","title":"SQL Injection","severity":"high","location":"src/Dao.java","line":42}]}`))
+		utils.AddFile(".pipeline/stepReports/step2.json", []byte(`{"stepName":"protecodeExecuteScan","title":"Title Scan 2"}`))
+
+		err := runPipelineCreateScanSummary(&config, nil, utils, &pipelineCreateScanSummaryCommonPipelineEnvironment{})
+
+		assert.NoError(t, err)
+		sarifContent, err := utils.FileRead("scanSummary.sarif")
+		assert.NoError(t, err)
+		sarif := reporting.SARIF{}
+		assert.NoError(t, json.Unmarshal(sarifContent, &sarif))
+		if assert.Equal(t, 2, len(sarif.Runs)) {
+			assert.Equal(t, "checkmarxExecuteScan", sarif.Runs[0].Tool.Driver.Name)
+			assert.Equal(t, "SQL_Injection", sarif.Runs[0].Results[0].RuleID)
+			assert.Equal(t, "error", sarif.Runs[0].Results[0].Level)
+			assert.Equal(t, "protecodeExecuteScan", sarif.Runs[1].Tool.Driver.Name)
+			assert.Equal(t, 0, len(sarif.Runs[1].Results))
+		}
+	})
+
+	t.Run("success - quality gate passed", func(t *testing.T) {
+		t.Parallel()
+
+		config := pipelineCreateScanSummaryOptions{
+			OutputFilePath: "scanSummary.md",
+			QualityGatePolicy: map[string]interface{}{"rules": []interface{}{
+				map[string]interface{}{"name": "no high checkmarx findings", "stepName": "checkmarxExecuteScan", "severities": []interface{}{"high"}},
+			}},
+		}
+
+		utils := newPipelineCreateScanSummaryTestsUtils()
+		utils.AddFile(".pipeline/stepReports/step1.json", []byte(`{"stepName":"checkmarxExecuteScan","title":"Title Scan 1","findings":[{"ruleId":"SQL_Injection","title":"SQL Injection","severity":"high","category":"sast","suppressed":true}]}`))
+		cpe := pipelineCreateScanSummaryCommonPipelineEnvironment{}
+
+		err := runPipelineCreateScanSummary(&config, nil, utils, &cpe)
+
+		assert.NoError(t, err)
+		assert.True(t, cpe.custom.qualityGatePassed)
+		assert.Equal(t, []string{}, cpe.custom.qualityGateViolations)
+	})
+
+	t.Run("success - decorate pull request", func(t *testing.T) {
+		t.Parallel()
+
+		config := pipelineCreateScanSummaryOptions{
+			OutputFilePath:      "scanSummary.md",
+			DecoratePullRequest: true,
+			FailedOnly:          true,
+			MaxLineComments:     5,
+			Owner:               "SAP",
+			Repository:          "jenkins-library",
+			Token:               "token-4711",
+			GithubAPIURL:        "https://api.github.com",
+		}
+
+		utils := newPipelineCreateScanSummaryTestsUtils()
+		utils.AddFile(".pipeline/stepReports/step1.json", []byte(`{"stepName":"checkmarxExecuteScan","title":"Title Scan 1","findings":[{"ruleId":"SQL_Injection","title":"SQL Injection","severity":"high","category":"sast","location":"src/Dao.java","line":42}]}`))
+		utils.AddFile(".pipeline/stepReports/step2.json", []byte(`{"stepName":"sonarExecuteScan","title":"Title Scan 2","successfulScan":true}`))
+
+		err := runPipelineCreateScanSummary(&config, nil, utils, &pipelineCreateScanSummaryCommonPipelineEnvironment{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, decoration.Options{Owner: "SAP", Repository: "jenkins-library", GitHubToken: "token-4711", GitHubAPIURL: "https://api.github.com"}, *utils.decoratorOptions)
+		if assert.Len(t, utils.decorator.comments, 1) {
+			for _, body := range utils.decorator.comments {
+				assert.Contains(t, body, "Title Scan 1")
+			}
+		}
+		if assert.Len(t, utils.decorator.lineComments, 1) {
+			assert.Equal(t, "src/Dao.java", utils.decorator.lineComments[0].Path)
+			assert.Equal(t, 42, utils.decorator.lineComments[0].Line)
+		}
+	})
+
+	t.Run("success - pull request not decorated", func(t *testing.T) {
+		t.Parallel()
+
+		config := pipelineCreateScanSummaryOptions{
+			OutputFilePath:      "scanSummary.md",
+			DecoratePullRequest: true,
+		}
+
+		utils := newPipelineCreateScanSummaryTestsUtils()
+		utils.newDecoratorError = fmt.Errorf("no pull request detected")
+		utils.AddFile(".pipeline/stepReports/step1.json", []byte(`{"title":"Title Scan 1"}`))
+
+		err := runPipelineCreateScanSummary(&config, nil, utils, &pipelineCreateScanSummaryCommonPipelineEnvironment{})
+
+		assert.NoError(t, err)
+		assert.Empty(t, utils.decorator.comments)
+		reportExists, _ := utils.FileExists("scanSummary.md")
+		assert.True(t, reportExists)
+	})
+
+	t.Run("error - quality gate violated", func(t *testing.T) {
+		t.Parallel()
+
+		config := pipelineCreateScanSummaryOptions{
+			OutputFilePath: "scanSummary.md",
+			QualityGatePolicy: map[string]interface{}{"rules": []interface{}{
+				map[string]interface{}{"name": "no high checkmarx findings", "stepName": "checkmarxExecuteScan", "severities": []interface{}{"high"}},
+				map[string]interface{}{"name": "no unapproved licenses", "category": "license"},
+			}},
+		}
+
+		utils := newPipelineCreateScanSummaryTestsUtils()
+		utils.AddFile(".pipeline/stepReports/step1.json", []byte(`{"stepName":"checkmarxExecuteScan","title":"Title Scan 1","findings":[{"ruleId":"SQL_Injection","title":"SQL Injection","severity":"high","category":"sast"}]}`))
+		utils.AddFile(".pipeline/stepReports/step2.json", []byte(`{"stepName":"whitesourceExecuteScan","title":"Title Scan 2"}`))
+		cpe := pipelineCreateScanSummaryCommonPipelineEnvironment{}
+
+		err := runPipelineCreateScanSummary(&config, nil, utils, &cpe)
+
+		assert.EqualError(t, err, "quality gate failed:\nrule 'no high checkmarx findings' violated: 1 finding(s) of severity high, step checkmarxExecuteScan, at most 0 allowed")
+		assert.False(t, cpe.custom.qualityGatePassed)
+		assert.Equal(t, []string{"no high checkmarx findings"}, cpe.custom.qualityGateViolations)
+		reportExists, _ := utils.FileExists("scanSummary.md")
+		assert.True(t, reportExists)
+	})
+
+	t.Run("error - invalid quality gate policy", func(t *testing.T) {
+		t.Parallel()
+
+		config := pipelineCreateScanSummaryOptions{
+			OutputFilePath:    "scanSummary.md",
+			QualityGatePolicy: map[string]interface{}{"rules": []interface{}{map[string]interface{}{"maxFindings": 1}}},
+		}
+
+		utils := newPipelineCreateScanSummaryTestsUtils()
+
+		err := runPipelineCreateScanSummary(&config, nil, utils, &pipelineCreateScanSummaryCommonPipelineEnvironment{})
+
+		assert.EqualError(t, err, "invalid parameter qualityGatePolicy: quality gate rule #1 has no name")
+	})
+
 	t.Run("error - read file", func(t *testing.T) {
 		t.Skip()
 		//ToDo
@@ -102,7 +283,7 @@ func TestRunPipelineCreateScanSummary(t *testing.T) {
 
 		utils := newPipelineCreateScanSummaryTestsUtils()
 
-		err := runPipelineCreateScanSummary(&config, nil, utils)
+		err := runPipelineCreateScanSummary(&config, nil, utils, &pipelineCreateScanSummaryCommonPipelineEnvironment{})
 
 		assert.Contains(t, fmt.Sprint(err), "failed to read report")
 	})
@@ -117,7 +298,7 @@ func TestRunPipelineCreateScanSummary(t *testing.T) {
 		utils := newPipelineCreateScanSummaryTestsUtils()
 		utils.AddFile(".pipeline/stepReports/step1.json", []byte(`{"title":"Title Scan 1"`))
 
-		err := runPipelineCreateScanSummary(&config, nil, utils)
+		err := runPipelineCreateScanSummary(&config, nil, utils, &pipelineCreateScanSummaryCommonPipelineEnvironment{})
 
 		assert.Contains(t, fmt.Sprint(err), "failed to parse report")
 	})
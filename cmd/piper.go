@@ -11,7 +11,9 @@ import (
 	"strings"
 
 	"github.com/SAP/jenkins-library/pkg/config"
+	piperGithub "github.com/SAP/jenkins-library/pkg/github"
 	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/opentelemetry"
 	"github.com/SAP/jenkins-library/pkg/orchestrator"
 	"github.com/SAP/jenkins-library/pkg/piperutils"
 	"github.com/pkg/errors"
@@ -26,6 +28,9 @@ type GeneralConfigOptions struct {
 	GitHubTokens         []string // list of entries in form of <server>:<token> to allow token authentication for downloading config / defaults
 	DefaultConfig        []string //ordered list of Piper default configurations. Can be filePath or ENV containing JSON in format 'ENV:MY_ENV_VAR'
 	IgnoreCustomDefaults bool
+	Local                bool
+	LocalPullRequest     string
+	LocalPullRequestBase string
 	ParametersJSON       string
 	EnvRootPath          string
 	NoTelemetry          bool
@@ -45,10 +50,12 @@ type GeneralConfigOptions struct {
 	MetaDataResolver     func() map[string]config.StepData
 }
 
-// HookConfiguration contains the configuration for supported hooks, so far Sentry and Splunk are supported.
+// HookConfiguration contains the configuration for supported hooks, so far Sentry, Splunk, OpenTelemetry and GitHub checks are supported.
 type HookConfiguration struct {
-	SentryConfig SentryConfiguration `json:"sentry,omitempty"`
-	SplunkConfig SplunkConfiguration `json:"splunk,omitempty"`
+	SentryConfig        SentryConfiguration      `json:"sentry,omitempty"`
+	SplunkConfig        SplunkConfiguration      `json:"splunk,omitempty"`
+	OpenTelemetryConfig opentelemetry.Config     `json:"openTelemetry,omitempty"`
+	GitHubChecksConfig  piperGithub.ChecksConfig `json:"githubChecks,omitempty"`
 }
 
 // SentryConfiguration defines the configuration options for the Sentry logging system
@@ -97,6 +104,7 @@ func Execute() {
 	rootCmd.AddCommand(GithubCreatePullRequestCommand())
 	rootCmd.AddCommand(GithubPublishReleaseCommand())
 	rootCmd.AddCommand(GithubSetCommitStatusCommand())
+	rootCmd.AddCommand(GithubSetCheckRunCommand())
 	rootCmd.AddCommand(GitopsUpdateDeploymentCommand())
 	rootCmd.AddCommand(CloudFoundryDeleteServiceCommand())
 	rootCmd.AddCommand(AbapEnvironmentPullGitRepoCommand())
@@ -168,6 +176,8 @@ func Execute() {
 	rootCmd.AddCommand(CheckStepActiveCommand())
 	rootCmd.AddCommand(ApiProxyDownloadCommand())
 	rootCmd.AddCommand(ApiKeyValueMapDownloadCommand())
+	rootCmd.AddCommand(OsvExecuteScanCommand())
+	rootCmd.AddCommand(GradleBuildCommand())
 
 	addRootFlags(rootCmd)
 
@@ -195,10 +205,13 @@ func addRootFlags(rootCmd *cobra.Command) {
 	rootCmd.PersistentFlags().StringVar(&GeneralConfig.ParametersJSON, "parametersJSON", os.Getenv("PIPER_parametersJSON"), "Parameters to be considered in JSON format")
 	rootCmd.PersistentFlags().StringVar(&GeneralConfig.EnvRootPath, "envRootPath", ".pipeline", "Root path to Piper pipeline shared environments")
 	rootCmd.PersistentFlags().StringVar(&GeneralConfig.StageName, "stageName", "", "Name of the stage for which configuration should be included")
+	rootCmd.PersistentFlags().BoolVar(&GeneralConfig.Local, "local", false, "Runs the step outside of a CI system, git information is read from the local checkout")
+	rootCmd.PersistentFlags().StringVar(&GeneralConfig.LocalPullRequest, "localPullRequest", "", "Key of a pull request to simulate when running with --local")
+	rootCmd.PersistentFlags().StringVar(&GeneralConfig.LocalPullRequestBase, "localPullRequestBase", "", "Target branch of the pull request to simulate when running with --local")
 	rootCmd.PersistentFlags().StringVar(&GeneralConfig.StepConfigJSON, "stepConfigJSON", os.Getenv("PIPER_stepConfigJSON"), "Step configuration in JSON format")
 	rootCmd.PersistentFlags().BoolVar(&GeneralConfig.NoTelemetry, "noTelemetry", false, "Disables telemetry reporting")
 	rootCmd.PersistentFlags().BoolVarP(&GeneralConfig.Verbose, "verbose", "v", false, "verbose output")
-	rootCmd.PersistentFlags().StringVar(&GeneralConfig.LogFormat, "logFormat", "default", "Log format to use. Options: default, timestamp, plain, full.")
+	rootCmd.PersistentFlags().StringVar(&GeneralConfig.LogFormat, "logFormat", "default", "Log format to use. Options: default, timestamp, plain, full, json.")
 	rootCmd.PersistentFlags().StringVar(&GeneralConfig.VaultServerURL, "vaultServerUrl", "", "The vault server which should be used to fetch credentials")
 	rootCmd.PersistentFlags().StringVar(&GeneralConfig.VaultNamespace, "vaultNamespace", "", "The vault namespace which should be used to fetch credentials")
 	rootCmd.PersistentFlags().StringVar(&GeneralConfig.VaultPath, "vaultPath", "", "The path which should be used to fetch credentials")
@@ -234,6 +247,25 @@ func AccessTokensFromEnvJSON(env string) []string {
 	return accessTokens
 }
 
+// initLocalOrchestrator activates the local orchestrator when requested via command line.
+// The settings are passed on via the environment so that every orchestrator specific config provider
+// created during the step execution (e.g. for telemetry) picks them up.
+func initLocalOrchestrator() {
+	if !GeneralConfig.Local {
+		return
+	}
+	os.Setenv("PIPER_LOCAL", "true")
+	if len(GeneralConfig.StageName) > 0 {
+		os.Setenv("PIPER_LOCAL_STAGE_NAME", GeneralConfig.StageName)
+	}
+	if len(GeneralConfig.LocalPullRequest) > 0 {
+		os.Setenv("PIPER_LOCAL_PULL_REQUEST", GeneralConfig.LocalPullRequest)
+	}
+	if len(GeneralConfig.LocalPullRequestBase) > 0 {
+		os.Setenv("PIPER_LOCAL_PULL_REQUEST_BASE", GeneralConfig.LocalPullRequestBase)
+	}
+}
+
 // initStageName initializes GeneralConfig.StageName from either GeneralConfig.ParametersJSON
 // or the environment variable (orchestrator specific), unless it has been provided as command line option.
 // Log output needs to be suppressed via outputToLog by the getConfig step.
@@ -245,6 +277,8 @@ func initStageName(outputToLog bool) {
 		}()
 	}
 
+	initLocalOrchestrator()
+
 	if GeneralConfig.StageName != "" {
 		// Means it was given as command line argument and has the highest precedence
 		stageNameSource = "command line arguments"
@@ -257,6 +291,9 @@ func initStageName(outputToLog bool) {
 		log.Entry().WithError(err).Warning("Cannot infer stage name from CI environment")
 	} else {
 		stageNameSource = "env variable"
+		if provider.OrchestratorType() == "Local" {
+			stageNameSource = "local orchestrator"
+		}
 		GeneralConfig.StageName = provider.GetStageName()
 	}
 
@@ -290,6 +327,8 @@ func PrepareConfig(cmd *cobra.Command, metadata *config.StepData, stepName strin
 	log.SetFormatter(GeneralConfig.LogFormat)
 
 	initStageName(true)
+	log.SetStageName(GeneralConfig.StageName)
+	log.SetCorrelationID(GeneralConfig.CorrelationID)
 
 	filters := metadata.GetParameterFilters()
 
@@ -91,6 +91,54 @@ func TestAdoptStageNameFromParametersJSON(t *testing.T) {
 	}
 }
 
+func TestInitLocalOrchestrator(t *testing.T) {
+	t.Run("local mode", func(t *testing.T) {
+		defer resetEnv(os.Environ())
+		os.Clearenv()
+		defer func(c GeneralConfigOptions) { GeneralConfig = c }(GeneralConfig)
+
+		GeneralConfig.Local = true
+		GeneralConfig.LocalPullRequest = "42"
+		GeneralConfig.LocalPullRequestBase = "main"
+		GeneralConfig.StageName = "Acceptance"
+
+		initStageName(false)
+
+		assert.Equal(t, "Acceptance", GeneralConfig.StageName)
+		assert.Equal(t, "true", os.Getenv("PIPER_LOCAL"))
+		assert.Equal(t, "Acceptance", os.Getenv("PIPER_LOCAL_STAGE_NAME"))
+		assert.Equal(t, "42", os.Getenv("PIPER_LOCAL_PULL_REQUEST"))
+		assert.Equal(t, "main", os.Getenv("PIPER_LOCAL_PULL_REQUEST_BASE"))
+	})
+
+	t.Run("stage name from local environment", func(t *testing.T) {
+		defer resetEnv(os.Environ())
+		os.Clearenv()
+		defer func(c GeneralConfigOptions) { GeneralConfig = c }(GeneralConfig)
+		GeneralConfig = GeneralConfigOptions{}
+
+		os.Setenv("PIPER_LOCAL", "true")
+		os.Setenv("PIPER_LOCAL_STAGE_NAME", "Build")
+
+		initStageName(false)
+
+		assert.Equal(t, "Build", GeneralConfig.StageName)
+	})
+
+	t.Run("not in local mode", func(t *testing.T) {
+		defer resetEnv(os.Environ())
+		os.Clearenv()
+		defer func(c GeneralConfigOptions) { GeneralConfig = c }(GeneralConfig)
+
+		GeneralConfig.StageName = "Acceptance"
+
+		initStageName(false)
+
+		assert.Equal(t, "", os.Getenv("PIPER_LOCAL"))
+		assert.Equal(t, "", os.Getenv("PIPER_LOCAL_STAGE_NAME"))
+	})
+}
+
 func TestPrepareConfig(t *testing.T) {
 	defaultsBak := GeneralConfig.DefaultConfig
 	GeneralConfig.DefaultConfig = []string{"testDefaults.yml"}
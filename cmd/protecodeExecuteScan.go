@@ -21,6 +21,7 @@ import (
 	"github.com/SAP/jenkins-library/pkg/log"
 	StepResults "github.com/SAP/jenkins-library/pkg/piperutils"
 	"github.com/SAP/jenkins-library/pkg/protecode"
+	"github.com/SAP/jenkins-library/pkg/reporting"
 	"github.com/SAP/jenkins-library/pkg/telemetry"
 	"github.com/SAP/jenkins-library/pkg/toolrecord"
 )
@@ -160,6 +161,12 @@ func executeProtecodeScan(influx *protecodeExecuteScanInflux, client protecode.P
 
 	log.Entry().Debugf("[DEBUG] ===> Load existing product Group:%v, VerifyOnly:%v, Filename:%v, replaceProductId:%v", config.Group, config.VerifyOnly, fileName, config.ReplaceProductID)
 
+	triage, err := reporting.ReadTriage(config.TriageFile, &StepResults.Files{})
+	if err != nil {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return err
+	}
+
 	productID := -1
 
 	// If replaceProductId is not provided then switch to automatic existing product detection
@@ -256,6 +263,7 @@ func executeProtecodeScan(influx *protecodeExecuteScanInflux, client protecode.P
 
 	// write custom report
 	scanReport := protecode.CreateCustomReport(fileName, productID, parsedResult, vulns)
+	triage.Apply(&scanReport)
 	paths, err := protecode.WriteCustomReports(scanReport, fileName, fmt.Sprint(productID))
 	if err != nil {
 		// do not fail - consider failing later on
@@ -275,7 +283,7 @@ func executeProtecodeScan(influx *protecodeExecuteScanInflux, client protecode.P
 
 	StepResults.PersistReportsAndLinks("protecodeExecuteScan", "", reports, links)
 
-	if config.FailOnSevereVulnerabilities && protecode.HasSevereVulnerabilities(result.Result, config.ExcludeCVEs) {
+	if config.FailOnSevereVulnerabilities && protecode.HasSevereVulnerabilities(result.Result, config.ExcludeCVEs, triage) {
 		log.SetErrorCategory(log.ErrorCompliance)
 		return fmt.Errorf("the product is not compliant")
 	}
@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"crypto/sha1"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"os/exec"
@@ -20,6 +22,7 @@ import (
 	FileUtils "github.com/SAP/jenkins-library/pkg/piperutils"
 	SliceUtils "github.com/SAP/jenkins-library/pkg/piperutils"
 	StepResults "github.com/SAP/jenkins-library/pkg/piperutils"
+	"github.com/SAP/jenkins-library/pkg/reporting"
 	SonarUtils "github.com/SAP/jenkins-library/pkg/sonar"
 	"github.com/SAP/jenkins-library/pkg/telemetry"
 	"github.com/SAP/jenkins-library/pkg/versioning"
@@ -231,8 +234,15 @@ func runSonar(config sonarExecuteScanOptions, client piperhttp.Downloader, runne
 	if err != nil {
 		return err
 	}
+	// fetch quality gate status of the analysed branch or pull request
+	qualityGateService := SonarUtils.NewQualityGateService(taskReport.ServerURL, config.Token, taskReport.ProjectKey, config.BranchName, config.ChangeID, apiClient)
+	qualityGate, err := qualityGateService.GetStatus()
+	if err != nil {
+		return err
+	}
+	influx.sonarqube_data.fields.quality_gate_status = qualityGate.Status
 	log.Entry().Debugf("Influx values: %v", influx.sonarqube_data.fields)
-	err = SonarUtils.WriteReport(SonarUtils.ReportData{
+	reportData := SonarUtils.ReportData{
 		ServerURL:    taskReport.ServerURL,
 		ProjectKey:   taskReport.ProjectKey,
 		TaskID:       taskReport.TaskID,
@@ -246,13 +256,69 @@ func runSonar(config sonarExecuteScanOptions, client piperhttp.Downloader, runne
 			Minor:    influx.sonarqube_data.fields.minor_issues,
 			Info:     influx.sonarqube_data.fields.info_issues,
 		},
-	}, sonar.workingDir, ioutil.WriteFile)
+		QualityGateStatus: qualityGate.Status,
+	}
+	err = SonarUtils.WriteReport(reportData, sonar.workingDir, ioutil.WriteFile)
+	if err != nil {
+		return err
+	}
+	// export all issues so that they are available without the Sonar UI, e.g. for pull request tooling
+	issues, err := issueService.GetIssues()
+	if err != nil {
+		return err
+	}
+	reports, err := writeSonarScanReports(reportData, SonarUtils.CreateScanReport(reportData, qualityGate, issues))
 	if err != nil {
 		return err
 	}
+	StepResults.PersistReportsAndLinks("sonarExecuteScan", sonar.workingDir, reports, links)
+
+	if qualityGate.Status == SonarUtils.QualityGateStatusError {
+		if config.FailOnQualityGate {
+			log.SetErrorCategory(log.ErrorCompliance)
+			return fmt.Errorf("quality gate of project '%s' failed - see %s for details", taskReport.ProjectKey, taskReport.DashboardURL)
+		}
+		log.Entry().Warnf("Quality gate of project '%s' failed - see %s for details", taskReport.ProjectKey, taskReport.DashboardURL)
+	}
 	return nil
 }
 
+// writeSonarScanReports writes the scan report as HTML and SARIF as well as JSON for step pipelineCreateScanSummary
+func writeSonarScanReports(reportData SonarUtils.ReportData, scanReport reporting.ScanReport) ([]StepResults.Path, error) {
+	reportPaths := []StepResults.Path{}
+
+	// ignore templating errors since template is in our hands and issues will be detected with the automated tests
+	htmlReport, _ := scanReport.ToHTML()
+	htmlReportPath := filepath.Join(sonar.workingDir, "sonarscan.html")
+	if err := ioutil.WriteFile(htmlReportPath, htmlReport, 0666); err != nil {
+		return reportPaths, errors.Wrap(err, "failed to write html report")
+	}
+	reportPaths = append(reportPaths, StepResults.Path{Name: "Sonar Report", Target: htmlReportPath})
+
+	sarifReport, err := scanReport.ToSARIF()
+	if err != nil {
+		return reportPaths, errors.Wrap(err, "failed to create SARIF report")
+	}
+	sarifReportPath := filepath.Join(sonar.workingDir, "sonarscan.sarif")
+	if err := ioutil.WriteFile(sarifReportPath, sarifReport, 0666); err != nil {
+		return reportPaths, errors.Wrap(err, "failed to write SARIF report")
+	}
+	reportPaths = append(reportPaths, StepResults.Path{Name: "Sonar SARIF Report", Target: sarifReportPath})
+
+	// JSON reports are used by step pipelineCreateSummary in order to e.g. prepare an issue creation in GitHub
+	// ignore JSON errors since structure is in our hands
+	jsonReport, _ := scanReport.ToJSON()
+	stepReportDirectory := filepath.Join(sonar.workingDir, reporting.StepReportDirectory)
+	if err := os.MkdirAll(stepReportDirectory, 0777); err != nil {
+		return reportPaths, errors.Wrap(err, "failed to create reporting directory")
+	}
+	reportSha := fmt.Sprintf("%x", sha1.Sum([]byte(strings.Join([]string{reportData.ProjectKey, reportData.BranchName, reportData.ChangeID}, ","))))
+	if err := ioutil.WriteFile(filepath.Join(stepReportDirectory, fmt.Sprintf("sonarExecuteScan_sonar_%v.json", reportSha)), jsonReport, 0666); err != nil {
+		return reportPaths, errors.Wrap(err, "failed to write json report")
+	}
+	return reportPaths, nil
+}
+
 // isInOptions returns true, if the given property is already provided in config.Options.
 func isInOptions(config sonarExecuteScanOptions, property string) bool {
 	property = strings.TrimSuffix(property, "=")
@@ -18,6 +18,7 @@ import (
 	piperHttp "github.com/SAP/jenkins-library/pkg/http"
 	"github.com/SAP/jenkins-library/pkg/mock"
 	FileUtils "github.com/SAP/jenkins-library/pkg/piperutils"
+	"github.com/SAP/jenkins-library/pkg/reporting"
 	SonarUtils "github.com/SAP/jenkins-library/pkg/sonar"
 )
 
@@ -119,6 +120,7 @@ func TestRunSonar(t *testing.T) {
 	// add response handler
 	httpmock.RegisterResponder(http.MethodGet, sonarServerURL+"/api/"+SonarUtils.EndpointCeTask+"", httpmock.NewStringResponder(http.StatusOK, `{ "task": { "componentId": "AXERR2JBbm9IiM5TEST", "status": "SUCCESS" }}`))
 	httpmock.RegisterResponder(http.MethodGet, sonarServerURL+"/api/"+SonarUtils.EndpointIssuesSearch+"", httpmock.NewStringResponder(http.StatusOK, `{ "total": 0 }`))
+	httpmock.RegisterResponder(http.MethodGet, sonarServerURL+"/api/"+SonarUtils.EndpointQualityGatesProjectStatus+"", httpmock.NewStringResponder(http.StatusOK, `{ "projectStatus": { "status": "OK" }}`))
 
 	t.Run("default", func(t *testing.T) {
 		// init
@@ -301,6 +303,81 @@ func TestRunSonar(t *testing.T) {
 	})
 }
 
+func TestRunSonarQualityGate(t *testing.T) {
+	mockRunner := mock.ExecMockRunner{}
+	mockDownloadClient := mockDownloader{shouldFail: false}
+	apiClient := &piperHttp.Client{}
+	apiClient.SetOptions(piperHttp.ClientOptions{MaxRetries: -1, UseDefaultTransport: true})
+	// mock SonarQube API calls
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	// add response handler
+	httpmock.RegisterResponder(http.MethodGet, sonarServerURL+"/api/"+SonarUtils.EndpointCeTask+"", httpmock.NewStringResponder(http.StatusOK, `{ "task": { "componentId": "AXERR2JBbm9IiM5TEST", "status": "SUCCESS" }}`))
+	httpmock.RegisterResponder(http.MethodGet, sonarServerURL+"/api/"+SonarUtils.EndpointIssuesSearch+"", func(req *http.Request) (*http.Response, error) {
+		if req.URL.Query().Get("ps") == "1" {
+			return httpmock.NewStringResponse(http.StatusOK, `{ "total": 1 }`), nil
+		}
+		return httpmock.NewStringResponse(http.StatusOK, `{ "total": 1, "issues": [{ "key": "AXVKXJLLrkwsFznOfAjG", "rule": "go:S3776", "severity": "CRITICAL", "component": "piper-test:cmd/main.go", "line": 42, "message": "Refactor this method", "type": "CODE_SMELL" }]}`), nil
+	})
+	httpmock.RegisterResponder(http.MethodGet, sonarServerURL+"/api/"+SonarUtils.EndpointQualityGatesProjectStatus+"", func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, "42", req.URL.Query().Get("pullRequest"))
+		return httpmock.NewStringResponse(http.StatusOK, `{ "projectStatus": { "status": "ERROR", "conditions": [{ "status": "ERROR", "metricKey": "new_coverage", "comparator": "LT", "errorThreshold": "80", "actualValue": "42.0" }]}}`), nil
+	})
+
+	run := func(t *testing.T, failOnQualityGate bool) (string, *sonarExecuteScanInflux, error) {
+		tmpFolder, err := ioutil.TempDir(".", "test-sonar-")
+		require.NoError(t, err)
+		createTaskReportFile(t, tmpFolder)
+
+		sonar = sonarSettings{
+			workingDir:  tmpFolder,
+			binary:      "sonar-scanner",
+			environment: []string{},
+			options:     []string{},
+		}
+		options := sonarExecuteScanOptions{
+			Token:               "secret-ABC",
+			ServerURL:           sonarServerURL,
+			ChangeID:            "42",
+			ChangeBranch:        "feature",
+			ChangeTarget:        "master",
+			PullRequestProvider: "GitHub",
+			FailOnQualityGate:   failOnQualityGate,
+		}
+		fileUtilsExists = mockFileUtilsExists(true)
+		influx := &sonarExecuteScanInflux{}
+		err = runSonar(options, &mockDownloadClient, &mockRunner, apiClient, influx)
+		return tmpFolder, influx, err
+	}
+
+	t.Run("failed quality gate", func(t *testing.T) {
+		tmpFolder, influx, err := run(t, true)
+		defer os.RemoveAll(tmpFolder)
+
+		assert.EqualError(t, err, "quality gate of project 'piper-test' failed - see "+sonarServerURL+"/dashboard/index/piper-test for details")
+		assert.Equal(t, "ERROR", influx.sonarqube_data.fields.quality_gate_status)
+		assert.FileExists(t, filepath.Join(tmpFolder, "sonarscan.html"))
+		sarif, err := ioutil.ReadFile(filepath.Join(tmpFolder, "sonarscan.sarif"))
+		require.NoError(t, err)
+		assert.Contains(t, string(sarif), `"ruleId": "go:S3776"`)
+		assert.Contains(t, string(sarif), `"uri": "cmd/main.go"`)
+		jsonReports, _ := filepath.Glob(filepath.Join(tmpFolder, reporting.StepReportDirectory, "sonarExecuteScan_sonar_*.json"))
+		assert.Len(t, jsonReports, 1)
+		stepReports, err := ioutil.ReadFile(filepath.Join(tmpFolder, "sonarExecuteScan_reports.json"))
+		require.NoError(t, err)
+		assert.Contains(t, string(stepReports), "sonarscan.sarif")
+	})
+
+	t.Run("failed quality gate not enforced", func(t *testing.T) {
+		tmpFolder, influx, err := run(t, false)
+		defer os.RemoveAll(tmpFolder)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "ERROR", influx.sonarqube_data.fields.quality_gate_status)
+		assert.Equal(t, 1, influx.sonarqube_data.fields.critical_issues)
+	})
+}
+
 func TestSonarHandlePullRequest(t *testing.T) {
 	t.Run("default", func(t *testing.T) {
 		// init
@@ -2,18 +2,28 @@ package cmd
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"fmt"
 	"github.com/SAP/jenkins-library/pkg/command"
 	"github.com/SAP/jenkins-library/pkg/log"
 	"github.com/SAP/jenkins-library/pkg/piperutils"
 	"github.com/SAP/jenkins-library/pkg/telemetry"
 	"github.com/SAP/jenkins-library/pkg/terraform"
+	"github.com/pkg/errors"
+	"os"
+	"path/filepath"
+	"strings"
 )
 
+const terraformDriftCommand = "drift"
+
 type terraformExecuteUtils interface {
 	command.ExecRunner
 
+	GetExitCode() int
 	FileExists(filename string) (bool, error)
+	FileRead(path string) ([]byte, error)
+	FileWrite(path string, content []byte, perm os.FileMode) error
 }
 
 type terraformExecuteUtilsBundle struct {
@@ -50,24 +60,50 @@ func runTerraformExecute(config *terraformExecuteOptions, telemetryData *telemet
 		utils.AppendEnv([]string{fmt.Sprintf("TF_WORKSPACE=%s", config.Workspace)})
 	}
 
+	usePlanFile := len(config.PlanFile) > 0 && piperutils.ContainsString([]string{"apply", "plan"}, config.Command)
+
+	// the policy is checked against the saved plan, without it changes would be rolled out unchecked
+	policyConfigured := len(config.ForbiddenDeletions) > 0 || len(config.RequiredTags) > 0
+	if policyConfigured && !usePlanFile && piperutils.ContainsString([]string{"apply", "plan", "destroy"}, config.Command) {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return fmt.Errorf("forbiddenDeletions and requiredTags are only checked for plans saved to planFile, command %v does not use a plan file", config.Command)
+	}
+
 	args := []string{}
 
 	if piperutils.ContainsString([]string{"apply", "destroy"}, config.Command) {
 		args = append(args, "-auto-approve")
 	}
 
-	if piperutils.ContainsString([]string{"apply", "plan"}, config.Command) && config.TerraformSecrets != "" {
+	if config.Command == terraformDriftCommand {
+		args = append(args, "-detailed-exitcode")
+	}
+
+	// variables cannot be set when applying a saved plan, they are part of the plan already
+	if piperutils.ContainsString([]string{"apply", "plan", terraformDriftCommand}, config.Command) && config.TerraformSecrets != "" && !(usePlanFile && config.Command == "apply") {
 		args = append(args, fmt.Sprintf("-var-file=%s", config.TerraformSecrets))
 	}
 
-	if piperutils.ContainsString([]string{"init", "validate", "plan", "apply", "destroy"}, config.Command) {
+	if piperutils.ContainsString([]string{"init", "validate", "plan", "apply", "destroy", terraformDriftCommand}, config.Command) {
 		args = append(args, "-no-color")
 	}
 
+	if usePlanFile && config.Command == "plan" {
+		args = append(args, fmt.Sprintf("-out=%s", config.PlanFile))
+	}
+
 	if config.AdditionalArgs != nil {
 		args = append(args, config.AdditionalArgs...)
 	}
 
+	if usePlanFile && config.Command == "apply" {
+		err := verifyTerraformPlan(config, utils)
+		if err != nil {
+			return err
+		}
+		args = append(args, config.PlanFile)
+	}
+
 	if config.Init {
 		err := runTerraform(utils, "init", []string{"-no-color"}, config.GlobalOptions)
 
@@ -76,12 +112,24 @@ func runTerraformExecute(config *terraformExecuteOptions, telemetryData *telemet
 		}
 	}
 
+	if config.Command == terraformDriftCommand {
+		return runTerraformDriftDetection(utils, args, config.GlobalOptions)
+	}
+
 	err := runTerraform(utils, config.Command, args, config.GlobalOptions)
 
 	if err != nil {
 		return err
 	}
 
+	if usePlanFile && config.Command == "plan" {
+		err = processTerraformPlan(config, utils, commonPipelineEnvironment)
+
+		if err != nil {
+			return err
+		}
+	}
+
 	var outputBuffer bytes.Buffer
 	utils.Stdout(&outputBuffer)
 
@@ -96,6 +144,103 @@ func runTerraformExecute(config *terraformExecuteOptions, telemetryData *telemet
 	return err
 }
 
+// runTerraformDriftDetection runs a plan which exits with code 2 in case the infrastructure differs from the configuration
+func runTerraformDriftDetection(utils terraformExecuteUtils, args []string, globalOptions []string) error {
+	err := runTerraform(utils, "plan", args, globalOptions)
+	if err != nil && utils.GetExitCode() == 2 {
+		log.SetErrorCategory(log.ErrorCompliance)
+		return errors.New("drift detected: terraform plan reports changes, the infrastructure differs from its configuration")
+	}
+	if err != nil {
+		return err
+	}
+	log.Entry().Info("no drift detected")
+	return nil
+}
+
+// processTerraformPlan stores the JSON rendering of the plan without sensitive values as report, checks it against the policy
+// and provides the checksum of the plan only if the plan complies with the policy
+func processTerraformPlan(config *terraformExecuteOptions, utils terraformExecuteUtils, commonPipelineEnvironment *terraformExecuteCommonPipelineEnvironment) error {
+	var planJSON bytes.Buffer
+	utils.Stdout(&planJSON)
+	err := runTerraform(utils, "show", []string{"-json", "-no-color", config.PlanFile}, config.GlobalOptions)
+	utils.Stdout(log.Writer())
+	if err != nil {
+		return err
+	}
+
+	redactedPlanJSON, err := terraform.RedactPlan(planJSON.Bytes())
+	if err != nil {
+		return err
+	}
+	planPath := terraformPlanPath(config)
+	err = utils.FileWrite(planPath+".json", redactedPlanJSON, 0666)
+	if err != nil {
+		return errors.Wrapf(err, "failed to write JSON representation of plan '%v'", planPath)
+	}
+	// the binary plan is not archived since it contains sensitive values in plain text
+	piperutils.PersistReportsAndLinks("terraformExecute", "", []piperutils.Path{
+		{Name: "Terraform plan (JSON)", Target: planPath + ".json"},
+	}, nil)
+
+	if len(config.ForbiddenDeletions) > 0 || len(config.RequiredTags) > 0 {
+		plan, err := terraform.ReadPlan(planJSON.Bytes())
+		if err != nil {
+			return err
+		}
+		violations := plan.CheckPolicy(terraform.PlanPolicy{ForbiddenDeletions: config.ForbiddenDeletions, RequiredTags: config.RequiredTags})
+		for _, violation := range violations {
+			log.Entry().Error(violation)
+		}
+		if len(violations) > 0 {
+			log.SetErrorCategory(log.ErrorCompliance)
+			return fmt.Errorf("plan violates %v policy rule(s)", len(violations))
+		}
+	}
+
+	checksum, err := terraformPlanChecksum(planPath, utils)
+	if err != nil {
+		return err
+	}
+	commonPipelineEnvironment.custom.terraformPlanChecksum = checksum
+	return nil
+}
+
+// verifyTerraformPlan ensures that the plan file is the one created by the command plan
+func verifyTerraformPlan(config *terraformExecuteOptions, utils terraformExecuteUtils) error {
+	if len(config.PlanChecksum) == 0 {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return fmt.Errorf("no checksum of plan '%v' available, the plan needs to be created by the command plan", config.PlanFile)
+	}
+	checksum, err := terraformPlanChecksum(terraformPlanPath(config), utils)
+	if err != nil {
+		return err
+	}
+	if checksum != config.PlanChecksum {
+		log.SetErrorCategory(log.ErrorCompliance)
+		return fmt.Errorf("plan '%v' does not match the plan created by the command plan", config.PlanFile)
+	}
+	return nil
+}
+
+func terraformPlanChecksum(planPath string, utils terraformExecuteUtils) (string, error) {
+	content, err := utils.FileRead(planPath)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to read plan '%v'", planPath)
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(content)), nil
+}
+
+// terraformPlanPath returns the path of the plan file, it is relative to the directory passed via the global option -chdir
+func terraformPlanPath(config *terraformExecuteOptions) string {
+	for _, option := range config.GlobalOptions {
+		if strings.HasPrefix(option, "-chdir=") {
+			return filepath.Join(strings.TrimPrefix(option, "-chdir="), config.PlanFile)
+		}
+	}
+	return config.PlanFile
+}
+
 func runTerraform(utils terraformExecuteUtils, command string, additionalArgs []string, globalOptions []string) error {
 	args := []string{}
 
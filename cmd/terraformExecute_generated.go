@@ -9,7 +9,9 @@ import (
 	"time"
 
 	"github.com/SAP/jenkins-library/pkg/config"
+	piperGithub "github.com/SAP/jenkins-library/pkg/github"
 	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/opentelemetry"
 	"github.com/SAP/jenkins-library/pkg/piperenv"
 	"github.com/SAP/jenkins-library/pkg/splunk"
 	"github.com/SAP/jenkins-library/pkg/telemetry"
@@ -18,18 +20,23 @@ import (
 )
 
 type terraformExecuteOptions struct {
-	Command          string   `json:"command,omitempty"`
-	TerraformSecrets string   `json:"terraformSecrets,omitempty"`
-	GlobalOptions    []string `json:"globalOptions,omitempty"`
-	AdditionalArgs   []string `json:"additionalArgs,omitempty"`
-	Init             bool     `json:"init,omitempty"`
-	CliConfigFile    string   `json:"cliConfigFile,omitempty"`
-	Workspace        string   `json:"workspace,omitempty"`
+	Command            string   `json:"command,omitempty"`
+	TerraformSecrets   string   `json:"terraformSecrets,omitempty"`
+	GlobalOptions      []string `json:"globalOptions,omitempty"`
+	AdditionalArgs     []string `json:"additionalArgs,omitempty"`
+	Init               bool     `json:"init,omitempty"`
+	CliConfigFile      string   `json:"cliConfigFile,omitempty"`
+	Workspace          string   `json:"workspace,omitempty"`
+	PlanFile           string   `json:"planFile,omitempty"`
+	PlanChecksum       string   `json:"planChecksum,omitempty"`
+	ForbiddenDeletions []string `json:"forbiddenDeletions,omitempty"`
+	RequiredTags       []string `json:"requiredTags,omitempty"`
 }
 
 type terraformExecuteCommonPipelineEnvironment struct {
 	custom struct {
-		terraformOutputs map[string]interface{}
+		terraformOutputs      map[string]interface{}
+		terraformPlanChecksum string
 	}
 }
 
@@ -40,6 +47,7 @@ func (p *terraformExecuteCommonPipelineEnvironment) persist(path, resourceName s
 		value    interface{}
 	}{
 		{category: "custom", name: "terraformOutputs", value: p.custom.terraformOutputs},
+		{category: "custom", name: "terraformPlanChecksum", value: p.custom.terraformPlanChecksum},
 	}
 
 	errCount := 0
@@ -65,12 +73,21 @@ func TerraformExecuteCommand() *cobra.Command {
 	var commonPipelineEnvironment terraformExecuteCommonPipelineEnvironment
 	var logCollector *log.CollectorHook
 	var splunkClient *splunk.Splunk
+	var otelClient *opentelemetry.OpenTelemetry
+	var checkRun *piperGithub.StepCheckRun
 	telemetryClient := &telemetry.Telemetry{}
 
 	var createTerraformExecuteCmd = &cobra.Command{
 		Use:   STEP_NAME,
 		Short: "Executes Terraform",
-		Long:  `This step executes the terraform binary with the given command, and is able to fetch additional variables from vault.`,
+		Long: `This step executes the terraform binary with the given command, and is able to fetch additional variables from vault.
+
+Changes can be rolled out in two phases by defining a ` + "`" + `planFile` + "`" + `:
+The command ` + "`" + `plan` + "`" + ` stores the binary plan in the ` + "`" + `planFile` + "`" + ` and its JSON rendering next to it as step report. Values marked as sensitive are replaced in the JSON rendering, the binary plan is not archived since it contains them in plain text.
+The JSON rendering of the plan is checked against the rules ` + "`" + `forbiddenDeletions` + "`" + ` and ` + "`" + `requiredTags` + "`" + `, the checksum of the plan is provided via the common pipeline environment only if the plan complies with them.
+A later ` + "`" + `apply` + "`" + ` with the same ` + "`" + `planFile` + "`" + ` only applies this exact plan and fails in case the plan file has been changed in between.
+
+The command ` + "`" + `drift` + "`" + ` is meant for scheduled runs, it fails in case ` + "`" + `terraform plan -detailed-exitcode` + "`" + ` reports changes, i.e. the infrastructure has drifted from its configuration.`,
 		PreRunE: func(cmd *cobra.Command, _ []string) error {
 			startTime = time.Now()
 			log.SetStepName(STEP_NAME)
@@ -100,6 +117,13 @@ func TerraformExecuteCommand() *cobra.Command {
 				log.RegisterHook(logCollector)
 			}
 
+			if len(GeneralConfig.HookConfig.OpenTelemetryConfig.Endpoint) > 0 {
+				otelClient = &opentelemetry.OpenTelemetry{}
+				if err := otelClient.Initialize(GeneralConfig.CorrelationID, STEP_NAME, GeneralConfig.HookConfig.OpenTelemetryConfig); err != nil {
+					log.Entry().WithError(err).Warning("failed to initialize OpenTelemetry")
+				}
+			}
+
 			validation, err := validation.New(validation.WithJSONNamesForStructFields(), validation.WithPredefinedErrorMessages())
 			if err != nil {
 				return err
@@ -125,6 +149,12 @@ func TerraformExecuteCommand() *cobra.Command {
 				if len(GeneralConfig.HookConfig.SplunkConfig.Dsn) > 0 {
 					splunkClient.Send(telemetryClient.GetData(), logCollector)
 				}
+				if len(GeneralConfig.HookConfig.OpenTelemetryConfig.Endpoint) > 0 {
+					otelClient.Send(telemetryClient.GetData().Attributes())
+				}
+				if checkRun != nil {
+					checkRun.Complete(stepTelemetryData.ErrorCode != "0", log.GetErrorCategory())
+				}
 			}
 			log.DeferExitHandler(handler)
 			defer handler()
@@ -136,6 +166,9 @@ func TerraformExecuteCommand() *cobra.Command {
 					GeneralConfig.HookConfig.SplunkConfig.Index,
 					GeneralConfig.HookConfig.SplunkConfig.SendLogs)
 			}
+			if GeneralConfig.HookConfig.GitHubChecksConfig.Enabled {
+				checkRun = piperGithub.StartStepCheckRun(STEP_NAME, GeneralConfig.StageName, GeneralConfig.HookConfig.GitHubChecksConfig)
+			}
 			terraformExecute(stepConfig, &stepTelemetryData, &commonPipelineEnvironment)
 			stepTelemetryData.ErrorCode = "0"
 			log.Entry().Info("SUCCESS")
@@ -147,13 +180,17 @@ func TerraformExecuteCommand() *cobra.Command {
 }
 
 func addTerraformExecuteFlags(cmd *cobra.Command, stepConfig *terraformExecuteOptions) {
-	cmd.Flags().StringVar(&stepConfig.Command, "command", `plan`, "")
+	cmd.Flags().StringVar(&stepConfig.Command, "command", `plan`, "Terraform command to execute, e.g. `init`, `plan`, `apply` or `destroy`. The command `drift` runs `plan -detailed-exitcode` and fails in case changes are detected.")
 	cmd.Flags().StringVar(&stepConfig.TerraformSecrets, "terraformSecrets", os.Getenv("PIPER_terraformSecrets"), "")
 	cmd.Flags().StringSliceVar(&stepConfig.GlobalOptions, "globalOptions", []string{}, "")
 	cmd.Flags().StringSliceVar(&stepConfig.AdditionalArgs, "additionalArgs", []string{}, "")
 	cmd.Flags().BoolVar(&stepConfig.Init, "init", false, "")
 	cmd.Flags().StringVar(&stepConfig.CliConfigFile, "cliConfigFile", os.Getenv("PIPER_cliConfigFile"), "Path to the terraform CLI configuration file (https://www.terraform.io/docs/cli/config/config-file.html#credentials).")
 	cmd.Flags().StringVar(&stepConfig.Workspace, "workspace", os.Getenv("PIPER_workspace"), "")
+	cmd.Flags().StringVar(&stepConfig.PlanFile, "planFile", os.Getenv("PIPER_planFile"), "Path of the binary plan file relative to the terraform working directory. It is written by the command `plan` and the only plan accepted by the command `apply`.")
+	cmd.Flags().StringVar(&stepConfig.PlanChecksum, "planChecksum", os.Getenv("PIPER_planChecksum"), "SHA-256 checksum of the plan file created by the command `plan`, the command `apply` fails in case the plan file does not match.")
+	cmd.Flags().StringSliceVar(&stepConfig.ForbiddenDeletions, "forbiddenDeletions", []string{}, "Resource types or addresses which must not be deleted or replaced by a plan, patterns like `module.database.*` are supported. The rule is checked for the plan saved to `planFile`, the commands `plan`, `apply` and `destroy` fail without `planFile`.")
+	cmd.Flags().StringSliceVar(&stepConfig.RequiredTags, "requiredTags", []string{}, "Tags every resource supporting tags needs to carry when being created or updated by a plan. The rule is checked for the plan saved to `planFile`, the commands `plan`, `apply` and `destroy` fail without `planFile`.")
 
 }
 
@@ -251,6 +288,47 @@ func terraformExecuteMetadata() config.StepData {
 						Aliases:     []config.Alias{},
 						Default:     os.Getenv("PIPER_workspace"),
 					},
+					{
+						Name:        "planFile",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     os.Getenv("PIPER_planFile"),
+					},
+					{
+						Name: "planChecksum",
+						ResourceRef: []config.ResourceReference{
+							{
+								Name:  "commonPipelineEnvironment",
+								Param: "custom/terraformPlanChecksum",
+							},
+						},
+						Scope:     []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:      "string",
+						Mandatory: false,
+						Aliases:   []config.Alias{},
+						Default:   os.Getenv("PIPER_planChecksum"),
+					},
+					{
+						Name:        "forbiddenDeletions",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "[]string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     []string{},
+					},
+					{
+						Name:        "requiredTags",
+						ResourceRef: []config.ResourceReference{},
+						Scope:       []string{"PARAMETERS", "STAGES", "STEPS"},
+						Type:        "[]string",
+						Mandatory:   false,
+						Aliases:     []config.Alias{},
+						Default:     []string{},
+					},
 				},
 			},
 			Containers: []config.Container{
@@ -263,6 +341,7 @@ func terraformExecuteMetadata() config.StepData {
 						Type: "piperEnvironment",
 						Parameters: []map[string]interface{}{
 							{"Name": "custom/terraformOutputs"},
+							{"Name": "custom/terraformPlanChecksum"},
 						},
 					},
 				},
@@ -156,3 +156,136 @@ func TestRunTerraformExecute(t *testing.T) {
 		assert.Equal(t, "a secret value", cpe.custom.terraformOutputs["sample_var"])
 	})
 }
+
+func TestRunTerraformExecuteWithPlanFile(t *testing.T) {
+	t.Parallel()
+
+	planJSON := `{"format_version":"0.2","resource_changes":[{"address":"aws_db_instance.main","mode":"managed","type":"aws_db_instance","change":{"actions":["delete","create"],"after":{"tags":{"owner":"team"}}}}]}`
+	// checksum of a different plan
+	planChecksum := "b1d57c08af8a4bbaa5151bd47f3ae2d3c0f8ef06e4f7d20b9e1e88b8fa66f7e6"
+
+	t.Run("plan stores plan and checksum", func(t *testing.T) {
+		t.Parallel()
+		config := terraformExecuteOptions{Command: "plan", PlanFile: "tfplan", GlobalOptions: []string{"-chdir=src"}, TerraformSecrets: "/tmp/test"}
+		utils := newTerraformExecuteTestsUtils()
+		utils.AddFile("src/tfplan", []byte("binary plan"))
+		utils.StdoutReturn = map[string]string{
+			"terraform -chdir=src show -json -no-color tfplan": planJSON,
+			"terraform -chdir=src output -json":                "{}",
+		}
+		cpe := terraformExecuteCommonPipelineEnvironment{}
+
+		err := runTerraformExecute(&config, nil, utils, &cpe)
+
+		assert.NoError(t, err)
+		assert.Equal(t, mock.ExecCall{Exec: "terraform", Params: []string{"-chdir=src", "plan", "-var-file=/tmp/test", "-no-color", "-out=tfplan"}}, utils.Calls[0])
+		content, err := utils.FileRead("src/tfplan.json")
+		assert.NoError(t, err)
+		assert.JSONEq(t, planJSON, string(content))
+		assert.Len(t, cpe.custom.terraformPlanChecksum, 64)
+	})
+
+	t.Run("plan violates policy", func(t *testing.T) {
+		t.Parallel()
+		config := terraformExecuteOptions{Command: "plan", PlanFile: "tfplan", ForbiddenDeletions: []string{"aws_db_instance"}, RequiredTags: []string{"owner"}}
+		utils := newTerraformExecuteTestsUtils()
+		utils.AddFile("tfplan", []byte("binary plan"))
+		utils.StdoutReturn = map[string]string{"terraform show -json -no-color tfplan": planJSON}
+		cpe := terraformExecuteCommonPipelineEnvironment{}
+
+		err := runTerraformExecute(&config, nil, utils, &cpe)
+
+		assert.EqualError(t, err, "plan violates 1 policy rule(s)")
+		assert.Len(t, utils.Calls, 2)
+		assert.Empty(t, cpe.custom.terraformPlanChecksum)
+	})
+
+	t.Run("policy without plan file", func(t *testing.T) {
+		t.Parallel()
+		config := terraformExecuteOptions{Command: "apply", RequiredTags: []string{"owner"}}
+		utils := newTerraformExecuteTestsUtils()
+
+		err := runTerraformExecute(&config, nil, utils, &terraformExecuteCommonPipelineEnvironment{})
+
+		assert.EqualError(t, err, "forbiddenDeletions and requiredTags are only checked for plans saved to planFile, command apply does not use a plan file")
+		assert.Empty(t, utils.Calls)
+	})
+
+	t.Run("apply accepts matching plan only", func(t *testing.T) {
+		t.Parallel()
+		config := terraformExecuteOptions{Command: "apply", PlanFile: "tfplan", TerraformSecrets: "/tmp/test", AdditionalArgs: []string{"-parallelism=2"}}
+		utils := newTerraformExecuteTestsUtils()
+		utils.AddFile("tfplan", []byte("binary plan"))
+		utils.StdoutReturn = map[string]string{"terraform output -json": "{}"}
+		checksum, _ := terraformPlanChecksum("tfplan", utils)
+		config.PlanChecksum = checksum
+
+		err := runTerraformExecute(&config, nil, utils, &terraformExecuteCommonPipelineEnvironment{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, mock.ExecCall{Exec: "terraform", Params: []string{"apply", "-auto-approve", "-no-color", "-parallelism=2", "tfplan"}}, utils.Calls[0])
+	})
+
+	t.Run("apply rejects changed plan", func(t *testing.T) {
+		t.Parallel()
+		config := terraformExecuteOptions{Command: "apply", PlanFile: "tfplan", PlanChecksum: planChecksum}
+		utils := newTerraformExecuteTestsUtils()
+		utils.AddFile("tfplan", []byte("another plan"))
+
+		err := runTerraformExecute(&config, nil, utils, &terraformExecuteCommonPipelineEnvironment{})
+
+		assert.EqualError(t, err, "plan 'tfplan' does not match the plan created by the command plan")
+		assert.Empty(t, utils.Calls)
+	})
+
+	t.Run("apply without checksum", func(t *testing.T) {
+		t.Parallel()
+		config := terraformExecuteOptions{Command: "apply", PlanFile: "tfplan"}
+		utils := newTerraformExecuteTestsUtils()
+
+		err := runTerraformExecute(&config, nil, utils, &terraformExecuteCommonPipelineEnvironment{})
+
+		assert.EqualError(t, err, "no checksum of plan 'tfplan' available, the plan needs to be created by the command plan")
+	})
+}
+
+func TestRunTerraformExecuteDrift(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no drift", func(t *testing.T) {
+		t.Parallel()
+		config := terraformExecuteOptions{Command: "drift", TerraformSecrets: "/tmp/test", Init: true}
+		utils := newTerraformExecuteTestsUtils()
+
+		err := runTerraformExecute(&config, nil, utils, &terraformExecuteCommonPipelineEnvironment{})
+
+		assert.NoError(t, err)
+		if assert.Len(t, utils.Calls, 2) {
+			assert.Equal(t, mock.ExecCall{Exec: "terraform", Params: []string{"plan", "-detailed-exitcode", "-var-file=/tmp/test", "-no-color"}}, utils.Calls[1])
+		}
+	})
+
+	t.Run("drift detected", func(t *testing.T) {
+		t.Parallel()
+		config := terraformExecuteOptions{Command: "drift"}
+		utils := newTerraformExecuteTestsUtils()
+		utils.ShouldFailOnCommand = map[string]error{"terraform plan": fmt.Errorf("exit status 2")}
+		utils.ExitCode = 2
+
+		err := runTerraformExecute(&config, nil, utils, &terraformExecuteCommonPipelineEnvironment{})
+
+		assert.EqualError(t, err, "drift detected: terraform plan reports changes, the infrastructure differs from its configuration")
+	})
+
+	t.Run("plan failure", func(t *testing.T) {
+		t.Parallel()
+		config := terraformExecuteOptions{Command: "drift"}
+		utils := newTerraformExecuteTestsUtils()
+		utils.ShouldFailOnCommand = map[string]error{"terraform plan": fmt.Errorf("exit status 1")}
+		utils.ExitCode = 1
+
+		err := runTerraformExecute(&config, nil, utils, &terraformExecuteCommonPipelineEnvironment{})
+
+		assert.EqualError(t, err, "exit status 1")
+	})
+}
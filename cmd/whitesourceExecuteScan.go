@@ -12,6 +12,7 @@ import (
 	"time"
 
 	piperDocker "github.com/SAP/jenkins-library/pkg/docker"
+	piperGithub "github.com/SAP/jenkins-library/pkg/github"
 	piperhttp "github.com/SAP/jenkins-library/pkg/http"
 	ws "github.com/SAP/jenkins-library/pkg/whitesource"
 
@@ -475,14 +476,27 @@ func executeScan(config *ScanOptions, scan *ws.Scan, utils whitesourceUtils) err
 }
 
 func checkPolicyViolations(config *ScanOptions, scan *ws.Scan, sys whitesource, utils whitesourceUtils, reportPaths []piperutils.Path, influx *whitesourceExecuteScanInflux) (piperutils.Path, error) {
+	triage, err := reporting.ReadTriage(config.TriageFile, utils)
+	if err != nil {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return piperutils.Path{}, err
+	}
 
 	policyViolationCount := 0
+	policyAlerts := []ws.Alert{}
 	for _, project := range scan.ScannedProjects() {
 		alerts, err := sys.GetProjectAlertsByType(project.Token, "REJECTED_BY_POLICY_RESOURCE")
 		if err != nil {
 			return piperutils.Path{}, fmt.Errorf("failed to retrieve project policy alerts from WhiteSource: %w", err)
 		}
-		policyViolationCount += len(alerts)
+		for _, alert := range alerts {
+			if triage.Suppresses(policyViolationFinding(alert)) {
+				log.Entry().Infof("Policy violation of library %v has been suppressed via triage file", alert.Library.Name)
+				continue
+			}
+			policyViolationCount++
+		}
+		policyAlerts = append(policyAlerts, alerts...)
 	}
 
 	violations := struct {
@@ -512,7 +526,8 @@ func checkPolicyViolations(config *ScanOptions, scan *ws.Scan, sys whitesource,
 
 	// create a json report to be used later, e.g. issue creation in GitHub
 	ipReport := reporting.ScanReport{
-		Title: "WhiteSource IP Report",
+		StepName: "whitesourceExecuteScan",
+		Title:    "WhiteSource IP Report",
 		Subheaders: []reporting.Subheader{
 			{Description: "WhiteSource product name", Details: config.ProductName},
 			{Description: "Filtered project names", Details: strings.Join(scan.ScannedProjectNames(), ", ")},
@@ -523,6 +538,10 @@ func checkPolicyViolations(config *ScanOptions, scan *ws.Scan, sys whitesource,
 		SuccessfulScan: policyViolationCount == 0,
 		ReportTime:     utils.Now(),
 	}
+	for _, alert := range policyAlerts {
+		ipReport.AddFinding(policyViolationFinding(alert))
+	}
+	triage.Apply(&ipReport)
 
 	// JSON reports are used by step pipelineCreateSummary in order to e.g. prepare an issue creation in GitHub
 	// ignore JSON errors since structure is in our hands
@@ -540,6 +559,8 @@ func checkPolicyViolations(config *ScanOptions, scan *ws.Scan, sys whitesource,
 	// since it is just an intermediary report used as input for later
 	// and there does not seem to be real benefit in archiving it.
 
+	syncResultIssues(config, fmt.Sprintf("whitesourceExecuteScan_ip_%v", reportSha(config, scan)), &ipReport)
+
 	if policyViolationCount > 0 {
 		log.SetErrorCategory(log.ErrorCompliance)
 		influx.whitesource_data.fields.policy_violations = policyViolationCount
@@ -549,6 +570,22 @@ func checkPolicyViolations(config *ScanOptions, scan *ws.Scan, sys whitesource,
 	return policyReport, nil
 }
 
+// policyViolationFinding represents a library which has been rejected by a WhiteSource policy, e.g. due to an unapproved license
+func policyViolationFinding(alert ws.Alert) reporting.Finding {
+	component := alert.Library.Name
+	if len(alert.Library.ArtifactID) > 0 {
+		component = fmt.Sprintf("%v:%v:%v", alert.Library.GroupID, alert.Library.ArtifactID, alert.Library.Version)
+	}
+	return reporting.Finding{
+		RuleID:    "REJECTED_BY_POLICY_RESOURCE",
+		Title:     "Library rejected by policy",
+		Severity:  reporting.SeverityHigh,
+		Category:  reporting.CategoryLicense,
+		Component: component,
+		Location:  alert.Library.Filename,
+	}
+}
+
 func checkSecurityViolations(config *ScanOptions, scan *ws.Scan, sys whitesource, utils whitesourceUtils, influx *whitesourceExecuteScanInflux) ([]piperutils.Path, error) {
 	var reportPaths []piperutils.Path
 	// Check for security vulnerabilities and fail the build if cvssSeverityLimit threshold is crossed
@@ -559,12 +596,17 @@ func checkSecurityViolations(config *ScanOptions, scan *ws.Scan, sys whitesource
 		return reportPaths, fmt.Errorf("failed to parse parameter cvssSeverityLimit (%s) "+
 			"as floating point number: %w", config.CvssSeverityLimit, err)
 	}
+	triage, err := reporting.ReadTriage(config.TriageFile, utils)
+	if err != nil {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return reportPaths, err
+	}
 
 	if config.ProjectToken != "" {
 		project := ws.Project{Name: config.ProjectName, Token: config.ProjectToken}
 		// ToDo: see if HTML report generation is really required here
 		// we anyway need to do some refactoring here since config.ProjectToken != "" essentially indicates an aggregated project
-		if _, _, err := checkProjectSecurityViolations(cvssSeverityLimit, project, sys, influx); err != nil {
+		if _, _, err := checkProjectSecurityViolations(cvssSeverityLimit, project, sys, influx, triage); err != nil {
 			return reportPaths, err
 		}
 	} else {
@@ -573,18 +615,19 @@ func checkSecurityViolations(config *ScanOptions, scan *ws.Scan, sys whitesource
 		allAlerts := []ws.Alert{}
 		for _, project := range scan.ScannedProjects() {
 			// collect errors and aggregate vulnerabilities from all projects
-			if vulCount, alerts, err := checkProjectSecurityViolations(cvssSeverityLimit, project, sys, influx); err != nil {
+			if vulCount, alerts, err := checkProjectSecurityViolations(cvssSeverityLimit, project, sys, influx, triage); err != nil {
 				allAlerts = append(allAlerts, alerts...)
 				vulnerabilitiesCount += vulCount
 				errorsOccured = append(errorsOccured, fmt.Sprint(err))
 			}
 		}
 
-		scanReport := createCustomVulnerabilityReport(config, scan, allAlerts, cvssSeverityLimit, utils)
+		scanReport := createCustomVulnerabilityReport(config, scan, allAlerts, cvssSeverityLimit, utils, triage)
 		reportPaths, err = writeCustomVulnerabilityReports(config, scan, scanReport, utils)
 		if err != nil {
 			errorsOccured = append(errorsOccured, fmt.Sprint(err))
 		}
+		syncResultIssues(config, fmt.Sprintf("whitesourceExecuteScan_oss_%v", reportSha(config, scan)), &scanReport)
 
 		if len(errorsOccured) > 0 {
 			if vulnerabilitiesCount > 0 {
@@ -597,14 +640,15 @@ func checkSecurityViolations(config *ScanOptions, scan *ws.Scan, sys whitesource
 }
 
 // checkSecurityViolations checks security violations and returns an error if the configured severity limit is crossed.
-func checkProjectSecurityViolations(cvssSeverityLimit float64, project ws.Project, sys whitesource, influx *whitesourceExecuteScanInflux) (int, []ws.Alert, error) {
+// Vulnerabilities suppressed via the triage file do not count as severe.
+func checkProjectSecurityViolations(cvssSeverityLimit float64, project ws.Project, sys whitesource, influx *whitesourceExecuteScanInflux, triage *reporting.Triage) (int, []ws.Alert, error) {
 	// get project alerts (vulnerabilities)
 	alerts, err := sys.GetProjectAlertsByType(project.Token, "SECURITY_VULNERABILITY")
 	if err != nil {
 		return 0, alerts, fmt.Errorf("failed to retrieve project alerts from WhiteSource: %w", err)
 	}
 
-	severeVulnerabilities, nonSevereVulnerabilities := countSecurityVulnerabilities(&alerts, cvssSeverityLimit)
+	severeVulnerabilities, nonSevereVulnerabilities := countSecurityVulnerabilities(&alerts, cvssSeverityLimit, triage)
 	influx.whitesource_data.fields.minor_vulnerabilities = nonSevereVulnerabilities
 	influx.whitesource_data.fields.major_vulnerabilities = severeVulnerabilities
 	influx.whitesource_data.fields.vulnerabilities = nonSevereVulnerabilities + severeVulnerabilities
@@ -626,10 +670,10 @@ func checkProjectSecurityViolations(cvssSeverityLimit float64, project ws.Projec
 	return 0, alerts, nil
 }
 
-func countSecurityVulnerabilities(alerts *[]ws.Alert, cvssSeverityLimit float64) (int, int) {
+func countSecurityVulnerabilities(alerts *[]ws.Alert, cvssSeverityLimit float64, triage *reporting.Triage) (int, int) {
 	severeVulnerabilities := 0
 	for _, alert := range *alerts {
-		if isSevereVulnerability(alert, cvssSeverityLimit) {
+		if isSevereVulnerability(alert, cvssSeverityLimit) && !triage.Suppresses(vulnerabilityFinding(alert)) {
 			severeVulnerabilities++
 		}
 	}
@@ -646,9 +690,9 @@ func isSevereVulnerability(alert ws.Alert, cvssSeverityLimit float64) bool {
 	return false
 }
 
-func createCustomVulnerabilityReport(config *ScanOptions, scan *ws.Scan, alerts []ws.Alert, cvssSeverityLimit float64, utils whitesourceUtils) reporting.ScanReport {
+func createCustomVulnerabilityReport(config *ScanOptions, scan *ws.Scan, alerts []ws.Alert, cvssSeverityLimit float64, utils whitesourceUtils, triage *reporting.Triage) reporting.ScanReport {
 
-	severe, _ := countSecurityVulnerabilities(&alerts, cvssSeverityLimit)
+	severe, _ := countSecurityVulnerabilities(&alerts, cvssSeverityLimit, triage)
 
 	// sort according to vulnerability severity
 	sort.Slice(alerts, func(i, j int) bool {
@@ -658,7 +702,8 @@ func createCustomVulnerabilityReport(config *ScanOptions, scan *ws.Scan, alerts
 	projectNames := scan.ScannedProjectNames()
 
 	scanReport := reporting.ScanReport{
-		Title: "WhiteSource Security Vulnerability Report",
+		StepName: "whitesourceExecuteScan",
+		Title:    "WhiteSource Security Vulnerability Report",
 		Subheaders: []reporting.Subheader{
 			{Description: "WhiteSource product name", Details: config.ProductName},
 			{Description: "Filtered project names", Details: strings.Join(projectNames, ", ")},
@@ -693,7 +738,7 @@ func createCustomVulnerabilityReport(config *ScanOptions, scan *ws.Scan, alerts
 	for _, alert := range alerts {
 		var score float64
 		var scoreStyle reporting.ColumnStyle = reporting.Yellow
-		if isSevereVulnerability(alert, cvssSeverityLimit) {
+		if isSevereVulnerability(alert, cvssSeverityLimit) && !triage.Suppresses(vulnerabilityFinding(alert)) {
 			scoreStyle = reporting.Red
 		}
 		var cveVersion string
@@ -725,12 +770,30 @@ func createCustomVulnerabilityReport(config *ScanOptions, scan *ws.Scan, alerts
 		row.AddColumn(topFix, 0)
 
 		detailTable.Rows = append(detailTable.Rows, row)
+		scanReport.AddFinding(vulnerabilityFinding(alert))
 	}
 	scanReport.DetailTable = detailTable
+	triage.Apply(&scanReport)
 
 	return scanReport
 }
 
+// vulnerabilityFinding represents a security vulnerability of a library
+func vulnerabilityFinding(alert ws.Alert) reporting.Finding {
+	score := vulnerabilityScore(alert)
+	return reporting.Finding{
+		RuleID:      alert.Vulnerability.Name,
+		Title:       alert.Vulnerability.Name,
+		Description: alert.Vulnerability.Description,
+		Severity:    reporting.SeverityFromScore(score),
+		Category:    reporting.CategoryVulnerability,
+		Score:       score,
+		Component:   fmt.Sprintf("%v:%v:%v", alert.Library.GroupID, alert.Library.ArtifactID, alert.Library.Version),
+		Location:    alert.Library.Filename,
+		URL:         alert.Vulnerability.URL,
+	}
+}
+
 func writeCustomVulnerabilityReports(config *ScanOptions, scan *ws.Scan, scanReport reporting.ScanReport, utils whitesourceUtils) ([]piperutils.Path, error) {
 	reportPaths := []piperutils.Path{}
 
@@ -769,6 +832,22 @@ func vulnerabilityScore(alert ws.Alert) float64 {
 	return alert.Vulnerability.Score
 }
 
+// syncResultIssues keeps the GitHub issues of the findings of the report in sync if configured,
+// the scope matches the name of the json report
+func syncResultIssues(config *ScanOptions, scope string, scanReport *reporting.ScanReport) {
+	if !config.CreateResultIssue {
+		return
+	}
+	options := piperGithub.ResultIssueOptions{
+		Owner:      config.Owner,
+		Repository: config.Repository,
+		Scope:      scope,
+		Labels:     config.IssueLabels,
+		Assignees:  config.IssueAssignees,
+	}
+	syncScanReportIssues(config.GithubToken, config.GithubAPIURL, "", options, scanReport)
+}
+
 func reportSha(config *ScanOptions, scan *ws.Scan) string {
 	reportShaData := []byte(config.ProductName + "," + strings.Join(scan.ScannedProjectNames(), ","))
 	return fmt.Sprintf("%x", sha1.Sum(reportShaData))
@@ -947,7 +1026,6 @@ func persistScannedProjects(config *ScanOptions, scan *ws.Scan, commonPipelineEn
 }
 
 // create toolrecord file for whitesource
-//
 func createToolRecordWhitesource(workspace string, config *whitesourceExecuteScanOptions, scan *ws.Scan) (string, error) {
 	record := toolrecord.New(workspace, "whitesource", config.ServiceURL)
 	wsUiRoot := "https://saas.whitesourcesoftware.com"
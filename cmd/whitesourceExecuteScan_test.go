@@ -1,12 +1,16 @@
 package cmd
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
 	"time"
 
+	piperGithub "github.com/SAP/jenkins-library/pkg/github"
+	"github.com/SAP/jenkins-library/pkg/log"
 	"github.com/SAP/jenkins-library/pkg/mock"
 	"github.com/SAP/jenkins-library/pkg/piperenv"
 	"github.com/SAP/jenkins-library/pkg/piperutils"
@@ -476,6 +480,35 @@ func TestCheckPolicyViolations(t *testing.T) {
 		content := string(fileContent)
 		assert.Contains(t, content, `"policyViolations":2`)
 		assert.Contains(t, content, `"reports":["report1.pdf","report2.pdf"]`)
+
+		ipReports, _ := utilsMock.Glob(filepath.Join(reporting.StepReportDirectory, "whitesourceExecuteScan_ip_*.json"))
+		if assert.Equal(t, 1, len(ipReports)) {
+			ipReportContent, _ := utilsMock.FileRead(ipReports[0])
+			ipReport := reporting.ScanReport{}
+			assert.NoError(t, json.Unmarshal(ipReportContent, &ipReport))
+			if assert.Equal(t, 2, len(ipReport.Findings)) {
+				assert.Equal(t, reporting.CategoryLicense, ipReport.Findings[0].Category)
+				assert.Equal(t, reporting.SeverityHigh, ipReport.Findings[0].Severity)
+			}
+		}
+	})
+
+	t.Run("success - policy violation suppressed via triage file", func(t *testing.T) {
+		config := ScanOptions{TriageFile: ".pipeline/triage.yml"}
+		scan := newWhitesourceScan(&config)
+		scan.AppendScannedProject("testProject1")
+		systemMock := ws.NewSystemMock("ignored")
+		systemMock.Alerts = []ws.Alert{
+			{Library: ws.Library{Name: "log4j-core-2.14.1.jar", GroupID: "org.apache.logging.log4j", ArtifactID: "log4j-core", Version: "2.14.1"}},
+		}
+		utilsMock := newWhitesourceUtilsMock()
+		utilsMock.AddFile(".pipeline/triage.yml", []byte("suppressions:\n  - id: REJECTED_BY_POLICY_RESOURCE\n    component: org.apache.logging.log4j:log4j-core\n    justification: license approved by legal\n"))
+		influx := whitesourceExecuteScanInflux{}
+
+		path, err := checkPolicyViolations(&config, scan, systemMock, utilsMock, []piperutils.Path{}, &influx)
+		assert.NoError(t, err)
+		fileContent, _ := utilsMock.FileRead(path.Target)
+		assert.Contains(t, string(fileContent), `"policyViolations":0`)
 	})
 
 	t.Run("error - get alerts", func(t *testing.T) {
@@ -598,6 +631,40 @@ func TestCheckSecurityViolations(t *testing.T) {
 		assert.True(t, len(fileContent) > 0)
 	})
 
+	t.Run("success - vulnerability suppressed via triage file", func(t *testing.T) {
+		config := ScanOptions{
+			CvssSeverityLimit: "5",
+			TriageFile:        ".pipeline/triage.yml",
+		}
+		scan := newWhitesourceScan(&config)
+		scan.AppendScannedProject("testProject1")
+		systemMock := ws.NewSystemMock("ignored")
+		systemMock.Alerts = []ws.Alert{
+			{Vulnerability: ws.Vulnerability{Name: "CVE-2021-23337", CVSS3Score: 7.2}},
+		}
+		utilsMock := newWhitesourceUtilsMock()
+		utilsMock.AddFile(".pipeline/triage.yml", []byte("suppressions:\n  - id: CVE-2021-23337\n    justification: template function is not used\n"))
+		influx := whitesourceExecuteScanInflux{}
+
+		_, err := checkSecurityViolations(&config, scan, systemMock, utilsMock, &influx)
+		assert.NoError(t, err)
+	})
+
+	t.Run("error - invalid triage file", func(t *testing.T) {
+		config := ScanOptions{
+			CvssSeverityLimit: "5",
+			TriageFile:        ".pipeline/triage.yml",
+		}
+		scan := newWhitesourceScan(&config)
+		systemMock := ws.NewSystemMock("ignored")
+		utilsMock := newWhitesourceUtilsMock()
+		utilsMock.AddFile(".pipeline/triage.yml", []byte("suppressions:\n  - id: CVE-2021-23337\n"))
+		influx := whitesourceExecuteScanInflux{}
+
+		_, err := checkSecurityViolations(&config, scan, systemMock, utilsMock, &influx)
+		assert.EqualError(t, err, "invalid triage file '.pipeline/triage.yml': suppression of CVE-2021-23337 has no justification")
+	})
+
 	t.Run("error - aggregated", func(t *testing.T) {
 		config := ScanOptions{
 			CvssSeverityLimit: "5",
@@ -625,7 +692,7 @@ func TestCheckProjectSecurityViolations(t *testing.T) {
 		systemMock.Alerts = []ws.Alert{}
 		influx := whitesourceExecuteScanInflux{}
 
-		severeVulnerabilities, alerts, err := checkProjectSecurityViolations(7.0, project, systemMock, &influx)
+		severeVulnerabilities, alerts, err := checkProjectSecurityViolations(7.0, project, systemMock, &influx, nil)
 		assert.NoError(t, err)
 		assert.Equal(t, 0, severeVulnerabilities)
 		assert.Equal(t, 0, len(alerts))
@@ -639,7 +706,7 @@ func TestCheckProjectSecurityViolations(t *testing.T) {
 		}
 		influx := whitesourceExecuteScanInflux{}
 
-		severeVulnerabilities, alerts, err := checkProjectSecurityViolations(7.0, project, systemMock, &influx)
+		severeVulnerabilities, alerts, err := checkProjectSecurityViolations(7.0, project, systemMock, &influx, nil)
 		assert.Contains(t, fmt.Sprint(err), "1 Open Source Software Security vulnerabilities")
 		assert.Equal(t, 1, severeVulnerabilities)
 		assert.Equal(t, 2, len(alerts))
@@ -650,7 +717,7 @@ func TestCheckProjectSecurityViolations(t *testing.T) {
 		systemMock.AlertError = fmt.Errorf("failed to read alerts")
 		influx := whitesourceExecuteScanInflux{}
 
-		_, _, err := checkProjectSecurityViolations(7.0, project, systemMock, &influx)
+		_, _, err := checkProjectSecurityViolations(7.0, project, systemMock, &influx, nil)
 		assert.Contains(t, fmt.Sprint(err), "failed to retrieve project alerts from WhiteSource")
 	})
 
@@ -665,9 +732,15 @@ func TestCountSecurityVulnerabilities(t *testing.T) {
 		{Vulnerability: ws.Vulnerability{CVSS3Score: 6}},
 	}
 
-	severe, nonSevere := countSecurityVulnerabilities(&alerts, 7.0)
+	severe, nonSevere := countSecurityVulnerabilities(&alerts, 7.0, nil)
 	assert.Equal(t, 2, severe)
 	assert.Equal(t, 1, nonSevere)
+
+	alerts[0].Vulnerability.Name = "CVE-2021-44228"
+	triage := &reporting.Triage{Suppressions: []reporting.Suppression{{ID: "CVE-2021-44228", Justification: "JNDI lookups are disabled"}}}
+	severe, nonSevere = countSecurityVulnerabilities(&alerts, 7.0, triage)
+	assert.Equal(t, 1, severe)
+	assert.Equal(t, 2, nonSevere)
 }
 
 func TestIsSevereVulnerability(t *testing.T) {
@@ -707,7 +780,7 @@ func TestCreateCustomVulnerabilityReport(t *testing.T) {
 		}
 		utilsMock := newWhitesourceUtilsMock()
 
-		scanReport := createCustomVulnerabilityReport(config, scan, alerts, 7.0, utilsMock)
+		scanReport := createCustomVulnerabilityReport(config, scan, alerts, 7.0, utilsMock, nil)
 
 		assert.Equal(t, "WhiteSource Security Vulnerability Report", scanReport.Title)
 		assert.Equal(t, 3, len(scanReport.DetailTable.Rows))
@@ -732,6 +805,12 @@ func TestCreateCustomVulnerabilityReport(t *testing.T) {
 
 		assert.Contains(t, scanReport.DetailTable.Rows[0].Columns[10].Content, "this is the top fix")
 
+		// assert that findings are available for SARIF/JUnit output
+		assert.Equal(t, 3, len(scanReport.Findings))
+		assert.Equal(t, "vul2", scanReport.Findings[0].Location)
+		assert.Equal(t, reporting.SeverityHigh, scanReport.Findings[0].Severity)
+		assert.Equal(t, 8.0, scanReport.Findings[0].Score)
+		assert.Equal(t, reporting.SeverityMedium, scanReport.Findings[2].Severity)
 	})
 }
 
@@ -908,3 +987,40 @@ func TestPersistScannedProjects(t *testing.T) {
 		assert.Equal(t, []string{"project - 1"}, cpe.custom.whitesourceProjectNames)
 	})
 }
+
+func TestSyncResultIssues(t *testing.T) {
+	logBuffer := new(bytes.Buffer)
+	logOutput := log.Entry().Logger.Out
+	log.Entry().Logger.Out = logBuffer
+	defer func() { log.Entry().Logger.Out = logOutput }()
+
+	t.Run("disabled", func(t *testing.T) {
+		logBuffer.Reset()
+		// init
+		config := &ScanOptions{}
+		// test
+		syncResultIssues(config, "whitesourceExecuteScan_oss_abc", &reporting.ScanReport{})
+		// assert
+		assert.Empty(t, logBuffer.String())
+	})
+	t.Run("repository missing", func(t *testing.T) {
+		logBuffer.Reset()
+		defer resetEnv(os.Environ())
+		os.Clearenv()
+		// init
+		config := &ScanOptions{CreateResultIssue: true, Owner: "SAP", GithubAPIURL: "https://api.github.com"}
+		// test
+		syncResultIssues(config, "whitesourceExecuteScan_oss_abc", &reporting.ScanReport{})
+		// assert
+		assert.Contains(t, logBuffer.String(), "Failed to synchronize the GitHub issues of the findings")
+		assert.Contains(t, logBuffer.String(), "GitHub owner 'SAP' or repository '' missing")
+	})
+	t.Run("pull request scan", func(t *testing.T) {
+		logBuffer.Reset()
+		// test
+		syncScanReportIssues("token", "https://api.github.com", "PR-42", piperGithub.ResultIssueOptions{Owner: "SAP"}, &reporting.ScanReport{})
+		// assert
+		assert.Contains(t, logBuffer.String(), "GitHub issues of the findings are not synchronized for pull request scans")
+		assert.NotContains(t, logBuffer.String(), "Failed to synchronize")
+	})
+}
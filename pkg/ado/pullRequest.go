@@ -0,0 +1,174 @@
+package ado
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/git"
+	"github.com/pkg/errors"
+)
+
+// PullRequestClient proposes changes to Azure Repos via pull requests
+type PullRequestClient interface {
+	CreateOrGetPullRequest(options PullRequestOptions) (string, error)
+}
+
+// pullRequestGitClient is the part of the Azure DevOps git client required to handle pull requests
+type pullRequestGitClient interface {
+	CreatePullRequest(context.Context, git.CreatePullRequestArgs) (*git.GitPullRequest, error)
+	GetPullRequests(context.Context, git.GetPullRequestsArgs) (*[]git.GitPullRequest, error)
+	UpdatePullRequest(context.Context, git.UpdatePullRequestArgs) (*git.GitPullRequest, error)
+}
+
+type PullRequestClientImpl struct {
+	ctx       context.Context
+	gitClient pullRequestGitClient
+}
+
+// PullRequestOptions defines the pull request to be created
+type PullRequestOptions struct {
+	Project      string
+	Repository   string
+	SourceBranch string
+	TargetBranch string
+	Title        string
+	Description  string
+	// AutoComplete lets Azure DevOps complete the pull request as soon as all policies like required builds pass
+	AutoComplete bool
+	// MergeStrategy used for the completion, one of merge, squash or rebase
+	MergeStrategy string
+}
+
+var mergeStrategies = map[string]git.GitPullRequestMergeStrategy{
+	"merge":  git.GitPullRequestMergeStrategyValues.NoFastForward,
+	"squash": git.GitPullRequestMergeStrategyValues.Squash,
+	"rebase": git.GitPullRequestMergeStrategyValues.Rebase,
+}
+
+//CreateOrGetPullRequest returns the URL of the active pull request from source to target branch, a new pull request is created in case there is none
+func (pc *PullRequestClientImpl) CreateOrGetPullRequest(options PullRequestOptions) (string, error) {
+	sourceRefName := "refs/heads/" + options.SourceBranch
+	targetRefName := "refs/heads/" + options.TargetBranch
+	status := git.PullRequestStatusValues.Active
+
+	pullRequests, err := pc.gitClient.GetPullRequests(pc.ctx, git.GetPullRequestsArgs{
+		Project:      &options.Project,
+		RepositoryId: &options.Repository,
+		SearchCriteria: &git.GitPullRequestSearchCriteria{
+			SourceRefName: &sourceRefName,
+			TargetRefName: &targetRefName,
+			Status:        &status,
+		},
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "error: get pull requests failed")
+	}
+
+	var pullRequest *git.GitPullRequest
+	if pullRequests != nil && len(*pullRequests) > 0 {
+		pullRequest = &(*pullRequests)[0]
+	} else {
+		pullRequest, err = pc.gitClient.CreatePullRequest(pc.ctx, git.CreatePullRequestArgs{
+			Project:      &options.Project,
+			RepositoryId: &options.Repository,
+			GitPullRequestToCreate: &git.GitPullRequest{
+				SourceRefName: &sourceRefName,
+				TargetRefName: &targetRefName,
+				Title:         &options.Title,
+				Description:   &options.Description,
+			},
+		})
+		if err != nil {
+			return "", errors.Wrap(err, "error: create pull request failed")
+		}
+	}
+	if pullRequest.PullRequestId == nil {
+		return "", errors.New("error: pull request id missing")
+	}
+
+	if options.AutoComplete {
+		if err := pc.setAutoComplete(pullRequest, options); err != nil {
+			return "", err
+		}
+	}
+
+	return pullRequestURL(pullRequest), nil
+}
+
+func (pc *PullRequestClientImpl) setAutoComplete(pullRequest *git.GitPullRequest, options PullRequestOptions) error {
+	if pullRequest.CreatedBy == nil || pullRequest.CreatedBy.Id == nil {
+		return errors.New("error: creator of pull request missing")
+	}
+	mergeStrategy, ok := mergeStrategies[options.MergeStrategy]
+	if !ok {
+		mergeStrategy = git.GitPullRequestMergeStrategyValues.NoFastForward
+	}
+	deleteSourceBranch := true
+	_, err := pc.gitClient.UpdatePullRequest(pc.ctx, git.UpdatePullRequestArgs{
+		Project:       &options.Project,
+		RepositoryId:  &options.Repository,
+		PullRequestId: pullRequest.PullRequestId,
+		GitPullRequestToUpdate: &git.GitPullRequest{
+			AutoCompleteSetBy: pullRequest.CreatedBy,
+			CompletionOptions: &git.GitPullRequestCompletionOptions{
+				DeleteSourceBranch: &deleteSourceBranch,
+				MergeStrategy:      &mergeStrategy,
+			},
+		},
+	})
+	if err != nil {
+		return errors.Wrapf(err, "error: enable auto-complete of pull request %v failed", *pullRequest.PullRequestId)
+	}
+	return nil
+}
+
+func pullRequestURL(pullRequest *git.GitPullRequest) string {
+	if pullRequest.Repository != nil && pullRequest.Repository.WebUrl != nil {
+		return fmt.Sprintf("%v/pullrequest/%v", *pullRequest.Repository.WebUrl, *pullRequest.PullRequestId)
+	}
+	if pullRequest.Url != nil {
+		return *pullRequest.Url
+	}
+	return ""
+}
+
+//RepositoryFromURL returns organization URL, project and repository of an Azure Repos URL like https://dev.azure.com/organization/project/_git/repository
+func RepositoryFromURL(repositoryURL string) (string, string, string, error) {
+	parsedURL, err := url.Parse(repositoryURL)
+	if err != nil {
+		return "", "", "", errors.Wrapf(err, "error: invalid repository URL '%v'", repositoryURL)
+	}
+	parsedURL.User = nil
+	segments := strings.Split(strings.Trim(parsedURL.Path, "/"), "/")
+	if len(segments) < 3 || segments[len(segments)-2] != "_git" {
+		return "", "", "", fmt.Errorf("error: failed to determine project and repository of '%v'", repositoryURL)
+	}
+	repository := segments[len(segments)-1]
+	project := segments[len(segments)-3]
+	parsedURL.Path = "/" + strings.Join(segments[:len(segments)-3], "/")
+	return strings.TrimSuffix(parsedURL.String(), "/"), project, repository, nil
+}
+
+//NewPullRequestClient Create a client to handle pull requests of the organization
+func NewPullRequestClient(organizationURL string, personalAccessToken string) (PullRequestClient, error) {
+	if organizationURL == "" {
+		return nil, errors.New("error: organization URL must not be empty")
+	}
+	if personalAccessToken == "" {
+		return nil, errors.New("error: personal access token must not be empty")
+	}
+
+	connection := azuredevops.NewPatConnection(organizationURL, personalAccessToken)
+
+	ctx := context.Background()
+
+	gitClient, err := git.NewClient(ctx, connection)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PullRequestClientImpl{ctx: ctx, gitClient: gitClient}, nil
+}
@@ -0,0 +1,107 @@
+package ado
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/git"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/webapi"
+	"github.com/stretchr/testify/assert"
+)
+
+type pullRequestGitClientMock struct {
+	existing    []git.GitPullRequest
+	searched    *git.GitPullRequestSearchCriteria
+	created     *git.GitPullRequest
+	updated     *git.GitPullRequest
+	createError error
+}
+
+func (c *pullRequestGitClientMock) CreatePullRequest(ctx context.Context, args git.CreatePullRequestArgs) (*git.GitPullRequest, error) {
+	if c.createError != nil {
+		return nil, c.createError
+	}
+	c.created = args.GitPullRequestToCreate
+	id := 42
+	creator := "c0ffee"
+	webURL := "https://dev.azure.com/org/project/_git/gitops"
+	return &git.GitPullRequest{PullRequestId: &id, CreatedBy: &webapi.IdentityRef{Id: &creator}, Repository: &git.GitRepository{WebUrl: &webURL}}, nil
+}
+
+func (c *pullRequestGitClientMock) GetPullRequests(ctx context.Context, args git.GetPullRequestsArgs) (*[]git.GitPullRequest, error) {
+	c.searched = args.SearchCriteria
+	return &c.existing, nil
+}
+
+func (c *pullRequestGitClientMock) UpdatePullRequest(ctx context.Context, args git.UpdatePullRequestArgs) (*git.GitPullRequest, error) {
+	c.updated = args.GitPullRequestToUpdate
+	return args.GitPullRequestToUpdate, nil
+}
+
+func TestCreateOrGetPullRequest(t *testing.T) {
+	t.Parallel()
+	options := PullRequestOptions{Project: "project", Repository: "gitops", SourceBranch: "gitops/myApp-1.0", TargetBranch: "main", Title: "Updated myApp to version 1.0"}
+
+	t.Run("create pull request", func(t *testing.T) {
+		t.Parallel()
+		gitClient := &pullRequestGitClientMock{}
+		client := PullRequestClientImpl{ctx: context.Background(), gitClient: gitClient}
+
+		url, err := client.CreateOrGetPullRequest(options)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "https://dev.azure.com/org/project/_git/gitops/pullrequest/42", url)
+		assert.Equal(t, "refs/heads/gitops/myApp-1.0", *gitClient.searched.SourceRefName)
+		assert.Equal(t, "refs/heads/main", *gitClient.created.TargetRefName)
+		assert.Equal(t, "Updated myApp to version 1.0", *gitClient.created.Title)
+		assert.Nil(t, gitClient.updated)
+	})
+
+	t.Run("enable auto-complete of existing pull request", func(t *testing.T) {
+		t.Parallel()
+		id := 7
+		creator := "c0ffee"
+		apiURL := "https://dev.azure.com/org/project/_apis/git/repositories/gitops/pullRequests/7"
+		gitClient := &pullRequestGitClientMock{existing: []git.GitPullRequest{{PullRequestId: &id, CreatedBy: &webapi.IdentityRef{Id: &creator}, Url: &apiURL}}}
+		client := PullRequestClientImpl{ctx: context.Background(), gitClient: gitClient}
+		autoCompleteOptions := options
+		autoCompleteOptions.AutoComplete = true
+		autoCompleteOptions.MergeStrategy = "squash"
+
+		url, err := client.CreateOrGetPullRequest(autoCompleteOptions)
+
+		assert.NoError(t, err)
+		assert.Equal(t, apiURL, url)
+		assert.Nil(t, gitClient.created)
+		if assert.NotNil(t, gitClient.updated) {
+			assert.Equal(t, "c0ffee", *gitClient.updated.AutoCompleteSetBy.Id)
+			assert.Equal(t, git.GitPullRequestMergeStrategyValues.Squash, *gitClient.updated.CompletionOptions.MergeStrategy)
+		}
+	})
+
+	t.Run("create error", func(t *testing.T) {
+		t.Parallel()
+		client := PullRequestClientImpl{ctx: context.Background(), gitClient: &pullRequestGitClientMock{createError: errors.New("unauthorized")}}
+
+		_, err := client.CreateOrGetPullRequest(options)
+
+		assert.EqualError(t, err, "error: create pull request failed: unauthorized")
+	})
+}
+
+func TestRepositoryFromURL(t *testing.T) {
+	t.Parallel()
+	organizationURL, project, repository, err := RepositoryFromURL("https://org@dev.azure.com/org/project/_git/gitops")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://dev.azure.com/org", organizationURL)
+	assert.Equal(t, "project", project)
+	assert.Equal(t, "gitops", repository)
+
+	organizationURL, _, _, err = RepositoryFromURL("https://org.visualstudio.com/project/_git/gitops")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://org.visualstudio.com", organizationURL)
+
+	_, _, _, err = RepositoryFromURL("https://github.com/SAP/gitops")
+	assert.EqualError(t, err, "error: failed to determine project and repository of 'https://github.com/SAP/gitops'")
+}
@@ -13,6 +13,7 @@ type BuildSettings struct {
 	NpmExecuteScripts []BuildOptions `json:"npmExecuteScripts,omitempty"`
 	KanikoExecute     []BuildOptions `json:"kanikoExecute,omitempty"`
 	MtaBuild          []BuildOptions `json:"mtaBuild,omitempty"`
+	GradleBuild       []BuildOptions `json:"gradleBuild,omitempty"`
 }
 
 type BuildOptions struct {
@@ -89,6 +90,10 @@ func CreateBuildSettingsInfo(config *BuildOptions, buildTool string) (string, er
 			jsonResult, err = json.Marshal(BuildSettings{
 				MtaBuild: settings,
 			})
+		case "gradleBuild":
+			jsonResult, err = json.Marshal(BuildSettings{
+				GradleBuild: settings,
+			})
 		default:
 			return "", errors.Wrapf(err, "invalid buildTool '%s' for native build - '%s' not supported", buildTool, buildTool)
 		}
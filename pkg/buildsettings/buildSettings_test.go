@@ -29,6 +29,11 @@ func TestCreateBuildSettingsInfo(t *testing.T) {
 				buildTool: "mtaBuild",
 				expected:  "{\"mtaBuild\":[{\"profiles\":[\"release.build\"],\"publish\":true,\"globalSettingsFile\":\"http://nexus.test:8081/nexus/\"}]}",
 			},
+			{
+				config:    BuildOptions{Publish: true},
+				buildTool: "gradleBuild",
+				expected:  "{\"gradleBuild\":[{\"publish\":true}]}",
+			},
 		}
 
 		for _, testCase := range testTableConfig {
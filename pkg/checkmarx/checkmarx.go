@@ -162,8 +162,13 @@ type DetailedResult struct {
 
 // Query - Query Structure
 type Query struct {
-	XMLName xml.Name `xml:"Query"`
-	Results []Result `xml:"Result"`
+	XMLName  xml.Name `xml:"Query"`
+	ID       string   `xml:"id,attr"`
+	Name     string   `xml:"name,attr"`
+	Group    string   `xml:"group,attr"`
+	CweID    string   `xml:"cweId,attr"`
+	Language string   `xml:"Language,attr"`
+	Results  []Result `xml:"Result"`
 }
 
 // Result - Result Structure
@@ -172,6 +177,9 @@ type Result struct {
 	State         string   `xml:"state,attr"`
 	Severity      string   `xml:"Severity,attr"`
 	FalsePositive string   `xml:"FalsePositive,attr"`
+	FileName      string   `xml:"FileName,attr"`
+	Line          int      `xml:"Line,attr"`
+	DeepLink      string   `xml:"DeepLink,attr"`
 }
 
 // SystemInstance is the client communicating with the Checkmarx backend
@@ -0,0 +1,409 @@
+package checkmarx
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	piperHttp "github.com/SAP/jenkins-library/pkg/http"
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// oneResultsPageSize defines the number of results fetched per request from Checkmarx One
+const oneResultsPageSize = 500
+
+// OneProject - Checkmarx One project structure
+type OneProject struct {
+	ID             string   `json:"id"`
+	Name           string   `json:"name"`
+	Groups         []string `json:"groups"`
+	ApplicationIDs []string `json:"applicationIds"`
+}
+
+// OneApplication - Checkmarx One application structure, an application groups the projects of a product
+type OneApplication struct {
+	ID         string   `json:"id"`
+	Name       string   `json:"name"`
+	ProjectIDs []string `json:"projectIds"`
+}
+
+// OneScan - Checkmarx One scan structure
+type OneScan struct {
+	ID            string                `json:"id"`
+	Status        string                `json:"status"`
+	StatusDetails []OneScanStatusDetail `json:"statusDetails"`
+	Branch        string                `json:"branch"`
+	CreatedAt     string                `json:"createdAt"`
+	UpdatedAt     string                `json:"updatedAt"`
+	ProjectID     string                `json:"projectId"`
+	ProjectName   string                `json:"projectName"`
+	Initiator     string                `json:"initiator"`
+	Metadata      OneScanMetadata       `json:"metadata"`
+}
+
+// OneScanStatusDetail - status of a single engine of a Checkmarx One scan
+type OneScanStatusDetail struct {
+	Name    string `json:"name"`
+	Status  string `json:"status"`
+	Details string `json:"details"`
+}
+
+// OneScanMetadata - configuration the Checkmarx One scan has been triggered with
+type OneScanMetadata struct {
+	Configs []OneScanConfig `json:"configs"`
+}
+
+// OneScanConfig - configuration of a single engine of a Checkmarx One scan
+type OneScanConfig struct {
+	Type  string            `json:"type"`
+	Value map[string]string `json:"value"`
+}
+
+// IsIncremental returns whether the SAST engine scanned incrementally
+func (s *OneScan) IsIncremental() bool {
+	for _, config := range s.Metadata.Configs {
+		if config.Type == "sast" {
+			return config.Value["incremental"] == "true"
+		}
+	}
+	return false
+}
+
+// OneResult - single SAST result of a Checkmarx One scan
+type OneResult struct {
+	ResultHash   string          `json:"resultHash"`
+	QueryID      json.Number     `json:"queryID"`
+	QueryName    string          `json:"queryName"`
+	Group        string          `json:"group"`
+	LanguageName string          `json:"languageName"`
+	Severity     string          `json:"severity"`
+	State        string          `json:"state"`
+	Status       string          `json:"status"`
+	CweID        int             `json:"cweID"`
+	Nodes        []OneResultNode `json:"nodes"`
+}
+
+// OneResultNode - node of the data flow of a Checkmarx One result
+type OneResultNode struct {
+	FileName string `json:"fileName"`
+	Line     int    `json:"line"`
+}
+
+type oneResultsPage struct {
+	Results    []OneResult `json:"results"`
+	TotalCount int         `json:"totalCount"`
+}
+
+// OneSystemInstance is the client communicating with the Checkmarx One backend
+type OneSystemInstance struct {
+	serverURL    string
+	iamURL       string
+	tenant       string
+	apiKey       string
+	client       piperHttp.Uploader
+	uploadClient piperHttp.Uploader
+	logger       *logrus.Entry
+}
+
+// OneSystem is the interface abstraction of a specific OneSystemInstance
+type OneSystem interface {
+	GetApplicationByName(applicationName string) (OneApplication, error)
+	GetProjectByName(projectName string) (OneProject, error)
+	CreateProject(projectName, applicationID string) (OneProject, error)
+	UploadProjectSourceCode(zipFile string) (string, error)
+	ScanProject(projectID, uploadURL, branch, preset string, incremental bool) (OneScan, error)
+	GetScan(scanID string) (OneScan, error)
+	GetScans(projectID, branch string) ([]OneScan, error)
+	GetResults(scanID string) ([]OneResult, error)
+	DeepLink(projectID, scanID string) string
+}
+
+// NewOneSystemInstance returns a new Checkmarx One client for communicating with the backend.
+// The API key is exchanged for an access token at the IAM of the tenant, which is served by the server itself if iamURL is empty.
+func NewOneSystemInstance(client piperHttp.Uploader, serverURL, iamURL, tenant, apiKey string) (*OneSystemInstance, error) {
+	loggerInstance := log.Entry().WithField("package", "SAP/jenkins-library/pkg/checkmarx")
+	if len(iamURL) == 0 {
+		iamURL = serverURL
+	}
+	uploadClient := &piperHttp.Client{}
+	uploadClient.SetOptions(piperHttp.ClientOptions{TransportTimeout: time.Minute * 15})
+	sys := &OneSystemInstance{
+		serverURL:    strings.TrimSuffix(serverURL, "/"),
+		iamURL:       strings.TrimSuffix(iamURL, "/"),
+		tenant:       tenant,
+		apiKey:       apiKey,
+		client:       client,
+		uploadClient: uploadClient,
+		logger:       loggerInstance,
+	}
+
+	token, err := sys.getAccessToken()
+	if err != nil {
+		return sys, errors.Wrap(err, "Error fetching access token")
+	}
+
+	log.RegisterSecret(token)
+
+	options := piperHttp.ClientOptions{
+		Token:            token,
+		TransportTimeout: time.Minute * 15,
+	}
+	sys.client.SetOptions(options)
+
+	return sys, nil
+}
+
+func sendOneRequest(sys *OneSystemInstance, method, url string, body io.Reader, header http.Header) ([]byte, error) {
+	return sendOneRequestToURL(sys, method, fmt.Sprintf("%v/api%v", sys.serverURL, url), body, header)
+}
+
+func sendOneRequestToURL(sys *OneSystemInstance, method, url string, body io.Reader, header http.Header) ([]byte, error) {
+	response, err := sys.client.SendRequest(method, url, body, header, nil)
+	if err != nil {
+		if response != nil && response.Body != nil {
+			data, _ := ioutil.ReadAll(response.Body)
+			sys.logger.Errorf("Response body: %s", data)
+			response.Body.Close()
+		}
+		sys.logger.Errorf("HTTP request failed with error: %s", err)
+		return nil, err
+	}
+
+	data, _ := ioutil.ReadAll(response.Body)
+	sys.logger.Debugf("Valid response body: %v", string(data))
+	defer response.Body.Close()
+	return data, nil
+}
+
+func (sys *OneSystemInstance) getAccessToken() (string, error) {
+	body := url.Values{
+		"grant_type":    {"refresh_token"},
+		"client_id":     {"ast-app"},
+		"refresh_token": {sys.apiKey},
+	}
+	header := http.Header{}
+	header.Add("Content-type", "application/x-www-form-urlencoded")
+	tokenURL := fmt.Sprintf("%v/auth/realms/%v/protocol/openid-connect/token", sys.iamURL, sys.tenant)
+	data, err := sendOneRequestToURL(sys, http.MethodPost, tokenURL, strings.NewReader(body.Encode()), header)
+	if err != nil {
+		return "", err
+	}
+
+	var token AuthToken
+	if err := json.Unmarshal(data, &token); err != nil {
+		return "", errors.Wrap(err, "failed to parse access token")
+	}
+	if len(token.AccessToken) == 0 {
+		return "", fmt.Errorf("no access token received for tenant %v", sys.tenant)
+	}
+	return "Bearer " + token.AccessToken, nil
+}
+
+// GetApplicationByName returns the application with the given name, if it does not exist an empty application is returned
+func (sys *OneSystemInstance) GetApplicationByName(applicationName string) (OneApplication, error) {
+	sys.logger.Debugf("Getting application with name %v...", applicationName)
+	var response struct {
+		Applications []OneApplication `json:"applications"`
+	}
+
+	data, err := sendOneRequest(sys, http.MethodGet, fmt.Sprintf("/applications?%v", url.Values{"name": {applicationName}}.Encode()), nil, nil)
+	if err != nil {
+		return OneApplication{}, errors.Wrapf(err, "fetching application %v failed", applicationName)
+	}
+
+	json.Unmarshal(data, &response)
+	for _, application := range response.Applications {
+		if application.Name == applicationName {
+			return application, nil
+		}
+	}
+	return OneApplication{}, nil
+}
+
+// GetProjectByName returns the project with the given name, if it does not exist an empty project is returned
+func (sys *OneSystemInstance) GetProjectByName(projectName string) (OneProject, error) {
+	sys.logger.Debugf("Getting project with name %v...", projectName)
+	var response struct {
+		Projects []OneProject `json:"projects"`
+	}
+
+	data, err := sendOneRequest(sys, http.MethodGet, fmt.Sprintf("/projects?%v", url.Values{"names": {projectName}}.Encode()), nil, nil)
+	if err != nil {
+		return OneProject{}, errors.Wrapf(err, "fetching project %v failed", projectName)
+	}
+
+	json.Unmarshal(data, &response)
+	for _, project := range response.Projects {
+		if project.Name == projectName {
+			return project, nil
+		}
+	}
+	return OneProject{}, nil
+}
+
+// CreateProject creates a new project in the Checkmarx One backend, if an applicationID is provided the project is created within the application
+func (sys *OneSystemInstance) CreateProject(projectName, applicationID string) (OneProject, error) {
+	var project OneProject
+	jsonData := map[string]interface{}{
+		"name":   projectName,
+		"groups": []string{},
+		"origin": "Piper",
+	}
+
+	jsonValue, err := json.Marshal(jsonData)
+	if err != nil {
+		return project, errors.Wrapf(err, "failed to marshal project data")
+	}
+
+	header := http.Header{}
+	header.Set("Content-Type", "application/json")
+
+	path := "/projects"
+	if len(applicationID) > 0 {
+		path = fmt.Sprintf("/projects/application/%v", applicationID)
+	}
+	data, err := sendOneRequest(sys, http.MethodPost, path, bytes.NewBuffer(jsonValue), header)
+	if err != nil {
+		return project, errors.Wrapf(err, "failed to create project %v", projectName)
+	}
+
+	json.Unmarshal(data, &project)
+	return project, nil
+}
+
+// UploadProjectSourceCode uploads the zipped sources and returns the URL of the upload to be used for scanning
+func (sys *OneSystemInstance) UploadProjectSourceCode(zipFile string) (string, error) {
+	sys.logger.Debug("Starting to upload files...")
+	var response struct {
+		URL string `json:"url"`
+	}
+
+	data, err := sendOneRequest(sys, http.MethodPost, "/uploads", nil, nil)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to request upload URL")
+	}
+	json.Unmarshal(data, &response)
+	if len(response.URL) == 0 {
+		return "", fmt.Errorf("no upload URL received")
+	}
+
+	// the pre-signed upload URL must not receive the access token
+	resp, err := sys.uploadClient.UploadRequest(http.MethodPut, response.URL, zipFile, "", http.Header{}, nil, "binary")
+	if err != nil {
+		return "", errors.Wrap(err, "failed to upload zipped sources")
+	}
+	defer resp.Body.Close()
+	return response.URL, nil
+}
+
+// ScanProject triggers a SAST scan of the uploaded sources
+func (sys *OneSystemInstance) ScanProject(projectID, uploadURL, branch, preset string, incremental bool) (OneScan, error) {
+	scan := OneScan{}
+	sastConfig := map[string]string{
+		"incremental": fmt.Sprint(incremental),
+	}
+	if len(preset) > 0 {
+		sastConfig["presetName"] = preset
+	}
+	jsonData := map[string]interface{}{
+		"type": "upload",
+		"handler": map[string]string{
+			"uploadurl": uploadURL,
+			"branch":    branch,
+		},
+		"project": map[string]string{
+			"id": projectID,
+		},
+		"config": []map[string]interface{}{
+			{"type": "sast", "value": sastConfig},
+		},
+	}
+
+	jsonValue, err := json.Marshal(jsonData)
+	if err != nil {
+		return scan, errors.Wrapf(err, "failed to marshal scan data")
+	}
+
+	header := http.Header{}
+	header.Set("Content-Type", "application/json")
+	data, err := sendOneRequest(sys, http.MethodPost, "/scans", bytes.NewBuffer(jsonValue), header)
+	if err != nil {
+		return scan, errors.Wrapf(err, "failed to trigger scan of project %v", projectID)
+	}
+
+	json.Unmarshal(data, &scan)
+	return scan, nil
+}
+
+// GetScan returns the scan addressed by scanID including its status
+func (sys *OneSystemInstance) GetScan(scanID string) (OneScan, error) {
+	var scan OneScan
+
+	data, err := sendOneRequest(sys, http.MethodGet, fmt.Sprintf("/scans/%v", scanID), nil, nil)
+	if err != nil {
+		return scan, errors.Wrapf(err, "failed to get scan %v", scanID)
+	}
+
+	json.Unmarshal(data, &scan)
+	return scan, nil
+}
+
+// GetScans returns the latest scans of the branch of the project, the most recent scan first
+func (sys *OneSystemInstance) GetScans(projectID, branch string) ([]OneScan, error) {
+	var response struct {
+		Scans []OneScan `json:"scans"`
+	}
+	query := url.Values{
+		"project-id": {projectID},
+		"sort":       {"-created_at"},
+		"limit":      {"20"},
+	}
+	if len(branch) > 0 {
+		query.Set("branch", branch)
+	}
+
+	data, err := sendOneRequest(sys, http.MethodGet, fmt.Sprintf("/scans?%v", query.Encode()), nil, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to fetch scans of project %v", projectID)
+	}
+
+	json.Unmarshal(data, &response)
+	return response.Scans, nil
+}
+
+// GetResults returns all SAST results of the scan addressed by scanID
+func (sys *OneSystemInstance) GetResults(scanID string) ([]OneResult, error) {
+	results := []OneResult{}
+	for {
+		query := url.Values{
+			"scan-id": {scanID},
+			"offset":  {fmt.Sprint(len(results))},
+			"limit":   {fmt.Sprint(oneResultsPageSize)},
+		}
+		data, err := sendOneRequest(sys, http.MethodGet, fmt.Sprintf("/sast-results?%v", query.Encode()), nil, nil)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to fetch results of scan %v", scanID)
+		}
+		var page oneResultsPage
+		if err := json.Unmarshal(data, &page); err != nil {
+			return nil, errors.Wrapf(err, "failed to parse results of scan %v", scanID)
+		}
+		results = append(results, page.Results...)
+		if len(page.Results) == 0 || len(results) >= page.TotalCount {
+			return results, nil
+		}
+	}
+}
+
+// DeepLink returns the link to the SAST results of the scan in the Checkmarx One UI
+func (sys *OneSystemInstance) DeepLink(projectID, scanID string) string {
+	return fmt.Sprintf("%v/results/%v/%v/sast", sys.serverURL, projectID, scanID)
+}
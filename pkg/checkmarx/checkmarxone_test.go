@@ -0,0 +1,173 @@
+package checkmarx
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	piperHttp "github.com/SAP/jenkins-library/pkg/http"
+	"github.com/stretchr/testify/assert"
+)
+
+type oneServerMock struct {
+	server   *httptest.Server
+	requests map[string]string
+	bodies   map[string]string
+}
+
+func newOneServerMock(t *testing.T) *oneServerMock {
+	mock := &oneServerMock{requests: map[string]string{}, bodies: map[string]string{}}
+	mock.server = httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		body, _ := ioutil.ReadAll(req.Body)
+		key := req.Method + " " + req.URL.Path
+		mock.requests[key] = req.URL.RawQuery
+		mock.bodies[key] = string(body)
+		if !strings.HasSuffix(key, "/openid-connect/token") && key != "PUT /storage/upload-4711" {
+			assert.Equal(t, "Bearer access-4711", req.Header.Get("Authorization"), key)
+		}
+		rw.Header().Add("Content-Type", "application/json")
+		switch key {
+		case "POST /auth/realms/sap/protocol/openid-connect/token":
+			rw.Write([]byte(`{"access_token": "access-4711", "token_type": "Bearer", "expires_in": 1800}`))
+		case "GET /api/applications":
+			rw.Write([]byte(`{"totalCount": 1, "applications": [{"id": "app-1", "name": "Bookstore", "projectIds": ["prj-1"]}]}`))
+		case "GET /api/projects":
+			rw.Write([]byte(`{"totalCount": 2, "projects": [{"id": "prj-2", "name": "bookstore-ui-legacy"}, {"id": "prj-1", "name": "bookstore-ui", "applicationIds": ["app-1"]}]}`))
+		case "POST /api/projects/application/app-1":
+			rw.Write([]byte(`{"id": "prj-3", "name": "bookstore-backend", "applicationIds": ["app-1"]}`))
+		case "POST /api/uploads":
+			rw.Write([]byte(`{"url": "` + mock.server.URL + `/storage/upload-4711"}`))
+		case "PUT /storage/upload-4711":
+			rw.WriteHeader(http.StatusOK)
+		case "POST /api/scans":
+			rw.Write([]byte(`{"id": "scan-1", "status": "Queued", "projectId": "prj-1", "branch": "main"}`))
+		case "GET /api/scans/scan-1":
+			rw.Write([]byte(`{"id": "scan-1", "status": "Completed", "projectId": "prj-1", "branch": "main", "metadata": {"configs": [{"type": "sast", "value": {"incremental": "true"}}]}}`))
+		case "GET /api/scans":
+			rw.Write([]byte(`{"totalCount": 2, "scans": [{"id": "scan-1", "status": "Completed"}, {"id": "scan-0", "status": "Failed"}]}`))
+		case "GET /api/sast-results":
+			if req.URL.Query().Get("offset") == "0" {
+				rw.Write([]byte(`{"totalCount": 2, "results": [{"resultHash": "r1", "queryID": 5157925289005576664, "queryName": "SQL_Injection", "group": "Java_High_Risk", "severity": "HIGH", "state": "TO_VERIFY", "cweID": 89, "nodes": [{"fileName": "/src/main/java/Dao.java", "line": 42}]}]}`))
+				return
+			}
+			rw.Write([]byte(`{"totalCount": 2, "results": [{"resultHash": "r2", "queryName": "Reflected_XSS", "severity": "MEDIUM", "state": "NOT_EXPLOITABLE", "cweID": 79, "nodes": [{"fileName": "/src/main/js/app.js", "line": 12}]}]}`))
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	return mock
+}
+
+func (mock *oneServerMock) system(t *testing.T) *OneSystemInstance {
+	sys, err := NewOneSystemInstance(&piperHttp.Client{}, mock.server.URL, "", "sap", "api-key-4711")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sys
+}
+
+func TestNewOneSystemInstance(t *testing.T) {
+	mock := newOneServerMock(t)
+	defer mock.server.Close()
+
+	t.Run("success", func(t *testing.T) {
+		sys := mock.system(t)
+		assert.Equal(t, mock.server.URL, sys.iamURL)
+		assert.Equal(t, "client_id=ast-app&grant_type=refresh_token&refresh_token=api-key-4711", mock.bodies["POST /auth/realms/sap/protocol/openid-connect/token"])
+	})
+
+	t.Run("unknown tenant", func(t *testing.T) {
+		_, err := NewOneSystemInstance(&piperHttp.Client{}, mock.server.URL, mock.server.URL+"/", "other", "api-key-4711")
+		assert.Contains(t, err.Error(), "Error fetching access token")
+	})
+}
+
+func TestOneProjects(t *testing.T) {
+	mock := newOneServerMock(t)
+	defer mock.server.Close()
+	sys := mock.system(t)
+
+	t.Run("get application", func(t *testing.T) {
+		application, err := sys.GetApplicationByName("Bookstore")
+		assert.NoError(t, err)
+		assert.Equal(t, "app-1", application.ID)
+		assert.Equal(t, "name=Bookstore", mock.requests["GET /api/applications"])
+	})
+
+	t.Run("get project", func(t *testing.T) {
+		project, err := sys.GetProjectByName("bookstore-ui")
+		assert.NoError(t, err)
+		assert.Equal(t, "prj-1", project.ID)
+
+		project, err = sys.GetProjectByName("bookstore-backend")
+		assert.NoError(t, err)
+		assert.Empty(t, project.ID)
+	})
+
+	t.Run("create project in application", func(t *testing.T) {
+		project, err := sys.CreateProject("bookstore-backend", "app-1")
+		assert.NoError(t, err)
+		assert.Equal(t, "prj-3", project.ID)
+		assert.Contains(t, mock.bodies["POST /api/projects/application/app-1"], `"name":"bookstore-backend"`)
+	})
+}
+
+func TestOneScan(t *testing.T) {
+	mock := newOneServerMock(t)
+	defer mock.server.Close()
+	sys := mock.system(t)
+
+	t.Run("upload and scan", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "cxone")
+		if err != nil {
+			t.Fatal("Failed to create temporary directory")
+		}
+		defer os.RemoveAll(dir)
+		zipFile := filepath.Join(dir, "sources.zip")
+		ioutil.WriteFile(zipFile, []byte("zipped sources"), 0700)
+
+		uploadURL, err := sys.UploadProjectSourceCode(zipFile)
+		assert.NoError(t, err)
+		assert.Equal(t, mock.server.URL+"/storage/upload-4711", uploadURL)
+		assert.Equal(t, "zipped sources", mock.bodies["PUT /storage/upload-4711"])
+
+		scan, err := sys.ScanProject("prj-1", uploadURL, "main", "Checkmarx Default", true)
+		assert.NoError(t, err)
+		assert.Equal(t, "scan-1", scan.ID)
+		var request map[string]interface{}
+		json.Unmarshal([]byte(mock.bodies["POST /api/scans"]), &request)
+		assert.Equal(t, map[string]interface{}{"uploadurl": uploadURL, "branch": "main"}, request["handler"])
+		assert.Equal(t, []interface{}{map[string]interface{}{"type": "sast", "value": map[string]interface{}{"incremental": "true", "presetName": "Checkmarx Default"}}}, request["config"])
+	})
+
+	t.Run("scan status", func(t *testing.T) {
+		scan, err := sys.GetScan("scan-1")
+		assert.NoError(t, err)
+		assert.Equal(t, "Completed", scan.Status)
+		assert.True(t, scan.IsIncremental())
+
+		scans, err := sys.GetScans("prj-1", "main")
+		assert.NoError(t, err)
+		assert.Len(t, scans, 2)
+		assert.Contains(t, mock.requests["GET /api/scans"], "branch=main")
+	})
+
+	t.Run("results", func(t *testing.T) {
+		results, err := sys.GetResults("scan-1")
+		assert.NoError(t, err)
+		if assert.Len(t, results, 2) {
+			assert.Equal(t, "SQL_Injection", results[0].QueryName)
+			assert.Equal(t, "NOT_EXPLOITABLE", results[1].State)
+		}
+		assert.Contains(t, mock.requests["GET /api/sast-results"], "offset=1")
+	})
+
+	t.Run("deep link", func(t *testing.T) {
+		assert.Equal(t, mock.server.URL+"/results/prj-1/scan-1/sast", sys.DeepLink("prj-1", "scan-1"))
+	})
+}
@@ -18,7 +18,8 @@ func CreateCustomReport(data map[string]interface{}, insecure, neutral []string)
 	deepLink := fmt.Sprintf(`<a href="%v" target="_blank">Link to scan in CX UI</a>`, data["DeepLink"])
 
 	scanReport := reporting.ScanReport{
-		Title: "Checkmarx SAST Report",
+		StepName: "checkmarxExecuteScan",
+		Title:    "Checkmarx SAST Report",
 		Subheaders: []reporting.Subheader{
 			{Description: "Project name", Details: fmt.Sprint(data["ProjectName"])},
 			{Description: "Project ID", Details: fmt.Sprint(data["ProjectID"])},
@@ -100,9 +101,55 @@ func CreateCustomReport(data map[string]interface{}, insecure, neutral []string)
 	}
 	scanReport.DetailTable = detailTable
 
+	if findings, ok := data["Findings"].([]reporting.Finding); ok {
+		scanReport.Findings = findings
+	}
+
 	return scanReport
 }
 
+// ToFinding converts a result of the detailed XML report into a tool independent finding.
+// Results marked as false positive or as not exploitable are considered as suppressed.
+func ToFinding(query Query, result Result) reporting.Finding {
+	finding := reporting.Finding{
+		RuleID:     query.Name,
+		Title:      strings.ReplaceAll(query.Name, "_", " "),
+		Severity:   reporting.ParseSeverity(result.Severity),
+		Category:   reporting.CategorySAST,
+		Location:   strings.ReplaceAll(result.FileName, "\\", "/"),
+		Line:       result.Line,
+		URL:        result.DeepLink,
+		Suppressed: result.FalsePositive == "True" || result.State == "1",
+	}
+	if len(query.CweID) > 0 && query.CweID != "0" {
+		finding.Description = fmt.Sprintf("CWE-%v (%v)", query.CweID, query.Group)
+		finding.Aliases = []string{"CWE-" + query.CweID}
+	}
+	return finding
+}
+
+// OneResultToFinding converts a result of a Checkmarx One scan into a tool independent finding.
+// Results marked as not exploitable are considered as suppressed.
+func OneResultToFinding(result OneResult, deepLink string) reporting.Finding {
+	finding := reporting.Finding{
+		RuleID:     result.QueryName,
+		Title:      strings.ReplaceAll(result.QueryName, "_", " "),
+		Severity:   reporting.ParseSeverity(result.Severity),
+		Category:   reporting.CategorySAST,
+		URL:        deepLink,
+		Suppressed: result.State == "NOT_EXPLOITABLE",
+	}
+	if len(result.Nodes) > 0 {
+		finding.Location = strings.TrimPrefix(strings.ReplaceAll(result.Nodes[0].FileName, "\\", "/"), "/")
+		finding.Line = result.Nodes[0].Line
+	}
+	if result.CweID > 0 {
+		finding.Description = fmt.Sprintf("CWE-%v (%v)", result.CweID, result.Group)
+		finding.Aliases = []string{fmt.Sprintf("CWE-%v", result.CweID)}
+	}
+	return finding
+}
+
 func WriteCustomReports(scanReport reporting.ScanReport, projectName, projectID string) ([]piperutils.Path, error) {
 	utils := piperutils.Files{}
 	reportPaths := []piperutils.Path{}
@@ -139,6 +186,11 @@ func WriteCustomReports(scanReport reporting.ScanReport, projectName, projectID
 	return reportPaths, nil
 }
 
+// ResultIssueScope identifies the GitHub issues of the findings of a project, it matches the name of the json report
+func ResultIssueScope(projectName, projectID string) string {
+	return fmt.Sprintf("checkmarxExecuteScan_sast_%v", reportShaCheckmarx([]string{projectName, projectID}))
+}
+
 func reportShaCheckmarx(parts []string) string {
 	reportShaData := []byte(strings.Join(parts, ","))
 	return fmt.Sprintf("%x", sha1.Sum(reportShaData))
@@ -0,0 +1,83 @@
+package checkmarx
+
+import (
+	"testing"
+
+	"github.com/SAP/jenkins-library/pkg/reporting"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToFinding(t *testing.T) {
+	query := Query{Name: "SQL_Injection", Group: "Java_High_Risk", CweID: "89"}
+
+	t.Run("open result", func(t *testing.T) {
+		finding := ToFinding(query, Result{Severity: "High", FileName: "src\\main\\Dao.java", Line: 42, DeepLink: "https://cx/result", State: "0", FalsePositive: "False"})
+
+		assert.Equal(t, reporting.Finding{
+			RuleID:      "SQL_Injection",
+			Aliases:     []string{"CWE-89"},
+			Title:       "SQL Injection",
+			Description: "CWE-89 (Java_High_Risk)",
+			Severity:    reporting.SeverityHigh,
+			Category:    reporting.CategorySAST,
+			Location:    "src/main/Dao.java",
+			Line:        42,
+			URL:         "https://cx/result",
+		}, finding)
+	})
+
+	t.Run("not exploitable result", func(t *testing.T) {
+		finding := ToFinding(query, Result{Severity: "Medium", State: "1"})
+
+		assert.True(t, finding.Suppressed)
+		assert.Equal(t, reporting.SeverityMedium, finding.Severity)
+	})
+
+	t.Run("false positive result", func(t *testing.T) {
+		finding := ToFinding(Query{Name: "Log_Forging"}, Result{Severity: "Information", FalsePositive: "True"})
+
+		assert.True(t, finding.Suppressed)
+		assert.Empty(t, finding.Description)
+	})
+}
+
+func TestCreateCustomReport(t *testing.T) {
+	data := map[string]interface{}{
+		"High":        map[string]int{"Issues": 1},
+		"Medium":      map[string]int{},
+		"Low":         map[string]int{},
+		"Information": map[string]int{},
+		"Findings":    []reporting.Finding{{RuleID: "SQL_Injection", Severity: reporting.SeverityHigh}},
+	}
+
+	scanReport := CreateCustomReport(data, []string{"High issues"}, []string{})
+
+	assert.Equal(t, "checkmarxExecuteScan", scanReport.StepName)
+	assert.Equal(t, []reporting.Finding{{RuleID: "SQL_Injection", Severity: reporting.SeverityHigh}}, scanReport.Findings)
+}
+
+func TestOneResultToFinding(t *testing.T) {
+	result := OneResult{QueryName: "SQL_Injection", Group: "Java_High_Risk", Severity: "HIGH", State: "TO_VERIFY", CweID: 89, Nodes: []OneResultNode{{FileName: "/src/main/java/Dao.java", Line: 42}}}
+
+	finding := OneResultToFinding(result, "https://cx.one/results/prj-1/scan-1/sast")
+
+	assert.Equal(t, reporting.Finding{
+		RuleID:      "SQL_Injection",
+		Aliases:     []string{"CWE-89"},
+		Title:       "SQL Injection",
+		Description: "CWE-89 (Java_High_Risk)",
+		Severity:    reporting.SeverityHigh,
+		Category:    reporting.CategorySAST,
+		Location:    "src/main/java/Dao.java",
+		Line:        42,
+		URL:         "https://cx.one/results/prj-1/scan-1/sast",
+	}, finding)
+
+	result.State = "NOT_EXPLOITABLE"
+	assert.True(t, OneResultToFinding(result, "").Suppressed)
+}
+
+func TestResultIssueScope(t *testing.T) {
+	assert.Equal(t, "checkmarxExecuteScan_sast_"+reportShaCheckmarx([]string{"project", "4711"}), ResultIssueScope("project", "4711"))
+	assert.NotEqual(t, ResultIssueScope("project", "4711"), ResultIssueScope("project", "4712"))
+}
@@ -11,8 +11,10 @@ import (
 	"syscall"
 
 	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/opentelemetry"
 	"github.com/SAP/jenkins-library/pkg/piperutils"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // Command defines the information required for executing a call to any executable
@@ -139,7 +141,13 @@ func (c *Command) RunExecutable(executable string, params ...string) error {
 		cmd.Stdin = c.stdin
 	}
 
-	if err := c.runCmd(cmd); err != nil {
+	// parameters are not added to the span since they might contain secrets
+	_, span := opentelemetry.StartSpan(fmt.Sprintf("run %v", executable), attribute.String("command.executable", executable))
+	err := c.runCmd(cmd)
+	span.SetAttributes(attribute.Int("command.exitCode", c.exitCode))
+	opentelemetry.EndSpan(span, err)
+
+	if err != nil {
 		return errors.Wrapf(err, "running command '%v' failed", executable)
 	}
 	return nil
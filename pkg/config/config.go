@@ -234,6 +234,10 @@ func (c *Config) GetStepConfig(flagValues map[string]interface{}, paramJSON stri
 	}
 
 	stepConfig.mixinVaultConfig(parameters, c.General, c.Steps[stepName], c.Stages[stageName])
+	secretProviders, err := getSecretProvidersFromConfig(stepConfig)
+	if err != nil {
+		return StepConfig{}, err
+	}
 	// check whether vault should be skipped
 	if skip, ok := stepConfig.Config["skipVault"].(bool); !ok || !skip {
 		// fetch secrets from vault
@@ -243,10 +247,11 @@ func (c *Config) GetStepConfig(flagValues map[string]interface{}, paramJSON stri
 		}
 		if vaultClient != nil {
 			defer vaultClient.MustRevokeToken()
-			resolveAllVaultReferences(&stepConfig, vaultClient, parameters)
+			secretProviders[secretBackendVault] = vaultClient
 			resolveVaultTestCredentials(&stepConfig, vaultClient)
 		}
 	}
+	resolveAllSecretReferences(&stepConfig, secretProviders, parameters)
 
 	// finally do the condition evaluation post processing
 	for _, p := range parameters {
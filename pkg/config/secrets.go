@@ -0,0 +1,172 @@
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/SAP/jenkins-library/pkg/command"
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/secrets"
+)
+
+const (
+	secretBackend              = "secretBackend"
+	secretBackendVault         = "vault"
+	secretBackendAWS           = "aws"
+	secretBackendAzureKeyVault = "azureKeyVault"
+	secretBackendSops          = "sops"
+	awsSecretsManagerRegion    = "awsSecretsManagerRegion"
+	awsSecretsManagerPath      = "awsSecretsManagerPath"
+	azureKeyVaultURL           = "azureKeyVaultUrl"
+	azureKeyVaultPath          = "azureKeyVaultPath"
+	sopsFile                   = "sopsFile"
+	sopsPath                   = "sopsPath"
+)
+
+var (
+	secretFilter = []string{
+		secretBackend,
+		awsSecretsManagerRegion,
+		awsSecretsManagerPath,
+		azureKeyVaultURL,
+		azureKeyVaultPath,
+		sopsFile,
+		sopsPath,
+	}
+
+	// AWSSecretsManagerRootPaths are the lookup paths piper tries to use during the AWS Secrets Manager lookup.
+	// The empty path allows to look up the secret name without any prefix.
+	AWSSecretsManagerRootPaths = []string{
+		"$(awsSecretsManagerPath)",
+		"",
+	}
+
+	// AzureKeyVaultRootPaths are the lookup paths piper tries to use during the Azure Key Vault lookup.
+	// Path separators are replaced by dashes since they are not allowed in key vault secret names.
+	AzureKeyVaultRootPaths = []string{
+		"$(azureKeyVaultPath)",
+		"",
+	}
+
+	// SopsRootPaths are the lookup paths piper tries to use during the lookup in the sops file
+	SopsRootPaths = []string{
+		"$(sopsPath)",
+		"",
+	}
+
+	// secretReferenceTypes lists the types of resource references which are resolved from a secret backend.
+	// References of type vaultSecret are resolved from the backend configured via secretBackend.
+	secretReferenceTypes = []secretReferenceType{
+		{name: "vaultSecret"},
+		{name: "vaultSecretFile", file: true},
+		{name: "awsSecret", backend: secretBackendAWS},
+		{name: "awsSecretFile", backend: secretBackendAWS, file: true},
+		{name: "azureKeyVaultSecret", backend: secretBackendAzureKeyVault},
+		{name: "azureKeyVaultSecretFile", backend: secretBackendAzureKeyVault, file: true},
+		{name: "sopsSecret", backend: secretBackendSops},
+		{name: "sopsSecretFile", backend: secretBackendSops, file: true},
+	}
+)
+
+// secretProvider is implemented by all backends secrets can be fetched from
+type secretProvider interface {
+	GetKvSecret(string) (map[string]string, error)
+}
+
+type secretReferenceType struct {
+	name    string
+	backend string
+	// file defines whether the secret is written to a temporary file whose path becomes the parameter value
+	file bool
+}
+
+func getDefaultSecretBackend(config StepConfig) (string, error) {
+	backend, _ := config.Config[secretBackend].(string)
+	switch backend {
+	case "":
+		return secretBackendVault, nil
+	case secretBackendVault, secretBackendAWS, secretBackendAzureKeyVault, secretBackendSops:
+		return backend, nil
+	}
+	return "", fmt.Errorf("secret backend '%v' is not supported, use one of %v, %v, %v or %v", backend, secretBackendVault, secretBackendAWS, secretBackendAzureKeyVault, secretBackendSops)
+}
+
+// getSecretProvidersFromConfig creates the providers of all backends except Vault which are configured
+func getSecretProvidersFromConfig(config StepConfig) (map[string]secretProvider, error) {
+	if _, err := getDefaultSecretBackend(config); err != nil {
+		return nil, err
+	}
+	providers := map[string]secretProvider{}
+
+	if region, ok := config.Config[awsSecretsManagerRegion].(string); ok && region != "" {
+		client, err := secrets.NewAWSSecretsManagerClient(region)
+		if err != nil {
+			return nil, err
+		}
+		log.Entry().Infof("Fetching secrets from AWS Secrets Manager in region %s", region)
+		providers[secretBackendAWS] = client
+	}
+
+	if vaultURL, ok := config.Config[azureKeyVaultURL].(string); ok && vaultURL != "" {
+		credentials := secrets.AzureCredentials{
+			TenantID:     os.Getenv("AZURE_TENANT_ID"),
+			ClientID:     os.Getenv("AZURE_CLIENT_ID"),
+			ClientSecret: os.Getenv("AZURE_CLIENT_SECRET"),
+		}
+		if credentials.TenantID == "" || credentials.ClientID == "" || credentials.ClientSecret == "" {
+			return nil, fmt.Errorf("Azure Key Vault requires the environment variables AZURE_TENANT_ID, AZURE_CLIENT_ID and AZURE_CLIENT_SECRET")
+		}
+		log.Entry().Infof("Fetching secrets from Azure Key Vault at %s", vaultURL)
+		providers[secretBackendAzureKeyVault] = secrets.NewAzureKeyVaultClient(vaultURL, credentials)
+	}
+
+	if filePath, ok := config.Config[sopsFile].(string); ok && filePath != "" {
+		sopsClient, err := secrets.NewSopsFile(filePath, &command.Command{})
+		if err != nil {
+			return nil, err
+		}
+		log.Entry().Infof("Fetching secrets from sops file %s", filePath)
+		providers[secretBackendSops] = sopsClient
+	}
+	return providers, nil
+}
+
+func getSecretRootPaths(backend string) []string {
+	switch backend {
+	case secretBackendAWS:
+		return AWSSecretsManagerRootPaths
+	case secretBackendAzureKeyVault:
+		return AzureKeyVaultRootPaths
+	case secretBackendSops:
+		return SopsRootPaths
+	}
+	return VaultRootPaths
+}
+
+func resolveAllSecretReferences(config *StepConfig, providers map[string]secretProvider, params []StepParameters) {
+	defaultBackend, err := getDefaultSecretBackend(*config)
+	if err != nil {
+		log.Entry().WithError(err).Warn("Not resolving secret references")
+		return
+	}
+	for _, param := range params {
+		for _, refType := range secretReferenceTypes {
+			ref := param.GetReference(refType.name)
+			if ref == nil {
+				continue
+			}
+			backend := refType.backend
+			if backend == "" {
+				backend = defaultBackend
+			}
+			provider, ok := providers[backend]
+			if !ok {
+				log.Entry().Debugf("Not resolving param '%s' from %s since it is not configured", param.Name, backend)
+				continue
+			}
+			if resolveSecretReference(ref, refType, backend, config, provider, param) {
+				break
+			}
+		}
+	}
+}
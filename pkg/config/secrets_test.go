@@ -0,0 +1,113 @@
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/SAP/jenkins-library/pkg/config/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveAllSecretReferences(t *testing.T) {
+	const secretName = "testSecret"
+	const secretNameOverrideKey = "mySecretVaultSecretName"
+
+	t.Run("Load secret from configured default backend", func(t *testing.T) {
+		awsMock := &mocks.VaultMock{}
+		stepConfig := StepConfig{Config: map[string]interface{}{
+			"secretBackend":         "aws",
+			"awsSecretsManagerPath": "team1",
+		}}
+		stepParams := []StepParameters{stepParam(secretName, "vaultSecret", secretNameOverrideKey, secretName)}
+		awsMock.On("GetKvSecret", "team1/testSecret").Return(map[string]string{secretName: "value1"}, nil)
+
+		resolveAllSecretReferences(&stepConfig, map[string]secretProvider{secretBackendAWS: awsMock}, stepParams)
+		assert.Equal(t, "value1", stepConfig.Config[secretName])
+	})
+
+	t.Run("Load secret from backend selected by reference type", func(t *testing.T) {
+		vaultMock := &mocks.VaultMock{}
+		azureMock := &mocks.VaultMock{}
+		stepConfig := StepConfig{Config: map[string]interface{}{}}
+		stepParams := []StepParameters{stepParam(secretName, "azureKeyVaultSecret", secretNameOverrideKey, secretName)}
+		azureMock.On("GetKvSecret", secretName).Return(map[string]string{secretName: "value1"}, nil)
+
+		resolveAllSecretReferences(&stepConfig, map[string]secretProvider{secretBackendVault: vaultMock, secretBackendAzureKeyVault: azureMock}, stepParams)
+		assert.Equal(t, "value1", stepConfig.Config[secretName])
+		vaultMock.AssertNotCalled(t, "GetKvSecret", secretName)
+	})
+
+	t.Run("Fall back to next reference", func(t *testing.T) {
+		vaultMock := &mocks.VaultMock{}
+		sopsMock := &mocks.VaultMock{}
+		stepConfig := StepConfig{Config: map[string]interface{}{
+			"vaultPath": "team1",
+			"sopsPath":  "pipeline",
+		}}
+		param := stepParam(secretName, "vaultSecret", secretNameOverrideKey, secretName)
+		param.ResourceRef = append(param.ResourceRef, ResourceReference{Type: "sopsSecretFile", Default: "fileSecret"})
+		vaultMock.On("GetKvSecret", "team1/testSecret").Return(nil, nil)
+		sopsMock.On("GetKvSecret", "pipeline/fileSecret").Return(map[string]string{secretName: "file content"}, nil)
+
+		resolveAllSecretReferences(&stepConfig, map[string]secretProvider{secretBackendVault: vaultMock, secretBackendSops: sopsMock}, []StepParameters{param})
+		filePath, ok := stepConfig.Config[secretName].(string)
+		if assert.True(t, ok) {
+			defer os.Remove(filePath)
+			content, err := ioutil.ReadFile(filePath)
+			assert.NoError(t, err)
+			assert.Equal(t, "file content", string(content))
+		}
+	})
+
+	t.Run("Backend not configured", func(t *testing.T) {
+		stepConfig := StepConfig{Config: map[string]interface{}{}}
+		stepParams := []StepParameters{stepParam(secretName, "awsSecret", secretNameOverrideKey, secretName)}
+
+		resolveAllSecretReferences(&stepConfig, map[string]secretProvider{}, stepParams)
+		assert.Len(t, stepConfig.Config, 0)
+	})
+}
+
+func TestGetSecretProvidersFromConfig(t *testing.T) {
+	t.Run("No backend configured", func(t *testing.T) {
+		providers, err := getSecretProvidersFromConfig(StepConfig{Config: map[string]interface{}{}})
+		assert.NoError(t, err)
+		assert.Len(t, providers, 0)
+	})
+
+	t.Run("AWS Secrets Manager", func(t *testing.T) {
+		providers, err := getSecretProvidersFromConfig(StepConfig{Config: map[string]interface{}{"awsSecretsManagerRegion": "eu-central-1"}})
+		assert.NoError(t, err)
+		assert.Contains(t, providers, secretBackendAWS)
+	})
+
+	t.Run("Azure Key Vault", func(t *testing.T) {
+		os.Setenv("AZURE_TENANT_ID", "tenant")
+		os.Setenv("AZURE_CLIENT_ID", "client")
+		os.Setenv("AZURE_CLIENT_SECRET", "secret")
+		defer os.Unsetenv("AZURE_TENANT_ID")
+		defer os.Unsetenv("AZURE_CLIENT_ID")
+		defer os.Unsetenv("AZURE_CLIENT_SECRET")
+
+		providers, err := getSecretProvidersFromConfig(StepConfig{Config: map[string]interface{}{"azureKeyVaultUrl": "https://my-vault.vault.azure.net"}})
+		assert.NoError(t, err)
+		assert.Contains(t, providers, secretBackendAzureKeyVault)
+	})
+
+	t.Run("Azure Key Vault without credentials", func(t *testing.T) {
+		_, err := getSecretProvidersFromConfig(StepConfig{Config: map[string]interface{}{"azureKeyVaultUrl": "https://my-vault.vault.azure.net"}})
+		assert.EqualError(t, err, "Azure Key Vault requires the environment variables AZURE_TENANT_ID, AZURE_CLIENT_ID and AZURE_CLIENT_SECRET")
+	})
+
+	t.Run("Sops file not decryptable", func(t *testing.T) {
+		_, err := getSecretProvidersFromConfig(StepConfig{Config: map[string]interface{}{"sopsFile": "not/existing/secrets.enc.yaml"}})
+		assert.Contains(t, fmt.Sprint(err), "failed to decrypt sops file 'not/existing/secrets.enc.yaml'")
+	})
+
+	t.Run("Unsupported backend", func(t *testing.T) {
+		_, err := getSecretProvidersFromConfig(StepConfig{Config: map[string]interface{}{"secretBackend": "keepass"}})
+		assert.EqualError(t, err, "secret backend 'keepass' is not supported, use one of vault, aws, azureKeyVault or sops")
+	})
+}
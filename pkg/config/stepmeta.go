@@ -420,9 +420,11 @@ func (m *StepParameters) GetReference(refType string) *ResourceReference {
 func getFilterForResourceReferences(params []StepParameters) []string {
 	var filter []string
 	for _, param := range params {
-		reference := param.GetReference("vaultSecret")
-		if reference == nil {
-			reference = param.GetReference("vaultSecretFile")
+		var reference *ResourceReference
+		for _, refType := range secretReferenceTypes {
+			if reference = param.GetReference(refType.name); reference != nil {
+				break
+			}
 		}
 		if reference == nil {
 			return filter
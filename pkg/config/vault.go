@@ -66,13 +66,14 @@ type VaultCredentials struct {
 
 // vaultClient interface for mocking
 type vaultClient interface {
-	GetKvSecret(string) (map[string]string, error)
+	secretProvider
 	MustRevokeToken()
 }
 
 func (s *StepConfig) mixinVaultConfig(parameters []StepParameters, configs ...map[string]interface{}) {
 	for _, config := range configs {
 		s.mixIn(config, vaultFilter)
+		s.mixIn(config, secretFilter)
 		// when an empty filter is returned we skip the mixin call since an empty filter will allow everything
 		if referencesFilter := getFilterForResourceReferences(parameters); len(referencesFilter) > 0 {
 			s.mixIn(config, referencesFilter)
@@ -114,50 +115,43 @@ func getVaultClientFromConfig(config StepConfig, creds VaultCredentials) (vaultC
 }
 
 func resolveAllVaultReferences(config *StepConfig, client vaultClient, params []StepParameters) {
-	for _, param := range params {
-		if ref := param.GetReference("vaultSecret"); ref != nil {
-			resolveVaultReference(ref, config, client, param)
-		}
-		if ref := param.GetReference("vaultSecretFile"); ref != nil {
-			resolveVaultReference(ref, config, client, param)
-		}
-	}
+	resolveAllSecretReferences(config, map[string]secretProvider{secretBackendVault: client}, params)
 }
 
-func resolveVaultReference(ref *ResourceReference, config *StepConfig, client vaultClient, param StepParameters) {
+// resolveSecretReference fetches the secret of the reference from the given backend and returns whether it has been resolved
+func resolveSecretReference(ref *ResourceReference, refType secretReferenceType, backend string, config *StepConfig, client secretProvider, param StepParameters) bool {
 	vaultDisableOverwrite, _ := config.Config["vaultDisableOverwrite"].(bool)
 	if _, ok := config.Config[param.Name].(string); vaultDisableOverwrite && ok {
-		log.Entry().Debugf("Not fetching '%s' from vault since it has already been set", param.Name)
-		return
+		log.Entry().Debugf("Not fetching '%s' from %s since it has already been set", param.Name, backend)
+		return true
 	}
 
 	var secretValue *string
-	for _, vaultPath := range getSecretReferencePaths(ref, config.Config) {
+	for _, secretPath := range getSecretReferencePaths(ref, config.Config, getSecretRootPaths(backend)) {
 		// it should be possible to configure the root path were the secret is stored
-		vaultPath, ok := interpolation.ResolveString(vaultPath, config.Config)
+		secretPath, ok := interpolation.ResolveString(secretPath, config.Config)
 		if !ok {
 			continue
 		}
 
-		secretValue = lookupPath(client, vaultPath, &param)
+		secretValue = lookupPath(client, secretPath, &param)
 		if secretValue != nil {
-			log.Entry().Debugf("Resolved param '%s' with vault path '%s'", param.Name, vaultPath)
-			if ref.Type == "vaultSecret" {
-				config.Config[param.Name] = *secretValue
-			} else if ref.Type == "vaultSecretFile" {
+			log.Entry().Debugf("Resolved param '%s' with %s path '%s'", param.Name, backend, secretPath)
+			if refType.file {
 				filePath, err := createTemporarySecretFile(param.Name, *secretValue)
 				if err != nil {
 					log.Entry().WithError(err).Warnf("Couldn't create temporary secret file for '%s'", param.Name)
-					return
+					return false
 				}
 				config.Config[param.Name] = filePath
+			} else {
+				config.Config[param.Name] = *secretValue
 			}
-			break
+			return true
 		}
 	}
-	if secretValue == nil {
-		log.Entry().Warnf("Could not resolve param '%s' from vault", param.Name)
-	}
+	log.Entry().Warnf("Could not resolve param '%s' from %s", param.Name, backend)
+	return false
 }
 
 // resolve test credential keys and expose as environment variables
@@ -276,8 +270,8 @@ func createTemporarySecretFile(namePattern string, content string) (string, erro
 	return file.Name(), nil
 }
 
-func lookupPath(client vaultClient, path string, param *StepParameters) *string {
-	log.Entry().Debugf("Trying to resolve vault parameter '%s' at '%s'", param.Name, path)
+func lookupPath(client secretProvider, path string, param *StepParameters) *string {
+	log.Entry().Debugf("Trying to resolve secret parameter '%s' at '%s'", param.Name, path)
 	secret, err := client.GetKvSecret(path)
 	if err != nil {
 		log.Entry().WithError(err).Warnf("Couldn't fetch secret at '%s'", path)
@@ -308,13 +302,13 @@ func lookupPath(client vaultClient, path string, param *StepParameters) *string
 	return nil
 }
 
-func getSecretReferencePaths(reference *ResourceReference, config map[string]interface{}) []string {
-	retPaths := make([]string, 0, len(VaultRootPaths))
+func getSecretReferencePaths(reference *ResourceReference, config map[string]interface{}, rootPaths []string) []string {
+	retPaths := make([]string, 0, len(rootPaths))
 	secretName := reference.Default
 	if providedName, ok := config[reference.Name].(string); ok && providedName != "" {
 		secretName = providedName
 	}
-	for _, rootPath := range VaultRootPaths {
+	for _, rootPath := range rootPaths {
 		fullPath := path.Join(rootPath, secretName)
 		retPaths = append(retPaths, fullPath)
 	}
@@ -0,0 +1,210 @@
+package cyclonedx
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// SchemaNamespace is the XML namespace of the CycloneDX schema version used for merged SBOMs
+const SchemaNamespace = "http://cyclonedx.org/schema/bom/1.3"
+
+// Component types as defined by the CycloneDX specification
+const (
+	ComponentTypeApplication = "application"
+	ComponentTypeContainer   = "container"
+	ComponentTypeLibrary     = "library"
+)
+
+// BOM is a CycloneDX software bill of materials in XML format.
+// Elements which are not required for merging SBOMs are not part of the model.
+type BOM struct {
+	XMLName      xml.Name     `xml:"bom"`
+	Namespace    string       `xml:"xmlns,attr"`
+	SerialNumber string       `xml:"serialNumber,attr,omitempty"`
+	Version      int          `xml:"version,attr"`
+	Metadata     *Metadata    `xml:"metadata,omitempty"`
+	Components   []Component  `xml:"components>component"`
+	Dependencies []Dependency `xml:"dependencies>dependency,omitempty"`
+}
+
+// Metadata describes the SBOM and the component it has been created for
+type Metadata struct {
+	Timestamp string     `xml:"timestamp,omitempty"`
+	Tools     []Tool     `xml:"tools>tool,omitempty"`
+	Component *Component `xml:"component,omitempty"`
+}
+
+// Tool is a tool which has been used to create the SBOM
+type Tool struct {
+	Vendor  string `xml:"vendor,omitempty"`
+	Name    string `xml:"name"`
+	Version string `xml:"version,omitempty"`
+}
+
+// Component is a software component like a library, an application or a container image
+type Component struct {
+	Type        string      `xml:"type,attr"`
+	BOMRef      string      `xml:"bom-ref,attr,omitempty"`
+	Group       string      `xml:"group,omitempty"`
+	Name        string      `xml:"name"`
+	Version     string      `xml:"version,omitempty"`
+	Description string      `xml:"description,omitempty"`
+	Scope       string      `xml:"scope,omitempty"`
+	Hashes      []Hash      `xml:"hashes>hash,omitempty"`
+	Licenses    []License   `xml:"licenses>license,omitempty"`
+	Purl        string      `xml:"purl,omitempty"`
+	Components  []Component `xml:"components>component,omitempty"`
+}
+
+// Hash is the hash of a component
+type Hash struct {
+	Algorithm string `xml:"alg,attr"`
+	Value     string `xml:",chardata"`
+}
+
+// License is the license of a component, either identified by its SPDX id or by its name
+type License struct {
+	ID   string `xml:"id,omitempty"`
+	Name string `xml:"name,omitempty"`
+}
+
+// Dependency lists the components a component depends on
+type Dependency struct {
+	Ref          string       `xml:"ref,attr"`
+	Dependencies []Dependency `xml:"dependency,omitempty"`
+}
+
+// FileUtils bundles the file system functionality required for reading and writing SBOMs
+type FileUtils interface {
+	FileExists(filename string) (bool, error)
+	FileRead(path string) ([]byte, error)
+	FileWrite(path string, content []byte, perm os.FileMode) error
+}
+
+// ReadBOM reads an SBOM in CycloneDX XML format, independent of the schema version
+func ReadBOM(path string, fileUtils FileUtils) (BOM, error) {
+	bom := BOM{}
+	content, err := fileUtils.FileRead(path)
+	if err != nil {
+		return bom, errors.Wrapf(err, "failed to read SBOM '%v'", path)
+	}
+	if err := xml.Unmarshal(content, &bom); err != nil {
+		return bom, errors.Wrapf(err, "failed to parse SBOM '%v'", path)
+	}
+	return bom, nil
+}
+
+// WriteBOM writes the SBOM in CycloneDX XML format
+func WriteBOM(bom BOM, path string, fileUtils FileUtils) error {
+	content, err := xml.MarshalIndent(bom, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to create SBOM")
+	}
+	if err := fileUtils.FileWrite(path, append([]byte(xml.Header), content...), 0644); err != nil {
+		return errors.Wrapf(err, "failed to write SBOM '%v'", path)
+	}
+	return nil
+}
+
+// Merge combines the SBOMs of several modules into one SBOM describing the given component.
+// The modules become components of the merged SBOM, components contained in several SBOMs are only listed once.
+func Merge(component Component, boms ...BOM) BOM {
+	merged := BOM{
+		Namespace:    SchemaNamespace,
+		SerialNumber: "urn:uuid:" + uuid.New().String(),
+		Version:      1,
+		Metadata: &Metadata{
+			Timestamp: time.Now().UTC().Format(time.RFC3339),
+			Tools:     []Tool{{Vendor: "SAP", Name: "Project Piper"}},
+			Component: &component,
+		},
+		Components: []Component{},
+	}
+
+	knownComponents := map[string]bool{}
+	addComponent := func(c Component) {
+		key := c.key()
+		if knownComponents[key] {
+			return
+		}
+		knownComponents[key] = true
+		merged.Components = append(merged.Components, c)
+	}
+	dependencies := map[string]*Dependency{}
+	dependencyRefs := []string{}
+
+	for _, bom := range boms {
+		if bom.Metadata != nil {
+			for _, tool := range bom.Metadata.Tools {
+				if !containsTool(merged.Metadata.Tools, tool) {
+					merged.Metadata.Tools = append(merged.Metadata.Tools, tool)
+				}
+			}
+			if bom.Metadata.Component != nil {
+				addComponent(*bom.Metadata.Component)
+			}
+		}
+		for _, c := range bom.Components {
+			addComponent(c)
+		}
+		for _, dependency := range bom.Dependencies {
+			existing, ok := dependencies[dependency.Ref]
+			if !ok {
+				existing = &Dependency{Ref: dependency.Ref}
+				dependencies[dependency.Ref] = existing
+				dependencyRefs = append(dependencyRefs, dependency.Ref)
+			}
+			for _, child := range dependency.Dependencies {
+				if !containsDependency(existing.Dependencies, child.Ref) {
+					existing.Dependencies = append(existing.Dependencies, Dependency{Ref: child.Ref})
+				}
+			}
+		}
+	}
+	for _, ref := range dependencyRefs {
+		merged.Dependencies = append(merged.Dependencies, *dependencies[ref])
+	}
+	return merged
+}
+
+// Describes returns whether the SBOM has been created for the component with the given group and name
+func (b *BOM) Describes(group, name string) bool {
+	if b.Metadata == nil || b.Metadata.Component == nil {
+		return false
+	}
+	return b.Metadata.Component.Group == group && b.Metadata.Component.Name == name
+}
+
+// key identifies a component across SBOMs created by different tools
+func (c *Component) key() string {
+	if len(c.Purl) > 0 {
+		return c.Purl
+	}
+	if len(c.BOMRef) > 0 {
+		return c.BOMRef
+	}
+	return fmt.Sprintf("%v/%v@%v", c.Group, c.Name, c.Version)
+}
+
+func containsTool(tools []Tool, tool Tool) bool {
+	for _, t := range tools {
+		if t == tool {
+			return true
+		}
+	}
+	return false
+}
+
+func containsDependency(dependencies []Dependency, ref string) bool {
+	for _, d := range dependencies {
+		if d.Ref == ref {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,154 @@
+package cyclonedx
+
+import (
+	"testing"
+
+	"github.com/SAP/jenkins-library/pkg/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const mavenBOM = `<?xml version="1.0" encoding="UTF-8"?>
+<bom xmlns="http://cyclonedx.org/schema/bom/1.2" serialNumber="urn:uuid:3e671687-395b-41f5-a30f-a58921a69b79" version="1">
+  <metadata>
+    <timestamp>2021-10-01T10:00:00Z</timestamp>
+    <tools>
+      <tool>
+        <vendor>OWASP Foundation</vendor>
+        <name>CycloneDX Maven plugin</name>
+        <version>2.5.3</version>
+      </tool>
+    </tools>
+    <component type="library" bom-ref="pkg:maven/com.example/backend@1.0.0?type=jar">
+      <group>com.example</group>
+      <name>backend</name>
+      <version>1.0.0</version>
+      <purl>pkg:maven/com.example/backend@1.0.0?type=jar</purl>
+    </component>
+  </metadata>
+  <components>
+    <component type="library" bom-ref="pkg:maven/org.slf4j/slf4j-api@1.7.32?type=jar">
+      <group>org.slf4j</group>
+      <name>slf4j-api</name>
+      <version>1.7.32</version>
+      <hashes>
+        <hash alg="SHA-1">cdcff33940d9f2de763bc41ea05a0be5941176c3</hash>
+      </hashes>
+      <licenses>
+        <license>
+          <id>MIT</id>
+        </license>
+      </licenses>
+      <purl>pkg:maven/org.slf4j/slf4j-api@1.7.32?type=jar</purl>
+    </component>
+  </components>
+  <dependencies>
+    <dependency ref="pkg:maven/com.example/backend@1.0.0?type=jar">
+      <dependency ref="pkg:maven/org.slf4j/slf4j-api@1.7.32?type=jar"/>
+    </dependency>
+  </dependencies>
+</bom>
+`
+
+const npmBOM = `<?xml version="1.0" encoding="UTF-8"?>
+<bom xmlns="http://cyclonedx.org/schema/bom/1.3" serialNumber="urn:uuid:a4c6f1b2-0d3e-4f4e-b54b-1c9e2a7b6d01" version="1">
+  <metadata>
+    <component type="library" bom-ref="pkg:npm/ui@1.0.0">
+      <name>ui</name>
+      <version>1.0.0</version>
+      <purl>pkg:npm/ui@1.0.0</purl>
+    </component>
+  </metadata>
+  <components>
+    <component type="library" bom-ref="pkg:npm/lodash@4.17.21">
+      <name>lodash</name>
+      <version>4.17.21</version>
+      <purl>pkg:npm/lodash@4.17.21</purl>
+    </component>
+    <component type="library" bom-ref="pkg:maven/org.slf4j/slf4j-api@1.7.32?type=jar">
+      <group>org.slf4j</group>
+      <name>slf4j-api</name>
+      <version>1.7.32</version>
+      <purl>pkg:maven/org.slf4j/slf4j-api@1.7.32?type=jar</purl>
+    </component>
+  </components>
+  <dependencies>
+    <dependency ref="pkg:npm/ui@1.0.0">
+      <dependency ref="pkg:npm/lodash@4.17.21"/>
+    </dependency>
+  </dependencies>
+</bom>
+`
+
+func TestReadBOM(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		fileUtils := &mock.FilesMock{}
+		fileUtils.AddFile("target/bom.xml", []byte(mavenBOM))
+
+		bom, err := ReadBOM("target/bom.xml", fileUtils)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, bom.Version)
+		assert.True(t, bom.Describes("com.example", "backend"))
+		if assert.Len(t, bom.Components, 1) {
+			assert.Equal(t, "slf4j-api", bom.Components[0].Name)
+			assert.Equal(t, []License{{ID: "MIT"}}, bom.Components[0].Licenses)
+			assert.Equal(t, []Hash{{Algorithm: "SHA-1", Value: "cdcff33940d9f2de763bc41ea05a0be5941176c3"}}, bom.Components[0].Hashes)
+		}
+	})
+
+	t.Run("error - file not found", func(t *testing.T) {
+		_, err := ReadBOM("target/bom.xml", &mock.FilesMock{})
+		assert.Contains(t, err.Error(), "failed to read SBOM 'target/bom.xml'")
+	})
+
+	t.Run("error - no xml", func(t *testing.T) {
+		fileUtils := &mock.FilesMock{}
+		fileUtils.AddFile("bom.json", []byte(`{"bomFormat": "CycloneDX"}`))
+
+		_, err := ReadBOM("bom.json", fileUtils)
+		assert.Contains(t, err.Error(), "failed to parse SBOM 'bom.json'")
+	})
+}
+
+func TestMerge(t *testing.T) {
+	fileUtils := &mock.FilesMock{}
+	fileUtils.AddFile("backend/target/bom.xml", []byte(mavenBOM))
+	fileUtils.AddFile("ui/bom.xml", []byte(npmBOM))
+	backend, err := ReadBOM("backend/target/bom.xml", fileUtils)
+	require.NoError(t, err)
+	ui, err := ReadBOM("ui/bom.xml", fileUtils)
+	require.NoError(t, err)
+
+	merged := Merge(Component{Type: ComponentTypeApplication, Name: "my-mta", Version: "1.0.0"}, backend, ui)
+
+	assert.Equal(t, SchemaNamespace, merged.Namespace)
+	assert.Contains(t, merged.SerialNumber, "urn:uuid:")
+	assert.True(t, merged.Describes("", "my-mta"))
+	assert.Equal(t, []Tool{
+		{Vendor: "SAP", Name: "Project Piper"},
+		{Vendor: "OWASP Foundation", Name: "CycloneDX Maven plugin", Version: "2.5.3"},
+	}, merged.Metadata.Tools)
+
+	names := []string{}
+	for _, component := range merged.Components {
+		names = append(names, component.Name)
+	}
+	assert.Equal(t, []string{"backend", "slf4j-api", "ui", "lodash"}, names)
+	assert.Equal(t, []Dependency{
+		{Ref: "pkg:maven/com.example/backend@1.0.0?type=jar", Dependencies: []Dependency{{Ref: "pkg:maven/org.slf4j/slf4j-api@1.7.32?type=jar"}}},
+		{Ref: "pkg:npm/ui@1.0.0", Dependencies: []Dependency{{Ref: "pkg:npm/lodash@4.17.21"}}},
+	}, merged.Dependencies)
+
+	t.Run("write and read merged SBOM", func(t *testing.T) {
+		require.NoError(t, WriteBOM(merged, "my-mta.bom.xml", fileUtils))
+
+		content, err := fileUtils.FileRead("my-mta.bom.xml")
+		require.NoError(t, err)
+		assert.Contains(t, string(content), `<bom xmlns="http://cyclonedx.org/schema/bom/1.3"`)
+
+		bom, err := ReadBOM("my-mta.bom.xml", fileUtils)
+		assert.NoError(t, err)
+		assert.Equal(t, merged.Components, bom.Components)
+		assert.Equal(t, merged.Dependencies, bom.Dependencies)
+	})
+}
@@ -0,0 +1,166 @@
+package cyclonedx
+
+import (
+	"path/filepath"
+
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/maven"
+	"github.com/SAP/jenkins-library/pkg/piperutils"
+	"github.com/pkg/errors"
+)
+
+// BOMFileName is the name of the SBOM files created for build modules
+const BOMFileName = "bom.xml"
+
+// MavenGoal is the maven goal creating one SBOM for all modules of a maven project in target/bom.xml
+const MavenGoal = "org.cyclonedx:cyclonedx-maven-plugin:makeAggregateBom"
+
+// MavenDefines configure the SBOM creation of MavenGoal
+var MavenDefines = []string{
+	"-DschemaVersion=1.2",
+	"-DincludeBomSerialNumber=true",
+	"-DincludeCompileScope=true",
+	"-DincludeProvidedScope=true",
+	"-DincludeRuntimeScope=true",
+	"-DincludeSystemScope=true",
+	"-DincludeTestScope=false",
+	"-DincludeLicenseText=false",
+	"-DoutputFormat=xml",
+}
+
+const (
+	gomodModule = "github.com/CycloneDX/cyclonedx-gomod/cmd/cyclonedx-gomod@v1.1.0"
+	pythonTool  = "cyclonedx-bom==3.11.0"
+)
+
+// CreateModuleBOM creates the SBOM of the build module in the given directory based on its build descriptor.
+// Maven, npm, go and pip (requirements.txt) modules are supported, for other modules an empty path is returned.
+// Except for maven the build tool needs to be available for installing the respective CycloneDX tool.
+func CreateModuleBOM(moduleDir string, utils maven.Utils) (string, error) {
+	bomPath := filepath.Join(moduleDir, BOMFileName)
+	switch {
+	case descriptorExists(moduleDir, "pom.xml", utils):
+		log.Entry().Infof("Creating SBOM of maven module '%v'", moduleDir)
+		options := maven.ExecuteOptions{
+			PomPath: filepath.Join(moduleDir, "pom.xml"),
+			Goals:   []string{MavenGoal},
+			Defines: MavenDefines,
+		}
+		if _, err := maven.Execute(&options, utils); err != nil {
+			return "", errors.Wrapf(err, "failed to create SBOM of maven module '%v'", moduleDir)
+		}
+		return filepath.Join(moduleDir, "target", BOMFileName), nil
+
+	case descriptorExists(moduleDir, "package.json", utils):
+		log.Entry().Infof("Creating SBOM of npm module '%v'", moduleDir)
+		if err := requireTool(utils, "npm modules", "npm", "--version"); err != nil {
+			return "", err
+		}
+		if err := utils.RunExecutable("npm", "install", "@cyclonedx/bom", "--no-save"); err != nil {
+			return "", errors.Wrap(err, "failed to install CycloneDX Node.js module")
+		}
+		if err := utils.RunExecutable("npx", "cyclonedx-bom", moduleDir, "--include-license-text", "false", "--include-dev", "false", "--output", bomPath); err != nil {
+			return "", errors.Wrapf(err, "failed to create SBOM of npm module '%v'", moduleDir)
+		}
+		return bomPath, nil
+
+	case descriptorExists(moduleDir, "go.mod", utils):
+		log.Entry().Infof("Creating SBOM of go module '%v'", moduleDir)
+		if err := requireTool(utils, "go modules", "go", "version"); err != nil {
+			return "", err
+		}
+		if err := utils.RunExecutable("go", "install", gomodModule); err != nil {
+			return "", errors.Wrap(err, "failed to install cyclonedx-gomod")
+		}
+		if err := utils.RunExecutable("cyclonedx-gomod", "mod", "-licenses", "-output", bomPath, moduleDir); err != nil {
+			return "", errors.Wrapf(err, "failed to create SBOM of go module '%v'", moduleDir)
+		}
+		return bomPath, nil
+
+	case descriptorExists(moduleDir, "requirements.txt", utils):
+		log.Entry().Infof("Creating SBOM of python module '%v'", moduleDir)
+		if err := requireTool(utils, "python modules", "pip", "--version"); err != nil {
+			return "", err
+		}
+		if err := utils.RunExecutable("pip", "install", "--upgrade", pythonTool); err != nil {
+			return "", errors.Wrap(err, "failed to install cyclonedx-bom")
+		}
+		if err := utils.RunExecutable("cyclonedx-py", "-r", "-i", filepath.Join(moduleDir, "requirements.txt"), "--format", "xml", "-o", bomPath, "--force"); err != nil {
+			return "", errors.Wrapf(err, "failed to create SBOM of python module '%v'", moduleDir)
+		}
+		return bomPath, nil
+	}
+	log.Entry().Infof("Not creating SBOM of module '%v' since it does not contain a supported build descriptor", moduleDir)
+	return "", nil
+}
+
+func descriptorExists(moduleDir, descriptor string, utils maven.Utils) bool {
+	exists, _ := utils.FileExists(filepath.Join(moduleDir, descriptor))
+	return exists
+}
+
+type toolRunner interface {
+	RunExecutable(executable string, params ...string) error
+}
+
+// requireTool verifies that the executable required for creating an SBOM is available by querying its version
+func requireTool(utils toolRunner, purpose, executable string, versionParams ...string) error {
+	if err := utils.RunExecutable(executable, versionParams...); err != nil {
+		log.SetErrorCategory(log.ErrorConfiguration)
+		return errors.Wrapf(err, "creating the SBOM of %v requires %v, please make sure that it is available in the environment of the step", purpose, executable)
+	}
+	return nil
+}
+
+// ImageUtils bundles the functionality required for creating the SBOM of a container image
+type ImageUtils interface {
+	AppendEnv(env []string)
+	RunExecutable(executable string, params ...string) error
+}
+
+// CreateImageBOM creates the SBOM of a container image with syft (https://github.com/anchore/syft).
+// The syft executable needs to be available in the environment of the step, e.g. by using a container image which contains it.
+// The source is an image reference supported by syft, e.g. registry:<image> or docker-archive:<path>,
+// credentials for accessing a registry are taken from the docker config.json in dockerConfigDir if provided.
+func CreateImageBOM(source, bomPath, dockerConfigDir string, utils ImageUtils) error {
+	if err := requireTool(utils, "container images", "syft", "version"); err != nil {
+		return err
+	}
+
+	if len(dockerConfigDir) > 0 {
+		utils.AppendEnv([]string{"DOCKER_CONFIG=" + dockerConfigDir})
+	}
+	log.Entry().Infof("Creating SBOM of container image '%v'", source)
+	if err := utils.RunExecutable("syft", "packages", source, "-o", "cyclonedx", "--file", bomPath, "-q"); err != nil {
+		return errors.Wrapf(err, "failed to create SBOM of container image '%v'", source)
+	}
+	return nil
+}
+
+// AppendSBOMFile adds the path of an SBOM to the list of SBOMs of the pipeline unless it is already contained
+func AppendSBOMFile(sbomFiles []string, path string) []string {
+	if piperutils.ContainsString(sbomFiles, path) {
+		return sbomFiles
+	}
+	return append(sbomFiles, path)
+}
+
+// FindBOM returns the path of the SBOM created for the artifact with the given group and name.
+// An SBOM without group matches any group. If none of the files is an SBOM of the artifact an empty path is returned.
+func FindBOM(sbomFiles []string, group, name string, fileUtils FileUtils) string {
+	for _, sbomFile := range sbomFiles {
+		if exists, _ := fileUtils.FileExists(sbomFile); !exists {
+			log.Entry().Debugf("Ignoring SBOM '%v' since it does not exist", sbomFile)
+			continue
+		}
+		bom, err := ReadBOM(sbomFile, fileUtils)
+		if err != nil {
+			log.Entry().WithError(err).Warnf("Ignoring SBOM '%v'", sbomFile)
+			continue
+		}
+		if bom.Describes(group, name) || bom.Describes("", name) {
+			return sbomFile
+		}
+	}
+	return ""
+}
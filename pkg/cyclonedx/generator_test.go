@@ -0,0 +1,155 @@
+package cyclonedx
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/SAP/jenkins-library/pkg/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+type generatorUtilsMock struct {
+	*mock.ExecMockRunner
+	*mock.FilesMock
+}
+
+func (g *generatorUtilsMock) DownloadFile(url, filename string, header http.Header, cookies []*http.Cookie) error {
+	return fmt.Errorf("not implemented")
+}
+
+func newGeneratorUtilsMock() *generatorUtilsMock {
+	return &generatorUtilsMock{
+		ExecMockRunner: &mock.ExecMockRunner{},
+		FilesMock:      &mock.FilesMock{},
+	}
+}
+
+func TestCreateModuleBOM(t *testing.T) {
+	t.Run("maven module", func(t *testing.T) {
+		utils := newGeneratorUtilsMock()
+		utils.AddFile("backend/pom.xml", []byte("<project/>"))
+
+		bomPath, err := CreateModuleBOM("backend", utils)
+		assert.NoError(t, err)
+		assert.Equal(t, "backend/target/bom.xml", bomPath)
+		if assert.Len(t, utils.Calls, 1) {
+			assert.Equal(t, "mvn", utils.Calls[0].Exec)
+			assert.Contains(t, utils.Calls[0].Params, "backend/pom.xml")
+			assert.Contains(t, utils.Calls[0].Params, MavenGoal)
+			assert.Contains(t, utils.Calls[0].Params, "-DoutputFormat=xml")
+		}
+	})
+
+	t.Run("npm module", func(t *testing.T) {
+		utils := newGeneratorUtilsMock()
+		utils.AddFile("ui/package.json", []byte("{}"))
+
+		bomPath, err := CreateModuleBOM("ui", utils)
+		assert.NoError(t, err)
+		assert.Equal(t, "ui/bom.xml", bomPath)
+		assert.Equal(t, []mock.ExecCall{
+			{Exec: "npm", Params: []string{"--version"}},
+			{Exec: "npm", Params: []string{"install", "@cyclonedx/bom", "--no-save"}},
+			{Exec: "npx", Params: []string{"cyclonedx-bom", "ui", "--include-license-text", "false", "--include-dev", "false", "--output", "ui/bom.xml"}},
+		}, utils.Calls)
+	})
+
+	t.Run("go module", func(t *testing.T) {
+		utils := newGeneratorUtilsMock()
+		utils.AddFile("srv/go.mod", []byte("module example.com/srv"))
+
+		bomPath, err := CreateModuleBOM("srv", utils)
+		assert.NoError(t, err)
+		assert.Equal(t, "srv/bom.xml", bomPath)
+		assert.Equal(t, []mock.ExecCall{
+			{Exec: "go", Params: []string{"version"}},
+			{Exec: "go", Params: []string{"install", gomodModule}},
+			{Exec: "cyclonedx-gomod", Params: []string{"mod", "-licenses", "-output", "srv/bom.xml", "srv"}},
+		}, utils.Calls)
+	})
+
+	t.Run("python module", func(t *testing.T) {
+		utils := newGeneratorUtilsMock()
+		utils.AddFile("worker/requirements.txt", []byte("requests==2.26.0"))
+
+		bomPath, err := CreateModuleBOM("worker", utils)
+		assert.NoError(t, err)
+		assert.Equal(t, "worker/bom.xml", bomPath)
+		assert.Equal(t, []mock.ExecCall{
+			{Exec: "pip", Params: []string{"--version"}},
+			{Exec: "pip", Params: []string{"install", "--upgrade", pythonTool}},
+			{Exec: "cyclonedx-py", Params: []string{"-r", "-i", "worker/requirements.txt", "--format", "xml", "-o", "worker/bom.xml", "--force"}},
+		}, utils.Calls)
+	})
+
+	t.Run("unsupported module", func(t *testing.T) {
+		utils := newGeneratorUtilsMock()
+		utils.AddFile("db/package.yaml", []byte(""))
+
+		bomPath, err := CreateModuleBOM("db", utils)
+		assert.NoError(t, err)
+		assert.Empty(t, bomPath)
+		assert.Empty(t, utils.Calls)
+	})
+
+	t.Run("error - tool fails", func(t *testing.T) {
+		utils := newGeneratorUtilsMock()
+		utils.AddFile("ui/package.json", []byte("{}"))
+		utils.ShouldFailOnCommand = map[string]error{"npx cyclonedx-bom": fmt.Errorf("execution failed")}
+
+		_, err := CreateModuleBOM("ui", utils)
+		assert.EqualError(t, err, "failed to create SBOM of npm module 'ui': execution failed")
+	})
+
+	t.Run("error - build tool missing", func(t *testing.T) {
+		utils := newGeneratorUtilsMock()
+		utils.AddFile("srv/go.mod", []byte("module example.com/srv"))
+		utils.ShouldFailOnCommand = map[string]error{"go version": fmt.Errorf("executable file not found in $PATH")}
+
+		_, err := CreateModuleBOM("srv", utils)
+		assert.EqualError(t, err, "creating the SBOM of go modules requires go, please make sure that it is available in the environment of the step: executable file not found in $PATH")
+		assert.Len(t, utils.Calls, 1)
+	})
+}
+
+func TestCreateImageBOM(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		utils := newGeneratorUtilsMock()
+
+		err := CreateImageBOM("registry:my.registry.io/app:1.0.0", "bom-image.xml", "/tmp/docker", utils)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"DOCKER_CONFIG=/tmp/docker"}, utils.Env)
+		assert.Equal(t, []mock.ExecCall{
+			{Exec: "syft", Params: []string{"version"}},
+			{Exec: "syft", Params: []string{"packages", "registry:my.registry.io/app:1.0.0", "-o", "cyclonedx", "--file", "bom-image.xml", "-q"}},
+		}, utils.Calls)
+	})
+
+	t.Run("error - syft missing", func(t *testing.T) {
+		utils := newGeneratorUtilsMock()
+		utils.ShouldFailOnCommand = map[string]error{"syft version": fmt.Errorf("executable file not found in $PATH")}
+
+		err := CreateImageBOM("docker-archive:image.tar", "bom-image.xml", "", utils)
+		assert.EqualError(t, err, "creating the SBOM of container images requires syft, please make sure that it is available in the environment of the step: executable file not found in $PATH")
+		assert.Len(t, utils.Calls, 1)
+	})
+}
+
+func TestAppendSBOMFile(t *testing.T) {
+	assert.Equal(t, []string{"bom.xml"}, AppendSBOMFile(nil, "bom.xml"))
+	assert.Equal(t, []string{"bom.xml", "target/bom.xml"}, AppendSBOMFile([]string{"bom.xml"}, "target/bom.xml"))
+	assert.Equal(t, []string{"bom.xml"}, AppendSBOMFile([]string{"bom.xml"}, "bom.xml"))
+}
+
+func TestFindBOM(t *testing.T) {
+	fileUtils := &mock.FilesMock{}
+	fileUtils.AddFile("target/bom.xml", []byte(mavenBOM))
+	fileUtils.AddFile("bom.xml", []byte(npmBOM))
+	fileUtils.AddFile("invalid.xml", []byte("no sbom"))
+	sbomFiles := []string{"missing.xml", "invalid.xml", "target/bom.xml", "bom.xml"}
+
+	assert.Equal(t, "target/bom.xml", FindBOM(sbomFiles, "com.example", "backend", fileUtils))
+	assert.Equal(t, "bom.xml", FindBOM(sbomFiles, "com.example", "ui", fileUtils))
+	assert.Equal(t, "", FindBOM(sbomFiles, "org.example", "backend", fileUtils))
+}
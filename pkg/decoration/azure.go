@@ -0,0 +1,201 @@
+package decoration
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	piperhttp "github.com/SAP/jenkins-library/pkg/http"
+	"github.com/pkg/errors"
+)
+
+const azureAPIVersion = "6.0"
+
+// AzureDecorator comments pull requests of Azure Repos, summaries and findings are comment threads
+type AzureDecorator struct {
+	CollectionURI string
+	Project       string
+	RepositoryID  string
+	PullRequestID string
+	client        piperhttp.Sender
+}
+
+type azureThreads struct {
+	Value []azureThread `json:"value"`
+}
+
+type azureThread struct {
+	ID            int                 `json:"id,omitempty"`
+	Comments      []azureComment      `json:"comments"`
+	Status        string              `json:"status,omitempty"`
+	ThreadContext *azureThreadContext `json:"threadContext,omitempty"`
+}
+
+type azureComment struct {
+	ID              int    `json:"id,omitempty"`
+	ParentCommentID int    `json:"parentCommentId"`
+	Content         string `json:"content"`
+	CommentType     int    `json:"commentType"`
+}
+
+type azureThreadContext struct {
+	FilePath       string            `json:"filePath"`
+	RightFileStart azureFilePosition `json:"rightFileStart"`
+	RightFileEnd   azureFilePosition `json:"rightFileEnd"`
+}
+
+type azureIterations struct {
+	Value []struct {
+		ID int `json:"id"`
+	} `json:"value"`
+}
+
+type azureIterationChanges struct {
+	ChangeEntries []struct {
+		ChangeType string `json:"changeType"`
+		Item       struct {
+			Path string `json:"path"`
+		} `json:"item"`
+	} `json:"changeEntries"`
+	NextSkip int `json:"nextSkip"`
+}
+
+type azureFilePosition struct {
+	Line   int `json:"line"`
+	Offset int `json:"offset"`
+}
+
+// UpsertComment updates the pull request comment containing the marker or creates a new comment thread
+func (d *AzureDecorator) UpsertComment(marker, body string) error {
+	threads, err := d.threads()
+	if err != nil {
+		return err
+	}
+	for _, thread := range threads {
+		for _, comment := range thread.Comments {
+			if strings.Contains(comment.Content, marker) {
+				url := fmt.Sprintf("%v/%v/comments/%v?api-version=%v", d.threadsURL(), thread.ID, comment.ID, azureAPIVersion)
+				return d.send(http.MethodPatch, url, azureComment{Content: body, CommentType: 1}, nil)
+			}
+		}
+	}
+	return d.createThread(azureThread{Comments: []azureComment{{Content: body, CommentType: 1}}, Status: "active"})
+}
+
+// LineCommentBodies returns the bodies of all comments of threads on files of the pull request
+func (d *AzureDecorator) LineCommentBodies() ([]string, error) {
+	threads, err := d.threads()
+	if err != nil {
+		return nil, err
+	}
+	bodies := []string{}
+	for _, thread := range threads {
+		if thread.ThreadContext == nil {
+			continue
+		}
+		for _, comment := range thread.Comments {
+			bodies = append(bodies, comment.Content)
+		}
+	}
+	return bodies, nil
+}
+
+// Changes returns the files changed by the latest iteration of the pull request.
+// Threads can be created on any line of a changed file, thus all lines of the files are contained.
+func (d *AzureDecorator) Changes() (Changes, error) {
+	var iterations azureIterations
+	url := fmt.Sprintf("%v/iterations?api-version=%v", d.pullRequestURL(), azureAPIVersion)
+	if err := d.send(http.MethodGet, url, nil, &iterations); err != nil {
+		return nil, errors.Wrapf(err, "failed to list iterations of pull request %v", d.PullRequestID)
+	}
+	changes := Changes{}
+	if len(iterations.Value) == 0 {
+		return changes, nil
+	}
+	iteration := iterations.Value[len(iterations.Value)-1].ID
+	skip := 0
+	for {
+		var iterationChanges azureIterationChanges
+		url := fmt.Sprintf("%v/iterations/%v/changes?$skip=%v&api-version=%v", d.pullRequestURL(), iteration, skip, azureAPIVersion)
+		if err := d.send(http.MethodGet, url, nil, &iterationChanges); err != nil {
+			return nil, errors.Wrapf(err, "failed to list changes of iteration %v of pull request %v", iteration, d.PullRequestID)
+		}
+		for _, entry := range iterationChanges.ChangeEntries {
+			if strings.Contains(entry.ChangeType, "delete") || len(entry.Item.Path) == 0 {
+				continue
+			}
+			changes[normalizePath(entry.Item.Path)] = []LineRange{wholeFile}
+		}
+		if iterationChanges.NextSkip <= skip {
+			break
+		}
+		skip = iterationChanges.NextSkip
+	}
+	return changes, nil
+}
+
+// CreateLineComment creates a comment thread on the line of the file in the pull request
+func (d *AzureDecorator) CreateLineComment(comment LineComment) error {
+	position := azureFilePosition{Line: comment.Line, Offset: 1}
+	return d.createThread(azureThread{
+		Comments: []azureComment{{Content: comment.Body, CommentType: 1}},
+		Status:   "active",
+		ThreadContext: &azureThreadContext{
+			FilePath:       "/" + strings.TrimPrefix(comment.Path, "/"),
+			RightFileStart: position,
+			RightFileEnd:   position,
+		},
+	})
+}
+
+func (d *AzureDecorator) pullRequestURL() string {
+	return fmt.Sprintf("%v/%v/_apis/git/repositories/%v/pullRequests/%v", strings.TrimSuffix(d.CollectionURI, "/"), d.Project, d.RepositoryID, d.PullRequestID)
+}
+
+func (d *AzureDecorator) threadsURL() string {
+	return d.pullRequestURL() + "/threads"
+}
+
+func (d *AzureDecorator) threads() ([]azureThread, error) {
+	var threads azureThreads
+	url := fmt.Sprintf("%v?api-version=%v", d.threadsURL(), azureAPIVersion)
+	if err := d.send(http.MethodGet, url, nil, &threads); err != nil {
+		return nil, errors.Wrapf(err, "failed to list threads of pull request %v", d.PullRequestID)
+	}
+	return threads.Value, nil
+}
+
+func (d *AzureDecorator) createThread(thread azureThread) error {
+	url := fmt.Sprintf("%v?api-version=%v", d.threadsURL(), azureAPIVersion)
+	return errors.Wrapf(d.send(http.MethodPost, url, thread, nil), "failed to create thread in pull request %v", d.PullRequestID)
+}
+
+func (d *AzureDecorator) send(method, url string, payload interface{}, result interface{}) error {
+	var body io.Reader
+	header := http.Header{}
+	if payload != nil {
+		content, err := json.Marshal(payload)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal request body")
+		}
+		body = bytes.NewBuffer(content)
+		header.Set("Content-Type", "application/json")
+	}
+	response, err := d.client.SendRequest(method, url, body, header, nil)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if result == nil {
+		return nil
+	}
+	content, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return errors.Wrap(err, "failed to read response body")
+	}
+	return errors.Wrap(json.Unmarshal(content, result), "failed to unmarshal response body")
+}
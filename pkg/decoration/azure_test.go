@@ -0,0 +1,136 @@
+package decoration
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	piperhttp "github.com/SAP/jenkins-library/pkg/http"
+	"github.com/stretchr/testify/assert"
+)
+
+type azureServerMock struct {
+	server   *httptest.Server
+	requests []string
+	bodies   map[string]string
+	threads  string
+}
+
+func newAzureServerMock(t *testing.T, threads string) *azureServerMock {
+	mock := &azureServerMock{bodies: map[string]string{}, threads: threads}
+	mock.server = httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		body, _ := ioutil.ReadAll(req.Body)
+		key := req.Method + " " + req.URL.Path
+		mock.requests = append(mock.requests, key)
+		mock.bodies[key] = string(body)
+		assert.Equal(t, "6.0", req.URL.Query().Get("api-version"))
+		_, password, _ := req.BasicAuth()
+		assert.Equal(t, "token-4711", password)
+		rw.Header().Add("Content-Type", "application/json")
+		switch key {
+		case "GET /org/prj/_apis/git/repositories/repo/pullRequests/7/threads":
+			rw.Write([]byte(mock.threads))
+		case "POST /org/prj/_apis/git/repositories/repo/pullRequests/7/threads":
+			rw.Write([]byte(`{"id": 3}`))
+		case "PATCH /org/prj/_apis/git/repositories/repo/pullRequests/7/threads/2/comments/1":
+			rw.Write([]byte(`{"id": 1}`))
+		case "GET /org/prj/_apis/git/repositories/repo/pullRequests/7/iterations":
+			rw.Write([]byte(`{"count": 2, "value": [{"id": 1}, {"id": 2}]}`))
+		case "GET /org/prj/_apis/git/repositories/repo/pullRequests/7/iterations/2/changes":
+			if req.URL.Query().Get("$skip") == "0" {
+				rw.Write([]byte(`{"changeEntries": [{"item": {"path": "/cmd/main.go"}, "changeType": "edit"}, {"item": {"path": "/pkg/old.go"}, "changeType": "delete"}], "nextSkip": 2}`))
+			} else {
+				rw.Write([]byte(`{"changeEntries": [{"item": {"path": "/pkg/util.go"}, "changeType": "add"}], "nextSkip": 0}`))
+			}
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	return mock
+}
+
+func (mock *azureServerMock) decorator() *AzureDecorator {
+	client := &piperhttp.Client{}
+	client.SetOptions(piperhttp.ClientOptions{Password: "token-4711"})
+	return &AzureDecorator{CollectionURI: mock.server.URL + "/org/", Project: "prj", RepositoryID: "repo", PullRequestID: "7", client: client}
+}
+
+const azureThreadsResponse = `{"count": 2, "value": [
+	{"id": 1, "comments": [{"id": 1, "content": "please rename"}], "threadContext": {"filePath": "/cmd/main.go"}},
+	{"id": 2, "comments": [{"id": 1, "content": "<!-- marker -->\nold report"}]}
+]}`
+
+func TestAzureUpsertComment(t *testing.T) {
+	t.Run("update existing comment", func(t *testing.T) {
+		mock := newAzureServerMock(t, azureThreadsResponse)
+		defer mock.server.Close()
+
+		err := mock.decorator().UpsertComment("<!-- marker -->", "<!-- marker -->\nnew report")
+
+		assert.NoError(t, err)
+		key := "PATCH /org/prj/_apis/git/repositories/repo/pullRequests/7/threads/2/comments/1"
+		assert.Contains(t, mock.requests, key)
+		var comment map[string]interface{}
+		json.Unmarshal([]byte(mock.bodies[key]), &comment)
+		assert.Equal(t, "<!-- marker -->\nnew report", comment["content"])
+	})
+
+	t.Run("create thread", func(t *testing.T) {
+		mock := newAzureServerMock(t, `{"count": 0, "value": []}`)
+		defer mock.server.Close()
+
+		err := mock.decorator().UpsertComment("<!-- marker -->", "report")
+
+		assert.NoError(t, err)
+		var thread map[string]interface{}
+		json.Unmarshal([]byte(mock.bodies["POST /org/prj/_apis/git/repositories/repo/pullRequests/7/threads"]), &thread)
+		assert.Equal(t, "active", thread["status"])
+		assert.Nil(t, thread["threadContext"])
+		assert.Equal(t, []interface{}{map[string]interface{}{"parentCommentId": float64(0), "content": "report", "commentType": float64(1)}}, thread["comments"])
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mock := newAzureServerMock(t, "")
+		defer mock.server.Close()
+		decorator := mock.decorator()
+		decorator.PullRequestID = "8"
+
+		err := decorator.UpsertComment("<!-- marker -->", "report")
+
+		assert.Contains(t, err.Error(), "failed to list threads of pull request 8")
+	})
+}
+
+func TestAzureLineComments(t *testing.T) {
+	mock := newAzureServerMock(t, azureThreadsResponse)
+	defer mock.server.Close()
+	decorator := mock.decorator()
+
+	bodies, err := decorator.LineCommentBodies()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"please rename"}, bodies)
+
+	err = decorator.CreateLineComment(LineComment{Path: "cmd/main.go", Line: 12, Body: "finding"})
+	assert.NoError(t, err)
+	var thread map[string]interface{}
+	json.Unmarshal([]byte(mock.bodies["POST /org/prj/_apis/git/repositories/repo/pullRequests/7/threads"]), &thread)
+	assert.Equal(t, map[string]interface{}{
+		"filePath":       "/cmd/main.go",
+		"rightFileStart": map[string]interface{}{"line": float64(12), "offset": float64(1)},
+		"rightFileEnd":   map[string]interface{}{"line": float64(12), "offset": float64(1)},
+	}, thread["threadContext"])
+}
+
+func TestAzureChanges(t *testing.T) {
+	mock := newAzureServerMock(t, azureThreadsResponse)
+	defer mock.server.Close()
+
+	changes, err := mock.decorator().Changes()
+
+	assert.NoError(t, err)
+	assert.Equal(t, Changes{"cmd/main.go": {wholeFile}, "pkg/util.go": {wholeFile}}, changes)
+	assert.True(t, changes.Contains("cmd/main.go", 4711))
+	assert.False(t, changes.Contains("pkg/old.go", 1))
+}
@@ -0,0 +1,227 @@
+package decoration
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"math"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	piperhttp "github.com/SAP/jenkins-library/pkg/http"
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/orchestrator"
+	"github.com/SAP/jenkins-library/pkg/reporting"
+	"github.com/pkg/errors"
+)
+
+// Decorator publishes comments in a pull request
+type Decorator interface {
+	// UpsertComment updates the pull request comment containing the marker or creates a new comment
+	UpsertComment(marker, body string) error
+	// LineCommentBodies returns the bodies of all comments on lines of files of the pull request
+	LineCommentBodies() ([]string, error)
+	// Changes returns the lines of the files changed by the pull request which can be commented
+	Changes() (Changes, error)
+	// CreateLineComment comments a line of a file of the pull request
+	CreateLineComment(comment LineComment) error
+}
+
+// LineRange is a range of lines of a file including the start and the end line
+type LineRange struct {
+	Start int
+	End   int
+}
+
+// wholeFile is the range of a file whose lines can be commented regardless of the changes
+var wholeFile = LineRange{Start: 1, End: math.MaxInt32}
+
+// Changes contains the commentable line ranges of the files changed by a pull request, keyed by the path relative to the repository root
+type Changes map[string][]LineRange
+
+// Contains returns whether the line of the file is part of the changes
+func (c Changes) Contains(filePath string, line int) bool {
+	for _, lineRange := range c[normalizePath(filePath)] {
+		if line >= lineRange.Start && line <= lineRange.End {
+			return true
+		}
+	}
+	return false
+}
+
+var hunkHeader = regexp.MustCompile(`(?m)^@@ -\d+(?:,\d+)? \+(\d+)(?:,(\d+))? @@`)
+
+// patchLineRanges returns the line ranges of the new file version covered by the hunks of a unified diff
+func patchLineRanges(patch string) []LineRange {
+	ranges := []LineRange{}
+	for _, match := range hunkHeader.FindAllStringSubmatch(patch, -1) {
+		start, _ := strconv.Atoi(match[1])
+		count := 1
+		if len(match[2]) > 0 {
+			count, _ = strconv.Atoi(match[2])
+		}
+		if count > 0 {
+			ranges = append(ranges, LineRange{Start: start, End: start + count - 1})
+		}
+	}
+	return ranges
+}
+
+// normalizePath converts the location of a finding into a path relative to the repository root, e.g. /src/main.go becomes src/main.go
+func normalizePath(filePath string) string {
+	return strings.TrimPrefix(path.Clean(filepath.ToSlash(filePath)), "/")
+}
+
+// LineComment is a comment on a line of a file
+type LineComment struct {
+	Path string
+	Line int
+	Body string
+}
+
+// Options contains the settings for decorating a pull request
+type Options struct {
+	// PullRequest is the number of the pull request, it is detected from the orchestrator if empty
+	PullRequest string
+	// Owner and Repository identify the GitHub repository
+	Owner        string
+	Repository   string
+	GitHubToken  string
+	GitHubAPIURL string
+	// AzureToken is used to authenticate to Azure DevOps, e.g. the access token of the build job
+	AzureToken string
+}
+
+// NewDecorator returns the decorator for the pull request of the orchestrator piper is running on.
+// Pull requests of Azure Repos are decorated on Azure DevOps, otherwise GitHub is used.
+func NewDecorator(options Options, client piperhttp.Sender) (Decorator, error) {
+	provider, err := orchestrator.NewOrchestratorSpecificConfigProvider()
+	if len(options.PullRequest) == 0 {
+		if err != nil || !provider.IsPullRequest() {
+			return nil, fmt.Errorf("no pull request detected on orchestrator %v", orchestrator.DetectOrchestrator())
+		}
+		options.PullRequest = provider.GetPullRequestConfig().Key
+	}
+
+	if orchestrator.DetectOrchestrator() == orchestrator.AzureDevOps && os.Getenv("BUILD_REPOSITORY_PROVIDER") != "GitHub" {
+		token := options.AzureToken
+		if len(token) == 0 {
+			token = os.Getenv("SYSTEM_ACCESSTOKEN")
+		}
+		if len(token) == 0 {
+			return nil, errors.New("no access token for Azure DevOps available")
+		}
+		log.RegisterSecret(token)
+		client.SetOptions(piperhttp.ClientOptions{Password: token})
+		return &AzureDecorator{
+			CollectionURI: os.Getenv("SYSTEM_COLLECTIONURI"),
+			Project:       os.Getenv("SYSTEM_TEAMPROJECTID"),
+			RepositoryID:  os.Getenv("BUILD_REPOSITORY_ID"),
+			PullRequestID: options.PullRequest,
+			client:        client,
+		}, nil
+	}
+
+	if len(options.GitHubToken) == 0 || len(options.Owner) == 0 || len(options.Repository) == 0 {
+		return nil, errors.New("GitHub token, owner and repository are required to decorate the pull request")
+	}
+	number, err := strconv.Atoi(options.PullRequest)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid pull request number '%v'", options.PullRequest)
+	}
+	return NewGitHubDecorator(options.GitHubToken, options.GitHubAPIURL, options.Owner, options.Repository, number)
+}
+
+// Decorate publishes the markdown of the report as summary comment of the pull request.
+// Findings with file and line information within the changes of the pull request are commented on their line,
+// findings which have already been commented are skipped. At most maxLineComments line comments are attempted.
+func Decorate(decorator Decorator, report reporting.ScanReport, maxLineComments int) error {
+	markdown, err := report.ToMarkdown()
+	if err != nil {
+		return errors.Wrap(err, "failed to create markdown of the scan report")
+	}
+	marker := summaryMarker(report)
+	if err := decorator.UpsertComment(marker, fmt.Sprintf("%v\n%v", marker, string(markdown))); err != nil {
+		return errors.Wrap(err, "failed to comment the pull request")
+	}
+
+	if maxLineComments <= 0 {
+		return nil
+	}
+	changes, err := decorator.Changes()
+	if err != nil {
+		return errors.Wrap(err, "failed to load the changes of the pull request")
+	}
+	existing, err := decorator.LineCommentBodies()
+	if err != nil {
+		return errors.Wrap(err, "failed to load the line comments of the pull request")
+	}
+	attempts := 0
+	for _, finding := range report.Findings {
+		if finding.Suppressed || len(finding.Location) == 0 || finding.Line <= 0 || !changes.Contains(finding.Location, finding.Line) {
+			continue
+		}
+		marker := findingMarker(finding)
+		if containsMarker(existing, marker) {
+			continue
+		}
+		if attempts >= maxLineComments {
+			log.Entry().Infof("Only the first %v findings within the changes are commented in the pull request", maxLineComments)
+			break
+		}
+		attempts++
+		comment := LineComment{Path: normalizePath(finding.Location), Line: finding.Line, Body: lineCommentBody(marker, report.StepName, finding)}
+		if err := decorator.CreateLineComment(comment); err != nil {
+			log.Entry().WithError(err).Warnf("Failed to comment finding %v on line %v of %v", finding.RuleID, finding.Line, finding.Location)
+		}
+	}
+	return nil
+}
+
+// newDecorator creates the decorator of PublishReport, it is replaced in tests
+var newDecorator = NewDecorator
+
+// PublishReport decorates the pull request the pipeline is running for with the scan report of a step.
+// Failures are only logged since the decoration is for information only.
+func PublishReport(options Options, report reporting.ScanReport, maxLineComments int, client piperhttp.Sender) {
+	decorator, err := newDecorator(options, client)
+	if err != nil {
+		log.Entry().WithError(err).Warn("Pull request is not decorated")
+		return
+	}
+	if err := Decorate(decorator, report, maxLineComments); err != nil {
+		log.Entry().WithError(err).Warnf("Failed to decorate pull request with report '%v'", report.Title)
+	}
+}
+
+func summaryMarker(report reporting.ScanReport) string {
+	id := fmt.Sprintf("%x", sha1.Sum([]byte(report.StepName+"|"+report.Title)))
+	return fmt.Sprintf("<!-- piper-decoration: %v -->", id)
+}
+
+func findingMarker(finding reporting.Finding) string {
+	return fmt.Sprintf("<!-- piper-finding: %v -->", finding.Fingerprint())
+}
+
+func containsMarker(bodies []string, marker string) bool {
+	for _, body := range bodies {
+		if strings.Contains(body, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+func lineCommentBody(marker, stepName string, finding reporting.Finding) string {
+	body := fmt.Sprintf("%v\n**%v** (%v, %v)", marker, finding.Title, finding.Severity, stepName)
+	if len(finding.Description) > 0 {
+		body += "\n\n" + finding.Description
+	}
+	if len(finding.URL) > 0 {
+		body += fmt.Sprintf("\n\n[Details](%v)", finding.URL)
+	}
+	return body
+}
@@ -0,0 +1,246 @@
+package decoration
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	piperhttp "github.com/SAP/jenkins-library/pkg/http"
+	"github.com/SAP/jenkins-library/pkg/reporting"
+	"github.com/stretchr/testify/assert"
+)
+
+type decoratorMock struct {
+	comments      map[string]string
+	lineComments  []LineComment
+	existingLines []string
+	failLines     map[string]bool
+	changes       Changes
+}
+
+func (d *decoratorMock) UpsertComment(marker, body string) error {
+	if d.comments == nil {
+		d.comments = map[string]string{}
+	}
+	d.comments[marker] = body
+	return nil
+}
+
+func (d *decoratorMock) LineCommentBodies() ([]string, error) {
+	return d.existingLines, nil
+}
+
+func (d *decoratorMock) Changes() (Changes, error) {
+	if d.changes == nil {
+		return Changes{"cmd/main.go": {{Start: 10, End: 20}}, "pkg/util.go": {{Start: 1, End: 5}}}, nil
+	}
+	return d.changes, nil
+}
+
+func (d *decoratorMock) CreateLineComment(comment LineComment) error {
+	if d.failLines[comment.Path] {
+		return fmt.Errorf("line %v is not part of the diff", comment.Line)
+	}
+	d.lineComments = append(d.lineComments, comment)
+	return nil
+}
+
+func testReport() reporting.ScanReport {
+	return reporting.ScanReport{
+		StepName: "sonarExecuteScan",
+		Title:    "Sonar Report",
+		Findings: []reporting.Finding{
+			{RuleID: "go:S1", Title: "Remove this unused variable", Severity: reporting.SeverityHigh, Location: "cmd/main.go", Line: 12},
+			{RuleID: "go:S2", Title: "Project wide finding", Severity: reporting.SeverityLow},
+			{RuleID: "go:S3", Title: "Suppressed finding", Severity: reporting.SeverityLow, Location: "cmd/main.go", Line: 20, Suppressed: true},
+			{RuleID: "go:S4", Title: "Rename this function", Severity: reporting.SeverityMedium, Location: "pkg/util.go", Line: 3, URL: "https://sonar/issues?open=4"},
+		},
+	}
+}
+
+func TestDecorate(t *testing.T) {
+	t.Run("summary and line comments", func(t *testing.T) {
+		decorator := &decoratorMock{}
+		report := testReport()
+
+		err := Decorate(decorator, report, 10)
+
+		assert.NoError(t, err)
+		marker := summaryMarker(report)
+		if assert.Contains(t, decorator.comments, marker) {
+			assert.True(t, strings.HasPrefix(decorator.comments[marker], marker))
+			assert.Contains(t, decorator.comments[marker], "Sonar Report")
+		}
+		if assert.Len(t, decorator.lineComments, 2) {
+			assert.Equal(t, "cmd/main.go", decorator.lineComments[0].Path)
+			assert.Equal(t, 12, decorator.lineComments[0].Line)
+			assert.Contains(t, decorator.lineComments[0].Body, findingMarker(report.Findings[0]))
+			assert.Contains(t, decorator.lineComments[0].Body, "**Remove this unused variable** (high, sonarExecuteScan)")
+			assert.Contains(t, decorator.lineComments[1].Body, "[Details](https://sonar/issues?open=4)")
+		}
+	})
+
+	t.Run("already commented findings are skipped", func(t *testing.T) {
+		report := testReport()
+		decorator := &decoratorMock{existingLines: []string{"some text\n" + findingMarker(report.Findings[0])}}
+
+		err := Decorate(decorator, report, 10)
+
+		assert.NoError(t, err)
+		if assert.Len(t, decorator.lineComments, 1) {
+			assert.Equal(t, "pkg/util.go", decorator.lineComments[0].Path)
+		}
+	})
+
+	t.Run("line comments are limited", func(t *testing.T) {
+		decorator := &decoratorMock{}
+
+		err := Decorate(decorator, testReport(), 1)
+
+		assert.NoError(t, err)
+		assert.Len(t, decorator.lineComments, 1)
+	})
+
+	t.Run("line comments disabled", func(t *testing.T) {
+		decorator := &decoratorMock{}
+
+		err := Decorate(decorator, testReport(), 0)
+
+		assert.NoError(t, err)
+		assert.Len(t, decorator.comments, 1)
+		assert.Empty(t, decorator.lineComments)
+	})
+
+	t.Run("findings outside of the changes are skipped", func(t *testing.T) {
+		decorator := &decoratorMock{changes: Changes{"pkg/util.go": {{Start: 3, End: 3}}}}
+
+		err := Decorate(decorator, testReport(), 10)
+
+		assert.NoError(t, err)
+		if assert.Len(t, decorator.lineComments, 1) {
+			assert.Equal(t, "pkg/util.go", decorator.lineComments[0].Path)
+		}
+	})
+
+	t.Run("failing line comments do not fail", func(t *testing.T) {
+		decorator := &decoratorMock{failLines: map[string]bool{"cmd/main.go": true}}
+
+		err := Decorate(decorator, testReport(), 2)
+
+		assert.NoError(t, err)
+		if assert.Len(t, decorator.lineComments, 1) {
+			assert.Equal(t, "pkg/util.go", decorator.lineComments[0].Path)
+		}
+	})
+
+	t.Run("failing line comments count as attempts", func(t *testing.T) {
+		decorator := &decoratorMock{failLines: map[string]bool{"cmd/main.go": true}}
+
+		err := Decorate(decorator, testReport(), 1)
+
+		assert.NoError(t, err)
+		assert.Empty(t, decorator.lineComments)
+	})
+}
+
+func TestPublishReport(t *testing.T) {
+	defer func() { newDecorator = NewDecorator }()
+
+	t.Run("success", func(t *testing.T) {
+		decorator := &decoratorMock{}
+		newDecorator = func(options Options, client piperhttp.Sender) (Decorator, error) {
+			assert.Equal(t, "42", options.PullRequest)
+			return decorator, nil
+		}
+
+		PublishReport(Options{PullRequest: "42"}, testReport(), 10, &piperhttp.Client{})
+
+		assert.Len(t, decorator.comments, 1)
+		assert.Len(t, decorator.lineComments, 2)
+	})
+
+	t.Run("no pull request", func(t *testing.T) {
+		newDecorator = func(options Options, client piperhttp.Sender) (Decorator, error) {
+			return nil, fmt.Errorf("no pull request detected")
+		}
+
+		assert.NotPanics(t, func() { PublishReport(Options{}, testReport(), 10, &piperhttp.Client{}) })
+	})
+}
+
+func TestSummaryMarker(t *testing.T) {
+	report := testReport()
+	other := testReport()
+	other.StepName = "checkmarxExecuteScan"
+
+	assert.Equal(t, summaryMarker(report), summaryMarker(testReport()))
+	assert.NotEqual(t, summaryMarker(report), summaryMarker(other))
+}
+
+func resetEnv(e []string) {
+	for _, val := range e {
+		tmp := strings.SplitN(val, "=", 2)
+		os.Setenv(tmp[0], tmp[1])
+	}
+}
+
+func TestNewDecorator(t *testing.T) {
+	t.Run("Azure Repos", func(t *testing.T) {
+		defer resetEnv(os.Environ())
+		os.Clearenv()
+		os.Setenv("AZURE_HTTP_USER_AGENT", "FOO BAR BAZ")
+		os.Setenv("BUILD_REASON", "PullRequest")
+		os.Setenv("BUILD_REPOSITORY_PROVIDER", "TfsGit")
+		os.Setenv("BUILD_REPOSITORY_ID", "repo")
+		os.Setenv("SYSTEM_COLLECTIONURI", "https://dev.azure.com/org/")
+		os.Setenv("SYSTEM_TEAMPROJECTID", "prj")
+		os.Setenv("SYSTEM_PULLREQUEST_PULLREQUESTID", "7")
+		os.Setenv("SYSTEM_ACCESSTOKEN", "token-4711")
+
+		decorator, err := NewDecorator(Options{}, &piperhttp.Client{})
+
+		assert.NoError(t, err)
+		if assert.IsType(t, &AzureDecorator{}, decorator) {
+			azure := decorator.(*AzureDecorator)
+			assert.Equal(t, "7", azure.PullRequestID)
+			assert.Equal(t, "https://dev.azure.com/org/prj/_apis/git/repositories/repo/pullRequests/7/threads", azure.threadsURL())
+		}
+	})
+
+	t.Run("GitHub repository on Azure DevOps", func(t *testing.T) {
+		defer resetEnv(os.Environ())
+		os.Clearenv()
+		os.Setenv("AZURE_HTTP_USER_AGENT", "FOO BAR BAZ")
+		os.Setenv("BUILD_REASON", "PullRequest")
+		os.Setenv("BUILD_REPOSITORY_PROVIDER", "GitHub")
+		os.Setenv("SYSTEM_PULLREQUEST_PULLREQUESTID", "4711")
+		os.Setenv("SYSTEM_PULLREQUEST_PULLREQUESTNUMBER", "42")
+
+		decorator, err := NewDecorator(Options{Owner: "SAP", Repository: "jenkins-library", GitHubToken: "token", GitHubAPIURL: "https://api.github.com"}, &piperhttp.Client{})
+
+		assert.NoError(t, err)
+		if assert.IsType(t, &GitHubDecorator{}, decorator) {
+			assert.Equal(t, 42, decorator.(*GitHubDecorator).PullRequest)
+		}
+	})
+
+	t.Run("no pull request", func(t *testing.T) {
+		defer resetEnv(os.Environ())
+		os.Clearenv()
+		os.Setenv("GITHUB_ACTIONS", "true")
+
+		_, err := NewDecorator(Options{}, &piperhttp.Client{})
+
+		assert.EqualError(t, err, "no pull request detected on orchestrator GitHubActions")
+	})
+
+	t.Run("GitHub token missing", func(t *testing.T) {
+		defer resetEnv(os.Environ())
+		os.Clearenv()
+
+		_, err := NewDecorator(Options{PullRequest: "42", Owner: "SAP", Repository: "jenkins-library"}, &piperhttp.Client{})
+
+		assert.EqualError(t, err, "GitHub token, owner and repository are required to decorate the pull request")
+	})
+}
@@ -0,0 +1,136 @@
+package decoration
+
+import (
+	"context"
+	"strings"
+
+	piperGithub "github.com/SAP/jenkins-library/pkg/github"
+	"github.com/google/go-github/v32/github"
+	"github.com/pkg/errors"
+)
+
+type githubIssueService interface {
+	ListComments(ctx context.Context, owner string, repo string, number int, opts *github.IssueListCommentsOptions) ([]*github.IssueComment, *github.Response, error)
+	CreateComment(ctx context.Context, owner string, repo string, number int, comment *github.IssueComment) (*github.IssueComment, *github.Response, error)
+	EditComment(ctx context.Context, owner string, repo string, commentID int64, comment *github.IssueComment) (*github.IssueComment, *github.Response, error)
+}
+
+type githubPullRequestService interface {
+	Get(ctx context.Context, owner string, repo string, number int) (*github.PullRequest, *github.Response, error)
+	ListComments(ctx context.Context, owner string, repo string, number int, opts *github.PullRequestListCommentsOptions) ([]*github.PullRequestComment, *github.Response, error)
+	ListFiles(ctx context.Context, owner string, repo string, number int, opts *github.ListOptions) ([]*github.CommitFile, *github.Response, error)
+	CreateComment(ctx context.Context, owner string, repo string, number int, comment *github.PullRequestComment) (*github.PullRequestComment, *github.Response, error)
+}
+
+// GitHubDecorator comments pull requests on GitHub, summaries are issue comments and findings are review comments
+type GitHubDecorator struct {
+	Owner        string
+	Repository   string
+	PullRequest  int
+	ctx          context.Context
+	issues       githubIssueService
+	pullRequests githubPullRequestService
+	headSHA      string
+}
+
+// NewGitHubDecorator returns a decorator for a pull request on GitHub
+func NewGitHubDecorator(token, apiURL, owner, repository string, pullRequest int) (*GitHubDecorator, error) {
+	ctx, client, err := piperGithub.NewClient(token, apiURL, "")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get GitHub client")
+	}
+	return &GitHubDecorator{
+		Owner:        owner,
+		Repository:   repository,
+		PullRequest:  pullRequest,
+		ctx:          ctx,
+		issues:       client.Issues,
+		pullRequests: client.PullRequests,
+	}, nil
+}
+
+// UpsertComment updates the pull request comment containing the marker or creates a new comment
+func (d *GitHubDecorator) UpsertComment(marker, body string) error {
+	options := &github.IssueListCommentsOptions{ListOptions: github.ListOptions{PerPage: 100}}
+	for {
+		comments, response, err := d.issues.ListComments(d.ctx, d.Owner, d.Repository, d.PullRequest, options)
+		if err != nil {
+			return errors.Wrapf(err, "failed to list comments of pull request %v", d.PullRequest)
+		}
+		for _, comment := range comments {
+			if strings.Contains(comment.GetBody(), marker) {
+				_, _, err := d.issues.EditComment(d.ctx, d.Owner, d.Repository, comment.GetID(), &github.IssueComment{Body: &body})
+				return errors.Wrapf(err, "failed to update comment %v", comment.GetID())
+			}
+		}
+		if response == nil || response.NextPage == 0 {
+			break
+		}
+		options.Page = response.NextPage
+	}
+	_, _, err := d.issues.CreateComment(d.ctx, d.Owner, d.Repository, d.PullRequest, &github.IssueComment{Body: &body})
+	return errors.Wrapf(err, "failed to comment pull request %v", d.PullRequest)
+}
+
+// LineCommentBodies returns the bodies of all review comments of the pull request
+func (d *GitHubDecorator) LineCommentBodies() ([]string, error) {
+	bodies := []string{}
+	options := &github.PullRequestListCommentsOptions{ListOptions: github.ListOptions{PerPage: 100}}
+	for {
+		comments, response, err := d.pullRequests.ListComments(d.ctx, d.Owner, d.Repository, d.PullRequest, options)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to list review comments of pull request %v", d.PullRequest)
+		}
+		for _, comment := range comments {
+			bodies = append(bodies, comment.GetBody())
+		}
+		if response == nil || response.NextPage == 0 {
+			break
+		}
+		options.Page = response.NextPage
+	}
+	return bodies, nil
+}
+
+// Changes returns the lines of the hunks of the files changed by the pull request since review comments are only possible within them.
+// Files without patch, e.g. binary files, are not contained.
+func (d *GitHubDecorator) Changes() (Changes, error) {
+	changes := Changes{}
+	options := &github.ListOptions{PerPage: 100}
+	for {
+		files, response, err := d.pullRequests.ListFiles(d.ctx, d.Owner, d.Repository, d.PullRequest, options)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to list files of pull request %v", d.PullRequest)
+		}
+		for _, file := range files {
+			if ranges := patchLineRanges(file.GetPatch()); len(ranges) > 0 {
+				changes[file.GetFilename()] = ranges
+			}
+		}
+		if response == nil || response.NextPage == 0 {
+			break
+		}
+		options.Page = response.NextPage
+	}
+	return changes, nil
+}
+
+// CreateLineComment creates a review comment on the line of the head commit of the pull request
+func (d *GitHubDecorator) CreateLineComment(comment LineComment) error {
+	if len(d.headSHA) == 0 {
+		pullRequest, _, err := d.pullRequests.Get(d.ctx, d.Owner, d.Repository, d.PullRequest)
+		if err != nil {
+			return errors.Wrapf(err, "failed to load pull request %v", d.PullRequest)
+		}
+		d.headSHA = pullRequest.GetHead().GetSHA()
+	}
+	side := "RIGHT"
+	_, _, err := d.pullRequests.CreateComment(d.ctx, d.Owner, d.Repository, d.PullRequest, &github.PullRequestComment{
+		Body:     &comment.Body,
+		CommitID: &d.headSHA,
+		Path:     &comment.Path,
+		Line:     &comment.Line,
+		Side:     &side,
+	})
+	return errors.Wrapf(err, "failed to create review comment on %v", comment.Path)
+}
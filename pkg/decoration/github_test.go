@@ -0,0 +1,165 @@
+package decoration
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-github/v32/github"
+	"github.com/stretchr/testify/assert"
+)
+
+type ghIssueServiceMock struct {
+	pages     [][]*github.IssueComment
+	created   []string
+	edited    map[int64]string
+	listError error
+}
+
+func (g *ghIssueServiceMock) ListComments(ctx context.Context, owner string, repo string, number int, opts *github.IssueListCommentsOptions) ([]*github.IssueComment, *github.Response, error) {
+	if g.listError != nil {
+		return nil, nil, g.listError
+	}
+	page := opts.Page
+	if page == 0 {
+		page = 1
+	}
+	response := &github.Response{}
+	if page < len(g.pages) {
+		response.NextPage = page + 1
+	}
+	return g.pages[page-1], response, nil
+}
+
+func (g *ghIssueServiceMock) CreateComment(ctx context.Context, owner string, repo string, number int, comment *github.IssueComment) (*github.IssueComment, *github.Response, error) {
+	g.created = append(g.created, comment.GetBody())
+	return comment, nil, nil
+}
+
+func (g *ghIssueServiceMock) EditComment(ctx context.Context, owner string, repo string, commentID int64, comment *github.IssueComment) (*github.IssueComment, *github.Response, error) {
+	if g.edited == nil {
+		g.edited = map[int64]string{}
+	}
+	g.edited[commentID] = comment.GetBody()
+	return comment, nil, nil
+}
+
+type ghPullRequestServiceMock struct {
+	comments []*github.PullRequestComment
+	created  []*github.PullRequestComment
+	files    [][]*github.CommitFile
+	getCalls int
+}
+
+func (g *ghPullRequestServiceMock) Get(ctx context.Context, owner string, repo string, number int) (*github.PullRequest, *github.Response, error) {
+	g.getCalls++
+	sha := "abc123"
+	return &github.PullRequest{Head: &github.PullRequestBranch{SHA: &sha}}, nil, nil
+}
+
+func (g *ghPullRequestServiceMock) ListComments(ctx context.Context, owner string, repo string, number int, opts *github.PullRequestListCommentsOptions) ([]*github.PullRequestComment, *github.Response, error) {
+	return g.comments, &github.Response{}, nil
+}
+
+func (g *ghPullRequestServiceMock) ListFiles(ctx context.Context, owner string, repo string, number int, opts *github.ListOptions) ([]*github.CommitFile, *github.Response, error) {
+	page := opts.Page
+	if page == 0 {
+		page = 1
+	}
+	response := &github.Response{}
+	if page < len(g.files) {
+		response.NextPage = page + 1
+	}
+	return g.files[page-1], response, nil
+}
+
+func (g *ghPullRequestServiceMock) CreateComment(ctx context.Context, owner string, repo string, number int, comment *github.PullRequestComment) (*github.PullRequestComment, *github.Response, error) {
+	g.created = append(g.created, comment)
+	return comment, nil, nil
+}
+
+func issueComment(id int64, body string) *github.IssueComment {
+	return &github.IssueComment{ID: &id, Body: &body}
+}
+
+func TestGitHubUpsertComment(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("update existing comment", func(t *testing.T) {
+		issues := &ghIssueServiceMock{pages: [][]*github.IssueComment{
+			{issueComment(1, "looks good")},
+			{issueComment(2, "<!-- marker -->\nold report")},
+		}}
+		decorator := &GitHubDecorator{Owner: "SAP", Repository: "jenkins-library", PullRequest: 42, ctx: ctx, issues: issues}
+
+		err := decorator.UpsertComment("<!-- marker -->", "<!-- marker -->\nnew report")
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[int64]string{2: "<!-- marker -->\nnew report"}, issues.edited)
+		assert.Empty(t, issues.created)
+	})
+
+	t.Run("create comment", func(t *testing.T) {
+		issues := &ghIssueServiceMock{pages: [][]*github.IssueComment{{issueComment(1, "looks good")}}}
+		decorator := &GitHubDecorator{Owner: "SAP", Repository: "jenkins-library", PullRequest: 42, ctx: ctx, issues: issues}
+
+		err := decorator.UpsertComment("<!-- marker -->", "<!-- marker -->\nnew report")
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"<!-- marker -->\nnew report"}, issues.created)
+		assert.Empty(t, issues.edited)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		issues := &ghIssueServiceMock{listError: fmt.Errorf("bad credentials")}
+		decorator := &GitHubDecorator{Owner: "SAP", Repository: "jenkins-library", PullRequest: 42, ctx: ctx, issues: issues}
+
+		err := decorator.UpsertComment("<!-- marker -->", "body")
+
+		assert.EqualError(t, err, "failed to list comments of pull request 42: bad credentials")
+	})
+}
+
+func TestGitHubLineComments(t *testing.T) {
+	ctx := context.Background()
+	body := "existing finding"
+	pullRequests := &ghPullRequestServiceMock{comments: []*github.PullRequestComment{{Body: &body}}}
+	decorator := &GitHubDecorator{Owner: "SAP", Repository: "jenkins-library", PullRequest: 42, ctx: ctx, pullRequests: pullRequests}
+
+	bodies, err := decorator.LineCommentBodies()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"existing finding"}, bodies)
+
+	assert.NoError(t, decorator.CreateLineComment(LineComment{Path: "cmd/main.go", Line: 12, Body: "finding 1"}))
+	assert.NoError(t, decorator.CreateLineComment(LineComment{Path: "cmd/main.go", Line: 14, Body: "finding 2"}))
+
+	assert.Equal(t, 1, pullRequests.getCalls)
+	if assert.Len(t, pullRequests.created, 2) {
+		comment := pullRequests.created[0]
+		assert.Equal(t, "abc123", comment.GetCommitID())
+		assert.Equal(t, "cmd/main.go", comment.GetPath())
+		assert.Equal(t, 12, comment.GetLine())
+		assert.Equal(t, "RIGHT", comment.GetSide())
+		assert.Equal(t, "finding 1", comment.GetBody())
+	}
+}
+
+func commitFile(name, patch string) *github.CommitFile {
+	return &github.CommitFile{Filename: &name, Patch: &patch}
+}
+
+func TestGitHubChanges(t *testing.T) {
+	pullRequests := &ghPullRequestServiceMock{files: [][]*github.CommitFile{
+		{commitFile("cmd/main.go", "@@ -10,6 +10,8 @@ func main() {\n context\n+added\n@@ -40 +42 @@\n-old\n+new")},
+		{commitFile("pkg/removed.go", "@@ -1,3 +0,0 @@\n-package pkg"), commitFile("logo.png", "")},
+	}}
+	decorator := &GitHubDecorator{Owner: "SAP", Repository: "jenkins-library", PullRequest: 42, ctx: context.Background(), pullRequests: pullRequests}
+
+	changes, err := decorator.Changes()
+
+	assert.NoError(t, err)
+	assert.Equal(t, Changes{"cmd/main.go": {{Start: 10, End: 17}, {Start: 42, End: 42}}}, changes)
+	assert.True(t, changes.Contains("/cmd/main.go", 17))
+	assert.False(t, changes.Contains("cmd/main.go", 18))
+	assert.False(t, changes.Contains("pkg/removed.go", 1))
+}
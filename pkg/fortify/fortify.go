@@ -19,6 +19,7 @@ import (
 	"github.com/piper-validation/fortify-client-go/fortify/file_token_controller"
 	"github.com/piper-validation/fortify-client-go/fortify/filter_set_of_project_version_controller"
 	"github.com/piper-validation/fortify-client-go/fortify/issue_group_of_project_version_controller"
+	"github.com/piper-validation/fortify-client-go/fortify/issue_of_project_version_controller"
 	"github.com/piper-validation/fortify-client-go/fortify/issue_selector_set_of_project_version_controller"
 	"github.com/piper-validation/fortify-client-go/fortify/issue_statistics_of_project_version_controller"
 	"github.com/piper-validation/fortify-client-go/fortify/project_controller"
@@ -40,6 +41,9 @@ import (
 // ReportsDirectory defines the subfolder for the Fortify reports which are generated
 const ReportsDirectory = "fortify"
 
+// issuePageSize defines the number of issues fetched per request
+const issuePageSize = 500
+
 // System is the interface abstraction of a specific SystemInstance
 type System interface {
 	GetProjectByName(name string, autoCreate bool, projectVersion string) (*models.Project, error)
@@ -61,6 +65,7 @@ type System interface {
 	GetProjectIssuesByIDAndFilterSetGroupedBySelector(id int64, filter, filterSetGUID string, issueFilterSelectorSet *models.IssueFilterSelectorSet) ([]*models.ProjectVersionIssueGroup, error)
 	ReduceIssueFilterSelectorSet(issueFilterSelectorSet *models.IssueFilterSelectorSet, names []string, options []string) *models.IssueFilterSelectorSet
 	GetIssueStatisticsOfProjectVersion(id int64) ([]*models.IssueStatistics, error)
+	GetIssuesOfProjectVersion(id int64, filterSetGUID string) ([]*models.ProjectVersionIssue, error)
 	GenerateQGateReport(projectID, projectVersionID, reportTemplateID int64, projectName, projectVersionName, reportFormat string) (*models.SavedReport, error)
 	GetReportDetails(id int64) (*models.SavedReport, error)
 	UploadResultFile(endpoint, file string, projectVersionID int64) error
@@ -599,6 +604,30 @@ func (sys *SystemInstance) GetIssueStatisticsOfProjectVersion(id int64) ([]*mode
 	return result.GetPayload().Data, nil
 }
 
+// GetIssuesOfProjectVersion returns all issues including suppressed ones of the project version addressed with id filtered with the respective set
+func (sys *SystemInstance) GetIssuesOfProjectVersion(id int64, filterSetGUID string) ([]*models.ProjectVersionIssue, error) {
+	issues := []*models.ProjectVersionIssue{}
+	enable := true
+	limit := int32(issuePageSize)
+	for {
+		start := int32(len(issues))
+		params := &issue_of_project_version_controller.ListIssueOfProjectVersionParams{ParentID: id, Showsuppressed: &enable, Start: &start, Limit: &limit}
+		params.WithTimeout(sys.timeout)
+		if len(filterSetGUID) > 0 {
+			params.WithFilterset(&filterSetGUID)
+		}
+		result, err := sys.client.IssueOfProjectVersionController.ListIssueOfProjectVersion(params, sys)
+		if err != nil {
+			return nil, err
+		}
+		payload := result.GetPayload()
+		issues = append(issues, payload.Data...)
+		if len(payload.Data) == 0 || int64(len(issues)) >= payload.Count {
+			return issues, nil
+		}
+	}
+}
+
 // GenerateQGateReport returns the issue statistics related to the project version addressed with id
 func (sys *SystemInstance) GenerateQGateReport(projectID, projectVersionID, reportTemplateID int64, projectName, projectVersionName, reportFormat string) (*models.SavedReport, error) {
 	paramIdentifier := "projectVersionId"
@@ -837,6 +837,39 @@ func TestGetIssueStatisticsOfProjectVersion(t *testing.T) {
 	})
 }
 
+func TestGetIssuesOfProjectVersion(t *testing.T) {
+	// Start a local HTTP server
+	requests := []string{}
+	sys, server := spinUpServer(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/projectVersions/10172/issues" {
+			requests = append(requests, req.URL.RawQuery)
+			header := rw.Header()
+			header.Add("Content-type", "application/json")
+			if req.URL.Query().Get("start") == "0" {
+				rw.Write([]byte(`{"data": [{"id": 1, "issueName": "SQL Injection", "fullFileName": "src/main/java/Dao.java", "lineNumber": 42, "friority": "Critical"}],"count": 2,"responseCode": 200}`))
+				return
+			}
+			rw.Write([]byte(`{"data": [{"id": 2, "issueName": "Log Forging", "primaryLocation": "Logger.java", "suppressed": true}],"count": 2,"responseCode": 200}`))
+			return
+		}
+	})
+	// Close the server when test finishes
+	defer server.Close()
+
+	t.Run("test success", func(t *testing.T) {
+		result, err := sys.GetIssuesOfProjectVersion(10172, "a243b195-0a59-3f8b-1403-d55b7a7d78e6")
+		assert.NoError(t, err, "GetIssuesOfProjectVersion call not successful")
+		if assert.Equal(t, 2, len(result), "Different result content expected") {
+			assert.Equal(t, int64(2), result[1].ID, "Different result content expected")
+		}
+		if assert.Len(t, requests, 2) {
+			assert.Contains(t, requests[0], "filterset=a243b195-0a59-3f8b-1403-d55b7a7d78e6")
+			assert.Contains(t, requests[0], "showsuppressed=true")
+			assert.Contains(t, requests[1], "start=1")
+		}
+	})
+}
+
 func TestGenerateQGateReport(t *testing.T) {
 	// Start a local HTTP server
 	data := ""
@@ -48,7 +48,8 @@ type SpotChecksAuditCount struct {
 func CreateCustomReport(data FortifyReportData, issueGroups []*models.ProjectVersionIssueGroup) reporting.ScanReport {
 
 	scanReport := reporting.ScanReport{
-		Title: "Fortify SAST Report",
+		StepName: "fortifyExecuteScan",
+		Title:    "Fortify SAST Report",
 		Subheaders: []reporting.Subheader{
 			{Description: "Fortify project name", Details: data.ProjectName},
 			{Description: "Fortify project version", Details: data.ProjectVersion},
@@ -83,6 +84,7 @@ func CreateCustomReport(data FortifyReportData, issueGroups []*models.ProjectVer
 		row.AddColumn(fmt.Sprint(*group.AuditedCount), 0)
 
 		detailTable.Rows = append(detailTable.Rows, row)
+		scanReport.AddFinding(issueGroupFinding(group))
 	}
 
 	scanReport.DetailTable = detailTable
@@ -91,6 +93,76 @@ func CreateCustomReport(data FortifyReportData, issueGroups []*models.ProjectVer
 	return scanReport
 }
 
+// issueGroupFinding represents an issue group as finding since single issues are not retrieved from Fortify.
+// The finding counts the unaudited issues of the group, completely audited groups are suppressed.
+// Groups of the analysis tags Suspicious and Exploitable count all their issues since these are audited by definition.
+func issueGroupFinding(group *models.ProjectVersionIssueGroup) reporting.Finding {
+	unaudited := *group.TotalCount - *group.AuditedCount
+	count := unaudited
+	if isAnalysisViolationGroup(*group.CleanName) {
+		count = *group.TotalCount
+	}
+	return reporting.Finding{
+		RuleID:      *group.ID,
+		Title:       *group.CleanName,
+		Description: fmt.Sprintf("%v of %v issues not audited", unaudited, *group.TotalCount),
+		Severity:    issueGroupSeverity(*group.CleanName),
+		Category:    reporting.CategorySAST,
+		Count:       int(count),
+		Suppressed:  count <= 0,
+	}
+}
+
+// issueGroupSeverity derives the severity of an issue group from the name of its folder or analysis tag.
+// Folders of the Quick View filter set are named by their Friority, the folders of the SAP filter set are mapped to the severity of their audit requirement.
+func issueGroupSeverity(name string) reporting.Severity {
+	switch name {
+	case "Corporate Security Requirements", "Exploitable":
+		return reporting.SeverityHigh
+	case "Audit All", "Suspicious":
+		return reporting.SeverityMedium
+	case "Spot Checks of Each Category":
+		return reporting.SeverityLow
+	}
+	return reporting.ParseSeverity(name)
+}
+
+func isAnalysisViolationGroup(name string) bool {
+	return name == "Suspicious" || name == "Exploitable"
+}
+
+// IssueFinding converts a single issue of a project version into a tool independent finding
+func IssueFinding(issue *models.ProjectVersionIssue) reporting.Finding {
+	finding := reporting.Finding{
+		RuleID:   StringValue(issue.IssueName),
+		Title:    StringValue(issue.IssueName),
+		Severity: reporting.ParseSeverity(StringValue(issue.Friority)),
+		Category: reporting.CategorySAST,
+		Location: StringValue(issue.FullFileName),
+	}
+	if len(finding.Location) == 0 {
+		finding.Location = StringValue(issue.PrimaryLocation)
+	}
+	if issue.LineNumber != nil {
+		finding.Line = int(*issue.LineNumber)
+	}
+	if issue.Kingdom != nil {
+		finding.Description = *issue.Kingdom
+	}
+	if issue.Suppressed != nil {
+		finding.Suppressed = *issue.Suppressed
+	}
+	return finding
+}
+
+// StringValue returns the value of an optional string of the Fortify API, empty in case it is not set
+func StringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
 func CreateJSONReport(reportData FortifyReportData, spotChecksCountByCategory []SpotChecksAuditCount, serverURL string) FortifyReportData {
 	reportData.AtleastOneSpotChecksCategoryAudited = true
 	for _, spotChecksElement := range spotChecksCountByCategory {
@@ -164,6 +236,11 @@ func WriteCustomReports(scanReport reporting.ScanReport, projectName string, pro
 	return reportPaths, nil
 }
 
+// ResultIssueScope identifies the GitHub issues of the findings of a project, it matches the name of the json report
+func ResultIssueScope(projectName, projectVersion string) string {
+	return fmt.Sprintf("fortifyExecuteScan_sast_%v", reportShaFortify([]string{projectName, projectVersion}))
+}
+
 func reportShaFortify(parts []string) string {
 	reportShaData := []byte(strings.Join(parts, ","))
 	return fmt.Sprintf("%x", sha1.Sum(reportShaData))
@@ -0,0 +1,62 @@
+package fortify
+
+import (
+	"testing"
+
+	"github.com/SAP/jenkins-library/pkg/reporting"
+	"github.com/piper-validation/fortify-client-go/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateCustomReport(t *testing.T) {
+	id1, name1, total1, audited1 := "1", "Medium", int32(3), int32(1)
+	id2, name2, total2, audited2 := "2", "Audit All", int32(2), int32(2)
+	id3, name3, total3, audited3 := "4", "Exploitable", int32(2), int32(2)
+	issueGroups := []*models.ProjectVersionIssueGroup{
+		{ID: &id1, CleanName: &name1, TotalCount: &total1, AuditedCount: &audited1},
+		{ID: &id2, CleanName: &name2, TotalCount: &total2, AuditedCount: &audited2},
+		{ID: &id3, CleanName: &name3, TotalCount: &total3, AuditedCount: &audited3},
+	}
+
+	scanReport := CreateCustomReport(FortifyReportData{Violations: 2}, issueGroups)
+
+	assert.False(t, scanReport.SuccessfulScan)
+	assert.Equal(t, 3, len(scanReport.DetailTable.Rows))
+	assert.Equal(t, []reporting.Finding{
+		{RuleID: "1", Title: "Medium", Description: "2 of 3 issues not audited", Severity: reporting.SeverityMedium, Category: reporting.CategorySAST, Count: 2},
+		{RuleID: "2", Title: "Audit All", Description: "0 of 2 issues not audited", Severity: reporting.SeverityMedium, Category: reporting.CategorySAST, Suppressed: true},
+		{RuleID: "4", Title: "Exploitable", Description: "0 of 2 issues not audited", Severity: reporting.SeverityHigh, Category: reporting.CategorySAST, Count: 2},
+	}, scanReport.Findings)
+
+}
+
+func TestIssueGroupSeverity(t *testing.T) {
+	assert.Equal(t, reporting.SeverityCritical, issueGroupSeverity("Critical"))
+	assert.Equal(t, reporting.SeverityLow, issueGroupSeverity("Low"))
+	assert.Equal(t, reporting.SeverityHigh, issueGroupSeverity("Corporate Security Requirements"))
+	assert.Equal(t, reporting.SeverityLow, issueGroupSeverity("Spot Checks of Each Category"))
+	assert.Equal(t, reporting.SeverityMedium, issueGroupSeverity("Suspicious"))
+}
+
+func TestIssueFinding(t *testing.T) {
+	name, friority, fileName, location, kingdom := "SQL Injection", "Critical", "src/main/java/Dao.java", "Dao.java", "Input Validation and Representation"
+	line := int32(42)
+	suppressed := true
+
+	t.Run("source code issue", func(t *testing.T) {
+		finding := IssueFinding(&models.ProjectVersionIssue{IssueName: &name, Friority: &friority, FullFileName: &fileName, PrimaryLocation: &location, LineNumber: &line, Kingdom: &kingdom})
+		assert.Equal(t, reporting.Finding{RuleID: "SQL Injection", Title: "SQL Injection", Description: "Input Validation and Representation", Severity: reporting.SeverityCritical, Category: reporting.CategorySAST, Location: "src/main/java/Dao.java", Line: 42}, finding)
+	})
+
+	t.Run("suppressed issue without file name", func(t *testing.T) {
+		finding := IssueFinding(&models.ProjectVersionIssue{IssueName: &name, PrimaryLocation: &location, Suppressed: &suppressed})
+		assert.Equal(t, "Dao.java", finding.Location)
+		assert.Equal(t, reporting.SeverityInfo, finding.Severity)
+		assert.True(t, finding.Suppressed)
+	})
+}
+
+func TestResultIssueScope(t *testing.T) {
+	assert.Equal(t, "fortifyExecuteScan_sast_"+reportShaFortify([]string{"project", "master"}), ResultIssueScope("project", "master"))
+	assert.NotEqual(t, ResultIssueScope("project", "master"), ResultIssueScope("project", "PR-1"))
+}
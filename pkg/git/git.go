@@ -3,6 +3,7 @@ package git
 import (
 	"github.com/SAP/jenkins-library/pkg/log"
 	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/go-git/go-git/v5/plumbing/transport/http"
@@ -68,6 +69,32 @@ func pushChangesToRepository(username, password string, repository utilsReposito
 	return nil
 }
 
+// PushBranchToRepository Pushes the local branch to the branch with the same name in the remote repository.
+// In case force is set an existing remote branch is overwritten.
+func PushBranchToRepository(username, password, branchName string, force bool, repository *git.Repository) error {
+	return pushBranchToRepository(username, password, branchName, force, repository)
+}
+
+func pushBranchToRepository(username, password, branchName string, force bool, repository utilsRepository) error {
+	if branchName == "" {
+		return errors.New("no branch name provided")
+	}
+	reference := plumbing.NewBranchReferenceName(branchName)
+	refSpec := config.RefSpec(reference + ":" + reference)
+	if force {
+		refSpec = "+" + refSpec
+	}
+	pushOptions := &git.PushOptions{
+		RefSpecs: []config.RefSpec{refSpec},
+		Auth:     &http.BasicAuth{Username: username, Password: password},
+	}
+	err := repository.Push(pushOptions)
+	if err != nil {
+		return errors.Wrapf(err, "failed to push branch '%v'", branchName)
+	}
+	return nil
+}
+
 // PlainClone Clones a non-bare repository to the provided directory
 func PlainClone(username, password, serverURL, directory string) (*git.Repository, error) {
 	abstractedGit := &abstractionGit{}
@@ -59,6 +59,40 @@ func TestPushChangesToRepository(t *testing.T) {
 	})
 }
 
+func TestPushBranchToRepository(t *testing.T) {
+	t.Parallel()
+	t.Run("successful forced push", func(t *testing.T) {
+		t.Parallel()
+		repository := &RepositoryPushMock{}
+		err := pushBranchToRepository("user", "password", "gitops/myApp-1.0", true, repository)
+		assert.NoError(t, err)
+		assert.Equal(t, "http-basic-auth - user:*******", repository.options.Auth.String())
+		if assert.Len(t, repository.options.RefSpecs, 1) {
+			assert.Equal(t, "+refs/heads/gitops/myApp-1.0:refs/heads/gitops/myApp-1.0", repository.options.RefSpecs[0].String())
+		}
+	})
+
+	t.Run("successful push", func(t *testing.T) {
+		t.Parallel()
+		repository := &RepositoryPushMock{}
+		err := pushBranchToRepository("user", "password", "main", false, repository)
+		assert.NoError(t, err)
+		assert.Equal(t, "refs/heads/main:refs/heads/main", repository.options.RefSpecs[0].String())
+	})
+
+	t.Run("empty branch raises error", func(t *testing.T) {
+		t.Parallel()
+		err := pushBranchToRepository("user", "password", "", true, &RepositoryPushMock{})
+		assert.EqualError(t, err, "no branch name provided")
+	})
+
+	t.Run("error pushing", func(t *testing.T) {
+		t.Parallel()
+		err := pushBranchToRepository("user", "password", "main", true, RepositoryMockError{})
+		assert.EqualError(t, err, "failed to push branch 'main': error on push commits")
+	})
+}
+
 func TestPlainClone(t *testing.T) {
 	t.Parallel()
 	t.Run("successful clone", func(t *testing.T) {
@@ -345,6 +379,19 @@ func (r RepositoryMock) Push(o *git.PushOptions) error {
 	return nil
 }
 
+type RepositoryPushMock struct {
+	options *git.PushOptions
+}
+
+func (r *RepositoryPushMock) Worktree() (*git.Worktree, error) {
+	return &git.Worktree{}, nil
+}
+
+func (r *RepositoryPushMock) Push(o *git.PushOptions) error {
+	r.options = o
+	return nil
+}
+
 type RepositoryMockError struct{}
 
 func (RepositoryMockError) Worktree() (*git.Worktree, error) {
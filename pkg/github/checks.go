@@ -0,0 +1,242 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/reporting"
+	"github.com/google/go-github/v32/github"
+	"github.com/pkg/errors"
+)
+
+// maxAnnotationsPerRequest is the maximum number of annotations GitHub accepts per request, further annotations are appended by subsequent updates
+const maxAnnotationsPerRequest = 50
+
+// maxSummaryLength is the maximum length of summary and text of a check run output
+const maxSummaryLength = 65535
+
+// Status values and conclusions of check runs
+const (
+	CheckRunStatusQueued     = "queued"
+	CheckRunStatusInProgress = "in_progress"
+	CheckRunStatusCompleted  = "completed"
+
+	CheckRunConclusionSuccess        = "success"
+	CheckRunConclusionFailure        = "failure"
+	CheckRunConclusionNeutral        = "neutral"
+	CheckRunConclusionActionRequired = "action_required"
+)
+
+// ChecksService is the part of the GitHub checks API used for check runs
+type ChecksService interface {
+	CreateCheckRun(ctx context.Context, owner, repo string, opts github.CreateCheckRunOptions) (*github.CheckRun, *github.Response, error)
+	UpdateCheckRun(ctx context.Context, owner, repo string, checkRunID int64, opts github.UpdateCheckRunOptions) (*github.CheckRun, *github.Response, error)
+	ListCheckRunsForRef(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error)
+	ReRequestCheckSuite(ctx context.Context, owner, repo string, checkSuiteID int64) (*github.Response, error)
+}
+
+// CheckRun is a check run of a commit identified by its name
+type CheckRun struct {
+	Owner      string
+	Repository string
+	HeadSHA    string
+	Name       string
+	ID         int64
+	SuiteID    int64
+	ctx        context.Context
+	checks     ChecksService
+}
+
+// CheckRunUpdate contains the new state of a check run, empty values are not changed
+type CheckRunUpdate struct {
+	Status      string
+	Conclusion  string
+	DetailsURL  string
+	Title       string
+	Summary     string
+	Text        string
+	Annotations []*github.CheckRunAnnotation
+}
+
+// NewCheckRun returns the check run with the name for the commit, it is created with the first update unless it is found before
+func NewCheckRun(ctx context.Context, checks ChecksService, owner, repository, headSHA, name string) *CheckRun {
+	return &CheckRun{Owner: owner, Repository: repository, HeadSHA: headSHA, Name: name, ctx: ctx, checks: checks}
+}
+
+// Find looks up the latest check run with the name for the commit and returns whether it exists
+func (c *CheckRun) Find() (bool, error) {
+	result, _, err := c.checks.ListCheckRunsForRef(c.ctx, c.Owner, c.Repository, c.HeadSHA, &github.ListCheckRunsOptions{CheckName: &c.Name})
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to list check runs of commit %v", c.HeadSHA)
+	}
+	for _, run := range result.CheckRuns {
+		if run.GetName() == c.Name {
+			c.ID = run.GetID()
+			c.SuiteID = run.GetCheckSuite().GetID()
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Update creates or updates the check run. Annotations are sent in batches since GitHub limits their number per request.
+func (c *CheckRun) Update(update CheckRunUpdate) error {
+	batches := annotationBatches(update.Annotations)
+	output := c.output(update, batches[0])
+
+	var run *github.CheckRun
+	var err error
+	if c.ID == 0 {
+		options := github.CreateCheckRunOptions{
+			Name:       c.Name,
+			HeadSHA:    c.HeadSHA,
+			Status:     stringOrNil(update.Status),
+			Conclusion: stringOrNil(update.Conclusion),
+			DetailsURL: stringOrNil(update.DetailsURL),
+			Output:     output,
+		}
+		if update.Status == CheckRunStatusInProgress {
+			options.StartedAt = &github.Timestamp{Time: time.Now()}
+		}
+		if len(update.Conclusion) > 0 {
+			options.CompletedAt = &github.Timestamp{Time: time.Now()}
+		}
+		run, _, err = c.checks.CreateCheckRun(c.ctx, c.Owner, c.Repository, options)
+		if err != nil {
+			return errors.Wrapf(err, "failed to create check run '%v'", c.Name)
+		}
+		c.ID = run.GetID()
+		c.SuiteID = run.GetCheckSuite().GetID()
+	} else {
+		if _, _, err = c.checks.UpdateCheckRun(c.ctx, c.Owner, c.Repository, c.ID, c.updateOptions(update, output)); err != nil {
+			return errors.Wrapf(err, "failed to update check run '%v'", c.Name)
+		}
+	}
+
+	for _, batch := range batches[1:] {
+		options := github.UpdateCheckRunOptions{Name: c.Name, Output: &github.CheckRunOutput{Title: output.Title, Summary: output.Summary, Annotations: batch}}
+		if _, _, err := c.checks.UpdateCheckRun(c.ctx, c.Owner, c.Repository, c.ID, options); err != nil {
+			return errors.Wrapf(err, "failed to add annotations to check run '%v'", c.Name)
+		}
+	}
+	return nil
+}
+
+func (c *CheckRun) updateOptions(update CheckRunUpdate, output *github.CheckRunOutput) github.UpdateCheckRunOptions {
+	options := github.UpdateCheckRunOptions{
+		Name:       c.Name,
+		Status:     stringOrNil(update.Status),
+		Conclusion: stringOrNil(update.Conclusion),
+		DetailsURL: stringOrNil(update.DetailsURL),
+		Output:     output,
+	}
+	if len(update.Conclusion) > 0 {
+		options.CompletedAt = &github.Timestamp{Time: time.Now()}
+	}
+	return options
+}
+
+// ReRequest requests GitHub to re-run the check suite of the check run
+func (c *CheckRun) ReRequest() error {
+	if c.SuiteID == 0 {
+		return fmt.Errorf("check run '%v' does not belong to a known check suite", c.Name)
+	}
+	_, err := c.checks.ReRequestCheckSuite(c.ctx, c.Owner, c.Repository, c.SuiteID)
+	return errors.Wrapf(err, "failed to re-request check suite %v", c.SuiteID)
+}
+
+// ConclusionFromErrorCategory maps the error category of a failed step to the conclusion of its check run
+func ConclusionFromErrorCategory(failed bool, category log.ErrorCategory) string {
+	if !failed {
+		return CheckRunConclusionSuccess
+	}
+	if category == log.ErrorConfiguration {
+		return CheckRunConclusionActionRequired
+	}
+	return CheckRunConclusionFailure
+}
+
+// AnnotationsFromFindings creates annotations for all unsuppressed findings with file and line information
+func AnnotationsFromFindings(findings []reporting.Finding) []*github.CheckRunAnnotation {
+	annotations := []*github.CheckRunAnnotation{}
+	for _, finding := range findings {
+		if finding.Suppressed || len(finding.Location) == 0 || finding.Line <= 0 {
+			continue
+		}
+		path := finding.Location
+		line := finding.Line
+		level := annotationLevel(finding.Severity)
+		title := finding.Title
+		message := finding.Title
+		if len(finding.Description) > 0 {
+			message = finding.Description
+		}
+		if len(finding.URL) > 0 {
+			message += "\n" + finding.URL
+		}
+		annotations = append(annotations, &github.CheckRunAnnotation{
+			Path:            &path,
+			StartLine:       &line,
+			EndLine:         &line,
+			AnnotationLevel: &level,
+			Title:           &title,
+			Message:         &message,
+		})
+	}
+	return annotations
+}
+
+func annotationLevel(severity reporting.Severity) string {
+	switch severity {
+	case reporting.SeverityCritical, reporting.SeverityHigh:
+		return "failure"
+	case reporting.SeverityMedium:
+		return "warning"
+	default:
+		return "notice"
+	}
+}
+
+func annotationBatches(annotations []*github.CheckRunAnnotation) [][]*github.CheckRunAnnotation {
+	batches := [][]*github.CheckRunAnnotation{}
+	for len(annotations) > maxAnnotationsPerRequest {
+		batches = append(batches, annotations[:maxAnnotationsPerRequest])
+		annotations = annotations[maxAnnotationsPerRequest:]
+	}
+	return append(batches, annotations)
+}
+
+// output returns the output of the check run, GitHub requires a title and a summary if annotations are provided
+func (c *CheckRun) output(update CheckRunUpdate, annotations []*github.CheckRunAnnotation) *github.CheckRunOutput {
+	if len(update.Title) == 0 && len(update.Summary) == 0 && len(annotations) == 0 {
+		return nil
+	}
+	title := update.Title
+	if len(title) == 0 {
+		title = c.Name
+	}
+	summary := truncate(update.Summary)
+	output := &github.CheckRunOutput{Title: &title, Summary: &summary, Annotations: annotations}
+	if len(update.Text) > 0 {
+		text := truncate(update.Text)
+		output.Text = &text
+	}
+	return output
+}
+
+func truncate(content string) string {
+	if len(content) <= maxSummaryLength {
+		return content
+	}
+	log.Entry().Warnf("Check run output is truncated to %v characters", maxSummaryLength)
+	return content[:maxSummaryLength]
+}
+
+func stringOrNil(value string) *string {
+	if len(value) == 0 {
+		return nil
+	}
+	return &value
+}
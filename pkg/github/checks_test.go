@@ -0,0 +1,162 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/reporting"
+	"github.com/google/go-github/v32/github"
+	"github.com/stretchr/testify/assert"
+)
+
+type checksServiceMock struct {
+	existing     []*github.CheckRun
+	created      []github.CreateCheckRunOptions
+	updated      []github.UpdateCheckRunOptions
+	reRequested  int64
+	serviceError error
+}
+
+func (c *checksServiceMock) CreateCheckRun(ctx context.Context, owner, repo string, opts github.CreateCheckRunOptions) (*github.CheckRun, *github.Response, error) {
+	if c.serviceError != nil {
+		return nil, nil, c.serviceError
+	}
+	c.created = append(c.created, opts)
+	id, suiteID := int64(1), int64(10)
+	return &github.CheckRun{ID: &id, CheckSuite: &github.CheckSuite{ID: &suiteID}}, nil, nil
+}
+
+func (c *checksServiceMock) UpdateCheckRun(ctx context.Context, owner, repo string, checkRunID int64, opts github.UpdateCheckRunOptions) (*github.CheckRun, *github.Response, error) {
+	if c.serviceError != nil {
+		return nil, nil, c.serviceError
+	}
+	c.updated = append(c.updated, opts)
+	return &github.CheckRun{ID: &checkRunID}, nil, nil
+}
+
+func (c *checksServiceMock) ListCheckRunsForRef(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+	if c.serviceError != nil {
+		return nil, nil, c.serviceError
+	}
+	return &github.ListCheckRunsResults{CheckRuns: c.existing}, nil, nil
+}
+
+func (c *checksServiceMock) ReRequestCheckSuite(ctx context.Context, owner, repo string, checkSuiteID int64) (*github.Response, error) {
+	c.reRequested = checkSuiteID
+	return nil, c.serviceError
+}
+
+func testAnnotations(count int) []*github.CheckRunAnnotation {
+	annotations := []*github.CheckRunAnnotation{}
+	for i := 0; i < count; i++ {
+		path := fmt.Sprintf("file%v.go", i)
+		annotations = append(annotations, &github.CheckRunAnnotation{Path: &path})
+	}
+	return annotations
+}
+
+func TestCheckRunUpdate(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("create with annotations in batches", func(t *testing.T) {
+		checks := &checksServiceMock{}
+		checkRun := NewCheckRun(ctx, checks, "SAP", "jenkins-library", "abc123", "scan")
+
+		err := checkRun.Update(CheckRunUpdate{Status: CheckRunStatusCompleted, Conclusion: CheckRunConclusionFailure, Summary: "120 findings", Annotations: testAnnotations(120)})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), checkRun.ID)
+		assert.Equal(t, int64(10), checkRun.SuiteID)
+		if assert.Len(t, checks.created, 1) {
+			assert.Equal(t, "scan", checks.created[0].Output.GetTitle())
+			assert.Len(t, checks.created[0].Output.Annotations, 50)
+			assert.NotNil(t, checks.created[0].CompletedAt)
+			assert.Nil(t, checks.created[0].StartedAt)
+		}
+		if assert.Len(t, checks.updated, 2) {
+			assert.Len(t, checks.updated[0].Output.Annotations, 50)
+			assert.Len(t, checks.updated[1].Output.Annotations, 20)
+			assert.Equal(t, "file119.go", checks.updated[1].Output.Annotations[19].GetPath())
+			assert.Equal(t, "120 findings", checks.updated[1].Output.GetSummary())
+		}
+	})
+
+	t.Run("update existing check run", func(t *testing.T) {
+		id, name := int64(4711), "scan"
+		checks := &checksServiceMock{existing: []*github.CheckRun{{ID: &id, Name: &name}}}
+		checkRun := NewCheckRun(ctx, checks, "SAP", "jenkins-library", "abc123", "scan")
+
+		found, err := checkRun.Find()
+		assert.NoError(t, err)
+		assert.True(t, found)
+
+		err = checkRun.Update(CheckRunUpdate{Status: CheckRunStatusInProgress})
+
+		assert.NoError(t, err)
+		assert.Empty(t, checks.created)
+		if assert.Len(t, checks.updated, 1) {
+			assert.Equal(t, CheckRunStatusInProgress, checks.updated[0].GetStatus())
+			assert.Nil(t, checks.updated[0].Output)
+			assert.Nil(t, checks.updated[0].CompletedAt)
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		checks := &checksServiceMock{serviceError: fmt.Errorf("bad credentials")}
+		checkRun := NewCheckRun(ctx, checks, "SAP", "jenkins-library", "abc123", "scan")
+
+		_, err := checkRun.Find()
+		assert.EqualError(t, err, "failed to list check runs of commit abc123: bad credentials")
+		err = checkRun.Update(CheckRunUpdate{Status: CheckRunStatusQueued})
+		assert.EqualError(t, err, "failed to create check run 'scan': bad credentials")
+	})
+}
+
+func TestCheckRunReRequest(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success", func(t *testing.T) {
+		checks := &checksServiceMock{}
+		checkRun := NewCheckRun(ctx, checks, "SAP", "jenkins-library", "abc123", "scan")
+		checkRun.SuiteID = 42
+
+		assert.NoError(t, checkRun.ReRequest())
+		assert.Equal(t, int64(42), checks.reRequested)
+	})
+
+	t.Run("unknown check suite", func(t *testing.T) {
+		checkRun := NewCheckRun(ctx, &checksServiceMock{}, "SAP", "jenkins-library", "abc123", "scan")
+
+		assert.EqualError(t, checkRun.ReRequest(), "check run 'scan' does not belong to a known check suite")
+	})
+}
+
+func TestConclusionFromErrorCategory(t *testing.T) {
+	assert.Equal(t, CheckRunConclusionSuccess, ConclusionFromErrorCategory(false, log.ErrorUndefined))
+	assert.Equal(t, CheckRunConclusionActionRequired, ConclusionFromErrorCategory(true, log.ErrorConfiguration))
+	assert.Equal(t, CheckRunConclusionFailure, ConclusionFromErrorCategory(true, log.ErrorCompliance))
+	assert.Equal(t, CheckRunConclusionFailure, ConclusionFromErrorCategory(true, log.ErrorUndefined))
+}
+
+func TestAnnotationsFromFindings(t *testing.T) {
+	annotations := AnnotationsFromFindings([]reporting.Finding{
+		{Title: "SQL Injection", Severity: reporting.SeverityCritical, Location: "src/Dao.java", Line: 42, URL: "https://cx/result/1"},
+		{Title: "Unused variable", Description: "remove the variable", Severity: reporting.SeverityMedium, Location: "main.go", Line: 3},
+		{Title: "Outdated library", Severity: reporting.SeverityLow, Location: "go.mod"},
+		{Title: "Suppressed", Severity: reporting.SeverityHigh, Location: "main.go", Line: 7, Suppressed: true},
+		{Title: "Style", Severity: reporting.SeverityInfo, Location: "main.go", Line: 9},
+	})
+
+	if assert.Len(t, annotations, 3) {
+		assert.Equal(t, "src/Dao.java", annotations[0].GetPath())
+		assert.Equal(t, 42, annotations[0].GetStartLine())
+		assert.Equal(t, 42, annotations[0].GetEndLine())
+		assert.Equal(t, "failure", annotations[0].GetAnnotationLevel())
+		assert.Equal(t, "SQL Injection\nhttps://cx/result/1", annotations[0].GetMessage())
+		assert.Equal(t, "warning", annotations[1].GetAnnotationLevel())
+		assert.Equal(t, "remove the variable", annotations[1].GetMessage())
+		assert.Equal(t, "notice", annotations[2].GetAnnotationLevel())
+	}
+}
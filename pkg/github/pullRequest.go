@@ -0,0 +1,167 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/google/go-github/v32/github"
+	"github.com/pkg/errors"
+)
+
+// PullRequestCreator is the part of the GitHub pull request service required to create pull requests
+type PullRequestCreator interface {
+	Create(ctx context.Context, owner string, repo string, pull *github.NewPullRequest) (*github.PullRequest, *github.Response, error)
+}
+
+// PullRequestService is the part of the GitHub pull request service required to propose changes
+type PullRequestService interface {
+	PullRequestCreator
+	List(ctx context.Context, owner string, repo string, opts *github.PullRequestListOptions) ([]*github.PullRequest, *github.Response, error)
+}
+
+// IssueEditor is the part of the GitHub issue service required to set labels and assignees of pull requests
+type IssueEditor interface {
+	Edit(ctx context.Context, owner string, repo string, number int, issue *github.IssueRequest) (*github.Issue, *github.Response, error)
+}
+
+// GraphQLClient sends requests to the GitHub GraphQL API, it is implemented by the GitHub client
+type GraphQLClient interface {
+	NewRequest(method, urlStr string, body interface{}) (*http.Request, error)
+	Do(ctx context.Context, req *http.Request, v interface{}) (*github.Response, error)
+}
+
+// CreatePullRequestOptions defines the pull request to be created
+type CreatePullRequestOptions struct {
+	Owner      string
+	Repository string
+	Title      string
+	Body       string
+	Head       string
+	Base       string
+	Labels     []string
+	Assignees  []string
+}
+
+// CreatePullRequest creates the pull request and sets its labels and assignees
+func CreatePullRequest(ctx context.Context, prService PullRequestCreator, issueService IssueEditor, options *CreatePullRequestOptions) (*github.PullRequest, error) {
+	prRequest := github.NewPullRequest{
+		Title: &options.Title,
+		Head:  &options.Head,
+		Base:  &options.Base,
+		Body:  &options.Body,
+	}
+
+	newPR, resp, err := prService.Create(ctx, options.Owner, options.Repository, &prRequest)
+	if err != nil {
+		logResponseStatus(resp)
+		return nil, errors.Wrap(err, "Error occurred when creating pull request")
+	}
+	log.Entry().Debugf("New pull request created: %v", newPR)
+
+	if len(options.Labels) == 0 && len(options.Assignees) == 0 {
+		return newPR, nil
+	}
+
+	issueRequest := github.IssueRequest{
+		Labels:    &options.Labels,
+		Assignees: &options.Assignees,
+	}
+
+	updatedPr, resp, err := issueService.Edit(ctx, options.Owner, options.Repository, newPR.GetNumber(), &issueRequest)
+	if err != nil {
+		logResponseStatus(resp)
+		return nil, errors.Wrap(err, "Error occurred when editing pull request")
+	}
+	log.Entry().Debugf("Updated pull request: %v", updatedPr)
+
+	return newPR, nil
+}
+
+// CreateOrGetPullRequest returns the open pull request from head to base, a new pull request is created in case there is none
+func CreateOrGetPullRequest(ctx context.Context, prService PullRequestService, issueService IssueEditor, options *CreatePullRequestOptions) (*github.PullRequest, error) {
+	listOptions := github.PullRequestListOptions{
+		State: "open",
+		Head:  options.Owner + ":" + options.Head,
+		Base:  options.Base,
+	}
+	pullRequests, resp, err := prService.List(ctx, options.Owner, options.Repository, &listOptions)
+	if err != nil {
+		logResponseStatus(resp)
+		return nil, errors.Wrap(err, "Error occurred when listing pull requests")
+	}
+	if len(pullRequests) > 0 {
+		log.Entry().Infof("Pull request %v from '%v' to '%v' already exists", pullRequests[0].GetNumber(), options.Head, options.Base)
+		return pullRequests[0], nil
+	}
+	return CreatePullRequest(ctx, prService, issueService, options)
+}
+
+const enableAutoMergeMutation = `mutation($pullRequestId: ID!, $mergeMethod: PullRequestMergeMethod!) {
+  enablePullRequestAutoMerge(input: {pullRequestId: $pullRequestId, mergeMethod: $mergeMethod}) {
+    clientMutationId
+  }
+}`
+
+type graphQLRequest struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables,omitempty"`
+}
+
+type graphQLResponse struct {
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// EnableAutoMerge enables auto-merge of the pull request, it is merged by GitHub as soon as all requirements like status checks are met.
+// The merge method is one of merge, squash or rebase and auto-merge needs to be allowed in the settings of the repository.
+func EnableAutoMerge(ctx context.Context, client GraphQLClient, apiURL string, pullRequest *github.PullRequest, mergeMethod string) error {
+	request := graphQLRequest{
+		Query: enableAutoMergeMutation,
+		Variables: map[string]interface{}{
+			"pullRequestId": pullRequest.GetNodeID(),
+			"mergeMethod":   strings.ToUpper(mergeMethod),
+		},
+	}
+	req, err := client.NewRequest(http.MethodPost, GraphQLURL(apiURL), request)
+	if err != nil {
+		return errors.Wrap(err, "failed to create GraphQL request")
+	}
+	var response graphQLResponse
+	resp, err := client.Do(ctx, req, &response)
+	if err != nil {
+		logResponseStatus(resp)
+		return errors.Wrapf(err, "failed to enable auto-merge of pull request %v", pullRequest.GetNumber())
+	}
+	if len(response.Errors) > 0 {
+		return errors.Errorf("failed to enable auto-merge of pull request %v: %v", pullRequest.GetNumber(), response.Errors[0].Message)
+	}
+	return nil
+}
+
+// GraphQLURL returns the GraphQL endpoint belonging to the REST API URL, e.g. https://github.example.com/api/graphql for https://github.example.com/api/v3
+func GraphQLURL(apiURL string) string {
+	apiURL = strings.TrimSuffix(apiURL, "/")
+	if strings.HasSuffix(apiURL, "/api/v3") {
+		return strings.TrimSuffix(apiURL, "/v3") + "/graphql"
+	}
+	return apiURL + "/graphql"
+}
+
+// RepositoryFromURL returns owner and name of the repository with the given URL, e.g. https://github.com/SAP/jenkins-library.git
+func RepositoryFromURL(repositoryURL string) (string, string, error) {
+	owner, repository := ownerAndRepository(repositoryURL)
+	if len(owner) == 0 || len(repository) == 0 {
+		return "", "", fmt.Errorf("failed to determine owner and repository of '%v'", repositoryURL)
+	}
+	return owner, repository, nil
+}
+
+func logResponseStatus(resp *github.Response) {
+	if resp != nil && resp.Response != nil {
+		log.Entry().Errorf("GitHub response code %v", resp.Status)
+	}
+}
@@ -0,0 +1,134 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-github/v32/github"
+	"github.com/stretchr/testify/assert"
+)
+
+type pullRequestServiceMock struct {
+	open        []*github.PullRequest
+	created     *github.NewPullRequest
+	listOptions *github.PullRequestListOptions
+	listError   error
+}
+
+func (p *pullRequestServiceMock) Create(ctx context.Context, owner string, repo string, pull *github.NewPullRequest) (*github.PullRequest, *github.Response, error) {
+	p.created = pull
+	return &github.PullRequest{Number: github.Int(7), NodeID: github.String("PR_7"), HTMLURL: github.String("https://github.com/SAP/gitops/pull/7")}, nil, nil
+}
+
+func (p *pullRequestServiceMock) List(ctx context.Context, owner string, repo string, opts *github.PullRequestListOptions) ([]*github.PullRequest, *github.Response, error) {
+	p.listOptions = opts
+	return p.open, nil, p.listError
+}
+
+type graphQLClientMock struct {
+	url      string
+	request  graphQLRequest
+	response string
+	err      error
+}
+
+func (g *graphQLClientMock) NewRequest(method, urlStr string, body interface{}) (*http.Request, error) {
+	g.url = urlStr
+	g.request = body.(graphQLRequest)
+	return http.NewRequest(method, urlStr, nil)
+}
+
+func (g *graphQLClientMock) Do(ctx context.Context, req *http.Request, v interface{}) (*github.Response, error) {
+	if g.err != nil {
+		return nil, g.err
+	}
+	return nil, json.Unmarshal([]byte(g.response), v)
+}
+
+func TestCreateOrGetPullRequest(t *testing.T) {
+	ctx := context.Background()
+	options := CreatePullRequestOptions{Owner: "SAP", Repository: "gitops", Title: "Updated myApp to version 1.0", Head: "gitops/myApp-1.0", Base: "main", Labels: []string{"gitops"}}
+
+	t.Run("create pull request", func(t *testing.T) {
+		prService := &pullRequestServiceMock{}
+		issueService := &issueServiceMock{}
+
+		pr, err := CreateOrGetPullRequest(ctx, prService, issueService, &options)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 7, pr.GetNumber())
+		assert.Equal(t, "SAP:gitops/myApp-1.0", prService.listOptions.Head)
+		assert.Equal(t, "main", prService.listOptions.Base)
+		assert.Equal(t, "gitops/myApp-1.0", prService.created.GetHead())
+		assert.Equal(t, "Updated myApp to version 1.0", prService.created.GetTitle())
+		assert.Equal(t, []string{"gitops"}, issueService.edited[7].GetLabels())
+	})
+
+	t.Run("pull request exists", func(t *testing.T) {
+		prService := &pullRequestServiceMock{open: []*github.PullRequest{{Number: github.Int(5)}}}
+
+		pr, err := CreateOrGetPullRequest(ctx, prService, &issueServiceMock{}, &options)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 5, pr.GetNumber())
+		assert.Nil(t, prService.created)
+	})
+
+	t.Run("list error", func(t *testing.T) {
+		prService := &pullRequestServiceMock{listError: errors.New("Bad credentials")}
+
+		_, err := CreateOrGetPullRequest(ctx, prService, &issueServiceMock{}, &options)
+
+		assert.EqualError(t, err, "Error occurred when listing pull requests: Bad credentials")
+	})
+}
+
+func TestEnableAutoMerge(t *testing.T) {
+	ctx := context.Background()
+	pr := &github.PullRequest{Number: github.Int(7), NodeID: github.String("PR_7")}
+
+	t.Run("success", func(t *testing.T) {
+		client := &graphQLClientMock{response: `{"data":{"enablePullRequestAutoMerge":{"clientMutationId":null}}}`}
+
+		err := EnableAutoMerge(ctx, client, "https://api.github.com", pr, "squash")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "https://api.github.com/graphql", client.url)
+		assert.Equal(t, "PR_7", client.request.Variables["pullRequestId"])
+		assert.Equal(t, "SQUASH", client.request.Variables["mergeMethod"])
+	})
+
+	t.Run("GraphQL error", func(t *testing.T) {
+		client := &graphQLClientMock{response: `{"errors":[{"message":"Pull request is in clean status"}]}`}
+
+		err := EnableAutoMerge(ctx, client, "https://api.github.com", pr, "merge")
+
+		assert.EqualError(t, err, "failed to enable auto-merge of pull request 7: Pull request is in clean status")
+	})
+
+	t.Run("request error", func(t *testing.T) {
+		client := &graphQLClientMock{err: errors.New("connection refused")}
+
+		err := EnableAutoMerge(ctx, client, "https://api.github.com", pr, "merge")
+
+		assert.EqualError(t, err, "failed to enable auto-merge of pull request 7: connection refused")
+	})
+}
+
+func TestGraphQLURL(t *testing.T) {
+	assert.Equal(t, "https://api.github.com/graphql", GraphQLURL("https://api.github.com/"))
+	assert.Equal(t, "https://github.example.com/api/graphql", GraphQLURL("https://github.example.com/api/v3/"))
+}
+
+func TestRepositoryFromURL(t *testing.T) {
+	owner, repository, err := RepositoryFromURL("https://github.com/SAP/gitops.git")
+	assert.NoError(t, err)
+	assert.Equal(t, "SAP", owner)
+	assert.Equal(t, "gitops", repository)
+
+	_, _, err = RepositoryFromURL("gitops")
+	assert.EqualError(t, err, "failed to determine owner and repository of 'gitops'")
+}
@@ -0,0 +1,241 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"unicode/utf8"
+
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/reporting"
+	"github.com/google/go-github/v32/github"
+	"github.com/pkg/errors"
+)
+
+// resultIssueMarker identifies the issues managed by piper, it contains the scope and the fingerprint of the result
+var resultIssueMarker = regexp.MustCompile(`<!-- piper-result-issue: (\S+) (\S+) -->`)
+
+// ResultIssueLabel is added to all issues managed by piper, it limits the issues which are listed during the synchronization
+const ResultIssueLabel = "piper-result"
+
+// maxIssueBodyLength is the maximum number of characters of an issue body accepted by GitHub
+const maxIssueBodyLength = 65536
+
+const truncationNote = "\n\n_The description is truncated since it exceeds the maximum length of an issue._"
+
+// IssueService is the part of the GitHub issues API used to keep the issues of scan results in sync
+type IssueService interface {
+	ListByRepo(ctx context.Context, owner string, repo string, opts *github.IssueListByRepoOptions) ([]*github.Issue, *github.Response, error)
+	Create(ctx context.Context, owner string, repo string, issue *github.IssueRequest) (*github.Issue, *github.Response, error)
+	Edit(ctx context.Context, owner string, repo string, number int, issue *github.IssueRequest) (*github.Issue, *github.Response, error)
+	CreateComment(ctx context.Context, owner string, repo string, number int, comment *github.IssueComment) (*github.IssueComment, *github.Response, error)
+}
+
+// ResultIssue is the content of the issue of one result, e.g. a group of findings
+type ResultIssue struct {
+	// Fingerprint identifies the result across runs
+	Fingerprint string
+	Title       string
+	Body        string
+}
+
+// ResultIssueOptions defines the repository and the properties of the issues
+type ResultIssueOptions struct {
+	Owner      string
+	Repository string
+	// Scope identifies the issues which are synchronized together, e.g. all results of a scan step for a project.
+	// Open issues of the scope whose fingerprint is not part of the results anymore are closed.
+	Scope     string
+	Labels    []string
+	Assignees []string
+}
+
+// ResultIssueSummary contains the numbers of the issues touched by the synchronization
+type ResultIssueSummary struct {
+	Created  []int
+	Updated  []int
+	Reopened []int
+	Closed   []int
+}
+
+// SyncResultIssues makes sure that exactly one open issue exists per result.
+// Existing issues are updated or reopened, issues of results which disappeared are closed.
+func SyncResultIssues(ctx context.Context, issues IssueService, options ResultIssueOptions, results []ResultIssue) (ResultIssueSummary, error) {
+	summary := ResultIssueSummary{}
+	existing, err := listResultIssues(ctx, issues, options)
+	if err != nil {
+		return summary, err
+	}
+
+	handled := map[int]bool{}
+	for _, result := range results {
+		request := &github.IssueRequest{Title: &result.Title}
+		body := issueBody(result.Body, fmt.Sprintf("<!-- piper-result-issue: %v %v -->", options.Scope, result.Fingerprint))
+		request.Body = &body
+
+		issue := selectIssue(existing[result.Fingerprint])
+		if issue == nil {
+			labels, assignees := append([]string{ResultIssueLabel}, options.Labels...), options.Assignees
+			request.Labels, request.Assignees = &labels, &assignees
+			created, _, err := issues.Create(ctx, options.Owner, options.Repository, request)
+			if err != nil {
+				return summary, errors.Wrapf(err, "failed to create issue '%v'", result.Title)
+			}
+			summary.Created = append(summary.Created, created.GetNumber())
+			continue
+		}
+
+		handled[issue.GetNumber()] = true
+		labels, assignees := mergeLabels(issue, append([]string{ResultIssueLabel}, options.Labels...)), mergeAssignees(issue, options.Assignees)
+		request.Labels, request.Assignees = &labels, &assignees
+		reopen := issue.GetState() == "closed"
+		if reopen {
+			state := "open"
+			request.State = &state
+		}
+		if _, _, err := issues.Edit(ctx, options.Owner, options.Repository, issue.GetNumber(), request); err != nil {
+			return summary, errors.Wrapf(err, "failed to update issue #%v", issue.GetNumber())
+		}
+		if reopen {
+			if err := comment(ctx, issues, options, issue.GetNumber(), "The result has been detected again, therefore the issue is reopened."); err != nil {
+				return summary, err
+			}
+			summary.Reopened = append(summary.Reopened, issue.GetNumber())
+		} else {
+			summary.Updated = append(summary.Updated, issue.GetNumber())
+		}
+	}
+
+	// close the open issues of results which disappeared as well as duplicates
+	for _, fingerprintIssues := range existing {
+		for _, issue := range fingerprintIssues {
+			if handled[issue.GetNumber()] || issue.GetState() != "open" {
+				continue
+			}
+			if err := comment(ctx, issues, options, issue.GetNumber(), "The result is not detected anymore, therefore the issue is closed."); err != nil {
+				return summary, err
+			}
+			state := "closed"
+			if _, _, err := issues.Edit(ctx, options.Owner, options.Repository, issue.GetNumber(), &github.IssueRequest{State: &state}); err != nil {
+				return summary, errors.Wrapf(err, "failed to close issue #%v", issue.GetNumber())
+			}
+			summary.Closed = append(summary.Closed, issue.GetNumber())
+		}
+	}
+	sort.Ints(summary.Closed)
+
+	log.Entry().Infof("Issues of %v: %v created, %v updated, %v reopened, %v closed", options.Scope, len(summary.Created), len(summary.Updated), len(summary.Reopened), len(summary.Closed))
+	return summary, nil
+}
+
+// ResultIssuesFromScanReport returns one issue per group of findings of the report, see reporting.ScanReport.FindingGroups
+func ResultIssuesFromScanReport(report *reporting.ScanReport) []ResultIssue {
+	issues := []ResultIssue{}
+	for _, group := range report.FindingGroups() {
+		issues = append(issues, ResultIssue{
+			Fingerprint: group.Fingerprint(),
+			Title:       group.IssueTitle(),
+			Body:        string(group.ToMarkdown(report.StepName)),
+		})
+	}
+	return issues
+}
+
+// SyncScanReportIssues keeps the issues of the findings of a scan report in sync, see SyncResultIssues
+func SyncScanReportIssues(token, apiURL string, options ResultIssueOptions, report *reporting.ScanReport) error {
+	if len(options.Owner) == 0 || len(options.Repository) == 0 {
+		return fmt.Errorf("GitHub owner '%v' or repository '%v' missing", options.Owner, options.Repository)
+	}
+	ctx, client, err := NewClient(token, apiURL, "")
+	if err != nil {
+		return errors.Wrap(err, "failed to get GitHub client")
+	}
+	_, err = SyncResultIssues(ctx, client.Issues, options, ResultIssuesFromScanReport(report))
+	return err
+}
+
+// issueBody appends the marker to the body, the body is truncated if both exceed the maximum length of an issue
+func issueBody(body, marker string) string {
+	suffix := "\n\n" + marker
+	if utf8.RuneCountInString(body)+utf8.RuneCountInString(suffix) <= maxIssueBodyLength {
+		return body + suffix
+	}
+	runes := []rune(body)
+	return string(runes[:maxIssueBodyLength-utf8.RuneCountInString(truncationNote+suffix)]) + truncationNote + suffix
+}
+
+// listResultIssues returns the issues of the scope grouped by their fingerprint, only issues with the ResultIssueLabel are considered
+func listResultIssues(ctx context.Context, issues IssueService, options ResultIssueOptions) (map[string][]*github.Issue, error) {
+	result := map[string][]*github.Issue{}
+	listOptions := &github.IssueListByRepoOptions{State: "all", Labels: []string{ResultIssueLabel}, Sort: "created", Direction: "asc", ListOptions: github.ListOptions{PerPage: 100}}
+	for {
+		page, response, err := issues.ListByRepo(ctx, options.Owner, options.Repository, listOptions)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to list issues of repository %v/%v", options.Owner, options.Repository)
+		}
+		for _, issue := range page {
+			if issue.IsPullRequest() {
+				continue
+			}
+			match := resultIssueMarker.FindStringSubmatch(issue.GetBody())
+			if match == nil || match[1] != options.Scope {
+				continue
+			}
+			result[match[2]] = append(result[match[2]], issue)
+		}
+		if response == nil || response.NextPage == 0 {
+			break
+		}
+		listOptions.Page = response.NextPage
+	}
+	return result, nil
+}
+
+// selectIssue returns the oldest open issue or, if none is open, the most recently closed issue
+func selectIssue(issues []*github.Issue) *github.Issue {
+	var selected *github.Issue
+	for _, issue := range issues {
+		if issue.GetState() == "open" {
+			return issue
+		}
+		selected = issue
+	}
+	return selected
+}
+
+func comment(ctx context.Context, issues IssueService, options ResultIssueOptions, number int, body string) error {
+	_, _, err := issues.CreateComment(ctx, options.Owner, options.Repository, number, &github.IssueComment{Body: &body})
+	return errors.Wrapf(err, "failed to comment issue #%v", number)
+}
+
+// mergeLabels keeps the labels which have been added to the issue manually
+func mergeLabels(issue *github.Issue, labels []string) []string {
+	merged := append([]string{}, labels...)
+	for _, label := range issue.Labels {
+		if !contains(merged, label.GetName()) {
+			merged = append(merged, label.GetName())
+		}
+	}
+	return merged
+}
+
+// mergeAssignees keeps the assignees which have been added to the issue manually
+func mergeAssignees(issue *github.Issue, assignees []string) []string {
+	merged := append([]string{}, assignees...)
+	for _, assignee := range issue.Assignees {
+		if !contains(merged, assignee.GetLogin()) {
+			merged = append(merged, assignee.GetLogin())
+		}
+	}
+	return merged
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
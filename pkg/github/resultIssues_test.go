@@ -0,0 +1,170 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/SAP/jenkins-library/pkg/reporting"
+	"github.com/google/go-github/v32/github"
+	"github.com/stretchr/testify/assert"
+)
+
+type issueServiceMock struct {
+	listLabels   []string
+	pages        [][]*github.Issue
+	created      []*github.IssueRequest
+	edited       map[int]*github.IssueRequest
+	closed       []int
+	comments     map[int][]string
+	serviceError error
+}
+
+func (i *issueServiceMock) ListByRepo(ctx context.Context, owner string, repo string, opts *github.IssueListByRepoOptions) ([]*github.Issue, *github.Response, error) {
+	if i.serviceError != nil {
+		return nil, nil, i.serviceError
+	}
+	i.listLabels = opts.Labels
+	if len(i.pages) == 0 {
+		return nil, &github.Response{}, nil
+	}
+	page := opts.Page
+	if page == 0 {
+		page = 1
+	}
+	response := &github.Response{}
+	if page < len(i.pages) {
+		response.NextPage = page + 1
+	}
+	return i.pages[page-1], response, nil
+}
+
+func (i *issueServiceMock) Create(ctx context.Context, owner string, repo string, issue *github.IssueRequest) (*github.Issue, *github.Response, error) {
+	if i.serviceError != nil {
+		return nil, nil, i.serviceError
+	}
+	i.created = append(i.created, issue)
+	number := 100 + len(i.created)
+	return &github.Issue{Number: &number}, nil, nil
+}
+
+func (i *issueServiceMock) Edit(ctx context.Context, owner string, repo string, number int, issue *github.IssueRequest) (*github.Issue, *github.Response, error) {
+	if issue.GetState() == "closed" {
+		i.closed = append(i.closed, number)
+	} else {
+		if i.edited == nil {
+			i.edited = map[int]*github.IssueRequest{}
+		}
+		i.edited[number] = issue
+	}
+	return &github.Issue{Number: &number}, nil, nil
+}
+
+func (i *issueServiceMock) CreateComment(ctx context.Context, owner string, repo string, number int, comment *github.IssueComment) (*github.IssueComment, *github.Response, error) {
+	if i.comments == nil {
+		i.comments = map[int][]string{}
+	}
+	i.comments[number] = append(i.comments[number], comment.GetBody())
+	return comment, nil, nil
+}
+
+func testIssue(number int, state, scope, fingerprint string) *github.Issue {
+	body := fmt.Sprintf("old body\n\n<!-- piper-result-issue: %v %v -->", scope, fingerprint)
+	return &github.Issue{Number: &number, State: &state, Body: &body}
+}
+
+func TestSyncResultIssues(t *testing.T) {
+	ctx := context.Background()
+	options := ResultIssueOptions{Owner: "SAP", Repository: "jenkins-library", Scope: "checkmarxExecuteScan/abc", Labels: []string{"security"}, Assignees: []string{"alice"}}
+
+	t.Run("create, update, reopen and close", func(t *testing.T) {
+		label, login := "triaged", "bob"
+		updated := testIssue(1, "open", options.Scope, "fp1")
+		updated.Labels = []*github.Label{{Name: &label}}
+		updated.Assignees = []*github.User{{Login: &login}}
+		pullRequest := testIssue(6, "open", options.Scope, "fp1")
+		pullRequest.PullRequestLinks = &github.PullRequestLinks{}
+		issues := &issueServiceMock{pages: [][]*github.Issue{
+			{updated, testIssue(2, "closed", options.Scope, "fp2"), testIssue(3, "open", options.Scope, "gone")},
+			{testIssue(4, "open", "otherScope", "other"), testIssue(5, "open", options.Scope, "fp1"), pullRequest, {}},
+		}}
+		results := []ResultIssue{
+			{Fingerprint: "fp1", Title: "XSS", Body: "new body"},
+			{Fingerprint: "fp2", Title: "SQL Injection", Body: "body"},
+			{Fingerprint: "fp3", Title: "CVE-2021-1", Body: "body"},
+		}
+
+		summary, err := SyncResultIssues(ctx, issues, options, results)
+
+		assert.NoError(t, err)
+		assert.Equal(t, ResultIssueSummary{Created: []int{101}, Updated: []int{1}, Reopened: []int{2}, Closed: []int{3, 5}}, summary)
+		if assert.Len(t, issues.created, 1) {
+			assert.Equal(t, "CVE-2021-1", issues.created[0].GetTitle())
+			assert.Equal(t, "body\n\n<!-- piper-result-issue: checkmarxExecuteScan/abc fp3 -->", issues.created[0].GetBody())
+			assert.Equal(t, []string{"piper-result", "security"}, issues.created[0].GetLabels())
+			assert.Equal(t, []string{"alice"}, issues.created[0].GetAssignees())
+		}
+		assert.Equal(t, "new body\n\n<!-- piper-result-issue: checkmarxExecuteScan/abc fp1 -->", issues.edited[1].GetBody())
+		assert.Equal(t, []string{"piper-result", "security", "triaged"}, issues.edited[1].GetLabels())
+		assert.Equal(t, []string{"alice", "bob"}, issues.edited[1].GetAssignees())
+		assert.Nil(t, issues.edited[1].State)
+		assert.Equal(t, "open", issues.edited[2].GetState())
+		assert.Equal(t, []string{"The result has been detected again, therefore the issue is reopened."}, issues.comments[2])
+		assert.Equal(t, []string{"The result is not detected anymore, therefore the issue is closed."}, issues.comments[3])
+		assert.ElementsMatch(t, []int{3, 5}, issues.closed)
+		assert.Equal(t, []string{"piper-result"}, issues.listLabels)
+	})
+
+	t.Run("no results", func(t *testing.T) {
+		issues := &issueServiceMock{pages: [][]*github.Issue{{testIssue(1, "closed", options.Scope, "fp1")}}}
+
+		summary, err := SyncResultIssues(ctx, issues, options, []ResultIssue{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, ResultIssueSummary{}, summary)
+		assert.Empty(t, issues.closed)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		issues := &issueServiceMock{serviceError: fmt.Errorf("bad credentials")}
+
+		_, err := SyncResultIssues(ctx, issues, options, []ResultIssue{{Fingerprint: "fp1"}})
+
+		assert.EqualError(t, err, "failed to list issues of repository SAP/jenkins-library: bad credentials")
+	})
+}
+
+func TestIssueBody(t *testing.T) {
+	marker := "<!-- piper-result-issue: scope fp -->"
+
+	assert.Equal(t, "body\n\n"+marker, issueBody("body", marker))
+
+	body := issueBody(strings.Repeat("ä", 70000), marker)
+	assert.Equal(t, maxIssueBodyLength, utf8.RuneCountInString(body))
+	assert.True(t, utf8.ValidString(body))
+	assert.True(t, strings.HasSuffix(body, truncationNote+"\n\n"+marker))
+}
+
+func TestResultIssuesFromScanReport(t *testing.T) {
+	report := reporting.ScanReport{StepName: "checkmarxExecuteScan", Findings: []reporting.Finding{
+		{RuleID: "XSS", Title: "Reflected XSS", Severity: reporting.SeverityHigh, Location: "index.js", Line: 3},
+		{RuleID: "XSS", Title: "Reflected XSS", Severity: reporting.SeverityHigh, Location: "search.js", Line: 8},
+		{RuleID: "SQL_Injection", Title: "SQL Injection", Severity: reporting.SeverityHigh, Suppressed: true},
+	}}
+
+	issues := ResultIssuesFromScanReport(&report)
+
+	if assert.Len(t, issues, 1) {
+		assert.Equal(t, "[HIGH] Reflected XSS", issues[0].Title)
+		assert.Equal(t, report.FindingGroups()[0].Fingerprint(), issues[0].Fingerprint)
+		assert.Contains(t, issues[0].Body, "| Reported by | checkmarxExecuteScan |")
+	}
+}
+
+func TestSyncScanReportIssues(t *testing.T) {
+	err := SyncScanReportIssues("token", "https://api.github.com", ResultIssueOptions{Owner: "SAP"}, &reporting.ScanReport{})
+
+	assert.EqualError(t, err, "GitHub owner 'SAP' or repository '' missing")
+}
@@ -0,0 +1,138 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/orchestrator"
+	"github.com/pkg/errors"
+)
+
+// ChecksConfig defines the configuration of the check runs reported for each step execution
+type ChecksConfig struct {
+	Enabled bool   `json:"enabled,omitempty"`
+	APIURL  string `json:"apiUrl,omitempty"`
+	// Owner and Repository are detected from the repository URL of the orchestrator if empty
+	Owner      string `json:"owner,omitempty"`
+	Repository string `json:"repository,omitempty"`
+	// Token is used to authenticate to GitHub, the environment variable GITHUB_TOKEN is used if empty
+	Token string `json:"token,omitempty"`
+}
+
+// StepCheckRun reports the execution of a step as check run of the commit which is built
+type StepCheckRun struct {
+	run       *CheckRun
+	stepName  string
+	buildURL  string
+	startTime time.Time
+}
+
+// StartStepCheckRun creates a check run in progress for the step.
+// Errors are only logged since the check run must not influence the step, nil is returned in this case.
+func StartStepCheckRun(stepName, stageName string, config ChecksConfig) *StepCheckRun {
+	token := config.Token
+	if len(token) == 0 {
+		token = os.Getenv("GITHUB_TOKEN")
+	}
+	apiURL := config.APIURL
+	if len(apiURL) == 0 {
+		apiURL = "https://api.github.com"
+	}
+	log.RegisterSecret(token)
+	ctx, client, err := NewClient(token, apiURL, "")
+	if err != nil {
+		log.Entry().WithError(err).Warn("Failed to get GitHub client for the check run of the step")
+		return nil
+	}
+	provider, err := orchestrator.NewOrchestratorSpecificConfigProvider()
+	if err != nil {
+		log.Entry().WithError(err).Warn("Failed to detect the commit for the check run of the step")
+		return nil
+	}
+	stepCheckRun, err := startStepCheckRun(ctx, client.Checks, provider, stepName, stageName, config)
+	if err != nil {
+		log.Entry().WithError(err).Warn("Failed to report the step as check run")
+		return nil
+	}
+	return stepCheckRun
+}
+
+func startStepCheckRun(ctx context.Context, checks ChecksService, provider orchestrator.OrchestratorSpecificConfigProviding, stepName, stageName string, config ChecksConfig) (*StepCheckRun, error) {
+	owner, repository := config.Owner, config.Repository
+	if len(owner) == 0 || len(repository) == 0 {
+		repoURL := provider.GetRepoUrl()
+		// on GitHub Actions the repository is available as owner/repository
+		if gitHubRepository := os.Getenv("GITHUB_REPOSITORY"); len(gitHubRepository) > 0 {
+			repoURL = gitHubRepository
+		}
+		owner, repository = ownerAndRepository(repoURL)
+	}
+	commit := provider.GetCommit()
+	if len(owner) == 0 || len(repository) == 0 || len(commit) == 0 || commit == "n/a" {
+		return nil, fmt.Errorf("repository '%v/%v' or commit '%v' unknown", owner, repository, commit)
+	}
+
+	name := stepName
+	if len(stageName) > 0 {
+		name = fmt.Sprintf("%v / %v", stageName, stepName)
+	}
+	stepCheckRun := &StepCheckRun{
+		run:       NewCheckRun(ctx, checks, owner, repository, commit, name),
+		stepName:  stepName,
+		buildURL:  provider.GetBuildUrl(),
+		startTime: time.Now(),
+	}
+	err := stepCheckRun.run.Update(CheckRunUpdate{
+		Status:     CheckRunStatusInProgress,
+		DetailsURL: stepCheckRun.detailsURL(),
+		Title:      fmt.Sprintf("%v is running", stepName),
+		Summary:    fmt.Sprintf("Step `%v` started at %v.", stepName, stepCheckRun.startTime.Format(time.RFC3339)),
+	})
+	return stepCheckRun, errors.Wrapf(err, "failed to start check run of step %v", stepName)
+}
+
+// Complete finishes the check run with the conclusion derived from the result of the step
+func (s *StepCheckRun) Complete(failed bool, category log.ErrorCategory) {
+	conclusion := ConclusionFromErrorCategory(failed, category)
+	duration := time.Since(s.startTime).Round(time.Second)
+	title := fmt.Sprintf("%v succeeded", s.stepName)
+	summary := fmt.Sprintf("Step `%v` succeeded after %v.", s.stepName, duration)
+	if failed {
+		title = fmt.Sprintf("%v failed", s.stepName)
+		summary = fmt.Sprintf("Step `%v` failed after %v with error category `%v`.", s.stepName, duration, category)
+	}
+	err := s.run.Update(CheckRunUpdate{
+		Status:     CheckRunStatusCompleted,
+		Conclusion: conclusion,
+		DetailsURL: s.detailsURL(),
+		Title:      title,
+		Summary:    summary,
+	})
+	if err != nil {
+		log.Entry().WithError(err).Warn("Failed to complete the check run of the step")
+	}
+}
+
+func (s *StepCheckRun) detailsURL() string {
+	if s.buildURL == "n/a" || !strings.HasPrefix(s.buildURL, "http") {
+		return ""
+	}
+	return s.buildURL
+}
+
+// ownerAndRepository extracts owner and repository from a repository URL like https://github.com/SAP/jenkins-library.git
+func ownerAndRepository(repoURL string) (string, string) {
+	if repoURL == "n/a" {
+		return "", ""
+	}
+	repoURL = strings.TrimSuffix(strings.TrimSuffix(repoURL, "/"), ".git")
+	parts := strings.Split(strings.ReplaceAll(repoURL, ":", "/"), "/")
+	if len(parts) < 2 {
+		return "", ""
+	}
+	return parts[len(parts)-2], parts[len(parts)-1]
+}
@@ -0,0 +1,98 @@
+package github
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/orchestrator"
+	"github.com/stretchr/testify/assert"
+)
+
+func resetEnv(e []string) {
+	for _, val := range e {
+		tmp := strings.SplitN(val, "=", 2)
+		os.Setenv(tmp[0], tmp[1])
+	}
+}
+
+func TestStepCheckRun(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("start and complete", func(t *testing.T) {
+		defer resetEnv(os.Environ())
+		os.Clearenv()
+		os.Setenv("GITHUB_ACTIONS", "true")
+		os.Setenv("GITHUB_SHA", "abc123")
+		os.Setenv("GITHUB_SERVER_URL", "https://github.com/")
+		os.Setenv("GITHUB_REPOSITORY", "SAP/jenkins-library")
+		os.Setenv("GITHUB_RUN_ID", "42")
+		provider, _ := orchestrator.NewOrchestratorSpecificConfigProvider()
+		checks := &checksServiceMock{}
+
+		stepCheckRun, err := startStepCheckRun(ctx, checks, provider, "mavenBuild", "Build", ChecksConfig{Enabled: true})
+
+		assert.NoError(t, err)
+		if assert.Len(t, checks.created, 1) {
+			created := checks.created[0]
+			assert.Equal(t, "Build / mavenBuild", created.Name)
+			assert.Equal(t, "abc123", created.HeadSHA)
+			assert.Equal(t, CheckRunStatusInProgress, created.GetStatus())
+			assert.Equal(t, "https://github.com/SAP/jenkins-library/actions/runs/42", created.GetDetailsURL())
+			assert.NotNil(t, created.StartedAt)
+			assert.Equal(t, "mavenBuild is running", created.Output.GetTitle())
+		}
+		assert.Equal(t, "SAP", stepCheckRun.run.Owner)
+		assert.Equal(t, "jenkins-library", stepCheckRun.run.Repository)
+
+		stepCheckRun.Complete(true, log.ErrorConfiguration)
+
+		if assert.Len(t, checks.updated, 1) {
+			updated := checks.updated[0]
+			assert.Equal(t, CheckRunStatusCompleted, updated.GetStatus())
+			assert.Equal(t, CheckRunConclusionActionRequired, updated.GetConclusion())
+			assert.Equal(t, "mavenBuild failed", updated.Output.GetTitle())
+			assert.Contains(t, updated.Output.GetSummary(), "with error category `config`")
+		}
+	})
+
+	t.Run("configured repository", func(t *testing.T) {
+		defer resetEnv(os.Environ())
+		os.Clearenv()
+		os.Setenv("JENKINS_URL", "https://jenkins.example.com/")
+		os.Setenv("GIT_COMMIT", "abc123")
+		provider, _ := orchestrator.NewOrchestratorSpecificConfigProvider()
+		checks := &checksServiceMock{}
+
+		stepCheckRun, err := startStepCheckRun(ctx, checks, provider, "mavenBuild", "", ChecksConfig{Enabled: true, Owner: "SAP", Repository: "jenkins-library"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "mavenBuild", stepCheckRun.run.Name)
+		assert.Equal(t, "SAP", stepCheckRun.run.Owner)
+	})
+
+	t.Run("commit unknown", func(t *testing.T) {
+		defer resetEnv(os.Environ())
+		os.Clearenv()
+		os.Setenv("GITHUB_ACTIONS", "true")
+		os.Setenv("GITHUB_REPOSITORY", "SAP/jenkins-library")
+		provider, _ := orchestrator.NewOrchestratorSpecificConfigProvider()
+
+		_, err := startStepCheckRun(ctx, &checksServiceMock{}, provider, "mavenBuild", "", ChecksConfig{Enabled: true})
+
+		assert.EqualError(t, err, "repository 'SAP/jenkins-library' or commit 'n/a' unknown")
+	})
+}
+
+func TestOwnerAndRepository(t *testing.T) {
+	for _, repoURL := range []string{"https://github.com/SAP/jenkins-library.git", "git@github.com:SAP/jenkins-library.git", "SAP/jenkins-library", "https://github.com/SAP/jenkins-library/"} {
+		owner, repository := ownerAndRepository(repoURL)
+		assert.Equal(t, "SAP", owner, repoURL)
+		assert.Equal(t, "jenkins-library", repository, repoURL)
+	}
+	owner, repository := ownerAndRepository("n/a")
+	assert.Empty(t, owner)
+	assert.Empty(t, repository)
+}
@@ -0,0 +1,140 @@
+package gitops
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// KustomizeImage defines the image to be set in the images list of a kustomization
+type KustomizeImage struct {
+	// Name of the image as referenced by the resources, used for new entries
+	Name string
+	// Aliases identify existing entries by their name or newName in addition to Name, e.g. the image including the registry
+	Aliases []string
+	NewName string
+	NewTag  string
+}
+
+func (i *KustomizeImage) matches(name string) bool {
+	if name == i.Name {
+		return true
+	}
+	for _, alias := range i.Aliases {
+		if name == alias {
+			return true
+		}
+	}
+	return false
+}
+
+// SetKustomizeImage updates all entries of the images list of a kustomization which match the image,
+// a new entry is added in case there is no matching one. Other content, formatting and comments are kept.
+func SetKustomizeImage(content []byte, image KustomizeImage) ([]byte, error) {
+	doc, err := parseDocument(content)
+	if err != nil {
+		return nil, err
+	}
+	if len(doc.nodes) == 0 || len(doc.nodes[0].Content) == 0 || doc.nodes[0].Content[0].Kind != yaml.MappingNode {
+		return nil, errors.New("kustomization does not contain a YAML mapping")
+	}
+	root := doc.nodes[0].Content[0]
+	if root.Style&yaml.FlowStyle != 0 {
+		return nil, errors.New("kustomization in flow style is not supported")
+	}
+
+	imagesKey, images := mappingValue(root, "images")
+	switch {
+	case images == nil:
+		indent := strings.Repeat(" ", root.Content[0].Column-1)
+		doc.insertLines(len(doc.lineStarts)+1, append([]string{indent + "images:"}, kustomizeImageEntry(image, indent+"- ", indent+"  ")...)...)
+	case images.Kind == yaml.ScalarNode && images.ShortTag() == "!!null":
+		indent := strings.Repeat(" ", imagesKey.Column-1)
+		doc.insertLines(imagesKey.Line+1, kustomizeImageEntry(image, indent+"- ", indent+"  ")...)
+	case images.Kind == yaml.SequenceNode:
+		matched := false
+		for _, item := range images.Content {
+			_, name := mappingValue(item, "name")
+			_, newName := mappingValue(item, "newName")
+			if (name == nil || !image.matches(name.Value)) && (newName == nil || !image.matches(newName.Value)) {
+				continue
+			}
+			matched = true
+			if err := updateKustomizeImageEntry(doc, item, image); err != nil {
+				return nil, err
+			}
+		}
+		if !matched {
+			if images.Style&yaml.FlowStyle != 0 || len(images.Content) == 0 {
+				return nil, errors.Errorf("adding image '%v' to images in flow style is not supported", image.Name)
+			}
+			keyColumn := images.Column + 2
+			if len(images.Content[0].Content) > 0 {
+				keyColumn = images.Content[0].Content[0].Column
+			}
+			dash := strings.Repeat(" ", images.Column-1) + "-" + strings.Repeat(" ", keyColumn-images.Column-1)
+			doc.insertLines(lastLine(images)+1, kustomizeImageEntry(image, dash, strings.Repeat(" ", keyColumn-1))...)
+		}
+	default:
+		return nil, errors.Errorf("images in line %v is not a list", imagesKey.Line)
+	}
+	return doc.bytes(), nil
+}
+
+func updateKustomizeImageEntry(doc *document, item *yaml.Node, image KustomizeImage) error {
+	if item.Kind != yaml.MappingNode || len(item.Content) == 0 {
+		return errors.Errorf("images entry in line %v is not a mapping", item.Line)
+	}
+	var missing []string
+	for _, field := range []struct{ key, value string }{{"newName", image.NewName}, {"newTag", image.NewTag}} {
+		if len(field.value) == 0 {
+			continue
+		}
+		_, value := mappingValue(item, field.key)
+		if value != nil {
+			if err := doc.setScalar(value, field.value); err != nil {
+				return errors.Wrapf(err, "failed to update %v", field.key)
+			}
+			continue
+		}
+		if _, name := mappingValue(item, "name"); field.key == "newName" && name != nil && name.Value == field.value {
+			continue
+		}
+		missing = append(missing, field.key+": "+scalar(field.value))
+	}
+
+	// a digest takes precedence over the tag, therefore it needs to be removed when setting a tag
+	if digestKey, digest := mappingValue(item, "digest"); digest != nil && len(image.NewTag) > 0 {
+		if digestKey == item.Content[0] {
+			return errors.Errorf("failed to remove digest of images entry in line %v", item.Line)
+		}
+		if err := doc.removeLine(digestKey, digest); err != nil {
+			return err
+		}
+	}
+
+	if len(missing) > 0 {
+		if item.Style&yaml.FlowStyle != 0 {
+			return errors.Errorf("updating images entry in flow style in line %v is not supported", item.Line)
+		}
+		indent := strings.Repeat(" ", item.Content[0].Column-1)
+		for i := range missing {
+			missing[i] = indent + missing[i]
+		}
+		doc.insertLines(lastLine(item)+1, missing...)
+	}
+	return nil
+}
+
+// kustomizeImageEntry renders a new entry of the images list, the first line is prefixed by dash, all others by indent
+func kustomizeImageEntry(image KustomizeImage, dash, indent string) []string {
+	lines := []string{dash + "name: " + scalar(image.Name)}
+	if len(image.NewName) > 0 && image.NewName != image.Name {
+		lines = append(lines, indent+"newName: "+scalar(image.NewName))
+	}
+	if len(image.NewTag) > 0 {
+		lines = append(lines, indent+"newTag: "+scalar(image.NewTag))
+	}
+	return lines
+}
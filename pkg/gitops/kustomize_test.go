@@ -0,0 +1,120 @@
+package gitops
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetKustomizeImage(t *testing.T) {
+	image := KustomizeImage{Name: "myFancyContainer", Aliases: []string{"myregistry.com/myFancyContainer"}, NewName: "myregistry.com/myFancyContainer", NewTag: "1337"}
+
+	t.Run("update existing entry", func(t *testing.T) {
+		content := `apiVersion: kustomize.config.k8s.io/v1beta1
+kind: Kustomization
+resources:
+  - ../../base # shared manifests
+images:
+  # the application image
+  - name: myFancyContainer
+    newName: myregistry.com/myFancyContainer
+    newTag: "1336" # updated by the pipeline
+  - name: sidecar
+    newTag: 1.0.0
+`
+		expected := `apiVersion: kustomize.config.k8s.io/v1beta1
+kind: Kustomization
+resources:
+  - ../../base # shared manifests
+images:
+  # the application image
+  - name: myFancyContainer
+    newName: myregistry.com/myFancyContainer
+    newTag: "1337" # updated by the pipeline
+  - name: sidecar
+    newTag: 1.0.0
+`
+		result, err := SetKustomizeImage([]byte(content), image)
+
+		assert.NoError(t, err)
+		assert.Equal(t, expected, string(result))
+	})
+
+	t.Run("add missing fields and remove digest", func(t *testing.T) {
+		content := `images:
+- name: myFancyContainer
+  digest: sha256:24a0c4b4a4c0eb97a1aabb8e29f18e917d05abfe1b7a7c07857230879ce7d3d3
+namePrefix: dev-
+`
+		expected := `images:
+- name: myFancyContainer
+  newName: myregistry.com/myFancyContainer
+  newTag: "1337"
+namePrefix: dev-
+`
+		result, err := SetKustomizeImage([]byte(content), image)
+
+		assert.NoError(t, err)
+		assert.Equal(t, expected, string(result))
+	})
+
+	t.Run("add entry to existing images", func(t *testing.T) {
+		content := `images:
+  -   name: sidecar
+      newTag: 1.0.0
+namePrefix: dev-`
+		expected := `images:
+  -   name: sidecar
+      newTag: 1.0.0
+  -   name: myFancyContainer
+      newName: myregistry.com/myFancyContainer
+      newTag: "1337"
+namePrefix: dev-`
+		result, err := SetKustomizeImage([]byte(content), image)
+
+		assert.NoError(t, err)
+		assert.Equal(t, expected, string(result))
+	})
+
+	t.Run("add images", func(t *testing.T) {
+		content := "resources:\n- deployment.yaml"
+		expected := "resources:\n- deployment.yaml\nimages:\n- name: myFancyContainer\n  newName: myregistry.com/myFancyContainer\n  newTag: \"1337\"\n"
+
+		result, err := SetKustomizeImage([]byte(content), image)
+
+		assert.NoError(t, err)
+		assert.Equal(t, expected, string(result))
+	})
+
+	t.Run("add entry to empty images", func(t *testing.T) {
+		content := "images:\nresources:\n- deployment.yaml\n"
+		expected := "images:\n- name: myFancyContainer\n  newName: myregistry.com/myFancyContainer\n  newTag: \"1337\"\nresources:\n- deployment.yaml\n"
+
+		result, err := SetKustomizeImage([]byte(content), image)
+
+		assert.NoError(t, err)
+		assert.Equal(t, expected, string(result))
+	})
+
+	t.Run("update entry in flow style", func(t *testing.T) {
+		content := "images: [{name: myregistry.com/myFancyContainer, newTag: '1336'}]\n"
+		expected := "images: [{name: myregistry.com/myFancyContainer, newTag: '1337'}]\n"
+
+		result, err := SetKustomizeImage([]byte(content), KustomizeImage{Name: "myFancyContainer", Aliases: []string{"myregistry.com/myFancyContainer"}, NewName: "myregistry.com/myFancyContainer", NewTag: "1337"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, expected, string(result))
+	})
+
+	t.Run("images is not a list", func(t *testing.T) {
+		_, err := SetKustomizeImage([]byte("images: myFancyContainer\n"), image)
+
+		assert.EqualError(t, err, "images in line 1 is not a list")
+	})
+
+	t.Run("invalid kustomization", func(t *testing.T) {
+		_, err := SetKustomizeImage([]byte("- deployment.yaml\n"), image)
+
+		assert.EqualError(t, err, "kustomization does not contain a YAML mapping")
+	})
+}
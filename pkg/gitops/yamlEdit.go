@@ -0,0 +1,319 @@
+package gitops
+
+import (
+	"bytes"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// edit replaces the bytes between start and end of the original content.
+// Insertions are edits with start == end.
+type edit struct {
+	start int
+	end   int
+	text  string
+}
+
+// document keeps the original content next to the parsed nodes, this way values can be replaced
+// in place and formatting as well as comments of the file stay untouched
+type document struct {
+	content    []byte
+	lineStarts []int
+	nodes      []*yaml.Node
+	edits      []edit
+	newline    bool
+}
+
+func parseDocument(content []byte) (*document, error) {
+	doc := &document{content: content, lineStarts: []int{0}}
+	for i, b := range content {
+		if b == '\n' {
+			doc.lineStarts = append(doc.lineStarts, i+1)
+		}
+	}
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	for {
+		var node yaml.Node
+		err := decoder.Decode(&node)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse YAML content")
+		}
+		doc.nodes = append(doc.nodes, &node)
+	}
+	return doc, nil
+}
+
+// offset converts the 1-based line and column of a node into a byte offset of the content
+func (d *document) offset(line, column int) int {
+	if line < 1 {
+		return 0
+	}
+	if line > len(d.lineStarts) {
+		return len(d.content)
+	}
+	offset := d.lineStarts[line-1]
+	for i := 1; i < column && offset < len(d.content); i++ {
+		_, size := utf8.DecodeRune(d.content[offset:])
+		offset += size
+	}
+	return offset
+}
+
+// lineStart returns the offset of the given line, a trailing line break is added to the content if required
+func (d *document) lineStart(line int) int {
+	if line <= len(d.lineStarts) && d.lineStarts[line-1] < len(d.content) {
+		return d.lineStarts[line-1]
+	}
+	if len(d.content) > 0 && d.content[len(d.content)-1] != '\n' && !d.newline {
+		d.newline = true
+		d.edits = append(d.edits, edit{start: len(d.content), end: len(d.content), text: "\n"})
+	}
+	return len(d.content)
+}
+
+// setScalar replaces the value of a scalar node while keeping its quoting style
+func (d *document) setScalar(node *yaml.Node, value string) error {
+	if node.Kind != yaml.ScalarNode {
+		return errors.Errorf("value in line %v is not a scalar", node.Line)
+	}
+	if node.Value == value {
+		return nil
+	}
+	start := d.offset(node.Line, node.Column)
+	var end int
+	var text string
+	switch {
+	case node.Style&yaml.DoubleQuotedStyle != 0:
+		end = quotedEnd(d.content, start, '"')
+		text = strconv.Quote(value)
+	case node.Style&yaml.SingleQuotedStyle != 0:
+		end = quotedEnd(d.content, start, '\'')
+		text = "'" + strings.ReplaceAll(value, "'", "''") + "'"
+	case node.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0:
+		return errors.Errorf("block scalar in line %v is not supported", node.Line)
+	default:
+		if bytes.HasPrefix(d.content[start:], []byte(node.Value)) {
+			end = start + len(node.Value)
+		}
+		text = scalar(value)
+	}
+	if end <= start {
+		return errors.Errorf("failed to locate value '%v' in line %v", node.Value, node.Line)
+	}
+	d.edits = append(d.edits, edit{start: start, end: end, text: text})
+	return nil
+}
+
+// removeLine removes the line of a key value pair
+func (d *document) removeLine(key, value *yaml.Node) error {
+	if key.Line != value.Line || value.Kind != yaml.ScalarNode {
+		return errors.Errorf("failed to remove '%v' in line %v", key.Value, key.Line)
+	}
+	end := len(d.content)
+	if key.Line < len(d.lineStarts) {
+		end = d.lineStarts[key.Line]
+	}
+	d.edits = append(d.edits, edit{start: d.lineStarts[key.Line-1], end: end})
+	return nil
+}
+
+// insertLines inserts the lines in front of the given line
+func (d *document) insertLines(line int, lines ...string) {
+	offset := d.lineStart(line)
+	d.edits = append(d.edits, edit{start: offset, end: offset, text: strings.Join(lines, "\n") + "\n"})
+}
+
+// bytes returns the content with all edits applied
+func (d *document) bytes() []byte {
+	edits := append([]edit{}, d.edits...)
+	sort.SliceStable(edits, func(i, j int) bool { return edits[i].start < edits[j].start })
+	var result bytes.Buffer
+	position := 0
+	for _, e := range edits {
+		if e.start < position {
+			continue
+		}
+		result.Write(d.content[position:e.start])
+		result.WriteString(e.text)
+		position = e.end
+	}
+	result.Write(d.content[position:])
+	return result.Bytes()
+}
+
+// quotedEnd returns the offset behind the closing quote of a quoted scalar starting at start
+func quotedEnd(content []byte, start int, quote byte) int {
+	if start >= len(content) || content[start] != quote {
+		return -1
+	}
+	for i := start + 1; i < len(content); i++ {
+		switch {
+		case quote == '"' && content[i] == '\\':
+			i++
+		case content[i] == quote && quote == '\'' && i+1 < len(content) && content[i+1] == '\'':
+			i++
+		case content[i] == quote:
+			return i + 1
+		}
+	}
+	return -1
+}
+
+// scalar renders a value as YAML scalar, values which would not be read as string like "1.0" are quoted
+func scalar(value string) string {
+	out, err := yaml.Marshal(value)
+	if err != nil {
+		return strconv.Quote(value)
+	}
+	return strings.TrimSuffix(string(out), "\n")
+}
+
+// lastLine returns the last line occupied by the node and its children
+func lastLine(node *yaml.Node) int {
+	line := node.Line
+	for _, child := range node.Content {
+		if l := lastLine(child); l > line {
+			line = l
+		}
+	}
+	return line
+}
+
+// mappingValue returns key and value node of the given key within a mapping node
+func mappingValue(node *yaml.Node, key string) (*yaml.Node, *yaml.Node) {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil, nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i], node.Content[i+1]
+		}
+	}
+	return nil, nil
+}
+
+var pathSegmentPattern = regexp.MustCompile(`^([^\[\]]*)((?:\[[^\[\]]+\])*)$`)
+var pathSelectorPattern = regexp.MustCompile(`\[([^\[\]]+)\]`)
+
+type pathSegment struct {
+	key       string
+	selectors []string
+}
+
+// parsePath parses paths like 'spec.template.spec.containers[name=app].image' or 'spec.generators[0].list.elements[*].image'
+func parsePath(path string) ([]pathSegment, error) {
+	var segments []pathSegment
+	for _, part := range strings.Split(path, ".") {
+		match := pathSegmentPattern.FindStringSubmatch(part)
+		if match == nil || (len(match[1]) == 0 && len(match[2]) == 0) {
+			return nil, errors.Errorf("invalid YAML path '%v'", path)
+		}
+		segment := pathSegment{key: match[1]}
+		for _, selector := range pathSelectorPattern.FindAllStringSubmatch(match[2], -1) {
+			segment.selectors = append(segment.selectors, selector[1])
+		}
+		segments = append(segments, segment)
+	}
+	return segments, nil
+}
+
+// lookup returns all nodes matching the path segments
+func lookup(node *yaml.Node, segments []pathSegment) []*yaml.Node {
+	if node.Kind == yaml.DocumentNode {
+		if len(node.Content) == 0 {
+			return nil
+		}
+		return lookup(node.Content[0], segments)
+	}
+	if len(segments) == 0 {
+		return []*yaml.Node{node}
+	}
+	nodes := []*yaml.Node{node}
+	if len(segments[0].key) > 0 {
+		_, value := mappingValue(node, segments[0].key)
+		if value == nil {
+			return nil
+		}
+		nodes = []*yaml.Node{value}
+	}
+	for _, selector := range segments[0].selectors {
+		var selected []*yaml.Node
+		for _, n := range nodes {
+			selected = append(selected, selectItems(n, selector)...)
+		}
+		nodes = selected
+	}
+	var result []*yaml.Node
+	for _, n := range nodes {
+		result = append(result, lookup(n, segments[1:])...)
+	}
+	return result
+}
+
+// selectItems supports the selectors '*', an index like '0' and 'key=value' on sequence nodes
+func selectItems(node *yaml.Node, selector string) []*yaml.Node {
+	if node.Kind != yaml.SequenceNode {
+		return nil
+	}
+	if selector == "*" {
+		return node.Content
+	}
+	if index, err := strconv.Atoi(selector); err == nil {
+		if index < 0 || index >= len(node.Content) {
+			return nil
+		}
+		return []*yaml.Node{node.Content[index]}
+	}
+	var result []*yaml.Node
+	if key, value, ok := cut(selector, "="); ok {
+		for _, item := range node.Content {
+			if _, v := mappingValue(item, key); v != nil && v.Kind == yaml.ScalarNode && v.Value == value {
+				result = append(result, item)
+			}
+		}
+	}
+	return result
+}
+
+func cut(s, sep string) (string, string, bool) {
+	if i := strings.Index(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+	return s, "", false
+}
+
+// SetYAMLValues sets all scalar values matching one of the paths in all documents of the content.
+// Only the values themselves are replaced, formatting and comments of the content are kept.
+// Besides the updated content the number of matching values is returned.
+func SetYAMLValues(content []byte, paths []string, value string) ([]byte, int, error) {
+	doc, err := parseDocument(content)
+	if err != nil {
+		return nil, 0, err
+	}
+	matches := 0
+	for _, path := range paths {
+		segments, err := parsePath(path)
+		if err != nil {
+			return nil, 0, err
+		}
+		for _, node := range doc.nodes {
+			for _, match := range lookup(node, segments) {
+				if err := doc.setScalar(match, value); err != nil {
+					return nil, 0, errors.Wrapf(err, "failed to set value of path '%v'", path)
+				}
+				matches++
+			}
+		}
+	}
+	return doc.bytes(), matches, nil
+}
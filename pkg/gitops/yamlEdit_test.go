@@ -0,0 +1,111 @@
+package gitops
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetYAMLValues(t *testing.T) {
+	t.Run("update container image in multiple documents", func(t *testing.T) {
+		content := `# deployment of the application
+apiVersion: apps/v1
+kind: Deployment
+spec:
+  template:
+    spec:
+      containers:
+        - name: sidecar
+          image: proxy:1.0
+        - name: myContainer
+          image: "myregistry.com/myFancyContainer:1336" # set by the pipeline
+---
+apiVersion: batch/v1
+kind: Job
+spec:
+  template:
+    spec:
+      containers:
+      - {name: myContainer, image: 'myregistry.com/myFancyContainer:1336'}
+`
+		expected := `# deployment of the application
+apiVersion: apps/v1
+kind: Deployment
+spec:
+  template:
+    spec:
+      containers:
+        - name: sidecar
+          image: proxy:1.0
+        - name: myContainer
+          image: "myregistry.com/myFancyContainer:1337" # set by the pipeline
+---
+apiVersion: batch/v1
+kind: Job
+spec:
+  template:
+    spec:
+      containers:
+      - {name: myContainer, image: 'myregistry.com/myFancyContainer:1337'}
+`
+		result, matches, err := SetYAMLValues([]byte(content), []string{"spec.template.spec.containers[name=myContainer].image"}, "myregistry.com/myFancyContainer:1337")
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, matches)
+		assert.Equal(t, expected, string(result))
+	})
+
+	t.Run("update tags of application set", func(t *testing.T) {
+		content := `spec:
+  generators:
+    - list:
+        elements:
+          - cluster: dev
+            tag: 1336
+          - cluster: prod
+            tag: 1335
+`
+		expected := `spec:
+  generators:
+    - list:
+        elements:
+          - cluster: dev
+            tag: "1337"
+          - cluster: prod
+            tag: "1337"
+`
+		result, matches, err := SetYAMLValues([]byte(content), []string{"spec.generators[0].list.elements[*].tag"}, "1337")
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, matches)
+		assert.Equal(t, expected, string(result))
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		content := "image: myFancyContainer:1336\n"
+
+		result, matches, err := SetYAMLValues([]byte(content), []string{"spec.image", "images[0]"}, "myFancyContainer:1337")
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, matches)
+		assert.Equal(t, content, string(result))
+	})
+
+	t.Run("value is no scalar", func(t *testing.T) {
+		_, _, err := SetYAMLValues([]byte("image:\n  tag: 1336\n"), []string{"image"}, "1337")
+
+		assert.EqualError(t, err, "failed to set value of path 'image': value in line 2 is not a scalar")
+	})
+
+	t.Run("invalid path", func(t *testing.T) {
+		_, _, err := SetYAMLValues([]byte("image: myFancyContainer:1336\n"), []string{"spec..image"}, "1337")
+
+		assert.EqualError(t, err, "invalid YAML path 'spec..image'")
+	})
+
+	t.Run("invalid YAML", func(t *testing.T) {
+		_, _, err := SetYAMLValues([]byte("image: [\n"), []string{"image"}, "1337")
+
+		assert.Contains(t, err.Error(), "failed to parse YAML content")
+	})
+}
@@ -0,0 +1,102 @@
+package gradle
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/SAP/jenkins-library/pkg/command"
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/piperutils"
+)
+
+const (
+	gradleExecutable  = "gradle"
+	wrapperExecutable = "gradlew"
+)
+
+// ExecuteOptions are used by Execute() to construct the Gradle command line.
+type ExecuteOptions struct {
+	// ProjectDir is the root directory of the Gradle project, the current directory is used if empty
+	ProjectDir string `json:"projectDir,omitempty"`
+	// UseWrapper defines whether the Gradle wrapper of the project is used if available
+	UseWrapper  bool     `json:"useWrapper,omitempty"`
+	Tasks       []string `json:"tasks,omitempty"`
+	InitScripts []string `json:"initScripts,omitempty"`
+	Flags       []string `json:"flags,omitempty"`
+}
+
+// Utils defines the functionality required to execute Gradle
+type Utils interface {
+	Stdout(out io.Writer)
+	Stderr(err io.Writer)
+	SetEnv(env []string)
+	RunExecutable(e string, p ...string) error
+
+	FileExists(filename string) (bool, error)
+	FileWrite(path string, content []byte, perm os.FileMode) error
+	FileRemove(path string) error
+	MkdirAll(path string, perm os.FileMode) error
+}
+
+type utilsBundle struct {
+	*command.Command
+	*piperutils.Files
+}
+
+// NewUtilsBundle creates the utils used to execute Gradle
+func NewUtilsBundle() Utils {
+	utils := utilsBundle{
+		Command: &command.Command{},
+		Files:   &piperutils.Files{},
+	}
+	utils.Stdout(log.Writer())
+	utils.Stderr(log.Writer())
+	return &utils
+}
+
+// Execute constructs a Gradle command line from the given options and runs it.
+// The Gradle wrapper of the project is preferred over the gradle executable if enabled.
+func Execute(options *ExecuteOptions, utils Utils) error {
+	if len(options.Tasks) == 0 {
+		return fmt.Errorf("no Gradle tasks specified")
+	}
+	executable := Executable(options, utils)
+
+	parameters := []string{"--no-daemon", "--console=plain"}
+	if len(options.ProjectDir) > 0 && options.ProjectDir != "." {
+		parameters = append(parameters, "--project-dir", options.ProjectDir)
+	}
+	for _, initScript := range options.InitScripts {
+		parameters = append(parameters, "--init-script", initScript)
+	}
+	parameters = append(parameters, options.Flags...)
+	parameters = append(parameters, options.Tasks...)
+
+	if err := utils.RunExecutable(executable, parameters...); err != nil {
+		log.SetErrorCategory(log.ErrorBuild)
+		commandLine := append([]string{executable}, parameters...)
+		return fmt.Errorf("failed to run executable, command: '%s', error: %w", commandLine, err)
+	}
+	return nil
+}
+
+// Executable returns the Gradle wrapper of the project if it is available and enabled, otherwise the gradle executable
+func Executable(options *ExecuteOptions, utils Utils) string {
+	if !options.UseWrapper {
+		return gradleExecutable
+	}
+	wrapper := filepath.Join(options.ProjectDir, wrapperExecutable)
+	if exists, _ := utils.FileExists(wrapper); !exists {
+		log.Entry().Debugf("No Gradle wrapper found at '%v', using %v", wrapper, gradleExecutable)
+		return gradleExecutable
+	}
+	log.Entry().Infof("Using Gradle wrapper '%v'", wrapper)
+	// executables without path separator are looked up via PATH
+	if !strings.ContainsRune(wrapper, filepath.Separator) {
+		return "." + string(filepath.Separator) + wrapper
+	}
+	return wrapper
+}
@@ -0,0 +1,70 @@
+package gradle
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/SAP/jenkins-library/pkg/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+type gradleMockUtils struct {
+	*mock.ExecMockRunner
+	*mock.FilesMock
+}
+
+func newGradleMockUtils() *gradleMockUtils {
+	return &gradleMockUtils{ExecMockRunner: &mock.ExecMockRunner{}, FilesMock: &mock.FilesMock{}}
+}
+
+func TestExecute(t *testing.T) {
+	t.Run("gradle executable", func(t *testing.T) {
+		utils := newGradleMockUtils()
+		options := ExecuteOptions{Tasks: []string{"build"}, InitScripts: []string{".pipeline/init.gradle"}, Flags: []string{"--stacktrace"}}
+
+		err := Execute(&options, utils)
+
+		assert.NoError(t, err)
+		if assert.Len(t, utils.Calls, 1) {
+			assert.Equal(t, mock.ExecCall{Exec: "gradle", Params: []string{"--no-daemon", "--console=plain", "--init-script", ".pipeline/init.gradle", "--stacktrace", "build"}}, utils.Calls[0])
+		}
+	})
+
+	t.Run("wrapper of sub project", func(t *testing.T) {
+		utils := newGradleMockUtils()
+		utils.AddFile("backend/gradlew", []byte{})
+		options := ExecuteOptions{ProjectDir: "backend", UseWrapper: true, Tasks: []string{"clean", "build"}}
+
+		err := Execute(&options, utils)
+
+		assert.NoError(t, err)
+		if assert.Len(t, utils.Calls, 1) {
+			assert.Equal(t, mock.ExecCall{Exec: "backend/gradlew", Params: []string{"--no-daemon", "--console=plain", "--project-dir", "backend", "clean", "build"}}, utils.Calls[0])
+		}
+	})
+
+	t.Run("no tasks", func(t *testing.T) {
+		err := Execute(&ExecuteOptions{}, newGradleMockUtils())
+
+		assert.EqualError(t, err, "no Gradle tasks specified")
+	})
+
+	t.Run("execution failure", func(t *testing.T) {
+		utils := newGradleMockUtils()
+		utils.ShouldFailOnCommand = map[string]error{"gradle": errors.New("exit status 1")}
+
+		err := Execute(&ExecuteOptions{Tasks: []string{"build"}}, utils)
+
+		assert.EqualError(t, err, "failed to run executable, command: '[gradle --no-daemon --console=plain build]', error: exit status 1")
+	})
+}
+
+func TestExecutable(t *testing.T) {
+	utils := newGradleMockUtils()
+	utils.AddFile("gradlew", []byte{})
+
+	assert.Equal(t, "./gradlew", Executable(&ExecuteOptions{UseWrapper: true}, utils))
+	assert.Equal(t, "./gradlew", Executable(&ExecuteOptions{ProjectDir: ".", UseWrapper: true}, utils))
+	assert.Equal(t, "gradle", Executable(&ExecuteOptions{}, utils))
+	assert.Equal(t, "gradle", Executable(&ExecuteOptions{ProjectDir: "backend", UseWrapper: true}, utils))
+}
@@ -0,0 +1,142 @@
+package gradle
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"text/template"
+
+	"github.com/pkg/errors"
+)
+
+// environment variables providing the repository credentials to the init script,
+// this way the credentials are not written to the file system
+const (
+	MirrorUsernameEnv  = "PIPER_GRADLE_MIRROR_USERNAME"
+	MirrorPasswordEnv  = "PIPER_GRADLE_MIRROR_PASSWORD"
+	PublishUsernameEnv = "PIPER_GRADLE_PUBLISH_USERNAME"
+	PublishPasswordEnv = "PIPER_GRADLE_PUBLISH_PASSWORD"
+)
+
+// InitScriptOptions defines the repositories configured via the init script
+type InitScriptOptions struct {
+	// MirrorURL replaces all Maven repositories of the build, e.g. by a Nexus proxy repository
+	MirrorURL      string
+	MirrorUsername string
+	MirrorPassword string
+	// PublishURL is added as target repository of the maven-publish plugin
+	PublishURL      string
+	PublishUsername string
+	PublishPassword string
+}
+
+const initScriptTemplate = `// generated by piper, do not edit
+{{- if .MirrorURL }}
+
+def piperMirrorUrl = '{{ .MirrorURL }}'
+def piperMirror = { RepositoryHandler repositories ->
+    repositories.all { ArtifactRepository repository ->
+        if (repository instanceof MavenArtifactRepository && repository.url.toString() != piperMirrorUrl) {
+            repositories.remove repository
+        }
+    }
+    repositories.maven {
+        name 'piperMirror'
+        url piperMirrorUrl
+{{- if .MirrorCredentials }}
+        credentials {
+            username System.getenv('{{ .MirrorUsernameEnv }}')
+            password System.getenv('{{ .MirrorPasswordEnv }}')
+        }
+{{- end }}
+    }
+}
+
+settingsEvaluated { settings ->
+    piperMirror(settings.pluginManagement.repositories)
+}
+
+allprojects {
+    buildscript {
+        piperMirror(repositories)
+    }
+    piperMirror(repositories)
+}
+{{- end }}
+{{- if .PublishURL }}
+
+allprojects {
+    plugins.withId('maven-publish') {
+        publishing {
+            repositories {
+                maven {
+                    name 'piperPublish'
+                    url '{{ .PublishURL }}'
+{{- if .PublishCredentials }}
+                    credentials {
+                        username System.getenv('{{ .PublishUsernameEnv }}')
+                        password System.getenv('{{ .PublishPasswordEnv }}')
+                    }
+{{- end }}
+                }
+            }
+        }
+    }
+}
+{{- end }}
+`
+
+// InitScript renders the init script which configures the repositories of the build
+func InitScript(options InitScriptOptions) ([]byte, error) {
+	tmpl, err := template.New("init.gradle").Parse(initScriptTemplate)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse init script template")
+	}
+	data := struct {
+		InitScriptOptions
+		MirrorCredentials  bool
+		PublishCredentials bool
+		MirrorUsernameEnv  string
+		MirrorPasswordEnv  string
+		PublishUsernameEnv string
+		PublishPasswordEnv string
+	}{
+		InitScriptOptions:  options,
+		MirrorCredentials:  len(options.MirrorUsername) > 0 && len(options.MirrorPassword) > 0,
+		PublishCredentials: len(options.PublishUsername) > 0 && len(options.PublishPassword) > 0,
+		MirrorUsernameEnv:  MirrorUsernameEnv,
+		MirrorPasswordEnv:  MirrorPasswordEnv,
+		PublishUsernameEnv: PublishUsernameEnv,
+		PublishPasswordEnv: PublishPasswordEnv,
+	}
+	var script bytes.Buffer
+	if err := tmpl.Execute(&script, data); err != nil {
+		return nil, errors.Wrap(err, "failed to render init script")
+	}
+	return script.Bytes(), nil
+}
+
+// WriteInitScript writes the init script to the given path and provides the credentials via environment variables.
+// Nothing is written if neither a mirror nor a publish repository is configured, an empty path is returned in this case.
+func WriteInitScript(path string, options InitScriptOptions, utils Utils) (string, error) {
+	if len(options.MirrorURL) == 0 && len(options.PublishURL) == 0 {
+		return "", nil
+	}
+	script, err := InitScript(options)
+	if err != nil {
+		return "", err
+	}
+	if err := utils.MkdirAll(filepath.Dir(path), 0777); err != nil {
+		return "", errors.Wrapf(err, "failed to create directory of init script '%v'", path)
+	}
+	if err := utils.FileWrite(path, script, 0666); err != nil {
+		return "", fmt.Errorf("failed to write init script to '%s': %w", path, err)
+	}
+	utils.SetEnv([]string{
+		MirrorUsernameEnv + "=" + options.MirrorUsername,
+		MirrorPasswordEnv + "=" + options.MirrorPassword,
+		PublishUsernameEnv + "=" + options.PublishUsername,
+		PublishPasswordEnv + "=" + options.PublishPassword,
+	})
+	return path, nil
+}
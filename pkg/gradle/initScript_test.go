@@ -0,0 +1,54 @@
+package gradle
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInitScript(t *testing.T) {
+	t.Run("mirror with credentials", func(t *testing.T) {
+		script, err := InitScript(InitScriptOptions{MirrorURL: "https://nexus.example.com/repository/maven-public/", MirrorUsername: "user", MirrorPassword: "secret"})
+
+		assert.NoError(t, err)
+		assert.Contains(t, string(script), "def piperMirrorUrl = 'https://nexus.example.com/repository/maven-public/'")
+		assert.Contains(t, string(script), "username System.getenv('PIPER_GRADLE_MIRROR_USERNAME')")
+		assert.Contains(t, string(script), "piperMirror(settings.pluginManagement.repositories)")
+		assert.NotContains(t, string(script), "secret")
+		assert.NotContains(t, string(script), "piperPublish")
+	})
+
+	t.Run("publish without credentials", func(t *testing.T) {
+		script, err := InitScript(InitScriptOptions{PublishURL: "https://nexus.example.com/repository/maven-releases/"})
+
+		assert.NoError(t, err)
+		assert.Contains(t, string(script), "plugins.withId('maven-publish')")
+		assert.Contains(t, string(script), "url 'https://nexus.example.com/repository/maven-releases/'")
+		assert.NotContains(t, string(script), "credentials")
+		assert.NotContains(t, string(script), "piperMirror")
+	})
+}
+
+func TestWriteInitScript(t *testing.T) {
+	t.Run("write script and credentials", func(t *testing.T) {
+		utils := newGradleMockUtils()
+
+		path, err := WriteInitScript(".pipeline/piperGradleInit.gradle", InitScriptOptions{PublishURL: "https://nexus.example.com/repository/maven-releases/", PublishUsername: "user", PublishPassword: "secret"}, utils)
+
+		assert.NoError(t, err)
+		assert.Equal(t, ".pipeline/piperGradleInit.gradle", path)
+		assert.True(t, utils.HasWrittenFile(".pipeline/piperGradleInit.gradle"))
+		assert.Contains(t, utils.Env, "PIPER_GRADLE_PUBLISH_USERNAME=user")
+		assert.Contains(t, utils.Env, "PIPER_GRADLE_PUBLISH_PASSWORD=secret")
+	})
+
+	t.Run("nothing to configure", func(t *testing.T) {
+		utils := newGradleMockUtils()
+
+		path, err := WriteInitScript(".pipeline/piperGradleInit.gradle", InitScriptOptions{}, utils)
+
+		assert.NoError(t, err)
+		assert.Empty(t, path)
+		assert.False(t, utils.HasWrittenFile(".pipeline/piperGradleInit.gradle"))
+	})
+}
@@ -20,12 +20,14 @@ import (
 	"time"
 
 	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/SAP/jenkins-library/pkg/opentelemetry"
 	"github.com/SAP/jenkins-library/pkg/piperutils"
 	"github.com/SAP/jenkins-library/pkg/reporting"
 	"github.com/hashicorp/go-retryablehttp"
 	"github.com/motemen/go-nuts/roundtime"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // Client defines an http client object
@@ -207,11 +209,26 @@ func (c *Client) SendRequest(method, url string, body io.Reader, header http.Hea
 // Send sends an http request
 func (c *Client) Send(request *http.Request) (*http.Response, error) {
 	httpClient := c.initialize()
+
+	// neither credentials nor query parameters are added to the span since they might contain secrets
+	ctx, span := opentelemetry.StartSpan(fmt.Sprintf("HTTP %v", request.Method),
+		attribute.String("http.method", request.Method),
+		attribute.String("http.url", fmt.Sprintf("%v://%v%v", request.URL.Scheme, request.URL.Host, request.URL.Path)),
+	)
+	if request.Header == nil {
+		request.Header = http.Header{}
+	}
+	opentelemetry.InjectTraceContext(ctx, request.Header)
+
 	response, err := httpClient.Do(request)
 	if err != nil {
+		opentelemetry.EndSpan(span, err)
 		return response, errors.Wrapf(err, "HTTP %v request to %v failed", request.Method, request.URL)
 	}
-	return c.handleResponse(response, request.URL.String())
+	span.SetAttributes(attribute.Int("http.status_code", response.StatusCode))
+	response, err = c.handleResponse(response, request.URL.String())
+	opentelemetry.EndSpan(span, err)
+	return response, err
 }
 
 // SetOptions sets options used for the http client
@@ -0,0 +1,139 @@
+package kubernetes
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// Workload is a Deployment or StatefulSet whose rollout can be watched
+type Workload struct {
+	Kind      string
+	Name      string
+	Namespace string
+	// Selector contains the labels identifying the pods of the workload
+	Selector map[string]string
+}
+
+// Resource returns the workload in the notation used by kubectl, e.g. deployment/my-app
+func (w Workload) Resource() string {
+	return strings.ToLower(w.Kind) + "/" + w.Name
+}
+
+// LabelSelector returns the selector of the pods in the notation used by kubectl, e.g. app=my-app,tier=web
+func (w Workload) LabelSelector() string {
+	labels := []string{}
+	for key, value := range w.Selector {
+		labels = append(labels, fmt.Sprintf("%v=%v", key, value))
+	}
+	sort.Strings(labels)
+	return strings.Join(labels, ",")
+}
+
+type manifest struct {
+	Kind     string `yaml:"kind"`
+	Metadata struct {
+		Name      string `yaml:"name"`
+		Namespace string `yaml:"namespace"`
+	} `yaml:"metadata"`
+	Spec struct {
+		Selector struct {
+			MatchLabels map[string]string `yaml:"matchLabels"`
+		} `yaml:"selector"`
+	} `yaml:"spec"`
+}
+
+// WorkloadsFromManifests returns the Deployments and StatefulSets contained in a multi-document YAML like an app template or the manifest of a helm release
+func WorkloadsFromManifests(manifests []byte) ([]Workload, error) {
+	workloads := []Workload{}
+	decoder := yaml.NewDecoder(bytes.NewReader(manifests))
+	for {
+		var m manifest
+		err := decoder.Decode(&m)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse manifests")
+		}
+		if (m.Kind != "Deployment" && m.Kind != "StatefulSet") || len(m.Metadata.Name) == 0 {
+			continue
+		}
+		workloads = append(workloads, Workload{
+			Kind:      m.Kind,
+			Name:      m.Metadata.Name,
+			Namespace: m.Metadata.Namespace,
+			Selector:  m.Spec.Selector.MatchLabels,
+		})
+	}
+	return workloads, nil
+}
+
+// Pod contains the containers of a pod which are not ready
+type Pod struct {
+	Name       string
+	Phase      string
+	Containers []Container
+}
+
+// Container describes a container which is not ready
+type Container struct {
+	Name string
+	// Restarted is true in case the container already crashed, its previous logs contain the reason
+	Restarted bool
+	Reason    string
+}
+
+type podList struct {
+	Items []struct {
+		Metadata struct {
+			Name string `json:"name"`
+		} `json:"metadata"`
+		Status struct {
+			Phase             string `json:"phase"`
+			ContainerStatuses []struct {
+				Name         string `json:"name"`
+				Ready        bool   `json:"ready"`
+				RestartCount int    `json:"restartCount"`
+				State        map[string]struct {
+					Reason string `json:"reason"`
+				} `json:"state"`
+			} `json:"containerStatuses"`
+		} `json:"status"`
+	} `json:"items"`
+}
+
+// FailingPods returns the pods of a pod list (kubectl get pods --output=json) which are not running properly
+func FailingPods(podListJSON []byte) ([]Pod, error) {
+	var pods podList
+	if err := json.Unmarshal(podListJSON, &pods); err != nil {
+		return nil, errors.Wrap(err, "failed to parse pod list")
+	}
+	failing := []Pod{}
+	for _, item := range pods.Items {
+		if item.Status.Phase == "Succeeded" {
+			continue
+		}
+		pod := Pod{Name: item.Metadata.Name, Phase: item.Status.Phase}
+		for _, status := range item.Status.ContainerStatuses {
+			if status.Ready {
+				continue
+			}
+			container := Container{Name: status.Name, Restarted: status.RestartCount > 0}
+			for _, state := range status.State {
+				container.Reason = state.Reason
+			}
+			pod.Containers = append(pod.Containers, container)
+		}
+		if len(pod.Containers) > 0 || item.Status.Phase == "Pending" || item.Status.Phase == "Failed" {
+			failing = append(failing, pod)
+		}
+	}
+	return failing, nil
+}
@@ -0,0 +1,82 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkloadsFromManifests(t *testing.T) {
+	t.Run("deployments and statefulsets", func(t *testing.T) {
+		manifests := `---
+# Source: app/templates/service.yaml
+apiVersion: v1
+kind: Service
+metadata:
+  name: my-app
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: my-app
+spec:
+  selector:
+    matchLabels:
+      tier: web
+      app: my-app
+---
+apiVersion: apps/v1
+kind: StatefulSet
+metadata:
+  name: my-db
+  namespace: data
+spec:
+  selector:
+    matchLabels:
+      app: my-db
+`
+
+		workloads, err := WorkloadsFromManifests([]byte(manifests))
+
+		assert.NoError(t, err)
+		if assert.Len(t, workloads, 2) {
+			assert.Equal(t, "deployment/my-app", workloads[0].Resource())
+			assert.Equal(t, "app=my-app,tier=web", workloads[0].LabelSelector())
+			assert.Equal(t, "statefulset/my-db", workloads[1].Resource())
+			assert.Equal(t, "data", workloads[1].Namespace)
+		}
+	})
+
+	t.Run("invalid manifest", func(t *testing.T) {
+		_, err := WorkloadsFromManifests([]byte("kind: [Deployment"))
+
+		assert.Contains(t, err.Error(), "failed to parse manifests")
+	})
+}
+
+func TestFailingPods(t *testing.T) {
+	podList := `{"items": [
+		{"metadata": {"name": "my-app-1"}, "status": {"phase": "Running", "containerStatuses": [
+			{"name": "app", "ready": false, "restartCount": 4, "state": {"waiting": {"reason": "CrashLoopBackOff"}}},
+			{"name": "sidecar", "ready": true, "restartCount": 0, "state": {"running": {}}}
+		]}},
+		{"metadata": {"name": "my-app-2"}, "status": {"phase": "Running", "containerStatuses": [
+			{"name": "app", "ready": true, "restartCount": 0, "state": {"running": {}}}
+		]}},
+		{"metadata": {"name": "my-app-3"}, "status": {"phase": "Pending"}},
+		{"metadata": {"name": "my-job"}, "status": {"phase": "Succeeded", "containerStatuses": [
+			{"name": "job", "ready": false, "restartCount": 0, "state": {"terminated": {"reason": "Completed"}}}
+		]}}
+	]}`
+
+	pods, err := FailingPods([]byte(podList))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []Pod{
+		{Name: "my-app-1", Phase: "Running", Containers: []Container{{Name: "app", Restarted: true, Reason: "CrashLoopBackOff"}}},
+		{Name: "my-app-3", Phase: "Pending"},
+	}, pods)
+
+	_, err = FailingPods([]byte("no json"))
+	assert.Contains(t, err.Error(), "failed to parse pod list")
+}
@@ -13,6 +13,7 @@ const (
 	ErrorInfrastructure
 	ErrorService
 	ErrorTest
+	ErrorDeployment
 )
 
 var errorCategory ErrorCategory = ErrorUndefined
@@ -27,6 +28,7 @@ func (e ErrorCategory) String() string {
 		"infrastructure",
 		"service",
 		"test",
+		"deployment",
 	}[e]
 }
 
@@ -47,6 +49,8 @@ func ErrorCategoryByString(category string) ErrorCategory {
 		return ErrorService
 	case "test":
 		return ErrorTest
+	case "deployment":
+		return ErrorDeployment
 	}
 	return ErrorUndefined
 }
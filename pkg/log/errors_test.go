@@ -17,3 +17,9 @@ func TestGetErrorCategory(t *testing.T) {
 	errorCategory = ErrorCompliance
 	assert.Equal(t, GetErrorCategory(), errorCategory)
 }
+
+func TestErrorCategoryByString(t *testing.T) {
+	assert.Equal(t, ErrorDeployment, ErrorCategoryByString("deployment"))
+	assert.Equal(t, "deployment", ErrorDeployment.String())
+	assert.Equal(t, ErrorUndefined, ErrorCategoryByString("unknown"))
+}
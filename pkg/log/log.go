@@ -1,10 +1,13 @@
 package log
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/sirupsen/logrus"
 )
@@ -19,6 +22,7 @@ const (
 	logFormatPlain         = "plain"
 	logFormatDefault       = "default"
 	logFormatWithTimestamp = "timestamp"
+	logFormatJSON          = "json"
 )
 
 //Format the log message
@@ -42,6 +46,8 @@ func (formatter *PiperLogFormatter) Format(entry *logrus.Entry) (bytes []byte, e
 	}
 
 	switch formatter.logFormat {
+	case logFormatJSON:
+		return formatJSON(entry, levelString)
 	case logFormatDefault:
 		message = fmt.Sprintf("%-5s %-6s - %s%s\n", levelString, stepName, entry.Message, errorMessageSnippet)
 	case logFormatWithTimestamp:
@@ -56,11 +62,77 @@ func (formatter *PiperLogFormatter) Format(entry *logrus.Entry) (bytes []byte, e
 		message = string(formattedMessage)
 	}
 
-	for _, secret := range secrets {
-		message = strings.Replace(message, secret, "****", -1)
+	return []byte(maskSecrets(message)), nil
+}
+
+// formatJSON writes the log entry as one line of JSON containing all fields of the entry.
+// Secrets are masked within the message as well as within the field values.
+func formatJSON(entry *logrus.Entry, level string) ([]byte, error) {
+	data := map[string]interface{}{}
+	for key, value := range entry.Data {
+		switch key {
+		case "time", "level", "message", "errorCategory":
+			// avoid that fields overwrite the default keys
+			key = "fields." + key
+		}
+		data[key] = maskValue(value)
+	}
+	data["time"] = entry.Time.Format(time.RFC3339Nano)
+	data["level"] = level
+	data["message"] = maskSecrets(entry.Message)
+	data["errorCategory"] = GetErrorCategory().String()
+
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(data); err != nil {
+		return nil, fmt.Errorf("failed to marshal log entry to JSON: %w", err)
+	}
+	return buffer.Bytes(), nil
+}
+
+// maskValue masks secrets within a field value, values other than strings are masked based on their JSON representation
+func maskValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		return maskSecrets(v)
+	case error:
+		return maskSecrets(v.Error())
+	case nil, bool, int, int64, float64:
+		return v
+	}
+	serialized, err := json.Marshal(value)
+	if err != nil {
+		return maskSecrets(fmt.Sprint(value))
+	}
+	var generic interface{}
+	if err := json.Unmarshal(serialized, &generic); err != nil {
+		return maskSecrets(string(serialized))
+	}
+	return maskGeneric(generic)
+}
+
+func maskGeneric(value interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		return maskSecrets(v)
+	case []interface{}:
+		for i := range v {
+			v[i] = maskGeneric(v[i])
+		}
+	case map[string]interface{}:
+		for key := range v {
+			v[key] = maskGeneric(v[key])
+		}
 	}
+	return value
+}
 
-	return []byte(message), nil
+func maskSecrets(text string) string {
+	for _, secret := range secrets {
+		text = strings.Replace(text, secret, "****", -1)
+	}
+	return text
 }
 
 // LibraryRepository that is passed into with -ldflags
@@ -102,6 +174,20 @@ func SetStepName(stepName string) {
 	logger = Entry().WithField("stepName", stepName)
 }
 
+// SetStageName sets the stageName field.
+func SetStageName(stageName string) {
+	if len(stageName) > 0 {
+		logger = Entry().WithField("stageName", stageName)
+	}
+}
+
+// SetCorrelationID sets the correlationId field.
+func SetCorrelationID(correlationID string) {
+	if len(correlationID) > 0 {
+		logger = Entry().WithField("correlationId", correlationID)
+	}
+}
+
 // DeferExitHandler registers a logrus exit handler to allow cleanup activities.
 func DeferExitHandler(handler func()) {
 	logrus.DeferExitHandler(handler)
@@ -0,0 +1,236 @@
+package opentelemetry
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	controller "go.opentelemetry.io/otel/sdk/metric/controller/basic"
+	processor "go.opentelemetry.io/otel/sdk/metric/processor/basic"
+	"go.opentelemetry.io/otel/sdk/metric/selector/simple"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	instrumentationName = "github.com/SAP/jenkins-library"
+	exportTimeout       = 5 * time.Second
+)
+
+// metricAttributes are the telemetry attributes which are added to metrics, all others would result in too many time series
+var metricAttributes = []string{"stepName", "stageName", "orchestrator", "exitCode", "errorCategory"}
+
+// stepContext contains the span of the currently executed step, child spans are created within this context
+var stepContext = context.Background()
+
+// Config defines the configuration of the OTLP exporters
+type Config struct {
+	// Endpoint of the collector as host:port, e.g. localhost:4317
+	Endpoint string `json:"endpoint,omitempty"`
+	// Protocol used for the export, either grpc (default) or http
+	Protocol string            `json:"protocol,omitempty"`
+	Insecure bool              `json:"insecure,omitempty"`
+	Headers  map[string]string `json:"headers,omitempty"`
+}
+
+// OpenTelemetry exports a span per step execution as well as step metrics via OTLP.
+// Commands and HTTP requests executed by the step are traced as child spans of the step span.
+type OpenTelemetry struct {
+	tracerProvider   *sdktrace.TracerProvider
+	metricController *controller.Controller
+	stepSpan         trace.Span
+	startTime        time.Time
+}
+
+// Initialize sets up the exporters and starts the span of the step.
+// If the environment variable TRACEPARENT contains a W3C trace context, the step span becomes part of this trace.
+func (o *OpenTelemetry) Initialize(correlationID, stepName string, config Config) error {
+	log.Entry().Debugf("Initializing OpenTelemetry with endpoint %v", config.Endpoint)
+	for _, header := range config.Headers {
+		log.RegisterSecret(header)
+	}
+
+	ctx := context.Background()
+	res := resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.ServiceNameKey.String("piper"),
+		attribute.String("piper.correlationId", correlationID),
+	)
+
+	traceExporter, err := newTraceExporter(ctx, config)
+	if err != nil {
+		return errors.Wrap(err, "failed to create OpenTelemetry trace exporter")
+	}
+	metricExporter, err := newMetricExporter(ctx, config)
+	if err != nil {
+		return errors.Wrap(err, "failed to create OpenTelemetry metric exporter")
+	}
+
+	o.tracerProvider = sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(traceExporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(o.tracerProvider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	o.metricController = controller.New(
+		processor.New(simple.NewWithHistogramDistribution(), metricExporter),
+		controller.WithExporter(metricExporter),
+		controller.WithResource(res),
+	)
+	if err := o.metricController.Start(ctx); err != nil {
+		return errors.Wrap(err, "failed to start OpenTelemetry metric controller")
+	}
+
+	if traceParent := os.Getenv("TRACEPARENT"); len(traceParent) > 0 {
+		carrier := propagation.HeaderCarrier(http.Header{})
+		carrier.Set("traceparent", traceParent)
+		ctx = propagation.TraceContext{}.Extract(ctx, carrier)
+	}
+	o.startTime = time.Now()
+	stepContext, o.stepSpan = otel.Tracer(instrumentationName).Start(ctx, stepName, trace.WithTimestamp(o.startTime))
+	return nil
+}
+
+// Send ends the step span using the telemetry data as attributes, records the step metrics and exports all data.
+// The telemetry data is expected with the labels of the telemetry data as keys, e.g. exitCode and errorCategory.
+func (o *OpenTelemetry) Send(telemetryData map[string]string) error {
+	if o.stepSpan == nil {
+		return nil
+	}
+
+	o.stepSpan.SetAttributes(toAttributes(telemetryData, nil)...)
+	if exitCode := telemetryData["exitCode"]; exitCode != "0" {
+		o.stepSpan.SetStatus(codes.Error, fmt.Sprintf("step failed with error category '%v'", telemetryData["errorCategory"]))
+	}
+	o.stepSpan.End()
+	stepContext = context.Background()
+
+	meter := o.metricController.MeterProvider().Meter(instrumentationName)
+	labels := toAttributes(telemetryData, metricAttributes)
+	duration := metric.Must(meter).NewFloat64Histogram("piper.step.duration", metric.WithDescription("Duration of a step execution in milliseconds"))
+	executions := metric.Must(meter).NewInt64Counter("piper.step.executions", metric.WithDescription("Number of step executions"))
+	meter.RecordBatch(context.Background(), labels,
+		duration.Measurement(float64(time.Since(o.startTime).Milliseconds())),
+		executions.Measurement(1),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), exportTimeout)
+	defer cancel()
+	if err := o.tracerProvider.Shutdown(ctx); err != nil {
+		return errors.Wrap(err, "failed to export OpenTelemetry traces")
+	}
+	if err := o.metricController.Stop(ctx); err != nil {
+		return errors.Wrap(err, "failed to export OpenTelemetry metrics")
+	}
+	return nil
+}
+
+// StartSpan starts a child span of the currently executed step.
+// It does not record anything as long as OpenTelemetry has not been initialized.
+func StartSpan(name string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(stepContext, name, trace.WithAttributes(attributes...))
+}
+
+// EndSpan ends the span and marks it as failed in case of an error
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// InjectTraceContext adds the trace context of the span contained in ctx to the headers of an outgoing request
+func InjectTraceContext(ctx context.Context, header http.Header) {
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(header))
+}
+
+func toAttributes(data map[string]string, keys []string) []attribute.KeyValue {
+	if keys == nil {
+		for key := range data {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+	}
+	attributes := []attribute.KeyValue{}
+	for _, key := range keys {
+		if value, ok := data[key]; ok && len(value) > 0 {
+			attributes = append(attributes, attribute.String("piper."+key, value))
+		}
+	}
+	return attributes
+}
+
+func newTraceExporter(ctx context.Context, config Config) (*otlptrace.Exporter, error) {
+	switch config.Protocol {
+	case "", "grpc":
+		options := []otlptracegrpc.Option{
+			otlptracegrpc.WithEndpoint(config.Endpoint),
+			otlptracegrpc.WithHeaders(config.Headers),
+			otlptracegrpc.WithTimeout(exportTimeout),
+			otlptracegrpc.WithRetry(otlptracegrpc.RetryConfig{Enabled: false}),
+		}
+		if config.Insecure {
+			options = append(options, otlptracegrpc.WithInsecure())
+		}
+		return otlptracegrpc.New(ctx, options...)
+	case "http":
+		options := []otlptracehttp.Option{
+			otlptracehttp.WithEndpoint(config.Endpoint),
+			otlptracehttp.WithHeaders(config.Headers),
+			otlptracehttp.WithTimeout(exportTimeout),
+			otlptracehttp.WithRetry(otlptracehttp.RetryConfig{Enabled: false}),
+		}
+		if config.Insecure {
+			options = append(options, otlptracehttp.WithInsecure())
+		}
+		return otlptracehttp.New(ctx, options...)
+	}
+	return nil, fmt.Errorf("protocol '%v' is not supported, use grpc or http", config.Protocol)
+}
+
+func newMetricExporter(ctx context.Context, config Config) (*otlpmetric.Exporter, error) {
+	switch config.Protocol {
+	case "", "grpc":
+		options := []otlpmetricgrpc.Option{
+			otlpmetricgrpc.WithEndpoint(config.Endpoint),
+			otlpmetricgrpc.WithHeaders(config.Headers),
+			otlpmetricgrpc.WithTimeout(exportTimeout),
+			otlpmetricgrpc.WithRetry(otlpmetricgrpc.RetrySettings{Enabled: false}),
+		}
+		if config.Insecure {
+			options = append(options, otlpmetricgrpc.WithInsecure())
+		}
+		return otlpmetricgrpc.New(ctx, options...)
+	case "http":
+		options := []otlpmetrichttp.Option{
+			otlpmetrichttp.WithEndpoint(config.Endpoint),
+			otlpmetrichttp.WithHeaders(config.Headers),
+			otlpmetrichttp.WithTimeout(exportTimeout),
+			otlpmetrichttp.WithMaxAttempts(1),
+		}
+		if config.Insecure {
+			options = append(options, otlpmetrichttp.WithInsecure())
+		}
+		return otlpmetrichttp.New(ctx, options...)
+	}
+	return nil, fmt.Errorf("protocol '%v' is not supported, use grpc or http", config.Protocol)
+}
@@ -0,0 +1,160 @@
+package opentelemetry
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metricservice "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	traceservice "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// collectorMock is a stand-in for an OpenTelemetry collector receiving OTLP via HTTP
+type collectorMock struct {
+	mutex   sync.Mutex
+	spans   []*tracepb.Span
+	metrics []string
+}
+
+func (c *collectorMock) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	body, _ := ioutil.ReadAll(r.Body)
+	switch r.URL.Path {
+	case "/v1/traces":
+		request := traceservice.ExportTraceServiceRequest{}
+		if err := proto.Unmarshal(body, &request); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		for _, resourceSpans := range request.ResourceSpans {
+			for _, librarySpans := range resourceSpans.InstrumentationLibrarySpans {
+				c.spans = append(c.spans, librarySpans.Spans...)
+			}
+		}
+	case "/v1/metrics":
+		request := metricservice.ExportMetricsServiceRequest{}
+		if err := proto.Unmarshal(body, &request); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		for _, resourceMetrics := range request.ResourceMetrics {
+			for _, libraryMetrics := range resourceMetrics.InstrumentationLibraryMetrics {
+				for _, metric := range libraryMetrics.Metrics {
+					c.metrics = append(c.metrics, metric.Name)
+				}
+			}
+		}
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/x-protobuf")
+	w.WriteHeader(http.StatusOK)
+}
+
+func (c *collectorMock) span(name string) *tracepb.Span {
+	for _, span := range c.spans {
+		if span.Name == name {
+			return span
+		}
+	}
+	return nil
+}
+
+func spanAttributes(span *tracepb.Span) map[string]string {
+	attributes := map[string]string{}
+	for _, attribute := range span.Attributes {
+		attributes[attribute.Key] = attribute.Value.GetStringValue()
+	}
+	return attributes
+}
+
+func TestStartSpan(t *testing.T) {
+	t.Run("not initialized", func(t *testing.T) {
+		_, span := StartSpan("run mvn")
+		assert.False(t, span.IsRecording())
+		EndSpan(span, nil)
+	})
+}
+
+func TestOpenTelemetry(t *testing.T) {
+	t.Run("success - export via http", func(t *testing.T) {
+		collector := &collectorMock{}
+		server := httptest.NewServer(collector)
+		defer server.Close()
+
+		otelClient := OpenTelemetry{}
+		err := otelClient.Initialize("correlation", "mavenBuild", Config{
+			Endpoint: strings.TrimPrefix(server.URL, "http://"),
+			Protocol: "http",
+			Insecure: true,
+		})
+		require.NoError(t, err)
+
+		_, commandSpan := StartSpan("run mvn")
+		EndSpan(commandSpan, fmt.Errorf("exit status 1"))
+		err = otelClient.Send(map[string]string{"stepName": "mavenBuild", "exitCode": "1", "errorCategory": "build", "pipelineUrlHash": "abc"})
+		assert.NoError(t, err)
+
+		collector.mutex.Lock()
+		defer collector.mutex.Unlock()
+		stepSpan := collector.span("mavenBuild")
+		require.NotNil(t, stepSpan)
+		assert.Equal(t, tracepb.Status_STATUS_CODE_ERROR, stepSpan.Status.Code)
+		assert.Equal(t, "step failed with error category 'build'", stepSpan.Status.Message)
+		assert.Equal(t, map[string]string{
+			"piper.stepName":        "mavenBuild",
+			"piper.exitCode":        "1",
+			"piper.errorCategory":   "build",
+			"piper.pipelineUrlHash": "abc",
+		}, spanAttributes(stepSpan))
+
+		childSpan := collector.span("run mvn")
+		require.NotNil(t, childSpan)
+		assert.Equal(t, stepSpan.SpanId, childSpan.ParentSpanId)
+		assert.Equal(t, stepSpan.TraceId, childSpan.TraceId)
+		assert.Equal(t, tracepb.Status_STATUS_CODE_ERROR, childSpan.Status.Code)
+
+		assert.Contains(t, collector.metrics, "piper.step.duration")
+		assert.Contains(t, collector.metrics, "piper.step.executions")
+	})
+
+	t.Run("success - trace parent from environment", func(t *testing.T) {
+		collector := &collectorMock{}
+		server := httptest.NewServer(collector)
+		defer server.Close()
+		os.Setenv("TRACEPARENT", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+		defer os.Unsetenv("TRACEPARENT")
+
+		otelClient := OpenTelemetry{}
+		err := otelClient.Initialize("correlation", "mavenBuild", Config{Endpoint: strings.TrimPrefix(server.URL, "http://"), Protocol: "http", Insecure: true})
+		require.NoError(t, err)
+		err = otelClient.Send(map[string]string{"exitCode": "0"})
+		assert.NoError(t, err)
+
+		collector.mutex.Lock()
+		defer collector.mutex.Unlock()
+		stepSpan := collector.span("mavenBuild")
+		require.NotNil(t, stepSpan)
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", fmt.Sprintf("%x", stepSpan.TraceId))
+		assert.Equal(t, "00f067aa0ba902b7", fmt.Sprintf("%x", stepSpan.ParentSpanId))
+		assert.Equal(t, tracepb.Status_STATUS_CODE_UNSET, stepSpan.Status.Code)
+	})
+
+	t.Run("error - unsupported protocol", func(t *testing.T) {
+		otelClient := OpenTelemetry{}
+		err := otelClient.Initialize("correlation", "mavenBuild", Config{Endpoint: "localhost:4317", Protocol: "thrift"})
+		assert.EqualError(t, err, "failed to create OpenTelemetry trace exporter: protocol 'thrift' is not supported, use grpc or http")
+		assert.NoError(t, otelClient.Send(map[string]string{}))
+	})
+}
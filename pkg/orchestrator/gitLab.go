@@ -0,0 +1,174 @@
+package orchestrator
+
+import (
+	piperHttp "github.com/SAP/jenkins-library/pkg/http"
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/pkg/errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"time"
+)
+
+type GitLabConfigProvider struct {
+	client  piperHttp.Client
+	options piperHttp.ClientOptions
+	header  http.Header
+}
+
+// InitOrchestratorProvider initializes the http client for GitLabConfigProvider.
+// An access token provided via GITLAB_TOKEN is preferred since the job token is not allowed to read job logs,
+// the job token is used as fall-back.
+func (g *GitLabConfigProvider) InitOrchestratorProvider(settings *OrchestratorSettings) {
+	g.client = piperHttp.Client{}
+	g.options = piperHttp.ClientOptions{}
+	g.client.SetOptions(g.options)
+
+	g.header = http.Header{}
+	if token := os.Getenv("GITLAB_TOKEN"); len(token) > 0 {
+		g.header.Set("PRIVATE-TOKEN", token)
+	} else {
+		g.header.Set("JOB-TOKEN", os.Getenv("CI_JOB_TOKEN"))
+	}
+	log.Entry().Debug("Successfully initialized GitLab config provider")
+}
+
+// OrchestratorVersion returns the version of the GitLab instance
+func (g *GitLabConfigProvider) OrchestratorVersion() string {
+	return getEnv("CI_SERVER_VERSION", "n/a")
+}
+
+// OrchestratorType returns the orchestrator name e.g. Azure/GitHubActions/Jenkins
+func (g *GitLabConfigProvider) OrchestratorType() string {
+	return "GitLab"
+}
+
+func (g *GitLabConfigProvider) getAPIInformation(path string, target interface{}) error {
+	URL := getEnv("CI_API_V4_URL", "n/a") + "/projects/" + getEnv("CI_PROJECT_ID", "n/a") + path
+	response, err := g.client.GetRequest(URL, g.header, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to get http response")
+	}
+	if response.StatusCode != http.StatusOK {
+		return errors.Errorf("response code is %v, could not get API information from GitLab", response.StatusCode)
+	}
+	err = piperHttp.ParseHTTPResponseBodyJSON(response, target)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse http response")
+	}
+	return nil
+}
+
+// GetBuildStatus returns the status of the current pipeline. While the pipeline is still running
+// it is considered successful as long as none of its jobs failed.
+func (g *GitLabConfigProvider) GetBuildStatus() string {
+	var pipeline struct {
+		Status string `json:"status"`
+	}
+	if err := g.getAPIInformation("/pipelines/"+g.GetBuildId(), &pipeline); err != nil {
+		log.Entry().WithError(err).Error("failed to get pipeline status, returning with FAILURE")
+		return "FAILURE"
+	}
+
+	// cases in GitLab: created, waiting_for_resource, preparing, pending, running, success, failed, canceled, skipped, manual, scheduled
+	switch pipeline.Status {
+	case "success":
+		return "SUCCESS"
+	case "canceled", "skipped":
+		return "ABORTED"
+	case "failed":
+		return "FAILURE"
+	default:
+		var failedJobs []interface{}
+		if err := g.getAPIInformation("/pipelines/"+g.GetBuildId()+"/jobs?scope[]=failed", &failedJobs); err != nil {
+			log.Entry().WithError(err).Error("failed to get failed jobs, returning with FAILURE")
+			return "FAILURE"
+		}
+		if len(failedJobs) > 0 {
+			return "FAILURE"
+		}
+		return "SUCCESS"
+	}
+}
+
+// GetLog returns the log of the current job as provided by the jobs trace API
+func (g *GitLabConfigProvider) GetLog() ([]byte, error) {
+	URL := getEnv("CI_API_V4_URL", "n/a") + "/projects/" + getEnv("CI_PROJECT_ID", "n/a") + "/jobs/" + getEnv("CI_JOB_ID", "n/a") + "/trace"
+
+	response, err := g.client.GetRequest(URL, g.header, nil)
+	if err != nil {
+		return []byte{}, errors.Wrap(err, "could not read GitLab job log")
+	}
+	if response.StatusCode != http.StatusOK {
+		log.Entry().Errorf("Response-Code is %v . \n Could not get log information from GitLab. Returning with empty log.", response.StatusCode)
+		return []byte{}, nil
+	}
+	defer response.Body.Close()
+
+	logFile, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return []byte{}, errors.Wrap(err, "could not read GitLab job log from request")
+	}
+	return logFile, nil
+}
+
+// GetPipelineStartTime returns the pipeline start time
+func (g *GitLabConfigProvider) GetPipelineStartTime() time.Time {
+	// "2021-10-11T13:49:09Z"
+	parsed, err := time.Parse(time.RFC3339, getEnv("CI_PIPELINE_CREATED_AT", "n/a"))
+	if err != nil {
+		log.Entry().Errorf("Could not parse timestamp. %v", err)
+		// Return 1970 in case parsing goes wrong
+		parsed = time.Date(1970, time.January, 01, 0, 0, 0, 0, time.UTC)
+	}
+	return parsed
+}
+
+func (g *GitLabConfigProvider) GetBuildId() string {
+	return getEnv("CI_PIPELINE_ID", "n/a")
+}
+
+func (g *GitLabConfigProvider) GetJobName() string {
+	return getEnv("CI_JOB_NAME", "n/a")
+}
+
+func (g *GitLabConfigProvider) GetStageName() string {
+	return getEnv("CI_JOB_STAGE", "n/a")
+}
+
+func (g *GitLabConfigProvider) GetBranch() string {
+	return getEnv("CI_COMMIT_REF_NAME", "n/a")
+}
+
+func (g *GitLabConfigProvider) GetBuildUrl() string {
+	return getEnv("CI_PIPELINE_URL", "n/a")
+}
+
+func (g *GitLabConfigProvider) GetJobUrl() string {
+	return getEnv("CI_JOB_URL", "n/a")
+}
+
+func (g *GitLabConfigProvider) GetCommit() string {
+	return getEnv("CI_COMMIT_SHA", "n/a")
+}
+
+func (g *GitLabConfigProvider) GetRepoUrl() string {
+	return getEnv("CI_PROJECT_URL", "n/a")
+}
+
+func (g *GitLabConfigProvider) GetPullRequestConfig() PullRequestConfig {
+	return PullRequestConfig{
+		Branch: getEnv("CI_MERGE_REQUEST_SOURCE_BRANCH_NAME", "n/a"),
+		Base:   getEnv("CI_MERGE_REQUEST_TARGET_BRANCH_NAME", "n/a"),
+		Key:    getEnv("CI_MERGE_REQUEST_IID", "n/a"),
+	}
+}
+
+func (g *GitLabConfigProvider) IsPullRequest() bool {
+	return truthy("CI_MERGE_REQUEST_IID")
+}
+
+func isGitLab() bool {
+	envVars := []string{"GITLAB_CI"}
+	return areIndicatingEnvVarsSet(envVars)
+}
@@ -0,0 +1,117 @@
+package orchestrator
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGitLab(t *testing.T) {
+	t.Run("BranchBuild", func(t *testing.T) {
+		defer resetEnv(os.Environ())
+		os.Clearenv()
+		os.Setenv("GITLAB_CI", "true")
+		os.Setenv("CI_COMMIT_REF_NAME", "feat/test-gitlab")
+		os.Setenv("CI_PIPELINE_URL", "https://gitlab.com/foo/bar/-/pipelines/42")
+		os.Setenv("CI_COMMIT_SHA", "abcdef42713")
+		os.Setenv("CI_PROJECT_URL", "https://gitlab.com/foo/bar")
+		os.Setenv("CI_JOB_STAGE", "build")
+		os.Setenv("CI_PIPELINE_CREATED_AT", "2021-10-11T13:49:09Z")
+
+		p, _ := NewOrchestratorSpecificConfigProvider()
+
+		assert.False(t, p.IsPullRequest())
+		assert.Equal(t, "feat/test-gitlab", p.GetBranch())
+		assert.Equal(t, "https://gitlab.com/foo/bar/-/pipelines/42", p.GetBuildUrl())
+		assert.Equal(t, "abcdef42713", p.GetCommit())
+		assert.Equal(t, "https://gitlab.com/foo/bar", p.GetRepoUrl())
+		assert.Equal(t, "build", p.GetStageName())
+		assert.Equal(t, "GitLab", p.OrchestratorType())
+		assert.Equal(t, time.Date(2021, time.October, 11, 13, 49, 9, 0, time.UTC), p.GetPipelineStartTime())
+	})
+
+	t.Run("PR", func(t *testing.T) {
+		defer resetEnv(os.Environ())
+		os.Clearenv()
+		os.Setenv("CI_MERGE_REQUEST_SOURCE_BRANCH_NAME", "feat/test-gitlab")
+		os.Setenv("CI_MERGE_REQUEST_TARGET_BRANCH_NAME", "main")
+		os.Setenv("CI_MERGE_REQUEST_IID", "42")
+
+		p := GitLabConfigProvider{}
+		c := p.GetPullRequestConfig()
+
+		assert.True(t, p.IsPullRequest())
+		assert.Equal(t, "feat/test-gitlab", c.Branch)
+		assert.Equal(t, "main", c.Base)
+		assert.Equal(t, "42", c.Key)
+	})
+
+	t.Run("API", func(t *testing.T) {
+		var pipelineStatus, failedJobs, token string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token = r.Header.Get("PRIVATE-TOKEN")
+			switch r.URL.Path {
+			case "/api/v4/projects/4711/jobs/815/trace":
+				w.Write([]byte("job log"))
+			case "/api/v4/projects/4711/pipelines/42":
+				w.Write([]byte(`{"id": 42, "status": "` + pipelineStatus + `"}`))
+			case "/api/v4/projects/4711/pipelines/42/jobs":
+				assert.Equal(t, "failed", r.URL.Query().Get("scope[]"))
+				w.Write([]byte(failedJobs))
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		defer server.Close()
+
+		defer resetEnv(os.Environ())
+		os.Clearenv()
+		os.Setenv("CI_API_V4_URL", server.URL+"/api/v4")
+		os.Setenv("CI_PROJECT_ID", "4711")
+		os.Setenv("CI_PIPELINE_ID", "42")
+		os.Setenv("CI_JOB_ID", "815")
+		os.Setenv("GITLAB_TOKEN", "secret")
+
+		p := GitLabConfigProvider{}
+		p.InitOrchestratorProvider(&OrchestratorSettings{})
+
+		logs, err := p.GetLog()
+		assert.NoError(t, err)
+		assert.Equal(t, "job log", string(logs))
+		assert.Equal(t, "secret", token)
+
+		pipelineStatus = "success"
+		assert.Equal(t, "SUCCESS", p.GetBuildStatus())
+		pipelineStatus = "canceled"
+		assert.Equal(t, "ABORTED", p.GetBuildStatus())
+		pipelineStatus = "failed"
+		assert.Equal(t, "FAILURE", p.GetBuildStatus())
+		pipelineStatus = "running"
+		failedJobs = `[]`
+		assert.Equal(t, "SUCCESS", p.GetBuildStatus())
+		failedJobs = `[{"id": 816, "status": "failed"}]`
+		assert.Equal(t, "FAILURE", p.GetBuildStatus())
+	})
+
+	t.Run("API - error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		defer resetEnv(os.Environ())
+		os.Clearenv()
+		os.Setenv("CI_API_V4_URL", server.URL+"/api/v4")
+
+		p := GitLabConfigProvider{}
+		p.InitOrchestratorProvider(&OrchestratorSettings{})
+
+		_, err := p.GetLog()
+		assert.Error(t, err)
+		assert.Equal(t, "FAILURE", p.GetBuildStatus())
+	})
+}
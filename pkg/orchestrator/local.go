@@ -0,0 +1,142 @@
+package orchestrator
+
+import (
+	piperGit "github.com/SAP/jenkins-library/pkg/git"
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/go-git/go-git/v5"
+	"os"
+	"time"
+)
+
+// LocalConfigProvider is used when piper is explicitly run outside of a CI system (e.g. on a developer laptop).
+// Branch, commit and repository URL are taken from the git checkout in the current working directory,
+// stage name and pull request information can be simulated via environment variables:
+//   PIPER_LOCAL                      activates the local orchestrator
+//   PIPER_LOCAL_STAGE_NAME           name of the stage to simulate
+//   PIPER_LOCAL_PULL_REQUEST         key of the pull request to simulate, no pull request if empty
+//   PIPER_LOCAL_PULL_REQUEST_BASE    target branch of the simulated pull request
+type LocalConfigProvider struct {
+	repository *git.Repository
+	startTime  time.Time
+}
+
+func (l *LocalConfigProvider) InitOrchestratorProvider(settings *OrchestratorSettings) {
+	log.Entry().Debug("Successfully initialized local config provider")
+}
+
+func (l *LocalConfigProvider) getRepository() *git.Repository {
+	if l.repository == nil {
+		repository, err := piperGit.PlainOpen(".")
+		if err != nil {
+			log.Entry().WithError(err).Warning("Cannot read git information of local checkout")
+			return nil
+		}
+		l.repository = repository
+	}
+	return l.repository
+}
+
+func (l *LocalConfigProvider) OrchestratorVersion() string {
+	return "n/a"
+}
+
+func (l *LocalConfigProvider) OrchestratorType() string {
+	return "Local"
+}
+
+func (l *LocalConfigProvider) GetBuildStatus() string {
+	return "SUCCESS"
+}
+
+func (l *LocalConfigProvider) GetLog() ([]byte, error) {
+	log.Entry().Debug("GetLog() is not available for local runs.")
+	return []byte{}, nil
+}
+
+// GetPipelineStartTime returns the time the provider has been queried for the first time
+func (l *LocalConfigProvider) GetPipelineStartTime() time.Time {
+	if l.startTime.IsZero() {
+		l.startTime = time.Now().UTC()
+	}
+	return l.startTime
+}
+
+func (l *LocalConfigProvider) GetBuildId() string {
+	return "n/a"
+}
+
+func (l *LocalConfigProvider) GetJobName() string {
+	return "n/a"
+}
+
+// GetStageName returns the simulated stage name, it is empty unless provided
+func (l *LocalConfigProvider) GetStageName() string {
+	return os.Getenv("PIPER_LOCAL_STAGE_NAME")
+}
+
+// GetBranch returns the branch currently checked out, "n/a" in case of a detached HEAD
+func (l *LocalConfigProvider) GetBranch() string {
+	repository := l.getRepository()
+	if repository == nil {
+		return "n/a"
+	}
+	head, err := repository.Head()
+	if err != nil || !head.Name().IsBranch() {
+		log.Entry().Warning("Cannot read current branch of local checkout")
+		return "n/a"
+	}
+	return head.Name().Short()
+}
+
+func (l *LocalConfigProvider) GetBuildUrl() string {
+	return "n/a"
+}
+
+func (l *LocalConfigProvider) GetJobUrl() string {
+	return "n/a"
+}
+
+// GetCommit returns the commit id of HEAD
+func (l *LocalConfigProvider) GetCommit() string {
+	repository := l.getRepository()
+	if repository == nil {
+		return "n/a"
+	}
+	head, err := repository.Head()
+	if err != nil {
+		log.Entry().WithError(err).Warning("Cannot read HEAD of local checkout")
+		return "n/a"
+	}
+	return head.Hash().String()
+}
+
+// GetRepoUrl returns the first URL of the remote "origin"
+func (l *LocalConfigProvider) GetRepoUrl() string {
+	repository := l.getRepository()
+	if repository == nil {
+		return "n/a"
+	}
+	remote, err := repository.Remote(git.DefaultRemoteName)
+	if err != nil || len(remote.Config().URLs) == 0 {
+		log.Entry().Warningf("Cannot read URL of remote '%v' of local checkout", git.DefaultRemoteName)
+		return "n/a"
+	}
+	return remote.Config().URLs[0]
+}
+
+func (l *LocalConfigProvider) GetPullRequestConfig() PullRequestConfig {
+	return PullRequestConfig{
+		Branch: l.GetBranch(),
+		Base:   getEnv("PIPER_LOCAL_PULL_REQUEST_BASE", "n/a"),
+		Key:    getEnv("PIPER_LOCAL_PULL_REQUEST", "n/a"),
+	}
+}
+
+func (l *LocalConfigProvider) IsPullRequest() bool {
+	return truthy("PIPER_LOCAL_PULL_REQUEST")
+}
+
+func isLocal() bool {
+	envVars := []string{"PIPER_LOCAL"}
+	return areIndicatingEnvVarsSet(envVars)
+}
@@ -0,0 +1,78 @@
+package orchestrator
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func initLocalRepository(t *testing.T) (*git.Repository, string) {
+	repository, err := git.Init(memory.NewStorage(), memfs.New())
+	require.NoError(t, err)
+	worktree, err := repository.Worktree()
+	require.NoError(t, err)
+	commit, err := worktree.Commit("initial commit", &git.CommitOptions{
+		Author: &object.Signature{Name: "piper", When: time.Now()},
+	})
+	require.NoError(t, err)
+	_, err = repository.CreateRemote(&config.RemoteConfig{Name: "origin", URLs: []string{"https://github.com/foo/bar.git"}})
+	require.NoError(t, err)
+	return repository, commit.String()
+}
+
+func TestLocal(t *testing.T) {
+	t.Run("BranchBuild", func(t *testing.T) {
+		defer resetEnv(os.Environ())
+		os.Clearenv()
+		os.Setenv("PIPER_LOCAL", "true")
+		os.Setenv("PIPER_LOCAL_STAGE_NAME", "Build")
+		// local mode takes precedence over CI detection
+		os.Setenv("JENKINS_HOME", "anything")
+
+		p, err := NewOrchestratorSpecificConfigProvider()
+		require.NoError(t, err)
+		repository, commit := initLocalRepository(t)
+		p.(*LocalConfigProvider).repository = repository
+
+		assert.False(t, p.IsPullRequest())
+		assert.Equal(t, "master", p.GetBranch())
+		assert.Equal(t, commit, p.GetCommit())
+		assert.Equal(t, "https://github.com/foo/bar.git", p.GetRepoUrl())
+		assert.Equal(t, "Build", p.GetStageName())
+		assert.Equal(t, "Local", p.OrchestratorType())
+		assert.Equal(t, "SUCCESS", p.GetBuildStatus())
+	})
+
+	t.Run("PR", func(t *testing.T) {
+		defer resetEnv(os.Environ())
+		os.Clearenv()
+		os.Setenv("PIPER_LOCAL_PULL_REQUEST", "42")
+		os.Setenv("PIPER_LOCAL_PULL_REQUEST_BASE", "main")
+
+		repository, _ := initLocalRepository(t)
+		p := LocalConfigProvider{repository: repository}
+		c := p.GetPullRequestConfig()
+
+		assert.True(t, p.IsPullRequest())
+		assert.Equal(t, "master", c.Branch)
+		assert.Equal(t, "main", c.Base)
+		assert.Equal(t, "42", c.Key)
+	})
+
+	t.Run("no stage name", func(t *testing.T) {
+		defer resetEnv(os.Environ())
+		os.Clearenv()
+
+		p := LocalConfigProvider{}
+
+		assert.Equal(t, "", p.GetStageName())
+	})
+}
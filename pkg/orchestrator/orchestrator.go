@@ -14,6 +14,9 @@ const (
 	AzureDevOps
 	GitHubActions
 	Jenkins
+	GitLab
+	Tekton
+	Local
 )
 
 type OrchestratorSpecificConfigProviding interface {
@@ -55,25 +58,39 @@ func NewOrchestratorSpecificConfigProvider() (OrchestratorSpecificConfigProvidin
 		return &GitHubActionsConfigProvider{}, nil
 	case Jenkins:
 		return &JenkinsConfigProvider{}, nil
+	case GitLab:
+		return &GitLabConfigProvider{}, nil
+	case Tekton:
+		return &TektonConfigProvider{}, nil
+	case Local:
+		return &LocalConfigProvider{}, nil
 	default:
-		return &UnknownOrchestratorConfigProvider{}, errors.New("unable to detect a supported orchestrator (Azure DevOps, GitHub Actions, Jenkins)")
+		return &UnknownOrchestratorConfigProvider{}, errors.New("unable to detect a supported orchestrator (Azure DevOps, GitHub Actions, Jenkins, GitLab, Tekton)")
 	}
 }
 
+// DetectOrchestrator returns the orchestrator piper is running on.
+// An explicitly activated local mode takes precedence over the detection of a CI system.
 func DetectOrchestrator() Orchestrator {
-	if isAzure() {
+	if isLocal() {
+		return Orchestrator(Local)
+	} else if isAzure() {
 		return Orchestrator(AzureDevOps)
 	} else if isGitHubActions() {
 		return Orchestrator(GitHubActions)
 	} else if isJenkins() {
 		return Orchestrator(Jenkins)
+	} else if isGitLab() {
+		return Orchestrator(GitLab)
+	} else if isTekton() {
+		return Orchestrator(Tekton)
 	} else {
 		return Orchestrator(Unknown)
 	}
 }
 
 func (o Orchestrator) String() string {
-	return [...]string{"Unknown", "AzureDevOps", "GitHubActions", "Jenkins"}[o]
+	return [...]string{"Unknown", "AzureDevOps", "GitHubActions", "Jenkins", "GitLab", "Tekton", "Local"}[o]
 }
 
 func areIndicatingEnvVarsSet(envVars []string) bool {
@@ -14,7 +14,7 @@ func TestOrchestrator(t *testing.T) {
 
 		provider, err := NewOrchestratorSpecificConfigProvider()
 
-		assert.EqualError(t, err, "unable to detect a supported orchestrator (Azure DevOps, GitHub Actions, Jenkins)")
+		assert.EqualError(t, err, "unable to detect a supported orchestrator (Azure DevOps, GitHub Actions, Jenkins, GitLab, Tekton)")
 		assert.Equal(t, "Unknown", provider.OrchestratorType())
 	})
 
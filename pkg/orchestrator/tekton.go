@@ -0,0 +1,272 @@
+package orchestrator
+
+import (
+	"fmt"
+	piperHttp "github.com/SAP/jenkins-library/pkg/http"
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/pkg/errors"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// Tekton does not expose any information about the current run via environment variables by default.
+// The Task running piper is expected to map the following values into the step environment:
+//   TEKTON_PIPELINE_RUN                 $(context.pipelineRun.name)
+//   TEKTON_NAMESPACE                    $(context.pipelineRun.namespace)
+//   TEKTON_PIPELINE_NAME                $(context.pipeline.name)
+//   TEKTON_TASK_NAME                    $(context.task.name)
+//   TEKTON_DASHBOARD_URL                URL of the Tekton dashboard (optional)
+//   TEKTON_GIT_URL, TEKTON_GIT_BRANCH, TEKTON_GIT_REVISION
+//   TEKTON_PULL_REQUEST_ID, TEKTON_PULL_REQUEST_SOURCE_BRANCH, TEKTON_PULL_REQUEST_TARGET_BRANCH
+// Build status, start time and log are read from the Kubernetes API using the service account of the TaskRun pod.
+
+const tektonServiceAccountTokenPath = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+
+type TektonConfigProvider struct {
+	client    piperHttp.Client
+	options   piperHttp.ClientOptions
+	apiURL    string
+	tokenFile string
+}
+
+func (t *TektonConfigProvider) tokenPath() string {
+	if len(t.tokenFile) > 0 {
+		return t.tokenFile
+	}
+	return tektonServiceAccountTokenPath
+}
+
+// InitOrchestratorProvider initializes the http client for TektonConfigProvider.
+// The service account token mounted into the pod is used to authenticate to the Kubernetes API.
+func (t *TektonConfigProvider) InitOrchestratorProvider(settings *OrchestratorSettings) {
+	content, err := ioutil.ReadFile(t.tokenPath())
+	if err != nil {
+		log.Entry().WithError(err).Warning("Could not read service account token, Kubernetes API calls will be unauthenticated")
+	}
+	token := strings.TrimSpace(string(content))
+
+	t.client = piperHttp.Client{}
+	t.options = piperHttp.ClientOptions{}
+	if len(token) > 0 {
+		t.options.Token = "Bearer " + token
+	}
+	t.client.SetOptions(t.options)
+
+	if len(t.apiURL) == 0 {
+		t.apiURL = fmt.Sprintf("https://%v:%v", getEnv("KUBERNETES_SERVICE_HOST", "kubernetes.default.svc"), getEnv("KUBERNETES_SERVICE_PORT", "443"))
+	}
+	log.Entry().Debug("Successfully initialized Tekton config provider")
+}
+
+func (t *TektonConfigProvider) OrchestratorVersion() string {
+	return getEnv("TEKTON_VERSION", "n/a")
+}
+
+func (t *TektonConfigProvider) OrchestratorType() string {
+	return "Tekton"
+}
+
+type tektonCondition struct {
+	Type   string `json:"type"`
+	Status string `json:"status"`
+	Reason string `json:"reason"`
+}
+
+type tektonPipelineRun struct {
+	Status struct {
+		StartTime  string            `json:"startTime"`
+		Conditions []tektonCondition `json:"conditions"`
+		TaskRuns   map[string]struct {
+			Status struct {
+				Conditions []tektonCondition `json:"conditions"`
+			} `json:"status"`
+		} `json:"taskRuns"`
+	} `json:"status"`
+}
+
+func (t *TektonConfigProvider) getAPIInformation(path string, target interface{}) error {
+	response, err := t.client.GetRequest(t.apiURL+path, nil, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to get http response")
+	}
+	if response.StatusCode != http.StatusOK {
+		return errors.Errorf("response code is %v, could not get API information from Kubernetes", response.StatusCode)
+	}
+	err = piperHttp.ParseHTTPResponseBodyJSON(response, target)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse http response")
+	}
+	return nil
+}
+
+func (t *TektonConfigProvider) getPipelineRun() (tektonPipelineRun, error) {
+	var pipelineRun tektonPipelineRun
+	path := fmt.Sprintf("/apis/tekton.dev/v1beta1/namespaces/%v/pipelineruns/%v", t.getNamespace(), t.GetBuildId())
+	err := t.getAPIInformation(path, &pipelineRun)
+	return pipelineRun, err
+}
+
+func succeededCondition(conditions []tektonCondition) (tektonCondition, bool) {
+	for _, condition := range conditions {
+		if condition.Type == "Succeeded" {
+			return condition, true
+		}
+	}
+	return tektonCondition{}, false
+}
+
+// GetBuildStatus returns the status of the current PipelineRun. While the PipelineRun is still running
+// it is considered successful as long as none of its TaskRuns failed.
+func (t *TektonConfigProvider) GetBuildStatus() string {
+	pipelineRun, err := t.getPipelineRun()
+	if err != nil {
+		log.Entry().WithError(err).Error("failed to get PipelineRun, returning with FAILURE")
+		return "FAILURE"
+	}
+
+	condition, ok := succeededCondition(pipelineRun.Status.Conditions)
+	if !ok {
+		return "SUCCESS"
+	}
+	switch condition.Status {
+	case "True":
+		return "SUCCESS"
+	case "False":
+		if strings.Contains(condition.Reason, "Cancelled") {
+			return "ABORTED"
+		}
+		return "FAILURE"
+	default:
+		for _, taskRun := range pipelineRun.Status.TaskRuns {
+			if taskCondition, ok := succeededCondition(taskRun.Status.Conditions); ok && taskCondition.Status == "False" {
+				return "FAILURE"
+			}
+		}
+		return "SUCCESS"
+	}
+}
+
+// GetLog returns the logs of all step containers of the TaskRun pod piper is running in,
+// containers whose log cannot be retrieved are skipped
+func (t *TektonConfigProvider) GetLog() ([]byte, error) {
+	pod := getEnv("HOSTNAME", "n/a")
+	var podInfo struct {
+		Spec struct {
+			Containers []struct {
+				Name string `json:"name"`
+			} `json:"containers"`
+		} `json:"spec"`
+	}
+	podPath := fmt.Sprintf("/api/v1/namespaces/%v/pods/%v", t.getNamespace(), pod)
+	if err := t.getAPIInformation(podPath, &podInfo); err != nil {
+		log.Entry().WithError(err).Error("Could not get pod information from Kubernetes. Returning with empty log.")
+		return []byte{}, nil
+	}
+
+	logs := []byte{}
+	for _, container := range podInfo.Spec.Containers {
+		logURL := t.apiURL + podPath + "/log?container=" + url.QueryEscape(container.Name)
+		log.Entry().Debugf("Getting log of container %v from %v", container.Name, logURL)
+		response, err := t.client.GetRequest(logURL, nil, nil)
+		if err != nil {
+			// e.g. containers which did not start yet respond with 400
+			log.Entry().WithError(err).Errorf("Could not get log of container %v from Kubernetes. Skipping it.", container.Name)
+			if response != nil && response.Body != nil {
+				response.Body.Close()
+			}
+			continue
+		}
+		if response.StatusCode != http.StatusOK {
+			log.Entry().Errorf("Response-Code is %v, could not get log of container %v from Kubernetes. Skipping it.", response.StatusCode, container.Name)
+			response.Body.Close()
+			continue
+		}
+		content, err := ioutil.ReadAll(response.Body)
+		response.Body.Close()
+		if err != nil {
+			return logs, errors.Wrapf(err, "could not read log of container %v from request", container.Name)
+		}
+		logs = append(logs, content...)
+	}
+	return logs, nil
+}
+
+// GetPipelineStartTime returns the start time of the PipelineRun
+func (t *TektonConfigProvider) GetPipelineStartTime() time.Time {
+	pipelineRun, err := t.getPipelineRun()
+	if err != nil {
+		log.Entry().WithError(err).Error("Could not get PipelineRun. Setting timestamp to 1970.")
+		return time.Date(1970, time.January, 01, 0, 0, 0, 0, time.UTC)
+	}
+	parsed, err := time.Parse(time.RFC3339, pipelineRun.Status.StartTime)
+	if err != nil {
+		log.Entry().Errorf("Could not parse timestamp. %v", err)
+		parsed = time.Date(1970, time.January, 01, 0, 0, 0, 0, time.UTC)
+	}
+	return parsed
+}
+
+func (t *TektonConfigProvider) getNamespace() string {
+	if namespace, ok := os.LookupEnv("TEKTON_NAMESPACE"); ok {
+		return namespace
+	}
+	content, err := ioutil.ReadFile("/var/run/secrets/kubernetes.io/serviceaccount/namespace")
+	if err != nil {
+		log.Entry().Warn("Could not read namespace, using fallback value default")
+		return "default"
+	}
+	return strings.TrimSpace(string(content))
+}
+
+func (t *TektonConfigProvider) GetBuildId() string {
+	return getEnv("TEKTON_PIPELINE_RUN", "n/a")
+}
+
+func (t *TektonConfigProvider) GetJobName() string {
+	return getEnv("TEKTON_PIPELINE_NAME", "n/a")
+}
+
+func (t *TektonConfigProvider) GetStageName() string {
+	return getEnv("TEKTON_TASK_NAME", "n/a")
+}
+
+func (t *TektonConfigProvider) GetBranch() string {
+	return strings.TrimPrefix(getEnv("TEKTON_GIT_BRANCH", "n/a"), "refs/heads/")
+}
+
+func (t *TektonConfigProvider) GetBuildUrl() string {
+	return getEnv("TEKTON_DASHBOARD_URL", "n/a") + "/#/namespaces/" + t.getNamespace() + "/pipelineruns/" + t.GetBuildId()
+}
+
+func (t *TektonConfigProvider) GetJobUrl() string {
+	return getEnv("TEKTON_DASHBOARD_URL", "n/a") + "/#/namespaces/" + t.getNamespace() + "/pipelines/" + t.GetJobName()
+}
+
+func (t *TektonConfigProvider) GetCommit() string {
+	return getEnv("TEKTON_GIT_REVISION", "n/a")
+}
+
+func (t *TektonConfigProvider) GetRepoUrl() string {
+	return getEnv("TEKTON_GIT_URL", "n/a")
+}
+
+func (t *TektonConfigProvider) GetPullRequestConfig() PullRequestConfig {
+	return PullRequestConfig{
+		Branch: getEnv("TEKTON_PULL_REQUEST_SOURCE_BRANCH", "n/a"),
+		Base:   getEnv("TEKTON_PULL_REQUEST_TARGET_BRANCH", "n/a"),
+		Key:    getEnv("TEKTON_PULL_REQUEST_ID", "n/a"),
+	}
+}
+
+func (t *TektonConfigProvider) IsPullRequest() bool {
+	return truthy("TEKTON_PULL_REQUEST_ID")
+}
+
+func isTekton() bool {
+	envVars := []string{"TEKTON_PIPELINE_RUN"}
+	return areIndicatingEnvVarsSet(envVars)
+}
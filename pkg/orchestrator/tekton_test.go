@@ -0,0 +1,138 @@
+package orchestrator
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	piperHttp "github.com/SAP/jenkins-library/pkg/http"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTekton(t *testing.T) {
+	t.Run("BranchBuild", func(t *testing.T) {
+		defer resetEnv(os.Environ())
+		os.Clearenv()
+		os.Setenv("TEKTON_PIPELINE_RUN", "build-run-42")
+		os.Setenv("TEKTON_NAMESPACE", "ci")
+		os.Setenv("TEKTON_PIPELINE_NAME", "build")
+		os.Setenv("TEKTON_TASK_NAME", "unit-tests")
+		os.Setenv("TEKTON_DASHBOARD_URL", "https://tekton.foo")
+		os.Setenv("TEKTON_GIT_BRANCH", "refs/heads/feat/test-tekton")
+		os.Setenv("TEKTON_GIT_REVISION", "abcdef42713")
+		os.Setenv("TEKTON_GIT_URL", "github.com/foo/bar")
+
+		p, _ := NewOrchestratorSpecificConfigProvider()
+
+		assert.False(t, p.IsPullRequest())
+		assert.Equal(t, "feat/test-tekton", p.GetBranch())
+		assert.Equal(t, "https://tekton.foo/#/namespaces/ci/pipelineruns/build-run-42", p.GetBuildUrl())
+		assert.Equal(t, "https://tekton.foo/#/namespaces/ci/pipelines/build", p.GetJobUrl())
+		assert.Equal(t, "abcdef42713", p.GetCommit())
+		assert.Equal(t, "github.com/foo/bar", p.GetRepoUrl())
+		assert.Equal(t, "unit-tests", p.GetStageName())
+		assert.Equal(t, "Tekton", p.OrchestratorType())
+	})
+
+	t.Run("PR", func(t *testing.T) {
+		defer resetEnv(os.Environ())
+		os.Clearenv()
+		os.Setenv("TEKTON_PULL_REQUEST_SOURCE_BRANCH", "feat/test-tekton")
+		os.Setenv("TEKTON_PULL_REQUEST_TARGET_BRANCH", "main")
+		os.Setenv("TEKTON_PULL_REQUEST_ID", "42")
+
+		p := TektonConfigProvider{}
+		c := p.GetPullRequestConfig()
+
+		assert.True(t, p.IsPullRequest())
+		assert.Equal(t, "feat/test-tekton", c.Branch)
+		assert.Equal(t, "main", c.Base)
+		assert.Equal(t, "42", c.Key)
+	})
+
+	t.Run("API", func(t *testing.T) {
+		var pipelineRunStatus, token string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token = r.Header.Get("Authorization")
+			switch r.URL.Path {
+			case "/apis/tekton.dev/v1beta1/namespaces/ci/pipelineruns/build-run-42":
+				w.Write([]byte(pipelineRunStatus))
+			case "/api/v1/namespaces/ci/pods/build-run-42-unit-tests-pod":
+				w.Write([]byte(`{"spec": {"containers": [{"name": "step-checkout"}, {"name": "step-publish"}, {"name": "step-piper"}]}}`))
+			case "/api/v1/namespaces/ci/pods/build-run-42-unit-tests-pod/log":
+				if r.URL.Query().Get("container") == "step-publish" {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				w.Write([]byte(r.URL.Query().Get("container") + " log\n"))
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		defer server.Close()
+
+		defer resetEnv(os.Environ())
+		os.Clearenv()
+		os.Setenv("TEKTON_PIPELINE_RUN", "build-run-42")
+		os.Setenv("TEKTON_NAMESPACE", "ci")
+		os.Setenv("HOSTNAME", "build-run-42-unit-tests-pod")
+
+		tokenFile := filepath.Join(t.TempDir(), "token")
+		ioutil.WriteFile(tokenFile, []byte("secret\n"), 0600)
+
+		p := TektonConfigProvider{apiURL: server.URL, tokenFile: tokenFile}
+		p.InitOrchestratorProvider(&OrchestratorSettings{})
+
+		logs, err := p.GetLog()
+		assert.NoError(t, err)
+		assert.Equal(t, "step-checkout log\nstep-piper log\n", string(logs))
+		assert.Equal(t, "Bearer secret", token)
+
+		pipelineRunStatus = `{"status": {"startTime": "2021-10-11T13:49:09Z", "conditions": [{"type": "Succeeded", "status": "True", "reason": "Succeeded"}]}}`
+		assert.Equal(t, "SUCCESS", p.GetBuildStatus())
+		assert.Equal(t, time.Date(2021, time.October, 11, 13, 49, 9, 0, time.UTC), p.GetPipelineStartTime())
+		pipelineRunStatus = `{"status": {"conditions": [{"type": "Succeeded", "status": "False", "reason": "Failed"}]}}`
+		assert.Equal(t, "FAILURE", p.GetBuildStatus())
+		pipelineRunStatus = `{"status": {"conditions": [{"type": "Succeeded", "status": "False", "reason": "PipelineRunCancelled"}]}}`
+		assert.Equal(t, "ABORTED", p.GetBuildStatus())
+		pipelineRunStatus = `{"status": {"conditions": [{"type": "Succeeded", "status": "Unknown", "reason": "Running"}], "taskRuns": {"build-run-42-build": {"status": {"conditions": [{"type": "Succeeded", "status": "True"}]}}}}}`
+		assert.Equal(t, "SUCCESS", p.GetBuildStatus())
+		pipelineRunStatus = `{"status": {"conditions": [{"type": "Succeeded", "status": "Unknown", "reason": "Running"}], "taskRuns": {"build-run-42-build": {"status": {"conditions": [{"type": "Succeeded", "status": "False"}]}}}}}`
+		assert.Equal(t, "FAILURE", p.GetBuildStatus())
+	})
+	t.Run("log request fails", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/api/v1/namespaces/ci/pods/build-run-42-unit-tests-pod":
+				w.Write([]byte(`{"spec": {"containers": [{"name": "step-checkout"}, {"name": "step-piper"}]}}`))
+			case "/api/v1/namespaces/ci/pods/build-run-42-unit-tests-pod/log":
+				if r.URL.Query().Get("container") == "step-checkout" {
+					// close the connection without response
+					conn, _, _ := w.(http.Hijacker).Hijack()
+					conn.Close()
+					return
+				}
+				w.Write([]byte(r.URL.Query().Get("container") + " log\n"))
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		defer server.Close()
+
+		defer resetEnv(os.Environ())
+		os.Clearenv()
+		os.Setenv("TEKTON_NAMESPACE", "ci")
+		os.Setenv("HOSTNAME", "build-run-42-unit-tests-pod")
+
+		p := TektonConfigProvider{apiURL: server.URL}
+		p.client.SetOptions(piperHttp.ClientOptions{MaxRetries: -1})
+
+		logs, err := p.GetLog()
+		assert.NoError(t, err)
+		assert.Equal(t, "step-piper log\n", string(logs))
+	})
+}
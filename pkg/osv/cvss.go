@@ -0,0 +1,111 @@
+package osv
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/SAP/jenkins-library/pkg/reporting"
+)
+
+var cvss3Weights = map[string]map[string]float64{
+	"AV": {"N": 0.85, "A": 0.62, "L": 0.55, "P": 0.2},
+	"AC": {"L": 0.77, "H": 0.44},
+	"UI": {"N": 0.85, "R": 0.62},
+	"C":  {"H": 0.56, "L": 0.22, "N": 0},
+	"I":  {"H": 0.56, "L": 0.22, "N": 0},
+	"A":  {"H": 0.56, "L": 0.22, "N": 0},
+}
+
+// CVSS3BaseScore calculates the base score of a CVSS v3.x vector like CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H
+// according to https://www.first.org/cvss/v3.1/specification-document#7-1-Base-Metrics-Equations
+func CVSS3BaseScore(vector string) (float64, error) {
+	if !strings.HasPrefix(vector, "CVSS:3.") {
+		return 0, fmt.Errorf("'%v' is no CVSS v3 vector", vector)
+	}
+	metrics := map[string]string{}
+	for _, metric := range strings.Split(vector, "/")[1:] {
+		keyValue := strings.SplitN(metric, ":", 2)
+		if len(keyValue) == 2 {
+			metrics[keyValue[0]] = keyValue[1]
+		}
+	}
+
+	scopeChanged := metrics["S"] == "C"
+	if !scopeChanged && metrics["S"] != "U" {
+		return 0, fmt.Errorf("invalid scope in CVSS vector '%v'", vector)
+	}
+	weights := map[string]float64{}
+	for metric, values := range cvss3Weights {
+		weight, ok := values[metrics[metric]]
+		if !ok {
+			return 0, fmt.Errorf("invalid metric %v in CVSS vector '%v'", metric, vector)
+		}
+		weights[metric] = weight
+	}
+	switch {
+	case metrics["PR"] == "N":
+		weights["PR"] = 0.85
+	case metrics["PR"] == "L" && scopeChanged:
+		weights["PR"] = 0.68
+	case metrics["PR"] == "L":
+		weights["PR"] = 0.62
+	case metrics["PR"] == "H" && scopeChanged:
+		weights["PR"] = 0.5
+	case metrics["PR"] == "H":
+		weights["PR"] = 0.27
+	default:
+		return 0, fmt.Errorf("invalid metric PR in CVSS vector '%v'", vector)
+	}
+
+	iss := 1 - (1-weights["C"])*(1-weights["I"])*(1-weights["A"])
+	impact := 6.42 * iss
+	if scopeChanged {
+		impact = 7.52*(iss-0.029) - 3.25*math.Pow(iss-0.02, 15)
+	}
+	exploitability := 8.22 * weights["AV"] * weights["AC"] * weights["PR"] * weights["UI"]
+
+	if impact <= 0 {
+		return 0, nil
+	}
+	if scopeChanged {
+		return roundUp(math.Min(1.08*(impact+exploitability), 10)), nil
+	}
+	return roundUp(math.Min(impact+exploitability, 10)), nil
+}
+
+// roundUp returns the smallest number with one decimal place which is equal to or higher than the input
+func roundUp(value float64) float64 {
+	intInput := math.Round(value * 100000)
+	if math.Mod(intInput, 10000) == 0 {
+		return intInput / 100000
+	}
+	return (math.Floor(intInput/10000) + 1) / 10
+}
+
+// severityScores are the lower bounds of the CVSS v3 qualitative severity ratings
+var severityScores = map[reporting.Severity]float64{
+	reporting.SeverityCritical: 9.0,
+	reporting.SeverityHigh:     7.0,
+	reporting.SeverityMedium:   4.0,
+	reporting.SeverityLow:      0.1,
+}
+
+// Score returns the CVSS v3 base score of the vulnerability.
+// If the vulnerability does not provide a CVSS v3 vector, the lower bound of the severity rating
+// provided by the database (e.g. database_specific.severity of GitHub advisories) is returned.
+func (v *Vulnerability) Score() float64 {
+	for _, severity := range v.Severity {
+		if severity.Type != "CVSS_V3" {
+			continue
+		}
+		score, err := CVSS3BaseScore(severity.Score)
+		if err == nil {
+			return score
+		}
+	}
+	if label, ok := v.DatabaseSpecific["severity"].(string); ok {
+		return severityScores[reporting.ParseSeverity(label)]
+	}
+	return 0
+}
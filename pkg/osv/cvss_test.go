@@ -0,0 +1,58 @@
+package osv
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCVSS3BaseScore(t *testing.T) {
+	tt := []struct {
+		vector   string
+		expected float64
+	}{
+		{vector: "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H", expected: 9.8},
+		{vector: "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:C/C:H/I:H/A:H", expected: 10.0},
+		{vector: "CVSS:3.1/AV:N/AC:L/PR:H/UI:N/S:U/C:H/I:H/A:H", expected: 7.2},
+		{vector: "CVSS:3.1/AV:N/AC:L/PR:N/UI:R/S:U/C:H/I:N/A:N", expected: 6.5},
+		{vector: "CVSS:3.0/AV:N/AC:L/PR:L/UI:R/S:C/C:L/I:L/A:N", expected: 5.4},
+		{vector: "CVSS:3.1/AV:L/AC:H/PR:H/UI:R/S:U/C:L/I:N/A:N", expected: 1.8},
+		{vector: "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:N/I:N/A:N", expected: 0},
+	}
+	for _, test := range tt {
+		t.Run(test.vector, func(t *testing.T) {
+			score, err := CVSS3BaseScore(test.vector)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, score)
+		})
+	}
+
+	t.Run("error - CVSS v2 vector", func(t *testing.T) {
+		_, err := CVSS3BaseScore("AV:N/AC:L/Au:N/C:P/I:P/A:P")
+		assert.EqualError(t, err, "'AV:N/AC:L/Au:N/C:P/I:P/A:P' is no CVSS v3 vector")
+	})
+
+	t.Run("error - missing metric", func(t *testing.T) {
+		_, err := CVSS3BaseScore("CVSS:3.1/AV:N/AC:L/PR:N/S:U/C:H/I:H/A:H")
+		assert.EqualError(t, err, "invalid metric UI in CVSS vector 'CVSS:3.1/AV:N/AC:L/PR:N/S:U/C:H/I:H/A:H'")
+	})
+}
+
+func TestScore(t *testing.T) {
+	t.Run("CVSS v3 vector", func(t *testing.T) {
+		vulnerability := Vulnerability{Severity: []Severity{
+			{Type: "CVSS_V2", Score: "AV:N/AC:L/Au:N/C:P/I:P/A:P"},
+			{Type: "CVSS_V3", Score: "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H"},
+		}}
+		assert.Equal(t, 9.8, vulnerability.Score())
+	})
+
+	t.Run("severity of database", func(t *testing.T) {
+		vulnerability := Vulnerability{DatabaseSpecific: map[string]interface{}{"severity": "MODERATE"}}
+		assert.Equal(t, 4.0, vulnerability.Score())
+	})
+
+	t.Run("no severity", func(t *testing.T) {
+		assert.Equal(t, 0.0, (&Vulnerability{}).Score())
+	})
+}
@@ -0,0 +1,225 @@
+package osv
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/pkg/errors"
+)
+
+// Vulnerability is an entry of an OSV database as defined by the OSV schema (https://ossf.github.io/osv-schema/).
+// Elements which are not required for matching packages are not part of the model.
+type Vulnerability struct {
+	ID               string                 `json:"id"`
+	Aliases          []string               `json:"aliases,omitempty"`
+	Summary          string                 `json:"summary,omitempty"`
+	Details          string                 `json:"details,omitempty"`
+	Withdrawn        string                 `json:"withdrawn,omitempty"`
+	Affected         []Affected             `json:"affected"`
+	Severity         []Severity             `json:"severity,omitempty"`
+	DatabaseSpecific map[string]interface{} `json:"database_specific,omitempty"`
+}
+
+// Affected describes the versions of a package which are affected by a vulnerability
+type Affected struct {
+	Package  Package  `json:"package"`
+	Ranges   []Range  `json:"ranges,omitempty"`
+	Versions []string `json:"versions,omitempty"`
+}
+
+// Package identifies a package within an ecosystem, e.g. npm or Maven
+type Package struct {
+	Ecosystem string `json:"ecosystem"`
+	Name      string `json:"name"`
+	Purl      string `json:"purl,omitempty"`
+}
+
+// Range is a range of affected versions defined by a sequence of events
+type Range struct {
+	Type   string  `json:"type"`
+	Events []Event `json:"events"`
+}
+
+// Event introduces or ends a range of affected versions
+type Event struct {
+	Introduced   string `json:"introduced,omitempty"`
+	Fixed        string `json:"fixed,omitempty"`
+	LastAffected string `json:"last_affected,omitempty"`
+}
+
+// Severity is the severity of a vulnerability in a scoring system like CVSS_V3
+type Severity struct {
+	Type  string `json:"type"`
+	Score string `json:"score"`
+}
+
+// Utils bundles the file system functionality required for reading an OSV database
+type Utils interface {
+	Glob(pattern string) (matches []string, err error)
+	FileRead(path string) ([]byte, error)
+}
+
+// Database contains the vulnerabilities of a local OSV mirror indexed by ecosystem and package name
+type Database struct {
+	vulnerabilities map[string][]Vulnerability
+	count           int
+}
+
+// LoadDatabase reads all vulnerabilities of a local OSV mirror.
+// The directory may contain the vulnerabilities as single JSON files in any sub-directory
+// as well as the zip archives provided per ecosystem by osv.dev (e.g. npm/all.zip).
+func LoadDatabase(path string, utils Utils) (*Database, error) {
+	db := &Database{vulnerabilities: map[string][]Vulnerability{}}
+
+	jsonFiles, err := utils.Glob(filepath.Join(path, "**", "*.json"))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to search OSV database '%v'", path)
+	}
+	for _, jsonFile := range jsonFiles {
+		content, err := utils.FileRead(jsonFile)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read '%v'", jsonFile)
+		}
+		if err := db.add(content, jsonFile); err != nil {
+			return nil, err
+		}
+	}
+
+	zipFiles, err := utils.Glob(filepath.Join(path, "**", "*.zip"))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to search OSV database '%v'", path)
+	}
+	for _, zipFile := range zipFiles {
+		if err := db.addArchive(zipFile, utils); err != nil {
+			return nil, err
+		}
+	}
+
+	if db.count == 0 {
+		return nil, fmt.Errorf("no vulnerabilities found in OSV database '%v'", path)
+	}
+	log.Entry().Infof("Loaded %v vulnerabilities from OSV database '%v'", db.count, path)
+	return db, nil
+}
+
+func (db *Database) addArchive(zipFile string, utils Utils) error {
+	content, err := utils.FileRead(zipFile)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read '%v'", zipFile)
+	}
+	archive, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return errors.Wrapf(err, "failed to open archive '%v'", zipFile)
+	}
+	for _, file := range archive.File {
+		if !strings.HasSuffix(file.Name, ".json") {
+			continue
+		}
+		reader, err := file.Open()
+		if err != nil {
+			return errors.Wrapf(err, "failed to open '%v' in archive '%v'", file.Name, zipFile)
+		}
+		entry, err := ioutil.ReadAll(reader)
+		reader.Close()
+		if err != nil {
+			return errors.Wrapf(err, "failed to read '%v' in archive '%v'", file.Name, zipFile)
+		}
+		if err := db.add(entry, zipFile+"/"+file.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (db *Database) add(content []byte, source string) error {
+	vulnerability := Vulnerability{}
+	if err := json.Unmarshal(content, &vulnerability); err != nil {
+		return errors.Wrapf(err, "failed to parse OSV entry '%v'", source)
+	}
+	if len(vulnerability.ID) == 0 {
+		log.Entry().Debugf("Ignoring '%v' since it is no OSV entry", source)
+		return nil
+	}
+	if len(vulnerability.Withdrawn) > 0 {
+		log.Entry().Debugf("Ignoring withdrawn vulnerability %v", vulnerability.ID)
+		return nil
+	}
+	keys := map[string]bool{}
+	for _, affected := range vulnerability.Affected {
+		key := packageKey(affected.Package.Ecosystem, affected.Package.Name)
+		if keys[key] {
+			continue
+		}
+		keys[key] = true
+		db.vulnerabilities[key] = append(db.vulnerabilities[key], vulnerability)
+	}
+	db.count++
+	return nil
+}
+
+// Match returns the vulnerabilities affecting the given version of a package
+func (db *Database) Match(pkg Package, version string) []Vulnerability {
+	matches := []Vulnerability{}
+	for _, vulnerability := range db.vulnerabilities[packageKey(pkg.Ecosystem, pkg.Name)] {
+		for _, affected := range vulnerability.Affected {
+			if packageKey(affected.Package.Ecosystem, affected.Package.Name) == packageKey(pkg.Ecosystem, pkg.Name) && affected.affects(version) {
+				matches = append(matches, vulnerability)
+				break
+			}
+		}
+	}
+	return matches
+}
+
+// FixedVersions returns the versions fixing the vulnerability for the given package
+func (v *Vulnerability) FixedVersions(pkg Package) []string {
+	fixed := []string{}
+	for _, affected := range v.Affected {
+		if packageKey(affected.Package.Ecosystem, affected.Package.Name) != packageKey(pkg.Ecosystem, pkg.Name) {
+			continue
+		}
+		for _, r := range affected.Ranges {
+			for _, event := range r.Events {
+				if len(event.Fixed) > 0 && r.Type != "GIT" {
+					fixed = append(fixed, event.Fixed)
+				}
+			}
+		}
+	}
+	return fixed
+}
+
+// CVE returns the CVE id of the vulnerability, if it has none its OSV id is returned
+func (v *Vulnerability) CVE() string {
+	if strings.HasPrefix(v.ID, "CVE-") {
+		return v.ID
+	}
+	for _, alias := range v.Aliases {
+		if strings.HasPrefix(alias, "CVE-") {
+			return alias
+		}
+	}
+	return v.ID
+}
+
+// IDs returns the OSV id and the aliases of the vulnerability
+func (v *Vulnerability) IDs() []string {
+	return append([]string{v.ID}, v.Aliases...)
+}
+
+// packageKey identifies a package independent of the ecosystem release (e.g. Debian:11) and the naming conventions of the ecosystem
+func packageKey(ecosystem, name string) string {
+	ecosystem = strings.ToLower(strings.SplitN(ecosystem, ":", 2)[0])
+	name = strings.ToLower(name)
+	if ecosystem == "pypi" {
+		// see https://www.python.org/dev/peps/pep-0503/#normalized-names
+		name = strings.NewReplacer("_", "-", ".", "-").Replace(name)
+	}
+	return ecosystem + "/" + name
+}
@@ -0,0 +1,134 @@
+package osv
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+
+	"github.com/SAP/jenkins-library/pkg/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const lodashEntry = `{
+  "id": "GHSA-35jh-r3h4-6jhm",
+  "aliases": ["CVE-2021-23337"],
+  "summary": "Command Injection in lodash",
+  "affected": [{
+    "package": {"ecosystem": "npm", "name": "lodash", "purl": "pkg:npm/lodash"},
+    "ranges": [{"type": "SEMVER", "events": [{"introduced": "0"}, {"fixed": "4.17.21"}]}]
+  }],
+  "severity": [{"type": "CVSS_V3", "score": "CVSS:3.1/AV:N/AC:L/PR:H/UI:N/S:U/C:H/I:H/A:H"}],
+  "database_specific": {"severity": "HIGH"}
+}`
+
+const log4jEntry = `{
+  "id": "GHSA-jfh8-c2jp-5v3q",
+  "aliases": ["CVE-2021-44228"],
+  "summary": "Remote code injection in Log4j",
+  "affected": [{
+    "package": {"ecosystem": "Maven", "name": "org.apache.logging.log4j:log4j-core"},
+    "ranges": [
+      {"type": "ECOSYSTEM", "events": [{"introduced": "2.13.0"}, {"fixed": "2.15.0"}]},
+      {"type": "ECOSYSTEM", "events": [{"introduced": "2.0-beta9"}, {"fixed": "2.12.2"}]}
+    ]
+  }],
+  "severity": [{"type": "CVSS_V3", "score": "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:C/C:H/I:H/A:H"}]
+}`
+
+const withdrawnEntry = `{
+  "id": "GHSA-xxxx-xxxx-xxxx",
+  "withdrawn": "2021-10-01T00:00:00Z",
+  "affected": [{"package": {"ecosystem": "npm", "name": "lodash"}, "versions": ["4.17.20"]}]
+}`
+
+func zipArchive(t *testing.T, files map[string]string) []byte {
+	archive := &bytes.Buffer{}
+	zipWriter := zip.NewWriter(archive)
+	for name, content := range files {
+		writer, err := zipWriter.Create(name)
+		require.NoError(t, err)
+		_, err = writer.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zipWriter.Close())
+	return archive.Bytes()
+}
+
+func TestLoadDatabase(t *testing.T) {
+	t.Run("json files and archives", func(t *testing.T) {
+		utils := &mock.FilesMock{}
+		utils.AddFile("osv/npm/GHSA-35jh-r3h4-6jhm.json", []byte(lodashEntry))
+		utils.AddFile("osv/npm/GHSA-xxxx-xxxx-xxxx.json", []byte(withdrawnEntry))
+		utils.AddFile("osv/Maven/all.zip", zipArchive(t, map[string]string{"GHSA-jfh8-c2jp-5v3q.json": log4jEntry}))
+
+		db, err := LoadDatabase("osv", utils)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, db.count)
+		assert.Len(t, db.Match(Package{Ecosystem: "npm", Name: "lodash"}, "4.17.20"), 1)
+		assert.Len(t, db.Match(Package{Ecosystem: "Maven", Name: "org.apache.logging.log4j:log4j-core"}, "2.14.1"), 1)
+	})
+
+	t.Run("error - empty database", func(t *testing.T) {
+		_, err := LoadDatabase("osv", &mock.FilesMock{})
+		assert.EqualError(t, err, "no vulnerabilities found in OSV database 'osv'")
+	})
+
+	t.Run("error - invalid entry", func(t *testing.T) {
+		utils := &mock.FilesMock{}
+		utils.AddFile("osv/npm/invalid.json", []byte("{"))
+
+		_, err := LoadDatabase("osv", utils)
+		assert.Contains(t, err.Error(), "failed to parse OSV entry 'osv/npm/invalid.json'")
+	})
+}
+
+func TestMatch(t *testing.T) {
+	db := &Database{vulnerabilities: map[string][]Vulnerability{}}
+	require.NoError(t, db.add([]byte(lodashEntry), "lodash.json"))
+	require.NoError(t, db.add([]byte(log4jEntry), "log4j.json"))
+
+	log4j := Package{Ecosystem: "Maven", Name: "org.apache.logging.log4j:log4j-core"}
+	tt := []struct {
+		pkg      Package
+		version  string
+		expected int
+	}{
+		{pkg: Package{Ecosystem: "npm", Name: "lodash"}, version: "4.17.20", expected: 1},
+		{pkg: Package{Ecosystem: "npm", Name: "lodash"}, version: "4.17.21", expected: 0},
+		{pkg: Package{Ecosystem: "npm", Name: "underscore"}, version: "1.0.0", expected: 0},
+		{pkg: log4j, version: "2.14.1", expected: 1},
+		{pkg: log4j, version: "2.12.1", expected: 1},
+		{pkg: log4j, version: "2.12.2", expected: 0},
+		{pkg: log4j, version: "2.15.0", expected: 0},
+		{pkg: log4j, version: "1.2.17", expected: 0},
+	}
+	for _, test := range tt {
+		t.Run(test.pkg.Name+"@"+test.version, func(t *testing.T) {
+			assert.Len(t, db.Match(test.pkg, test.version), test.expected)
+		})
+	}
+}
+
+func TestVulnerability(t *testing.T) {
+	vulnerability := Vulnerability{
+		ID:      "GHSA-35jh-r3h4-6jhm",
+		Aliases: []string{"CVE-2021-23337"},
+		Affected: []Affected{
+			{Package: Package{Ecosystem: "npm", Name: "lodash"}, Ranges: []Range{{Type: "SEMVER", Events: []Event{{Introduced: "0"}, {Fixed: "4.17.21"}}}}},
+			{Package: Package{Ecosystem: "npm", Name: "lodash-es"}, Ranges: []Range{{Type: "SEMVER", Events: []Event{{Introduced: "0"}, {Fixed: "4.17.21"}}}}},
+		},
+	}
+
+	assert.Equal(t, "CVE-2021-23337", vulnerability.CVE())
+	assert.Equal(t, []string{"GHSA-35jh-r3h4-6jhm", "CVE-2021-23337"}, vulnerability.IDs())
+	assert.Equal(t, []string{"4.17.21"}, vulnerability.FixedVersions(Package{Ecosystem: "npm", Name: "lodash"}))
+	assert.Equal(t, "GHSA-xxxx", (&Vulnerability{ID: "GHSA-xxxx"}).CVE())
+}
+
+func TestPackageKey(t *testing.T) {
+	assert.Equal(t, "debian/openssl", packageKey("Debian:11", "openssl"))
+	assert.Equal(t, "pypi/zope-interface", packageKey("PyPI", "zope.interface"))
+	assert.Equal(t, "pypi/zope-interface", packageKey("PyPI", "Zope_Interface"))
+	assert.Equal(t, "maven/org.slf4j:slf4j-api", packageKey("Maven", "org.slf4j:slf4j-api"))
+}
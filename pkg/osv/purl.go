@@ -0,0 +1,67 @@
+package osv
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// purlEcosystems maps package URL types (https://github.com/package-url/purl-spec) to OSV ecosystems
+var purlEcosystems = map[string]string{
+	"apk":      "Alpine",
+	"cargo":    "crates.io",
+	"composer": "Packagist",
+	"deb":      "Debian",
+	"gem":      "RubyGems",
+	"golang":   "Go",
+	"hex":      "Hex",
+	"maven":    "Maven",
+	"npm":      "npm",
+	"nuget":    "NuGet",
+	"pub":      "Pub",
+	"pypi":     "PyPI",
+}
+
+// ParsePurl returns the OSV package and the version identified by a package URL like pkg:maven/org.slf4j/slf4j-api@1.7.32?type=jar
+func ParsePurl(purl string) (Package, string, error) {
+	pkg := Package{Purl: purl}
+	if !strings.HasPrefix(purl, "pkg:") {
+		return pkg, "", fmt.Errorf("'%v' is no package URL", purl)
+	}
+	remainder := strings.TrimPrefix(purl, "pkg:")
+	remainder = strings.SplitN(remainder, "#", 2)[0]
+	remainder = strings.SplitN(remainder, "?", 2)[0]
+
+	version := ""
+	if index := strings.LastIndex(remainder, "@"); index > strings.LastIndex(remainder, "/") {
+		version, _ = url.PathUnescape(remainder[index+1:])
+		remainder = remainder[:index]
+	}
+
+	segments := strings.Split(strings.Trim(remainder, "/"), "/")
+	if len(segments) < 2 {
+		return pkg, "", fmt.Errorf("package URL '%v' does not contain a name", purl)
+	}
+	for i, segment := range segments {
+		segments[i], _ = url.PathUnescape(segment)
+	}
+	purlType := strings.ToLower(segments[0])
+	namespace := strings.Join(segments[1:len(segments)-1], "/")
+	name := segments[len(segments)-1]
+
+	ecosystem, ok := purlEcosystems[purlType]
+	if !ok {
+		return pkg, "", fmt.Errorf("package URL type '%v' is not supported", purlType)
+	}
+	pkg.Ecosystem = ecosystem
+	pkg.Name = name
+	switch purlType {
+	case "maven":
+		pkg.Name = namespace + ":" + name
+	case "npm", "golang", "composer":
+		if len(namespace) > 0 {
+			pkg.Name = namespace + "/" + name
+		}
+	}
+	return pkg, version, nil
+}
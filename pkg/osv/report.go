@@ -0,0 +1,151 @@
+package osv
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/SAP/jenkins-library/pkg/reporting"
+)
+
+// Result is a vulnerability affecting a component of an SBOM
+type Result struct {
+	Component     Component
+	Vulnerability Vulnerability
+	Score         float64
+	// Suppression is set if the vulnerability has been excluded via configuration or triage file
+	Suppression *reporting.Suppression
+}
+
+// Scan matches the components against the database, the results are sorted by descending score.
+// Vulnerabilities are suppressed by the triage via the OSV id or one of its aliases.
+func Scan(db *Database, components []Component, triage *reporting.Triage) []Result {
+	results := []Result{}
+	known := map[string]bool{}
+	for _, component := range components {
+		for _, vulnerability := range db.Match(component.Package, component.Version) {
+			key := fmt.Sprintf("%v|%v|%v", vulnerability.ID, packageKey(component.Package.Ecosystem, component.Package.Name), component.Version)
+			if known[key] {
+				continue
+			}
+			known[key] = true
+			result := Result{
+				Component:     component,
+				Vulnerability: vulnerability,
+				Score:         vulnerability.Score(),
+			}
+			if suppression, expired := triage.Find(vulnerability.IDs(), component.Package.Purl, component.Package.Name); !expired {
+				result.Suppression = suppression
+			}
+			results = append(results, result)
+		}
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
+	})
+	return results
+}
+
+// IsSevere returns whether the result is not excluded and reaches the CVSS limit, a negative limit disables the check
+func (r *Result) IsSevere(cvssSeverityLimit float64) bool {
+	return r.Suppression == nil && cvssSeverityLimit >= 0 && r.Score >= cvssSeverityLimit
+}
+
+// Finding converts the result into a tool independent finding
+func (r *Result) Finding() reporting.Finding {
+	return reporting.Finding{
+		RuleID:      r.Vulnerability.CVE(),
+		Aliases:     r.aliases(),
+		Title:       fmt.Sprintf("%v in %v@%v", r.Vulnerability.CVE(), r.Component.Package.Name, r.Component.Version),
+		Description: r.Vulnerability.Summary,
+		Severity:    reporting.SeverityFromScore(r.Score),
+		Category:    reporting.CategoryVulnerability,
+		Score:       r.Score,
+		Location:    r.Component.SBOM,
+		Component:   r.Component.Package.Purl,
+		URL:         "https://osv.dev/vulnerability/" + r.Vulnerability.ID,
+		Suppressed:  r.Suppression != nil,
+	}
+}
+
+// aliases returns the ids of the vulnerability other than its CVE
+func (r *Result) aliases() []string {
+	var aliases []string
+	for _, id := range r.Vulnerability.IDs() {
+		if id != r.Vulnerability.CVE() {
+			aliases = append(aliases, id)
+		}
+	}
+	return aliases
+}
+
+// CreateScanReport creates the report of an OSV scan
+func CreateScanReport(results []Result, componentCount int, sbomFiles []string, databasePath string, cvssSeverityLimit float64) reporting.ScanReport {
+	severe, excluded := 0, 0
+	for _, result := range results {
+		if result.IsSevere(cvssSeverityLimit) {
+			severe++
+		}
+		if result.Suppression != nil {
+			excluded++
+		}
+	}
+
+	scanReport := reporting.ScanReport{
+		StepName: "osvExecuteScan",
+		Title:    "OSV Vulnerability Report",
+		Subheaders: []reporting.Subheader{
+			{Description: "SBOMs", Details: strings.Join(sbomFiles, ", ")},
+			{Description: "OSV database", Details: databasePath},
+		},
+		Overview: []reporting.OverviewRow{
+			{Description: "Scanned components", Details: fmt.Sprint(componentCount)},
+			{Description: "Total number of vulnerabilities", Details: fmt.Sprint(len(results))},
+			{Description: fmt.Sprintf("Vulnerabilities with CVSS score >= %.1f", cvssSeverityLimit), Details: fmt.Sprint(severe)},
+			{Description: "Excluded vulnerabilities", Details: fmt.Sprint(excluded)},
+		},
+		SuccessfulScan: severe == 0,
+		ReportTime:     time.Now(),
+	}
+
+	detailTable := reporting.ScanDetailTable{
+		NoRowsMessage: "No publicly known vulnerabilities detected",
+		Headers: []string{
+			"Vulnerability",
+			"CVSS Score",
+			"Component",
+			"Version",
+			"Fixed versions",
+			"Summary",
+			"Excluded",
+		},
+		WithCounter:   true,
+		CounterHeader: "Entry #",
+	}
+	for _, result := range results {
+		var scoreStyle reporting.ColumnStyle = reporting.Yellow
+		if result.IsSevere(cvssSeverityLimit) {
+			scoreStyle = reporting.Red
+		}
+		exclusion := ""
+		if result.Suppression != nil {
+			exclusion = result.Suppression.Justification
+		}
+
+		row := reporting.ScanRow{}
+		row.AddColumn(fmt.Sprintf(`<a href="https://osv.dev/vulnerability/%v">%v</a>`, result.Vulnerability.ID, result.Vulnerability.CVE()), 0)
+		row.AddColumn(result.Score, scoreStyle)
+		row.AddColumn(result.Component.Package.Name, 0)
+		row.AddColumn(result.Component.Version, 0)
+		row.AddColumn(strings.Join(result.Vulnerability.FixedVersions(result.Component.Package), ", "), 0)
+		row.AddColumn(result.Vulnerability.Summary, 0)
+		row.AddColumn(exclusion, 0)
+		detailTable.Rows = append(detailTable.Rows, row)
+
+		scanReport.AddFinding(result.Finding())
+	}
+	scanReport.DetailTable = detailTable
+
+	return scanReport
+}
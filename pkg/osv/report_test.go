@@ -0,0 +1,69 @@
+package osv
+
+import (
+	"testing"
+
+	"github.com/SAP/jenkins-library/pkg/reporting"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanAndReport(t *testing.T) {
+	db := &Database{vulnerabilities: map[string][]Vulnerability{}}
+	require.NoError(t, db.add([]byte(lodashEntry), "lodash.json"))
+	require.NoError(t, db.add([]byte(log4jEntry), "log4j.json"))
+
+	components := []Component{
+		{Package: Package{Ecosystem: "npm", Name: "lodash", Purl: "pkg:npm/lodash@4.17.20"}, Version: "4.17.20", SBOM: "bom.xml"},
+		{Package: Package{Ecosystem: "npm", Name: "lodash", Purl: "pkg:npm/lodash@4.17.20"}, Version: "4.17.20", SBOM: "ui/bom.xml"},
+		{Package: Package{Ecosystem: "Maven", Name: "org.apache.logging.log4j:log4j-core", Purl: "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1"}, Version: "2.14.1", SBOM: "target/bom.xml"},
+		{Package: Package{Ecosystem: "npm", Name: "react", Purl: "pkg:npm/react@17.0.2"}, Version: "17.0.2", SBOM: "bom.xml"},
+	}
+	triage := &reporting.Triage{Suppressions: []reporting.Suppression{
+		{ID: "CVE-2021-44228", Justification: "JNDI lookups are disabled"},
+		{ID: "GHSA-35jh-r3h4-6jhm", Component: "lodash-es", Justification: "not used"},
+	}}
+
+	results := Scan(db, components, triage)
+	require.Len(t, results, 2)
+	assert.Equal(t, "GHSA-jfh8-c2jp-5v3q", results[0].Vulnerability.ID)
+	assert.Equal(t, 10.0, results[0].Score)
+	assert.Equal(t, &triage.Suppressions[0], results[0].Suppression)
+	assert.Equal(t, "GHSA-35jh-r3h4-6jhm", results[1].Vulnerability.ID)
+	assert.Equal(t, 7.2, results[1].Score)
+	assert.Nil(t, results[1].Suppression)
+
+	assert.False(t, results[0].IsSevere(7.0))
+	assert.True(t, results[1].IsSevere(7.0))
+	assert.False(t, results[1].IsSevere(9.0))
+	assert.False(t, results[1].IsSevere(-1))
+
+	t.Run("report", func(t *testing.T) {
+		scanReport := CreateScanReport(results, len(components), []string{"bom.xml", "target/bom.xml"}, "osv", 7.0)
+
+		assert.Equal(t, "osvExecuteScan", scanReport.StepName)
+		assert.False(t, scanReport.SuccessfulScan)
+		assert.Equal(t, []reporting.OverviewRow{
+			{Description: "Scanned components", Details: "4"},
+			{Description: "Total number of vulnerabilities", Details: "2"},
+			{Description: "Vulnerabilities with CVSS score >= 7.0", Details: "1"},
+			{Description: "Excluded vulnerabilities", Details: "1"},
+		}, scanReport.Overview)
+		assert.Len(t, scanReport.DetailTable.Rows, 2)
+		if assert.Len(t, scanReport.Findings, 2) {
+			assert.Equal(t, reporting.Finding{
+				RuleID:      "CVE-2021-23337",
+				Aliases:     []string{"GHSA-35jh-r3h4-6jhm"},
+				Title:       "CVE-2021-23337 in lodash@4.17.20",
+				Description: "Command Injection in lodash",
+				Severity:    reporting.SeverityHigh,
+				Category:    reporting.CategoryVulnerability,
+				Score:       7.2,
+				Location:    "bom.xml",
+				Component:   "pkg:npm/lodash@4.17.20",
+				URL:         "https://osv.dev/vulnerability/GHSA-35jh-r3h4-6jhm",
+			}, scanReport.Findings[1])
+			assert.True(t, scanReport.Findings[0].Suppressed)
+		}
+	})
+}
@@ -0,0 +1,106 @@
+package osv
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+
+	"github.com/SAP/jenkins-library/pkg/cyclonedx"
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/pkg/errors"
+)
+
+// Component is a package listed in an SBOM
+type Component struct {
+	Package Package
+	Version string
+	// SBOM is the path of the SBOM listing the component
+	SBOM string
+}
+
+// sbomDocument contains the elements of CycloneDX and SPDX documents in JSON format which list packages
+type sbomDocument struct {
+	BOMFormat   string          `json:"bomFormat"`
+	Components  []jsonComponent `json:"components"`
+	SPDXVersion string          `json:"spdxVersion"`
+	Packages    []struct {
+		ExternalRefs []struct {
+			ReferenceType    string `json:"referenceType"`
+			ReferenceLocator string `json:"referenceLocator"`
+		} `json:"externalRefs"`
+	} `json:"packages"`
+}
+
+type jsonComponent struct {
+	Purl       string          `json:"purl"`
+	Components []jsonComponent `json:"components"`
+}
+
+// ReadComponents returns the components of an SBOM in CycloneDX (XML or JSON) or SPDX (JSON) format.
+// Components are identified by their package URL, components without package URL are ignored.
+func ReadComponents(path string, fileUtils cyclonedx.FileUtils) ([]Component, error) {
+	content, err := fileUtils.FileRead(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read SBOM '%v'", path)
+	}
+
+	purls := []string{}
+	if bytes.HasPrefix(bytes.TrimSpace(content), []byte("<")) {
+		bom := cyclonedx.BOM{}
+		if err := xml.Unmarshal(content, &bom); err != nil {
+			return nil, errors.Wrapf(err, "failed to parse SBOM '%v'", path)
+		}
+		purls = xmlPurls(purls, bom.Components)
+	} else {
+		document := sbomDocument{}
+		if err := json.Unmarshal(content, &document); err != nil {
+			return nil, errors.Wrapf(err, "failed to parse SBOM '%v'", path)
+		}
+		switch {
+		case document.BOMFormat == "CycloneDX":
+			purls = jsonPurls(purls, document.Components)
+		case len(document.SPDXVersion) > 0:
+			for _, pkg := range document.Packages {
+				for _, ref := range pkg.ExternalRefs {
+					if ref.ReferenceType == "purl" {
+						purls = append(purls, ref.ReferenceLocator)
+					}
+				}
+			}
+		default:
+			return nil, fmt.Errorf("'%v' is neither a CycloneDX nor an SPDX document", path)
+		}
+	}
+
+	components := []Component{}
+	for _, purl := range purls {
+		pkg, version, err := ParsePurl(purl)
+		if err != nil {
+			log.Entry().Debugf("Ignoring component of SBOM '%v': %v", path, err)
+			continue
+		}
+		components = append(components, Component{Package: pkg, Version: version, SBOM: path})
+	}
+	return components, nil
+}
+
+func xmlPurls(purls []string, components []cyclonedx.Component) []string {
+	for _, component := range components {
+		if len(component.Purl) > 0 {
+			purls = append(purls, component.Purl)
+		}
+		purls = xmlPurls(purls, component.Components)
+	}
+	return purls
+}
+
+func jsonPurls(purls []string, components []jsonComponent) []string {
+	for _, component := range components {
+		if len(component.Purl) > 0 {
+			purls = append(purls, component.Purl)
+		}
+		purls = jsonPurls(purls, component.Components)
+	}
+	return purls
+}
@@ -0,0 +1,124 @@
+package osv
+
+import (
+	"testing"
+
+	"github.com/SAP/jenkins-library/pkg/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+const cycloneDXXML = `<?xml version="1.0" encoding="UTF-8"?>
+<bom xmlns="http://cyclonedx.org/schema/bom/1.3" version="1">
+  <components>
+    <component type="library">
+      <group>org.apache.logging.log4j</group>
+      <name>log4j-core</name>
+      <version>2.14.1</version>
+      <purl>pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1?type=jar</purl>
+      <components>
+        <component type="library">
+          <name>shaded</name>
+          <purl>pkg:npm/%40babel/core@7.15.0</purl>
+        </component>
+      </components>
+    </component>
+    <component type="library">
+      <name>no-purl</name>
+    </component>
+  </components>
+</bom>
+`
+
+const cycloneDXJSON = `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.3",
+  "components": [
+    {"type": "library", "name": "lodash", "version": "4.17.20", "purl": "pkg:npm/lodash@4.17.20"},
+    {"type": "library", "name": "requests", "version": "2.26.0", "purl": "pkg:pypi/requests@2.26.0"}
+  ]
+}`
+
+const spdxJSON = `{
+  "spdxVersion": "SPDX-2.2",
+  "packages": [
+    {"name": "golang.org/x/text", "externalRefs": [{"referenceCategory": "PACKAGE-MANAGER", "referenceType": "purl", "referenceLocator": "pkg:golang/golang.org/x/text@v0.3.6"}]},
+    {"name": "openssl", "externalRefs": [{"referenceCategory": "SECURITY", "referenceType": "cpe23Type", "referenceLocator": "cpe:2.3:a:openssl:openssl:1.1.1:*:*:*:*:*:*:*"}]}
+  ]
+}`
+
+func TestReadComponents(t *testing.T) {
+	utils := &mock.FilesMock{}
+	utils.AddFile("bom.xml", []byte(cycloneDXXML))
+	utils.AddFile("bom.json", []byte(cycloneDXJSON))
+	utils.AddFile("sbom.spdx.json", []byte(spdxJSON))
+	utils.AddFile("package.json", []byte(`{"name": "ui"}`))
+
+	t.Run("CycloneDX XML", func(t *testing.T) {
+		components, err := ReadComponents("bom.xml", utils)
+		assert.NoError(t, err)
+		assert.Equal(t, []Component{
+			{Package: Package{Ecosystem: "Maven", Name: "org.apache.logging.log4j:log4j-core", Purl: "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1?type=jar"}, Version: "2.14.1", SBOM: "bom.xml"},
+			{Package: Package{Ecosystem: "npm", Name: "@babel/core", Purl: "pkg:npm/%40babel/core@7.15.0"}, Version: "7.15.0", SBOM: "bom.xml"},
+		}, components)
+	})
+
+	t.Run("CycloneDX JSON", func(t *testing.T) {
+		components, err := ReadComponents("bom.json", utils)
+		assert.NoError(t, err)
+		if assert.Len(t, components, 2) {
+			assert.Equal(t, Package{Ecosystem: "PyPI", Name: "requests", Purl: "pkg:pypi/requests@2.26.0"}, components[1].Package)
+		}
+	})
+
+	t.Run("SPDX JSON", func(t *testing.T) {
+		components, err := ReadComponents("sbom.spdx.json", utils)
+		assert.NoError(t, err)
+		assert.Equal(t, []Component{
+			{Package: Package{Ecosystem: "Go", Name: "golang.org/x/text", Purl: "pkg:golang/golang.org/x/text@v0.3.6"}, Version: "v0.3.6", SBOM: "sbom.spdx.json"},
+		}, components)
+	})
+
+	t.Run("error - no SBOM", func(t *testing.T) {
+		_, err := ReadComponents("package.json", utils)
+		assert.EqualError(t, err, "'package.json' is neither a CycloneDX nor an SPDX document")
+	})
+
+	t.Run("error - file not found", func(t *testing.T) {
+		_, err := ReadComponents("missing.xml", utils)
+		assert.Contains(t, err.Error(), "failed to read SBOM 'missing.xml'")
+	})
+}
+
+func TestParsePurl(t *testing.T) {
+	tt := []struct {
+		purl            string
+		expectedPackage Package
+		expectedVersion string
+	}{
+		{purl: "pkg:maven/org.slf4j/slf4j-api@1.7.32?type=jar", expectedPackage: Package{Ecosystem: "Maven", Name: "org.slf4j:slf4j-api"}, expectedVersion: "1.7.32"},
+		{purl: "pkg:npm/@angular/core@12.0.0", expectedPackage: Package{Ecosystem: "npm", Name: "@angular/core"}, expectedVersion: "12.0.0"},
+		{purl: "pkg:npm/lodash", expectedPackage: Package{Ecosystem: "npm", Name: "lodash"}},
+		{purl: "pkg:golang/github.com/pkg/errors@v0.9.1", expectedPackage: Package{Ecosystem: "Go", Name: "github.com/pkg/errors"}, expectedVersion: "v0.9.1"},
+		{purl: "pkg:deb/debian/openssl@1.1.1k-1?arch=amd64&distro=debian-11", expectedPackage: Package{Ecosystem: "Debian", Name: "openssl"}, expectedVersion: "1.1.1k-1"},
+		{purl: "pkg:pypi/django@3.2.8#subpath", expectedPackage: Package{Ecosystem: "PyPI", Name: "django"}, expectedVersion: "3.2.8"},
+	}
+	for _, test := range tt {
+		t.Run(test.purl, func(t *testing.T) {
+			pkg, version, err := ParsePurl(test.purl)
+			assert.NoError(t, err)
+			test.expectedPackage.Purl = test.purl
+			assert.Equal(t, test.expectedPackage, pkg)
+			assert.Equal(t, test.expectedVersion, version)
+		})
+	}
+
+	t.Run("error - unsupported type", func(t *testing.T) {
+		_, _, err := ParsePurl("pkg:github/package-url/purl-spec@244fd47")
+		assert.EqualError(t, err, "package URL type 'github' is not supported")
+	})
+
+	t.Run("error - no package URL", func(t *testing.T) {
+		_, _, err := ParsePurl("cpe:2.3:a:openssl:openssl:1.1.1")
+		assert.EqualError(t, err, "'cpe:2.3:a:openssl:openssl:1.1.1' is no package URL")
+	})
+}
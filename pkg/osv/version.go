@@ -0,0 +1,148 @@
+package osv
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// affects returns whether the version is affected, either since it is listed explicitly
+// or since it is contained in one of the SEMVER or ECOSYSTEM ranges. GIT ranges cannot be evaluated for package versions.
+func (a *Affected) affects(version string) bool {
+	for _, v := range a.Versions {
+		if v == version {
+			return true
+		}
+	}
+	for _, r := range a.Ranges {
+		if r.Type == "GIT" {
+			continue
+		}
+		if r.contains(version) {
+			return true
+		}
+	}
+	return false
+}
+
+// contains evaluates the events of the range as described in https://ossf.github.io/osv-schema/#evaluation
+func (r *Range) contains(version string) bool {
+	events := make([]Event, len(r.Events))
+	copy(events, r.Events)
+	sort.SliceStable(events, func(i, j int) bool {
+		return compareVersions(events[i].version(), events[j].version()) < 0
+	})
+
+	affected := false
+	for _, event := range events {
+		switch {
+		case len(event.Introduced) > 0:
+			if event.Introduced == "0" || compareVersions(version, event.Introduced) >= 0 {
+				affected = true
+			}
+		case len(event.Fixed) > 0:
+			if compareVersions(version, event.Fixed) >= 0 {
+				affected = false
+			}
+		case len(event.LastAffected) > 0:
+			if compareVersions(version, event.LastAffected) > 0 {
+				affected = false
+			}
+		}
+	}
+	return affected
+}
+
+func (e *Event) version() string {
+	switch {
+	case len(e.Introduced) > 0:
+		return e.Introduced
+	case len(e.Fixed) > 0:
+		return e.Fixed
+	}
+	return e.LastAffected
+}
+
+// compareVersions compares two versions independent of the ecosystem.
+// The versions are split into numeric and alphabetic parts which are compared one by one,
+// a version with additional alphabetic parts (e.g. 1.0.0-rc.1) is considered as pre-release of the shorter version.
+// This covers semantic versions as well as the usual Maven, PyPI or NuGet versions.
+func compareVersions(a, b string) int {
+	partsA := versionParts(a)
+	partsB := versionParts(b)
+	for i := 0; i < len(partsA) || i < len(partsB); i++ {
+		switch {
+		case i >= len(partsA):
+			return -remainderOrder(partsB[i])
+		case i >= len(partsB):
+			return remainderOrder(partsA[i])
+		}
+		if result := compareParts(partsA[i], partsB[i]); result != 0 {
+			return result
+		}
+	}
+	return 0
+}
+
+// remainderOrder returns whether the version having this additional part is greater (numeric part) or smaller (pre-release) than the shorter version
+func remainderOrder(part string) int {
+	if isNumeric(part) {
+		return 1
+	}
+	return -1
+}
+
+func compareParts(a, b string) int {
+	numericA, numericB := isNumeric(a), isNumeric(b)
+	switch {
+	case numericA && numericB:
+		intA, _ := strconv.ParseUint(a, 10, 64)
+		intB, _ := strconv.ParseUint(b, 10, 64)
+		switch {
+		case intA < intB:
+			return -1
+		case intA > intB:
+			return 1
+		}
+		return 0
+	case numericA:
+		return 1
+	case numericB:
+		return -1
+	}
+	return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+}
+
+// versionParts splits a version into its numeric and alphabetic parts ignoring a leading v and build metadata
+func versionParts(version string) []string {
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	version = strings.SplitN(version, "+", 2)[0]
+
+	parts := []string{}
+	current := []rune{}
+	for _, r := range version {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			parts = appendPart(parts, current)
+			current = []rune{}
+			continue
+		}
+		if len(current) > 0 && unicode.IsDigit(r) != unicode.IsDigit(current[0]) {
+			parts = appendPart(parts, current)
+			current = []rune{}
+		}
+		current = append(current, r)
+	}
+	return appendPart(parts, current)
+}
+
+func appendPart(parts []string, part []rune) []string {
+	if len(part) == 0 {
+		return parts
+	}
+	return append(parts, string(part))
+}
+
+func isNumeric(part string) bool {
+	return len(part) > 0 && unicode.IsDigit([]rune(part)[0])
+}
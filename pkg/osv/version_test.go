@@ -0,0 +1,62 @@
+package osv
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareVersions(t *testing.T) {
+	tt := []struct {
+		a, b     string
+		expected int
+	}{
+		{a: "1.0.0", b: "1.0.0", expected: 0},
+		{a: "v1.0.0", b: "1.0.0", expected: 0},
+		{a: "1.0.0+build.1", b: "1.0.0", expected: 0},
+		{a: "1.0.1", b: "1.0.0", expected: 1},
+		{a: "1.10.0", b: "1.9.0", expected: 1},
+		{a: "1.0", b: "1.0.0", expected: -1},
+		{a: "1.0.0-rc.1", b: "1.0.0", expected: -1},
+		{a: "1.0.0-alpha", b: "1.0.0-beta", expected: -1},
+		{a: "2.0-beta9", b: "2.0", expected: -1},
+		{a: "2.0-beta9", b: "2.0.1", expected: -1},
+		{a: "1.0rc1", b: "1.0", expected: -1},
+		{a: "5.3.10.RELEASE", b: "5.3.9.RELEASE", expected: 1},
+	}
+	for _, test := range tt {
+		t.Run(test.a+" vs "+test.b, func(t *testing.T) {
+			assert.Equal(t, test.expected, compareVersions(test.a, test.b))
+			assert.Equal(t, -test.expected, compareVersions(test.b, test.a))
+		})
+	}
+}
+
+func TestAffects(t *testing.T) {
+	t.Run("explicit versions", func(t *testing.T) {
+		affected := Affected{Versions: []string{"1.0.0", "1.0.1"}}
+		assert.True(t, affected.affects("1.0.1"))
+		assert.False(t, affected.affects("1.0.2"))
+	})
+
+	t.Run("last affected version", func(t *testing.T) {
+		affected := Affected{Ranges: []Range{{Type: "ECOSYSTEM", Events: []Event{{Introduced: "1.2.0"}, {LastAffected: "1.4.0"}}}}}
+		assert.False(t, affected.affects("1.1.9"))
+		assert.True(t, affected.affects("1.2.0"))
+		assert.True(t, affected.affects("1.4.0"))
+		assert.False(t, affected.affects("1.4.1"))
+	})
+
+	t.Run("unsorted events", func(t *testing.T) {
+		affected := Affected{Ranges: []Range{{Type: "SEMVER", Events: []Event{{Fixed: "2.0.0"}, {Introduced: "1.0.0"}, {Fixed: "1.5.0"}, {Introduced: "1.8.0"}}}}}
+		assert.True(t, affected.affects("1.4.0"))
+		assert.False(t, affected.affects("1.6.0"))
+		assert.True(t, affected.affects("1.9.0"))
+		assert.False(t, affected.affects("2.0.0"))
+	})
+
+	t.Run("git ranges are ignored", func(t *testing.T) {
+		affected := Affected{Ranges: []Range{{Type: "GIT", Events: []Event{{Introduced: "0"}}}}}
+		assert.False(t, affected.affects("1.0.0"))
+	})
+}
@@ -1,6 +1,10 @@
 package protecode
 
-import "strconv"
+import (
+	"strconv"
+
+	"github.com/SAP/jenkins-library/pkg/reporting"
+)
 
 const (
 	vulnerabilitySeverityThreshold = 7.0
@@ -12,13 +16,15 @@ func HasFailed(result ResultData) bool {
 	return len(result.Result.Status) > 0 && result.Result.Status == statusFailed
 }
 
-//HasSevereVulnerabilities checks if any non-historic, non-triaged, non-excluded vulnerability has a CVSS score above the defined threshold
-func HasSevereVulnerabilities(result Result, excludeCVEs string) bool {
+//HasSevereVulnerabilities checks if any non-historic, non-triaged, non-excluded vulnerability has a CVSS score above the defined threshold.
+//Vulnerabilities suppressed via the triage file of the repository are not considered.
+func HasSevereVulnerabilities(result Result, excludeCVEs string, triage *reporting.Triage) bool {
 	for _, component := range result.Components {
 		for _, vulnerability := range component.Vulns {
 			if isSevere(vulnerability) &&
 				!isTriaged(vulnerability) &&
 				!isExcluded(vulnerability, excludeCVEs) &&
+				!triage.Suppresses(vulnFinding(componentVuln(component, vulnerability))) &&
 				isExact(vulnerability) {
 				return true
 			}
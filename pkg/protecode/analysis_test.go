@@ -3,6 +3,8 @@ package protecode
 import (
 	"testing"
 
+	"github.com/SAP/jenkins-library/pkg/reporting"
+
 	"github.com/stretchr/testify/assert"
 )
 
@@ -92,36 +94,55 @@ func TestHasSevereVulnerabilities(t *testing.T) {
 		// init
 		data := Result{Components: []Component{{Vulns: []Vulnerability{nonSevere1, severeV3}}}}
 		// test && assert
-		assert.True(t, HasSevereVulnerabilities(data, ""))
+		assert.True(t, HasSevereVulnerabilities(data, "", nil))
 	})
 	t.Run("with severe v2 vulnerabilities", func(t *testing.T) {
 		// init
 		data := Result{Components: []Component{{Vulns: []Vulnerability{nonSevere1, severeV2}}}}
 		// test && assert
-		assert.True(t, HasSevereVulnerabilities(data, ""))
+		assert.True(t, HasSevereVulnerabilities(data, "", nil))
 	})
 	t.Run("without severe vulnerabilities", func(t *testing.T) {
 		// init
 		data := Result{Components: []Component{{Vulns: []Vulnerability{nonSevere1, nonSevere2}}}}
 		// test && assert
-		assert.False(t, HasSevereVulnerabilities(data, ""))
+		assert.False(t, HasSevereVulnerabilities(data, "", nil))
 	})
 	t.Run("with historic vulnerabilities", func(t *testing.T) {
 		// init
 		data := Result{Components: []Component{{Vulns: []Vulnerability{nonSevere1, triaged}}}}
 		// test && assert
-		assert.False(t, HasSevereVulnerabilities(data, ""))
+		assert.False(t, HasSevereVulnerabilities(data, "", nil))
 	})
 	t.Run("with excluded vulnerabilities", func(t *testing.T) {
 		// init
 		data := Result{Components: []Component{{Vulns: []Vulnerability{nonSevere1, excluded}}}}
 		// test && assert
-		assert.False(t, HasSevereVulnerabilities(data, "Cve5,Cve14"))
+		assert.False(t, HasSevereVulnerabilities(data, "Cve5,Cve14", nil))
+	})
+	t.Run("with suppressed vulnerabilities", func(t *testing.T) {
+		// init
+		data := Result{Components: []Component{{Vulns: []Vulnerability{nonSevere1, excluded}}}}
+		triage := &reporting.Triage{Suppressions: []reporting.Suppression{{ID: "Cve5", Justification: "not reachable"}}}
+		// test && assert
+		assert.False(t, HasSevereVulnerabilities(data, "", triage))
+	})
+	t.Run("with vulnerabilities suppressed for a component", func(t *testing.T) {
+		// init
+		data := Result{Components: []Component{
+			{Lib: "openssl", Version: "1.1.1k", Vulns: []Vulnerability{excluded}},
+			{Lib: "curl", Version: "7.79.1", Vulns: []Vulnerability{nonSevere1}},
+		}}
+		triage := &reporting.Triage{Suppressions: []reporting.Suppression{{ID: "Cve5", Component: "openssl", Justification: "not reachable"}}}
+		otherTriage := &reporting.Triage{Suppressions: []reporting.Suppression{{ID: "Cve5", Component: "curl", Justification: "not reachable"}}}
+		// test && assert
+		assert.False(t, HasSevereVulnerabilities(data, "", triage))
+		assert.True(t, HasSevereVulnerabilities(data, "", otherTriage))
 	})
 	t.Run("with historic vulnerabilities", func(t *testing.T) {
 		// init
 		data := Result{Components: []Component{{Vulns: []Vulnerability{nonSevere1, historic}}}}
 		// test && assert
-		assert.False(t, HasSevereVulnerabilities(data, ""))
+		assert.False(t, HasSevereVulnerabilities(data, "", nil))
 	})
 }
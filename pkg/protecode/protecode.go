@@ -45,7 +45,17 @@ type Result struct {
 
 //Component the protecode component information
 type Component struct {
-	Vulns []Vulnerability `json:"vulns,omitempty"`
+	Lib     string          `json:"lib,omitempty"`
+	Version string          `json:"version,omitempty"`
+	Vulns   []Vulnerability `json:"vulns,omitempty"`
+}
+
+//Name returns the name of the component including its version if known, e.g. lodash@4.17.20
+func (c *Component) Name() string {
+	if len(c.Version) == 0 {
+		return c.Lib
+	}
+	return c.Lib + "@" + c.Version
 }
 
 //Vulnerability the protecode vulnerability information
@@ -60,6 +70,8 @@ type Vuln struct {
 	Cve        string  `json:"cve,omitempty"`
 	Cvss       float64 `json:"cvss,omitempty"`
 	Cvss3Score string  `json:"cvss3_score,omitempty"`
+	// Component is not part of the vulnerability data of Protecode but is filled with the name of the affected component
+	Component string `json:"component,omitempty"`
 }
 
 //Triage holds the triaging information
@@ -195,6 +207,13 @@ func (pc *Protecode) sendAPIRequest(method string, url string, headers map[strin
 	return &r.Body, r.StatusCode, nil
 }
 
+// componentVuln returns the vulnerability data of Protecode enriched with the name of the affected component
+func componentVuln(component Component, vulnerability Vulnerability) Vuln {
+	vuln := vulnerability.Vuln
+	vuln.Component = component.Name()
+	return vuln
+}
+
 // ParseResultForInflux parses the result from the scan into the internal format
 func (pc *Protecode) ParseResultForInflux(result Result, excludeCVEs string) (map[string]int, []Vuln) {
 
@@ -228,7 +247,7 @@ func (pc *Protecode) ParseResultForInflux(result Result, excludeCVEs string) (ma
 				m["vulnerabilities"]++
 
 				//collect all vulns here
-				vulns = append(vulns, vulnerability.Vuln)
+				vulns = append(vulns, componentVuln(components, vulnerability))
 			}
 			if countVulnerability && isSevereCVSS3(vulnerability) {
 				m["cvss3GreaterOrEqualSeven"]++
@@ -54,7 +54,7 @@ func TestParseResultSuccess(t *testing.T) {
 				{Exact: true, Triage: []Triage{}, Vuln: Vuln{Cve: "Cve2b", Cvss: 0.0, Cvss3Score: "0.0"}},
 			},
 			},
-			{Vulns: []Vulnerability{
+			{Lib: "openssl", Version: "1.1.1k", Vulns: []Vulnerability{
 				{Exact: true, Triage: []Triage{}, Vuln: Vuln{Cve: "Cve3", Cvss: 3.2, Cvss3Score: "7.3"}},
 				{Exact: true, Triage: []Triage{}, Vuln: Vuln{Cve: "Cve4", Cvss: 8.0, Cvss3Score: "8.0"}},
 				{Exact: false, Triage: []Triage{}, Vuln: Vuln{Cve: "Cve4b", Cvss: 8.0, Cvss3Score: "8.0"}},
@@ -73,6 +73,7 @@ func TestParseResultSuccess(t *testing.T) {
 		assert.Equal(t, 3, m["vulnerabilities"])
 
 		assert.Equal(t, 3, len(vulns))
+		assert.Equal(t, "openssl@1.1.1k", vulns[2].Component)
 	})
 }
 
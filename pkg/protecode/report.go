@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -59,7 +60,8 @@ func writeJSON(path, name string, data interface{}, writeToFile func(f string, d
 
 func CreateCustomReport(productName string, productID int, data map[string]int, vulns []Vuln) reporting.ScanReport {
 	scanReport := reporting.ScanReport{
-		Title: "Protecode Vulnerability Report",
+		StepName: "protecodeExecuteScan",
+		Title:    "Protecode Vulnerability Report",
 		Subheaders: []reporting.Subheader{
 			{Description: "Product name", Details: productName},
 			{Description: "Product ID", Details: fmt.Sprint(productID)},
@@ -92,12 +94,34 @@ func CreateCustomReport(productName string, productID int, data map[string]int,
 		row.AddColumn(fmt.Sprint(*&vuln.Cvss3Score), 0)
 
 		detailTable.Rows = append(detailTable.Rows, row)
+		scanReport.AddFinding(vulnFinding(vuln))
 	}
 	scanReport.DetailTable = detailTable
 
 	return scanReport
 }
 
+// vulnFinding converts a vulnerability into a tool independent finding, the CVSS v3 score is preferred if available.
+// The location is left empty since Protecode does not report files, the finding is attributed to its component instead.
+func vulnFinding(vuln Vuln) reporting.Finding {
+	score, _ := strconv.ParseFloat(vuln.Cvss3Score, 64)
+	if score <= 0 {
+		score = vuln.Cvss
+	}
+	finding := reporting.Finding{
+		RuleID:    vuln.Cve,
+		Title:     vuln.Cve,
+		Severity:  reporting.SeverityFromScore(score),
+		Category:  reporting.CategoryVulnerability,
+		Score:     score,
+		Component: vuln.Component,
+	}
+	if strings.HasPrefix(vuln.Cve, "CVE-") {
+		finding.URL = "https://nvd.nist.gov/vuln/detail/" + vuln.Cve
+	}
+	return finding
+}
+
 func WriteCustomReports(scanReport reporting.ScanReport, projectName, projectID string) ([]piperutils.Path, error) {
 	utils := piperutils.Files{}
 	reportPaths := []piperutils.Path{}
@@ -25,7 +25,29 @@ func TestWriteReport(t *testing.T) {
 	parsedResult["cvss2GreaterOrEqualSeven"] = 4
 	parsedResult["vulnerabilities"] = 5
 
-	err := WriteReport(ReportData{ServerURL: "DUMMYURL", FailOnSevereVulnerabilities: false, ExcludeCVEs: "", Target: "REPORTFILENAME", ProductID: fmt.Sprintf("%v", 4711), Vulnerabilities: []Vuln{{"Vulnerability", 2.5, "5.5"}}}, ".", "", parsedResult, writeToFileMock)
+	err := WriteReport(ReportData{ServerURL: "DUMMYURL", FailOnSevereVulnerabilities: false, ExcludeCVEs: "", Target: "REPORTFILENAME", ProductID: fmt.Sprintf("%v", 4711), Vulnerabilities: []Vuln{{Cve: "Vulnerability", Cvss: 2.5, Cvss3Score: "5.5"}}}, ".", "", parsedResult, writeToFileMock)
 	assert.Equal(t, fileContent, expected, "content should be not empty")
 	assert.NoError(t, err)
 }
+
+func TestCreateCustomReport(t *testing.T) {
+	vulns := []Vuln{
+		{Cve: "CVE-2021-1234", Cvss: 5.0, Cvss3Score: "9.8", Component: "openssl@1.1.1k"},
+		{Cve: "Cve2", Cvss: 4.0, Cvss3Score: "0.0"},
+	}
+
+	scanReport := CreateCustomReport("image.tar", 1, map[string]int{}, vulns)
+
+	assert.Equal(t, 2, len(scanReport.DetailTable.Rows))
+	if assert.Equal(t, 2, len(scanReport.Findings)) {
+		assert.Equal(t, "CVE-2021-1234", scanReport.Findings[0].RuleID)
+		assert.Equal(t, 9.8, scanReport.Findings[0].Score)
+		assert.Equal(t, "critical", string(scanReport.Findings[0].Severity))
+		assert.Empty(t, scanReport.Findings[0].Location)
+		assert.Equal(t, "openssl@1.1.1k", scanReport.Findings[0].Component)
+		assert.Equal(t, "https://nvd.nist.gov/vuln/detail/CVE-2021-1234", scanReport.Findings[0].URL)
+		assert.Equal(t, 4.0, scanReport.Findings[1].Score)
+		assert.Equal(t, "medium", string(scanReport.Findings[1].Severity))
+		assert.Empty(t, scanReport.Findings[1].URL)
+	}
+}
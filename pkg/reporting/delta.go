@@ -0,0 +1,96 @@
+package reporting
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FindingsDelta separates the findings of a pull request scan into findings introduced by the pull request,
+// findings fixed by the pull request and findings which are already contained in the scan of the base branch
+type FindingsDelta struct {
+	// Base identifies the scan the findings have been compared with, e.g. the base branch of the pull request
+	Base      string    `json:"base,omitempty"`
+	New       []Finding `json:"new"`
+	Fixed     []Finding `json:"fixed"`
+	Unchanged []Finding `json:"unchanged"`
+}
+
+// CompareFindings compares the findings of the current scan with the findings of the base scan.
+// Findings are matched via their fingerprint. Since changes of a pull request usually shift source code lines,
+// findings without identical fingerprint are matched by rule, location and component ignoring the line.
+func CompareFindings(base, current []Finding) FindingsDelta {
+	delta := FindingsDelta{New: []Finding{}, Fixed: []Finding{}, Unchanged: []Finding{}}
+
+	matchedBase := make([]bool, len(base))
+	matchedCurrent := make([]bool, len(current))
+	for _, key := range []func(Finding) string{fingerprintKey, locationKey} {
+		unmatched := map[string][]int{}
+		for i, finding := range base {
+			if !matchedBase[i] {
+				unmatched[key(finding)] = append(unmatched[key(finding)], i)
+			}
+		}
+		for i, finding := range current {
+			if matchedCurrent[i] {
+				continue
+			}
+			candidates := unmatched[key(finding)]
+			if len(candidates) == 0 {
+				continue
+			}
+			matchedBase[candidates[0]] = true
+			matchedCurrent[i] = true
+			unmatched[key(finding)] = candidates[1:]
+		}
+	}
+
+	for i, finding := range current {
+		if matchedCurrent[i] {
+			delta.Unchanged = append(delta.Unchanged, finding)
+		} else {
+			delta.New = append(delta.New, finding)
+		}
+	}
+	for i, finding := range base {
+		if !matchedBase[i] {
+			delta.Fixed = append(delta.Fixed, finding)
+		}
+	}
+	return delta
+}
+
+// ActiveNew returns the new findings which are not suppressed
+func (d *FindingsDelta) ActiveNew() []Finding {
+	active := []Finding{}
+	for _, finding := range d.New {
+		if !finding.Suppressed {
+			active = append(active, finding)
+		}
+	}
+	return active
+}
+
+// AddDelta adds the result of the comparison with the base scan to the report
+func (s *ScanReport) AddDelta(delta FindingsDelta) {
+	s.Delta = &delta
+	if len(delta.Base) > 0 {
+		s.AddSubHeader("Compared with", delta.Base)
+	}
+	newStyle := ColumnStyle(Green)
+	if len(delta.ActiveNew()) > 0 {
+		newStyle = Red
+	}
+	s.Overview = append(s.Overview,
+		OverviewRow{Description: "New findings", Details: fmt.Sprint(len(delta.New)), Style: newStyle},
+		OverviewRow{Description: "Fixed findings", Details: fmt.Sprint(len(delta.Fixed)), Style: Green},
+		OverviewRow{Description: "Unchanged findings", Details: fmt.Sprint(len(delta.Unchanged))},
+	)
+}
+
+func fingerprintKey(finding Finding) string {
+	return finding.Fingerprint()
+}
+
+func locationKey(finding Finding) string {
+	return strings.Join([]string{finding.RuleID, finding.Location, finding.Component}, "|")
+}
@@ -0,0 +1,71 @@
+package reporting
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareFindings(t *testing.T) {
+	base := []Finding{
+		{RuleID: "SQL_Injection", Location: "src/main/java/Dao.java", Line: 42},
+		{RuleID: "Reflected_XSS", Location: "src/main/js/app.js", Line: 12},
+		{RuleID: "Reflected_XSS", Location: "src/main/js/app.js", Line: 30},
+		{RuleID: "CVE-2021-23337", Component: "pkg:npm/lodash@4.17.20"},
+	}
+
+	t.Run("new, fixed and unchanged findings", func(t *testing.T) {
+		current := []Finding{
+			// shifted by the pull request
+			{RuleID: "SQL_Injection", Location: "src/main/java/Dao.java", Line: 45},
+			{RuleID: "Reflected_XSS", Location: "src/main/js/app.js", Line: 30},
+			{RuleID: "Reflected_XSS", Location: "src/main/js/app.js", Line: 50},
+			{RuleID: "Reflected_XSS", Location: "src/main/js/app.js", Line: 51},
+			{RuleID: "CVE-2021-23337", Component: "pkg:npm/lodash@4.17.21"},
+		}
+
+		delta := CompareFindings(base, current)
+
+		assert.Equal(t, []Finding{current[0], current[1], current[2]}, delta.Unchanged)
+		assert.Equal(t, []Finding{current[3], current[4]}, delta.New)
+		assert.Equal(t, []Finding{base[3]}, delta.Fixed)
+	})
+
+	t.Run("no base scan", func(t *testing.T) {
+		delta := CompareFindings(nil, base)
+		assert.Equal(t, base, delta.New)
+		assert.Empty(t, delta.Fixed)
+		assert.Empty(t, delta.Unchanged)
+	})
+}
+
+func TestAddDelta(t *testing.T) {
+	t.Run("new findings", func(t *testing.T) {
+		report := ScanReport{}
+		delta := FindingsDelta{
+			Base:      "main",
+			New:       []Finding{{RuleID: "Reflected_XSS", Suppressed: true}, {RuleID: "SQL_Injection"}},
+			Fixed:     []Finding{},
+			Unchanged: []Finding{{RuleID: "Path_Traversal"}},
+		}
+
+		report.AddDelta(delta)
+
+		assert.Equal(t, &delta, report.Delta)
+		assert.Equal(t, []Subheader{{Description: "Compared with", Details: "main"}}, report.Subheaders)
+		assert.Equal(t, []OverviewRow{
+			{Description: "New findings", Details: "2", Style: Red},
+			{Description: "Fixed findings", Details: "0", Style: Green},
+			{Description: "Unchanged findings", Details: "1"},
+		}, report.Overview)
+		assert.Equal(t, []Finding{{RuleID: "SQL_Injection"}}, delta.ActiveNew())
+	})
+
+	t.Run("only suppressed new findings", func(t *testing.T) {
+		report := ScanReport{}
+		report.AddDelta(FindingsDelta{New: []Finding{{RuleID: "Reflected_XSS", Suppressed: true}}})
+
+		assert.Empty(t, report.Subheaders)
+		assert.Equal(t, OverviewRow{Description: "New findings", Details: "1", Style: Green}, report.Overview[0])
+	})
+}
@@ -0,0 +1,105 @@
+package reporting
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"strings"
+)
+
+// Severity defines the tool independent severity of a finding
+type Severity string
+
+// supported severities
+const (
+	SeverityCritical Severity = "critical"
+	SeverityHigh     Severity = "high"
+	SeverityMedium   Severity = "medium"
+	SeverityLow      Severity = "low"
+	SeverityInfo     Severity = "info"
+)
+
+// ParseSeverity maps the severity labels of the different scan tools to a Severity
+func ParseSeverity(severity string) Severity {
+	switch strings.ToLower(strings.TrimSpace(severity)) {
+	case "critical", "blocker":
+		return SeverityCritical
+	case "high", "major":
+		return SeverityHigh
+	case "medium", "moderate":
+		return SeverityMedium
+	case "low", "minor":
+		return SeverityLow
+	}
+	return SeverityInfo
+}
+
+// Category defines the kind of scan which produced a finding
+type Category string
+
+// supported categories
+const (
+	CategorySAST          Category = "sast"
+	CategoryVulnerability Category = "vulnerability"
+	CategoryLicense       Category = "license"
+)
+
+// SeverityFromScore maps a CVSS score to a Severity according to the CVSS v3 qualitative rating scale
+func SeverityFromScore(score float64) Severity {
+	switch {
+	case score >= 9.0:
+		return SeverityCritical
+	case score >= 7.0:
+		return SeverityHigh
+	case score >= 4.0:
+		return SeverityMedium
+	case score > 0:
+		return SeverityLow
+	}
+	return SeverityInfo
+}
+
+// Finding defines a single finding of a scan in a tool independent way, it is e.g. used for SARIF and JUnit output
+type Finding struct {
+	// RuleID identifies the kind of finding, e.g. the query name of a SAST tool or the CVE of a vulnerability
+	RuleID string `json:"ruleId"`
+	// Aliases contains further identifiers of the finding like the CWE of a query or the GHSA of a CVE
+	Aliases     []string `json:"aliases,omitempty"`
+	Title       string   `json:"title"`
+	Description string   `json:"description,omitempty"`
+	Severity    Severity `json:"severity"`
+	Category    Category `json:"category,omitempty"`
+	Score       float64  `json:"score,omitempty"`
+	// Location contains the affected file, Line is only available for source code findings
+	Location string `json:"location,omitempty"`
+	Line     int    `json:"line,omitempty"`
+	// Component contains the affected library/package in case of open source findings
+	Component  string `json:"component,omitempty"`
+	URL        string `json:"url,omitempty"`
+	Suppressed bool   `json:"suppressed,omitempty"`
+	// Count contains the number of issues represented by an aggregated finding, e.g. a Fortify issue group, zero means one
+	Count int `json:"count,omitempty"`
+}
+
+// Occurrences returns the number of issues represented by the finding
+func (f *Finding) Occurrences() int {
+	if f.Count > 0 {
+		return f.Count
+	}
+	return 1
+}
+
+// Fingerprint returns an identifier of the finding which is stable across scans
+func (f *Finding) Fingerprint() string {
+	data := strings.Join([]string{f.RuleID, f.Location, fmt.Sprint(f.Line), f.Component}, "|")
+	return fmt.Sprintf("%x", sha1.Sum([]byte(data)))
+}
+
+// ids returns the rule id and the aliases of the finding
+func (f *Finding) ids() []string {
+	return append([]string{f.RuleID}, f.Aliases...)
+}
+
+// AddFinding adds a finding to the report
+func (s *ScanReport) AddFinding(finding Finding) {
+	s.Findings = append(s.Findings, finding)
+}
@@ -0,0 +1,45 @@
+package reporting
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSeverity(t *testing.T) {
+	tt := []struct {
+		severity string
+		expected Severity
+	}{
+		{severity: "CRITICAL", expected: SeverityCritical},
+		{severity: "High", expected: SeverityHigh},
+		{severity: "major", expected: SeverityHigh},
+		{severity: "Medium", expected: SeverityMedium},
+		{severity: "low", expected: SeverityLow},
+		{severity: "Information", expected: SeverityInfo},
+		{severity: "", expected: SeverityInfo},
+	}
+	for _, test := range tt {
+		assert.Equal(t, test.expected, ParseSeverity(test.severity), test.severity)
+	}
+}
+
+func TestSeverityFromScore(t *testing.T) {
+	assert.Equal(t, SeverityCritical, SeverityFromScore(9.8))
+	assert.Equal(t, SeverityHigh, SeverityFromScore(7.0))
+	assert.Equal(t, SeverityMedium, SeverityFromScore(5.3))
+	assert.Equal(t, SeverityLow, SeverityFromScore(0.1))
+	assert.Equal(t, SeverityInfo, SeverityFromScore(0))
+}
+
+func TestFingerprint(t *testing.T) {
+	finding := Finding{RuleID: "CVE-2021-1234", Component: "lodash:4.17.20", Title: "Prototype pollution"}
+	fingerprint := finding.Fingerprint()
+
+	finding.Title = "changed title"
+	finding.Score = 7.5
+	assert.Equal(t, fingerprint, finding.Fingerprint(), "fingerprint must not depend on descriptive fields")
+
+	finding.Component = "lodash:4.17.21"
+	assert.NotEqual(t, fingerprint, finding.Fingerprint())
+}
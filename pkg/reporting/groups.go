@@ -0,0 +1,119 @@
+package reporting
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// FindingGroup combines the findings of one rule in one component, e.g. all locations of a SAST query or
+// all occurrences of a vulnerability in a library. It is used to report results which belong together only once.
+type FindingGroup struct {
+	RuleID    string
+	Component string
+	Title     string
+	// Severity is the highest severity of the findings of the group
+	Severity Severity
+	Findings []Finding
+}
+
+var severityRanks = map[Severity]int{
+	SeverityCritical: 4,
+	SeverityHigh:     3,
+	SeverityMedium:   2,
+	SeverityLow:      1,
+}
+
+// FindingGroups groups the findings of the report which are not suppressed by rule and component.
+// The groups are sorted by severity, groups of the same severity by rule and component.
+func (s *ScanReport) FindingGroups() []FindingGroup {
+	groups := []FindingGroup{}
+	index := map[string]int{}
+	for _, finding := range s.Findings {
+		if finding.Suppressed {
+			continue
+		}
+		key := strings.Join([]string{finding.RuleID, finding.Component}, "|")
+		i, ok := index[key]
+		if !ok {
+			i = len(groups)
+			index[key] = i
+			groups = append(groups, FindingGroup{RuleID: finding.RuleID, Component: finding.Component, Title: finding.Title, Severity: finding.Severity})
+		}
+		if severityRanks[finding.Severity] > severityRanks[groups[i].Severity] {
+			groups[i].Severity = finding.Severity
+		}
+		groups[i].Findings = append(groups[i].Findings, finding)
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		if severityRanks[groups[i].Severity] != severityRanks[groups[j].Severity] {
+			return severityRanks[groups[i].Severity] > severityRanks[groups[j].Severity]
+		}
+		if groups[i].RuleID != groups[j].RuleID {
+			return groups[i].RuleID < groups[j].RuleID
+		}
+		return groups[i].Component < groups[j].Component
+	})
+	return groups
+}
+
+// Fingerprint returns an identifier of the group which is stable across scans
+func (g *FindingGroup) Fingerprint() string {
+	data := strings.Join([]string{g.RuleID, g.Component}, "|")
+	return fmt.Sprintf("%x", sha1.Sum([]byte(data)))
+}
+
+// IssueTitle returns a short title of the group, e.g. to be used for a ticket
+func (g *FindingGroup) IssueTitle() string {
+	title := g.Title
+	if len(title) == 0 {
+		title = g.RuleID
+	}
+	if len(g.Component) > 0 {
+		title = fmt.Sprintf("%v in %v", title, g.Component)
+	}
+	return fmt.Sprintf("[%v] %v", strings.ToUpper(string(g.Severity)), title)
+}
+
+// maxGroupMarkdownFindings limits the findings listed by FindingGroup.ToMarkdown to keep the description readable
+const maxGroupMarkdownFindings = 100
+
+// ToMarkdown describes the group and lists its findings, at most maxGroupMarkdownFindings are listed
+func (g *FindingGroup) ToMarkdown(stepName string) []byte {
+	var md strings.Builder
+	fmt.Fprintf(&md, "## %v\n\n", g.IssueTitle())
+	fmt.Fprintf(&md, "| | |\n| --- | --- |\n")
+	fmt.Fprintf(&md, "| Reported by | %v |\n", stepName)
+	fmt.Fprintf(&md, "| Rule | %v |\n", g.RuleID)
+	if len(g.Component) > 0 {
+		fmt.Fprintf(&md, "| Component | %v |\n", g.Component)
+	}
+	fmt.Fprintf(&md, "| Severity | %v |\n", g.Severity)
+	fmt.Fprintf(&md, "| Findings | %v |\n", len(g.Findings))
+	if description := g.Findings[0].Description; len(description) > 0 {
+		fmt.Fprintf(&md, "\n%v\n", description)
+	}
+
+	fmt.Fprintf(&md, "\n### Findings\n\n| Severity | Location | Details |\n| --- | --- | --- |\n")
+	for i, finding := range g.Findings {
+		if i == maxGroupMarkdownFindings {
+			fmt.Fprintf(&md, "\n%v more findings are not listed.\n", len(g.Findings)-maxGroupMarkdownFindings)
+			break
+		}
+		location := finding.Location
+		if finding.Line > 0 {
+			location = fmt.Sprintf("%v:%v", location, finding.Line)
+		}
+		details := finding.Title
+		if len(finding.URL) > 0 {
+			details = fmt.Sprintf("[%v](%v)", details, finding.URL)
+		}
+		fmt.Fprintf(&md, "| %v | %v | %v |\n", finding.Severity, markdownCell(location), markdownCell(details))
+	}
+	return []byte(md.String())
+}
+
+func markdownCell(content string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(content, "|", "\\|"), "\n", " ")
+}
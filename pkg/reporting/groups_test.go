@@ -0,0 +1,71 @@
+package reporting
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindingGroups(t *testing.T) {
+	report := ScanReport{Findings: []Finding{
+		{RuleID: "XSS", Title: "Reflected XSS", Severity: SeverityMedium, Location: "index.js", Line: 3},
+		{RuleID: "CVE-2021-1", Title: "CVE-2021-1", Severity: SeverityMedium, Component: "lodash:4.17.0"},
+		{RuleID: "XSS", Title: "Reflected XSS", Severity: SeverityHigh, Location: "search.js", Line: 8},
+		{RuleID: "CVE-2021-1", Title: "CVE-2021-1", Severity: SeverityMedium, Component: "lodash:4.17.1"},
+		{RuleID: "SQL_Injection", Title: "SQL Injection", Severity: SeverityHigh, Location: "Dao.java", Suppressed: true},
+	}}
+
+	groups := report.FindingGroups()
+
+	if assert.Len(t, groups, 3) {
+		assert.Equal(t, "XSS", groups[0].RuleID)
+		assert.Equal(t, SeverityHigh, groups[0].Severity)
+		assert.Len(t, groups[0].Findings, 2)
+		assert.Equal(t, "lodash:4.17.0", groups[1].Component)
+		assert.Equal(t, "lodash:4.17.1", groups[2].Component)
+		assert.NotEqual(t, groups[1].Fingerprint(), groups[2].Fingerprint())
+	}
+	assert.Empty(t, (&ScanReport{}).FindingGroups())
+}
+
+func TestFindingGroupFingerprint(t *testing.T) {
+	group := FindingGroup{RuleID: "XSS", Findings: []Finding{{RuleID: "XSS", Line: 3}}}
+	moved := FindingGroup{RuleID: "XSS", Findings: []Finding{{RuleID: "XSS", Line: 5}, {RuleID: "XSS", Line: 9}}}
+
+	assert.Equal(t, group.Fingerprint(), moved.Fingerprint())
+	assert.NotEqual(t, group.Fingerprint(), (&FindingGroup{RuleID: "XSS", Component: "app"}).Fingerprint())
+}
+
+func TestFindingGroupToMarkdown(t *testing.T) {
+	group := FindingGroup{RuleID: "CVE-2021-1", Title: "Prototype pollution", Component: "lodash:4.17.0", Severity: SeverityCritical, Findings: []Finding{
+		{RuleID: "CVE-2021-1", Title: "Prototype pollution", Description: "Upgrade to 4.17.21", Severity: SeverityCritical, Location: "package.json", URL: "https://nvd/CVE-2021-1"},
+		{RuleID: "CVE-2021-1", Title: "a|b", Severity: SeverityHigh, Location: "ui/package.json", Line: 4},
+	}}
+
+	assert.Equal(t, "[CRITICAL] Prototype pollution in lodash:4.17.0", group.IssueTitle())
+	assert.Equal(t, "[LOW] XSS", (&FindingGroup{RuleID: "XSS", Severity: SeverityLow}).IssueTitle())
+
+	markdown := string(group.ToMarkdown("whitesourceExecuteScan"))
+
+	assert.Contains(t, markdown, "## [CRITICAL] Prototype pollution in lodash:4.17.0\n")
+	assert.Contains(t, markdown, "| Reported by | whitesourceExecuteScan |\n")
+	assert.Contains(t, markdown, "| Findings | 2 |\n")
+	assert.Contains(t, markdown, "\nUpgrade to 4.17.21\n")
+	assert.Contains(t, markdown, "| critical | package.json | [Prototype pollution](https://nvd/CVE-2021-1) |\n")
+	assert.Contains(t, markdown, "| high | ui/package.json:4 | a\\|b |\n")
+}
+
+func TestFindingGroupToMarkdownLimit(t *testing.T) {
+	group := FindingGroup{RuleID: "XSS", Severity: SeverityHigh}
+	for i := 0; i < maxGroupMarkdownFindings+5; i++ {
+		group.Findings = append(group.Findings, Finding{RuleID: "XSS", Title: "XSS", Severity: SeverityHigh, Location: fmt.Sprintf("file%v.js", i)})
+	}
+
+	markdown := string(group.ToMarkdown("checkmarxExecuteScan"))
+
+	assert.Contains(t, markdown, "| Findings | 105 |\n")
+	assert.Contains(t, markdown, "| high | file99.js | XSS |\n")
+	assert.NotContains(t, markdown, "file100.js")
+	assert.Contains(t, markdown, "\n5 more findings are not listed.\n")
+}
@@ -0,0 +1,127 @@
+package reporting
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// JUnitTestSuites defines the root element of a JUnit XML report
+type JUnitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Suites   []JUnitTestSuite `xml:"testsuite"`
+}
+
+// JUnitTestSuite defines a test suite, one per scan report
+type JUnitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Timestamp string          `xml:"timestamp,attr,omitempty"`
+	TestCases []JUnitTestCase `xml:"testcase"`
+}
+
+// JUnitTestCase defines a test case, one per finding
+type JUnitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *JUnitFailure `xml:"failure,omitempty"`
+	Skipped   *JUnitSkipped `xml:"skipped,omitempty"`
+}
+
+// JUnitFailure defines why a test case failed
+type JUnitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Content string `xml:",chardata"`
+}
+
+// JUnitSkipped defines why a test case has been skipped
+type JUnitSkipped struct {
+	Message string `xml:"message,attr"`
+}
+
+// ToJUnitXML returns the findings of the report as JUnit XML, each finding is represented as failed test case.
+// Suppressed findings are reported as skipped test cases, a scan without findings results in one successful test case.
+func (s *ScanReport) ToJUnitXML() ([]byte, error) {
+	suite := JUnitTestSuite{
+		Name:      s.Title,
+		TestCases: []JUnitTestCase{},
+	}
+	if !s.ReportTime.IsZero() {
+		suite.Timestamp = s.ReportTime.Format("2006-01-02T15:04:05")
+	}
+
+	className := s.StepName
+	if len(className) == 0 {
+		className = s.Title
+	}
+	for _, finding := range s.Findings {
+		testCase := JUnitTestCase{Name: junitTestCaseName(finding), ClassName: className}
+		if finding.Suppressed {
+			testCase.Skipped = &JUnitSkipped{Message: "finding has been suppressed"}
+			suite.Skipped++
+		} else {
+			testCase.Failure = &JUnitFailure{
+				Message: finding.Title,
+				Type:    string(finding.Severity),
+				Content: junitFailureContent(finding),
+			}
+			suite.Failures++
+		}
+		suite.TestCases = append(suite.TestCases, testCase)
+	}
+	if len(suite.TestCases) == 0 {
+		suite.TestCases = append(suite.TestCases, JUnitTestCase{Name: "no findings", ClassName: className})
+	}
+	suite.Tests = len(suite.TestCases)
+
+	suites := JUnitTestSuites{
+		Name:     s.Title,
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Skipped:  suite.Skipped,
+		Suites:   []JUnitTestSuite{suite},
+	}
+	report, err := xml.MarshalIndent(suites, "", "  ")
+	if err != nil {
+		return []byte{}, errors.Wrap(err, "failed to create JUnit XML report")
+	}
+	return append([]byte(xml.Header), report...), nil
+}
+
+func junitTestCaseName(finding Finding) string {
+	parts := []string{finding.RuleID}
+	if len(finding.Component) > 0 {
+		parts = append(parts, finding.Component)
+	}
+	if len(finding.Location) > 0 {
+		location := finding.Location
+		if finding.Line > 0 {
+			location = fmt.Sprintf("%v:%v", location, finding.Line)
+		}
+		parts = append(parts, location)
+	}
+	return strings.Join(parts, " - ")
+}
+
+func junitFailureContent(finding Finding) string {
+	lines := []string{fmt.Sprintf("Severity: %v", finding.Severity)}
+	if finding.Score > 0 {
+		lines = append(lines, fmt.Sprintf("Score: %v", finding.Score))
+	}
+	if len(finding.Description) > 0 {
+		lines = append(lines, finding.Description)
+	}
+	if len(finding.URL) > 0 {
+		lines = append(lines, finding.URL)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,48 @@
+package reporting
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToJUnitXML(t *testing.T) {
+	t.Run("with findings", func(t *testing.T) {
+		report := ScanReport{
+			StepName:   "protecodeExecuteScan",
+			Title:      "Protecode Vulnerability Report",
+			ReportTime: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			Findings: []Finding{
+				{RuleID: "CVE-2021-1", Title: "CVE-2021-1", Severity: SeverityHigh, Score: 7.5, Component: "openssl:1.1.1", Description: "buffer overflow"},
+				{RuleID: "CVE-2021-2", Title: "CVE-2021-2", Severity: SeverityLow, Location: "pom.xml", Suppressed: true},
+			},
+		}
+
+		res, err := report.ToJUnitXML()
+		require.NoError(t, err)
+
+		assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites name="Protecode Vulnerability Report" tests="2" failures="1" skipped="1">
+  <testsuite name="Protecode Vulnerability Report" tests="2" failures="1" skipped="1" timestamp="2021-01-01T00:00:00">
+    <testcase name="CVE-2021-1 - openssl:1.1.1" classname="protecodeExecuteScan">
+      <failure message="CVE-2021-1" type="high">Severity: high&#xA;Score: 7.5&#xA;buffer overflow</failure>
+    </testcase>
+    <testcase name="CVE-2021-2 - pom.xml" classname="protecodeExecuteScan">
+      <skipped message="finding has been suppressed"></skipped>
+    </testcase>
+  </testsuite>
+</testsuites>`, string(res))
+	})
+
+	t.Run("without findings", func(t *testing.T) {
+		report := ScanReport{Title: "Fortify SAST Report"}
+
+		res, err := report.ToJUnitXML()
+		require.NoError(t, err)
+
+		assert.Contains(t, string(res), `<testsuite name="Fortify SAST Report" tests="1" failures="0" skipped="0">`)
+		assert.Contains(t, string(res), `<testcase name="no findings" classname="Fortify SAST Report"></testcase>`)
+	})
+}
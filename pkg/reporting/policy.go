@@ -0,0 +1,171 @@
+package reporting
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// QualityGatePolicy defines the rules which the findings of all scan reports need to comply with
+type QualityGatePolicy struct {
+	Rules []QualityGateRule `json:"rules"`
+}
+
+// QualityGateRule limits the number of findings which match the rule.
+// Empty selectors match all findings, suppressed findings are never counted.
+type QualityGateRule struct {
+	Name string `json:"name"`
+	// StepName restricts the rule to the reports of a step, e.g. checkmarxExecuteScan
+	StepName   string     `json:"stepName,omitempty"`
+	Category   Category   `json:"category,omitempty"`
+	Severities []Severity `json:"severities,omitempty"`
+	// MaxFindings defines how many matching findings are tolerated, default is 0
+	MaxFindings int `json:"maxFindings"`
+}
+
+// QualityGateVerdict contains the result of evaluating a QualityGatePolicy
+type QualityGateVerdict struct {
+	Passed bool                    `json:"passed"`
+	Rules  []QualityGateRuleResult `json:"rules"`
+}
+
+// QualityGateRuleResult contains the result of evaluating a single QualityGateRule
+type QualityGateRuleResult struct {
+	Rule     QualityGateRule `json:"rule"`
+	Findings int             `json:"findings"`
+	Passed   bool            `json:"passed"`
+}
+
+// ParseQualityGatePolicy creates a policy from the step configuration and validates its rules
+func ParseQualityGatePolicy(config map[string]interface{}) (QualityGatePolicy, error) {
+	policy := QualityGatePolicy{}
+	content, err := json.Marshal(config)
+	if err != nil {
+		return policy, errors.Wrap(err, "failed to read quality gate policy")
+	}
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&policy); err != nil {
+		return policy, errors.Wrap(err, "failed to parse quality gate policy")
+	}
+
+	names := map[string]bool{}
+	for i := range policy.Rules {
+		rule := &policy.Rules[i]
+		if len(rule.Name) == 0 {
+			return policy, fmt.Errorf("quality gate rule #%v has no name", i+1)
+		}
+		if names[rule.Name] {
+			return policy, fmt.Errorf("quality gate rule '%v' is defined more than once", rule.Name)
+		}
+		names[rule.Name] = true
+		if rule.MaxFindings < 0 {
+			return policy, fmt.Errorf("quality gate rule '%v': maxFindings must not be negative", rule.Name)
+		}
+		rule.Category = Category(strings.ToLower(string(rule.Category)))
+		switch rule.Category {
+		case "", CategorySAST, CategoryVulnerability, CategoryLicense:
+		default:
+			return policy, fmt.Errorf("quality gate rule '%v': unknown category '%v'", rule.Name, rule.Category)
+		}
+		for j, severity := range rule.Severities {
+			severity = Severity(strings.ToLower(string(severity)))
+			rule.Severities[j] = severity
+			switch severity {
+			case SeverityCritical, SeverityHigh, SeverityMedium, SeverityLow, SeverityInfo:
+			default:
+				return policy, fmt.Errorf("quality gate rule '%v': unknown severity '%v'", rule.Name, severity)
+			}
+		}
+	}
+	return policy, nil
+}
+
+// Evaluate counts the findings of the reports matching each rule of the policy
+func (p *QualityGatePolicy) Evaluate(reports []ScanReport) QualityGateVerdict {
+	verdict := QualityGateVerdict{Passed: true, Rules: []QualityGateRuleResult{}}
+	for _, rule := range p.Rules {
+		result := QualityGateRuleResult{Rule: rule}
+		for _, report := range reports {
+			if len(rule.StepName) > 0 && rule.StepName != report.StepName {
+				continue
+			}
+			for _, finding := range report.Findings {
+				if rule.matches(finding) {
+					result.Findings += finding.Occurrences()
+				}
+			}
+		}
+		result.Passed = result.Findings <= rule.MaxFindings
+		if !result.Passed {
+			verdict.Passed = false
+		}
+		verdict.Rules = append(verdict.Rules, result)
+	}
+	return verdict
+}
+
+func (r *QualityGateRule) matches(finding Finding) bool {
+	if finding.Suppressed {
+		return false
+	}
+	if len(r.Category) > 0 && r.Category != finding.Category {
+		return false
+	}
+	if len(r.Severities) == 0 {
+		return true
+	}
+	for _, severity := range r.Severities {
+		if severity == finding.Severity {
+			return true
+		}
+	}
+	return false
+}
+
+// ViolatedRules returns the names of all rules which have not been passed
+func (v *QualityGateVerdict) ViolatedRules() []string {
+	violated := []string{}
+	for _, result := range v.Rules {
+		if !result.Passed {
+			violated = append(violated, result.Rule.Name)
+		}
+	}
+	return violated
+}
+
+// Explanation describes for each violated rule which findings have been counted against which limit
+func (v *QualityGateVerdict) Explanation() string {
+	lines := []string{}
+	for _, result := range v.Rules {
+		if result.Passed {
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("rule '%v' violated: %v finding(s) of %v, at most %v allowed", result.Rule.Name, result.Findings, result.Rule.scope(), result.Rule.MaxFindings))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (r *QualityGateRule) scope() string {
+	scope := []string{}
+	if len(r.Severities) > 0 {
+		severities := []string{}
+		for _, severity := range r.Severities {
+			severities = append(severities, string(severity))
+		}
+		scope = append(scope, fmt.Sprintf("severity %v", strings.Join(severities, "/")))
+	}
+	if len(r.Category) > 0 {
+		scope = append(scope, fmt.Sprintf("category %v", r.Category))
+	}
+	if len(r.StepName) > 0 {
+		scope = append(scope, fmt.Sprintf("step %v", r.StepName))
+	}
+	if len(scope) == 0 {
+		return "all scans"
+	}
+	return strings.Join(scope, ", ")
+}
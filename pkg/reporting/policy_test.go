@@ -0,0 +1,125 @@
+package reporting
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseQualityGatePolicy(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		policy, err := ParseQualityGatePolicy(map[string]interface{}{
+			"rules": []interface{}{
+				map[string]interface{}{"name": "no high checkmarx findings", "stepName": "checkmarxExecuteScan", "severities": []interface{}{"High", "critical"}},
+				map[string]interface{}{"name": "few medium fortify findings", "stepName": "fortifyExecuteScan", "severities": []interface{}{"medium"}, "maxFindings": 5},
+				map[string]interface{}{"name": "no unapproved licenses", "category": "license"},
+			},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, QualityGatePolicy{Rules: []QualityGateRule{
+			{Name: "no high checkmarx findings", StepName: "checkmarxExecuteScan", Severities: []Severity{SeverityHigh, SeverityCritical}},
+			{Name: "few medium fortify findings", StepName: "fortifyExecuteScan", Severities: []Severity{SeverityMedium}, MaxFindings: 5},
+			{Name: "no unapproved licenses", Category: CategoryLicense},
+		}}, policy)
+	})
+
+	t.Run("empty policy", func(t *testing.T) {
+		policy, err := ParseQualityGatePolicy(map[string]interface{}{})
+		assert.NoError(t, err)
+		assert.Equal(t, 0, len(policy.Rules))
+	})
+
+	tt := []struct {
+		name     string
+		rule     map[string]interface{}
+		expected string
+	}{
+		{name: "unknown field", rule: map[string]interface{}{"name": "rule", "severity": "high"}, expected: "failed to parse quality gate policy"},
+		{name: "missing name", rule: map[string]interface{}{"maxFindings": 1}, expected: "quality gate rule #1 has no name"},
+		{name: "negative limit", rule: map[string]interface{}{"name": "rule", "maxFindings": -1}, expected: "quality gate rule 'rule': maxFindings must not be negative"},
+		{name: "unknown category", rule: map[string]interface{}{"name": "rule", "category": "dast"}, expected: "quality gate rule 'rule': unknown category 'dast'"},
+		{name: "unknown severity", rule: map[string]interface{}{"name": "rule", "severities": []interface{}{"urgent"}}, expected: "quality gate rule 'rule': unknown severity 'urgent'"},
+	}
+	for _, test := range tt {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ParseQualityGatePolicy(map[string]interface{}{"rules": []interface{}{test.rule}})
+			assert.Contains(t, err.Error(), test.expected)
+		})
+	}
+
+	t.Run("duplicate rule", func(t *testing.T) {
+		_, err := ParseQualityGatePolicy(map[string]interface{}{"rules": []interface{}{
+			map[string]interface{}{"name": "rule"},
+			map[string]interface{}{"name": "rule"},
+		}})
+		assert.EqualError(t, err, "quality gate rule 'rule' is defined more than once")
+	})
+}
+
+func TestQualityGatePolicyEvaluate(t *testing.T) {
+	reports := []ScanReport{
+		{StepName: "checkmarxExecuteScan", Findings: []Finding{
+			{RuleID: "SQL_Injection", Severity: SeverityHigh, Category: CategorySAST},
+			{RuleID: "XSS", Severity: SeverityHigh, Category: CategorySAST, Suppressed: true},
+			{RuleID: "Log_Forging", Severity: SeverityMedium, Category: CategorySAST},
+		}},
+		{StepName: "fortifyExecuteScan", Findings: []Finding{
+			{RuleID: "1", Severity: SeverityMedium, Category: CategorySAST},
+			{RuleID: "2", Severity: SeverityMedium, Category: CategorySAST},
+		}},
+		{StepName: "whitesourceExecuteScan", Findings: []Finding{
+			{RuleID: "REJECTED_BY_POLICY_RESOURCE", Severity: SeverityHigh, Category: CategoryLicense},
+		}},
+	}
+
+	t.Run("passed", func(t *testing.T) {
+		policy := QualityGatePolicy{Rules: []QualityGateRule{
+			{Name: "no critical findings", Severities: []Severity{SeverityCritical}},
+			{Name: "few medium fortify findings", StepName: "fortifyExecuteScan", Severities: []Severity{SeverityMedium}, MaxFindings: 5},
+		}}
+
+		verdict := policy.Evaluate(reports)
+
+		assert.True(t, verdict.Passed)
+		assert.Equal(t, []QualityGateRuleResult{
+			{Rule: policy.Rules[0], Findings: 0, Passed: true},
+			{Rule: policy.Rules[1], Findings: 2, Passed: true},
+		}, verdict.Rules)
+		assert.Equal(t, []string{}, verdict.ViolatedRules())
+		assert.Equal(t, "", verdict.Explanation())
+	})
+
+	t.Run("violated", func(t *testing.T) {
+		policy := QualityGatePolicy{Rules: []QualityGateRule{
+			{Name: "no high checkmarx findings", StepName: "checkmarxExecuteScan", Severities: []Severity{SeverityHigh}},
+			{Name: "few sast findings", Category: CategorySAST, MaxFindings: 3},
+			{Name: "no unapproved licenses", Category: CategoryLicense},
+		}}
+
+		verdict := policy.Evaluate(reports)
+
+		assert.False(t, verdict.Passed)
+		assert.Equal(t, 1, verdict.Rules[0].Findings)
+		assert.Equal(t, 4, verdict.Rules[1].Findings)
+		assert.Equal(t, 1, verdict.Rules[2].Findings)
+		assert.Equal(t, []string{"no high checkmarx findings", "few sast findings", "no unapproved licenses"}, verdict.ViolatedRules())
+		assert.Equal(t, `rule 'no high checkmarx findings' violated: 1 finding(s) of severity high, step checkmarxExecuteScan, at most 0 allowed
+rule 'few sast findings' violated: 4 finding(s) of category sast, at most 3 allowed
+rule 'no unapproved licenses' violated: 1 finding(s) of category license, at most 0 allowed`, verdict.Explanation())
+	})
+
+	t.Run("aggregated findings", func(t *testing.T) {
+		policy := QualityGatePolicy{Rules: []QualityGateRule{
+			{Name: "few medium fortify findings", StepName: "fortifyExecuteScan", Severities: []Severity{SeverityMedium}, MaxFindings: 5},
+		}}
+		aggregated := []ScanReport{{StepName: "fortifyExecuteScan", Findings: []Finding{
+			{RuleID: "1", Severity: SeverityMedium, Category: CategorySAST, Count: 4},
+			{RuleID: "2", Severity: SeverityMedium, Category: CategorySAST, Count: 3},
+		}}}
+
+		verdict := policy.Evaluate(aggregated)
+
+		assert.False(t, verdict.Passed)
+		assert.Equal(t, 7, verdict.Rules[0].Findings)
+	})
+}
@@ -20,6 +20,10 @@ type ScanReport struct {
 	ReportTime     time.Time       `json:"reportTime"`
 	DetailTable    ScanDetailTable `json:"detailTable"`
 	SuccessfulScan bool            `json:"successfulScan"`
+	Findings       []Finding       `json:"findings,omitempty"`
+	Delta          *FindingsDelta  `json:"delta,omitempty"`
+	// BuildDescriptor is the file findings without location are attributed to, e.g. in SARIF output
+	BuildDescriptor string `json:"buildDescriptor,omitempty"`
 }
 
 // ScanDetailTable defines a table containing scan result details
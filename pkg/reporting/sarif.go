@@ -0,0 +1,212 @@
+package reporting
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+)
+
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+	// sarifRepositoryRoot is the location of findings without location in case the report has no build descriptor
+	sarifRepositoryRoot = "."
+)
+
+// SARIF defines a log in the Static Analysis Results Interchange Format (SARIF) 2.1.0
+// Only the subset of the format which is required to represent a ScanReport is covered.
+type SARIF struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []SARIFRun `json:"runs"`
+}
+
+// SARIFRun defines the results of one tool
+type SARIFRun struct {
+	Tool    SARIFTool     `json:"tool"`
+	Results []SARIFResult `json:"results"`
+}
+
+// SARIFTool defines the tool which produced the results
+type SARIFTool struct {
+	Driver SARIFDriver `json:"driver"`
+}
+
+// SARIFDriver defines the component of the tool which contains the rules
+type SARIFDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri,omitempty"`
+	Rules          []SARIFRule `json:"rules"`
+}
+
+// SARIFRule defines the kind of a result
+type SARIFRule struct {
+	ID               string          `json:"id"`
+	ShortDescription SARIFMessage    `json:"shortDescription"`
+	FullDescription  *SARIFMessage   `json:"fullDescription,omitempty"`
+	HelpURI          string          `json:"helpUri,omitempty"`
+	Properties       SARIFProperties `json:"properties,omitempty"`
+}
+
+// SARIFMessage defines a plain text message
+type SARIFMessage struct {
+	Text string `json:"text"`
+}
+
+// SARIFResult defines one finding
+type SARIFResult struct {
+	RuleID              string             `json:"ruleId"`
+	RuleIndex           int                `json:"ruleIndex"`
+	Level               string             `json:"level"`
+	Message             SARIFMessage       `json:"message"`
+	Locations           []SARIFLocation    `json:"locations,omitempty"`
+	PartialFingerprints map[string]string  `json:"partialFingerprints,omitempty"`
+	Suppressions        []SARIFSuppression `json:"suppressions,omitempty"`
+	Properties          SARIFProperties    `json:"properties,omitempty"`
+}
+
+// SARIFLocation defines where a result has been detected
+type SARIFLocation struct {
+	PhysicalLocation SARIFPhysicalLocation `json:"physicalLocation"`
+}
+
+// SARIFPhysicalLocation defines the artifact and region of a location
+type SARIFPhysicalLocation struct {
+	ArtifactLocation SARIFArtifactLocation `json:"artifactLocation"`
+	Region           *SARIFRegion          `json:"region,omitempty"`
+}
+
+// SARIFArtifactLocation defines the URI of an artifact
+type SARIFArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// SARIFRegion defines a region within an artifact
+type SARIFRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+// SARIFSuppression defines that a result has been suppressed, e.g. as false positive
+type SARIFSuppression struct {
+	Kind string `json:"kind"`
+}
+
+// SARIFProperties contains additional information, e.g. the security severity evaluated by GitHub code scanning
+type SARIFProperties map[string]interface{}
+
+// ToSARIF returns the findings of the report in SARIF format
+func (s *ScanReport) ToSARIF() ([]byte, error) {
+	return ScanReportsToSARIF([]ScanReport{*s})
+}
+
+// ScanReportsToSARIF returns the findings of all reports as one SARIF log containing one run per report
+func ScanReportsToSARIF(reports []ScanReport) ([]byte, error) {
+	sarif := SARIF{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs:    []SARIFRun{},
+	}
+	for _, report := range reports {
+		sarif.Runs = append(sarif.Runs, report.sarifRun())
+	}
+	return json.MarshalIndent(sarif, "", "  ")
+}
+
+func (s *ScanReport) sarifRun() SARIFRun {
+	name := s.StepName
+	if len(name) == 0 {
+		name = s.Title
+	}
+	run := SARIFRun{
+		Tool:    SARIFTool{Driver: SARIFDriver{Name: name, Rules: []SARIFRule{}}},
+		Results: []SARIFResult{},
+	}
+
+	ruleIndex := map[string]int{}
+	for _, finding := range s.Findings {
+		index, ok := ruleIndex[finding.RuleID]
+		if !ok {
+			index = len(run.Tool.Driver.Rules)
+			ruleIndex[finding.RuleID] = index
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule(finding))
+		}
+
+		result := SARIFResult{
+			RuleID:              finding.RuleID,
+			RuleIndex:           index,
+			Level:               sarifLevel(finding.Severity),
+			Message:             SARIFMessage{Text: sarifMessage(finding)},
+			PartialFingerprints: map[string]string{"piperFindingHash/v1": finding.Fingerprint()},
+			Properties:          SARIFProperties{"severity": string(finding.Severity)},
+		}
+		// consumers like GitHub code scanning reject results without location
+		location := SARIFLocation{PhysicalLocation: SARIFPhysicalLocation{ArtifactLocation: SARIFArtifactLocation{URI: s.sarifFallbackLocation()}}}
+		if len(finding.Location) > 0 {
+			location.PhysicalLocation.ArtifactLocation.URI = finding.Location
+			if finding.Line > 0 {
+				location.PhysicalLocation.Region = &SARIFRegion{StartLine: finding.Line}
+			}
+		}
+		result.Locations = []SARIFLocation{location}
+		if finding.Suppressed {
+			result.Suppressions = []SARIFSuppression{{Kind: "external"}}
+		}
+		run.Results = append(run.Results, result)
+	}
+	return run
+}
+
+func (s *ScanReport) sarifFallbackLocation() string {
+	if len(s.BuildDescriptor) > 0 {
+		return filepath.ToSlash(filepath.Clean(s.BuildDescriptor))
+	}
+	return sarifRepositoryRoot
+}
+
+func sarifRule(finding Finding) SARIFRule {
+	rule := SARIFRule{
+		ID:               finding.RuleID,
+		ShortDescription: SARIFMessage{Text: finding.Title},
+		HelpURI:          finding.URL,
+		Properties:       SARIFProperties{"security-severity": fmt.Sprintf("%.1f", securitySeverity(finding))},
+	}
+	if len(finding.Description) > 0 {
+		rule.FullDescription = &SARIFMessage{Text: finding.Description}
+	}
+	return rule
+}
+
+func sarifMessage(finding Finding) string {
+	if len(finding.Component) > 0 {
+		return fmt.Sprintf("%v in %v", finding.Title, finding.Component)
+	}
+	return finding.Title
+}
+
+func sarifLevel(severity Severity) string {
+	switch severity {
+	case SeverityCritical, SeverityHigh:
+		return "error"
+	case SeverityMedium:
+		return "warning"
+	}
+	return "note"
+}
+
+// securitySeverity returns the score used by GitHub code scanning to classify security findings
+func securitySeverity(finding Finding) float64 {
+	if finding.Score > 0 {
+		return finding.Score
+	}
+	switch finding.Severity {
+	case SeverityCritical:
+		return 9.5
+	case SeverityHigh:
+		return 8.0
+	case SeverityMedium:
+		return 5.5
+	case SeverityLow:
+		return 2.0
+	}
+	return 0.0
+}
@@ -0,0 +1,80 @@
+package reporting
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToSARIF(t *testing.T) {
+	report := ScanReport{
+		StepName: "checkmarxExecuteScan",
+		Title:    "Checkmarx SAST Report",
+		Findings: []Finding{
+			{RuleID: "SQL_Injection", Title: "SQL Injection", Severity: SeverityHigh, Location: "src/db.go", Line: 42, URL: "https://cx/1"},
+			{RuleID: "SQL_Injection", Title: "SQL Injection", Severity: SeverityHigh, Location: "src/api.go", Line: 7, Suppressed: true},
+			{RuleID: "Log_Forging", Title: "Log Forging", Severity: SeverityLow},
+		},
+	}
+
+	res, err := report.ToSARIF()
+	require.NoError(t, err)
+
+	sarif := SARIF{}
+	require.NoError(t, json.Unmarshal(res, &sarif))
+	assert.Equal(t, "2.1.0", sarif.Version)
+	require.Len(t, sarif.Runs, 1)
+	run := sarif.Runs[0]
+	assert.Equal(t, "checkmarxExecuteScan", run.Tool.Driver.Name)
+	require.Len(t, run.Tool.Driver.Rules, 2)
+	assert.Equal(t, "SQL_Injection", run.Tool.Driver.Rules[0].ID)
+	assert.Equal(t, "8.0", run.Tool.Driver.Rules[0].Properties["security-severity"])
+	assert.Equal(t, "https://cx/1", run.Tool.Driver.Rules[0].HelpURI)
+
+	require.Len(t, run.Results, 3)
+	assert.Equal(t, "error", run.Results[0].Level)
+	assert.Equal(t, 0, run.Results[0].RuleIndex)
+	assert.Equal(t, "src/db.go", run.Results[0].Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	assert.Equal(t, 42, run.Results[0].Locations[0].PhysicalLocation.Region.StartLine)
+	assert.Equal(t, report.Findings[0].Fingerprint(), run.Results[0].PartialFingerprints["piperFindingHash/v1"])
+	assert.Empty(t, run.Results[0].Suppressions)
+	assert.Equal(t, []SARIFSuppression{{Kind: "external"}}, run.Results[1].Suppressions)
+	assert.Equal(t, "note", run.Results[2].Level)
+	assert.Equal(t, 1, run.Results[2].RuleIndex)
+	assert.Equal(t, ".", run.Results[2].Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	assert.Nil(t, run.Results[2].Locations[0].PhysicalLocation.Region)
+
+	t.Run("build descriptor as fallback location", func(t *testing.T) {
+		report.BuildDescriptor = "./pom.xml"
+
+		res, err := report.ToSARIF()
+		require.NoError(t, err)
+
+		sarif := SARIF{}
+		require.NoError(t, json.Unmarshal(res, &sarif))
+		assert.Equal(t, "src/db.go", sarif.Runs[0].Results[0].Locations[0].PhysicalLocation.ArtifactLocation.URI)
+		assert.Equal(t, "pom.xml", sarif.Runs[0].Results[2].Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	})
+}
+
+func TestScanReportsToSARIF(t *testing.T) {
+	reports := []ScanReport{
+		{Title: "WhiteSource Security Vulnerability Report", Findings: []Finding{{RuleID: "CVE-2021-1", Title: "CVE-2021-1", Severity: SeverityCritical, Score: 9.8, Component: "lib:1.0"}}},
+		{Title: "Fortify SAST Report"},
+	}
+
+	res, err := ScanReportsToSARIF(reports)
+	require.NoError(t, err)
+
+	sarif := SARIF{}
+	require.NoError(t, json.Unmarshal(res, &sarif))
+	require.Len(t, sarif.Runs, 2)
+	assert.Equal(t, "WhiteSource Security Vulnerability Report", sarif.Runs[0].Tool.Driver.Name)
+	assert.Equal(t, "9.8", sarif.Runs[0].Tool.Driver.Rules[0].Properties["security-severity"])
+	assert.Equal(t, "CVE-2021-1 in lib:1.0", sarif.Runs[0].Results[0].Message.Text)
+	assert.Equal(t, "Fortify SAST Report", sarif.Runs[1].Tool.Driver.Name)
+	assert.Empty(t, sarif.Runs[1].Results)
+	assert.Contains(t, string(res), `"results": []`)
+}
@@ -0,0 +1,221 @@
+package reporting
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/bmatcuk/doublestar"
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+)
+
+// DefaultTriageFile is the default location of the triage file within the repository
+const DefaultTriageFile = ".pipeline/triage.yml"
+
+// triageDateFormat is the format of the expiry date of a suppression
+const triageDateFormat = "2006-01-02"
+
+// Suppression documents the assessment of a finding which shall not fail a scan
+type Suppression struct {
+	// ID is a CVE, a CWE (e.g. CWE-79) or the rule id of the scan tool (e.g. a Checkmarx query or a Fortify category)
+	ID string `json:"id"`
+	// Component restricts the suppression to a library (name, coordinates or package URL without version) or a file (glob pattern)
+	Component     string `json:"component,omitempty"`
+	Justification string `json:"justification"`
+	// Expires is the date (YYYY-MM-DD) until which the suppression is valid
+	Expires string `json:"expires,omitempty"`
+
+	expiryDate time.Time
+}
+
+// Triage contains the suppressions of a triage file shared by all scan steps.
+// The methods can be called on a nil Triage which does not suppress any finding.
+type Triage struct {
+	Suppressions []Suppression `json:"suppressions"`
+
+	now func() time.Time
+}
+
+// TriageFileUtils bundles the file system functionality required for reading a triage file
+type TriageFileUtils interface {
+	FileExists(filename string) (bool, error)
+	FileRead(path string) ([]byte, error)
+}
+
+// ReadTriage reads the triage file in YAML format, e.g.
+//
+//	suppressions:
+//	  - id: CVE-2021-23337
+//	    component: pkg:npm/lodash
+//	    justification: template function is not used
+//	    expires: 2022-06-30
+//
+// If the file does not exist, a triage without suppressions is returned.
+func ReadTriage(path string, fileUtils TriageFileUtils) (*Triage, error) {
+	triage := &Triage{}
+	if len(path) == 0 {
+		return triage, nil
+	}
+	if exists, _ := fileUtils.FileExists(path); !exists {
+		log.Entry().Debugf("Triage file '%v' does not exist", path)
+		return triage, nil
+	}
+	content, err := fileUtils.FileRead(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read triage file '%v'", path)
+	}
+	if err := yaml.Unmarshal(content, triage); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse triage file '%v'", path)
+	}
+	for i, suppression := range triage.Suppressions {
+		if len(suppression.ID) == 0 {
+			return nil, fmt.Errorf("invalid triage file '%v': suppression #%v has no id", path, i+1)
+		}
+		if len(suppression.Justification) == 0 {
+			return nil, fmt.Errorf("invalid triage file '%v': suppression of %v has no justification", path, suppression.ID)
+		}
+		if len(suppression.Expires) > 0 {
+			expiryDate, err := time.Parse(triageDateFormat, suppression.Expires)
+			if err != nil {
+				return nil, fmt.Errorf("invalid triage file '%v': expiry date '%v' of %v is not in format YYYY-MM-DD", path, suppression.Expires, suppression.ID)
+			}
+			triage.Suppressions[i].expiryDate = expiryDate
+		}
+	}
+	log.Entry().Infof("Read %v suppressions from triage file '%v'", len(triage.Suppressions), path)
+	return triage, nil
+}
+
+// Find returns the suppression matching one of the ids and, if the suppression is restricted to a component, one of the components.
+// The second return value indicates whether the suppression has expired. If no suppression matches, nil is returned.
+func (t *Triage) Find(ids []string, components ...string) (*Suppression, bool) {
+	if t == nil {
+		return nil, false
+	}
+	var expired *Suppression
+	for i, suppression := range t.Suppressions {
+		if !containsID(ids, suppression.ID) || !suppression.matchesComponent(components) {
+			continue
+		}
+		if suppression.isExpired(t.currentTime()) {
+			expired = &t.Suppressions[i]
+			continue
+		}
+		return &t.Suppressions[i], false
+	}
+	return expired, expired != nil
+}
+
+// Suppresses returns whether the finding is suppressed by a suppression which has not expired
+func (t *Triage) Suppresses(finding Finding) bool {
+	suppression, expired := t.Find(finding.ids(), finding.Component, finding.Location)
+	return suppression != nil && !expired
+}
+
+// Apply marks the findings of the report which are suppressed by the triage.
+// Expired suppressions still matching a finding are flagged in the overview of the report.
+func (t *Triage) Apply(report *ScanReport) {
+	if t == nil {
+		return
+	}
+	flagged := map[*Suppression]bool{}
+	for i, finding := range report.Findings {
+		suppression, expired := t.Find(finding.ids(), finding.Component, finding.Location)
+		if suppression == nil {
+			continue
+		}
+		if !expired {
+			report.Findings[i].Suppressed = true
+			continue
+		}
+		if flagged[suppression] {
+			continue
+		}
+		flagged[suppression] = true
+		log.Entry().Warnf("Suppression of %v has expired on %v, please re-assess the finding", suppression.ID, suppression.Expires)
+		details := suppression.ID
+		if len(suppression.Component) > 0 {
+			details += " in " + suppression.Component
+		}
+		report.Overview = append(report.Overview, OverviewRow{
+			Description: "Expired suppression",
+			Details:     fmt.Sprintf("%v expired on %v (%v)", details, suppression.Expires, suppression.Justification),
+			Style:       Red,
+		})
+	}
+}
+
+func (t *Triage) currentTime() time.Time {
+	if t.now == nil {
+		return time.Now()
+	}
+	return t.now()
+}
+
+func (s *Suppression) isExpired(now time.Time) bool {
+	return !s.expiryDate.IsZero() && now.After(s.expiryDate.AddDate(0, 0, 1))
+}
+
+func (s *Suppression) matchesComponent(components []string) bool {
+	if len(s.Component) == 0 {
+		return true
+	}
+	for _, component := range components {
+		if len(component) > 0 && componentMatches(s.Component, component) {
+			return true
+		}
+	}
+	return false
+}
+
+// componentMatches checks whether the component is described by the pattern of a suppression.
+// The pattern matches a component of any version, e.g. pkg:npm/lodash matches pkg:npm/lodash@4.17.20 and
+// org.slf4j:slf4j-api matches org.slf4j:slf4j-api:1.7.32. Package URLs are also matched by the package name
+// (e.g. lodash or org.slf4j:slf4j-api). Files are matched with glob patterns like src/**/*.js.
+func componentMatches(pattern, component string) bool {
+	if strings.EqualFold(pattern, component) {
+		return true
+	}
+	for _, name := range purlNames(component) {
+		if strings.EqualFold(pattern, name) {
+			return true
+		}
+	}
+	for _, versionSeparator := range []string{"@", ":"} {
+		if strings.HasPrefix(strings.ToLower(component), strings.ToLower(pattern)+versionSeparator) {
+			return true
+		}
+	}
+	matched, _ := doublestar.Match(pattern, component)
+	return matched
+}
+
+// purlNames returns the package name of a package URL with and without namespace
+func purlNames(component string) []string {
+	if !strings.HasPrefix(component, "pkg:") {
+		return nil
+	}
+	path := strings.SplitN(strings.SplitN(component, "?", 2)[0], "@", 2)[0]
+	segments := strings.Split(path, "/")
+	if len(segments) < 2 {
+		return nil
+	}
+	name := segments[len(segments)-1]
+	names := []string{name}
+	if len(segments) > 2 {
+		namespace := strings.Join(segments[1:len(segments)-1], "/")
+		names = append(names, namespace+"/"+name, namespace+":"+name)
+	}
+	return names
+}
+
+func containsID(ids []string, id string) bool {
+	for _, i := range ids {
+		if strings.EqualFold(i, id) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,143 @@
+package reporting
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SAP/jenkins-library/pkg/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+const testTriageFile = `suppressions:
+  - id: CVE-2021-23337
+    component: pkg:npm/lodash
+    justification: template function is not used
+    expires: 2022-06-30
+  - id: CWE-79
+    component: src/test/**
+    justification: test code is not shipped
+  - id: CVE-2021-44228
+    justification: JNDI lookups are disabled
+    expires: 2021-12-31
+`
+
+func TestReadTriage(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		files := &mock.FilesMock{}
+		files.AddFile(DefaultTriageFile, []byte(testTriageFile))
+
+		triage, err := ReadTriage(DefaultTriageFile, files)
+		if assert.NoError(t, err) && assert.Len(t, triage.Suppressions, 3) {
+			assert.Equal(t, "pkg:npm/lodash", triage.Suppressions[0].Component)
+			assert.Equal(t, time.Date(2022, 6, 30, 0, 0, 0, 0, time.UTC), triage.Suppressions[0].expiryDate)
+			assert.True(t, triage.Suppressions[1].expiryDate.IsZero())
+		}
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		triage, err := ReadTriage(DefaultTriageFile, &mock.FilesMock{})
+		assert.NoError(t, err)
+		assert.Empty(t, triage.Suppressions)
+	})
+
+	tt := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{name: "invalid yaml", content: "suppressions: {", expected: "failed to parse triage file '.pipeline/triage.yml'"},
+		{name: "missing id", content: "suppressions:\n  - justification: false positive\n", expected: "invalid triage file '.pipeline/triage.yml': suppression #1 has no id"},
+		{name: "missing justification", content: "suppressions:\n  - id: CVE-2021-23337\n", expected: "invalid triage file '.pipeline/triage.yml': suppression of CVE-2021-23337 has no justification"},
+		{name: "invalid expiry date", content: "suppressions:\n  - id: CVE-2021-23337\n    justification: false positive\n    expires: 30.06.2022\n", expected: "invalid triage file '.pipeline/triage.yml': expiry date '30.06.2022' of CVE-2021-23337 is not in format YYYY-MM-DD"},
+	}
+	for _, test := range tt {
+		t.Run(test.name, func(t *testing.T) {
+			files := &mock.FilesMock{}
+			files.AddFile(DefaultTriageFile, []byte(test.content))
+
+			_, err := ReadTriage(DefaultTriageFile, files)
+			assert.Contains(t, err.Error(), test.expected)
+		})
+	}
+}
+
+func testTriage(t *testing.T, now time.Time) *Triage {
+	files := &mock.FilesMock{}
+	files.AddFile(DefaultTriageFile, []byte(testTriageFile))
+	triage, err := ReadTriage(DefaultTriageFile, files)
+	assert.NoError(t, err)
+	triage.now = func() time.Time { return now }
+	return triage
+}
+
+func TestTriageFind(t *testing.T) {
+	triage := testTriage(t, time.Date(2022, 6, 30, 23, 0, 0, 0, time.UTC))
+
+	t.Run("component with version", func(t *testing.T) {
+		suppression, expired := triage.Find([]string{"GHSA-35jh-r3h4-6jhm", "cve-2021-23337"}, "pkg:npm/lodash@4.17.20")
+		if assert.NotNil(t, suppression) {
+			assert.Equal(t, "template function is not used", suppression.Justification)
+		}
+		assert.False(t, expired)
+	})
+
+	t.Run("file pattern", func(t *testing.T) {
+		suppression, _ := triage.Find([]string{"Reflected_XSS", "CWE-79"}, "src/test/js/app.js")
+		assert.NotNil(t, suppression)
+		suppression, _ = triage.Find([]string{"Reflected_XSS", "CWE-79"}, "src/main/js/app.js")
+		assert.Nil(t, suppression)
+	})
+
+	t.Run("package name of package URL", func(t *testing.T) {
+		assert.True(t, componentMatches("lodash", "pkg:npm/lodash@4.17.20"))
+		assert.True(t, componentMatches("org.apache.logging.log4j:log4j-core", "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1?type=jar"))
+		assert.False(t, componentMatches("log4j-api", "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1"))
+	})
+
+	t.Run("other component", func(t *testing.T) {
+		suppression, _ := triage.Find([]string{"CVE-2021-23337"}, "pkg:npm/lodash.template@4.5.0")
+		assert.Nil(t, suppression)
+		suppression, _ = triage.Find([]string{"CVE-2021-23337"})
+		assert.Nil(t, suppression)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		suppression, expired := triage.Find([]string{"CVE-2021-44228"}, "org.apache.logging.log4j:log4j-core:2.14.1")
+		assert.NotNil(t, suppression)
+		assert.True(t, expired)
+	})
+
+	t.Run("nil triage", func(t *testing.T) {
+		var triage *Triage
+		suppression, expired := triage.Find([]string{"CVE-2021-44228"})
+		assert.Nil(t, suppression)
+		assert.False(t, expired)
+		assert.False(t, triage.Suppresses(Finding{RuleID: "CVE-2021-44228"}))
+	})
+}
+
+func TestTriageApply(t *testing.T) {
+	triage := testTriage(t, time.Date(2022, 1, 10, 0, 0, 0, 0, time.UTC))
+	report := ScanReport{Findings: []Finding{
+		{RuleID: "CVE-2021-23337", Component: "pkg:npm/lodash@4.17.20"},
+		{RuleID: "Reflected_XSS", Aliases: []string{"CWE-79"}, Location: "src/test/js/app.js", Line: 12},
+		{RuleID: "CVE-2021-44228", Component: "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1"},
+		{RuleID: "CVE-2021-44228", Component: "pkg:maven/org.apache.logging.log4j/log4j-api@2.14.1"},
+		{RuleID: "CVE-2022-22965", Component: "pkg:maven/org.springframework/spring-beans@5.3.17"},
+	}}
+
+	triage.Apply(&report)
+
+	assert.True(t, report.Findings[0].Suppressed)
+	assert.True(t, report.Findings[1].Suppressed)
+	assert.False(t, report.Findings[2].Suppressed)
+	assert.False(t, report.Findings[3].Suppressed)
+	assert.False(t, report.Findings[4].Suppressed)
+	assert.Equal(t, []OverviewRow{{
+		Description: "Expired suppression",
+		Details:     "CVE-2021-44228 expired on 2021-12-31 (JNDI lookups are disabled)",
+		Style:       Red,
+	}}, report.Overview)
+	assert.True(t, triage.Suppresses(report.Findings[0]))
+	assert.False(t, triage.Suppresses(report.Findings[2]))
+}
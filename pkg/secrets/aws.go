@@ -0,0 +1,72 @@
+package secrets
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/pkg/errors"
+)
+
+// secretsManagerAPI interface for mocking
+type secretsManagerAPI interface {
+	GetSecretValue(*secretsmanager.GetSecretValueInput) (*secretsmanager.GetSecretValueOutput, error)
+}
+
+// AWSSecretsManagerClient fetches secrets from AWS Secrets Manager
+type AWSSecretsManagerClient struct {
+	api secretsManagerAPI
+}
+
+// NewAWSSecretsManagerClient creates a client for the given region.
+// The credentials are taken from the default AWS credential chain, e.g. AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY or an instance role.
+func NewAWSSecretsManagerClient(region string) (*AWSSecretsManagerClient, error) {
+	awsSession, err := session.NewSession(&aws.Config{Region: aws.String(region)})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create AWS session")
+	}
+	return &AWSSecretsManagerClient{api: secretsmanager.New(awsSession)}, nil
+}
+
+// GetKvSecret reads the secret with the given name, the secret string is expected to contain a JSON object with the secret fields.
+// If the secret does not exist nil is returned.
+func (c *AWSSecretsManagerClient) GetKvSecret(name string) (map[string]string, error) {
+	log.Entry().Debugf("Reading secret '%s' from AWS Secrets Manager", name)
+	output, err := c.api.GetSecretValue(&secretsmanager.GetSecretValueInput{SecretId: aws.String(name)})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == secretsmanager.ErrCodeResourceNotFoundException {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "failed to read secret '%s' from AWS Secrets Manager", name)
+	}
+	if output.SecretString == nil {
+		return nil, fmt.Errorf("secret '%s' does not contain a secret string", name)
+	}
+	return parseSecretFields(name, *output.SecretString)
+}
+
+// parseSecretFields converts a JSON object into the fields of a secret
+func parseSecretFields(name, content string) (map[string]string, error) {
+	rawFields := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(content), &rawFields); err != nil {
+		return nil, errors.Wrapf(err, "secret '%s' does not contain a JSON object", name)
+	}
+	fields := make(map[string]string, len(rawFields))
+	for key, value := range rawFields {
+		if stringValue, ok := value.(string); ok {
+			fields[key] = stringValue
+			continue
+		}
+		// numbers, booleans and nested structures are kept in their JSON representation
+		encodedValue, err := json.Marshal(value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read field '%s' of secret '%s'", key, name)
+		}
+		fields[key] = string(encodedValue)
+	}
+	return fields, nil
+}
@@ -0,0 +1,63 @@
+package secrets
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/stretchr/testify/assert"
+)
+
+type secretsManagerMock struct {
+	secrets map[string]*string
+	err     error
+}
+
+func (m *secretsManagerMock) GetSecretValue(input *secretsmanager.GetSecretValueInput) (*secretsmanager.GetSecretValueOutput, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	secret, ok := m.secrets[*input.SecretId]
+	if !ok {
+		return nil, awserr.New(secretsmanager.ErrCodeResourceNotFoundException, "Secrets Manager can't find the specified secret.", nil)
+	}
+	return &secretsmanager.GetSecretValueOutput{Name: input.SecretId, SecretString: secret}, nil
+}
+
+func TestAWSSecretsManagerGetKvSecret(t *testing.T) {
+	client := AWSSecretsManagerClient{api: &secretsManagerMock{secrets: map[string]*string{
+		"team1/github":  aws.String(`{"token": "secret-token", "port": 443, "enabled": true}`),
+		"team1/invalid": aws.String("plain value"),
+		"team1/binary":  nil,
+	}}}
+
+	t.Run("success", func(t *testing.T) {
+		secret, err := client.GetKvSecret("team1/github")
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"token": "secret-token", "port": "443", "enabled": "true"}, secret)
+	})
+
+	t.Run("secret not found", func(t *testing.T) {
+		secret, err := client.GetKvSecret("team1/unknown")
+		assert.NoError(t, err)
+		assert.Nil(t, secret)
+	})
+
+	t.Run("error - no JSON object", func(t *testing.T) {
+		_, err := client.GetKvSecret("team1/invalid")
+		assert.Contains(t, fmt.Sprint(err), "secret 'team1/invalid' does not contain a JSON object")
+	})
+
+	t.Run("error - binary secret", func(t *testing.T) {
+		_, err := client.GetKvSecret("team1/binary")
+		assert.EqualError(t, err, "secret 'team1/binary' does not contain a secret string")
+	})
+
+	t.Run("error - access denied", func(t *testing.T) {
+		client := AWSSecretsManagerClient{api: &secretsManagerMock{err: awserr.New("AccessDeniedException", "not authorized", nil)}}
+		_, err := client.GetKvSecret("team1/github")
+		assert.Contains(t, fmt.Sprint(err), "failed to read secret 'team1/github' from AWS Secrets Manager: AccessDeniedException")
+	})
+}
@@ -0,0 +1,134 @@
+package secrets
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	piperhttp "github.com/SAP/jenkins-library/pkg/http"
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/pkg/errors"
+)
+
+const (
+	azureAuthorityURL        = "https://login.microsoftonline.com"
+	azureKeyVaultScope       = "https://vault.azure.net/.default"
+	azureKeyVaultAPIVersion  = "7.2"
+	azureKeyVaultSecretPath  = "/secrets/"
+	azureKeyVaultNameInvalid = "[^0-9a-zA-Z-]"
+)
+
+// AzureCredentials contain the service principal used to authenticate against Azure Active Directory
+type AzureCredentials struct {
+	TenantID     string
+	ClientID     string
+	ClientSecret string
+}
+
+// AzureKeyVaultClient fetches secrets from an Azure Key Vault
+type AzureKeyVaultClient struct {
+	vaultURL     string
+	authorityURL string
+	credentials  AzureCredentials
+	client       *piperhttp.Client
+	token        string
+}
+
+type azureTokenResponse struct {
+	AccessToken string `json:"access_token"`
+}
+
+type azureSecretResponse struct {
+	Value string `json:"value"`
+}
+
+// NewAzureKeyVaultClient creates a client for the key vault with the given URL, e.g. https://my-vault.vault.azure.net
+func NewAzureKeyVaultClient(vaultURL string, credentials AzureCredentials) *AzureKeyVaultClient {
+	log.RegisterSecret(credentials.ClientSecret)
+	return &AzureKeyVaultClient{
+		vaultURL:     strings.TrimSuffix(vaultURL, "/"),
+		authorityURL: azureAuthorityURL,
+		credentials:  credentials,
+		client:       &piperhttp.Client{},
+	}
+}
+
+// GetKvSecret reads the secret with the given name, the secret value is expected to contain a JSON object with the secret fields.
+// Since key vault secret names only consist of alphanumeric characters and dashes, all other characters (e.g. path separators) are replaced by dashes.
+// If the secret does not exist nil is returned.
+func (c *AzureKeyVaultClient) GetKvSecret(name string) (map[string]string, error) {
+	secretName := AzureKeyVaultSecretName(name)
+	log.Entry().Debugf("Reading secret '%s' from Azure Key Vault %s", secretName, c.vaultURL)
+	if len(c.token) == 0 {
+		token, err := c.fetchToken()
+		if err != nil {
+			return nil, err
+		}
+		c.token = token
+	}
+
+	secretURL := fmt.Sprintf("%s%s%s?api-version=%s", c.vaultURL, azureKeyVaultSecretPath, url.PathEscape(secretName), azureKeyVaultAPIVersion)
+	request, err := http.NewRequest(http.MethodGet, secretURL, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create request for secret '%s'", secretName)
+	}
+	request.Header.Set("Authorization", "Bearer "+c.token)
+	// missing secrets are expected during the lookup, thus the standard client is used which does not report them as error
+	response, err := c.client.StandardClient().Do(request)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read secret '%s' from Azure Key Vault", secretName)
+	}
+	defer response.Body.Close()
+	if response.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to read secret '%s' from Azure Key Vault: %v", secretName, response.Status)
+	}
+
+	secret := azureSecretResponse{}
+	if err := piperhttp.ParseHTTPResponseBodyJSON(response, &secret); err != nil {
+		return nil, errors.Wrapf(err, "failed to read secret '%s' from Azure Key Vault", secretName)
+	}
+	return parseSecretFields(secretName, secret.Value)
+}
+
+// fetchToken obtains an access token for the key vault via the client credentials flow
+func (c *AzureKeyVaultClient) fetchToken() (string, error) {
+	form := url.Values{}
+	form.Set("grant_type", "client_credentials")
+	form.Set("client_id", c.credentials.ClientID)
+	form.Set("client_secret", c.credentials.ClientSecret)
+	form.Set("scope", azureKeyVaultScope)
+
+	tokenURL := fmt.Sprintf("%s/%s/oauth2/v2.0/token", c.authorityURL, url.PathEscape(c.credentials.TenantID))
+	header := http.Header{}
+	header.Set("Content-Type", "application/x-www-form-urlencoded")
+	response, err := c.client.SendRequest(http.MethodPost, tokenURL, strings.NewReader(form.Encode()), header, nil)
+	if err != nil {
+		if response != nil && response.Body != nil {
+			body, _ := ioutil.ReadAll(response.Body)
+			log.Entry().Debugf("Azure Active Directory response: %s", string(body))
+		}
+		return "", errors.Wrap(err, "failed to authenticate against Azure Active Directory")
+	}
+	defer response.Body.Close()
+
+	token := azureTokenResponse{}
+	if err := piperhttp.ParseHTTPResponseBodyJSON(response, &token); err != nil {
+		return "", errors.Wrap(err, "failed to read Azure Active Directory token")
+	}
+	if len(token.AccessToken) == 0 {
+		return "", fmt.Errorf("Azure Active Directory did not return an access token")
+	}
+	log.RegisterSecret(token.AccessToken)
+	return token.AccessToken, nil
+}
+
+// AzureKeyVaultSecretName converts a secret path into a valid key vault secret name, e.g. team1/github becomes team1-github
+func AzureKeyVaultSecretName(name string) string {
+	return strings.Trim(regexp.MustCompile(azureKeyVaultNameInvalid).ReplaceAllString(name, "-"), "-")
+}
@@ -0,0 +1,76 @@
+package secrets
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAzureKeyVaultGetKvSecret(t *testing.T) {
+	tokenRequests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/tenant/oauth2/v2.0/token":
+			tokenRequests++
+			r.ParseForm()
+			if r.Form.Get("client_id") != "client" || r.Form.Get("client_secret") != "clientSecret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]string{"access_token": "accessToken", "token_type": "Bearer"})
+		case "/secrets/team1-github":
+			if r.Header.Get("Authorization") != "Bearer accessToken" || r.URL.Query().Get("api-version") != "7.2" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]string{"value": `{"token": "secret-token"}`})
+		case "/secrets/team1-broken":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	newClient := func(credentials AzureCredentials) *AzureKeyVaultClient {
+		client := NewAzureKeyVaultClient(server.URL+"/", credentials)
+		client.authorityURL = server.URL
+		return client
+	}
+
+	t.Run("success", func(t *testing.T) {
+		tokenRequests = 0
+		client := newClient(AzureCredentials{TenantID: "tenant", ClientID: "client", ClientSecret: "clientSecret"})
+
+		secret, err := client.GetKvSecret("team1/github")
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"token": "secret-token"}, secret)
+
+		secret, err = client.GetKvSecret("team1/unknown")
+		assert.NoError(t, err)
+		assert.Nil(t, secret)
+		assert.Equal(t, 1, tokenRequests)
+	})
+
+	t.Run("error - invalid credentials", func(t *testing.T) {
+		client := newClient(AzureCredentials{TenantID: "tenant", ClientID: "client", ClientSecret: "wrong"})
+		_, err := client.GetKvSecret("team1/github")
+		assert.Contains(t, fmt.Sprint(err), "failed to authenticate against Azure Active Directory")
+	})
+
+	t.Run("error - server error", func(t *testing.T) {
+		client := newClient(AzureCredentials{TenantID: "tenant", ClientID: "client", ClientSecret: "clientSecret"})
+		_, err := client.GetKvSecret("team1/broken")
+		assert.EqualError(t, err, "failed to read secret 'team1-broken' from Azure Key Vault: 500 Internal Server Error")
+	})
+}
+
+func TestAzureKeyVaultSecretName(t *testing.T) {
+	assert.Equal(t, "team1-github", AzureKeyVaultSecretName("team1/github"))
+	assert.Equal(t, "github", AzureKeyVaultSecretName("/github"))
+	assert.Equal(t, "kv-team1-my-secret", AzureKeyVaultSecretName("kv/team1/my_secret"))
+}
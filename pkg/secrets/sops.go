@@ -0,0 +1,73 @@
+package secrets
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/SAP/jenkins-library/pkg/command"
+	"github.com/SAP/jenkins-library/pkg/log"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+const sopsExecutable = "sops"
+
+// SopsFile contains the decrypted content of a file which has been encrypted by SOPS (https://github.com/mozilla/sops)
+type SopsFile struct {
+	content yaml.MapSlice
+}
+
+// NewSopsFile decrypts a YAML or JSON file encrypted by SOPS using the sops CLI.
+// The sops CLI reads the keys itself, e.g. the age keys from the environment variable SOPS_AGE_KEY or SOPS_AGE_KEY_FILE,
+// and verifies the message authentication code of the file.
+func NewSopsFile(filePath string, runner command.ExecRunner) (*SopsFile, error) {
+	decrypted := bytes.Buffer{}
+	stdout := runner.GetStdout()
+	runner.Stdout(&decrypted)
+	defer runner.Stdout(stdout)
+
+	if err := runner.RunExecutable(sopsExecutable, "--decrypt", "--output-type", "yaml", filePath); err != nil {
+		return nil, errors.Wrapf(err, "failed to decrypt sops file '%s', make sure that the sops CLI is available", filePath)
+	}
+
+	content := yaml.MapSlice{}
+	if err := yaml.Unmarshal(decrypted.Bytes(), &content); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse decrypted sops file '%s'", filePath)
+	}
+	return &SopsFile{content: content}, nil
+}
+
+// GetKvSecret returns the fields of the object at the given path, e.g. team1/github reads the object github within the object team1.
+// If the object does not exist nil is returned.
+func (s *SopsFile) GetKvSecret(secretPath string) (map[string]string, error) {
+	log.Entry().Debugf("Reading secret '%s' from sops file", secretPath)
+	current := s.content
+	for _, key := range strings.Split(strings.Trim(secretPath, "/"), "/") {
+		next, ok := lookupKey(current, key).(yaml.MapSlice)
+		if !ok {
+			return nil, nil
+		}
+		current = next
+	}
+
+	fields := make(map[string]string, len(current))
+	for _, item := range current {
+		switch value := item.Value.(type) {
+		case yaml.MapSlice, []interface{}, nil:
+			// nested objects are secrets on their own
+		default:
+			fields[fmt.Sprint(item.Key)] = fmt.Sprint(value)
+		}
+	}
+	return fields, nil
+}
+
+func lookupKey(content yaml.MapSlice, key string) interface{} {
+	for _, item := range content {
+		if fmt.Sprint(item.Key) == key {
+			return item.Value
+		}
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package secrets
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/SAP/jenkins-library/pkg/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const decryptedSopsFile = `team1:
+    github:
+        token: secret-token
+        port: 443
+        enabled: true
+    description_unencrypted: public
+`
+
+func TestSopsFile(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		runner := &mock.ExecMockRunner{StdoutReturn: map[string]string{"sops --decrypt": decryptedSopsFile}}
+
+		sopsFile, err := NewSopsFile("secrets.enc.yaml", runner)
+		require.NoError(t, err)
+		assert.Equal(t, []mock.ExecCall{{Exec: "sops", Params: []string{"--decrypt", "--output-type", "yaml", "secrets.enc.yaml"}}}, runner.Calls)
+
+		secret, err := sopsFile.GetKvSecret("team1/github")
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"token": "secret-token", "port": "443", "enabled": "true"}, secret)
+
+		secret, err = sopsFile.GetKvSecret("team1")
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"description_unencrypted": "public"}, secret)
+
+		secret, err = sopsFile.GetKvSecret("team1/unknown")
+		assert.NoError(t, err)
+		assert.Nil(t, secret)
+	})
+
+	t.Run("error - decryption failed", func(t *testing.T) {
+		runner := &mock.ExecMockRunner{ShouldFailOnCommand: map[string]error{"sops --decrypt": fmt.Errorf("MAC mismatch")}}
+
+		_, err := NewSopsFile("secrets.enc.yaml", runner)
+		assert.EqualError(t, err, "failed to decrypt sops file 'secrets.enc.yaml', make sure that the sops CLI is available: MAC mismatch")
+	})
+
+	t.Run("error - invalid output", func(t *testing.T) {
+		runner := &mock.ExecMockRunner{StdoutReturn: map[string]string{"sops --decrypt": "{invalid"}}
+
+		_, err := NewSopsFile("secrets.enc.yaml", runner)
+		assert.Contains(t, fmt.Sprint(err), "failed to parse decrypted sops file 'secrets.enc.yaml'")
+	})
+}
@@ -2,7 +2,9 @@ package sonar
 
 import (
 	"net/http"
+	"strconv"
 
+	"github.com/SAP/jenkins-library/pkg/log"
 	sonargo "github.com/magicsong/sonargo/sonar"
 	"github.com/pkg/errors"
 )
@@ -10,6 +12,11 @@ import (
 // EndpointIssuesSearch API endpoint for https://sonarcloud.io/web_api/api/issues/search
 const EndpointIssuesSearch = "issues/search"
 
+const (
+	issuesPageSize    = 500
+	issuesSearchLimit = 10000
+)
+
 // IssueService ...
 type IssueService struct {
 	Organization string
@@ -44,12 +51,10 @@ func (service *IssueService) SearchIssues(options *IssuesSearchOption) (*sonargo
 	return result, response, nil
 }
 
-func (service *IssueService) getIssueCount(severity issueSeverity) (int, error) {
+func (service *IssueService) searchOptions() *IssuesSearchOption {
 	options := &IssuesSearchOption{
 		ComponentKeys: service.Project,
-		Severities:    severity.ToString(),
 		Resolved:      "false",
-		Ps:            "1",
 	}
 	if len(service.Branch) > 0 {
 		options.Branch = service.Branch
@@ -60,6 +65,13 @@ func (service *IssueService) getIssueCount(severity issueSeverity) (int, error)
 	if len(service.PullRequest) > 0 {
 		options.PullRequest = service.PullRequest
 	}
+	return options
+}
+
+func (service *IssueService) getIssueCount(severity issueSeverity) (int, error) {
+	options := service.searchOptions()
+	options.Severities = severity.ToString()
+	options.Ps = "1"
 	result, _, err := service.SearchIssues(options)
 	if err != nil {
 		return -1, errors.Wrapf(err, "failed to fetch the numer of '%s' issues", severity)
@@ -92,6 +104,30 @@ func (service *IssueService) GetNumberOfInfoIssues() (int, error) {
 	return service.getIssueCount(info)
 }
 
+// GetIssues returns all unresolved issues of the project, the issues are fetched page by page.
+// The API does not return more than 10000 issues, remaining issues are skipped.
+func (service *IssueService) GetIssues() ([]*sonargo.Issue, error) {
+	issues := []*sonargo.Issue{}
+	for page := 1; ; page++ {
+		options := service.searchOptions()
+		options.P = strconv.Itoa(page)
+		options.Ps = strconv.Itoa(issuesPageSize)
+		result, _, err := service.SearchIssues(options)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to fetch page %d of the issues", page)
+		}
+		issues = append(issues, result.Issues...)
+		if len(result.Issues) == 0 || len(issues) >= result.Total {
+			break
+		}
+		if page*issuesPageSize >= issuesSearchLimit {
+			log.Entry().Warnf("Only the first %d of %d issues are exported", len(issues), result.Total)
+			break
+		}
+	}
+	return issues, nil
+}
+
 // NewIssuesService returns a new instance of a service for the issues API endpoint.
 func NewIssuesService(host, token, project, organization, branch, pullRequest string, client Sender) *IssueService {
 	return &IssueService{
@@ -70,6 +70,54 @@ func TestIssueService(t *testing.T) {
 	})
 }
 
+func TestGetIssues(t *testing.T) {
+	testURL := "https://example.org"
+	t.Run("success", func(t *testing.T) {
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+
+		sender := &piperhttp.Client{}
+		sender.SetOptions(piperhttp.ClientOptions{MaxRetries: -1, UseDefaultTransport: true})
+		// add response handler
+		pages := []string{}
+		httpmock.RegisterResponder(http.MethodGet, testURL+"/api/"+EndpointIssuesSearch+"", func(req *http.Request) (*http.Response, error) {
+			page := req.URL.Query().Get("p")
+			pages = append(pages, page)
+			assert.Equal(t, "500", req.URL.Query().Get("ps"))
+			assert.Equal(t, "42", req.URL.Query().Get("pullRequest"))
+			return httpmock.NewStringResponse(http.StatusOK, `{"total": 2, "issues": [{"key": "issue-`+page+`", "rule": "go:S3776"}]}`), nil
+		})
+		// create service instance
+		serviceUnderTest := NewIssuesService(testURL, mock.Anything, "piper-test", "", "", "42", sender)
+		// test
+		issues, err := serviceUnderTest.GetIssues()
+		// assert
+		assert.NoError(t, err)
+		if assert.Len(t, issues, 2) {
+			assert.Equal(t, "issue-1", issues[0].Key)
+			assert.Equal(t, "issue-2", issues[1].Key)
+		}
+		assert.Equal(t, []string{"1", "2"}, pages)
+	})
+	t.Run("error", func(t *testing.T) {
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+
+		sender := &piperhttp.Client{}
+		sender.SetOptions(piperhttp.ClientOptions{MaxRetries: -1, UseDefaultTransport: true})
+		// add response handler
+		httpmock.RegisterResponder(http.MethodGet, testURL+"/api/"+EndpointIssuesSearch+"", httpmock.NewStringResponder(http.StatusNotFound, responseIssueSearchError))
+		// create service instance
+		serviceUnderTest := NewIssuesService(testURL, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, sender)
+		// test
+		issues, err := serviceUnderTest.GetIssues()
+		// assert
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to fetch page 1 of the issues")
+		assert.Nil(t, issues)
+	})
+}
+
 const responseIssueSearchError = `{
   "errors": [
     {
@@ -0,0 +1,82 @@
+package sonar
+
+import (
+	"net/http"
+
+	sonargo "github.com/magicsong/sonargo/sonar"
+	"github.com/pkg/errors"
+)
+
+// EndpointQualityGatesProjectStatus API endpoint for https://sonarcloud.io/web_api/api/qualitygates/project_status
+const EndpointQualityGatesProjectStatus = "qualitygates/project_status"
+
+// quality gate states as returned by the API, NONE is returned if no quality gate is associated with the project
+const (
+	QualityGateStatusOK    = "OK"
+	QualityGateStatusWarn  = "WARN"
+	QualityGateStatusError = "ERROR"
+	QualityGateStatusNone  = "NONE"
+)
+
+// QualityGateService ...
+type QualityGateService struct {
+	Project     string
+	Branch      string
+	PullRequest string
+	apiClient   *Requester
+}
+
+// GetProjectStatus ...
+func (service *QualityGateService) GetProjectStatus(options *QualityGateProjectStatusOption) (*sonargo.QualitygatesProjectStatusObject, *http.Response, error) {
+	request, err := service.apiClient.create("GET", EndpointQualityGatesProjectStatus, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	// use custom HTTP client to send request
+	response, err := service.apiClient.send(request)
+	if err != nil {
+		return nil, nil, err
+	}
+	// reuse response verrification from sonargo
+	err = sonargo.CheckResponse(response)
+	if err != nil {
+		return nil, response, err
+	}
+	// decode JSON response
+	result := new(sonargo.QualitygatesProjectStatusObject)
+	err = service.apiClient.decode(response, result)
+	if err != nil {
+		return nil, response, err
+	}
+	return result, response, nil
+}
+
+// GetStatus returns the quality gate status of the analysed branch or pull request of the project.
+func (service *QualityGateService) GetStatus() (*sonargo.ProjectStatus, error) {
+	options := &QualityGateProjectStatusOption{
+		ProjectKey: service.Project,
+	}
+	if len(service.PullRequest) > 0 {
+		options.PullRequest = service.PullRequest
+	} else if len(service.Branch) > 0 {
+		options.Branch = service.Branch
+	}
+	result, _, err := service.GetProjectStatus(options)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to fetch the quality gate status of project '%s'", service.Project)
+	}
+	if result.ProjectStatus == nil {
+		return &sonargo.ProjectStatus{Status: QualityGateStatusNone}, nil
+	}
+	return result.ProjectStatus, nil
+}
+
+// NewQualityGateService returns a new instance of a service for the quality gates API endpoint.
+func NewQualityGateService(host, token, project, branch, pullRequest string, client Sender) *QualityGateService {
+	return &QualityGateService{
+		Project:     project,
+		Branch:      branch,
+		PullRequest: pullRequest,
+		apiClient:   NewAPIClient(host, token, client),
+	}
+}
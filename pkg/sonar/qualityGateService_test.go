@@ -0,0 +1,98 @@
+package sonar
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	piperhttp "github.com/SAP/jenkins-library/pkg/http"
+)
+
+func TestQualityGateService(t *testing.T) {
+	testURL := "https://example.org"
+	t.Run("success", func(t *testing.T) {
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+
+		sender := &piperhttp.Client{}
+		sender.SetOptions(piperhttp.ClientOptions{MaxRetries: -1, UseDefaultTransport: true})
+		// add response handler
+		httpmock.RegisterResponder(http.MethodGet, testURL+"/api/"+EndpointQualityGatesProjectStatus+"", func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "branch=feature&projectKey=piper-test", req.URL.RawQuery)
+			return httpmock.NewStringResponse(http.StatusOK, responseQualityGateError), nil
+		})
+		// create service instance
+		serviceUnderTest := NewQualityGateService(testURL, mock.Anything, "piper-test", "feature", "", sender)
+		// test
+		status, err := serviceUnderTest.GetStatus()
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, QualityGateStatusError, status.Status)
+		if assert.Len(t, status.Conditions, 2) {
+			assert.Equal(t, "new_coverage", status.Conditions[0].MetricKey)
+		}
+		assert.Equal(t, 1, httpmock.GetTotalCallCount(), "unexpected number of requests")
+	})
+	t.Run("pull request", func(t *testing.T) {
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+
+		sender := &piperhttp.Client{}
+		sender.SetOptions(piperhttp.ClientOptions{MaxRetries: -1, UseDefaultTransport: true})
+		// add response handler
+		httpmock.RegisterResponder(http.MethodGet, testURL+"/api/"+EndpointQualityGatesProjectStatus+"", func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "projectKey=piper-test&pullRequest=42", req.URL.RawQuery)
+			return httpmock.NewStringResponse(http.StatusOK, `{}`), nil
+		})
+		// create service instance
+		serviceUnderTest := NewQualityGateService(testURL, mock.Anything, "piper-test", "feature", "42", sender)
+		// test
+		status, err := serviceUnderTest.GetStatus()
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, QualityGateStatusNone, status.Status)
+	})
+	t.Run("error", func(t *testing.T) {
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+
+		sender := &piperhttp.Client{}
+		sender.SetOptions(piperhttp.ClientOptions{MaxRetries: -1, UseDefaultTransport: true})
+		// add response handler
+		httpmock.RegisterResponder(http.MethodGet, testURL+"/api/"+EndpointQualityGatesProjectStatus+"", httpmock.NewStringResponder(http.StatusNotFound, `{"errors":[{"msg":"Project 'piper-test' not found"}]}`))
+		// create service instance
+		serviceUnderTest := NewQualityGateService(testURL, mock.Anything, "piper-test", "", "", sender)
+		// test
+		status, err := serviceUnderTest.GetStatus()
+		// assert
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to fetch the quality gate status of project 'piper-test'")
+		assert.Nil(t, status)
+	})
+}
+
+const responseQualityGateError = `{
+  "projectStatus": {
+    "status": "ERROR",
+    "conditions": [
+      {
+        "status": "ERROR",
+        "metricKey": "new_coverage",
+        "comparator": "LT",
+        "errorThreshold": "80",
+        "actualValue": "42.5"
+      },
+      {
+        "status": "OK",
+        "metricKey": "new_duplicated_lines_density",
+        "comparator": "GT",
+        "errorThreshold": "3",
+        "actualValue": "0.0"
+      }
+    ],
+    "ignoredConditions": false
+  }
+}`
@@ -17,6 +17,8 @@ type ReportData struct {
 	BranchName     string `json:"branchName,omitempty"`
 	Organization   string `json:"organization,omitempty"`
 	NumberOfIssues Issues `json:"numberOfIssues"`
+	// QualityGateStatus is the status of the quality gate of the analysed branch or pull request, e.g. OK or ERROR
+	QualityGateStatus string `json:"qualityGateStatus,omitempty"`
 }
 
 // Issues ...
@@ -0,0 +1,119 @@
+package sonar
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	sonargo "github.com/magicsong/sonargo/sonar"
+
+	"github.com/SAP/jenkins-library/pkg/reporting"
+)
+
+// IssueToFinding converts a Sonar issue into a tool independent finding
+func IssueToFinding(issue *sonargo.Issue, data ReportData) reporting.Finding {
+	finding := reporting.Finding{
+		RuleID:      issue.Rule,
+		Title:       issue.Message,
+		Description: strings.ToLower(strings.ReplaceAll(issue.Type, "_", " ")),
+		Severity:    reporting.ParseSeverity(issue.Severity),
+		Category:    reporting.CategorySAST,
+		Line:        issue.Line,
+		URL:         issueURL(issue, data),
+	}
+	// the component of an issue is prefixed with the project key, e.g. my-project:src/main.go
+	if parts := strings.SplitN(issue.Component, ":", 2); len(parts) == 2 {
+		finding.Location = parts[1]
+	}
+	return finding
+}
+
+func issueURL(issue *sonargo.Issue, data ReportData) string {
+	query := url.Values{}
+	query.Set("id", data.ProjectKey)
+	query.Set("open", issue.Key)
+	if len(data.ChangeID) > 0 {
+		query.Set("pullRequest", data.ChangeID)
+	} else if len(data.BranchName) > 0 {
+		query.Set("branch", data.BranchName)
+	}
+	return fmt.Sprintf("%v/project/issues?%v", strings.TrimSuffix(data.ServerURL, "/"), query.Encode())
+}
+
+// CreateScanReport creates the report of a Sonar analysis containing the quality gate status and all unresolved issues
+func CreateScanReport(data ReportData, qualityGate *sonargo.ProjectStatus, issues []*sonargo.Issue) reporting.ScanReport {
+	scanReport := reporting.ScanReport{
+		StepName: "sonarExecuteScan",
+		Title:    "Sonar Report",
+		Subheaders: []reporting.Subheader{
+			{Description: "Project key", Details: data.ProjectKey},
+		},
+		SuccessfulScan: qualityGate == nil || qualityGate.Status != QualityGateStatusError,
+		ReportTime:     time.Now(),
+	}
+	if len(data.ChangeID) > 0 {
+		scanReport.AddSubHeader("Pull request", data.ChangeID)
+	} else if len(data.BranchName) > 0 {
+		scanReport.AddSubHeader("Branch", data.BranchName)
+	}
+
+	if qualityGate != nil {
+		var gateStyle reporting.ColumnStyle = reporting.Green
+		if qualityGate.Status == QualityGateStatusError {
+			gateStyle = reporting.Red
+		} else if qualityGate.Status != QualityGateStatusOK {
+			gateStyle = reporting.Yellow
+		}
+		scanReport.Overview = append(scanReport.Overview, reporting.OverviewRow{Description: "Quality gate", Details: qualityGate.Status, Style: gateStyle})
+		for _, condition := range qualityGate.Conditions {
+			if condition.Status == QualityGateStatusError {
+				scanReport.Overview = append(scanReport.Overview, reporting.OverviewRow{
+					Description: fmt.Sprintf("Failed condition %v", condition.MetricKey),
+					Details:     fmt.Sprintf("%v (%v %v)", condition.ActualValue, condition.Comparator, condition.ErrorThreshold),
+					Style:       reporting.Red,
+				})
+			}
+		}
+	}
+	scanReport.Overview = append(scanReport.Overview,
+		reporting.OverviewRow{Description: "Blocker issues", Details: fmt.Sprint(data.NumberOfIssues.Blocker)},
+		reporting.OverviewRow{Description: "Critical issues", Details: fmt.Sprint(data.NumberOfIssues.Critical)},
+		reporting.OverviewRow{Description: "Major issues", Details: fmt.Sprint(data.NumberOfIssues.Major)},
+		reporting.OverviewRow{Description: "Minor issues", Details: fmt.Sprint(data.NumberOfIssues.Minor)},
+		reporting.OverviewRow{Description: "Info issues", Details: fmt.Sprint(data.NumberOfIssues.Info)},
+	)
+
+	detailTable := reporting.ScanDetailTable{
+		NoRowsMessage: "No unresolved issues",
+		Headers: []string{
+			"Severity",
+			"Type",
+			"Rule",
+			"Location",
+			"Message",
+		},
+		WithCounter:   true,
+		CounterHeader: "Entry #",
+	}
+	for _, issue := range issues {
+		finding := IssueToFinding(issue, data)
+		location := finding.Location
+		if finding.Line > 0 {
+			location = fmt.Sprintf("%v:%v", location, finding.Line)
+		}
+
+		row := reporting.ScanRow{}
+		row.AddColumn(issue.Severity, 0)
+		row.AddColumn(issue.Type, 0)
+		row.AddColumn(issue.Rule, 0)
+		row.AddColumn(fmt.Sprintf(`<a href="%v">%v</a>`, finding.URL, location), 0)
+		row.AddColumn(issue.Message, 0)
+		detailTable.Rows = append(detailTable.Rows, row)
+
+		scanReport.AddFinding(finding)
+	}
+	scanReport.DetailTable = detailTable
+
+	return scanReport
+}
@@ -0,0 +1,83 @@
+package sonar
+
+import (
+	"testing"
+
+	sonargo "github.com/magicsong/sonargo/sonar"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/SAP/jenkins-library/pkg/reporting"
+)
+
+func TestIssueToFinding(t *testing.T) {
+	issue := &sonargo.Issue{
+		Key:       "AXVKXJLLrkwsFznOfAjG",
+		Rule:      "go:S3776",
+		Severity:  "CRITICAL",
+		Component: "SAP_jenkins-library:cmd/fortifyExecuteScan.go",
+		Line:      647,
+		Message:   "Refactor this method to reduce its Cognitive Complexity from 16 to the 15 allowed.",
+		Type:      "CODE_SMELL",
+	}
+
+	t.Run("branch", func(t *testing.T) {
+		finding := IssueToFinding(issue, ReportData{ServerURL: "https://sonarcloud.io/", ProjectKey: "SAP_jenkins-library", BranchName: "master"})
+
+		assert.Equal(t, reporting.Finding{
+			RuleID:      "go:S3776",
+			Title:       "Refactor this method to reduce its Cognitive Complexity from 16 to the 15 allowed.",
+			Description: "code smell",
+			Severity:    reporting.SeverityCritical,
+			Category:    reporting.CategorySAST,
+			Location:    "cmd/fortifyExecuteScan.go",
+			Line:        647,
+			URL:         "https://sonarcloud.io/project/issues?branch=master&id=SAP_jenkins-library&open=AXVKXJLLrkwsFznOfAjG",
+		}, finding)
+	})
+
+	t.Run("pull request", func(t *testing.T) {
+		finding := IssueToFinding(issue, ReportData{ServerURL: "https://sonarcloud.io", ProjectKey: "SAP_jenkins-library", BranchName: "master", ChangeID: "42"})
+
+		assert.Equal(t, "https://sonarcloud.io/project/issues?id=SAP_jenkins-library&open=AXVKXJLLrkwsFznOfAjG&pullRequest=42", finding.URL)
+	})
+}
+
+func TestCreateScanReport(t *testing.T) {
+	data := ReportData{
+		ServerURL:      "https://sonarcloud.io",
+		ProjectKey:     "SAP_jenkins-library",
+		ChangeID:       "42",
+		NumberOfIssues: Issues{Critical: 1},
+	}
+	issues := []*sonargo.Issue{{Key: "AXVKXJLLrkwsFznOfAjG", Rule: "go:S3776", Severity: "CRITICAL", Component: "SAP_jenkins-library:cmd/main.go", Line: 12, Type: "CODE_SMELL"}}
+
+	t.Run("failed quality gate", func(t *testing.T) {
+		qualityGate := &sonargo.ProjectStatus{
+			Status: QualityGateStatusError,
+			Conditions: []*sonargo.Condition{
+				{Status: QualityGateStatusError, MetricKey: "new_coverage", Comparator: "LT", ErrorThreshold: "80", ActualValue: "42.5"},
+				{Status: QualityGateStatusOK, MetricKey: "new_duplicated_lines_density", Comparator: "GT", ErrorThreshold: "3", ActualValue: "0.0"},
+			},
+		}
+
+		scanReport := CreateScanReport(data, qualityGate, issues)
+
+		assert.False(t, scanReport.SuccessfulScan)
+		assert.Equal(t, []reporting.Subheader{{Description: "Project key", Details: "SAP_jenkins-library"}, {Description: "Pull request", Details: "42"}}, scanReport.Subheaders)
+		assert.Equal(t, reporting.OverviewRow{Description: "Quality gate", Details: "ERROR", Style: reporting.Red}, scanReport.Overview[0])
+		assert.Equal(t, reporting.OverviewRow{Description: "Failed condition new_coverage", Details: "42.5 (LT 80)", Style: reporting.Red}, scanReport.Overview[1])
+		assert.Equal(t, reporting.OverviewRow{Description: "Blocker issues", Details: "0"}, scanReport.Overview[2])
+		assert.Len(t, scanReport.DetailTable.Rows, 1)
+		if assert.Len(t, scanReport.Findings, 1) {
+			assert.Equal(t, "cmd/main.go", scanReport.Findings[0].Location)
+		}
+	})
+
+	t.Run("passed quality gate", func(t *testing.T) {
+		scanReport := CreateScanReport(data, &sonargo.ProjectStatus{Status: QualityGateStatusOK}, nil)
+
+		assert.True(t, scanReport.SuccessfulScan)
+		assert.Equal(t, reporting.OverviewRow{Description: "Quality gate", Details: "OK", Style: reporting.Green}, scanReport.Overview[0])
+		assert.Empty(t, scanReport.Findings)
+	})
+}
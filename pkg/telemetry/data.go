@@ -84,6 +84,34 @@ func (d *Data) toMap() (result map[string]string) {
 	return
 }
 
+// Attributes returns the telemetry data with its labels as keys, e.g. to be used as attributes of a trace span.
+// Empty values are omitted.
+func (d Data) Attributes() map[string]string {
+	labeled := [][2]string{
+		{d.StepNameLabel, d.StepName},
+		{d.StageNameLabel, d.StageName},
+		{d.PipelineURLHashLabel, d.PipelineURLHash},
+		{d.BuildURLHashLabel, d.BuildURLHash},
+		{d.DurationLabel, d.Duration},
+		{d.ExitCodeLabel, d.ErrorCode},
+		{d.ErrorCategoryLabel, d.ErrorCategory},
+		{d.OrchestratorLabel, d.Orchestrator},
+		{d.PiperCommitHashLabel, d.PiperCommitHash},
+		{d.Custom1Label, d.Custom1},
+		{d.Custom2Label, d.Custom2},
+		{d.Custom3Label, d.Custom3},
+		{d.Custom4Label, d.Custom4},
+		{d.Custom5Label, d.Custom5},
+	}
+	attributes := map[string]string{}
+	for _, entry := range labeled {
+		if len(entry[0]) > 0 && len(entry[1]) > 0 {
+			attributes[entry[0]] = entry[1]
+		}
+	}
+	return attributes
+}
+
 // toPayloadString transfers the data object into a 'key=value&..' string
 func (d *Data) toPayloadString() string {
 	parameters := url.Values{}
@@ -65,3 +65,20 @@ func TestDataToPayload(t *testing.T) {
 		assert.NotContains(t, result, "t€štÄçtïøñ")
 	})
 }
+
+func TestDataAttributes(t *testing.T) {
+	testData := Data{
+		BaseData:     BaseData{StepName: "mavenBuild", StageName: "Build", Orchestrator: "Jenkins"},
+		BaseMetaData: baseMetaData,
+		CustomData:   CustomData{ErrorCode: "1", ErrorCategory: "build", Custom1Label: "buildTool", Custom1: "maven"},
+	}
+
+	assert.Equal(t, map[string]string{
+		"stepName":      "mavenBuild",
+		"stageName":     "Build",
+		"orchestrator":  "Jenkins",
+		"exitCode":      "1",
+		"errorCategory": "build",
+		"buildTool":     "maven",
+	}, testData.Attributes())
+}
@@ -0,0 +1,207 @@
+package terraform
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/SAP/jenkins-library/pkg/piperutils"
+	"github.com/pkg/errors"
+)
+
+// Plan contains the parts of the JSON representation of a plan (terraform show -json) which are relevant for policy checks
+type Plan struct {
+	FormatVersion   string           `json:"format_version"`
+	ResourceChanges []ResourceChange `json:"resource_changes"`
+}
+
+// ResourceChange describes the planned actions for a single resource
+type ResourceChange struct {
+	Address string `json:"address"`
+	Mode    string `json:"mode"`
+	Type    string `json:"type"`
+	Change  struct {
+		Actions []string               `json:"actions"`
+		After   map[string]interface{} `json:"after"`
+	} `json:"change"`
+}
+
+// PlanPolicy defines the rules a plan needs to comply with
+type PlanPolicy struct {
+	// ForbiddenDeletions contains patterns of resource types or addresses which must not be deleted or replaced, e.g. aws_db_instance or module.db.*
+	ForbiddenDeletions []string
+	// RequiredTags contains the tags every created or updated resource supporting tags needs to carry
+	RequiredTags []string
+}
+
+// ReadPlan parses the JSON representation of a plan
+func ReadPlan(planJSON []byte) (*Plan, error) {
+	var plan Plan
+	if err := json.Unmarshal(planJSON, &plan); err != nil {
+		return nil, errors.Wrap(err, "failed to parse JSON representation of plan")
+	}
+	return &plan, nil
+}
+
+// sensitiveValue replaces sensitive values in the JSON representation of a plan, like terraform does in its human readable output
+const sensitiveValue = "(sensitive value)"
+
+// RedactPlan replaces the values marked as sensitive in the JSON representation of a plan,
+// i.e. sensitive attributes of resources, sensitive outputs and the values of sensitive variables
+func RedactPlan(planJSON []byte) ([]byte, error) {
+	var plan map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(planJSON))
+	// keep numbers as they are instead of converting them to float64
+	decoder.UseNumber()
+	if err := decoder.Decode(&plan); err != nil {
+		return nil, errors.Wrap(err, "failed to parse JSON representation of plan")
+	}
+	for _, key := range []string{"resource_changes", "resource_drift"} {
+		for _, change := range asSlice(plan[key]) {
+			redactChange(asMap(asMap(change)["change"]))
+		}
+	}
+	for _, change := range asMap(plan["output_changes"]) {
+		redactChange(asMap(change))
+	}
+	redactValues(asMap(plan["planned_values"]))
+	redactValues(asMap(asMap(plan["prior_state"])["values"]))
+	variableDeclarations := asMap(asMap(asMap(plan["configuration"])["root_module"])["variables"])
+	for name, variable := range asMap(plan["variables"]) {
+		if sensitive, _ := asMap(variableDeclarations[name])["sensitive"].(bool); sensitive {
+			asMap(variable)["value"] = sensitiveValue
+		}
+	}
+	return json.Marshal(plan)
+}
+
+// redactChange redacts the values before and after a change according to before_sensitive and after_sensitive
+func redactChange(change map[string]interface{}) {
+	for _, key := range []string{"before", "after"} {
+		if value, ok := change[key]; ok {
+			change[key] = redact(value, change[key+"_sensitive"])
+		}
+	}
+}
+
+// redactValues redacts the sensitive outputs and resource attributes of the planned values or the prior state
+func redactValues(values map[string]interface{}) {
+	for _, output := range asMap(values["outputs"]) {
+		if sensitive, _ := asMap(output)["sensitive"].(bool); sensitive {
+			asMap(output)["value"] = sensitiveValue
+		}
+	}
+	redactModule(asMap(values["root_module"]))
+}
+
+func redactModule(module map[string]interface{}) {
+	for _, resource := range asSlice(module["resources"]) {
+		if values, ok := asMap(resource)["values"]; ok {
+			asMap(resource)["values"] = redact(values, asMap(resource)["sensitive_values"])
+		}
+	}
+	for _, childModule := range asSlice(module["child_modules"]) {
+		redactModule(asMap(childModule))
+	}
+}
+
+// redact replaces the parts of the value marked by the sensitivity structure, which is either true for a sensitive value
+// or mirrors the structure of the value for objects and lists with sensitive elements
+func redact(value, sensitive interface{}) interface{} {
+	switch marks := sensitive.(type) {
+	case bool:
+		if marks && value != nil {
+			return sensitiveValue
+		}
+	case map[string]interface{}:
+		if object, ok := value.(map[string]interface{}); ok {
+			for key, mark := range marks {
+				if element, ok := object[key]; ok {
+					object[key] = redact(element, mark)
+				}
+			}
+		}
+	case []interface{}:
+		if list, ok := value.([]interface{}); ok {
+			for i, mark := range marks {
+				if i < len(list) {
+					list[i] = redact(list[i], mark)
+				}
+			}
+		}
+	}
+	return value
+}
+
+func asMap(value interface{}) map[string]interface{} {
+	object, _ := value.(map[string]interface{})
+	return object
+}
+
+func asSlice(value interface{}) []interface{} {
+	list, _ := value.([]interface{})
+	return list
+}
+
+// CheckPolicy returns the violations of the policy by the plan, one entry per violating resource change
+func (p *Plan) CheckPolicy(policy PlanPolicy) []string {
+	violations := []string{}
+	for _, change := range p.ResourceChanges {
+		if change.Mode != "" && change.Mode != "managed" {
+			continue
+		}
+		if change.deletes() && matchesAny(policy.ForbiddenDeletions, change.Type, change.Address) {
+			violations = append(violations, fmt.Sprintf("%v must not be deleted (actions: %v)", change.Address, strings.Join(change.Change.Actions, ", ")))
+		}
+		if missing := change.missingTags(policy.RequiredTags); len(missing) > 0 {
+			violations = append(violations, fmt.Sprintf("%v is missing required tags: %v", change.Address, strings.Join(missing, ", ")))
+		}
+	}
+	return violations
+}
+
+// deletes returns true for deletions as well as replacements of resources
+func (c *ResourceChange) deletes() bool {
+	return piperutils.ContainsString(c.Change.Actions, "delete")
+}
+
+func (c *ResourceChange) missingTags(requiredTags []string) []string {
+	if len(requiredTags) == 0 || c.Change.After == nil {
+		return nil
+	}
+	if !piperutils.ContainsString(c.Change.Actions, "create") && !piperutils.ContainsString(c.Change.Actions, "update") {
+		return nil
+	}
+	// tags_all includes tags inherited from the provider configuration, e.g. default_tags of the AWS provider
+	tags, ok := c.Change.After["tags_all"]
+	if !ok || tags == nil {
+		tags, ok = c.Change.After["tags"]
+	}
+	if !ok {
+		// the resource does not support tags
+		return nil
+	}
+	tagMap, _ := tags.(map[string]interface{})
+	missing := []string{}
+	for _, tag := range requiredTags {
+		if _, ok := tagMap[tag]; !ok {
+			missing = append(missing, tag)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+func matchesAny(patterns []string, values ...string) bool {
+	for _, pattern := range patterns {
+		for _, value := range values {
+			if matched, _ := path.Match(pattern, value); matched {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,124 @@
+package terraform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testPlan = `{
+	"format_version": "0.2",
+	"resource_changes": [
+		{
+			"address": "aws_db_instance.main",
+			"mode": "managed",
+			"type": "aws_db_instance",
+			"change": {"actions": ["delete", "create"], "after": {"tags": {"owner": "team"}, "tags_all": {"owner": "team", "cost-center": "4711"}}}
+		},
+		{
+			"address": "module.network.aws_vpc.main",
+			"mode": "managed",
+			"type": "aws_vpc",
+			"change": {"actions": ["update"], "after": {"tags": {"owner": "team"}, "tags_all": null}}
+		},
+		{
+			"address": "aws_s3_bucket.logs",
+			"mode": "managed",
+			"type": "aws_s3_bucket",
+			"change": {"actions": ["delete"], "after": null}
+		},
+		{
+			"address": "random_id.suffix",
+			"mode": "managed",
+			"type": "random_id",
+			"change": {"actions": ["create"], "after": {"byte_length": 4}}
+		},
+		{
+			"address": "data.aws_caller_identity.current",
+			"mode": "data",
+			"type": "aws_caller_identity",
+			"change": {"actions": ["read"], "after": {"tags": null}}
+		}
+	]
+}`
+
+func TestCheckPolicy(t *testing.T) {
+	plan, err := ReadPlan([]byte(testPlan))
+	assert.NoError(t, err)
+
+	t.Run("forbidden deletions", func(t *testing.T) {
+		violations := plan.CheckPolicy(PlanPolicy{ForbiddenDeletions: []string{"aws_db_instance", "aws_s3_bucket.*"}})
+
+		assert.Equal(t, []string{
+			"aws_db_instance.main must not be deleted (actions: delete, create)",
+			"aws_s3_bucket.logs must not be deleted (actions: delete)",
+		}, violations)
+	})
+
+	t.Run("required tags", func(t *testing.T) {
+		violations := plan.CheckPolicy(PlanPolicy{RequiredTags: []string{"owner", "cost-center"}})
+
+		assert.Equal(t, []string{"module.network.aws_vpc.main is missing required tags: cost-center"}, violations)
+	})
+
+	t.Run("no violations", func(t *testing.T) {
+		violations := plan.CheckPolicy(PlanPolicy{ForbiddenDeletions: []string{"module.network.*"}, RequiredTags: []string{"owner"}})
+
+		assert.Empty(t, violations)
+	})
+}
+
+func TestReadPlan(t *testing.T) {
+	_, err := ReadPlan([]byte("no json"))
+
+	assert.Contains(t, err.Error(), "failed to parse JSON representation of plan")
+}
+
+func TestRedactPlan(t *testing.T) {
+	t.Run("sensitive values", func(t *testing.T) {
+		plan := `{
+			"format_version": "1.0",
+			"variables": {"db_password": {"value": "secret"}, "region": {"value": "eu-central-1"}},
+			"planned_values": {
+				"outputs": {"connection": {"sensitive": true, "value": "postgres://admin:secret@db"}, "id": {"sensitive": false, "value": "db-1"}},
+				"root_module": {"child_modules": [{"resources": [{"address": "module.db.aws_db_instance.main", "values": {"password": "secret", "port": 5432}, "sensitive_values": {"password": true}}]}]}
+			},
+			"resource_changes": [{
+				"address": "module.db.aws_db_instance.main",
+				"change": {
+					"actions": ["update"],
+					"before": {"password": "old", "users": [{"name": "admin", "key": "k1"}], "port": 5432},
+					"after": {"password": "secret", "users": [{"name": "admin", "key": "k2"}], "port": 12345678901234567890},
+					"before_sensitive": {"password": true, "users": [{"key": true}]},
+					"after_sensitive": {"password": true, "users": [{"key": true}]}
+				}
+			}],
+			"output_changes": {"connection": {"actions": ["update"], "before": null, "after": "postgres://admin:secret@db", "before_sensitive": true, "after_sensitive": true}},
+			"prior_state": {"values": {"outputs": {"connection": {"sensitive": true, "value": "postgres://admin:old@db"}}}},
+			"configuration": {"root_module": {"variables": {"db_password": {"sensitive": true}, "region": {}}}}
+		}`
+
+		redacted, err := RedactPlan([]byte(plan))
+
+		assert.NoError(t, err)
+		assert.NotContains(t, string(redacted), "secret")
+		assert.NotContains(t, string(redacted), "old")
+		assert.NotContains(t, string(redacted), "k1")
+		assert.NotContains(t, string(redacted), "k2")
+		assert.Contains(t, string(redacted), `"after":"(sensitive value)","after_sensitive":true,"before":null`)
+		assert.Contains(t, string(redacted), `"region":{"value":"eu-central-1"}`)
+		assert.Contains(t, string(redacted), `"id":{"sensitive":false,"value":"db-1"}`)
+		assert.Contains(t, string(redacted), `"port":12345678901234567890`)
+		assert.Contains(t, string(redacted), `"users":[{"key":"(sensitive value)","name":"admin"}]`)
+
+		parsed, err := ReadPlan(redacted)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"update"}, parsed.ResourceChanges[0].Change.Actions)
+	})
+
+	t.Run("invalid JSON", func(t *testing.T) {
+		_, err := RedactPlan([]byte("no json"))
+
+		assert.Contains(t, err.Error(), "failed to parse JSON representation of plan")
+	})
+}
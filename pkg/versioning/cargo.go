@@ -0,0 +1,125 @@
+package versioning
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"strings"
+
+	toml "github.com/pelletier/go-toml"
+	"github.com/pkg/errors"
+)
+
+var cargoVersionLine = regexp.MustCompile(`^(\s*version\s*=\s*)(["'])([^"']*)(["'])`)
+
+// Cargo defines a Rust crate using Cargo.toml for versioning
+type Cargo struct {
+	path      string
+	content   []byte
+	tree      *toml.Tree
+	readFile  func(string) ([]byte, error)
+	writeFile func(string, []byte, os.FileMode) error
+}
+
+func (c *Cargo) init() error {
+	if len(c.path) == 0 {
+		c.path = "Cargo.toml"
+	}
+	if c.readFile == nil {
+		c.readFile = ioutil.ReadFile
+	}
+	if c.writeFile == nil {
+		c.writeFile = ioutil.WriteFile
+	}
+	if c.tree != nil {
+		return nil
+	}
+	content, err := c.readFile(c.path)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read file '%v'", c.path)
+	}
+	tree, err := toml.LoadBytes(content)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read toml content of file '%v'", c.path)
+	}
+	c.content = content
+	c.tree = tree
+	return nil
+}
+
+// versionKey returns the key holding the version, a workspace root may define it for all its members
+func (c *Cargo) versionKey() (string, error) {
+	for _, key := range []string{"package.version", "workspace.package.version"} {
+		if _, ok := c.tree.Get(key).(string); ok {
+			return key, nil
+		}
+	}
+	return "", fmt.Errorf("no version available in file '%v'", c.path)
+}
+
+// VersioningScheme returns the relevant versioning scheme
+func (c *Cargo) VersioningScheme() string {
+	return "semver2"
+}
+
+// GetVersion returns the current version of the crate
+func (c *Cargo) GetVersion() (string, error) {
+	err := c.init()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to retrieve version")
+	}
+	key, err := c.versionKey()
+	if err != nil {
+		return "", err
+	}
+	return c.tree.Get(key).(string), nil
+}
+
+// SetVersion updates the version of the crate while keeping the remaining content of Cargo.toml untouched
+func (c *Cargo) SetVersion(version string) error {
+	err := c.init()
+	if err != nil {
+		return errors.Wrap(err, "failed to set version")
+	}
+	key, err := c.versionKey()
+	if err != nil {
+		return err
+	}
+
+	lines := strings.Split(string(c.content), "\n")
+	lineIndex := c.tree.GetPosition(key).Line - 1
+	if lineIndex < 0 || lineIndex >= len(lines) || !cargoVersionLine.MatchString(lines[lineIndex]) {
+		return fmt.Errorf("failed to locate version in file '%v'", c.path)
+	}
+	lines[lineIndex] = cargoVersionLine.ReplaceAllStringFunc(lines[lineIndex], func(match string) string {
+		parts := cargoVersionLine.FindStringSubmatch(match)
+		return parts[1] + parts[2] + version + parts[4]
+	})
+
+	content := []byte(strings.Join(lines, "\n"))
+	err = c.writeFile(c.path, content, 0700)
+	if err != nil {
+		return errors.Wrapf(err, "failed to write file '%v'", c.path)
+	}
+	c.content = content
+	c.tree.Set(key, version)
+	return nil
+}
+
+// GetCoordinates returns the coordinates
+func (c *Cargo) GetCoordinates() (Coordinates, error) {
+	result := Coordinates{}
+	err := c.init()
+	if err != nil {
+		return result, errors.Wrap(err, "failed to retrieve coordinates")
+	}
+	if name, ok := c.tree.Get("package.name").(string); ok {
+		result.ArtifactID = name
+	}
+	result.Version, err = c.GetVersion()
+	if err != nil {
+		return result, errors.Wrap(err, "failed to retrieve coordinates")
+	}
+	return result, nil
+}
@@ -0,0 +1,100 @@
+package versioning
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const cargoDescriptor = `[package]
+name = "my-crate"
+# keep in sync with the changelog
+version = "1.2.3"
+edition = "2021"
+
+[dependencies]
+serde = { version = "1.0", features = ["derive"] }
+`
+
+func TestCargoGetVersion(t *testing.T) {
+	t.Run("success case", func(t *testing.T) {
+		cargo := Cargo{
+			path:     "Cargo.toml",
+			readFile: func(filename string) ([]byte, error) { return []byte(cargoDescriptor), nil },
+		}
+		version, err := cargo.GetVersion()
+		assert.NoError(t, err)
+		assert.Equal(t, "1.2.3", version)
+	})
+
+	t.Run("success case - workspace", func(t *testing.T) {
+		cargo := Cargo{
+			path: "Cargo.toml",
+			readFile: func(filename string) ([]byte, error) {
+				return []byte("[workspace]\nmembers = [\"a\"]\n\n[workspace.package]\nversion = \"2.0.0\"\n"), nil
+			},
+		}
+		version, err := cargo.GetVersion()
+		assert.NoError(t, err)
+		assert.Equal(t, "2.0.0", version)
+	})
+
+	t.Run("error case - no version", func(t *testing.T) {
+		cargo := Cargo{
+			path:     "Cargo.toml",
+			readFile: func(filename string) ([]byte, error) { return []byte("[package]\nname = \"my-crate\"\n"), nil },
+		}
+		_, err := cargo.GetVersion()
+		assert.EqualError(t, err, "no version available in file 'Cargo.toml'")
+	})
+
+	t.Run("error case - read error", func(t *testing.T) {
+		cargo := Cargo{
+			path:     "Cargo.toml",
+			readFile: func(filename string) ([]byte, error) { return []byte{}, fmt.Errorf("read error") },
+		}
+		_, err := cargo.GetVersion()
+		assert.EqualError(t, err, "failed to retrieve version: failed to read file 'Cargo.toml': read error")
+	})
+}
+
+func TestCargoSetVersion(t *testing.T) {
+	t.Run("success case", func(t *testing.T) {
+		var content []byte
+		cargo := Cargo{
+			path:      "Cargo.toml",
+			readFile:  func(filename string) ([]byte, error) { return []byte(cargoDescriptor), nil },
+			writeFile: func(filename string, filecontent []byte, mode os.FileMode) error { content = filecontent; return nil },
+		}
+		err := cargo.SetVersion("1.2.4-20210101120000+abcdef")
+		assert.NoError(t, err)
+		assert.Contains(t, string(content), "# keep in sync with the changelog\nversion = \"1.2.4-20210101120000+abcdef\"\n")
+		assert.Contains(t, string(content), `serde = { version = "1.0", features = ["derive"] }`)
+
+		version, err := cargo.GetVersion()
+		assert.NoError(t, err)
+		assert.Equal(t, "1.2.4-20210101120000+abcdef", version)
+	})
+
+	t.Run("error case", func(t *testing.T) {
+		cargo := Cargo{
+			path:      "Cargo.toml",
+			readFile:  func(filename string) ([]byte, error) { return []byte(cargoDescriptor), nil },
+			writeFile: func(filename string, filecontent []byte, mode os.FileMode) error { return fmt.Errorf("write error") },
+		}
+		err := cargo.SetVersion("1.2.4")
+		assert.EqualError(t, err, "failed to write file 'Cargo.toml': write error")
+	})
+}
+
+func TestCargoGetCoordinates(t *testing.T) {
+	cargo := Cargo{
+		path:     "Cargo.toml",
+		readFile: func(filename string) ([]byte, error) { return []byte(cargoDescriptor), nil },
+	}
+	coordinates, err := cargo.GetCoordinates()
+	assert.NoError(t, err)
+	assert.Equal(t, Coordinates{ArtifactID: "my-crate", Version: "1.2.3"}, coordinates)
+}
@@ -0,0 +1,156 @@
+package versioning
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// VersionBump defines which part of a semantic version is increased
+type VersionBump int
+
+// Supported version bumps, ordered by significance
+const (
+	BumpNone VersionBump = iota
+	BumpPatch
+	BumpMinor
+	BumpMajor
+)
+
+func (b VersionBump) String() string {
+	switch b {
+	case BumpPatch:
+		return "patch"
+	case BumpMinor:
+		return "minor"
+	case BumpMajor:
+		return "major"
+	}
+	return "none"
+}
+
+var (
+	conventionalCommitHeader = regexp.MustCompile(`^([a-zA-Z]+)(?:\(([^)]*)\))?(!)?:\s*(.+)$`)
+	breakingChangeFooter     = regexp.MustCompile(`(?m)^BREAKING[ -]CHANGE:\s*(.+)$`)
+	semanticVersionCore      = regexp.MustCompile(`^v?(\d+)\.(\d+)\.(\d+)`)
+)
+
+// ConventionalCommit contains the information of a commit message following https://www.conventionalcommits.org
+type ConventionalCommit struct {
+	Hash           string
+	Type           string
+	Scope          string
+	Description    string
+	Breaking       bool
+	BreakingChange string
+}
+
+// ParseConventionalCommit parses a commit message, false is returned if the message does not follow the Conventional Commits specification
+func ParseConventionalCommit(hash, message string) (ConventionalCommit, bool) {
+	lines := strings.SplitN(strings.TrimSpace(message), "\n", 2)
+	match := conventionalCommitHeader.FindStringSubmatch(strings.TrimSpace(lines[0]))
+	if match == nil {
+		return ConventionalCommit{}, false
+	}
+	commit := ConventionalCommit{
+		Hash:        hash,
+		Type:        strings.ToLower(match[1]),
+		Scope:       match[2],
+		Description: strings.TrimSpace(match[4]),
+		Breaking:    match[3] == "!",
+	}
+	if len(lines) > 1 {
+		if footer := breakingChangeFooter.FindStringSubmatch(lines[1]); footer != nil {
+			commit.Breaking = true
+			commit.BreakingChange = strings.TrimSpace(footer[1])
+		}
+	}
+	if commit.Breaking && len(commit.BreakingChange) == 0 {
+		commit.BreakingChange = commit.Description
+	}
+	return commit, true
+}
+
+// DetermineVersionBump returns the most significant bump required by the commits:
+// breaking changes result in a major, features in a minor and fixes as well as performance improvements in a patch bump
+func DetermineVersionBump(commits []ConventionalCommit) VersionBump {
+	bump := BumpNone
+	for _, commit := range commits {
+		current := BumpNone
+		switch {
+		case commit.Breaking:
+			current = BumpMajor
+		case commit.Type == "feat":
+			current = BumpMinor
+		case commit.Type == "fix" || commit.Type == "perf":
+			current = BumpPatch
+		}
+		if current > bump {
+			bump = current
+		}
+	}
+	return bump
+}
+
+// BumpVersion increases the version according to the bump, pre-release and build information are dropped
+func BumpVersion(version string, bump VersionBump) (string, error) {
+	match := semanticVersionCore.FindStringSubmatch(version)
+	if match == nil {
+		return "", fmt.Errorf("version '%v' does not follow the pattern <major>.<minor>.<patch>", version)
+	}
+	major, _ := strconv.Atoi(match[1])
+	minor, _ := strconv.Atoi(match[2])
+	patch, _ := strconv.Atoi(match[3])
+
+	switch bump {
+	case BumpMajor:
+		major, minor, patch = major+1, 0, 0
+	case BumpMinor:
+		minor, patch = minor+1, 0
+	case BumpPatch:
+		patch++
+	default:
+		return version, nil
+	}
+	return fmt.Sprintf("%v.%v.%v", major, minor, patch), nil
+}
+
+// GenerateChangelog creates a markdown changelog section for the version listing breaking changes, features and bug fixes
+func GenerateChangelog(version string, date time.Time, commits []ConventionalCommit) string {
+	sections := []struct {
+		title   string
+		include func(ConventionalCommit) bool
+		text    func(ConventionalCommit) string
+	}{
+		{"⚠ BREAKING CHANGES", func(c ConventionalCommit) bool { return c.Breaking }, func(c ConventionalCommit) string { return c.BreakingChange }},
+		{"Features", func(c ConventionalCommit) bool { return c.Type == "feat" }, func(c ConventionalCommit) string { return c.Description }},
+		{"Bug Fixes", func(c ConventionalCommit) bool { return c.Type == "fix" }, func(c ConventionalCommit) string { return c.Description }},
+		{"Performance Improvements", func(c ConventionalCommit) bool { return c.Type == "perf" }, func(c ConventionalCommit) string { return c.Description }},
+	}
+
+	var changelog strings.Builder
+	changelog.WriteString(fmt.Sprintf("## %v (%v)\n", version, date.Format("2006-01-02")))
+	for _, section := range sections {
+		entries := []string{}
+		for _, commit := range commits {
+			if !section.include(commit) {
+				continue
+			}
+			entry := "* "
+			if len(commit.Scope) > 0 {
+				entry += fmt.Sprintf("**%v:** ", commit.Scope)
+			}
+			entry += section.text(commit)
+			if len(commit.Hash) > 0 {
+				entry += fmt.Sprintf(" (%.7v)", commit.Hash)
+			}
+			entries = append(entries, entry)
+		}
+		if len(entries) > 0 {
+			changelog.WriteString(fmt.Sprintf("\n### %v\n\n%v\n", section.title, strings.Join(entries, "\n")))
+		}
+	}
+	return changelog.String()
+}
@@ -0,0 +1,104 @@
+package versioning
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseConventionalCommit(t *testing.T) {
+	t.Run("feature with scope", func(t *testing.T) {
+		commit, ok := ParseConventionalCommit("abc", "feat(api): add endpoint\n\nsome details")
+		assert.True(t, ok)
+		assert.Equal(t, ConventionalCommit{Hash: "abc", Type: "feat", Scope: "api", Description: "add endpoint"}, commit)
+	})
+
+	t.Run("breaking change indicator", func(t *testing.T) {
+		commit, ok := ParseConventionalCommit("abc", "refactor!: drop old api")
+		assert.True(t, ok)
+		assert.True(t, commit.Breaking)
+		assert.Equal(t, "drop old api", commit.BreakingChange)
+	})
+
+	t.Run("breaking change footer", func(t *testing.T) {
+		commit, ok := ParseConventionalCommit("abc", "fix: rename parameter\n\nBREAKING CHANGE: parameter foo is now called bar")
+		assert.True(t, ok)
+		assert.True(t, commit.Breaking)
+		assert.Equal(t, "parameter foo is now called bar", commit.BreakingChange)
+	})
+
+	t.Run("no conventional commit", func(t *testing.T) {
+		_, ok := ParseConventionalCommit("abc", "Merge pull request #1 from foo/bar")
+		assert.False(t, ok)
+	})
+}
+
+func TestDetermineVersionBump(t *testing.T) {
+	tt := []struct {
+		commits  []ConventionalCommit
+		expected VersionBump
+	}{
+		{commits: []ConventionalCommit{}, expected: BumpNone},
+		{commits: []ConventionalCommit{{Type: "docs"}, {Type: "chore"}}, expected: BumpNone},
+		{commits: []ConventionalCommit{{Type: "docs"}, {Type: "fix"}}, expected: BumpPatch},
+		{commits: []ConventionalCommit{{Type: "perf"}}, expected: BumpPatch},
+		{commits: []ConventionalCommit{{Type: "fix"}, {Type: "feat"}, {Type: "fix"}}, expected: BumpMinor},
+		{commits: []ConventionalCommit{{Type: "feat"}, {Type: "chore", Breaking: true}}, expected: BumpMajor},
+	}
+	for _, test := range tt {
+		assert.Equal(t, test.expected, DetermineVersionBump(test.commits))
+	}
+}
+
+func TestBumpVersion(t *testing.T) {
+	tt := []struct {
+		version  string
+		bump     VersionBump
+		expected string
+	}{
+		{version: "1.2.3", bump: BumpNone, expected: "1.2.3"},
+		{version: "1.2.3", bump: BumpPatch, expected: "1.2.4"},
+		{version: "1.2.3", bump: BumpMinor, expected: "1.3.0"},
+		{version: "1.2.3", bump: BumpMajor, expected: "2.0.0"},
+		{version: "1.2.3-SNAPSHOT", bump: BumpPatch, expected: "1.2.4"},
+		{version: "v0.9.12", bump: BumpMinor, expected: "0.10.0"},
+	}
+	for _, test := range tt {
+		version, err := BumpVersion(test.version, test.bump)
+		assert.NoError(t, err)
+		assert.Equal(t, test.expected, version)
+	}
+
+	t.Run("error case", func(t *testing.T) {
+		_, err := BumpVersion("1.2", BumpPatch)
+		assert.EqualError(t, err, "version '1.2' does not follow the pattern <major>.<minor>.<patch>")
+	})
+}
+
+func TestGenerateChangelog(t *testing.T) {
+	commits := []ConventionalCommit{
+		{Hash: "1111111111", Type: "feat", Scope: "ui", Description: "add dark mode"},
+		{Hash: "2222222222", Type: "fix", Description: "handle empty input"},
+		{Hash: "3333333333", Type: "feat", Description: "remove v1 api", Breaking: true, BreakingChange: "v1 api has been removed"},
+		{Hash: "4444444444", Type: "docs", Description: "update readme"},
+	}
+
+	changelog := GenerateChangelog("2.0.0", time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC), commits)
+
+	assert.Equal(t, `## 2.0.0 (2021-06-01)
+
+### ⚠ BREAKING CHANGES
+
+* v1 api has been removed (3333333)
+
+### Features
+
+* **ui:** add dark mode (1111111)
+* remove v1 api (3333333)
+
+### Bug Fixes
+
+* handle empty input (2222222)
+`, changelog)
+}
@@ -0,0 +1,107 @@
+package versioning
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	dotnetVersionElement      = regexp.MustCompile(`<Version>\s*([^<]*?)\s*</Version>`)
+	dotnetPackageIDElement    = regexp.MustCompile(`<PackageId>\s*([^<]*?)\s*</PackageId>`)
+	dotnetAssemblyNameElement = regexp.MustCompile(`<AssemblyName>\s*([^<]*?)\s*</AssemblyName>`)
+)
+
+// DotNet defines a .NET project using the <Version> property of a *.csproj or Directory.Build.props file for versioning
+type DotNet struct {
+	path      string
+	content   string
+	readFile  func(string) ([]byte, error)
+	writeFile func(string, []byte, os.FileMode) error
+}
+
+func (d *DotNet) init() error {
+	if d.readFile == nil {
+		d.readFile = ioutil.ReadFile
+	}
+	if d.writeFile == nil {
+		d.writeFile = ioutil.WriteFile
+	}
+	if len(d.content) > 0 {
+		return nil
+	}
+	content, err := d.readFile(d.path)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read file '%v'", d.path)
+	}
+	d.content = string(content)
+	return nil
+}
+
+// VersioningScheme returns the relevant versioning scheme
+func (d *DotNet) VersioningScheme() string {
+	// NuGet supports SemVer 2.0.0 since NuGet 4.3.0
+	return "semver2"
+}
+
+// GetVersion returns the content of the first <Version> element
+func (d *DotNet) GetVersion() (string, error) {
+	err := d.init()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to retrieve version")
+	}
+	match := dotnetVersionElement.FindStringSubmatch(d.content)
+	if match == nil || len(match[1]) == 0 {
+		return "", fmt.Errorf("no <Version> element available in file '%v'", d.path)
+	}
+	return match[1], nil
+}
+
+// SetVersion updates the first <Version> element and keeps the remaining content untouched
+func (d *DotNet) SetVersion(version string) error {
+	err := d.init()
+	if err != nil {
+		return errors.Wrap(err, "failed to set version")
+	}
+
+	indices := dotnetVersionElement.FindStringSubmatchIndex(d.content)
+	if indices == nil {
+		return fmt.Errorf("no <Version> element available in file '%v'", d.path)
+	}
+	content := d.content[:indices[2]] + version + d.content[indices[3]:]
+
+	err = d.writeFile(d.path, []byte(content), 0700)
+	if err != nil {
+		return errors.Wrapf(err, "failed to write file '%v'", d.path)
+	}
+	d.content = content
+	return nil
+}
+
+// GetCoordinates returns the coordinates, the artifact id is derived from PackageId, AssemblyName or the project file name
+func (d *DotNet) GetCoordinates() (Coordinates, error) {
+	result := Coordinates{}
+	err := d.init()
+	if err != nil {
+		return result, errors.Wrap(err, "failed to retrieve coordinates")
+	}
+
+	if match := dotnetPackageIDElement.FindStringSubmatch(d.content); match != nil {
+		result.ArtifactID = match[1]
+	} else if match := dotnetAssemblyNameElement.FindStringSubmatch(d.content); match != nil {
+		result.ArtifactID = match[1]
+	} else if filepath.Ext(d.path) == ".csproj" {
+		result.ArtifactID = strings.TrimSuffix(filepath.Base(d.path), ".csproj")
+	}
+
+	result.Version, err = d.GetVersion()
+	if err != nil {
+		return result, errors.Wrap(err, "failed to retrieve coordinates")
+	}
+	return result, nil
+}
@@ -0,0 +1,99 @@
+package versioning
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const dotnetProject = `<Project Sdk="Microsoft.NET.Sdk">
+
+  <PropertyGroup>
+    <TargetFramework>net6.0</TargetFramework>
+    <Version>1.2.3</Version>
+  </PropertyGroup>
+
+</Project>
+`
+
+func TestDotNetGetVersion(t *testing.T) {
+	t.Run("success case", func(t *testing.T) {
+		dotnet := DotNet{
+			path:     "my.app.csproj",
+			readFile: func(filename string) ([]byte, error) { return []byte(dotnetProject), nil },
+		}
+		version, err := dotnet.GetVersion()
+		assert.NoError(t, err)
+		assert.Equal(t, "1.2.3", version)
+	})
+
+	t.Run("error case - no version", func(t *testing.T) {
+		dotnet := DotNet{
+			path: "my.app.csproj",
+			readFile: func(filename string) ([]byte, error) {
+				return []byte(`<Project Sdk="Microsoft.NET.Sdk"></Project>`), nil
+			},
+		}
+		_, err := dotnet.GetVersion()
+		assert.EqualError(t, err, "no <Version> element available in file 'my.app.csproj'")
+	})
+
+	t.Run("error case - read error", func(t *testing.T) {
+		dotnet := DotNet{
+			path:     "my.app.csproj",
+			readFile: func(filename string) ([]byte, error) { return []byte{}, fmt.Errorf("read error") },
+		}
+		_, err := dotnet.GetVersion()
+		assert.EqualError(t, err, "failed to retrieve version: failed to read file 'my.app.csproj': read error")
+	})
+}
+
+func TestDotNetSetVersion(t *testing.T) {
+	t.Run("success case", func(t *testing.T) {
+		var content []byte
+		dotnet := DotNet{
+			path:      "Directory.Build.props",
+			readFile:  func(filename string) ([]byte, error) { return []byte(dotnetProject), nil },
+			writeFile: func(filename string, filecontent []byte, mode os.FileMode) error { content = filecontent; return nil },
+		}
+		err := dotnet.SetVersion("1.2.4-20210101120000+abcdef")
+		assert.NoError(t, err)
+		assert.Contains(t, string(content), "    <TargetFramework>net6.0</TargetFramework>\n    <Version>1.2.4-20210101120000+abcdef</Version>\n")
+	})
+
+	t.Run("error case", func(t *testing.T) {
+		dotnet := DotNet{
+			path:      "my.app.csproj",
+			readFile:  func(filename string) ([]byte, error) { return []byte(dotnetProject), nil },
+			writeFile: func(filename string, filecontent []byte, mode os.FileMode) error { return fmt.Errorf("write error") },
+		}
+		err := dotnet.SetVersion("1.2.4")
+		assert.EqualError(t, err, "failed to write file 'my.app.csproj': write error")
+	})
+}
+
+func TestDotNetGetCoordinates(t *testing.T) {
+	t.Run("artifact id from file name", func(t *testing.T) {
+		dotnet := DotNet{
+			path:     "src/my.app.csproj",
+			readFile: func(filename string) ([]byte, error) { return []byte(dotnetProject), nil },
+		}
+		coordinates, err := dotnet.GetCoordinates()
+		assert.NoError(t, err)
+		assert.Equal(t, Coordinates{ArtifactID: "my.app", Version: "1.2.3"}, coordinates)
+	})
+
+	t.Run("artifact id from PackageId", func(t *testing.T) {
+		dotnet := DotNet{
+			path: "my.app.csproj",
+			readFile: func(filename string) ([]byte, error) {
+				return []byte("<Project><PropertyGroup><PackageId>My.Package</PackageId><Version>1.0.0</Version></PropertyGroup></Project>"), nil
+			},
+		}
+		coordinates, err := dotnet.GetCoordinates()
+		assert.NoError(t, err)
+		assert.Equal(t, Coordinates{ArtifactID: "My.Package", Version: "1.0.0"}, coordinates)
+	})
+}
@@ -0,0 +1,137 @@
+package versioning
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+)
+
+// HelmChart defines an artifact using a Chart.yaml for versioning
+type HelmChart struct {
+	path      string
+	content   []byte
+	chart     map[string]interface{}
+	readFile  func(string) ([]byte, error)
+	writeFile func(string, []byte, os.FileMode) error
+}
+
+func (h *HelmChart) init() error {
+	if len(h.path) == 0 {
+		h.path = "Chart.yaml"
+	}
+	if h.readFile == nil {
+		h.readFile = ioutil.ReadFile
+	}
+	if h.writeFile == nil {
+		h.writeFile = ioutil.WriteFile
+	}
+	if h.chart != nil {
+		return nil
+	}
+	content, err := h.readFile(h.path)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read file '%v'", h.path)
+	}
+	err = yaml.Unmarshal(content, &h.chart)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read yaml content of file '%v'", h.path)
+	}
+	h.content = content
+	return nil
+}
+
+func (h *HelmChart) readField(key string) string {
+	value, ok := h.chart[key]
+	if !ok || value == nil {
+		return ""
+	}
+	return strings.TrimSpace(fmt.Sprint(value))
+}
+
+// VersioningScheme returns the relevant versioning scheme
+func (h *HelmChart) VersioningScheme() string {
+	return "semver2"
+}
+
+// GetVersion returns the current version of the chart
+func (h *HelmChart) GetVersion() (string, error) {
+	err := h.init()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to retrieve version")
+	}
+	version := h.readField("version")
+	if len(version) == 0 {
+		return "", fmt.Errorf("no version available in file '%v'", h.path)
+	}
+	return version, nil
+}
+
+// GetAppVersion returns the version of the application contained in the chart
+func (h *HelmChart) GetAppVersion() (string, error) {
+	err := h.init()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to retrieve appVersion")
+	}
+	return h.readField("appVersion"), nil
+}
+
+// SetVersion updates version and - if available - appVersion of the chart.
+// Only the affected lines are changed in order to keep comments and formatting of Chart.yaml.
+func (h *HelmChart) SetVersion(version string) error {
+	err := h.init()
+	if err != nil {
+		return errors.Wrap(err, "failed to set version")
+	}
+
+	content, err := replaceTopLevelYAMLValue(h.content, "version", version)
+	if err != nil {
+		return errors.Wrapf(err, "failed to set version in file '%v'", h.path)
+	}
+	if _, ok := h.chart["appVersion"]; ok {
+		content, err = replaceTopLevelYAMLValue(content, "appVersion", version)
+		if err != nil {
+			return errors.Wrapf(err, "failed to set appVersion in file '%v'", h.path)
+		}
+		h.chart["appVersion"] = version
+	}
+
+	err = h.writeFile(h.path, content, 0700)
+	if err != nil {
+		return errors.Wrapf(err, "failed to write file '%v'", h.path)
+	}
+	h.content = content
+	h.chart["version"] = version
+	return nil
+}
+
+// GetCoordinates returns the coordinates
+func (h *HelmChart) GetCoordinates() (Coordinates, error) {
+	result := Coordinates{}
+	err := h.init()
+	if err != nil {
+		return result, errors.Wrap(err, "failed to retrieve coordinates")
+	}
+	result.ArtifactID = h.readField("name")
+	result.Version, err = h.GetVersion()
+	if err != nil {
+		return result, errors.Wrap(err, "failed to retrieve coordinates")
+	}
+	return result, nil
+}
+
+// replaceTopLevelYAMLValue replaces the scalar value of a top-level key and keeps quotes and trailing comments
+func replaceTopLevelYAMLValue(content []byte, key, value string) ([]byte, error) {
+	re := regexp.MustCompile(`(?m)^(` + regexp.QuoteMeta(key) + `:[ \t]*)(["']?)([^"'\s#]*)(["']?)`)
+	indices := re.FindSubmatchIndex(content)
+	if indices == nil {
+		return content, fmt.Errorf("key '%v' not found", key)
+	}
+	result := append([]byte{}, content[:indices[6]]...)
+	result = append(result, []byte(value)...)
+	return append(result, content[indices[7]:]...), nil
+}
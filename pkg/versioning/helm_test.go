@@ -0,0 +1,94 @@
+package versioning
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const helmChart = `apiVersion: v2
+name: my-chart
+description: A Helm chart for Kubernetes
+# chart version
+version: 1.2.3
+appVersion: "1.2.3" # version of the app
+`
+
+func TestHelmChartGetVersion(t *testing.T) {
+	t.Run("success case", func(t *testing.T) {
+		chart := HelmChart{
+			path:     "Chart.yaml",
+			readFile: func(filename string) ([]byte, error) { return []byte(helmChart), nil },
+		}
+		version, err := chart.GetVersion()
+		assert.NoError(t, err)
+		assert.Equal(t, "1.2.3", version)
+
+		appVersion, err := chart.GetAppVersion()
+		assert.NoError(t, err)
+		assert.Equal(t, "1.2.3", appVersion)
+	})
+
+	t.Run("error case", func(t *testing.T) {
+		chart := HelmChart{
+			path:     "Chart.yaml",
+			readFile: func(filename string) ([]byte, error) { return []byte{}, fmt.Errorf("read error") },
+		}
+		_, err := chart.GetVersion()
+		assert.EqualError(t, err, "failed to retrieve version: failed to read file 'Chart.yaml': read error")
+	})
+}
+
+func TestHelmChartSetVersion(t *testing.T) {
+	t.Run("success case", func(t *testing.T) {
+		var content []byte
+		chart := HelmChart{
+			path:      "Chart.yaml",
+			readFile:  func(filename string) ([]byte, error) { return []byte(helmChart), nil },
+			writeFile: func(filename string, filecontent []byte, mode os.FileMode) error { content = filecontent; return nil },
+		}
+		err := chart.SetVersion("1.2.4-20210101120000+abcdef")
+		assert.NoError(t, err)
+		assert.Equal(t, `apiVersion: v2
+name: my-chart
+description: A Helm chart for Kubernetes
+# chart version
+version: 1.2.4-20210101120000+abcdef
+appVersion: "1.2.4-20210101120000+abcdef" # version of the app
+`, string(content))
+	})
+
+	t.Run("success case - no appVersion", func(t *testing.T) {
+		var content []byte
+		chart := HelmChart{
+			path:      "Chart.yaml",
+			readFile:  func(filename string) ([]byte, error) { return []byte("name: my-chart\nversion: '0.1.0'\n"), nil },
+			writeFile: func(filename string, filecontent []byte, mode os.FileMode) error { content = filecontent; return nil },
+		}
+		err := chart.SetVersion("0.2.0")
+		assert.NoError(t, err)
+		assert.Equal(t, "name: my-chart\nversion: '0.2.0'\n", string(content))
+	})
+
+	t.Run("error case", func(t *testing.T) {
+		chart := HelmChart{
+			path:      "Chart.yaml",
+			readFile:  func(filename string) ([]byte, error) { return []byte(helmChart), nil },
+			writeFile: func(filename string, filecontent []byte, mode os.FileMode) error { return fmt.Errorf("write error") },
+		}
+		err := chart.SetVersion("1.2.4")
+		assert.EqualError(t, err, "failed to write file 'Chart.yaml': write error")
+	})
+}
+
+func TestHelmChartGetCoordinates(t *testing.T) {
+	chart := HelmChart{
+		path:     "Chart.yaml",
+		readFile: func(filename string) ([]byte, error) { return []byte(helmChart), nil },
+	}
+	coordinates, err := chart.GetCoordinates()
+	assert.NoError(t, err)
+	assert.Equal(t, Coordinates{ArtifactID: "my-chart", Version: "1.2.3"}, coordinates)
+}
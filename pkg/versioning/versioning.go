@@ -5,6 +5,8 @@ import (
 	"path/filepath"
 
 	"github.com/SAP/jenkins-library/pkg/piperutils"
+	"github.com/bmatcuk/doublestar"
+	"github.com/pkg/errors"
 
 	"github.com/SAP/jenkins-library/pkg/maven"
 )
@@ -52,6 +54,7 @@ func (m *mvnRunner) Evaluate(options *maven.EvaluateOptions, expression string,
 }
 
 var fileExists func(string) (bool, error)
+var fileGlob func(string) ([]string, error)
 
 // GetArtifact returns the build tool specific implementation for retrieving version, etc. of an artifact
 func GetArtifact(buildTool, buildDescriptorFilePath string, opts *Options, utils Utils) (Artifact, error) {
@@ -59,7 +62,15 @@ func GetArtifact(buildTool, buildDescriptorFilePath string, opts *Options, utils
 	if fileExists == nil {
 		fileExists = piperutils.FileExists
 	}
+	if fileGlob == nil {
+		fileGlob = doublestar.Glob
+	}
 	switch buildTool {
+	case "cargo":
+		if len(buildDescriptorFilePath) == 0 {
+			buildDescriptorFilePath = "Cargo.toml"
+		}
+		artifact = &Cargo{path: buildDescriptorFilePath}
 	case "custom":
 		var err error
 		artifact, err = customArtifact(buildDescriptorFilePath, opts.VersionField, opts.VersionSection, opts.VersioningScheme)
@@ -74,6 +85,15 @@ func GetArtifact(buildTool, buildDescriptorFilePath string, opts *Options, utils
 			versionSource:    opts.VersionSource,
 			versioningScheme: opts.VersioningScheme,
 		}
+	case "dotnet":
+		if len(buildDescriptorFilePath) == 0 {
+			var err error
+			buildDescriptorFilePath, err = searchDotNetDescriptor()
+			if err != nil {
+				return artifact, err
+			}
+		}
+		artifact = &DotNet{path: buildDescriptorFilePath}
 	case "dub":
 		if len(buildDescriptorFilePath) == 0 {
 			buildDescriptorFilePath = "dub.json"
@@ -106,6 +126,11 @@ func GetArtifact(buildTool, buildDescriptorFilePath string, opts *Options, utils
 		default:
 			artifact = &Versionfile{path: buildDescriptorFilePath}
 		}
+	case "helm":
+		if len(buildDescriptorFilePath) == 0 {
+			buildDescriptorFilePath = "Chart.yaml"
+		}
+		artifact = &HelmChart{path: buildDescriptorFilePath}
 	case "maven":
 		if len(buildDescriptorFilePath) == 0 {
 			buildDescriptorFilePath = "pom.xml"
@@ -183,6 +208,25 @@ func searchDescriptor(supported []string, existsFunc func(string) (bool, error))
 	return descriptor, nil
 }
 
+// searchDotNetDescriptor prefers a central Directory.Build.props over a single project file
+func searchDotNetDescriptor() (string, error) {
+	if exists, _ := fileExists("Directory.Build.props"); exists {
+		return "Directory.Build.props", nil
+	}
+	projects, err := fileGlob("*.csproj")
+	if err != nil {
+		return "", errors.Wrap(err, "failed to search for project files")
+	}
+	switch len(projects) {
+	case 0:
+		return "", fmt.Errorf("no build descriptor available, supported: [Directory.Build.props *.csproj]")
+	case 1:
+		return projects[0], nil
+	default:
+		return "", fmt.Errorf("multiple project files found %v, please provide the one to use via filePath", projects)
+	}
+}
+
 func customArtifact(buildDescriptorFilePath, field, section, scheme string) (Artifact, error) {
 	switch filepath.Ext(buildDescriptorFilePath) {
 	case ".cfg", ".ini":
@@ -7,6 +7,17 @@ import (
 )
 
 func TestGetArtifact(t *testing.T) {
+	t.Run("cargo", func(t *testing.T) {
+		cargo, err := GetArtifact("cargo", "", &Options{}, nil)
+
+		assert.NoError(t, err)
+
+		theType, ok := cargo.(*Cargo)
+		assert.True(t, ok)
+		assert.Equal(t, "Cargo.toml", theType.path)
+		assert.Equal(t, "semver2", cargo.VersioningScheme())
+	})
+
 	t.Run("custom", func(t *testing.T) {
 		custom, err := GetArtifact("custom", "test.ini", &Options{VersionField: "theversion", VersionSection: "test"}, nil)
 
@@ -33,6 +44,45 @@ func TestGetArtifact(t *testing.T) {
 		assert.Equal(t, "docker", docker.VersioningScheme())
 	})
 
+	t.Run("dotnet - Directory.Build.props", func(t *testing.T) {
+		fileExists = func(string) (bool, error) { return true, nil }
+		dotnet, err := GetArtifact("dotnet", "", &Options{}, nil)
+
+		assert.NoError(t, err)
+
+		theType, ok := dotnet.(*DotNet)
+		assert.True(t, ok)
+		assert.Equal(t, "Directory.Build.props", theType.path)
+		assert.Equal(t, "semver2", dotnet.VersioningScheme())
+	})
+
+	t.Run("dotnet - project file", func(t *testing.T) {
+		fileExists = func(string) (bool, error) { return false, nil }
+		fileGlob = func(string) ([]string, error) { return []string{"my.app.csproj"}, nil }
+		defer func() { fileGlob = nil }()
+		dotnet, err := GetArtifact("dotnet", "", &Options{}, nil)
+
+		assert.NoError(t, err)
+
+		theType, ok := dotnet.(*DotNet)
+		assert.True(t, ok)
+		assert.Equal(t, "my.app.csproj", theType.path)
+	})
+
+	t.Run("dotnet - error", func(t *testing.T) {
+		fileExists = func(string) (bool, error) { return false, nil }
+		fileGlob = func(string) ([]string, error) { return []string{"a.csproj", "b.csproj"}, nil }
+		defer func() { fileGlob = nil }()
+		_, err := GetArtifact("dotnet", "", &Options{}, nil)
+
+		assert.EqualError(t, err, "multiple project files found [a.csproj b.csproj], please provide the one to use via filePath")
+
+		fileGlob = func(string) ([]string, error) { return []string{}, nil }
+		_, err = GetArtifact("dotnet", "", &Options{}, nil)
+
+		assert.EqualError(t, err, "no build descriptor available, supported: [Directory.Build.props *.csproj]")
+	})
+
 	t.Run("dub", func(t *testing.T) {
 		dub, err := GetArtifact("dub", "", &Options{VersionField: "theversion"}, nil)
 
@@ -76,6 +126,17 @@ func TestGetArtifact(t *testing.T) {
 		assert.Equal(t, "semver2", gradle.VersioningScheme())
 	})
 
+	t.Run("helm", func(t *testing.T) {
+		helm, err := GetArtifact("helm", "", &Options{}, nil)
+
+		assert.NoError(t, err)
+
+		theType, ok := helm.(*HelmChart)
+		assert.True(t, ok)
+		assert.Equal(t, "Chart.yaml", theType.path)
+		assert.Equal(t, "semver2", helm.VersioningScheme())
+	})
+
 	t.Run("maven", func(t *testing.T) {
 		opts := Options{
 			ProjectSettingsFile: "projectsettings.xml",
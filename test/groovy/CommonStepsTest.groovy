@@ -148,6 +148,7 @@ public class CommonStepsTest extends BasePiperTest{
         'githubCheckBranchProtection', //implementing new golang pattern without fields
         'githubCommentIssue', //implementing new golang pattern without fields
         'githubSetCommitStatus', //implementing new golang pattern without fields
+        'githubSetCheckRun', //implementing new golang pattern without fields
         'kubernetesDeploy', //implementing new golang pattern without fields
         'piperExecuteBin', //implementing new golang pattern without fields
         'protecodeExecuteScan', //implementing new golang pattern without fields
@@ -175,6 +176,7 @@ public class CommonStepsTest extends BasePiperTest{
         'kanikoExecute', //implementing new golang pattern without fields
         'karmaExecuteTests', //implementing new golang pattern without fields
         'gitopsUpdateDeployment', //implementing new golang pattern without fields
+        'gradleBuild', //implementing new golang pattern without fields
         'vaultRotateSecretId', //implementing new golang pattern without fields
         'deployIntegrationArtifact', //implementing new golang pattern without fields
         'newmanExecute', //implementing new golang pattern without fields
@@ -203,6 +205,7 @@ public class CommonStepsTest extends BasePiperTest{
         'isChangeInDevelopment', //implementing new golang pattern without fields
         'apiProxyDownload', //implementing new golang pattern without fields
         'apiKeyValueMapDownload', //implementing new golang pattern without fields
+        'osvExecuteScan', //implementing new golang pattern without fields
     ]
 
     @Test
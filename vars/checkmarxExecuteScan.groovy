@@ -6,6 +6,10 @@ import groovy.transform.Field
 //Metadata maintained in file project://resources/metadata/checkmarxExecuteScan.yaml
 
 void call(Map parameters = [:]) {
-    List credentials = [[type: 'usernamePassword', id: 'checkmarxCredentialsId', env: ['PIPER_username', 'PIPER_password']]]
+    List credentials = [
+        [type: 'usernamePassword', id: 'checkmarxCredentialsId', env: ['PIPER_username', 'PIPER_password']],
+        [type: 'token', id: 'checkmarxOneApiKeyCredentialsId', env: ['PIPER_apiKey']],
+        [type: 'token', id: 'githubTokenCredentialsId', env: ['PIPER_githubToken']],
+    ]
     piperExecuteBin(parameters, STEP_NAME, METADATA_FILE, credentials, true)
 }
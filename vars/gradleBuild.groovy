@@ -0,0 +1,12 @@
+import groovy.transform.Field
+
+@Field String STEP_NAME = getClass().getName()
+@Field String METADATA_FILE = 'metadata/gradleBuild.yaml'
+
+void call(Map parameters = [:]) {
+    List credentials = [
+        [type: 'usernamePassword', id: 'publishCredentialsId', env: ['PIPER_repositoryUsername', 'PIPER_repositoryPassword']],
+        [type: 'usernamePassword', id: 'repositoryMirrorCredentialsId', env: ['PIPER_repositoryMirrorUsername', 'PIPER_repositoryMirrorPassword']],
+    ]
+    piperExecuteBin(parameters, STEP_NAME, METADATA_FILE, credentials)
+}
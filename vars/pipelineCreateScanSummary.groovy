@@ -4,6 +4,8 @@ import groovy.transform.Field
 @Field String METADATA_FILE = 'metadata/pipelineCreateScanSummary.yaml'
 
 void call(Map parameters = [:]) {
-    List credentials = []
+    List credentials = [
+        [type: 'token', id: 'githubTokenCredentialsId', env: ['PIPER_token']]
+    ]
     piperExecuteBin(parameters, STEP_NAME, METADATA_FILE, credentials)
 }
@@ -65,8 +65,12 @@ void call(Map parameters = [:]) {
                             withEnv(environment){
                                 influxWrapper(script){
                                     piperExecuteBin.credentialWrapper(config, credentialInfo){
-                                        sh "${piperGoPath} ${STEP_NAME}${customDefaultConfig}${customConfigArg}"
-                                        archiveArtifacts artifacts: "sonarscan.json", allowEmptyArchive: true
+                                        try {
+                                            sh "${piperGoPath} ${STEP_NAME}${customDefaultConfig}${customConfigArg}"
+                                        } finally {
+                                            // also archive the reports in case of a failed quality gate
+                                            archiveArtifacts artifacts: "sonarscan.json,sonarscan.html,sonarscan.sarif", allowEmptyArchive: true
+                                        }
                                     }
                                     jenkinsUtils.handleStepResults(STEP_NAME, false, false)
                                     readPipelineEnv(script: script, piperGoPath: piperGoPath)